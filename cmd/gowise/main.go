@@ -0,0 +1,105 @@
+// Command gowise is the GoWise command-line tool.
+//
+// Usage:
+//
+//	gowise doctor [packages]
+//	gowise usage [packages]
+//
+// The doctor command inspects Go source, tests included, for misuses of
+// GoWise, such as an Assert shared across subtests, an Eventually whose
+// interval exceeds its timeout, or a body assertion on a response whose body
+// was already read, and prints a suggested fix for each. Packages are
+// directories, and a directory ending in "/..." includes its
+// subdirectories; the default is "./...". It exits with status 1 if it finds
+// any problem, so it can gate CI.
+//
+// The usage command counts the GoWise assertions each package uses, and
+// lists hand-written checks in tests that an assertion could replace, such
+// as sleep-and-check loops that could use Eventually.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"gowise/pkg/doctor"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// usage describes the command line.
+const usage = `usage: gowise <command> [arguments]
+
+commands:
+  doctor [packages]  report misuses of GoWise and how to fix them
+  usage [packages]   count the assertions each package uses, with hints
+`
+
+// run runs the command given by args and returns the exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "doctor":
+		return runDoctor(args[1:], stdout, stderr)
+	case "usage":
+		return runUsage(args[1:], stdout, stderr)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "gowise: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+}
+
+// runDoctor runs the doctor command over the packages in args.
+func runDoctor(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+
+	found := 0
+	for _, dir := range args {
+		diagnostics, err := doctor.Dir(dir, doctor.Analyzers...)
+		if err != nil {
+			fmt.Fprintf(stderr, "gowise doctor: %v\n", err)
+			return 2
+		}
+		for _, d := range diagnostics {
+			fmt.Fprintln(stdout, d)
+		}
+		found += len(diagnostics)
+	}
+
+	if found > 0 {
+		fmt.Fprintf(stderr, "gowise doctor: found %d problem(s)\n", found)
+		return 1
+	}
+	return 0
+}
+
+// runUsage runs the usage command over the packages in args.
+func runUsage(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		args = []string{"./..."}
+	}
+
+	for _, dir := range args {
+		report, err := doctor.ScanUsage(dir)
+		if err == nil {
+			err = report.WriteText(stdout)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "gowise usage: %v\n", err)
+			return 2
+		}
+	}
+	return 0
+}
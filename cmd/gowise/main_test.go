@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+func TestRunDoctor(t *testing.T) {
+	assert := assertions.New(t)
+
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	assert.Equal(run([]string{"doctor", dir}, &stdout, &stderr), 0)
+	assert.Equal(stdout.String(), "")
+
+	src := `package p
+
+import (
+	"testing"
+	"time"
+
+	"gowise/pkg/assertions"
+)
+
+func TestP(t *testing.T) {
+	assertions.New(t).Eventually(func() bool { return true }, time.Millisecond, time.Second)
+}
+`
+	assert.NoError(os.WriteFile(filepath.Join(dir, "p_test.go"), []byte(src), 0o644))
+	stdout.Reset()
+	assert.Equal(run([]string{"doctor", dir + "/..."}, &stdout, &stderr), 1)
+	assert.Contains(stdout.String(), "p_test.go:11:")
+	assert.Contains(stdout.String(), "\tfix: ")
+	assert.Contains(stderr.String(), "found 1 problem(s)")
+}
+
+func TestRunUsage(t *testing.T) {
+	assert := assertions.New(t)
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(run(nil, &stdout, &stderr), 2)
+	assert.Contains(stderr.String(), "usage: gowise")
+
+	stderr.Reset()
+	assert.Equal(run([]string{"frobnicate"}, &stdout, &stderr), 2)
+	assert.Contains(stderr.String(), `unknown command "frobnicate"`)
+
+	assert.Equal(run([]string{"doctor", filepath.Join(t.TempDir(), "missing")}, &stdout, &stderr), 2)
+}
+
+func TestRunUsageReport(t *testing.T) {
+	assert := assertions.New(t)
+
+	dir := t.TempDir()
+	src := `package p
+
+import (
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+func TestP(t *testing.T) {
+	assertions.New(t).Equal(1, 1).True(true)
+}
+`
+	assert.NoError(os.WriteFile(filepath.Join(dir, "p_test.go"), []byte(src), 0o644))
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(run([]string{"usage", dir}, &stdout, &stderr), 0)
+	assert.Contains(stdout.String(), "Totals (2 calls in 1 packages)")
+	assert.Equal(stderr.String(), "")
+}
@@ -5,7 +5,6 @@ package custom
 import (
 	"encoding/json"
 	"net/http"
-	"net/url"
 	"reflect"
 	"regexp"
 	"strings"
@@ -17,42 +16,56 @@ import (
 // DomainAssert extends the base Assert with domain-specific assertions
 type DomainAssert struct {
 	*assertions.Assert
-	t assertions.TestingT
 }
 
 // NewDomainAssert creates a new domain-specific assertion context
 func NewDomainAssert(t assertions.TestingT) *DomainAssert {
 	return &DomainAssert{
 		Assert: assertions.New(t),
-		t:      t,
 	}
 }
 
-// Email Validation Assertions
-func (a *DomainAssert) IsValidEmail(email string) *DomainAssert {
-	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+// Registered assertions are available to any Assert through Check, without
+// a wrapper type
+func init() {
+	assertions.Register("IsValidEmail", checkEmail)
+}
+
+var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// checkEmail is the registered IsValidEmail assertion
+func checkEmail(args ...interface{}) assertions.CheckResult {
+	email, ok := args[0].(string)
+	if !ok {
+		return assertions.Failf("want an email string, got %T", args[0])
+	}
 	if !emailRegex.MatchString(email) {
-		a.t.Errorf("IsValidEmail: invalid email format\n  got: %q\n  expected: valid email format", email)
+		return assertions.Failf("invalid email format\n  got: %q\n  expected: valid email format", email)
 	}
+	return assertions.Pass()
+}
+
+// Email Validation Assertions
+func (a *DomainAssert) IsValidEmail(email string) *DomainAssert {
+	a.Check("IsValidEmail", email)
 	return a
 }
 
 func (a *DomainAssert) HasEmailDomain(email, expectedDomain string) *DomainAssert {
 	if !strings.Contains(email, "@") {
-		a.t.Errorf("HasEmailDomain: invalid email format: %q", email)
+		a.Report(assertions.Failf("HasEmailDomain: invalid email format: %q", email))
 		return a
 	}
 
 	parts := strings.Split(email, "@")
 	if len(parts) != 2 {
-		a.t.Errorf("HasEmailDomain: invalid email format: %q", email)
+		a.Report(assertions.Failf("HasEmailDomain: invalid email format: %q", email))
 		return a
 	}
 
 	domain := parts[1]
 	if domain != expectedDomain {
-		a.t.Errorf("HasEmailDomain: wrong email domain\n  got domain: %q\n  want domain: %q\n  full email: %q",
-			domain, expectedDomain, email)
+		a.Report(assertions.Mismatch(domain, expectedDomain, "HasEmailDomain: wrong email domain in "+email))
 	}
 	return a
 }
@@ -60,9 +73,9 @@ func (a *DomainAssert) HasEmailDomain(email, expectedDomain string) *DomainAsser
 // HTTP Response Assertions
 func (a *DomainAssert) HasStatusCode(response *http.Response, expectedStatus int) *DomainAssert {
 	if response.StatusCode != expectedStatus {
-		a.t.Errorf("HasStatusCode: wrong HTTP status\n  got: %d (%s)\n  want: %d (%s)",
+		a.Report(assertions.Failf("HasStatusCode: wrong HTTP status\n  got: %d (%s)\n  want: %d (%s)",
 			response.StatusCode, http.StatusText(response.StatusCode),
-			expectedStatus, http.StatusText(expectedStatus))
+			expectedStatus, http.StatusText(expectedStatus)))
 	}
 	return a
 }
@@ -70,8 +83,8 @@ func (a *DomainAssert) HasStatusCode(response *http.Response, expectedStatus int
 func (a *DomainAssert) HasHeader(response *http.Response, headerName, expectedValue string) *DomainAssert {
 	actualValue := response.Header.Get(headerName)
 	if actualValue != expectedValue {
-		a.t.Errorf("HasHeader: wrong header value\n  header: %q\n  got: %q\n  want: %q",
-			headerName, actualValue, expectedValue)
+		a.Report(assertions.Failf("HasHeader: wrong header value\n  header: %q\n  got: %q\n  want: %q",
+			headerName, actualValue, expectedValue))
 	}
 	return a
 }
@@ -81,8 +94,8 @@ func (a *DomainAssert) HasContentType(response *http.Response, expectedContentTy
 
 	// Handle cases where content type might include charset
 	if !strings.HasPrefix(contentType, expectedContentType) {
-		a.t.Errorf("HasContentType: wrong content type\n  got: %q\n  want: %q (or with charset)",
-			contentType, expectedContentType)
+		a.Report(assertions.Failf("HasContentType: wrong content type\n  got: %q\n  want: %q (or with charset)",
+			contentType, expectedContentType))
 	}
 	return a
 }
@@ -91,7 +104,7 @@ func (a *DomainAssert) HasContentType(response *http.Response, expectedContentTy
 func (a *DomainAssert) IsValidJSON(data string) *DomainAssert {
 	var js interface{}
 	if err := json.Unmarshal([]byte(data), &js); err != nil {
-		a.t.Errorf("IsValidJSON: invalid JSON format\n  error: %v\n  data: %q", err, data)
+		a.Report(assertions.Failf("IsValidJSON: invalid JSON format\n  error: %v\n  data: %q", err, data))
 	}
 	return a
 }
@@ -99,7 +112,7 @@ func (a *DomainAssert) IsValidJSON(data string) *DomainAssert {
 func (a *DomainAssert) JSONHasKey(jsonData string, keyPath string) *DomainAssert {
 	var data interface{}
 	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
-		a.t.Errorf("JSONHasKey: invalid JSON format\n  error: %v", err)
+		a.Report(assertions.Failf("JSONHasKey: invalid JSON format\n  error: %v", err))
 		return a
 	}
 
@@ -113,13 +126,13 @@ func (a *DomainAssert) JSONHasKey(jsonData string, keyPath string) *DomainAssert
 			if val, exists := v[key]; exists {
 				current = val
 			} else {
-				a.t.Errorf("JSONHasKey: key not found\n  key path: %q\n  missing key: %q (at position %d)\n  available keys: %v",
-					keyPath, key, i, getMapKeys(v))
+				a.Report(assertions.Failf("JSONHasKey: key not found\n  key path: %q\n  missing key: %q (at position %d)\n  available keys: %v",
+					keyPath, key, i, getMapKeys(v)))
 				return a
 			}
 		default:
-			a.t.Errorf("JSONHasKey: cannot traverse key path\n  key path: %q\n  stopped at: %q\n  current value type: %T",
-				keyPath, key, current)
+			a.Report(assertions.Failf("JSONHasKey: cannot traverse key path\n  key path: %q\n  stopped at: %q\n  current value type: %T",
+				keyPath, key, current))
 			return a
 		}
 	}
@@ -130,74 +143,42 @@ func (a *DomainAssert) JSONHasKey(jsonData string, keyPath string) *DomainAssert
 func (a *DomainAssert) JSONEquals(jsonData string, key string, expectedValue interface{}) *DomainAssert {
 	var data map[string]interface{}
 	if err := json.Unmarshal([]byte(jsonData), &data); err != nil {
-		a.t.Errorf("JSONEquals: invalid JSON format\n  error: %v", err)
+		a.Report(assertions.Failf("JSONEquals: invalid JSON format\n  error: %v", err))
 		return a
 	}
 
 	actualValue, exists := data[key]
 	if !exists {
-		a.t.Errorf("JSONEquals: key not found\n  key: %q\n  available keys: %v", key, getMapKeys(data))
+		a.Report(assertions.Failf("JSONEquals: key not found\n  key: %q\n  available keys: %v", key, getMapKeys(data)))
 		return a
 	}
 
 	if !reflect.DeepEqual(actualValue, expectedValue) {
-		a.t.Errorf("JSONEquals: wrong value for key\n  key: %q\n  got: %v (%T)\n  want: %v (%T)",
-			key, actualValue, actualValue, expectedValue, expectedValue)
+		a.Report(assertions.Failf("JSONEquals: wrong value for key\n  key: %q\n  got: %v (%T)\n  want: %v (%T)",
+			key, actualValue, actualValue, expectedValue, expectedValue))
 	}
 
 	return a
 }
 
-// URL Validation Assertions
-func (a *DomainAssert) IsValidURL(rawURL string) *DomainAssert {
-	if _, err := url.Parse(rawURL); err != nil {
-		a.t.Errorf("IsValidURL: invalid URL format\n  URL: %q\n  error: %v", rawURL, err)
-	}
-	return a
-}
-
-func (a *DomainAssert) URLHasScheme(rawURL, expectedScheme string) *DomainAssert {
-	parsed, err := url.Parse(rawURL)
-	if err != nil {
-		a.t.Errorf("URLHasScheme: invalid URL format\n  URL: %q\n  error: %v", rawURL, err)
-		return a
-	}
-
-	if parsed.Scheme != expectedScheme {
-		a.t.Errorf("URLHasScheme: wrong URL scheme\n  URL: %q\n  got scheme: %q\n  want scheme: %q",
-			rawURL, parsed.Scheme, expectedScheme)
-	}
-	return a
-}
-
-func (a *DomainAssert) URLHasHost(rawURL, expectedHost string) *DomainAssert {
-	parsed, err := url.Parse(rawURL)
-	if err != nil {
-		a.t.Errorf("URLHasHost: invalid URL format\n  URL: %q\n  error: %v", rawURL, err)
-		return a
-	}
-
-	if parsed.Host != expectedHost {
-		a.t.Errorf("URLHasHost: wrong URL host\n  URL: %q\n  got host: %q\n  want host: %q",
-			rawURL, parsed.Host, expectedHost)
-	}
-	return a
-}
+// URL assertions (IsValidURL, URLHasScheme, URLHasHost and more) are
+// provided by the embedded *assertions.Assert.
 
-// Time Assertions
+// Time Assertions read the Assert's clock, so a test can freeze it with
+// timecontrol.Freeze rather than depend on the wall clock
 func (a *DomainAssert) IsRecentTime(timestamp time.Time, maxAge time.Duration) *DomainAssert {
-	age := time.Since(timestamp)
+	age := a.Now().Sub(timestamp)
 	if age > maxAge {
-		a.t.Errorf("IsRecentTime: timestamp too old\n  timestamp: %v\n  age: %v\n  max age: %v",
-			timestamp, age, maxAge)
+		a.Report(assertions.Failf("IsRecentTime: timestamp too old\n  timestamp: %v\n  age: %v\n  max age: %v",
+			timestamp, age, maxAge))
 	}
 	return a
 }
 
 func (a *DomainAssert) IsFutureTime(timestamp time.Time) *DomainAssert {
-	if !timestamp.After(time.Now()) {
-		a.t.Errorf("IsFutureTime: timestamp is not in the future\n  timestamp: %v\n  current time: %v",
-			timestamp, time.Now())
+	if now := a.Now(); !timestamp.After(now) {
+		a.Report(assertions.Failf("IsFutureTime: timestamp is not in the future\n  timestamp: %v\n  current time: %v",
+			timestamp, now))
 	}
 	return a
 }
@@ -212,8 +193,8 @@ func (a *DomainAssert) IsWorkingHours(timestamp time.Time, timezone *time.Locati
 	isWorkingHour := hour >= 9 && hour < 17
 
 	if !isWeekday || !isWorkingHour {
-		a.t.Errorf("IsWorkingHours: timestamp is outside working hours\n  timestamp: %v\n  local time: %v\n  hour: %d\n  weekday: %v",
-			timestamp, localTime, hour, weekday)
+		a.Report(assertions.Failf("IsWorkingHours: timestamp is outside working hours\n  timestamp: %v\n  local time: %v\n  hour: %d\n  weekday: %v",
+			timestamp, localTime, hour, weekday))
 	}
 	return a
 }
@@ -222,12 +203,12 @@ func (a *DomainAssert) IsWorkingHours(timestamp time.Time, timezone *time.Locati
 func (a *DomainAssert) MatchesPattern(str, pattern string) *DomainAssert {
 	matched, err := regexp.MatchString(pattern, str)
 	if err != nil {
-		a.t.Errorf("MatchesPattern: invalid regex pattern\n  pattern: %q\n  error: %v", pattern, err)
+		a.Report(assertions.Failf("MatchesPattern: invalid regex pattern\n  pattern: %q\n  error: %v", pattern, err))
 		return a
 	}
 
 	if !matched {
-		a.t.Errorf("MatchesPattern: string does not match pattern\n  string: %q\n  pattern: %q", str, pattern)
+		a.Report(assertions.Failf("MatchesPattern: string does not match pattern\n  string: %q\n  pattern: %q", str, pattern))
 	}
 	return a
 }
@@ -235,8 +216,8 @@ func (a *DomainAssert) MatchesPattern(str, pattern string) *DomainAssert {
 func (a *DomainAssert) HasLength(str string, min, max int) *DomainAssert {
 	length := len(str)
 	if length < min || length > max {
-		a.t.Errorf("HasLength: string length outside valid range\n  string: %q\n  length: %d\n  valid range: %d-%d",
-			str, length, min, max)
+		a.Report(assertions.Failf("HasLength: string length outside valid range\n  string: %q\n  length: %d\n  valid range: %d-%d",
+			str, length, min, max))
 	}
 	return a
 }
@@ -244,7 +225,7 @@ func (a *DomainAssert) HasLength(str string, min, max int) *DomainAssert {
 func (a *DomainAssert) IsAlphanumeric(str string) *DomainAssert {
 	alphanumeric := regexp.MustCompile(`^[a-zA-Z0-9]+$`)
 	if !alphanumeric.MatchString(str) {
-		a.t.Errorf("IsAlphanumeric: string contains non-alphanumeric characters\n  string: %q", str)
+		a.Report(assertions.Failf("IsAlphanumeric: string contains non-alphanumeric characters\n  string: %q", str))
 	}
 	return a
 }
@@ -252,24 +233,24 @@ func (a *DomainAssert) IsAlphanumeric(str string) *DomainAssert {
 // Business Logic Assertions
 func (a *DomainAssert) IsValidUserAge(age int) *DomainAssert {
 	if age < 0 || age > 150 {
-		a.t.Errorf("IsValidUserAge: age outside realistic range\n  age: %d\n  valid range: 0-150", age)
+		a.Report(assertions.Failf("IsValidUserAge: age outside realistic range\n  age: %d\n  valid range: 0-150", age))
 	}
 	return a
 }
 
 func (a *DomainAssert) IsValidPrice(price float64) *DomainAssert {
 	if price < 0 {
-		a.t.Errorf("IsValidPrice: price cannot be negative\n  price: %.2f", price)
+		a.Report(assertions.Failf("IsValidPrice: price cannot be negative\n  price: %.2f", price))
 	}
 	if price > 1000000 {
-		a.t.Errorf("IsValidPrice: price exceeds maximum allowed\n  price: %.2f\n  maximum: 1,000,000", price)
+		a.Report(assertions.Failf("IsValidPrice: price exceeds maximum allowed\n  price: %.2f\n  maximum: 1,000,000", price))
 	}
 	return a
 }
 
 func (a *DomainAssert) HasValidInventoryCount(count int) *DomainAssert {
 	if count < 0 {
-		a.t.Errorf("HasValidInventoryCount: inventory count cannot be negative\n  count: %d", count)
+		a.Report(assertions.Failf("HasValidInventoryCount: inventory count cannot be negative\n  count: %d", count))
 	}
 	return a
 }
@@ -292,7 +273,7 @@ func (a *DomainAssert) HasUniqueElements(slice interface{}) *DomainAssert {
 		}
 
 		if len(duplicates) > 0 {
-			a.t.Errorf("HasUniqueElements: found duplicate elements\n  duplicates: %v", duplicates)
+			a.Report(assertions.Failf("HasUniqueElements: found duplicate elements\n  duplicates: %v", duplicates))
 		}
 
 	case []int:
@@ -308,11 +289,11 @@ func (a *DomainAssert) HasUniqueElements(slice interface{}) *DomainAssert {
 		}
 
 		if len(duplicates) > 0 {
-			a.t.Errorf("HasUniqueElements: found duplicate elements\n  duplicates: %v", duplicates)
+			a.Report(assertions.Failf("HasUniqueElements: found duplicate elements\n  duplicates: %v", duplicates))
 		}
 
 	default:
-		a.t.Errorf("HasUniqueElements: unsupported type %T", slice)
+		a.Report(assertions.Failf("HasUniqueElements: unsupported type %T", slice))
 	}
 
 	return a
@@ -323,21 +304,21 @@ func (a *DomainAssert) IsSortedAscending(slice interface{}) *DomainAssert {
 	case []int:
 		for i := 1; i < len(s); i++ {
 			if s[i] < s[i-1] {
-				a.t.Errorf("IsSortedAscending: slice not sorted in ascending order\n  position %d: %d > %d",
-					i, s[i-1], s[i])
+				a.Report(assertions.Failf("IsSortedAscending: slice not sorted in ascending order\n  position %d: %d > %d",
+					i, s[i-1], s[i]))
 				return a
 			}
 		}
 	case []string:
 		for i := 1; i < len(s); i++ {
 			if s[i] < s[i-1] {
-				a.t.Errorf("IsSortedAscending: slice not sorted in ascending order\n  position %d: %q > %q",
-					i, s[i-1], s[i])
+				a.Report(assertions.Failf("IsSortedAscending: slice not sorted in ascending order\n  position %d: %q > %q",
+					i, s[i-1], s[i]))
 				return a
 			}
 		}
 	default:
-		a.t.Errorf("IsSortedAscending: unsupported type %T", slice)
+		a.Report(assertions.Failf("IsSortedAscending: unsupported type %T", slice))
 	}
 
 	return a
@@ -8,6 +8,8 @@ import (
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"gowise/pkg/timecontrol"
 )
 
 func TestDomainAssertions(t *testing.T) {
@@ -92,14 +94,15 @@ func TestDomainAssertions(t *testing.T) {
 	t.Run("TimeValidation", func(t *testing.T) {
 		assert := NewDomainAssert(t)
 
-		now := time.Now()
-		recent := now.Add(-30 * time.Minute)
-		future := now.Add(2 * time.Hour)
-
 		// UK timezone for working hours test
 		uk, _ := time.LoadLocation("Europe/London")
 		workingTime := time.Date(2024, 1, 15, 14, 30, 0, 0, uk) // Monday 2:30 PM
 
+		// The clock stands still, so the times are always recent and future
+		clock := timecontrol.Freeze(t, workingTime)
+		recent := clock.Now().Add(-30 * time.Minute)
+		future := clock.Now().Add(2 * time.Hour)
+
 		assert.IsRecentTime(recent, time.Hour).
 			IsFutureTime(future).
 			IsWorkingHours(workingTime, uk)
@@ -141,6 +144,7 @@ func TestDomainAssertions(t *testing.T) {
 
 func TestComprehensiveDomainValidation(t *testing.T) {
 	assert := NewDomainAssert(t)
+	clock := timecontrol.Freeze(t, time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC))
 
 	t.Run("CompleteUserValidation", func(t *testing.T) {
 		user := User{
@@ -149,7 +153,7 @@ func TestComprehensiveDomainValidation(t *testing.T) {
 			Email:    "alice@company.com",
 			Age:      28,
 			Active:   true,
-			Created:  time.Now().Add(-1 * time.Hour),
+			Created:  clock.Now().Add(-1 * time.Hour),
 		}
 
 		assert.IsValidUser(user)
@@ -192,7 +196,7 @@ func TestComprehensiveDomainValidation(t *testing.T) {
 			Products: products,
 			Total:    79.98,
 			Status:   "processing",
-			Created:  time.Now().Add(-2 * time.Hour),
+			Created:  clock.Now().Add(-2 * time.Hour),
 		}
 
 		assert.IsValidOrder(order)
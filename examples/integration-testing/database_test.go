@@ -351,46 +351,24 @@ func TestConcurrentAccess(t *testing.T) {
 
 	t.Run("ConcurrentUserCreation", func(t *testing.T) {
 		const numGoroutines = 10
-		var wg sync.WaitGroup
-		usersChan := make(chan User, numGoroutines)
-		errorsChan := make(chan error, numGoroutines)
-
-		// Create users concurrently
-		for i := 0; i < numGoroutines; i++ {
-			wg.Add(1)
-			go func(id int) {
-				defer wg.Done()
-				username := fmt.Sprintf("user%d", id)
-				email := fmt.Sprintf("user%d@example.com", id)
-
-				user, err := service.RegisterUser(username, email)
-				if err != nil {
-					errorsChan <- err
-				} else {
-					usersChan <- user
-				}
-			}(i)
-		}
-
-		wg.Wait()
-		close(usersChan)
-		close(errorsChan)
-
-		// Collect results
-		var users []User
-		var errors []error
-
-		for user := range usersChan {
-			users = append(users, user)
-		}
-
-		for err := range errorsChan {
-			errors = append(errors, err)
+		users := make([]User, numGoroutines)
+		errors := make([]error, numGoroutines)
+
+		// Create users concurrently, each writing only its own slot
+		registrations := make([]func(), numGoroutines)
+		for i := range registrations {
+			registrations[i] = func() {
+				username := fmt.Sprintf("user%d", i)
+				email := fmt.Sprintf("user%d@example.com", i)
+				users[i], errors[i] = service.RegisterUser(username, email)
+			}
 		}
+		assert.CompletesConcurrently(registrations, 5*time.Second)
 
 		// All operations should succeed (no username conflicts)
-		assert.Len(errors, 0)
-		assert.Len(users, numGoroutines)
+		for _, err := range errors {
+			assert.NoError(err)
+		}
 
 		// Verify all users have unique IDs and usernames
 		userIDs := make(map[int]bool)
@@ -5,12 +5,12 @@ package performance
 
 import (
 	"fmt"
-	"runtime"
 	"strings"
 	"testing"
 	"time"
 
 	"gowise/pkg/assertions"
+	"gowise/pkg/benchassert"
 )
 
 // mockT is a minimal TestingT implementation for benchmarks
@@ -266,27 +266,9 @@ func TestPerformanceRegression(t *testing.T) {
 	})
 
 	t.Run("MemoryAllocationRegression", func(t *testing.T) {
-		// Measure memory allocations for success path
-		var m1, m2 runtime.MemStats
-		runtime.GC()
-		runtime.ReadMemStats(&m1)
-
+		// The success path of Equal must not allocate
 		testAssert := assertions.New(&mockT{})
-		for i := 0; i < 1000; i++ {
-			testAssert.Equal(i, i)
-		}
-
-		runtime.GC()
-		runtime.ReadMemStats(&m2)
-
-		allocations := m2.TotalAlloc - m1.TotalAlloc
-		allocPerOp := allocations / 1000
-
-		// Success path should have minimal allocations
-		assert.True(allocPerOp < 100) // Less than 100 bytes per successful assertion
-
-		t.Logf("Memory allocation: %d bytes total, %d bytes/op",
-			allocations, allocPerOp)
+		benchassert.NoAllocs(assert, func() { testAssert.Equal(42, 42) })
 	})
 }
 
@@ -386,35 +368,14 @@ func TestPerformanceMonitoring(t *testing.T) {
 	})
 
 	t.Run("MemoryUsageMonitoring", func(t *testing.T) {
-		var m runtime.MemStats
-		runtime.GC()
-		runtime.ReadMemStats(&m)
-		startAlloc := m.Alloc
-
-		// Operation to monitor
-		testAssert := assertions.New(&mockT{})
-		data := make([]string, 1000)
-		for i := 0; i < 1000; i++ {
-			data[i] = fmt.Sprintf("item%d", i)
-			testAssert.Len(data, i+1)
-		}
-
-		runtime.GC()
-		runtime.ReadMemStats(&m)
-		endAlloc := m.Alloc
-
-		var memUsed uint64
-		if endAlloc >= startAlloc {
-			memUsed = endAlloc - startAlloc
-		} else {
-			// GC reduced memory between measurements, use 0
-			memUsed = 0
-		}
-
-		// Assert memory usage is reasonable
-		maxMemory := uint64(1024 * 1024) // 1MB
-		assert.True(memUsed < maxMemory)
-
-		t.Logf("Memory used: %d bytes", memUsed)
+		// Memory the operation keeps reachable must stay under 1 MiB
+		benchassert.MaxHeapGrowth(assert, func() {
+			testAssert := assertions.New(&mockT{})
+			data := make([]string, 1000)
+			for i := 0; i < 1000; i++ {
+				data[i] = fmt.Sprintf("item%d", i)
+				testAssert.Len(data, i+1)
+			}
+		}, 1<<20)
 	})
 }
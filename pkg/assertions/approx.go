@@ -0,0 +1,255 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gowise/pkg/diff"
+)
+
+// ApproxOption sets a tolerance of EqualApprox.
+type ApproxOption func(*approxConfig)
+
+// approxConfig holds the tolerances of EqualApprox; zero values compare
+// exactly.
+type approxConfig struct {
+	delta   float64       // Absolute tolerance for floats
+	epsilon float64       // Tolerance for floats relative to the wanted value
+	within  time.Duration // Tolerance for time.Time values
+}
+
+// ApproxDelta makes EqualApprox treat two floats as equal when they differ by
+// at most delta.
+func ApproxDelta(delta float64) ApproxOption {
+	return func(c *approxConfig) { c.delta = delta }
+}
+
+// ApproxEpsilon makes EqualApprox treat two floats as equal when they differ
+// by at most epsilon times the magnitude of the wanted value, e.g. 0.01 for
+// 1%. With ApproxDelta as well, floats within either tolerance are equal.
+func ApproxEpsilon(epsilon float64) ApproxOption {
+	return func(c *approxConfig) { c.epsilon = epsilon }
+}
+
+// ApproxTime makes EqualApprox treat two time.Time values as equal when they
+// are at most tolerance apart. Without it times are compared with their Equal
+// method.
+func ApproxTime(tolerance time.Duration) ApproxOption {
+	return func(c *approxConfig) { c.within = tolerance }
+}
+
+// maxApproxPaths is the number of differing paths EqualApprox lists unless
+// WithMaxDifferences sets another budget.
+const maxApproxPaths = 10
+
+// timeType is the type of time.Time values, compared by ApproxTime.
+var timeType = reflect.TypeFor[time.Time]()
+
+// EqualApprox asserts that got and want are deeply equal, as Equal does,
+// except that floats, and with ApproxTime times, within the given tolerances
+// are treated as equal wherever they appear: in fields, elements, map values
+// and behind pointers and interfaces. On failure every path that differs, up
+// to the difference budget, is reported with its values and, for floats and
+// times, by how much they differ. Without options floats and times must be
+// equal. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.EqualApprox(report.Summary(), want, assertions.ApproxDelta(1e-9), assertions.ApproxTime(time.Second))
+//	// values differ at .Regions["eu"].Mean
+//	//   got: 12.61
+//	//   want: 12.5
+//	//   diff: 0.11, beyond delta 1e-09
+func (a *Assert) EqualApprox(got, want interface{}, opts ...ApproxOption) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if got == nil || want == nil {
+		if got != want {
+			a.reportErrorConsistent(got, want, "values differ")
+		}
+		return a
+	}
+
+	w := approxWalker{a: a, visited: make(map[visit]bool)}
+	for _, opt := range opts {
+		opt(&w.config)
+	}
+	w.differences = &differenceList{limit: a.maxDifferences}
+	if w.differences.limit <= 0 {
+		w.differences.limit = maxApproxPaths
+	}
+
+	w.walk("", reflect.ValueOf(got), reflect.ValueOf(want))
+	a.reportDifferences(w.differences, "values", "path", "paths")
+	return a
+}
+
+// approxWalker compares two values for EqualApprox, collecting the paths at
+// which they differ.
+type approxWalker struct {
+	a           *Assert
+	config      approxConfig
+	differences *differenceList
+	visited     map[visit]bool
+}
+
+// walk compares got and want at path, such as `.Points[2].Lat`, empty for
+// the values themselves.
+func (w *approxWalker) walk(path string, got, want reflect.Value) {
+	if !got.IsValid() || !want.IsValid() || got.Type() != want.Type() {
+		if got.IsValid() || want.IsValid() {
+			w.mismatch(path, got, want, "")
+		}
+		return
+	}
+
+	t := got.Type()
+	switch {
+	case t == timeType && got.CanInterface():
+		w.times(path, got.Interface().(time.Time), want.Interface().(time.Time))
+		return
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		w.floats(path, got, want)
+		return
+	}
+	if equal := equalityFor(t).equal; equal != nil && got.CanInterface() {
+		if !equal(got, want) {
+			w.mismatch(path, got, want, "")
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if got.IsNil() != want.IsNil() {
+			w.mismatch(path, got, want, "")
+			return
+		}
+		if got.IsNil() || (t.Kind() != reflect.Slice && got.Pointer() == want.Pointer()) {
+			return
+		}
+		v := visit{got.Pointer(), want.Pointer(), t}
+		if w.visited[v] {
+			return
+		}
+		w.visited[v] = true
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		w.walk(path, got.Elem(), want.Elem())
+	case reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				w.mismatch(path, got, want, "")
+			}
+			return
+		}
+		w.walk(path, got.Elem(), want.Elem())
+	case reflect.Struct:
+		names := structInfoFor(t).names
+		for i, name := range names {
+			w.walk(path+"."+name, got.Field(i), want.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if got.Len() != want.Len() {
+			w.mismatch(path, got, want, fmt.Sprintf("lengths %d and %d", got.Len(), want.Len()))
+			return
+		}
+		for i := 0; i < got.Len(); i++ {
+			w.walk(fmt.Sprintf("%s[%d]", path, i), got.Index(i), want.Index(i))
+		}
+	case reflect.Map:
+		keys := diff.SortedKeys(want)
+		for _, key := range diff.SortedKeys(got) {
+			if !want.MapIndex(key).IsValid() {
+				keys = append(keys, key)
+			}
+		}
+		for _, key := range keys {
+			w.walk(path+"["+w.render(key)+"]", got.MapIndex(key), want.MapIndex(key))
+		}
+	default:
+		if !scalarsEqual(got, want) {
+			w.mismatch(path, got, want, "")
+		}
+	}
+}
+
+// floats compares two float values within the configured tolerances.
+func (w *approxWalker) floats(path string, got, want reflect.Value) {
+	g, v := got.Float(), want.Float()
+	if withinDelta(g, v, w.config.delta) || (w.config.epsilon > 0 && math.Abs(g-v) <= w.config.epsilon*math.Abs(v)) {
+		return
+	}
+
+	opts := w.a.formatOptions
+	var tolerances []string
+	if w.config.delta > 0 {
+		tolerances = append(tolerances, "delta "+formatNumeric(w.config.delta, opts))
+	}
+	if w.config.epsilon > 0 {
+		tolerances = append(tolerances, "epsilon "+formatNumeric(w.config.epsilon, opts))
+	}
+	// Six significant digits keep float noise, as in 0.009999999999999787,
+	// out of the reported difference
+	distance, _ := strconv.ParseFloat(strconv.FormatFloat(math.Abs(g-v), 'g', 6, 64), 64)
+	note := formatNumeric(distance, opts)
+	if len(tolerances) > 0 {
+		note += ", beyond " + strings.Join(tolerances, " and ")
+	}
+	w.mismatch(path, got, want, note)
+}
+
+// times compares two times within the configured tolerance.
+func (w *approxWalker) times(path string, got, want time.Time) {
+	distance := got.Sub(want).Abs()
+	if got.Equal(want) || (w.config.within > 0 && distance <= w.config.within) {
+		return
+	}
+
+	note := humaniseDuration(distance)
+	if w.config.within > 0 {
+		note += ", beyond " + humaniseDuration(w.config.within)
+	}
+	w.mismatch(path, reflect.ValueOf(got), reflect.ValueOf(want), note)
+}
+
+// mismatch records a differing path, with a note on how the values differ
+// if there is more to say than the values themselves.
+func (w *approxWalker) mismatch(path string, got, want reflect.Value, note string) {
+	if path == "" {
+		path = "value"
+	}
+	w.differences.add(" at ", func() string {
+		description := fmt.Sprintf("%s\n  got: %s\n  want: %s", path, w.render(got), w.render(want))
+		if note != "" {
+			description += "\n  diff: " + note
+		}
+		return description
+	})
+}
+
+// render formats a value found at a path: a missing map value as <missing>,
+// and values in unexported fields as far as reflect allows.
+func (w *approxWalker) render(v reflect.Value) string {
+	switch {
+	case !v.IsValid():
+		return "<missing>"
+	case v.CanInterface():
+		return formatNumeric(v.Interface(), w.a.formatOptions)
+	default:
+		return formatScalar(v)
+	}
+}
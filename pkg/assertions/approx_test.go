@@ -0,0 +1,127 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type geoPoint struct {
+	Lat, Lng float64
+}
+
+type regionStats struct {
+	Mean    float64
+	Samples int
+	Updated time.Time
+}
+
+type analytics struct {
+	Name    string
+	Route   []geoPoint
+	Regions map[string]regionStats
+	Peak    *float32
+}
+
+// TestEqualApprox tests deep comparison with float and time tolerances.
+func TestEqualApprox(t *testing.T) {
+	noon := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	peak, nearPeak := float32(9.5), float32(9.5000001)
+	base := func() analytics {
+		return analytics{
+			Name:    "daily",
+			Route:   []geoPoint{{51.5074, -0.1278}, {48.8566, 2.3522}},
+			Regions: map[string]regionStats{"eu": {12.5, 40, noon}, "us": {7.25, 12, noon}},
+			Peak:    &peak,
+		}
+	}
+	computed := base()
+	computed.Route[1].Lat += 1e-9
+	computed.Regions["eu"] = regionStats{12.5000000001, 40, noon.Add(300 * time.Millisecond)}
+	computed.Peak = &nearPeak
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"within delta and time tolerance", func(a *Assert) {
+			a.EqualApprox(computed, base(), ApproxDelta(1e-6), ApproxTime(time.Second))
+		}, true, ""},
+		{"through pointers", func(a *Assert) {
+			c, b := computed, base()
+			a.EqualApprox(&c, &b, ApproxDelta(1e-6), ApproxTime(time.Second))
+		}, true, ""},
+		{"time beyond tolerance", func(a *Assert) {
+			a.EqualApprox(computed, base(), ApproxDelta(1e-6), ApproxTime(100*time.Millisecond))
+		}, false, "values differ at .Regions[\"eu\"].Updated\n" +
+			"  got: " + formatValue(noon.Add(300*time.Millisecond), DefaultFormatOptions()) + "\n" +
+			"  want: " + formatValue(noon, DefaultFormatOptions()) + "\n" +
+			"  diff: 300ms, beyond 100ms"},
+		{"without options floats must be equal", func(a *Assert) { a.EqualApprox(geoPoint{1, 2}, geoPoint{1, 2.5}) }, false,
+			"values differ at .Lng\n  got: 2\n  want: 2.5\n  diff: 0.5"},
+		{"every path beyond tolerance is listed", func(a *Assert) {
+			a.EqualApprox([]geoPoint{{1, 2}, {3, 4}}, []geoPoint{{1.5, 2}, {3, 4.01}}, ApproxDelta(0.001))
+		}, false, "values differ in 2 paths\n" +
+			"  [0].Lat\n    got: 1\n    want: 1.5\n    diff: 0.5, beyond delta 0.001\n" +
+			"  [1].Lng\n    got: 4\n    want: 4.01\n    diff: 0.01, beyond delta 0.001"},
+		{"relative epsilon", func(a *Assert) {
+			a.EqualApprox(map[string]float64{"x": 1010}, map[string]float64{"x": 1000}, ApproxEpsilon(0.01))
+		}, true, ""},
+		{"beyond epsilon", func(a *Assert) { a.EqualApprox(1011.0, 1000.0, ApproxEpsilon(0.01)) }, false,
+			"values differ at value\n  got: 1011\n  want: 1000\n  diff: 11, beyond epsilon 0.01"},
+		{"other fields compared exactly", func(a *Assert) {
+			changed := base()
+			changed.Name = "weekly"
+			a.EqualApprox(changed, base(), ApproxDelta(1))
+		}, false, "values differ at .Name\n  got: \"weekly\"\n  want: \"daily\""},
+		{"missing map key", func(a *Assert) {
+			a.EqualApprox(map[string]float64{"a": 1}, map[string]float64{"a": 1, "b": 2}, ApproxDelta(1))
+		}, false, "values differ at [\"b\"]\n  got: <missing>\n  want: 2"},
+		{"slice lengths", func(a *Assert) { a.EqualApprox([]float64{1}, []float64{1, 2}, ApproxDelta(1)) }, false,
+			"values differ at value\n  got: []float64{1}\n  want: []float64{1, 2}\n  diff: lengths 1 and 2"},
+		{"nil want", func(a *Assert) { a.EqualApprox(1.0, nil) }, false, "values differ"},
+		{"difference budget", func(a *Assert) {
+			a.WithMaxDifferences(1).EqualApprox([]float64{1, 2, 3}, []float64{2, 3, 4})
+		}, false, "values differ in 3 paths\n  [0]\n    got: 1\n    want: 2\n    diff: 1\n  ... and 2 more paths"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.HasPrefix(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// ExampleAssert_EqualApprox compares computed coordinates within a delta.
+func ExampleAssert_EqualApprox() {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	got := []geoPoint{{51.50741, -0.12779}, {48.8566, 2.3622}}
+	want := []geoPoint{{51.5074, -0.1278}, {48.8566, 2.3522}}
+	assert.EqualApprox(got, want, ApproxDelta(0.001))
+
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// values differ at [1].Lng
+	//   got: 2.3622
+	//   want: 2.3522
+	//   diff: 0.01, beyond delta 0.001
+}
@@ -28,22 +28,38 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"math"
 	"net/http"
-	"os"
 	"reflect"
 	"regexp"
-	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
-	"gowise/pkg/assertions/internal/diff"
+	"gowise/pkg/diff"
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/logging"
+	"gowise/pkg/timecontrol"
 )
 
 // TestingT represents the interface that testing.T implements.
 // This allows for both real tests and mock implementations.
+//
+// *testing.T, *testing.B, *testing.F and testing.TB are TestingTs, and
+// testrunner.AsTestingT adapts a TestRunner's contexts. Assertions also use
+// these methods of a context when it has them:
+//
+//	Name() string          // names the test in FailureEvents and crash dumps
+//	Cleanup(func())        // runs VerifyAll's deferred checks
+//	ArtifactDir() string   // holds attachments and crash dumps
+//
+// New accepts contexts that are not TestingTs, for runners that collect
+// failures through Error rather than being told of them; the package-level
+// assertion functions, which have no Assert to read, need a TestingT.
 type TestingT interface {
 	Errorf(format string, args ...interface{})
 	FailNow()
@@ -68,6 +84,66 @@ func isComparable(a, b interface{}) bool {
 	return va.Type().Comparable()
 }
 
+// primitiveEqual compares got and want without reflection when both hold the
+// same predeclared boolean, numeric or string type, the common case for
+// Equal and NotEqual. ok is false for any other pair of values.
+func primitiveEqual(got, want interface{}) (equal, ok bool) {
+	switch g := got.(type) {
+	case int:
+		w, ok := want.(int)
+		return ok && g == w, ok
+	case int8:
+		w, ok := want.(int8)
+		return ok && g == w, ok
+	case int16:
+		w, ok := want.(int16)
+		return ok && g == w, ok
+	case int32:
+		w, ok := want.(int32)
+		return ok && g == w, ok
+	case int64:
+		w, ok := want.(int64)
+		return ok && g == w, ok
+	case uint:
+		w, ok := want.(uint)
+		return ok && g == w, ok
+	case uint8:
+		w, ok := want.(uint8)
+		return ok && g == w, ok
+	case uint16:
+		w, ok := want.(uint16)
+		return ok && g == w, ok
+	case uint32:
+		w, ok := want.(uint32)
+		return ok && g == w, ok
+	case uint64:
+		w, ok := want.(uint64)
+		return ok && g == w, ok
+	case uintptr:
+		w, ok := want.(uintptr)
+		return ok && g == w, ok
+	case float32:
+		w, ok := want.(float32)
+		return ok && g == w, ok
+	case float64:
+		w, ok := want.(float64)
+		return ok && g == w, ok
+	case complex64:
+		w, ok := want.(complex64)
+		return ok && g == w, ok
+	case complex128:
+		w, ok := want.(complex128)
+		return ok && g == w, ok
+	case string:
+		w, ok := want.(string)
+		return ok && g == w, ok
+	case bool:
+		w, ok := want.(bool)
+		return ok && g == w, ok
+	}
+	return false, false
+}
+
 // DiffFormat specifies the preferred format for multi-line string diffs
 type DiffFormat int
 
@@ -85,49 +161,175 @@ const (
 // Assert is a struct that holds the testing context and error message.
 // Thread-safe for concurrent use across goroutines.
 type Assert struct {
-	t          interface{}
-	errorMsg   string
-	failed     *int32     // atomic: pointer to shared failure state (0=not failed, 1=failed)
-	diffFormat DiffFormat // Preferred format for multi-line string diffs
+	t             interface{}
+	shared        *chainState   // Failure state shared with every Assert derived by With
+	diffFormat    DiffFormat    // Preferred format for multi-line string diffs
+	formatOptions FormatOptions // Limits applied when rendering values in failure messages
+	fsys          fs.FS         // Filesystem for file assertions; nil means the operating system
+
+	floatTolerance float64           // Tolerance for float comparisons in Equal and NotEqual; 0 is exact
+	timeout        time.Duration     // Default Eventually and WithinTimeout timeout; 0 uses the built-in
+	interval       time.Duration     // Default Eventually polling interval; 0 uses the built-in
+	maxReadBytes   int64             // Bound on bytes consumed by reader assertions; 0 uses DefaultMaxReadBytes
+	maxDifferences int               // Differences reported per diff assertion; 0 stops at the first
+	clock          timecontrol.Clock // Clock for Eventually, Never and Now; nil uses the test's
+	noDiffs        bool              // Report got/want only, skipping diff generation
+	evaluated      *atomic.Int64     // Assertions evaluated, for NewB's metrics; nil when not counting
+	stats          *statsCollector   // Statistics of this Assert and those derived from it; nil unless UseStats
+	fatal          bool              // Stop the test with FailNow after reporting a failure
+	stackTraces    bool              // Include the trimmed call stack in failures
+	crashDump      *CrashDumpConfig  // Bundle written before a fatal failure stops the test; nil disables
+	propertyChecks int               // Inputs generated per property assertion; 0 uses DefaultPropertyChecks
+	propertySeed   uint64            // Seed for property inputs; 0 picks a fresh seed per assertion
+	delegated      bool              // Set on the Assert an assertion delegates to; the caller was counted
+
+	attachments []pendingAttachment                 // Created if an assertion fails
+	onAttach    func(testattachment.TestAttachment) // Receives created attachments; nil drops them
+
+	logger   logging.LoggerInterface // Receives a FailureEvent per failure; nil disables
+	logStart time.Time               // When the logger was set, for the event's SinceStart
+}
+
+// ChainedAssert is the result of an assertion: the Assert it was called on,
+// through which further assertions chain. It is an alias, so every assertion
+// method and package-level assertion function returns one.
+//
+// A chain is a two-state machine. It starts passing; the first failing
+// assertion reports its message and moves the chain to failed, where it stays.
+// Once failed, every later assertion on the chain, or on an Assert derived
+// from it with With, is skipped without evaluating or formatting anything, and
+// HasFailed, Error and Unwrap report the first failure. For starts a new
+// chain.
+type ChainedAssert = Assert
+
+// chainState is the state an Assert shares with the Asserts derived from it.
+// Only the first failure of a chain is reported, by the goroutine that marks
+// the chain as failed, so the Failure it writes is guarded by mu for readers
+// on other goroutines. Every Assert of the chain reports that failure, however
+// it was derived.
+type chainState struct {
+	failed     atomic.Int32              // 0 until an assertion in the chain fails, then 1
+	evaluated  atomic.Int64              // Assertions evaluated in the chain, numbering its failure
+	mu         sync.Mutex                // Guards the fields below
+	reported   bool                      // Whether failure holds the first failure
+	failure    Failure                   // The first failure
+	stack      [maxFailureFrames]uintptr // Call stack of the first failure, until resolved into failure
+	frames     int                       // Frames of stack not yet resolved; 0 once resolved
+	pendingMsg func() string             // Builds failure's text when first consumed; nil once built
+
+	patternsMu sync.Mutex                // Guards patterns
+	patterns   map[string]*regexp.Regexp // Patterns compiled by the chain's assertions; see compileRegexp
 }
 
 // New creates a new Assert instance with the given testing context.
-// Note: Allocates one int32 on the heap to enable fail-fast chaining across all chain methods that return new Assert instances sharing the failure state (e.g., WithDiffFormat).
+// Note: Allocates the chain state on the heap to enable fail-fast chaining across all chain methods that return new Assert instances sharing the failure state (e.g., WithDiffFormat).
+//
+// An Assert is safe for concurrent use: assertions may run on several
+// goroutines at once, and only the first failure of the chain is reported.
 func New(t interface{}) *Assert {
-	// Initialize shared failure state - will escape to heap for pointer sharing
-	var failed int32
-
 	return &Assert{
-		t:          t,
-		failed:     &failed,        // Pointer to shared atomic int32
-		diffFormat: DiffFormatAuto, // Default to automatic format selection
+		t:             t,
+		shared:        new(chainState), // Shared failure state
+		diffFormat:    DiffFormatAuto,  // Default to automatic format selection
+		formatOptions: DefaultFormatOptions(),
 	}
 }
 
 // WithDiffFormat returns a new Assert instance with the specified diff format preference.
 // This follows GoWise principles of immutable configuration.
 // NOTE: Shares failure state with original for proper fail-fast chaining.
+//
+//funcgen:skip
 func (a *Assert) WithDiffFormat(format DiffFormat) *Assert {
-	// Share the same atomic failure state pointer for proper chaining
-	newAssert := &Assert{
-		t:          a.t,
-		errorMsg:   a.errorMsg,
-		failed:     a.failed, // Share the same atomic pointer
-		diffFormat: format,
-	}
-	return newAssert
+	return a.With(UseDiffFormat(format))
 }
 
 // shouldSkipDueToFailure checks if we should skip this assertion due to fail-fast
 // Thread-safe for concurrent access.
+// Also the point at which the assertion is counted, numbering a failure in
+// its chain and for stats, unless it was delegated to.
 func (a *Assert) shouldSkipDueToFailure() bool {
-	return atomic.LoadInt32(a.failed) != 0
+	skip := a.shared.failed.Load() != 0
+	if a.delegated {
+		return skip
+	}
+	a.shared.evaluated.Add(1)
+	if a.evaluated != nil {
+		a.evaluated.Add(1)
+	}
+	recordAssertion(a.stats, skip)
+	return skip
+}
+
+// countAssertion records an assertion for statistics. Assertions that call
+// shouldSkipDueToFailure are recorded there; those that do not use fail-fast
+// call this instead.
+func (a *Assert) countAssertion() {
+	if a.delegated {
+		return
+	}
+	a.shared.evaluated.Add(1)
+	recordAssertion(a.stats, a.shared.failed.Load() != 0)
+}
+
+// delegate returns the Assert through which an assertion, having counted
+// itself, calls another, such as InDelta calling WithinTolerance, so the
+// call is one assertion with one index. Return a, not the delegate, so that
+// chained assertions are counted.
+func (a *Assert) delegate() *Assert {
+	derived := *a
+	derived.delegated = true
+	return &derived
 }
 
 // markAsFailed atomically marks this assertion chain as failed
 // Thread-safe for concurrent access.
 func (a *Assert) markAsFailed() bool {
-	return atomic.CompareAndSwapInt32(a.failed, 0, 1)
+	if !a.shared.failed.CompareAndSwap(0, 1) {
+		return false
+	}
+	recordFailure(a.stats)
+	return true
+}
+
+// emitFailure completes f with its message msg and delivers it to testingT.
+// With fatal failures enabled it then stops the test.
+func (a *Assert) emitFailure(testingT TestingT, f *Failure, msg string) {
+	testingT.Helper()
+
+	msg = a.completeFailure(f, msg)
+	testingT.Errorf("%s", msg)
+	if a.fatal {
+		testingT.FailNow()
+	}
+}
+
+// completeFailure renders f with its message msg, after creating any
+// attachments, and records it as the chain's failure, returning the final
+// message. It runs whatever the testing context, so attachments reach their
+// handler even when the context does not report failures itself.
+func (a *Assert) completeFailure(f *Failure, msg string) string {
+	if len(a.attachments) > 0 {
+		msg = a.writeAttachments(msg)
+	}
+	f.setText(msg)
+	if a.logger != nil {
+		a.logFailure(f)
+	}
+	if a.fatal && a.crashDump != nil {
+		if path, err := a.writeCrashDump(msg); err != nil {
+			msg += fmt.Sprintf("\n  failed to write crash dump: %v", err)
+		} else {
+			msg += "\n  crash dump: " + path
+		}
+		f.setText(msg)
+	}
+
+	a.shared.mu.Lock()
+	a.shared.failure = *f
+	a.shared.reported = true
+	a.shared.mu.Unlock()
+	return msg
 }
 
 // reportErrorConsistent provides consistent error reporting across all assertion methods
@@ -142,24 +344,140 @@ func (a *Assert) reportErrorConsistent(got, want interface{}, message string) {
 		t.Helper()
 	}
 
+	a.failWith(got, want, true, func() string { return a.gotWantMessage(got, want, message) })
+}
+
+// reportErrorf is reportErrorConsistent with a message formatted as by
+// fmt.Sprintf, only once the failure is reported.
+func (a *Assert) reportErrorf(got, want interface{}, format string, args ...interface{}) {
+	if !a.markAsFailed() {
+		return
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.failWith(got, want, true, func() string { return a.gotWantMessage(got, want, fmt.Sprintf(format, args...)) })
+}
+
+// gotWantMessage builds the failure message for got and want, headed by
+// message.
+func (a *Assert) gotWantMessage(got, want interface{}, message string) string {
 	// Check if both values are strings and use diff for better error messages
-	if gotStr, gotOK := got.(string); gotOK {
+	if gotStr, gotOK := got.(string); gotOK && !a.noDiffs {
 		if wantStr, wantOK := want.(string); wantOK {
-			a.reportStringError(gotStr, wantStr, message)
-			// Call the TestingT interface to actually fail the test
-			if testingT, ok := a.t.(TestingT); ok {
-				testingT.Errorf("%s", a.errorMsg)
+			return a.stringErrorMessage(gotStr, wantStr, message)
+		}
+	}
+
+	// Composite values get a structural diff that elides identical subtrees,
+	// keeping the output proportional to the change rather than the value
+	if structural, ok := a.structuralDiff(got, want); ok {
+		return fmt.Sprintf("%s\n  diff (- got, + want):\n%s", message, structural)
+	}
+
+	// Default error message for non-string types. Values of different types can
+	// render identically, as 5 and 5 for int64 and int, so show the types then
+	opts := a.formatOptions
+	if reflect.TypeOf(got) != reflect.TypeOf(want) {
+		opts.ShowTypes = true
+	}
+	gotText, wantText := formatNumeric(got, opts), formatNumeric(want, opts)
+
+	// Humanised durations are rounded, so 1.0001s and 1.0002s both read as
+	// 1s; show them exactly when rounding hides the difference
+	if gotText == wantText {
+		if gotDuration, ok := got.(time.Duration); ok {
+			if wantDuration, ok := want.(time.Duration); ok {
+				gotText, wantText = gotDuration.String(), wantDuration.String()
 			}
-			return
 		}
 	}
+	return fmt.Sprintf("%s\n  got:  %s\n  want: %s", message, gotText, wantText)
+}
+
+// reportMessageConsistent reports a pre-formatted failure message for assertions
+// whose output does not fit the got/want layout.
+func (a *Assert) reportMessageConsistent(message string) {
+	// Only report the first error (fail-fast chaining)
+	if !a.markAsFailed() {
+		return
+	}
+
+	// Set helper context for better stack traces
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.fail(func() string { return message })
+}
+
+// reportMessagef is reportMessageConsistent with a message formatted as by
+// fmt.Sprintf, only once the failure is reported.
+func (a *Assert) reportMessagef(format string, args ...interface{}) {
+	if !a.markAsFailed() {
+		return
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.fail(func() string { return fmt.Sprintf(format, args...) })
+}
 
-	// Default error message for non-string types
-	a.errorMsg = fmt.Sprintf("%s\n  got:  %#v\n  want: %#v", message, got, want)
-	// Call the TestingT interface to actually fail the test
-	if testingT, ok := a.t.(TestingT); ok {
-		testingT.Errorf("%s", a.errorMsg)
+// fail reports a failure whose message is built by message, once the chain
+// has been marked as failed. The message, with any diff, is built only when it
+// is consumed: at once if the testing context is a TestingT, which reports
+// it, or if completing it has effects (see completesEagerly), and otherwise
+// when Error or LastFailure is first called.
+func (a *Assert) fail(message func() string) {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
 	}
+	a.failWith(nil, nil, false, message)
+}
+
+// failWith is fail for an assertion that may have compared values, got and
+// want, which the chain's Failure records if hasValues is set. Only the call
+// stack is captured until the failure is consumed; naming and locating the
+// assertion from it is left, with building the message, until then.
+func (a *Assert) failWith(got, want interface{}, hasValues bool, message func() string) {
+	a.shared.mu.Lock()
+	a.shared.capture(got, want, hasValues)
+	if t, ok := a.t.(interface{ Name() string }); ok {
+		test := t.Name()
+		a.shared.failure.Test = test
+		message = attributed(message, test, a.shared.failure.Index)
+	}
+	if a.stackTraces {
+		a.shared.failure.Stack = callStack(2)
+		message = withStack(message, a.shared.failure.Stack)
+	}
+	testingT, ok := a.t.(TestingT)
+	if !ok && !a.completesEagerly() {
+		a.shared.reported = true
+		a.shared.pendingMsg = message
+		a.shared.mu.Unlock()
+		return
+	}
+	a.shared.build()
+	f := a.shared.failure
+	a.shared.mu.Unlock()
+
+	if !ok {
+		a.completeFailure(&f, message())
+		return
+	}
+	testingT.Helper()
+	a.emitFailure(testingT, &f, message())
+}
+
+// completesEagerly reports whether a failure against a context that does not
+// report it must still be completed when it happens, rather than when it is
+// read, because completing it has effects: creating attachments, logging a
+// FailureEvent or writing a crash dump.
+func (a *Assert) completesEagerly() bool {
+	return len(a.attachments) > 0 || a.logger != nil || (a.fatal && a.crashDump != nil)
 }
 
 // reportCollectionErrorConsistent provides consistent collection error reporting
@@ -182,15 +500,13 @@ func (a *Assert) reportCollectionErrorConsistent(result diff.CollectionDiffResul
 		errorMsg.WriteString(strings.ReplaceAll(result.Detail, "\n", "\n  "))
 	}
 
-	a.errorMsg = errorMsg.String()
-	// Call the TestingT interface to actually fail the test
-	if testingT, ok := a.t.(TestingT); ok {
-		testingT.Errorf("%s", a.errorMsg)
-	}
+	a.fail(func() string { return errorMsg.String() })
 }
 
 // Equal asserts that two values are equal.
 // Uses fast-path comparison for comparable types, falls back to reflect.DeepEqual.
+// Values of types with an Equal method (see Equaler) or a comparer registered
+// with RegisterComparer are compared with it, wherever they appear.
 // Returns *Assert to enable method chaining.
 //
 // Example:
@@ -215,17 +531,32 @@ func (a *Assert) Equal(got, want interface{}) *Assert {
 		return a
 	}
 
-	// Fast path for comparable types using type assertion
-	if isComparable(got, want) && got == want {
+	if equal, ok := a.floatsWithinTolerance(got, want); ok {
+		if !equal {
+			a.reportErrorf(got, want, "values differ by more than tolerance %s", formatNumeric(a.floatTolerance, a.formatOptions))
+		}
 		return a
 	}
-	if isComparable(got, want) && got != want {
-		a.reportErrorConsistent(got, want, "values differ")
+
+	// Fast path for primitives: a type switch, with no reflection or allocation
+	if equal, ok := primitiveEqual(got, want); ok {
+		if !equal {
+			a.reportErrorConsistent(got, want, "values differ")
+		}
 		return a
 	}
 
-	// Fallback to deep equality
-	if !reflect.DeepEqual(got, want) {
+	// Fast path for comparable types using type assertion, unless the type
+	// defines its own equality
+	if isComparable(got, want) && !equalityFor(reflect.TypeOf(got)).custom {
+		if got != want {
+			a.reportErrorConsistent(got, want, "values differ")
+		}
+		return a
+	}
+
+	// Fallback to deep equality, honouring Equal methods and comparers
+	if !objectsEqual(got, want) {
 		a.reportErrorConsistent(got, want, "values differ")
 	}
 	return a
@@ -233,6 +564,7 @@ func (a *Assert) Equal(got, want interface{}) *Assert {
 
 // NotEqual asserts that two values are not equal.
 // Uses fast-path comparison for comparable types, falls back to reflect.DeepEqual.
+// Custom equality is honoured as by Equal.
 func (a *Assert) NotEqual(got, want interface{}) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
@@ -252,16 +584,31 @@ func (a *Assert) NotEqual(got, want interface{}) *Assert {
 		return a // different nil states = not equal, which is what we want
 	}
 
-	// Fast path for comparable types
-	if isComparable(got, want) {
+	if equal, ok := a.floatsWithinTolerance(got, want); ok {
+		if equal {
+			a.reportErrorf(got, want, "values should not be equal (within tolerance %s)", formatNumeric(a.floatTolerance, a.formatOptions))
+		}
+		return a
+	}
+
+	// Fast path for primitives: a type switch, with no reflection or allocation
+	if equal, ok := primitiveEqual(got, want); ok {
+		if equal {
+			a.reportErrorConsistent(got, want, "values should not be equal")
+		}
+		return a
+	}
+
+	// Fast path for comparable types, unless the type defines its own equality
+	if isComparable(got, want) && !equalityFor(reflect.TypeOf(got)).custom {
 		if got == want {
 			a.reportErrorConsistent(got, want, "values should not be equal")
 		}
 		return a
 	}
 
-	// Fallback to deep equality
-	if reflect.DeepEqual(got, want) {
+	// Fallback to deep equality, honouring Equal methods and comparers
+	if objectsEqual(got, want) {
 		a.reportErrorConsistent(got, want, "values should not be equal")
 	}
 	return a
@@ -307,6 +654,25 @@ func (a *Assert) Same(got, want interface{}) *Assert {
 	return a
 }
 
+// NotSame asserts that two values do not have the same pointer identity.
+// Values of different types, or equal values that are not pointers to the
+// same thing, pass.
+func (a *Assert) NotSame(got, want interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if got == want {
+		a.reportMessagef("expected different pointer identities\n  both: %s", formatValue(got, a.formatOptions))
+	}
+	return a
+}
+
 // True asserts that a boolean condition is true.
 // Returns *Assert to enable method chaining.
 //
@@ -346,25 +712,8 @@ func (a *Assert) False(condition bool) *Assert {
 	return a
 }
 
-// reportCollectionError provides enhanced error messages for collection comparisons using diff infrastructure
-func (a *Assert) reportCollectionError(result diff.CollectionDiffResult) {
-	// Note: failure marking is handled by the caller
-
-	var errorMsg strings.Builder
-	errorMsg.WriteString(result.Summary)
-
-	if result.Detail != "" {
-		errorMsg.WriteString("\n  ")
-		errorMsg.WriteString(strings.ReplaceAll(result.Detail, "\n", "\n  "))
-	}
-
-	a.errorMsg = errorMsg.String()
-}
-
-// reportStringError provides enhanced error messages for string comparisons using diff infrastructure
-func (a *Assert) reportStringError(got, want string, message string) {
-	// Note: failure marking is handled by the caller (reportError)
-
+// stringErrorMessage builds enhanced error messages for string comparisons using diff infrastructure
+func (a *Assert) stringErrorMessage(got, want string, message string) string {
 	// Choose appropriate diff function based on string characteristics
 	var result diff.DiffResult
 
@@ -379,13 +728,18 @@ func (a *Assert) reportStringError(got, want string, message string) {
 
 		var errorMsg strings.Builder
 		errorMsg.WriteString(message)
-		errorMsg.WriteString(fmt.Sprintf("\n  got:  %q", got))
-		errorMsg.WriteString(fmt.Sprintf("\n  want: %q", want))
+		errorMsg.WriteString("\n  got:  " + formatString(got, a.formatOptions))
+		errorMsg.WriteString("\n  want: " + formatString(want, a.formatOptions))
 
 		if enhanced.HasDiff && enhanced.LineNumber != nil {
 			errorMsg.WriteString(fmt.Sprintf("\n  difference at line %d", *enhanced.LineNumber))
 		}
 
+		// Summarise every hunk so the full set of changes is visible in one failure
+		if len(enhanced.Hunks) > 1 {
+			errorMsg.WriteString(fmt.Sprintf("\n  %d hunks differ at lines %s", len(enhanced.Hunks)+enhanced.OmittedHunks, formatHunkLines(enhanced.Hunks, enhanced.OmittedHunks)))
+		}
+
 		// Choose diff format based on configuration and content complexity
 		if enhanced.ContextLines != "" {
 			contextLines := strings.Split(enhanced.ContextLines, "\n")
@@ -405,7 +759,8 @@ func (a *Assert) reportStringError(got, want string, message string) {
 						diffLineCount++
 					}
 				}
-				useUnified = diffLineCount > 4
+				// Multiple hunks need unified output, as context only covers the first
+				useUnified = diffLineCount > 4 || len(enhanced.Hunks) > 1
 			}
 
 			if useUnified && enhanced.UnifiedDiff != "" {
@@ -427,8 +782,7 @@ func (a *Assert) reportStringError(got, want string, message string) {
 			}
 		}
 
-		a.errorMsg = strings.TrimSuffix(errorMsg.String(), "\n")
-		return
+		return strings.TrimSuffix(errorMsg.String(), "\n")
 	} else if hasUnicodeChars(got) || hasUnicodeChars(want) {
 		// Use Unicode diff for strings with multi-byte characters
 		result = diff.UnicodeStringDiff(got, want)
@@ -460,10 +814,68 @@ func (a *Assert) reportStringError(got, want string, message string) {
 	}
 
 	// Always show the full values for reference
-	errorMsg.WriteString(fmt.Sprintf("  got:  %q\n", got))
-	errorMsg.WriteString(fmt.Sprintf("  want: %q", want))
+	errorMsg.WriteString("  got:  " + formatString(got, a.formatOptions) + "\n")
+	errorMsg.WriteString("  want: " + formatString(want, a.formatOptions))
+
+	return errorMsg.String()
+}
 
-	a.errorMsg = errorMsg.String()
+// structuralDiffMinWidth is the rendered width below which composite values are
+// shown in full: values that fit on one line read best side by side.
+const structuralDiffMinWidth = 80
+
+// maxStructuralDiffChanges bounds the differing values a structural diff
+// renders; the rest are counted.
+const maxStructuralDiffChanges = 50
+
+// structuralDiff returns an indented diff.ValueDiff of got and want when they
+// are large composite values of the same type and the diff elides at least one
+// identical subtree; otherwise the got/want layout is clearer. Changed values
+// are rendered within the format limits. It is skipped when diffs are
+// disabled.
+func (a *Assert) structuralDiff(got, want interface{}) (string, bool) {
+	if a.noDiffs || got == nil || want == nil || reflect.TypeOf(got) != reflect.TypeOf(want) {
+		return "", false
+	}
+
+	t := reflect.TypeOf(got)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+	default:
+		return "", false
+	}
+	if !exceedsWidth(reflect.ValueOf(got), structuralDiffMinWidth-1) && !exceedsWidth(reflect.ValueOf(want), structuralDiffMinWidth-1) {
+		return "", false
+	}
+
+	result := diff.ValueDiffWith(got, want, diff.ValueDiffOptions{
+		Format:     func(v reflect.Value) string { return formatReflectValue(v, a.formatOptions) },
+		MaxChanges: maxStructuralDiffChanges,
+	})
+	if !result.HasDiff || result.Elided == 0 {
+		return "", false
+	}
+	return "  " + strings.ReplaceAll(result.Diff, "\n", "\n  "), true
+}
+
+// formatHunkLines renders the got-side line ranges of each hunk, e.g. "2, 7-8, 15".
+func formatHunkLines(hunks []diff.Hunk, omitted int) string {
+	ranges := make([]string, 0, len(hunks))
+	for _, hunk := range hunks {
+		if hunk.GotCount > 1 {
+			ranges = append(ranges, fmt.Sprintf("%d-%d", hunk.GotStart, hunk.GotStart+hunk.GotCount-1))
+		} else {
+			ranges = append(ranges, fmt.Sprintf("%d", hunk.GotStart))
+		}
+	}
+	result := strings.Join(ranges, ", ")
+	if omitted > 0 {
+		result += fmt.Sprintf(" (and %d more)", omitted)
+	}
+	return result
 }
 
 // hasUnicodeChars checks if a string contains non-ASCII characters
@@ -476,15 +888,35 @@ func hasUnicodeChars(s string) bool {
 	return false
 }
 
-// Error returns the error message if the assertion failed.
+// Error returns the message of the chain's first failure, or "" if no
+// assertion in the chain has failed. Every Assert derived from the chain with
+// With returns the same message. When the testing context does not report
+// failures itself, the message is built on the first call.
 func (a *Assert) Error() string {
-	return a.errorMsg
+	a.shared.mu.Lock()
+	defer a.shared.mu.Unlock()
+
+	if !a.shared.reported {
+		return ""
+	}
+	a.shared.build()
+	return a.shared.failure.String()
+}
+
+// Unwrap returns the chain's first failure as an error, or nil if no assertion
+// in the chain has failed, for helpers that hand a chain's outcome back to
+// their caller as an error.
+func (a *Assert) Unwrap() error {
+	if !a.HasFailed() {
+		return nil
+	}
+	return errors.New(a.Error())
 }
 
 // HasFailed returns true if any assertion in the chain has failed.
 // This enables fail-fast chaining behaviour.
 func (a *Assert) HasFailed() bool {
-	return atomic.LoadInt32(a.failed) != 0
+	return a.shared.failed.Load() != 0
 }
 
 // Nil asserts that a value is nil.
@@ -565,35 +997,60 @@ func (a *Assert) Contains(container, item interface{}) *Assert {
 	return a
 }
 
-// Greater asserts that the first value is greater than the second.
+// NotContains asserts that container does not contain item. It accepts the
+// same containers as Contains: slices, arrays, map keys and strings, searched
+// for substrings, runes or bytes. An unsupported container or item is reported
+// rather than passing.
 // Returns *Assert to enable method chaining.
 //
 // Example:
 //
-//	assert.Greater(response.StatusCode, 199).Less(response.StatusCode, 300)
-func (a *Assert) Greater(v1, v2 float64) *Assert {
+//	assert.NotContains(user.Roles, "admin").NotContains(logLine, "password")
+func (a *Assert) NotContains(container, item interface{}) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
 
-	if v1 <= v2 {
-		a.reportErrorConsistent(v1, v2, "expected to be greater")
+	result := diff.CollectionContainsDiff(container, item)
+	switch {
+	case result.Invalid:
+		a.reportCollectionErrorConsistent(result)
+	case !result.HasDiff:
+		a.reportMessagef("expected %s not to contain item\n  item:      %s\n  container: %s",
+			result.CollectionType, formatValue(item, a.formatOptions), formatValue(container, a.formatOptions))
 	}
 	return a
 }
 
+// Greater asserts that the first value is greater than the second.
+// Returns *Assert to enable method chaining.
+//
+// Deprecated: converting to float64 loses precision for large integers and
+// excludes strings and durations. Use the generic function Greater instead:
+//
+//	assertions.Greater(assert, response.StatusCode, 199)
+func (a *Assert) Greater(v1, v2 float64) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return Greater(a, v1, v2)
+}
+
 // Less asserts that the first value is less than the second.
+//
+// Deprecated: converting to float64 loses precision for large integers and
+// excludes strings and durations. Use the generic function Less instead.
 func (a *Assert) Less(v1, v2 float64) *Assert {
-	// Fail-fast: if already failed, return immediately
-	if a.shouldSkipDueToFailure() {
-		return a
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
 	}
 
-	if v1 >= v2 {
-		a.reportErrorConsistent(v1, v2, "expected to be less")
-	}
-	return a
+	return Less(a, v1, v2)
 }
 
 // HasPrefix asserts that a string starts with a certain substring.
@@ -629,6 +1086,7 @@ func (a *Assert) HasSuffix(s, suffix string) *Assert {
 
 // WithinTolerance asserts that the difference between two numeric values is within a certain tolerance.
 // This is useful for floating-point comparisons where exact equality is not reliable.
+// NaN is within no tolerance of anything, itself included.
 // Returns *Assert to enable method chaining.
 //
 // Example:
@@ -640,8 +1098,8 @@ func (a *Assert) WithinTolerance(expected, actual, tolerance float64) *Assert {
 		return a
 	}
 
-	if math.Abs(expected-actual) > tolerance {
-		a.reportErrorConsistent(expected, actual, fmt.Sprintf("expected difference to be within tolerance %v", tolerance))
+	if !withinDelta(expected, actual, tolerance) {
+		a.reportErrorf(expected, actual, "expected difference to be within tolerance %s", formatNumeric(tolerance, a.formatOptions))
 	}
 	return a
 }
@@ -649,7 +1107,10 @@ func (a *Assert) WithinTolerance(expected, actual, tolerance float64) *Assert {
 // InDelta is an alias for WithinTolerance for backward compatibility.
 // Deprecated: Use WithinTolerance for better readability.
 func (a *Assert) InDelta(expected, actual, delta float64) *Assert {
-	return a.WithinTolerance(expected, actual, delta)
+	a.countAssertion()
+
+	a.delegate().WithinTolerance(expected, actual, delta)
+	return a
 }
 
 // WithinPercentage asserts that the difference between two numeric values is within a certain percentage.
@@ -665,8 +1126,8 @@ func (a *Assert) WithinPercentage(expected, actual, percentage float64) *Assert
 		return a
 	}
 
-	if math.Abs((expected-actual)/((expected+actual)/2)) > percentage {
-		a.reportErrorConsistent(expected, actual, fmt.Sprintf("expected difference to be within %.1f percent", percentage*100))
+	if expected != actual && !(math.Abs((expected-actual)/((expected+actual)/2)) <= percentage) {
+		a.reportErrorf(expected, actual, "expected difference to be within %.1f percent", percentage*100)
 	}
 	return a
 }
@@ -674,22 +1135,59 @@ func (a *Assert) WithinPercentage(expected, actual, percentage float64) *Assert
 // InEpsilon is an alias for WithinPercentage for backward compatibility.
 // Deprecated: Use WithinPercentage for better readability.
 func (a *Assert) InEpsilon(expected, actual, epsilon float64) *Assert {
-	return a.WithinPercentage(expected, actual, epsilon)
+	a.countAssertion()
+
+	a.delegate().WithinPercentage(expected, actual, epsilon)
+	return a
 }
 
 // Regexp asserts that a string matches a regular expression.
+// Invalid patterns are reported as such rather than as a failed match.
 func (a *Assert) Regexp(pattern, str string) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
 
-	if matched, _ := regexp.MatchString(pattern, str); !matched {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	re, err := a.compileRegexp(pattern)
+	if err != nil {
+		a.reportInvalidPattern(pattern, err)
+		return a
+	}
+	if !re.MatchString(str) {
 		a.reportErrorConsistent(pattern, str, "expected to match regular expression")
 	}
 	return a
 }
 
+// NotRegexp asserts that a string does not match a regular expression.
+// Invalid patterns are reported as such rather than passing.
+func (a *Assert) NotRegexp(pattern, str string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	re, err := a.compileRegexp(pattern)
+	if err != nil {
+		a.reportInvalidPattern(pattern, err)
+		return a
+	}
+	if loc := re.FindStringIndex(str); loc != nil {
+		a.reportMessagef("expected not to match regular expression\n  pattern: %s\n  string:  %s\n  match:   %s",
+			pattern, formatString(str, a.formatOptions), formatString(str[loc[0]:loc[1]], a.formatOptions))
+	}
+	return a
+}
+
 // NoError asserts that a function call returns no error.
 // Returns *Assert to enable method chaining.
 //
@@ -722,6 +1220,15 @@ func (a *Assert) ErrorType(expected, actual error) *Assert {
 		t.Helper()
 	}
 
+	if expected == nil {
+		a.reportMessagef("cannot check error type against a nil expected error")
+		return a
+	}
+	if actual == nil {
+		a.reportMessagef("expected an error of type %T, got nil", expected)
+		return a
+	}
+
 	expectedType := reflect.TypeOf(expected)
 	actualType := reflect.TypeOf(actual)
 
@@ -767,6 +1274,24 @@ func (a *Assert) ErrorIs(err, target error) *Assert {
 	return a
 }
 
+// NotErrorIs asserts that no error in err's chain matches target, using
+// errors.Is. A nil err passes.
+func (a *Assert) NotErrorIs(err, target error) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if errors.Is(err, target) {
+		a.reportMessagef("expected error not to match target\n  error:  %s\n  target: %s",
+			formatValue(err, a.formatOptions), formatValue(target, a.formatOptions))
+	}
+	return a
+}
+
 // ErrorAs asserts that an error can be assigned to a target type using errors.As.
 // This follows Go 1.13+ error wrapping patterns.
 func (a *Assert) ErrorAs(err error, target interface{}) *Assert {
@@ -778,6 +1303,10 @@ func (a *Assert) ErrorAs(err error, target interface{}) *Assert {
 		t.Helper()
 	}
 
+	if targetType := reflect.TypeOf(target); targetType == nil || targetType.Kind() != reflect.Pointer || reflect.ValueOf(target).IsNil() {
+		a.reportMessagef("ErrorAs target must be a non-nil pointer\n  got: %T", target)
+		return a
+	}
 	if !errors.As(err, target) {
 		a.reportErrorConsistent(reflect.TypeOf(target).Elem(), err, "expected error to be assignable to target type")
 	}
@@ -841,160 +1370,332 @@ func (a *Assert) ErrorMatches(err error, pattern string) *Assert {
 		}
 		// Use direct error message format to avoid string diff confusion
 		// Show raw pattern (no quotes) for better readability
-		a.errorMsg = fmt.Sprintf("expected error message to match pattern\n  pattern: %s\n  error:   %q", pattern, errorMessage)
-		// Call the TestingT interface to actually fail the test
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string {
+			return fmt.Sprintf("expected error message to match pattern\n  pattern: %s\n  error:   %q", pattern, errorMessage)
+		})
 	}
 	return a
 }
 
-// IsEmpty asserts that a given array, slice, map, or string is empty.
+// IsEmpty asserts that a given array, slice, map, channel or string is empty.
+//
+// Deprecated: use Empty, which it calls.
 func (a *Assert) IsEmpty(value interface{}) *Assert {
+	a.countAssertion()
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	a.delegate().Empty(value)
+	return a
+}
+
+// IsNotEmpty asserts that a given array, slice, map, channel or string is
+// not empty.
+//
+// Deprecated: use NotEmpty, which it calls.
+func (a *Assert) IsNotEmpty(value interface{}) *Assert {
+	a.countAssertion()
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	a.delegate().NotEmpty(value)
+	return a
+}
+
+// Len asserts that a container has the expected length.
+// Supports strings, slices, arrays, maps, and channels.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.Len(users, 3).Contains(users[0].Email, "@").True(len(users) > 0)
+func (a *Assert) Len(container interface{}, expectedLen int) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	result := diff.CollectionLenDiff(container, expectedLen)
+	if result.HasDiff {
+		a.reportCollectionErrorConsistent(result)
+	}
+	return a
+}
+
+// LenGreaterThan asserts that a container has more than n elements.
+// It accepts the same containers as Len. Returns *Assert to enable method
+// chaining.
+//
+// Example:
+//
+//	assert.LenGreaterThan(results, 0)
+func (a *Assert) LenGreaterThan(container interface{}, n int) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.lenMatches(container, func(length int) bool { return length > n }, fmt.Sprintf("length greater than %d", n))
+	return a
+}
+
+// LenLessThan asserts that a container has fewer than n elements.
+// It accepts the same containers as Len. Returns *Assert to enable method
+// chaining.
+//
+// Example:
+//
+//	assert.LenLessThan(page.Items, 51)
+func (a *Assert) LenLessThan(container interface{}, n int) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.lenMatches(container, func(length int) bool { return length < n }, fmt.Sprintf("length less than %d", n))
+	return a
+}
+
+// LenBetween asserts that a container has between min and max elements,
+// inclusive. It accepts the same containers as Len. Returns *Assert to
+// enable method chaining.
+//
+// Example:
+//
+//	assert.LenBetween(password, 12, 64)
+func (a *Assert) LenBetween(container interface{}, min, max int) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if min > max {
+		a.reportMessagef("LenBetween needs min <= max, got min %d and max %d", min, max)
+		return a
+	}
+	a.lenMatches(container, func(length int) bool { return length >= min && length <= max },
+		fmt.Sprintf("length between %d and %d", min, max))
+	return a
+}
+
+// Empty asserts that a container has no elements. It accepts the same
+// containers as Len, and a nil slice, map or channel is empty.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.Empty(queue.Pending())
+func (a *Assert) Empty(container interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.lenMatches(container, func(length int) bool { return length == 0 }, "empty")
+	return a
+}
+
+// NotEmpty asserts that a container has at least one element. It accepts
+// the same containers as Len. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.NotEmpty(resp.Header.Get("ETag"))
+func (a *Assert) NotEmpty(container interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.lenMatches(container, func(length int) bool { return length > 0 }, "not empty")
+	return a
+}
+
+// lenMatches reports a failure, with a preview of the container's contents,
+// unless its length satisfies the bound described by want.
+func (a *Assert) lenMatches(container interface{}, satisfied func(length int) bool, want string) {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
 
-	valueType := reflect.TypeOf(value)
-	switch valueType.Kind() {
-	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
-		if reflect.ValueOf(value).Len() != 0 {
-			a.reportErrorConsistent(0, reflect.ValueOf(value).Len(), "expected to be empty")
-		}
-	default:
-		a.reportErrorConsistent(nil, value, "invalid type for IsEmpty")
+	if result := diff.CollectionLenMatch(container, satisfied, want); result.HasDiff {
+		a.reportCollectionErrorConsistent(result)
 	}
-	return a
 }
 
-// IsNotEmpty asserts that a given array, slice, map, or string is not empty.
-func (a *Assert) IsNotEmpty(value interface{}) *Assert {
+// Implements asserts that an object implements a certain interface, given
+// as a nil pointer to it, such as (*io.Reader)(nil). The generic function
+// Implements[io.Reader] needs no such pointer.
+//
+//funcgen:skip
+func (a *Assert) Implements(object, interfaceObj interface{}) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
 
-	valueType := reflect.TypeOf(value)
-	switch valueType.Kind() {
-	case reflect.Slice, reflect.Array, reflect.Map, reflect.String:
-		if reflect.ValueOf(value).Len() == 0 {
-			a.reportErrorConsistent("not empty", reflect.ValueOf(value).Len(), "expected to be not empty")
-		}
-	default:
-		a.reportErrorConsistent(nil, value, "invalid type for IsNotEmpty")
+	interfaceType, ok := a.interfaceTypeOf(interfaceObj)
+	if !ok || a.rejectNil(object, "interface implementation") {
+		return a
+	}
+
+	objectType := reflect.TypeOf(object)
+	if !objectType.Implements(interfaceType) {
+		a.reportErrorConsistent(interfaceType, objectType, "expected to implement interface")
 	}
 	return a
 }
 
-// Len asserts that a container has the expected length.
-// Supports strings, slices, arrays, maps, and channels.
-// Returns *Assert to enable method chaining.
-//
-// Example:
+// NotImplements asserts that an object does not implement an interface,
+// given as a pointer to it as for Implements:
 //
-//	assert.Len(users, 3).Contains(users[0].Email, "@").True(len(users) > 0)
-func (a *Assert) Len(container interface{}, expectedLen int) *Assert {
+//	assert.NotImplements(cfg, (*io.Closer)(nil))
+func (a *Assert) NotImplements(object, interfaceObj interface{}) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
-	if t, ok := a.t.(interface{ Helper() }); ok {
-		t.Helper()
+
+	interfaceType, ok := a.interfaceTypeOf(interfaceObj)
+	if !ok || a.rejectNil(object, "interface implementation") {
+		return a
 	}
 
-	result := diff.CollectionLenDiff(container, expectedLen)
-	if result.HasDiff {
-		a.reportCollectionErrorConsistent(result)
+	if objectType := reflect.TypeOf(object); objectType.Implements(interfaceType) {
+		a.reportMessagef("expected not to implement interface\n  type:      %s\n  interface: %s", objectType, interfaceType)
 	}
 	return a
 }
 
-// Implements asserts that an object implements a certain interface.
-func (a *Assert) Implements(object, interfaceObj interface{}) *Assert {
+// IsZero asserts that value is the zero value of its type, for any type:
+// an empty string, a nil pointer, slice or map, a struct with no field set.
+// Numbers are checked without reflection, and a time.Time by its IsZero
+// method, so a zero instant in any location counts. A nil interface is zero.
+func (a *Assert) IsZero(value interface{}) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
 
-	objectType := reflect.TypeOf(object)
-	interfaceType := reflect.TypeOf(interfaceObj).Elem()
-	if !objectType.Implements(interfaceType) {
-		a.reportErrorConsistent(interfaceType, objectType, "expected to implement interface")
+	if !isZeroValue(value) {
+		a.reportErrorConsistent(reflect.Zero(reflect.TypeOf(value)).Interface(), value, "expected to be zero")
 	}
 	return a
 }
 
-// IsZero asserts that a given numeric value or time.Time is zero.
-func (a *Assert) IsZero(value interface{}) *Assert {
+// IsNotZero asserts that value is not the zero value of its type, for any
+// type, with the same fast paths as IsZero.
+func (a *Assert) IsNotZero(value interface{}) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
 
+	if isZeroValue(value) {
+		a.reportErrorConsistent("not zero", value, "expected to be not zero")
+	}
+	return a
+}
+
+// isZeroValue reports whether value is nil or the zero value of its type.
+// Numbers, strings and booleans are checked with a type switch, and a
+// time.Time with its IsZero method; anything else uses reflection.
+func isZeroValue(value interface{}) bool {
 	switch v := value.(type) {
-	case int, int8, int16, int32, int64:
-		if v != 0 {
-			a.reportErrorConsistent(0, v, "expected to be zero")
-		}
-	case uint, uint8, uint16, uint32, uint64:
-		if v != 0 {
-			a.reportErrorConsistent(0, v, "expected to be zero")
-		}
-	case float32, float64:
-		if v != 0 {
-			a.reportErrorConsistent(0, v, "expected to be zero")
-		}
+	case nil:
+		return true
+	case int:
+		return v == 0
+	case int8:
+		return v == 0
+	case int16:
+		return v == 0
+	case int32:
+		return v == 0
+	case int64:
+		return v == 0
+	case uint:
+		return v == 0
+	case uint8:
+		return v == 0
+	case uint16:
+		return v == 0
+	case uint32:
+		return v == 0
+	case uint64:
+		return v == 0
+	case float32:
+		return v == 0
+	case float64:
+		return v == 0
+	case string:
+		return v == ""
+	case bool:
+		return !v
 	case time.Time:
-		if !v.IsZero() {
-			a.reportErrorConsistent(time.Time{}, v, "expected to be zero")
-		}
-	default:
-		a.reportErrorConsistent(nil, value, "invalid type for IsZero")
+		return v.IsZero()
 	}
-	return a
+	return reflect.ValueOf(value).IsZero()
 }
 
-// IsNotZero asserts that a given numeric value or time.Time is not zero.
-func (a *Assert) IsNotZero(value interface{}) *Assert {
+// NotZero asserts that value is not the zero value of its type, for any type:
+// a non-empty string, a non-nil pointer, a struct with any field set. A nil
+// interface is zero. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.NotZero(order.ID).NotZero(order.CreatedAt)
+func (a *Assert) NotZero(value interface{}) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
 
-	switch v := value.(type) {
-	case int, int8, int16, int32, int64:
-		if v == 0 {
-			a.reportErrorConsistent("not zero", v, "expected to be not zero")
-		}
-	case uint, uint8, uint16, uint32, uint64:
-		if v == 0 {
-			a.reportErrorConsistent("not zero", v, "expected to be not zero")
-		}
-	case float32, float64:
-		if v == 0 {
-			a.reportErrorConsistent("not zero", v, "expected to be not zero")
-		}
-	case time.Time:
-		if v.IsZero() {
-			a.reportErrorConsistent("not zero", v, "expected to be not zero")
-		}
-	default:
-		a.reportErrorConsistent(nil, value, "invalid type for IsNotZero")
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if isZeroValue(value) {
+		a.reportMessagef("expected a non-zero value\n  got: %s (the zero value of %T)", formatValue(value, a.formatOptions), value)
 	}
 	return a
 }
 
-// IsWithinDuration asserts that a given time.Time is within a certain duration from another time.Time.
+// IsWithinDuration asserts that a given time.Time is within a certain duration from another time.Time,
+// in either direction.
 func (a *Assert) IsWithinDuration(t1, t2 time.Time, d time.Duration) *Assert {
 	// Fail-fast: if already failed, return immediately
 	if a.shouldSkipDueToFailure() {
 		return a
 	}
 
-	if abs := t1.Sub(t2); abs > d {
-		a.reportErrorConsistent(d, abs, "expected to be within duration")
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if delta := t1.Sub(t2); delta > d || delta < -d {
+		a.reportMessagef("expected times to be within %s\n  got:        %s\n  want:       %s\n  difference: %s",
+			d, formatTime(t1), formatTime(t2), delta)
 	}
 	return a
 }
@@ -1006,7 +1707,12 @@ func (a *Assert) MatchesPattern(pattern, s string) *Assert {
 		return a
 	}
 
-	if matched, _ := regexp.MatchString(pattern, s); !matched {
+	re, err := a.compileRegexp(pattern)
+	if err != nil {
+		a.reportInvalidPattern(pattern, err)
+		return a
+	}
+	if !re.MatchString(s) {
 		a.reportErrorConsistent(pattern, s, "expected to match pattern")
 	}
 	return a
@@ -1095,37 +1801,35 @@ func (a *Assert) SliceDiff(got, want []int) *Assert {
 		if !a.markAsFailed() {
 			return a
 		}
-		a.errorMsg = fmt.Sprintf("slices differ in length\n  got: %d\n  want: %d", len(got), len(want))
-		// Call the TestingT interface to actually fail the test
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string {
+			return fmt.Sprintf("slices differ in length\n  got: %d\n  want: %d", len(got), len(want))
+		})
 		return a
 	}
 
-	// Find first difference
+	// Find differences, up to the difference budget
+	differences := a.newDifferenceList()
 	for i, gotVal := range got {
 		if gotVal != want[i] {
-			// Only report first failure (fail-fast chaining)
-			if !a.markAsFailed() {
-				return a
-			}
-			a.errorMsg = fmt.Sprintf("slices differ at index %d\n  got: %d\n  want: %d", i, gotVal, want[i])
-			// Call the TestingT interface to actually fail the test
-			if testingT, ok := a.t.(TestingT); ok {
-				testingT.Errorf("%s", a.errorMsg)
+			wantVal := want[i]
+			more := differences.add(" at ", func() string {
+				return fmt.Sprintf("index %d\n  got: %d\n  want: %d", i, gotVal, wantVal)
+			})
+			if !more {
+				break
 			}
-			return a
 		}
 	}
 
-	// Slices are identical - no error
+	a.reportDifferences(differences, "slices", "index", "indices")
 	return a
 }
 
 // SliceDiffGeneric asserts that two slices of any comparable type are equal with enhanced diff output.
 // Provides detailed context showing which elements differ and their positions.
 func (a *Assert) SliceDiffGeneric(got, want any) {
+	a.countAssertion()
+
 	if t, ok := a.t.(interface{ Helper() }); ok {
 		t.Helper()
 	}
@@ -1139,22 +1843,14 @@ func (a *Assert) SliceDiffGeneric(got, want any) {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("got is not a slice: %T", got)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("got is not a slice: %T", got) })
 		return
 	}
 	if wantReflect.Kind() != reflect.Slice {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("want is not a slice: %T", want)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("want is not a slice: %T", want) })
 		return
 	}
 
@@ -1165,38 +1861,34 @@ func (a *Assert) SliceDiffGeneric(got, want any) {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("slices differ in length\n  got: %d\n  want: %d", gotLen, wantLen)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("slices differ in length\n  got: %d\n  want: %d", gotLen, wantLen) })
 		return
 	}
 
-	// Find first difference
+	// Find differences, up to the difference budget
+	differences := a.newDifferenceList()
 	for i := 0; i < gotLen; i++ {
 		gotVal := gotReflect.Index(i).Interface()
 		wantVal := wantReflect.Index(i).Interface()
 
-		if !reflect.DeepEqual(gotVal, wantVal) {
-			if !a.markAsFailed() {
-				return
-			}
-			a.errorMsg = fmt.Sprintf("slices differ at index %d\n  got: %v\n  want: %v", i, gotVal, wantVal)
-			if testingT, ok := a.t.(TestingT); ok {
-				testingT.Helper()
-				testingT.Errorf("%s", a.errorMsg)
+		if !objectsEqual(gotVal, wantVal) {
+			more := differences.add(" at ", func() string {
+				return fmt.Sprintf("index %d\n  got: %v\n  want: %v", i, gotVal, wantVal)
+			})
+			if !more {
+				break
 			}
-			return
 		}
 	}
 
-	// Slices are identical - no error
+	a.reportDifferences(differences, "slices", "index", "indices")
 }
 
 // MapDiff asserts that two maps are equal with enhanced diff output for failures.
 // Provides detailed context showing missing keys, extra keys, and value differences.
 func (a *Assert) MapDiff(got, want any) {
+	a.countAssertion()
+
 	if t, ok := a.t.(interface{ Helper() }); ok {
 		t.Helper()
 	}
@@ -1210,83 +1902,79 @@ func (a *Assert) MapDiff(got, want any) {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("got is not a map: %T", got)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("got is not a map: %T", got) })
 		return
 	}
 	if wantReflect.Kind() != reflect.Map {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("want is not a map: %T", want)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("want is not a map: %T", want) })
 		return
 	}
 
+	a.reportDifferences(a.mapDifferences(gotReflect, wantReflect), "maps", "key", "keys")
+}
+
+// mapDifferences finds the missing keys, then the unexpected keys, then the
+// differing values of two maps, up to the difference budget. Keys are taken
+// in sorted order so the reported keys are the same on every run.
+func (a *Assert) mapDifferences(gotReflect, wantReflect reflect.Value) *differenceList {
+	differences := a.newDifferenceList()
+
 	// Check for missing keys (in want but not in got)
-	wantKeys := wantReflect.MapKeys()
+	wantKeys := diff.SortedKeys(wantReflect)
 	for _, wantKey := range wantKeys {
 		if !gotReflect.MapIndex(wantKey).IsValid() {
-			if !a.markAsFailed() {
-				return
-			}
 			wantValue := wantReflect.MapIndex(wantKey).Interface()
-			a.errorMsg = fmt.Sprintf("maps differ: missing key %q\n  expected value: %v", wantKey.Interface(), wantValue)
-			if testingT, ok := a.t.(TestingT); ok {
-				testingT.Helper()
-				testingT.Errorf("%s", a.errorMsg)
+			more := differences.add(": ", func() string {
+				return fmt.Sprintf("missing key %q\n  expected value: %v", wantKey.Interface(), wantValue)
+			})
+			if !more {
+				return differences
 			}
-			return
 		}
 	}
 
 	// Check for extra keys (in got but not in want)
-	gotKeys := gotReflect.MapKeys()
-	for _, gotKey := range gotKeys {
+	for _, gotKey := range diff.SortedKeys(gotReflect) {
 		if !wantReflect.MapIndex(gotKey).IsValid() {
-			if !a.markAsFailed() {
-				return
-			}
 			gotValue := gotReflect.MapIndex(gotKey).Interface()
-			a.errorMsg = fmt.Sprintf("maps differ: unexpected key %q\n  got value: %v", gotKey.Interface(), gotValue)
-			if testingT, ok := a.t.(TestingT); ok {
-				testingT.Helper()
-				testingT.Errorf("%s", a.errorMsg)
+			more := differences.add(": ", func() string {
+				return fmt.Sprintf("unexpected key %q\n  got value: %v", gotKey.Interface(), gotValue)
+			})
+			if !more {
+				return differences
 			}
-			return
 		}
 	}
 
 	// Check for value differences
 	for _, key := range wantKeys {
-		gotValue := gotReflect.MapIndex(key).Interface()
+		gotMapValue := gotReflect.MapIndex(key)
+		if !gotMapValue.IsValid() {
+			continue // Already reported as missing
+		}
+		gotValue := gotMapValue.Interface()
 		wantValue := wantReflect.MapIndex(key).Interface()
 
-		if !reflect.DeepEqual(gotValue, wantValue) {
-			if !a.markAsFailed() {
-				return
+		if !objectsEqual(gotValue, wantValue) {
+			more := differences.add(" at ", func() string {
+				return fmt.Sprintf("key %q\n  got: %v\n  want: %v", key.Interface(), gotValue, wantValue)
+			})
+			if !more {
+				return differences
 			}
-			a.errorMsg = fmt.Sprintf("maps differ at key %q\n  got: %v\n  want: %v", key.Interface(), gotValue, wantValue)
-			if testingT, ok := a.t.(TestingT); ok {
-				testingT.Helper()
-				testingT.Errorf("%s", a.errorMsg)
-			}
-			return
 		}
 	}
-
-	// Maps are identical - no error
+	return differences
 }
 
 // StructDiff asserts that two structs are equal with enhanced diff output for failures.
 // Provides detailed context showing which fields differ and their values.
 func (a *Assert) StructDiff(got, want any) {
+	a.countAssertion()
+
 	if t, ok := a.t.(interface{ Helper() }); ok {
 		t.Helper()
 	}
@@ -1300,22 +1988,14 @@ func (a *Assert) StructDiff(got, want any) {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("got is not a struct: %T", got)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("got is not a struct: %T", got) })
 		return
 	}
 	if wantReflect.Kind() != reflect.Struct {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("want is not a struct: %T", want)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("want is not a struct: %T", want) })
 		return
 	}
 
@@ -1326,41 +2006,29 @@ func (a *Assert) StructDiff(got, want any) {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("struct types differ: got %s, want %s", gotType, wantType)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("struct types differ: got %s, want %s", gotType, wantType) })
 		return
 	}
 
-	// Compare each field
-	numFields := gotType.NumField()
-	for i := 0; i < numFields; i++ {
-		field := gotType.Field(i)
-
-		// Skip unexported fields
-		if !field.IsExported() {
-			continue
-		}
-
+	// Compare each exported field, with the type's field metadata cached,
+	// up to the difference budget
+	differences := a.newDifferenceList()
+	info := structInfoFor(gotType)
+	for _, i := range info.exported {
 		gotFieldValue := gotReflect.Field(i).Interface()
 		wantFieldValue := wantReflect.Field(i).Interface()
 
-		if !reflect.DeepEqual(gotFieldValue, wantFieldValue) {
-			if !a.markAsFailed() {
-				return
-			}
-			a.errorMsg = fmt.Sprintf("structs differ at field %q\n  got: %v\n  want: %v", field.Name, gotFieldValue, wantFieldValue)
-			if testingT, ok := a.t.(TestingT); ok {
-				testingT.Helper()
-				testingT.Errorf("%s", a.errorMsg)
+		if !objectsEqual(gotFieldValue, wantFieldValue) {
+			more := differences.add(" at ", func() string {
+				return fmt.Sprintf("field %q\n  got: %v\n  want: %v", info.names[i], gotFieldValue, wantFieldValue)
+			})
+			if !more {
+				break
 			}
-			return
 		}
 	}
 
-	// Structs are identical - no error
+	a.reportDifferences(differences, "structs", "field", "fields")
 }
 
 // DeepDiff asserts that two values of any type are equal with intelligent diff routing.
@@ -1369,13 +2037,19 @@ func (a *Assert) StructDiff(got, want any) {
 // - Maps use MapDiff for key/value analysis
 // - Structs use StructDiff for field-level comparison
 // - Other types use standard deep equality with clear error reporting
+//
+// Slices, maps and structs report their first difference, or with
+// WithMaxDifferences up to a budget of differences in one failure. Custom
+// equality is honoured as by Equal.
 func (a *Assert) DeepDiff(got, want any) {
+	a.countAssertion()
+
 	if t, ok := a.t.(interface{ Helper() }); ok {
 		t.Helper()
 	}
 
-	// Quick equality check first
-	if reflect.DeepEqual(got, want) {
+	// Quick equality check first, honouring Equal methods and comparers
+	if objectsEqual(got, want) {
 		return // Values are identical
 	}
 
@@ -1390,37 +2064,31 @@ func (a *Assert) DeepDiff(got, want any) {
 		if !a.markAsFailed() {
 			return
 		}
-		a.errorMsg = fmt.Sprintf("types differ\n  got: %s\n  want: %s", gotType, wantType)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string { return fmt.Sprintf("types differ\n  got: %s\n  want: %s", gotType, wantType) })
 		return
 	}
 
-	// Route to specialized diff methods based on type
-	switch gotValue.Kind() {
-	case reflect.Slice:
-		a.SliceDiffGeneric(got, want)
-		return
-	case reflect.Map:
-		a.MapDiff(got, want)
-		return
-	case reflect.Struct:
-		a.StructDiff(got, want)
-		return
-	default:
-		// For primitives and other types, provide basic comparison
-		if !a.markAsFailed() {
+	// Route to specialized diff methods based on type, unless the type
+	// defines its own equality, when the values differ as a whole
+	if equalityFor(gotType).equal == nil {
+		switch gotValue.Kind() {
+		case reflect.Slice:
+			a.delegate().SliceDiffGeneric(got, want)
+			return
+		case reflect.Map:
+			a.delegate().MapDiff(got, want)
+			return
+		case reflect.Struct:
+			a.delegate().StructDiff(got, want)
 			return
 		}
-		a.errorMsg = fmt.Sprintf("values differ\n  got: %v\n  want: %v", got, want)
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Helper()
-			testingT.Errorf("%s", a.errorMsg)
-		}
+	}
+
+	// For primitives and other types, provide basic comparison
+	if !a.markAsFailed() {
 		return
 	}
+	a.fail(func() string { return fmt.Sprintf("values differ\n  got: %v\n  want: %v", got, want) })
 }
 
 // Condition asserts that a certain condition is true.
@@ -1444,7 +2112,7 @@ func (a *Assert) Conditionf(condition bool, format string, args ...interface{})
 	}
 
 	if !condition {
-		a.reportErrorConsistent(true, condition, fmt.Sprintf(format, args...))
+		a.reportErrorf(true, condition, format, args...)
 	}
 	return a
 }
@@ -1508,34 +2176,6 @@ func (a *Assert) JsonEqual(expected, actual string) *Assert {
 	return a
 }
 
-// HasHeader asserts that a HTTP response has a certain header.
-// Returns *Assert to enable method chaining.
-func (a *Assert) HasHeader(response *http.Response, header string) *Assert {
-	// Fail-fast: if already failed, return immediately
-	if a.shouldSkipDueToFailure() {
-		return a
-	}
-
-	if _, ok := response.Header[header]; !ok {
-		a.reportErrorConsistent(header, nil, "expected to have header")
-	}
-	return a
-}
-
-// HeaderEqual asserts that a HTTP response header has a certain value.
-// Returns *Assert to enable method chaining.
-func (a *Assert) HeaderEqual(response *http.Response, header, expected string) *Assert {
-	// Fail-fast: if already failed, return immediately
-	if a.shouldSkipDueToFailure() {
-		return a
-	}
-
-	if value, ok := response.Header[header]; !ok || len(value) == 0 || value[0] != expected {
-		a.reportErrorConsistent(expected, response.Header[header], "expected different header value")
-	}
-	return a
-}
-
 // BodyContains asserts that a HTTP response body contains a certain string.
 // Returns *Assert to enable method chaining.
 func (a *Assert) BodyContains(response *http.Response, expected string) *Assert {
@@ -1665,76 +2305,101 @@ func (a *Assert) BodyJsonEqual(response *http.Response, expected interface{}) *A
 	return a
 }
 
-// HasCookie asserts that a HTTP response has a certain cookie.
-func (a *Assert) HasCookie(response *http.Response, name string) {
-	var hasCookie bool
-	for _, cookie := range response.Cookies() {
-		if cookie.Name == name {
-			hasCookie = true
-			break
-		}
-	}
-	if !hasCookie {
-		a.reportErrorConsistent(name, nil, "expected to have cookie")
-	}
-}
+// ResponseTime asserts that a GET request for url, sent with
+// http.DefaultClient, is answered within maxTime. It is ResponseTimeWith for
+// a single request; use that for a custom client, request or context, or for
+// warm-up requests and percentiles over several samples.
+func (a *Assert) ResponseTime(url string, maxTime time.Duration) {
+	a.countAssertion()
 
-// HeaderContains asserts that a HTTP response header contains a certain value.
-func (a *Assert) HeaderContains(response *http.Response, header, expected string) *Assert {
-	if a.shouldSkipDueToFailure() {
-		return a
-	}
-	values, ok := response.Header[header]
-	if !ok {
-		a.reportErrorConsistent(expected, nil, "expected to have header")
-	} else {
-		var found bool
-		for _, value := range values {
-			if value == expected {
-				found = true
-				break
-			}
-		}
-		if !found {
-			a.reportErrorConsistent(expected, values, "expected header to contain value")
-		}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
 	}
-	return a
-}
 
-func (a *Assert) ResponseTime(url string, maxTime time.Duration) {
-	start := time.Now()
-	_, err := http.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		a.reportErrorConsistent(maxTime, err, "error making request")
+		return
 	}
-	elapsed := time.Since(start)
-	if elapsed > maxTime {
-		a.reportErrorConsistent(maxTime, elapsed, "response time exceeded")
-	}
+	a.delegate().ResponseTimeWith(nil, req, maxTime)
 }
 
+// IsSorted asserts that slice is in ascending order.
+//
+// Deprecated: use the generic function Sorted, which accepts any ordered
+// element type and returns the Assert for chaining:
+//
+//	assertions.Sorted(assert, ids)
 func (a *Assert) IsSorted(slice []int) {
-	if !sort.IntsAreSorted(slice) {
-		a.reportErrorConsistent(nil, slice, "slice is not sorted")
+	a.countAssertion()
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
 	}
+
+	Sorted(a.delegate(), slice)
 }
 
+// IsSortedFloat64 asserts that slice is in ascending order.
+//
+// Deprecated: use the generic function Sorted instead:
+//
+//	assertions.Sorted(assert, readings)
 func (a *Assert) IsSortedFloat64(slice []float64) {
-	if !sort.Float64sAreSorted(slice) {
-		a.reportErrorConsistent(nil, slice, "slice is not sorted")
+	a.countAssertion()
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
 	}
+
+	Sorted(a.delegate(), slice)
 }
 
+// FileExists asserts that path exists. It reads from the filesystem set with
+// WithFS, if any.
 func (a *Assert) FileExists(path string) {
-	if _, err := os.Stat(path); os.IsNotExist(err) {
+	a.countAssertion()
+
+	if _, err := a.statFile(path); errors.Is(err, fs.ErrNotExist) {
 		a.reportErrorConsistent(path, nil, "file does not exist")
 	}
 }
 
+// NoFileExists asserts that nothing exists at path, neither file nor
+// directory. Errors other than the path not existing, such as a permission
+// error, are reported. It reads from the filesystem set with WithFS, if any.
+// Returns *Assert to enable method chaining.
+func (a *Assert) NoFileExists(path string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	info, err := a.statFile(path)
+	switch {
+	case err == nil:
+		kind := "file"
+		if info.IsDir() {
+			kind = "directory"
+		}
+		a.reportMessagef("expected path not to exist\n  path:  %s\n  found: %s", path, kind)
+	case !errors.Is(err, fs.ErrNotExist):
+		a.reportMessagef("failed to check path\n  path:  %s\n  error: %v", path, err)
+	}
+	return a
+}
+
+// DirectoryExists asserts that path exists and is a directory. It reads from
+// the filesystem set with WithFS, if any.
 func (a *Assert) DirectoryExists(path string) {
-	info, err := os.Stat(path)
-	if os.IsNotExist(err) || !info.IsDir() {
+	a.countAssertion()
+
+	info, err := a.statFile(path)
+	if err != nil || !info.IsDir() {
 		a.reportErrorConsistent(path, nil, "directory does not exist")
 	}
 }
@@ -1766,6 +2431,8 @@ func defaultEventuallyConfig() EventuallyConfig {
 // Eventually asserts that a condition becomes true within a timeout period.
 // Uses configurable polling with optional exponential backoff.
 // Follows GoWise principles of deterministic timing and resource cleanup.
+// On a clock frozen with timecontrol.Freeze, or set with WithClock, each
+// interval advances the clock instead of sleeping.
 // Returns *Assert to enable method chaining.
 //
 // Example:
@@ -1831,10 +2498,10 @@ func (a *Assert) EventuallyWith(condition func() bool, config EventuallyConfig)
 
 	// Validate and apply defaults
 	if config.Timeout <= 0 {
-		config.Timeout = defaultEventuallyConfig().Timeout
+		config.Timeout = a.eventuallyDefaults().Timeout
 	}
 	if config.Interval <= 0 {
-		config.Interval = defaultEventuallyConfig().Interval
+		config.Interval = a.eventuallyDefaults().Interval
 	}
 	if config.BackoffFactor < 1.0 {
 		config.BackoffFactor = 1.0
@@ -1858,10 +2525,10 @@ func (a *Assert) NeverWith(condition func() bool, config EventuallyConfig) *Asse
 
 	// Validate and apply defaults
 	if config.Timeout <= 0 {
-		config.Timeout = defaultEventuallyConfig().Timeout
+		config.Timeout = a.eventuallyDefaults().Timeout
 	}
 	if config.Interval <= 0 {
-		config.Interval = defaultEventuallyConfig().Interval
+		config.Interval = a.eventuallyDefaults().Interval
 	}
 	if config.BackoffFactor < 1.0 {
 		config.BackoffFactor = 1.0
@@ -1873,143 +2540,116 @@ func (a *Assert) NeverWith(condition func() bool, config EventuallyConfig) *Asse
 
 // eventuallyWithConfig implements the core Eventually logic with proper resource management.
 func (a *Assert) eventuallyWithConfig(condition func() bool, config EventuallyConfig) {
+	defer a.recordDuration(time.Now())
+
+	poll := pollUntil(condition, config, a.currentClock())
+	if poll.met {
+		return
+	}
+	// Timeout reached - report failure with timing context
+	// Use consistent fail-fast pattern
+	if !a.markAsFailed() {
+		return
+	}
+	errorMsg := fmt.Sprintf("Eventually: condition not met within timeout\n  timeout: %v\n  elapsed: %s\n  attempts: %s\n  final interval: %v",
+		config.Timeout, humaniseDuration(poll.elapsed), groupDigits(strconv.Itoa(poll.attempts), a.formatOptions.Numbers), poll.finalInterval)
+	a.fail(func() string { return errorMsg })
+}
+
+// pollResult records how pollUntil ended.
+type pollResult struct {
+	met           bool
+	elapsed       time.Duration
+	attempts      int
+	finalInterval time.Duration
+}
+
+// pollUntil checks condition at once and then at config's intervals, with
+// backoff, until it holds or config.Timeout passes on clock.
+func pollUntil(condition func() bool, config EventuallyConfig, clock timecontrol.Clock) pollResult {
+	if clock != timecontrol.Real {
+		return pollOnClock(condition, config, clock)
+	}
+
 	// Create context with timeout for clean cancellation
 	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
 	defer cancel()
 
 	// Track timing for error reporting
 	startTime := time.Now()
-	attempts := 0
-	currentInterval := config.Interval
+	result := pollResult{finalInterval: config.Interval}
 
 	// First check without delay
-	attempts++
+	result.attempts++
 	if condition() {
-		return // Success on first try
+		result.met = true
+		result.elapsed = time.Since(startTime)
+		return result // Success on first try
 	}
 
 	// Start polling loop
-	ticker := time.NewTicker(currentInterval)
+	ticker := time.NewTicker(result.finalInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
-			// Timeout reached - report failure with timing context
-			elapsed := time.Since(startTime)
-			// Use consistent fail-fast pattern
-			if !a.markAsFailed() {
-				return
-			}
-			errorMsg := fmt.Sprintf("Eventually: condition not met within timeout\n  timeout: %v\n  elapsed: %v\n  attempts: %d\n  final interval: %v",
-				config.Timeout, elapsed, attempts, currentInterval)
-			a.errorMsg = errorMsg
-			// Call the TestingT interface to actually fail the test
-			if testingT, ok := a.t.(TestingT); ok {
-				testingT.Helper()
-				testingT.Errorf("%s", a.errorMsg)
-			}
-			return
+			result.elapsed = time.Since(startTime)
+			return result
 
 		case <-ticker.C:
-			attempts++
+			result.attempts++
 			if condition() {
-				return // Success
+				result.met = true
+				result.elapsed = time.Since(startTime)
+				return result // Success
 			}
 
 			// Apply exponential backoff if configured
-			if config.BackoffFactor > 1.0 {
-				newInterval := time.Duration(float64(currentInterval) * config.BackoffFactor)
-
-				// Respect maximum interval if set
-				if config.MaxInterval > 0 && newInterval > config.MaxInterval {
-					newInterval = config.MaxInterval
-				}
-
-				if newInterval != currentInterval {
-					currentInterval = newInterval
-					ticker.Stop()
-					ticker = time.NewTicker(currentInterval)
-				}
+			if newInterval := nextInterval(result.finalInterval, config); newInterval != result.finalInterval {
+				result.finalInterval = newInterval
+				ticker.Reset(result.finalInterval)
 			}
 		}
 	}
 }
 
-// neverWithConfig implements the core Never logic with proper resource management.
-func (a *Assert) neverWithConfig(condition func() bool, config EventuallyConfig) {
-	// Create context with timeout for clean cancellation
-	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
-	defer cancel()
+// nextInterval returns the polling interval after current, grown by
+// config's backoff factor up to any maximum interval.
+func nextInterval(current time.Duration, config EventuallyConfig) time.Duration {
+	if config.BackoffFactor <= 1.0 {
+		return current
+	}
+	next := time.Duration(float64(current) * config.BackoffFactor)
 
-	// Track timing for error reporting
-	startTime := time.Now()
-	attempts := 0
-	currentInterval := config.Interval
+	// Respect maximum interval if set
+	if config.MaxInterval > 0 && next > config.MaxInterval {
+		next = config.MaxInterval
+	}
+	return next
+}
 
-	// First check without delay
-	attempts++
-	if condition() {
-		elapsed := time.Since(startTime)
-		// Only report first failure (fail-fast chaining)
-		if !a.markAsFailed() {
-			return
-		}
-		a.errorMsg = fmt.Sprintf("Never: condition became true unexpectedly\n  elapsed: %v\n  attempts: %d\n  interval: %v",
-			elapsed, attempts, config.Interval)
+// neverWithConfig implements the core Never logic with proper resource management.
+func (a *Assert) neverWithConfig(condition func() bool, config EventuallyConfig) {
+	defer a.recordDuration(time.Now())
 
-		// Call the TestingT interface to actually fail the test
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Errorf("%s", a.errorMsg)
-		}
+	poll := pollUntil(condition, config, a.currentClock())
+	if !poll.met {
+		// Timeout reached successfully - condition never became true
 		return
 	}
-
-	// Start polling loop
-	ticker := time.NewTicker(currentInterval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			// Timeout reached successfully - condition never became true
-			return
-
-		case <-ticker.C:
-			attempts++
-			if condition() {
-				elapsed := time.Since(startTime)
-				// Only report first failure (fail-fast chaining)
-				if !a.markAsFailed() {
-					return
-				}
-				a.errorMsg = fmt.Sprintf("Never: condition became true unexpectedly\n  elapsed: %v\n  attempts: %d\n  final interval: %v",
-					elapsed, attempts, currentInterval)
-
-				// Call the TestingT interface to actually fail the test
-				if testingT, ok := a.t.(TestingT); ok {
-					testingT.Errorf("%s", a.errorMsg)
-				}
-				return
-			}
-
-			// Apply exponential backoff if configured
-			if config.BackoffFactor > 1.0 {
-				newInterval := time.Duration(float64(currentInterval) * config.BackoffFactor)
-
-				// Respect maximum interval if set
-				if config.MaxInterval > 0 && newInterval > config.MaxInterval {
-					newInterval = config.MaxInterval
-				}
-
-				if newInterval != currentInterval {
-					currentInterval = newInterval
-					ticker.Stop()
-					ticker = time.NewTicker(currentInterval)
-				}
-			}
-		}
+	// Only report first failure (fail-fast chaining)
+	if !a.markAsFailed() {
+		return
 	}
+	a.fail(func() string {
+		interval := fmt.Sprintf("final interval: %v", poll.finalInterval)
+		if poll.attempts == 1 {
+			interval = fmt.Sprintf("interval: %v", config.Interval)
+		}
+		return fmt.Sprintf("Never: condition became true unexpectedly\n  elapsed: %s\n  attempts: %s\n  %s",
+			humaniseDuration(poll.elapsed), groupDigits(strconv.Itoa(poll.attempts), a.formatOptions.Numbers), interval)
+	})
 }
 
 // WithinTimeout asserts that a function completes execution within the specified timeout.
@@ -2023,10 +2663,11 @@ func (a *Assert) WithinTimeout(f func(), timeout time.Duration) *Assert {
 	if t, ok := a.t.(interface{ Helper() }); ok {
 		t.Helper()
 	}
+	defer a.recordDuration(time.Now())
 
 	// Validate timeout - apply sensible default for invalid values
 	if timeout <= 0 {
-		timeout = 5 * time.Second // Use same default as Eventually
+		timeout = a.eventuallyDefaults().Timeout // Use same default as Eventually
 	}
 
 	// Create context with timeout for clean cancellation
@@ -2061,12 +2702,9 @@ func (a *Assert) WithinTimeout(f func(), timeout time.Duration) *Assert {
 		if !a.markAsFailed() {
 			return a
 		}
-		a.errorMsg = fmt.Sprintf("WithinTimeout: function did not complete within timeout\n  timeout: %v\n  elapsed: %v", timeout, elapsed)
-
-		// Call the TestingT interface to actually fail the test
-		if testingT, ok := a.t.(TestingT); ok {
-			testingT.Errorf("%s", a.errorMsg)
-		}
+		a.fail(func() string {
+			return fmt.Sprintf("WithinTimeout: function did not complete within timeout\n  timeout: %v\n  elapsed: %s", timeout, humaniseDuration(elapsed))
+		})
 		return a
 	}
 }
@@ -0,0 +1,112 @@
+package assertions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gowise/pkg/interfaces/testattachment"
+)
+
+// pendingAttachment is an attachment added with WithAttachment or
+// WithAttachmentFile, created only if an assertion fails.
+type pendingAttachment struct {
+	name        string // File name for data; empty for an existing file
+	data        []byte
+	path        string // Path of an existing file
+	description string
+}
+
+// WithAttachment returns a new Assert that, if one of its assertions fails,
+// writes data to a file named name and attaches it to the failure, such as
+// the payload of the response under test. The file's path is appended to the
+// failure message, and the attachment is passed to the handler set with
+// UseAttachmentHandler, through which the test runner sends it to its
+// reporter. Files are written to a new directory under the test's artifact
+// directory if the testing context provides one (go test -artifacts), and
+// under os.TempDir otherwise. Passing assertions write nothing.
+//
+// Example:
+//
+//	body, _ := io.ReadAll(resp.Body)
+//	resp.Body = io.NopCloser(bytes.NewReader(body))
+//	assert.WithAttachment("response.json", body).BodyJsonEqual(resp, map[string]any{"status": "ok"})
+//
+// NOTE: Shares failure state with original for proper fail-fast chaining.
+//
+//funcgen:skip
+func (a *Assert) WithAttachment(name string, data []byte) *Assert {
+	derived := *a
+	derived.attachments = append(slices.Clone(a.attachments), pendingAttachment{name: name, data: data})
+	return &derived
+}
+
+// WithAttachmentFile returns a new Assert that, if one of its assertions
+// fails, attaches the existing file at path, such as a screenshot or a log,
+// with description. See WithAttachment.
+//
+// NOTE: Shares failure state with original for proper fail-fast chaining.
+//
+//funcgen:skip
+func (a *Assert) WithAttachmentFile(path, description string) *Assert {
+	derived := *a
+	derived.attachments = append(slices.Clone(a.attachments), pendingAttachment{path: path, description: description})
+	return &derived
+}
+
+// UseAttachmentHandler sets the function that receives the attachments
+// created when an assertion fails. The test runner sets one that reports
+// them to its reporter.
+func UseAttachmentHandler(handle func(testattachment.TestAttachment)) Option {
+	return func(a *Assert) { a.onAttach = handle }
+}
+
+// writeAttachments creates the pending attachments for the current failure,
+// names them in the failure message msg, which it returns, and passes them to
+// the handler.
+func (a *Assert) writeAttachments(msg string) string {
+	dir := ""
+	for _, pending := range a.attachments {
+		path := pending.path
+		if path == "" {
+			if dir == "" {
+				var err error
+				if dir, err = a.attachmentDir(); err != nil {
+					msg += fmt.Sprintf("\n  failed to write attachment %s: %v", pending.name, err)
+					continue
+				}
+			}
+			path = filepath.Join(dir, sanitiseFileName(pending.name))
+			if err := os.WriteFile(path, pending.data, 0o644); err != nil {
+				msg += fmt.Sprintf("\n  failed to write attachment %s: %v", pending.name, err)
+				continue
+			}
+		}
+
+		attachment, err := testattachment.NewTestAttachment(path, pending.description)
+		if err != nil {
+			msg += fmt.Sprintf("\n  failed to attach %s: %v", path, err)
+			continue
+		}
+		msg += "\n  attachment: " + path
+		if a.onAttach != nil {
+			a.onAttach(attachment)
+		}
+	}
+	return msg
+}
+
+// attachmentDir creates a directory for the attachments of the current
+// failure.
+func (a *Assert) attachmentDir() (string, error) {
+	parent := os.TempDir()
+	if t, ok := a.t.(interface{ ArtifactDir() string }); ok {
+		parent = t.ArtifactDir()
+	}
+	name := a.testName()
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return "", err
+	}
+	return os.MkdirTemp(parent, "gowise-attachments-"+sanitiseFileName(name)+"-*")
+}
@@ -0,0 +1,75 @@
+package assertions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gowise/pkg/interfaces/testattachment"
+)
+
+// TestWithAttachmentOnFailure tests that attachments are written and passed
+// to the handler only when an assertion fails.
+func TestWithAttachmentOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	screenshot := filepath.Join(dir, "screen.png")
+	if err := os.WriteFile(screenshot, []byte("\x89PNG"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var attached []testattachment.TestAttachment
+	mock := &artifactMockT{name: "TestCheckout/pay", dir: dir}
+	assert := New(mock).With(UseAttachmentHandler(func(ta testattachment.TestAttachment) {
+		attached = append(attached, ta)
+	}))
+
+	assert.WithAttachment("response.json", []byte(`{"ok":true}`)).Equal(1, 1)
+	if len(attached) != 0 || len(mock.errorCalls) != 0 {
+		t.Fatalf("Expected a passing assertion to attach nothing, got %v", attached)
+	}
+
+	assert.WithAttachment("response.json", []byte(`{"ok":false}`)).
+		WithAttachmentFile(screenshot, "checkout page").
+		Equal("declined", "accepted")
+
+	if len(attached) != 2 {
+		t.Fatalf("Expected two attachments, got %v", attached)
+	}
+	written := attached[0].FilePath
+	if !strings.HasPrefix(written, filepath.Join(dir, "gowise-attachments-TestCheckout_pay-")) || filepath.Base(written) != "response.json" {
+		t.Errorf("Expected the payload in a new directory under the artifact directory, got %s", written)
+	}
+	if content, err := os.ReadFile(written); err != nil || string(content) != `{"ok":false}` {
+		t.Errorf("Expected the payload to be written, got %q (%v)", content, err)
+	}
+	if attached[1].FilePath != screenshot || attached[1].Description != "checkout page" || attached[1].FileType != ".png" {
+		t.Errorf("Expected the screenshot to be attached as is, got %+v", attached[1])
+	}
+
+	message := mock.errorCalls[0]
+	if !strings.Contains(message, "\n  attachment: "+written) || !strings.Contains(message, "\n  attachment: "+screenshot) {
+		t.Errorf("Expected the failure message to name both attachments, got: %s", message)
+	}
+}
+
+// TestWithAttachmentScope tests that attachments belong to the derived
+// Assert only, and that a missing file is reported in the failure message.
+func TestWithAttachmentScope(t *testing.T) {
+	var attached []testattachment.TestAttachment
+	handler := UseAttachmentHandler(func(ta testattachment.TestAttachment) { attached = append(attached, ta) })
+
+	mock := &behaviorMockT{}
+	assert := New(mock).With(handler)
+	_ = assert.WithAttachment("unused.txt", []byte("unused"))
+	assert.Equal(1, 2)
+	if len(attached) != 0 {
+		t.Errorf("Expected the original Assert to carry no attachments, got %v", attached)
+	}
+
+	mock = &behaviorMockT{}
+	New(mock).With(handler).WithAttachmentFile(filepath.Join(t.TempDir(), "missing.log"), "").True(false)
+	if len(attached) != 0 || !strings.Contains(mock.errorCalls[0], "failed to attach") {
+		t.Errorf("Expected a missing file to be reported, got %v and %v", attached, mock.errorCalls)
+	}
+}
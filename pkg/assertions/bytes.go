@@ -0,0 +1,86 @@
+package assertions
+
+import (
+	"bytes"
+	"strings"
+
+	"gowise/pkg/diff"
+)
+
+// BytesEqual asserts that got and want hold the same bytes. On mismatch the
+// failure shows a side-by-side hex dump around the first differing offset,
+// which reads far better than %#v for binary payloads. A nil slice equals an
+// empty one. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.BytesEqual(frame.Encode(), []byte{0x01, 0x00, 0x04, 'p', 'i', 'n', 'g'})
+func (a *Assert) BytesEqual(got, want []byte) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if result := diff.Bytes(got, want); result.HasDiff {
+		a.reportBytesError("byte slices differ", result, len(got), len(want))
+	}
+	return a
+}
+
+// HasBytePrefix asserts that b begins with prefix.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.HasBytePrefix(png, []byte{0x89, 'P', 'N', 'G'})
+func (a *Assert) HasBytePrefix(b, prefix []byte) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !bytes.HasPrefix(b, prefix) {
+		head := b[:min(len(b), len(prefix))]
+		a.reportBytesError("expected bytes to have prefix", diff.Bytes(head, prefix), len(b), len(prefix))
+	}
+	return a
+}
+
+// HasByteSuffix asserts that b ends with suffix. Offsets in the failure are
+// relative to the start of the compared tail of b.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.HasByteSuffix(message, []byte("\r\n"))
+func (a *Assert) HasByteSuffix(b, suffix []byte) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !bytes.HasSuffix(b, suffix) {
+		tail := b[max(len(b)-len(suffix), 0):]
+		a.reportBytesError("expected bytes to have suffix", diff.Bytes(tail, suffix), len(b), len(suffix))
+	}
+	return a
+}
+
+// reportBytesError reports a byte comparison with its hex dump indented under
+// the message.
+func (a *Assert) reportBytesError(message string, result diff.BytesDiffResult, gotLen, wantLen int) {
+	a.reportMessagef("%s at offset %d (0x%x)\n  got:  %d bytes\n  want: %d bytes\n\n  %s",
+		message, result.Offset, result.Offset, gotLen, wantLen, strings.ReplaceAll(result.Dump, "\n", "\n  "))
+}
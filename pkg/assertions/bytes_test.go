@@ -0,0 +1,75 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestByteAssertions tests BytesEqual, HasBytePrefix and HasByteSuffix.
+func TestByteAssertions(t *testing.T) {
+	payload := []byte{0x01, 0x00, 0x04, 'p', 'i', 'n', 'g', '\r', '\n'}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"BytesEqual equal", func(a *Assert) { a.BytesEqual(payload, []byte{0x01, 0x00, 0x04, 'p', 'i', 'n', 'g', '\r', '\n'}) }, true, ""},
+		{"BytesEqual nil and empty", func(a *Assert) { a.BytesEqual(nil, []byte{}) }, true, ""},
+		{"BytesEqual differs", func(a *Assert) { a.BytesEqual(payload, []byte{0x01, 0x00, 0x04, 'p', 'o', 'n', 'g', '\r', '\n'}) }, false, "byte slices differ at offset 4 (0x4)\n  got:  9 bytes\n  want: 9 bytes"},
+		{"BytesEqual length differs", func(a *Assert) { a.BytesEqual(payload[:3], payload) }, false, "byte slices differ at offset 3 (0x3)\n  got:  3 bytes\n  want: 9 bytes"},
+
+		{"HasBytePrefix present", func(a *Assert) { a.HasBytePrefix(payload, []byte{0x01, 0x00}) }, true, ""},
+		{"HasBytePrefix absent", func(a *Assert) { a.HasBytePrefix(payload, []byte{0x01, 0x01}) }, false, "expected bytes to have prefix at offset 1 (0x1)"},
+		{"HasBytePrefix longer than input", func(a *Assert) { a.HasBytePrefix([]byte{0x01}, []byte{0x01, 0x00}) }, false, "expected bytes to have prefix at offset 1 (0x1)\n  got:  1 bytes\n  want: 2 bytes"},
+
+		{"HasByteSuffix present", func(a *Assert) { a.HasByteSuffix(payload, []byte("\r\n")) }, true, ""},
+		{"HasByteSuffix absent", func(a *Assert) { a.HasByteSuffix(payload, []byte("g\n")) }, false, "expected bytes to have suffix at offset 0 (0x0)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected assertion to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestBytesEqualShowsHexDump tests that failures include hex and ASCII columns.
+func TestBytesEqualShowsHexDump(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).BytesEqual([]byte("ping"), []byte("pong"))
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	if !strings.Contains(mock.errorCalls[0], "> 00000000  70 69 6e 67              ping      70 6f 6e 67              pong") {
+		t.Errorf("Expected hex dump row, got:\n%s", mock.errorCalls[0])
+	}
+}
+
+// ExampleAssert_BytesEqual demonstrates comparing binary payloads.
+func ExampleAssert_BytesEqual() {
+	t := &silentT{}
+	frame := []byte{0x01, 0x00, 0x04, 'p', 'i', 'n', 'g'}
+
+	New(t).
+		HasBytePrefix(frame, []byte{0x01}).
+		BytesEqual(frame, []byte{0x01, 0x00, 0x04, 'p', 'i', 'n', 'g'})
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
@@ -1,6 +1,7 @@
 package assertions
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -152,6 +153,73 @@ func TestChainingPreservesFirstError(t *testing.T) {
 	}
 }
 
+// TestChainStateMachine tests that a chain moves from passing to failed on its
+// first failure, across methods, package-level functions, extensions and
+// derived Asserts, and that everything after it is skipped.
+func TestChainStateMachine(t *testing.T) {
+	t.Run("MixedChain", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+		var result *ChainedAssert = Greater(assert.Equal(2, 2), 3, 1).Contains("order-7", "order")
+
+		if result.HasFailed() || result.Unwrap() != nil {
+			t.Fatalf("Expected a passing chain, got error %q", result.Error())
+		}
+
+		ran := false
+		result = Greater(assert.With(UseDiffFormat(DiffFormatUnified)).Equal("first", "FIRST"), 1, 3).
+			Fail("extension failure").
+			Panics(func() { ran = true })
+
+		if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "FIRST") {
+			t.Fatalf("Expected only the first failure to be reported, got %v", mock.errorCalls)
+		}
+		if ran {
+			t.Error("Expected assertions after the failure to be skipped unevaluated")
+		}
+		if result.Error() != mock.errorCalls[0] {
+			t.Errorf("Expected the chain to keep the first failure, got %q", result.Error())
+		}
+	})
+
+	t.Run("DerivedAssertFailsChain", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+		assert.WithFormatOptions(FormatOptions{MaxDepth: 1}).Equal(1, 2)
+		assert.Equal("later", "failure")
+
+		if !assert.HasFailed() || assert.Error() != mock.errorCalls[0] {
+			t.Errorf("Expected the original Assert to report the derived failure, got %q", assert.Error())
+		}
+		if len(mock.errorCalls) != 1 {
+			t.Errorf("Expected later assertions on the original to be skipped, got %v", mock.errorCalls)
+		}
+	})
+
+	t.Run("Unwrap", func(t *testing.T) {
+		assert := New(recordingT{}).Len([]int{1}, 2)
+
+		err := assert.Unwrap()
+		if err == nil || err.Error() != assert.Error() || !strings.Contains(err.Error(), "2") {
+			t.Errorf("Expected Unwrap to return the first failure, got %v", err)
+		}
+	})
+
+	t.Run("ForStartsNewChain", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		failed := New(mock).True(false)
+		fresh := failed.For(mock)
+
+		if fresh.HasFailed() || fresh.Error() != "" || fresh.Unwrap() != nil {
+			t.Fatalf("Expected For to start a passing chain, got %q", fresh.Error())
+		}
+		fresh.Equal(1, 2)
+		if len(mock.errorCalls) != 2 || failed.Error() == fresh.Error() {
+			t.Errorf("Expected each chain to report its own first failure, got %v", mock.errorCalls)
+		}
+	})
+}
+
 // chainTestContainsString checks if a string contains a substring (helper function).
 func chainTestContainsString(s, substr string) bool {
 	return len(substr) == 0 || (len(s) >= len(substr) &&
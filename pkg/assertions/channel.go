@@ -0,0 +1,168 @@
+package assertions
+
+import "time"
+
+// Channel assertions are generic package-level functions, like the ordering
+// assertions, so that received values keep their static type:
+//
+//	msg := assertions.ReceivedWithin(assert, events, 100*time.Millisecond)
+//	assert.Equal(msg.Kind, "created")
+//
+// Functions that receive consume the value they observe.
+
+// Received asserts that a value is ready on ch without blocking, and returns it.
+// On failure it returns the zero value of T.
+//
+// Example:
+//
+//	result := assertions.Received(assert, results)
+func Received[T any](a *Assert, ch <-chan T) T {
+	var zero T
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return zero
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	select {
+	case value, ok := <-ch:
+		if !ok {
+			a.reportMessagef("expected to receive a value, but channel is closed\n  channel: %T", ch)
+			return zero
+		}
+		return value
+	default:
+		a.reportMessagef("expected a value to be ready on channel\n  channel: %T (%d buffered)", ch, len(ch))
+		return zero
+	}
+}
+
+// ReceivedWithin asserts that a value arrives on ch within timeout, and returns it.
+// On failure it returns the zero value of T.
+//
+// Example:
+//
+//	event := assertions.ReceivedWithin(assert, events, 100*time.Millisecond)
+func ReceivedWithin[T any](a *Assert, ch <-chan T, timeout time.Duration) T {
+	var zero T
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return zero
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	defer a.recordDuration(time.Now())
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	start := time.Now()
+	select {
+	case value, ok := <-ch:
+		if !ok {
+			a.reportMessagef("expected to receive a value, but channel was closed\n  channel: %T\n  elapsed: %s", ch, humaniseDuration(time.Since(start)))
+			return zero
+		}
+		return value
+	case <-timer.C:
+		a.reportMessagef("expected to receive a value within timeout\n  channel: %T\n  timeout: %v", ch, timeout)
+		return zero
+	}
+}
+
+// NotReceived asserts that no value is ready on ch at the moment of the call.
+// A closed channel counts as ready, since a receive would not block.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.NotReceived(assert, errs)
+func NotReceived[T any](a *Assert, ch <-chan T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	select {
+	case value, ok := <-ch:
+		a.reportUnexpectedReceive(ch, value, ok, 0)
+	default:
+	}
+	return a
+}
+
+// NeverReceives asserts that nothing is received on ch for the whole window,
+// and that it is not closed during it. Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.NeverReceives(assert, shutdown, 50*time.Millisecond)
+func NeverReceives[T any](a *Assert, ch <-chan T, window time.Duration) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	defer a.recordDuration(time.Now())
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	start := time.Now()
+	select {
+	case value, ok := <-ch:
+		a.reportUnexpectedReceive(ch, value, ok, time.Since(start))
+	case <-timer.C:
+	}
+	return a
+}
+
+// Closed asserts that ch is closed and drained, so that a receive returns
+// immediately with ok false. A pending value is consumed and reported.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	cancel()
+//	assertions.Closed(assert, worker.Done())
+func Closed[T any](a *Assert, ch <-chan T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	select {
+	case value, ok := <-ch:
+		if ok {
+			a.reportMessagef("expected channel to be closed, but received a value\n  channel: %T\n  value:   %s", ch, formatValue(value, a.formatOptions))
+		}
+	default:
+		a.reportMessagef("expected channel to be closed, but it is open\n  channel: %T", ch)
+	}
+	return a
+}
+
+// reportUnexpectedReceive reports a receive, or a close, on a channel expected to stay quiet.
+func (a *Assert) reportUnexpectedReceive(ch, value interface{}, ok bool, elapsed time.Duration) {
+	if !ok {
+		a.reportMessagef("expected no receive, but channel was closed\n  channel: %T\n  elapsed: %s", ch, humaniseDuration(elapsed))
+		return
+	}
+	a.reportMessagef("expected no receive, but received a value\n  channel: %T\n  value:   %s\n  elapsed: %s", ch, formatValue(value, a.formatOptions), humaniseDuration(elapsed))
+}
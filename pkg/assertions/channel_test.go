@@ -0,0 +1,125 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestChannelAssertions tests the channel assertions against ready, empty and closed channels.
+func TestChannelAssertions(t *testing.T) {
+	ready := func() chan int {
+		ch := make(chan int, 1)
+		ch <- 42
+		return ch
+	}
+	closed := func() chan int {
+		ch := make(chan int)
+		close(ch)
+		return ch
+	}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"Received ready", func(a *Assert) { Received(a, ready()) }, true, ""},
+		{"Received empty", func(a *Assert) { Received(a, make(chan int, 2)) }, false, "expected a value to be ready on channel\n  channel: <-chan int (0 buffered)"},
+		{"Received closed", func(a *Assert) { Received(a, closed()) }, false, "channel is closed"},
+
+		{"ReceivedWithin delayed send", func(a *Assert) {
+			ch := make(chan string)
+			go func() {
+				time.Sleep(5 * time.Millisecond)
+				ch <- "done"
+			}()
+			ReceivedWithin(a, ch, time.Second)
+		}, true, ""},
+		{"ReceivedWithin timeout", func(a *Assert) { ReceivedWithin(a, make(chan int), 10*time.Millisecond) }, false, "timeout: 10ms"},
+		{"ReceivedWithin closed", func(a *Assert) { ReceivedWithin(a, closed(), time.Second) }, false, "channel was closed"},
+
+		{"NotReceived empty", func(a *Assert) { NotReceived(a, make(chan int)) }, true, ""},
+		{"NotReceived ready", func(a *Assert) { NotReceived(a, ready()) }, false, "received a value\n  channel: <-chan int\n  value:   42"},
+		{"NotReceived closed", func(a *Assert) { NotReceived(a, closed()) }, false, "channel was closed"},
+
+		{"NeverReceives quiet", func(a *Assert) { NeverReceives(a, make(chan int), 10*time.Millisecond) }, true, ""},
+		{"NeverReceives late send", func(a *Assert) {
+			ch := make(chan int)
+			go func() {
+				time.Sleep(5 * time.Millisecond)
+				ch <- 7
+			}()
+			NeverReceives(a, ch, time.Second)
+		}, false, "value:   7"},
+
+		{"Closed", func(a *Assert) { Closed(a, closed()) }, true, ""},
+		{"Closed open", func(a *Assert) { Closed(a, make(chan int)) }, false, "but it is open"},
+		{"Closed pending value", func(a *Assert) { Closed(a, ready()) }, false, "but received a value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestReceivedReturnsValue tests that received values are returned with their static type.
+func TestReceivedReturnsValue(t *testing.T) {
+	type event struct{ Kind string }
+	ch := make(chan event, 1)
+	ch <- event{Kind: "created"}
+
+	mock := &behaviorMockT{}
+	got := ReceivedWithin(New(mock), ch, time.Second)
+
+	if got.Kind != "created" || len(mock.errorCalls) != 0 {
+		t.Errorf("Expected to receive the event, got %+v with errors %v", got, mock.errorCalls)
+	}
+}
+
+// TestChannelAssertionsSkipAfterFailure tests that a failed Assert does not consume values.
+func TestChannelAssertionsSkipAfterFailure(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 1
+
+	mock := &behaviorMockT{}
+	assert := New(mock).True(false)
+	if got := Received(assert, ch); got != 0 {
+		t.Errorf("Expected zero value after failure, got %d", got)
+	}
+	if len(ch) != 1 {
+		t.Error("Expected the pending value not to be consumed after a failure")
+	}
+}
+
+// ExampleReceivedWithin demonstrates waiting for a value without select boilerplate.
+func ExampleReceivedWithin() {
+	t := &silentT{}
+	assert := New(t)
+
+	results := make(chan string)
+	go func() { results <- "done" }()
+
+	fmt.Println(ReceivedWithin(assert, results, time.Second))
+	NeverReceives(assert, results, 10*time.Millisecond)
+	fmt.Println("Failed:", t.failed)
+	// Output:
+	// done
+	// Failed: false
+}
@@ -0,0 +1,77 @@
+package assertions
+
+import (
+	"time"
+
+	"gowise/pkg/timecontrol"
+)
+
+// UseClock sets the clock that Eventually, EventuallyWith, Never, NeverWith
+// and EventuallyInOrder wait on and Now reads, in place of the test's clock:
+// the one frozen by timecontrol.Freeze, or else the wall clock. nil restores
+// the test's clock.
+func UseClock(clock timecontrol.Clock) Option {
+	return func(a *Assert) { a.clock = clock }
+}
+
+// WithClock returns a new Assert that reads and waits on clock, as set by
+// UseClock.
+//
+// Example:
+//
+//	clock := timecontrol.NewFrozen(launch)
+//	assert.WithClock(clock).Eventually(func() bool { return cache.Expired() }, time.Hour, time.Minute)
+//
+//funcgen:skip
+func (a *Assert) WithClock(clock timecontrol.Clock) *Assert {
+	return a.With(UseClock(clock))
+}
+
+// Now returns the current time on the Assert's clock, so that custom
+// assertions on recent or future times follow a clock frozen with
+// timecontrol.Freeze rather than the wall clock.
+//
+// Example:
+//
+//	func (a *DomainAssert) IsRecentTime(ts time.Time, maxAge time.Duration) *DomainAssert {
+//		if age := a.Now().Sub(ts); age > maxAge {
+//			a.Report(assertions.Failf("timestamp too old\n  age: %v\n  max age: %v", age, maxAge))
+//		}
+//		return a
+//	}
+func (a *Assert) Now() time.Time {
+	return a.currentClock().Now()
+}
+
+// currentClock returns the clock set by UseClock, or else the test's.
+func (a *Assert) currentClock() timecontrol.Clock {
+	if a.clock != nil {
+		return a.clock
+	}
+	return timecontrol.For(a.t)
+}
+
+// pollOnClock is pollUntil for a clock other than the wall clock: it waits
+// out each interval with clock.Sleep, which a frozen clock answers by
+// advancing at once, so polling takes no real time.
+func pollOnClock(condition func() bool, config EventuallyConfig, clock timecontrol.Clock) pollResult {
+	start := clock.Now()
+	result := pollResult{finalInterval: config.Interval}
+	for {
+		result.attempts++
+		if condition() {
+			result.met = true
+			result.elapsed = clock.Now().Sub(start)
+			return result
+		}
+
+		remaining := config.Timeout - clock.Now().Sub(start)
+		if result.finalInterval <= 0 || result.finalInterval >= remaining {
+			clock.Sleep(remaining)
+			result.elapsed = clock.Now().Sub(start)
+			return result
+		}
+		clock.Sleep(result.finalInterval)
+		result.finalInterval = nextInterval(result.finalInterval, config)
+	}
+}
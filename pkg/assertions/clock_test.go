@@ -0,0 +1,105 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gowise/pkg/timecontrol"
+)
+
+var opening = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// TestFrozenClock tests that Now, Eventually and Never follow a frozen clock
+// without waiting in real time.
+func TestFrozenClock(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert, clock *timecontrol.Frozen)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"Now reads the clock", func(a *Assert, clock *timecontrol.Frozen) {
+			clock.Advance(time.Hour)
+			a.TimeEqual(a.Now(), opening.Add(time.Hour))
+		}, true, ""},
+		{"Eventually advances the clock", func(a *Assert, clock *timecontrol.Frozen) {
+			expires := opening.Add(30 * time.Minute)
+			a.Eventually(func() bool { return !clock.Now().Before(expires) }, time.Hour, time.Minute).
+				TimeEqual(clock.Now(), expires)
+		}, true, ""},
+		{"Eventually times out on the clock", func(a *Assert, clock *timecontrol.Frozen) {
+			a.Eventually(func() bool { return false }, time.Hour, time.Minute)
+		}, false, "Eventually: condition not met within timeout\n  timeout: 1h0m0s\n  elapsed: 1h0m0s\n  attempts: 60\n"},
+		{"EventuallyWith backs off on the clock", func(a *Assert, clock *timecontrol.Frozen) {
+			a.EventuallyWith(func() bool { return false }, EventuallyConfig{
+				Timeout: time.Hour, Interval: time.Minute, BackoffFactor: 2, MaxInterval: 10 * time.Minute,
+			})
+		}, false, "attempts: 9\n  final interval: 10m0s"},
+		{"Never holds over the clock's timeout", func(a *Assert, clock *timecontrol.Frozen) {
+			a.Never(func() bool { return clock.Now().After(opening.Add(2 * time.Hour)) }, time.Hour, time.Minute)
+		}, true, ""},
+		{"Never fails when the clock reaches the condition", func(a *Assert, clock *timecontrol.Frozen) {
+			a.Never(func() bool { return clock.Now().After(opening.Add(10 * time.Minute)) }, time.Hour, time.Minute)
+		}, false, "Never: condition became true unexpectedly\n  elapsed: 11m0s\n  attempts: 12\n  final interval: 1m0s"},
+		{"Never fails on the first check", func(a *Assert, clock *timecontrol.Frozen) {
+			a.Never(func() bool { return true }, time.Hour, time.Minute)
+		}, false, "elapsed: 0s\n  attempts: 1\n  interval: 1m0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := timecontrol.NewFrozen(opening)
+			mock := &behaviorMockT{}
+			start := time.Now()
+			tt.assert(New(mock).WithClock(clock), clock)
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Expected no real waiting on a frozen clock, took %v", elapsed)
+			}
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// TestFreezeAppliesToTheTestsAsserts tests that an Assert for a test with a
+// frozen clock uses it without WithClock.
+func TestFreezeAppliesToTheTestsAsserts(t *testing.T) {
+	assert := New(t)
+	clock := timecontrol.Freeze(t, opening)
+
+	clock.Advance(time.Minute)
+	if got := assert.Now(); !got.Equal(opening.Add(time.Minute)) {
+		t.Errorf("Now = %v, want the frozen clock's %v", got, opening.Add(time.Minute))
+	}
+	assert.Eventually(func() bool { return clock.Now().After(opening.Add(time.Hour)) }, 2*time.Hour, time.Minute)
+}
+
+// ExampleAssert_WithClock tests expiry against a frozen clock, without
+// waiting for it.
+func ExampleAssert_WithClock() {
+	clock := timecontrol.NewFrozen(opening)
+	expires := opening.Add(90 * time.Minute)
+
+	mock := &behaviorMockT{}
+	New(mock).WithClock(clock).Eventually(func() bool { return clock.Now().After(expires) }, time.Hour, time.Minute)
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// Eventually: condition not met within timeout
+	//   timeout: 1h0m0s
+	//   elapsed: 1h0m0s
+	//   attempts: 60
+	//   final interval: 1m0s
+}
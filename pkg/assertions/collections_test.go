@@ -2,6 +2,7 @@ package assertions
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -271,6 +272,59 @@ func TestLenAssertion(t *testing.T) {
 	})
 }
 
+// TestLenBounds tests LenGreaterThan, LenLessThan, LenBetween, Empty and NotEmpty.
+func TestLenBounds(t *testing.T) {
+	ch := make(chan int, 2)
+	ch <- 1
+	many := make([]int, 12)
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"LenGreaterThan holds", func(a *Assert) { a.LenGreaterThan([]int{1, 2}, 1) }, true, ""},
+		{"LenGreaterThan fails", func(a *Assert) { a.LenGreaterThan([]int{1, 2}, 2) }, false,
+			"got length: 2, want length greater than 2\n  collection content: [1 2]"},
+		{"LenLessThan holds", func(a *Assert) { a.LenLessThan("abc", 4) }, true, ""},
+		{"LenLessThan fails", func(a *Assert) { a.LenLessThan(map[string]int{"a": 1}, 1) }, false,
+			"got length: 1, want length less than 1\n  collection content: [a:1]"},
+		{"LenLessThan truncates preview", func(a *Assert) { a.LenLessThan(many, 5) }, false, "(showing first 10 elements)"},
+		{"LenBetween inclusive low", func(a *Assert) { a.LenBetween([]string{"a"}, 1, 3) }, true, ""},
+		{"LenBetween inclusive high", func(a *Assert) { a.LenBetween([3]int{}, 1, 3) }, true, ""},
+		{"LenBetween fails", func(a *Assert) { a.LenBetween("", 1, 3) }, false,
+			"got length: 0, want length between 1 and 3\n  collection is empty"},
+		{"LenBetween reversed bounds", func(a *Assert) { a.LenBetween("abc", 3, 1) }, false,
+			"LenBetween needs min <= max, got min 3 and max 1"},
+		{"Empty holds", func(a *Assert) { a.Empty([]int(nil)) }, true, ""},
+		{"Empty fails", func(a *Assert) { a.Empty([]string{"x"}) }, false, "got length: 1, want empty\n  collection content: [x]"},
+		{"Empty channel fails", func(a *Assert) { a.Empty(ch) }, false, "collection content: <1 buffered values>"},
+		{"NotEmpty holds", func(a *Assert) { a.NotEmpty(ch) }, true, ""},
+		{"NotEmpty fails", func(a *Assert) { a.NotEmpty(map[int]int{}) }, false, "got length: 0, want not empty"},
+		{"unsupported type", func(a *Assert) { a.NotEmpty(42) }, false,
+			"unsupported container type for length check\n  container must be string, slice, array, map, or channel, got: int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
 // Examples for documentation.
 
 func ExampleAssert_Contains() {
@@ -0,0 +1,265 @@
+package assertions
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Equaler is implemented by types that define their own equality, as
+// time.Time does with its Equal method. Equal, NotEqual and the diff
+// assertions compare values of such a type with the method, wherever the
+// values appear, rather than field by field; two time.Time values for the
+// same instant are then equal whatever their location or monotonic clock
+// reading. The method must take a value of its own receiver type:
+//
+//	func (m Money) Equal(other Money) bool { return m.Cents == other.Cents && m.Currency == other.Currency }
+//
+//	var _ assertions.Equaler[Money] = Money{}
+//
+// A comparer registered with RegisterComparer takes precedence over the
+// method. Values held in unexported struct fields are compared field by
+// field, as reflect.DeepEqual does, since their methods cannot be called.
+type Equaler[T any] interface {
+	Equal(other T) bool
+}
+
+// comparers holds the comparers registered with RegisterComparer, by type.
+var comparers = struct {
+	sync.RWMutex
+	byType map[reflect.Type]func(got, want reflect.Value) bool
+}{byType: make(map[reflect.Type]func(got, want reflect.Value) bool)}
+
+// RegisterComparer makes Equal, NotEqual and the diff assertions compare
+// values of type T with equal, wherever they appear, in place of an Equal
+// method or a field-by-field comparison. Use it for types you do not own.
+// Register comparers from an init function. It panics if equal is nil, if T
+// is an interface type or a predeclared type such as int or string, which
+// Equal compares directly, or if T already has a comparer.
+//
+// Example:
+//
+//	func init() {
+//		assertions.RegisterComparer(func(got, want *big.Int) bool { return got.Cmp(want) == 0 })
+//	}
+func RegisterComparer[T any](equal func(got, want T) bool) {
+	t := reflect.TypeFor[T]()
+	if equal == nil {
+		panic("assertions: RegisterComparer called with a nil comparer for " + t.String())
+	}
+	if t.Kind() == reflect.Interface {
+		panic("assertions: RegisterComparer called for interface type " + t.String())
+	}
+	if t.PkgPath() == "" && t.Name() != "" {
+		panic("assertions: RegisterComparer called for predeclared type " + t.String())
+	}
+
+	comparers.Lock()
+	defer comparers.Unlock()
+	if _, dup := comparers.byType[t]; dup {
+		panic("assertions: RegisterComparer called twice for " + t.String())
+	}
+	comparers.byType[t] = func(got, want reflect.Value) bool {
+		return equal(got.Interface().(T), want.Interface().(T))
+	}
+	equalityCache.Clear()
+}
+
+// equality is how values of a type are compared, computed once per type.
+type equality struct {
+	equal  func(got, want reflect.Value) bool // Registered comparer or Equal method; nil if neither
+	custom bool                               // Whether custom equality may apply to the value or anything it holds
+}
+
+// equalityCache maps a reflect.Type to its *equality. It is cleared when a
+// comparer is registered.
+var equalityCache sync.Map
+
+// equalityFor returns how values of type t are compared.
+func equalityFor(t reflect.Type) *equality {
+	if eq, ok := equalityCache.Load(t); ok {
+		return eq.(*equality)
+	}
+
+	eq := &equality{equal: customEqual(t)}
+	eq.custom = eq.equal != nil || holdsCustomEquality(t, map[reflect.Type]bool{})
+	actual, _ := equalityCache.LoadOrStore(t, eq)
+	return actual.(*equality)
+}
+
+// customEqual returns the registered comparer for t, or failing that a call
+// of t's Equal method, or nil if t has neither.
+func customEqual(t reflect.Type) func(got, want reflect.Value) bool {
+	comparers.RLock()
+	equal := comparers.byType[t]
+	comparers.RUnlock()
+
+	if equal == nil {
+		method, ok := t.MethodByName("Equal")
+		if !ok || method.Type.NumIn() != 2 || method.Type.In(1) != t ||
+			method.Type.NumOut() != 1 || method.Type.Out(0).Kind() != reflect.Bool {
+			return nil
+		}
+		equal = func(got, want reflect.Value) bool {
+			return method.Func.Call([]reflect.Value{got, want})[0].Bool()
+		}
+	}
+	if t.Kind() != reflect.Ptr {
+		return equal
+	}
+	return func(got, want reflect.Value) bool {
+		if got.IsNil() || want.IsNil() {
+			return got.IsNil() && want.IsNil()
+		}
+		return equal(got, want)
+	}
+}
+
+// holdsCustomEquality reports whether values of type t may hold values with
+// custom equality: in their fields or elements, or behind an interface,
+// whose dynamic type is only known at run time. seen guards against
+// recursive types.
+func holdsCustomEquality(t reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	if customEqual(t) != nil {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return holdsCustomEquality(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if holdsCustomEquality(t.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// objectsEqual is reflect.DeepEqual honouring custom equality: registered
+// comparers and Equal methods. Values without custom equality anywhere in
+// their type are compared by reflect.DeepEqual itself.
+func objectsEqual(got, want interface{}) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	t := reflect.TypeOf(got)
+	if t != reflect.TypeOf(want) {
+		return false
+	}
+	if !equalityFor(t).custom {
+		return reflect.DeepEqual(got, want)
+	}
+	return deepEqual(reflect.ValueOf(got), reflect.ValueOf(want), make(map[visit]bool))
+}
+
+// visit records a pair of references already being compared, so that cyclic
+// values terminate, in the manner of reflect.DeepEqual.
+type visit struct {
+	got, want uintptr
+	typ       reflect.Type
+}
+
+// deepEqual walks got and want as reflect.DeepEqual does, comparing values
+// with custom equality by their comparer or Equal method.
+func deepEqual(got, want reflect.Value, visited map[visit]bool) bool {
+	if !got.IsValid() || !want.IsValid() {
+		return got.IsValid() == want.IsValid()
+	}
+	if got.Type() != want.Type() {
+		return false
+	}
+
+	eq := equalityFor(got.Type())
+	if !eq.custom && got.CanInterface() && want.CanInterface() {
+		return reflect.DeepEqual(got.Interface(), want.Interface())
+	}
+	if eq.equal != nil && got.CanInterface() && want.CanInterface() {
+		return eq.equal(got, want)
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if got.IsNil() != want.IsNil() {
+			return false
+		}
+		if got.Kind() != reflect.Slice && got.Pointer() == want.Pointer() {
+			return true
+		}
+		v := visit{got.Pointer(), want.Pointer(), got.Type()}
+		if visited[v] {
+			return true
+		}
+		visited[v] = true
+	}
+
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			return got.IsNil() == want.IsNil()
+		}
+		return deepEqual(got.Elem(), want.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < got.NumField(); i++ {
+			if !deepEqual(got.Field(i), want.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if got.Len() != want.Len() {
+			return false
+		}
+		for i := 0; i < got.Len(); i++ {
+			if !deepEqual(got.Index(i), want.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if got.Len() != want.Len() {
+			return false
+		}
+		iter := got.MapRange()
+		for iter.Next() {
+			other := want.MapIndex(iter.Key())
+			if !other.IsValid() || !deepEqual(iter.Value(), other, visited) {
+				return false
+			}
+		}
+		return true
+	default:
+		// Scalars with custom equality, or in unexported fields, which
+		// reflect.DeepEqual cannot be given
+		return scalarsEqual(got, want)
+	}
+}
+
+// scalarsEqual compares two values of the same non-composite kind.
+func scalarsEqual(got, want reflect.Value) bool {
+	switch got.Kind() {
+	case reflect.Bool:
+		return got.Bool() == want.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return got.Int() == want.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return got.Uint() == want.Uint()
+	case reflect.Float32, reflect.Float64:
+		return got.Float() == want.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return got.Complex() == want.Complex()
+	case reflect.String:
+		return got.String() == want.String()
+	case reflect.Func:
+		// As with reflect.DeepEqual, only nil functions are equal
+		return got.IsNil() && want.IsNil()
+	default:
+		return got.Pointer() == want.Pointer()
+	}
+}
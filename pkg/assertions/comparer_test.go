@@ -0,0 +1,140 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// money defines its own equality, ignoring the case of the currency.
+type money struct {
+	Cents    int
+	Currency string
+}
+
+func (m money) Equal(other money) bool {
+	return m.Cents == other.Cents && strings.EqualFold(m.Currency, other.Currency)
+}
+
+var _ Equaler[money] = money{}
+
+// version has an Equal method on its pointer type.
+type version struct{ Major, Minor int }
+
+func (v *version) Equal(other *version) bool { return v.Major == other.Major }
+
+// sku has a comparer registered in init.
+type sku struct{ Code string }
+
+func init() {
+	RegisterComparer(func(got, want sku) bool { return strings.EqualFold(got.Code, want.Code) })
+}
+
+// TestCustomEquality tests that Equal, NotEqual and the diff assertions
+// honour Equal methods and registered comparers.
+func TestCustomEquality(t *testing.T) {
+	now := time.Now()
+	sameInstant := now.Round(0).In(time.FixedZone("UTC+1", 3600)) // No monotonic reading, another location
+
+	type event struct {
+		Name string
+		At   time.Time
+		Cost money
+	}
+	type hidden struct{ at time.Time }
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"time.Time ignores monotonic clock and location", func(a *Assert) { a.Equal(now, sameInstant) }, true, ""},
+		{"time.Time different instants", func(a *Assert) { a.Equal(now, now.Add(time.Second)) }, false, "values differ"},
+		{"time.Time nested in a struct", func(a *Assert) {
+			a.Equal(event{"launch", now, money{100, "GBP"}}, event{"launch", sameInstant, money{100, "gbp"}})
+		}, true, ""},
+		{"time.Time in a slice", func(a *Assert) { a.Equal([]time.Time{now}, []time.Time{sameInstant}) }, true, ""},
+		{"time.Time in a map", func(a *Assert) {
+			a.Equal(map[string]time.Time{"at": now}, map[string]time.Time{"at": sameInstant})
+		}, true, ""},
+		{"time.Time behind an interface", func(a *Assert) { a.Equal([]any{1, now}, []any{1, sameInstant}) }, true, ""},
+		{"unexported fields compared field by field", func(a *Assert) { a.Equal(hidden{now}, hidden{sameInstant}) }, false, "values differ"},
+		{"NotEqual honours Equal methods", func(a *Assert) { a.NotEqual(money{100, "GBP"}, money{100, "gbp"}) }, false,
+			"values should not be equal"},
+		{"NotEqual passes on differing values", func(a *Assert) { a.NotEqual(money{100, "GBP"}, money{200, "GBP"}) }, true, ""},
+		{"pointer Equal method", func(a *Assert) { a.Equal(&version{1, 2}, &version{1, 3}) }, true, ""},
+		{"pointer Equal method with nil", func(a *Assert) { a.Equal(&version{1, 2}, (*version)(nil)) }, false, "values differ"},
+		{"registered comparer", func(a *Assert) { a.Equal(sku{"ab-1"}, sku{"AB-1"}) }, true, ""},
+		{"registered comparer in DeepDiff", func(a *Assert) {
+			a.DeepDiff(map[string]sku{"x": {"ab-1"}}, map[string]sku{"x": {"AB-1"}})
+		}, true, ""},
+		{"registered comparer in StructDiff", func(a *Assert) {
+			a.StructDiff(struct{ Item sku }{sku{"ab-1"}}, struct{ Item sku }{sku{"AB-2"}})
+		}, false, "structs differ at field \"Item\""},
+		{"DeepDiff compares custom types as a whole", func(a *Assert) {
+			a.DeepDiff(money{100, "GBP"}, money{200, "GBP"})
+		}, false, "values differ\n  got: {100 GBP}\n  want: {200 GBP}"},
+		{"DeepDiff time.Time", func(a *Assert) { a.DeepDiff([]time.Time{now}, []time.Time{sameInstant}) }, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.HasPrefix(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message starting %q, got:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// TestRegisterComparerRejectsInvalidRegistrations tests the panics of
+// RegisterComparer.
+func TestRegisterComparerRejectsInvalidRegistrations(t *testing.T) {
+	type unregistered struct{}
+	tests := []struct {
+		name     string
+		register func()
+		contains string
+	}{
+		{"nil comparer", func() { RegisterComparer[unregistered](nil) }, "nil comparer"},
+		{"interface type", func() { RegisterComparer(func(got, want error) bool { return true }) }, "interface type error"},
+		{"predeclared type", func() { RegisterComparer(func(got, want string) bool { return true }) }, "predeclared type string"},
+		{"duplicate", func() { RegisterComparer(func(got, want sku) bool { return true }) }, "twice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				r := recover()
+				if message, _ := r.(string); !strings.Contains(message, tt.contains) {
+					t.Errorf("Expected a panic containing %q, got %v", tt.contains, r)
+				}
+			}()
+			tt.register()
+		})
+	}
+}
+
+// ExampleRegisterComparer compares values of a type by a registered comparer.
+func ExampleRegisterComparer() {
+	type Email struct{ Address string }
+	RegisterComparer(func(got, want Email) bool { return strings.EqualFold(got.Address, want.Address) })
+
+	mock := &behaviorMockT{}
+	New(mock).Equal([]Email{{"Ada@example.com"}}, []Email{{"ada@example.com"}})
+	fmt.Println("failures:", len(mock.errorCalls))
+	// Output: failures: 0
+}
@@ -76,7 +76,7 @@ func TestComprehensiveEnhancedDiffIntegration(t *testing.T) {
 			},
 			expectErrorContains: []string{
 				"expected different header value",
-				// HeaderEqual compares string to []string, so no enhanced diff
+				// HeaderEqual compares the first value as a string
 				"got:",
 			},
 		},
@@ -0,0 +1,83 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockedMockT is a TestingT safe for concurrent use, as *testing.T is.
+type lockedMockT struct {
+	mu         sync.Mutex
+	errorCalls []string
+}
+
+func (m *lockedMockT) Errorf(format string, args ...interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *lockedMockT) FailNow() {}
+func (m *lockedMockT) Helper()  {}
+
+// TestAssertConcurrentUse tests that one Assert shared by many goroutines,
+// some failing, some reading its state, reports exactly one failure. Run with
+// -race to check the concurrency contract.
+func TestAssertConcurrentUse(t *testing.T) {
+	mock := &lockedMockT{}
+	assert := New(mock)
+	derived := assert.WithDiffFormat(DiffFormatUnified)
+
+	var wg sync.WaitGroup
+	for i := range 32 {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			assert.Equal(i, i).Contains([]int{1, 2, 3}, 2)
+			assert.Equal(fmt.Sprintf("user%d", i), "user0")
+		}()
+		go func() {
+			defer wg.Done()
+			derived.Equal([]string{"a", "b"}, []string{"a", fmt.Sprint(i)})
+		}()
+		go func() {
+			defer wg.Done()
+			_ = assert.Error()
+			_ = derived.Error()
+			_ = assert.HasFailed()
+		}()
+	}
+	wg.Wait()
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected exactly one reported failure, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+	}
+	if assert.Error() != mock.errorCalls[0] || derived.Error() != mock.errorCalls[0] {
+		t.Errorf("Expected both Asserts to report the reported message, got %q and %q", assert.Error(), derived.Error())
+	}
+}
+
+// TestAssertConcurrentLazyError tests that concurrent calls to Error build a
+// pending message once and agree on it.
+func TestAssertConcurrentLazyError(t *testing.T) {
+	assert := New(recordingT{})
+	assert.Equal("shipped", "pending")
+
+	var wg sync.WaitGroup
+	messages := make([]string, 16)
+	for i := range messages {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			messages[i] = assert.Error()
+		}()
+	}
+	wg.Wait()
+
+	for _, msg := range messages {
+		if msg != messages[0] || !strings.Contains(msg, "values differ") {
+			t.Fatalf("Expected every caller to get the same message, got %q", messages)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NoDataRaceGoroutines is the number of goroutines NoDataRace runs its
+// function in.
+const NoDataRaceGoroutines = 8
+
+// concurrentOutcome records how one function run by runTogether ended.
+type concurrentOutcome struct {
+	finished bool
+	panicked any
+}
+
+// runTogether runs each function in its own goroutine, releasing them at the
+// same moment, and waits up to timeout for all of them, or indefinitely if
+// timeout is negative. Panics are recovered and recorded. Functions still
+// running at the timeout are left to finish in the background.
+func runTogether(fns []func(), timeout time.Duration) []concurrentOutcome {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		outcomes = make([]concurrentOutcome, len(fns))
+		start    = make(chan struct{})
+	)
+	for i, fn := range fns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				p := recover()
+				mu.Lock()
+				outcomes[i] = concurrentOutcome{finished: true, panicked: p}
+				mu.Unlock()
+			}()
+			<-start
+			fn()
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	close(start)
+
+	if timeout < 0 {
+		<-done
+	} else {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case <-done:
+		case <-timer.C:
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return append([]concurrentOutcome(nil), outcomes...)
+}
+
+// describePanics lists the functions that panicked, by index, or returns ""
+// if none did.
+func describePanics(outcomes []concurrentOutcome) string {
+	var b strings.Builder
+	for i, o := range outcomes {
+		if o.panicked != nil {
+			fmt.Fprintf(&b, "\n  function %d panicked: %v", i, o.panicked)
+		}
+	}
+	return b.String()
+}
+
+// CompletesConcurrently runs each function in its own goroutine, all released
+// at the same moment, and asserts that every one returns without panicking
+// within timeout. A failure lists the functions, by index, that had not
+// finished or that panicked. Functions still running at the timeout are left
+// to finish in the background, so they must not outlive resources the test
+// cleans up. The functions may make assertions on a, which is safe for
+// concurrent use. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.CompletesConcurrently([]func(){
+//		func() { cache.Set("a", 1) },
+//		func() { cache.Set("b", 2) },
+//		func() { assert.Equal(cache.Len() <= 2, true) },
+//	}, time.Second)
+func (a *Assert) CompletesConcurrently(fns []func(), timeout time.Duration) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	defer a.recordDuration(time.Now())
+
+	if timeout < 0 {
+		a.reportMessagef("CompletesConcurrently needs a non-negative timeout, got %v", timeout)
+		return a
+	}
+
+	outcomes := runTogether(fns, timeout)
+	var unfinished []int
+	for i, o := range outcomes {
+		if !o.finished {
+			unfinished = append(unfinished, i)
+		}
+	}
+
+	panics := describePanics(outcomes)
+	switch {
+	case len(unfinished) > 0:
+		a.reportMessagef("expected %d functions to complete within %v, %d did not\n  unfinished: %v%s",
+			len(fns), timeout, len(unfinished), unfinished, panics)
+	case panics != "":
+		a.reportMessagef("expected %d functions to complete without panicking%s", len(fns), panics)
+	}
+	return a
+}
+
+// NoDataRace runs fn from NoDataRaceGoroutines goroutines at once and waits
+// for them all, asserting that none panics. It is a hook for the race
+// detector: under go test -race, unsynchronised access to shared state in fn
+// fails the test with a race report, while without -race only panics, such
+// as a nil dereference from a torn write, are caught. Keep a test using it
+// in the suite run by CI with -race. Returns *Assert to enable method
+// chaining.
+//
+// Example:
+//
+//	assert.NoDataRace(func() { counter.Increment() })
+//	assert.Equal(counter.Value(), assertions.NoDataRaceGoroutines)
+func (a *Assert) NoDataRace(fn func()) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	defer a.recordDuration(time.Now())
+
+	if fn == nil {
+		a.reportMessagef("NoDataRace needs a function to run")
+		return a
+	}
+
+	fns := make([]func(), NoDataRaceGoroutines)
+	for i := range fns {
+		fns[i] = fn
+	}
+	if panics := describePanics(runTogether(fns, -1)); panics != "" {
+		a.reportMessagef("expected concurrent calls to complete without panicking%s", panics)
+	}
+	return a
+}
@@ -0,0 +1,107 @@
+package assertions
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCompletesConcurrently tests that CompletesConcurrently waits for every
+// function and reports those that hang or panic.
+func TestCompletesConcurrently(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	tests := []struct {
+		name          string
+		fns           []func()
+		timeout       time.Duration
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"none", nil, 0, true, ""},
+		{"all complete", []func(){func() {}, func() { time.Sleep(5 * time.Millisecond) }}, time.Second, true, ""},
+		{"one hangs", []func(){func() {}, func() { <-block }, func() {}}, 20 * time.Millisecond, false,
+			"expected 3 functions to complete within 20ms, 1 did not\n  unfinished: [1]"},
+		{"one panics", []func(){func() {}, func() { panic("boom") }}, time.Second, false,
+			"expected 2 functions to complete without panicking\n  function 1 panicked: boom"},
+		{"hang and panic", []func(){func() { panic("boom") }, func() { <-block }}, 20 * time.Millisecond, false,
+			"unfinished: [1]\n  function 0 panicked: boom"},
+		{"negative timeout", []func(){func() {}}, -time.Second, false, "needs a non-negative timeout, got -1s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			New(mock).CompletesConcurrently(tt.fns, tt.timeout)
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+
+	t.Run("runs functions at the same time", func(t *testing.T) {
+		// Each function waits for all the others to start, so they only
+		// complete if they run concurrently
+		var started sync.WaitGroup
+		started.Add(3)
+		fn := func() {
+			started.Done()
+			started.Wait()
+		}
+
+		mock := &behaviorMockT{}
+		New(mock).CompletesConcurrently([]func(){fn, fn, fn}, time.Second)
+		if len(mock.errorCalls) != 0 {
+			t.Errorf("Expected concurrent functions to complete, got %v", mock.errorCalls)
+		}
+	})
+
+	t.Run("assertions inside functions", func(t *testing.T) {
+		mock := &lockedMockT{}
+		a := New(mock)
+		a.CompletesConcurrently([]func(){
+			func() { a.Equal(1, 1) },
+			func() { a.Equal(1, 2) },
+		}, time.Second)
+		if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "values differ") {
+			t.Errorf("Expected the inner failure to be reported once, got %v", mock.errorCalls)
+		}
+	})
+}
+
+// TestNoDataRace tests that NoDataRace runs its function concurrently and
+// reports panics.
+func TestNoDataRace(t *testing.T) {
+	var calls atomic.Int32
+	mock := &behaviorMockT{}
+	New(mock).NoDataRace(func() { calls.Add(1) })
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected a synchronised function to pass, got %v", mock.errorCalls)
+	}
+	if got := calls.Load(); got != NoDataRaceGoroutines {
+		t.Errorf("Expected %d calls, got %d", NoDataRaceGoroutines, got)
+	}
+
+	mock = &behaviorMockT{}
+	New(mock).NoDataRace(func() { panic("torn write") })
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "function 0 panicked: torn write") {
+		t.Errorf("Expected panics to be reported, got %v", mock.errorCalls)
+	}
+
+	mock = &behaviorMockT{}
+	New(mock).NoDataRace(nil)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "needs a function") {
+		t.Errorf("Expected a nil function to be rejected, got %v", mock.errorCalls)
+	}
+}
@@ -0,0 +1,203 @@
+package assertions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// contextKey is the key under which IntoContext stores an Assert.
+type contextKey struct{}
+
+// IntoContext returns a copy of ctx carrying a, so that code deep inside the
+// system under test, such as an HTTP middleware or a worker callback, can
+// record assertions against the current test without an Assert being
+// threaded through every signature.
+//
+// Example:
+//
+//	req = req.WithContext(assertions.IntoContext(req.Context(), assert))
+//	handler.ServeHTTP(rec, req)
+func IntoContext(ctx context.Context, a *Assert) context.Context {
+	return context.WithValue(ctx, contextKey{}, a)
+}
+
+// FromContext returns the Assert stored in ctx by IntoContext, and whether
+// there was one. Code that also runs outside tests should check ok, as ctx
+// carries no Assert in production.
+//
+// Example:
+//
+//	func audit(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			if assert, ok := assertions.FromContext(r.Context()); ok {
+//				assert.NotEqual(r.Header.Get("X-Request-ID"), "")
+//			}
+//			next.ServeHTTP(w, r)
+//		})
+//	}
+func FromContext(ctx context.Context) (*Assert, bool) {
+	a, ok := ctx.Value(contextKey{}).(*Assert)
+	return a, ok && a != nil
+}
+
+// describeContextErr renders why ctx is done: its error, and its cause when
+// context.WithCancelCause or a similar function gave a different one.
+func describeContextErr(ctx context.Context) string {
+	err := ctx.Err()
+	if cause := context.Cause(ctx); cause != nil && cause != err {
+		return fmt.Sprintf("%v (cause: %v)", err, cause)
+	}
+	return fmt.Sprint(err)
+}
+
+// ContextDone asserts that ctx has been cancelled or has passed its deadline.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	cancel()
+//	assert.ContextDone(workerCtx)
+func (a *Assert) ContextDone(ctx context.Context) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if a.rejectNil(ctx, "state") {
+		return a
+	}
+	if ctx.Err() == nil {
+		a.reportMessagef("expected context to be done, but it is still active")
+	}
+	return a
+}
+
+// ContextNotDone asserts that ctx has been neither cancelled nor passed its
+// deadline. Returns *Assert to enable method chaining.
+func (a *Assert) ContextNotDone(ctx context.Context) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if a.rejectNil(ctx, "state") {
+		return a
+	}
+	if ctx.Err() != nil {
+		a.reportMessagef("expected context to be active, but it is done\n  error: %s", describeContextErr(ctx))
+	}
+	return a
+}
+
+// ContextCancelledWithin asserts that ctx becomes done within timeout, waiting
+// for it if need be, to check that cancellation propagates from a parent or a
+// shutdown signal. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	server.Shutdown()
+//	assert.ContextCancelledWithin(requestCtx, 100*time.Millisecond)
+func (a *Assert) ContextCancelledWithin(ctx context.Context, timeout time.Duration) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	defer a.recordDuration(time.Now())
+
+	if a.rejectNil(ctx, "state") {
+		return a
+	}
+	done := ctx.Done()
+	if done == nil {
+		a.reportMessagef("expected context to be cancelled within %v, but it can never be cancelled\n  context: %v", timeout, ctx)
+		return a
+	}
+
+	if ctx.Err() != nil {
+		return a
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		a.reportMessagef("expected context to be cancelled within timeout\n  timeout: %v", timeout)
+	}
+	return a
+}
+
+// ContextErrIs asserts that ctx is done with an error matching target by
+// errors.Is, such as context.Canceled or context.DeadlineExceeded. The cause
+// given to context.WithCancelCause or a similar function is also checked, so
+// a custom cause can be asserted. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.ContextErrIs(ctx, context.DeadlineExceeded)
+func (a *Assert) ContextErrIs(ctx context.Context, target error) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if a.rejectNil(ctx, "error") {
+		return a
+	}
+	err := ctx.Err()
+	switch {
+	case err == nil:
+		a.reportMessagef("expected context to be done with %v, but it is still active", target)
+	case !errors.Is(err, target) && !errors.Is(context.Cause(ctx), target):
+		a.reportMessagef("context error does not match\n  got:  %s\n  want: %v", describeContextErr(ctx), target)
+	}
+	return a
+}
+
+// ContextHasValue asserts that ctx carries want under key, compared with
+// reflect.DeepEqual. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.ContextHasValue(req.Context(), requestIDKey{}, "req-42")
+func (a *Assert) ContextHasValue(ctx context.Context, key, want any) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if a.rejectNil(ctx, "values") {
+		return a
+	}
+	got := ctx.Value(key)
+	switch {
+	case got == nil && want != nil:
+		a.reportMessagef("expected context to carry a value\n  key:  %T %s\n  want: %s", key, formatValue(key, a.formatOptions), formatValue(want, a.formatOptions))
+	case !reflect.DeepEqual(got, want):
+		a.reportErrorf(got, want, "context value differs\n  key: %T %s", key, formatValue(key, a.formatOptions))
+	}
+	return a
+}
@@ -0,0 +1,156 @@
+package assertions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestContextPropagation tests IntoContext and FromContext.
+func TestContextPropagation(t *testing.T) {
+	t.Run("Round trip", func(t *testing.T) {
+		a := New(&behaviorMockT{})
+		got, ok := FromContext(IntoContext(context.Background(), a))
+		if !ok || got != a {
+			t.Errorf("expected the stored Assert, got %p (ok=%v)", got, ok)
+		}
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		if got, ok := FromContext(context.Background()); ok || got != nil {
+			t.Errorf("expected no Assert, got %p (ok=%v)", got, ok)
+		}
+	})
+
+	t.Run("Nil Assert", func(t *testing.T) {
+		if _, ok := FromContext(IntoContext(context.Background(), nil)); ok {
+			t.Errorf("expected a nil Assert not to be reported as present")
+		}
+	})
+
+	t.Run("Derived contexts", func(t *testing.T) {
+		a := New(&behaviorMockT{})
+		type otherKey struct{}
+		ctx, cancel := context.WithCancel(IntoContext(context.Background(), a))
+		defer cancel()
+		if got, ok := FromContext(context.WithValue(ctx, otherKey{}, 1)); !ok || got != a {
+			t.Errorf("expected the Assert to survive derived contexts")
+		}
+	})
+
+	t.Run("Failures from a handler reach the test", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		a := New(mock)
+
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if assert, ok := FromContext(r.Context()); ok {
+				assert.NotEqual(r.Header.Get("X-Request-ID"), "")
+			}
+		})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(IntoContext(req.Context(), a)))
+
+		if len(mock.errorCalls) != 1 || !a.HasFailed() {
+			t.Errorf("expected the handler's failure to be reported, got %v", mock.errorCalls)
+		}
+	})
+}
+
+// TestContextAssertions tests the assertions on a context's cancellation,
+// error and values.
+func TestContextAssertions(t *testing.T) {
+	type userKey struct{}
+	errShutdown := errors.New("server shutting down")
+
+	active := context.WithValue(context.Background(), userKey{}, "ann")
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	shutdown, cancelShutdown := context.WithCancelCause(context.Background())
+	cancelShutdown(errShutdown)
+	pending, cancelPending := context.WithCancel(context.Background())
+	defer cancelPending()
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"ContextDone", func(a *Assert) { a.ContextDone(cancelled) }, true, ""},
+		{"ContextDone expired", func(a *Assert) { a.ContextDone(expired) }, true, ""},
+		{"ContextDone active", func(a *Assert) { a.ContextDone(active) }, false, "expected context to be done, but it is still active"},
+		{"ContextDone nil", func(a *Assert) { a.ContextDone(nil) }, false, "cannot check state of nil value"},
+		{"ContextNotDone", func(a *Assert) { a.ContextNotDone(active) }, true, ""},
+		{"ContextNotDone cancelled", func(a *Assert) { a.ContextNotDone(shutdown) }, false,
+			"expected context to be active, but it is done\n  error: context canceled (cause: server shutting down)"},
+
+		{"ContextCancelledWithin already done", func(a *Assert) { a.ContextCancelledWithin(cancelled, 0) }, true, ""},
+		{"ContextCancelledWithin later", func(a *Assert) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(10*time.Millisecond, cancel)
+			a.ContextCancelledWithin(ctx, time.Second)
+		}, true, ""},
+		{"ContextCancelledWithin times out", func(a *Assert) { a.ContextCancelledWithin(pending, 10*time.Millisecond) }, false,
+			"expected context to be cancelled within timeout\n  timeout: 10ms"},
+		{"ContextCancelledWithin never cancellable", func(a *Assert) { a.ContextCancelledWithin(active, time.Hour) }, false,
+			"it can never be cancelled"},
+
+		{"ContextErrIs cancelled", func(a *Assert) { a.ContextErrIs(cancelled, context.Canceled) }, true, ""},
+		{"ContextErrIs deadline", func(a *Assert) { a.ContextErrIs(expired, context.DeadlineExceeded) }, true, ""},
+		{"ContextErrIs cause", func(a *Assert) { a.ContextErrIs(shutdown, errShutdown) }, true, ""},
+		{"ContextErrIs differs", func(a *Assert) { a.ContextErrIs(cancelled, context.DeadlineExceeded) }, false,
+			"context error does not match\n  got:  context canceled\n  want: context deadline exceeded"},
+		{"ContextErrIs active", func(a *Assert) { a.ContextErrIs(active, context.Canceled) }, false,
+			"expected context to be done with context canceled, but it is still active"},
+
+		{"ContextHasValue", func(a *Assert) { a.ContextHasValue(active, userKey{}, "ann") }, true, ""},
+		{"ContextHasValue through derived context", func(a *Assert) {
+			ctx, cancel := context.WithTimeout(active, time.Hour)
+			defer cancel()
+			a.ContextHasValue(ctx, userKey{}, "ann")
+		}, true, ""},
+		{"ContextHasValue differs", func(a *Assert) { a.ContextHasValue(active, userKey{}, "bob") }, false, "context value differs"},
+		{"ContextHasValue missing", func(a *Assert) { a.ContextHasValue(context.Background(), userKey{}, "ann") }, false,
+			"expected context to carry a value\n  key:  assertions.userKey"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+func ExampleFromContext() {
+	assert := New(&silentT{})
+	ctx := IntoContext(context.Background(), assert)
+
+	process := func(ctx context.Context, total int) {
+		if a, ok := FromContext(ctx); ok {
+			a.Equal(total, 42)
+		}
+	}
+	process(ctx, 42)
+
+	fmt.Println("Failed:", assert.HasFailed())
+	// Output: Failed: false
+}
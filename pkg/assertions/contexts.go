@@ -0,0 +1,151 @@
+package assertions
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// The testing package's contexts are checked to be TestingTs at compile
+// time, so New, NewB, NewF and the package-level assertion functions accept
+// each of them.
+var (
+	_ TestingT = (*testing.T)(nil)
+	_ TestingT = (*testing.B)(nil)
+	_ TestingT = (*testing.F)(nil)
+	_ TestingT = testing.TB(nil)
+)
+
+// AssertionsPerOpMetric is the unit under which NewB reports the number of
+// assertions evaluated per benchmark iteration.
+const AssertionsPerOpMetric = "assertions/op"
+
+// UseDiffs enables or disables diff generation in failure messages. With
+// diffs disabled, failures show got and want within the format limits only,
+// which keeps the cost of a failing assertion flat.
+func UseDiffs(enabled bool) Option {
+	return func(a *Assert) { a.noDiffs = !enabled }
+}
+
+// NewB creates an Assert for a benchmark. Diff generation is disabled, so a
+// failing assertion inside the timed loop does not distort the measurement,
+// and the number of assertions evaluated per iteration is reported alongside
+// ns/op as assertions/op, making assertion overhead visible in benchmark
+// comparisons.
+//
+// Example:
+//
+//	func BenchmarkEncode(b *testing.B) {
+//		assert := assertions.NewB(b)
+//		for b.Loop() {
+//			assert.Equal(len(encode(payload)), 128)
+//		}
+//	}
+func NewB(b *testing.B) *Assert {
+	evaluated := new(atomic.Int64)
+	b.Cleanup(func() {
+		if b.N > 0 {
+			b.ReportMetric(float64(evaluated.Load())/float64(b.N), AssertionsPerOpMetric)
+		}
+	})
+
+	a := New(b).With(UseDiffs(false))
+	a.evaluated = evaluated
+	return a
+}
+
+// fuzzFormatOptions bounds values rendered in fuzz failures, where inputs are
+// generated and frequently large.
+var fuzzFormatOptions = FormatOptions{
+	MaxStringLength:  256,
+	MaxSliceElements: 32,
+	MaxMapEntries:    32,
+	MaxDepth:         4,
+}
+
+// NewF creates an Assert for a fuzz test, for use while adding the seed corpus.
+// Inside the fuzz target, derive an Assert for each input with For. Failures
+// show got and want, tightly bounded, rather than diffs: generated inputs make
+// long diffs noise, and the fuzzer records the failing input itself.
+//
+// Example:
+//
+//	func FuzzParse(f *testing.F) {
+//		fuzz := assertions.NewF(f)
+//		f.Add("key=value")
+//		f.Fuzz(func(t *testing.T, input string) {
+//			assert := fuzz.For(t)
+//			parsed, err := Parse(input)
+//			assert.NoError(err).Equal(parsed.String(), input)
+//		})
+//	}
+func NewF(f *testing.F) *Assert {
+	return New(f).With(UseDiffs(false), UseFormatOptions(fuzzFormatOptions))
+}
+
+// For returns a new Assert reporting to t with the settings of a, such as
+// format options and diff mode, but with its own failure state. It suits
+// fuzz targets and other callbacks that receive a fresh testing context.
+//
+// Example:
+//
+//	t.Run(name, func(t *testing.T) {
+//		assert := parent.For(t)
+//		assert.Equal(got, want)
+//	})
+//
+//funcgen:skip
+func (a *Assert) For(t interface{}) *Assert {
+	derived := a.With()
+	derived.t = t
+	derived.shared = new(chainState)
+	derived.evaluated = nil
+	return derived
+}
+
+// Run runs fn as a subtest of t named name, with an Assert of its own
+// configured by opts. A fresh Assert per subtest keeps one subtest's failure
+// from suppressing, through fail-fast chaining, the assertions of the next,
+// which is easy to get wrong when an Assert is shared across t.Run calls.
+// It reports whether the subtest passed.
+//
+// Example:
+//
+//	for _, tc := range cases {
+//		assertions.Run(t, tc.name, func(t *testing.T, assert *assertions.Assert) {
+//			assert.Equal(Parse(tc.input), tc.want)
+//		})
+//	}
+func Run(t *testing.T, name string, fn func(t *testing.T, assert *Assert), opts ...Option) bool {
+	t.Helper()
+	return t.Run(name, func(t *testing.T) {
+		fn(t, New(t).With(opts...))
+	})
+}
+
+// Run runs fn as a subtest of the test a reports to, with an Assert derived
+// from a by For: it keeps a's settings, such as tolerances and format
+// options, but has its own failure state. It reports whether the subtest
+// passed. If a does not report to a *testing.T, Run fails a and returns
+// false.
+//
+// Example:
+//
+//	assert := assertions.New(t).With(assertions.UseFloatTolerance(1e-9))
+//	for _, tc := range cases {
+//		assert.Run(tc.name, func(t *testing.T, assert *assertions.Assert) {
+//			assert.Equal(Area(tc.shape), tc.want)
+//		})
+//	}
+func (a *Assert) Run(name string, fn func(t *testing.T, assert *Assert)) bool {
+	t, ok := a.t.(*testing.T)
+	if !ok {
+		a.Fail(fmt.Sprintf("Run requires an Assert created for a *testing.T, got %T", a.t))
+		return false
+	}
+
+	t.Helper()
+	return t.Run(name, func(t *testing.T) {
+		fn(t, a.For(t))
+	})
+}
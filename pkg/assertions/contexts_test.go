@@ -0,0 +1,127 @@
+package assertions
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestNewBReportsAssertionsPerOp tests the metric reported by benchmark asserts.
+func TestNewBReportsAssertionsPerOp(t *testing.T) {
+	result := testing.Benchmark(func(b *testing.B) {
+		assert := NewB(b)
+		for i := 0; i < b.N; i++ {
+			assert.Equal(i, i).True(i >= 0)
+		}
+	})
+
+	if got := result.Extra[AssertionsPerOpMetric]; got != 2 {
+		t.Errorf("Expected 2 %s, got %v", AssertionsPerOpMetric, got)
+	}
+}
+
+// TestUseDiffsDisabled tests that failures fall back to got/want without diffs.
+func TestUseDiffsDisabled(t *testing.T) {
+	got := "line one\nline two\nline three"
+	want := "line one\nline 2\nline three"
+
+	mock := &behaviorMockT{}
+	New(mock).With(UseDiffs(false)).Equal(got, want)
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	message := mock.errorCalls[0]
+	if !strings.Contains(message, "values differ\n  got:  "+strconv.Quote(got)) {
+		t.Errorf("Expected plain got/want layout, got: %s", message)
+	}
+	if strings.Contains(message, "@@") || strings.Contains(message, "line 2:") {
+		t.Errorf("Expected no diff output, got: %s", message)
+	}
+}
+
+// TestForHasOwnFailureState tests that For keeps settings but not failures.
+func TestForHasOwnFailureState(t *testing.T) {
+	parentMock := &behaviorMockT{}
+	parent := New(parentMock).With(UseFloatTolerance(0.5))
+	parent.True(false)
+
+	childMock := &behaviorMockT{}
+	child := parent.For(childMock)
+	child.Equal(1.2, 1.0)
+	child.Equal("a", "b")
+
+	if len(parentMock.errorCalls) != 1 {
+		t.Errorf("Expected parent to keep its own failure, got %d Errorf calls", len(parentMock.errorCalls))
+	}
+	if len(childMock.errorCalls) != 1 || !strings.Contains(childMock.errorCalls[0], `"a"`) {
+		t.Errorf("Expected child to inherit tolerance and fail only on strings, got: %v", childMock.errorCalls)
+	}
+	if child.Error() == parent.Error() {
+		t.Errorf("Expected separate error messages, both were %q", child.Error())
+	}
+}
+
+// FuzzNewF exercises fuzz asserts over the seed corpus.
+func FuzzNewF(f *testing.F) {
+	fuzz := NewF(f)
+
+	for _, seed := range []string{"", "key=value", "ünïcödé"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		assert := fuzz.For(t)
+		unquoted, err := strconv.Unquote(strconv.Quote(input))
+		assert.NoError(err).Equal(unquoted, input)
+	})
+}
+
+// TestRunCreatesAssertPerSubtest tests that Run gives each subtest its own
+// configured Assert.
+func TestRunCreatesAssertPerSubtest(t *testing.T) {
+	var seen []*Assert
+	for _, name := range []string{"first", "second"} {
+		passed := Run(t, name, func(t *testing.T, assert *Assert) {
+			seen = append(seen, assert)
+			assert.Equal(1.2, 1.0) // Within the configured tolerance
+		}, UseFloatTolerance(0.5))
+		if !passed {
+			t.Errorf("Expected subtest %s to pass", name)
+		}
+	}
+
+	if len(seen) != 2 || seen[0] == seen[1] {
+		t.Errorf("Expected a distinct Assert per subtest, got %v", seen)
+	}
+}
+
+// TestAssertRunInheritsSettings tests that Assert.Run derives each
+// subtest's Assert from the parent's settings.
+func TestAssertRunInheritsSettings(t *testing.T) {
+	parent := New(t).With(UseFloatTolerance(0.5))
+
+	var child *Assert
+	passed := parent.Run("inherits tolerance", func(t *testing.T, assert *Assert) {
+		child = assert
+		assert.Equal(1.2, 1.0)
+	})
+
+	if !passed || child == nil || child == parent {
+		t.Errorf("Expected the subtest to pass with its own Assert, passed=%v", passed)
+	}
+}
+
+// TestAssertRunRequiresTestingT tests that Assert.Run fails without a
+// *testing.T to run subtests on.
+func TestAssertRunRequiresTestingT(t *testing.T) {
+	mock := &behaviorMockT{}
+	ran := false
+	passed := New(mock).Run("unsupported", func(t *testing.T, assert *Assert) { ran = true })
+
+	if passed || ran {
+		t.Errorf("Expected Run to refuse, passed=%v ran=%v", passed, ran)
+	}
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "Run requires an Assert created for a *testing.T, got *assertions.behaviorMockT") {
+		t.Errorf("Expected an explanatory failure, got %v", mock.errorCalls)
+	}
+}
@@ -0,0 +1,146 @@
+package assertions
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// CrashDumpConfig configures the diagnostic bundle written when a fatal
+// assertion stops a test. See UseCrashDump.
+type CrashDumpConfig struct {
+	// Dir is the directory bundles are written to. When empty, the test's
+	// artifact directory is used if the testing context provides one
+	// (go test -artifacts), and os.TempDir otherwise.
+	Dir string
+	// Logs, if set, returns recent log output to include, such as the
+	// contents of a buffer the code under test logs to.
+	Logs func() string
+}
+
+// UseFatal makes failures stop the test with FailNow after they are reported,
+// as t.Fatalf does, instead of letting it continue.
+func UseFatal(enabled bool) Option {
+	return func(a *Assert) { a.fatal = enabled }
+}
+
+// UseCrashDump enables fatal failures and has each one first write a
+// diagnostic bundle, holding the failure, an environment summary, any
+// configured logs and a dump of every goroutine, for post-mortem debugging of
+// CI runs. The bundle's path is appended to the failure message. It is
+// written whatever the testing context, but only a TestingT is stopped: a
+// context without FailNow, such as a test runner's TestInterface, reads the
+// failure, with the bundle's path, from Error.
+//
+// Example:
+//
+//	var logs bytes.Buffer
+//	service := NewService(log.New(&logs, "", 0))
+//	assert := assertions.New(t).With(assertions.UseCrashDump(assertions.CrashDumpConfig{Logs: logs.String}))
+//	assert.NoError(service.Start()) // stops the test and writes a bundle on failure
+func UseCrashDump(config CrashDumpConfig) Option {
+	return func(a *Assert) {
+		a.fatal = true
+		a.crashDump = &config
+	}
+}
+
+// crashDumpDir returns the directory crash dumps are written to.
+func (a *Assert) crashDumpDir() string {
+	if a.crashDump.Dir != "" {
+		return a.crashDump.Dir
+	}
+	if t, ok := a.t.(interface{ ArtifactDir() string }); ok {
+		return t.ArtifactDir()
+	}
+	return os.TempDir()
+}
+
+// writeCrashDump writes the diagnostic bundle for the current failure and
+// returns its path.
+func (a *Assert) writeCrashDump(msg string) (string, error) {
+	name := a.testName()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "gowise crash dump for %s\n", name)
+	fmt.Fprintf(&b, "written: %s\n", time.Now().Format(time.RFC3339))
+
+	b.WriteString("\n== failure ==\n")
+	b.WriteString(msg)
+	b.WriteString("\n")
+
+	b.WriteString("\n== environment ==\n")
+	writeEnvironmentSummary(&b)
+
+	if a.crashDump.Logs != nil {
+		b.WriteString("\n== logs ==\n")
+		b.WriteString(strings.TrimSuffix(a.crashDump.Logs(), "\n"))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n== goroutines ==\n")
+	b.Write(goroutineDump())
+
+	dir := a.crashDumpDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp(dir, "gowise-crash-"+sanitiseFileName(name)+"-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(b.String()); err != nil {
+		return "", err
+	}
+	return file.Name(), file.Close()
+}
+
+// crashDumpEnvVars are the environment variables included in the summary.
+// Only well-known build and CI settings are listed, so that secrets held in
+// the environment never reach an artifact.
+var crashDumpEnvVars = []string{"GOFLAGS", "GOMAXPROCS", "GODEBUG", "GOGC", "CI", "GITHUB_RUN_ID", "GITHUB_SHA"}
+
+// writeEnvironmentSummary describes the runtime and process the test ran in.
+func writeEnvironmentSummary(b *strings.Builder) {
+	fmt.Fprintf(b, "go:         %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(b, "cpus:       %d (GOMAXPROCS %d)\n", runtime.NumCPU(), runtime.GOMAXPROCS(0))
+	fmt.Fprintf(b, "goroutines: %d\n", runtime.NumGoroutine())
+	if wd, err := os.Getwd(); err == nil {
+		fmt.Fprintf(b, "directory:  %s\n", wd)
+	}
+	for _, key := range crashDumpEnvVars {
+		if value, ok := os.LookupEnv(key); ok {
+			fmt.Fprintf(b, "%s=%s\n", key, value)
+		}
+	}
+}
+
+// goroutineDump returns the stacks of all goroutines, growing the buffer
+// until the dump fits.
+func goroutineDump() []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// sanitiseFileName makes a test name, which may contain slashes and spaces
+// from subtests, safe to use in a file name.
+func sanitiseFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
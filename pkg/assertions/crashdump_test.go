@@ -0,0 +1,137 @@
+package assertions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// artifactMockT is a behaviorMockT that names its test and provides an artifact directory.
+type artifactMockT struct {
+	behaviorMockT
+	name, dir string
+}
+
+func (m *artifactMockT) Name() string        { return m.name }
+func (m *artifactMockT) ArtifactDir() string { return m.dir }
+
+// crashDumpFile extracts and reads the bundle named in a failure message.
+func crashDumpFile(t *testing.T, message string) (string, string) {
+	t.Helper()
+	_, path, ok := strings.Cut(message, "\n  crash dump: ")
+	if !ok {
+		t.Fatalf("Expected failure to name the crash dump, got: %s", message)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected crash dump to be readable: %v", err)
+	}
+	return path, string(content)
+}
+
+// TestUseFatal tests that fatal failures call FailNow after reporting.
+func TestUseFatal(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock).With(UseFatal(true))
+
+	assert.Equal(1, 1)
+	if mock.failNowCalls != 0 {
+		t.Fatalf("Expected passing assertion not to call FailNow")
+	}
+
+	assert.Equal(1, 2)
+	if len(mock.errorCalls) != 1 || mock.failNowCalls != 1 {
+		t.Errorf("Expected 1 Errorf and 1 FailNow call, got %d and %d", len(mock.errorCalls), mock.failNowCalls)
+	}
+
+	New(mock).Equal(1, 2)
+	if mock.failNowCalls != 1 {
+		t.Errorf("Expected non-fatal Assert not to call FailNow, got %d calls", mock.failNowCalls)
+	}
+}
+
+// TestCrashDumpBundle tests the contents of the diagnostic bundle.
+func TestCrashDumpBundle(t *testing.T) {
+	dir := t.TempDir()
+	mock := &behaviorMockT{}
+	config := CrashDumpConfig{Dir: dir, Logs: func() string { return "connecting to db\nretrying\n" }}
+
+	New(mock).With(UseCrashDump(config)).Equal("ready", "starting")
+
+	if len(mock.errorCalls) != 1 || mock.failNowCalls != 1 {
+		t.Fatalf("Expected 1 Errorf and 1 FailNow call, got %d and %d", len(mock.errorCalls), mock.failNowCalls)
+	}
+	path, content := crashDumpFile(t, mock.errorCalls[0])
+	if filepath.Dir(path) != dir {
+		t.Errorf("Expected crash dump in %s, got %s", dir, path)
+	}
+
+	for _, want := range []string{
+		"== failure ==\nvalues differ",
+		"== environment ==\ngo:         go",
+		"== logs ==\nconnecting to db\nretrying\n",
+		"== goroutines ==\ngoroutine ",
+		"TestCrashDumpBundle",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected crash dump to contain %q, got:\n%.2000s", want, content)
+		}
+	}
+}
+
+// TestCrashDumpUsesArtifactDir tests the default directory and file naming.
+func TestCrashDumpUsesArtifactDir(t *testing.T) {
+	mock := &artifactMockT{name: "TestCheckout/empty cart", dir: t.TempDir()}
+
+	New(mock).With(UseCrashDump(CrashDumpConfig{})).True(false)
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	path, content := crashDumpFile(t, mock.errorCalls[0])
+	if filepath.Dir(path) != mock.dir {
+		t.Errorf("Expected crash dump in the artifact directory %s, got %s", mock.dir, path)
+	}
+	if !strings.HasPrefix(filepath.Base(path), "gowise-crash-TestCheckout_empty_cart-") {
+		t.Errorf("Expected file name derived from the test name, got %s", filepath.Base(path))
+	}
+	if !strings.HasPrefix(content, "gowise crash dump for TestCheckout/empty cart\n") {
+		t.Errorf("Expected header naming the test, got:\n%.200s", content)
+	}
+	if strings.Contains(content, "== logs ==") {
+		t.Errorf("Expected no logs section without a Logs source")
+	}
+}
+
+// TestCrashDumpWithoutTestingT tests that the bundle is written when the
+// testing context does not report failures itself.
+func TestCrashDumpWithoutTestingT(t *testing.T) {
+	dir := t.TempDir()
+	assert := New(recordingT{}).With(UseCrashDump(CrashDumpConfig{Dir: dir}))
+
+	assert.Equal("ready", "starting")
+
+	path, content := crashDumpFile(t, assert.Error())
+	if filepath.Dir(path) != dir || !strings.Contains(content, "== failure ==\nvalues differ") {
+		t.Errorf("Expected the failure's crash dump in %s, got %s:\n%.200s", dir, path, content)
+	}
+}
+
+// TestCrashDumpWriteError tests that a bundle that cannot be written is reported.
+func TestCrashDumpWriteError(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &behaviorMockT{}
+	New(mock).With(UseCrashDump(CrashDumpConfig{Dir: filepath.Join(blocker, "dumps")})).True(false)
+
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "failed to write crash dump") {
+		t.Errorf("Expected write failure to be reported, got: %v", mock.errorCalls)
+	}
+	if mock.failNowCalls != 1 {
+		t.Errorf("Expected FailNow regardless, got %d calls", mock.failNowCalls)
+	}
+}
@@ -172,6 +172,67 @@ func TestDeepDiff(t *testing.T) {
 	}
 }
 
+// listNode is a self-referential type for cyclic comparisons.
+type listNode struct {
+	Value    int
+	Next     *listNode
+	Children []*listNode
+	Name     string
+	Tags     []string
+}
+
+// cyclicList returns a two-node list whose last node points back to the first
+// and whose first node lists itself as a child.
+func cyclicList(second int) *listNode {
+	head := &listNode{Value: 1, Name: "a rather long node name to widen the rendering", Tags: []string{"x", "y"}}
+	head.Next = &listNode{Value: second, Next: head}
+	head.Children = []*listNode{head, head.Next}
+	return head
+}
+
+// TestDiffAssertionsOnCyclicValues tests that the diff assertions terminate
+// on self-referential values, passing equal ones and reporting differences.
+func TestDiffAssertionsOnCyclicValues(t *testing.T) {
+	tests := []struct {
+		name           string
+		assert         func(a *Assert)
+		shouldPass     bool
+		expectContains string
+	}{
+		{"DeepDiff equal", func(a *Assert) { a.DeepDiff(cyclicList(2), cyclicList(2)) }, true, ""},
+		{"DeepDiff differing", func(a *Assert) { a.DeepDiff(cyclicList(2), cyclicList(3)) }, false, "values differ"},
+		{"StructDiff equal", func(a *Assert) { a.StructDiff(*cyclicList(2), *cyclicList(2)) }, true, ""},
+		{"StructDiff differing", func(a *Assert) { a.StructDiff(*cyclicList(2), *cyclicList(3)) }, false, `field "Next"`},
+		{"SliceDiffGeneric differing", func(a *Assert) {
+			a.SliceDiffGeneric(cyclicList(2).Children, cyclicList(3).Children)
+		}, false, "slices differ at index 0"},
+		{"Equal differing", func(a *Assert) { a.Equal(cyclicList(2), cyclicList(3)) }, false, "… (cycle)"},
+		{"budget on cyclic fields", func(a *Assert) {
+			a.WithMaxDifferences(5).StructDiff(*cyclicList(2), *cyclicList(3))
+		}, false, "structs differ in 2 fields"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectContains) {
+				t.Errorf("Error message missing expected content %q\nFull error message:\n%s", tt.expectContains, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
 // silentT is defined in assertions_passing_test.go - shared across test files
 
 // ExampleAssert_DeepDiff demonstrates proper usage of universal deep diff assertion
@@ -0,0 +1,92 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UseMaxDifferences sets the difference budget of the diff assertions:
+// SliceDiff, SliceDiffGeneric, MapDiff, StructDiff and DeepDiff. With a budget
+// of n they compare every index, key or field and report up to n differences
+// in one failure, with a count of the rest, instead of stopping at the first.
+// Zero or less restores stopping at the first difference.
+func UseMaxDifferences(n int) Option {
+	return func(a *Assert) { a.maxDifferences = n }
+}
+
+// WithMaxDifferences returns a new Assert that reports up to n differences per
+// diff assertion, as set by UseMaxDifferences.
+//
+// Example:
+//
+//	assert.WithMaxDifferences(10).StructDiff(got, want)
+//	// structs differ in 3 fields
+//	//   field "Name"
+//	//     got: Bob
+//	//     want: Alice
+//	//   ...
+//
+//funcgen:skip
+func (a *Assert) WithMaxDifferences(n int) *Assert {
+	return a.With(UseMaxDifferences(n))
+}
+
+// difference is one index, key or field found to differ by a diff assertion.
+type difference struct {
+	joiner   string        // Joins "<kind> differ" to the description when it is the only difference
+	describe func() string // Where the values differ, then the values on indented lines
+}
+
+// differenceList collects the differences found by a diff assertion within
+// the Assert's difference budget, counting those beyond it.
+type differenceList struct {
+	limit int // Differences to describe; 0 stops at the first
+	found []difference
+	total int
+}
+
+func (a *Assert) newDifferenceList() *differenceList {
+	return &differenceList{limit: a.maxDifferences}
+}
+
+// add records a difference and reports whether to keep looking for more.
+func (l *differenceList) add(joiner string, describe func() string) bool {
+	l.total++
+	if len(l.found) < max(l.limit, 1) {
+		l.found = append(l.found, difference{joiner, describe})
+	}
+	return l.limit > 0
+}
+
+// reportDifferences fails the assertion if l holds any differences. A single
+// difference reads "<kind> differ at <description>"; several are listed under
+// a "<kind> differ in <count> <units>" heading, followed by a count of those
+// beyond the budget.
+func (a *Assert) reportDifferences(l *differenceList, kind, unit, units string) {
+	if l.total == 0 || !a.markAsFailed() {
+		return
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.fail(func() string {
+		if l.total == 1 {
+			return kind + " differ" + l.found[0].joiner + l.found[0].describe()
+		}
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s differ in %d %s", kind, l.total, units)
+		for _, d := range l.found {
+			b.WriteString("\n  " + strings.ReplaceAll(d.describe(), "\n", "\n  "))
+		}
+		if more := l.total - len(l.found); more > 0 {
+			noun := units
+			if more == 1 {
+				noun = unit
+			}
+			fmt.Fprintf(&b, "\n  ... and %d more %s", more, noun)
+		}
+		return b.String()
+	})
+}
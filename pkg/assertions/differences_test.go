@@ -0,0 +1,90 @@
+package assertions
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestMaxDifferences tests that the diff assertions report up to the
+// difference budget in one failure.
+func TestMaxDifferences(t *testing.T) {
+	got := Person{Name: "Bob", Age: 25, City: "Manchester"}
+	want := Person{Name: "Alice", Age: 30, City: "London"}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"no budget stops at the first", func(a *Assert) { a.StructDiff(got, want) }, false,
+			"structs differ at field \"Name\"\n  got: Bob\n  want: Alice"},
+		{"struct fields within budget", func(a *Assert) { a.WithMaxDifferences(5).StructDiff(got, want) }, false,
+			"structs differ in 3 fields\n" +
+				"  field \"Name\"\n    got: Bob\n    want: Alice\n" +
+				"  field \"Age\"\n    got: 25\n    want: 30\n" +
+				"  field \"City\"\n    got: Manchester\n    want: London"},
+		{"struct fields beyond budget are counted", func(a *Assert) { a.WithMaxDifferences(1).StructDiff(got, want) }, false,
+			"structs differ in 3 fields\n  field \"Name\"\n    got: Bob\n    want: Alice\n  ... and 2 more fields"},
+		{"single difference keeps the short form", func(a *Assert) {
+			a.WithMaxDifferences(5).StructDiff(Person{Name: "Bob"}, Person{Name: "Alice"})
+		}, false, "structs differ at field \"Name\"\n  got: Bob\n  want: Alice"},
+		{"map keys", func(a *Assert) {
+			a.WithMaxDifferences(2).MapDiff(map[string]int{"a": 1, "b": 5, "z": 9}, map[string]int{"a": 2, "b": 2, "c": 3})
+		}, false,
+			"maps differ in 4 keys\n" +
+				"  missing key \"c\"\n    expected value: 3\n" +
+				"  unexpected key \"z\"\n    got value: 9\n" +
+				"  ... and 2 more keys"},
+		{"slice indices", func(a *Assert) { a.WithMaxDifferences(2).SliceDiff([]int{1, 9, 3, 8}, []int{1, 2, 3, 4}) }, false,
+			"slices differ in 2 indices\n  index 1\n    got: 9\n    want: 2\n  index 3\n    got: 8\n    want: 4"},
+		{"DeepDiff routes the budget", func(a *Assert) {
+			a.With(UseMaxDifferences(3)).DeepDiff([]string{"x", "b", "y", "z"}, []string{"a", "b", "c", "d"})
+		}, false, "slices differ in 3 indices\n  index 0\n    got: x\n    want: a\n  index 2\n    got: y\n    want: c\n  index 3\n    got: z\n    want: d"},
+		{"equal values pass", func(a *Assert) { a.WithMaxDifferences(5).StructDiff(want, want) }, true, ""},
+		{"zero restores the first difference", func(a *Assert) {
+			a.WithMaxDifferences(5).WithMaxDifferences(0).StructDiff(got, want)
+		}, false, "structs differ at field \"Name\"\n  got: Bob\n  want: Alice"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if mock.errorCalls[0] != tt.expectMessage {
+				t.Errorf("Expected message:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// ExampleAssert_WithMaxDifferences reports every differing field in one
+// failure.
+func ExampleAssert_WithMaxDifferences() {
+	mock := &behaviorMockT{}
+	assert := New(mock).WithMaxDifferences(10)
+
+	assert.StructDiff(
+		Person{Name: "Bob", Age: 25, City: "London"},
+		Person{Name: "Alice", Age: 30, City: "London"},
+	)
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// structs differ in 2 fields
+	//   field "Name"
+	//     got: Bob
+	//     want: Alice
+	//   field "Age"
+	//     got: 25
+	//     want: 30
+}
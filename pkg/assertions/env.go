@@ -0,0 +1,115 @@
+package assertions
+
+import "os"
+
+// EnvSet asserts that the environment variable key is set, possibly to the
+// empty string. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.EnvSet("DATABASE_URL")
+func (a *Assert) EnvSet(key string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if _, ok := os.LookupEnv(key); !ok {
+		a.reportMessagef("expected environment variable to be set\n  key: %s", key)
+	}
+	return a
+}
+
+// EnvEqual asserts that the environment variable key is set to value.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.EnvEqual("APP_ENV", "test")
+func (a *Assert) EnvEqual(key, value string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	got, ok := os.LookupEnv(key)
+	switch {
+	case !ok:
+		a.reportMessagef("expected environment variable to be set\n  key:  %s\n  want: %s", key, formatString(value, a.formatOptions))
+	case got != value:
+		a.reportMessagef("environment variable differs\n  key:  %s\n  got:  %s\n  want: %s",
+			key, formatString(got, a.formatOptions), formatString(value, a.formatOptions))
+	}
+	return a
+}
+
+// EnvUnset asserts that the environment variable key is not set. A variable
+// set to the empty string is set. Returns *Assert to enable method chaining.
+func (a *Assert) EnvUnset(key string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if got, ok := os.LookupEnv(key); ok {
+		a.reportMessagef("expected environment variable to be unset\n  key: %s\n  got: %s", key, formatString(got, a.formatOptions))
+	}
+	return a
+}
+
+// WithEnv sets the environment variable key to value, runs fn and restores
+// the variable, unsetting it if it was unset before, even if fn panics. A
+// failure to set or restore the variable is reported. The environment belongs
+// to the whole process, so WithEnv must not be used from parallel tests; with
+// a *testing.T, t.Setenv enforces that. Returns *Assert to enable method
+// chaining.
+//
+// Example:
+//
+//	assert.WithEnv("TZ", "Europe/London", func() {
+//		assert.Equal(config.Load().Zone, "Europe/London")
+//	})
+//
+//funcgen:skip
+func (a *Assert) WithEnv(key, value string, fn func()) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	previous, wasSet := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		a.reportMessagef("failed to set environment variable\n  key:   %s\n  error: %v", key, err)
+		return a
+	}
+	defer func() {
+		var err error
+		if wasSet {
+			err = os.Setenv(key, previous)
+		} else {
+			err = os.Unsetenv(key)
+		}
+		if err != nil {
+			a.reportMessagef("failed to restore environment variable\n  key:   %s\n  error: %v", key, err)
+		}
+	}()
+
+	fn()
+	return a
+}
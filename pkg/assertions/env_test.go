@@ -0,0 +1,84 @@
+package assertions
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestEnvAssertions tests EnvSet, EnvEqual and EnvUnset, and that WithEnv
+// restores the previous state of a variable.
+func TestEnvAssertions(t *testing.T) {
+	t.Setenv("GOWISE_TEST_SET", "value")
+	t.Setenv("GOWISE_TEST_EMPTY", "")
+	os.Unsetenv("GOWISE_TEST_UNSET")
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"EnvSet", func(a *Assert) { a.EnvSet("GOWISE_TEST_SET") }, true, ""},
+		{"EnvSet empty", func(a *Assert) { a.EnvSet("GOWISE_TEST_EMPTY") }, true, ""},
+		{"EnvSet fails", func(a *Assert) { a.EnvSet("GOWISE_TEST_UNSET") }, false,
+			"expected environment variable to be set\n  key: GOWISE_TEST_UNSET"},
+		{"EnvEqual", func(a *Assert) { a.EnvEqual("GOWISE_TEST_SET", "value") }, true, ""},
+		{"EnvEqual empty", func(a *Assert) { a.EnvEqual("GOWISE_TEST_EMPTY", "") }, true, ""},
+		{"EnvEqual differs", func(a *Assert) { a.EnvEqual("GOWISE_TEST_SET", "other") }, false,
+			"environment variable differs\n  key:  GOWISE_TEST_SET\n  got:  \"value\"\n  want: \"other\""},
+		{"EnvEqual unset", func(a *Assert) { a.EnvEqual("GOWISE_TEST_UNSET", "") }, false, "expected environment variable to be set"},
+		{"EnvUnset", func(a *Assert) { a.EnvUnset("GOWISE_TEST_UNSET") }, true, ""},
+		{"EnvUnset empty is set", func(a *Assert) { a.EnvUnset("GOWISE_TEST_EMPTY") }, false,
+			"expected environment variable to be unset\n  key: GOWISE_TEST_EMPTY"},
+		{"WithEnv", func(a *Assert) {
+			a.WithEnv("GOWISE_TEST_SET", "scoped", func() { a.EnvEqual("GOWISE_TEST_SET", "scoped") })
+		}, true, ""},
+		{"WithEnv reports inner failure", func(a *Assert) {
+			a.WithEnv("GOWISE_TEST_UNSET", "scoped", func() { a.EnvUnset("GOWISE_TEST_UNSET") })
+		}, false, "expected environment variable to be unset"},
+		{"WithEnv invalid key", func(a *Assert) { a.WithEnv("", "x", func() {}) }, false, "failed to set environment variable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+
+	t.Run("WithEnv restores", func(t *testing.T) {
+		a := New(&behaviorMockT{})
+		a.WithEnv("GOWISE_TEST_SET", "scoped", func() {})
+		a.WithEnv("GOWISE_TEST_UNSET", "scoped", func() {})
+
+		if got := os.Getenv("GOWISE_TEST_SET"); got != "value" {
+			t.Errorf("Expected GOWISE_TEST_SET restored to %q, got %q", "value", got)
+		}
+		if _, ok := os.LookupEnv("GOWISE_TEST_UNSET"); ok {
+			t.Error("Expected GOWISE_TEST_UNSET to be unset again")
+		}
+	})
+
+	t.Run("WithEnv restores after panic", func(t *testing.T) {
+		func() {
+			defer func() { _ = recover() }()
+			New(&behaviorMockT{}).WithEnv("GOWISE_TEST_SET", "scoped", func() { panic("boom") })
+		}()
+
+		if got := os.Getenv("GOWISE_TEST_SET"); got != "value" {
+			t.Errorf("Expected GOWISE_TEST_SET restored to %q, got %q", "value", got)
+		}
+	})
+}
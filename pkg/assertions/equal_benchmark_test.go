@@ -0,0 +1,74 @@
+package assertions
+
+import (
+	"testing"
+)
+
+// primitivePairs are equal values of each type Equal compares without
+// reflection, boxed once so that the benchmarks measure Equal rather than the
+// conversion to interface{} at the call site.
+var primitivePairs = []struct {
+	name      string
+	got, want interface{}
+}{
+	{"Int", 1000, 1000},
+	{"Int64", int64(-1 << 40), int64(-1 << 40)},
+	{"Uint8", uint8(200), uint8(200)},
+	{"Float64", 3.25, 3.25},
+	{"String", "order-1042", "order-1042"},
+	{"Bool", true, true},
+}
+
+// BenchmarkEqualPrimitives measures the success path of Equal and NotEqual
+// on primitive values, which should not allocate.
+func BenchmarkEqualPrimitives(b *testing.B) {
+	assert := New(&mockT{})
+	for _, pair := range primitivePairs {
+		b.Run("Equal/"+pair.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				assert.Equal(pair.got, pair.want)
+			}
+		})
+	}
+
+	b.Run("NotEqual/Int", func(b *testing.B) {
+		var got, want interface{} = 1, 2
+		b.ReportAllocs()
+		for b.Loop() {
+			assert.NotEqual(got, want)
+		}
+	})
+}
+
+// TestEqualPrimitivesDoNotAllocate tests that Equal and NotEqual make no
+// allocations of their own when primitive values pass.
+func TestEqualPrimitivesDoNotAllocate(t *testing.T) {
+	assert := New(&mockT{})
+	for _, pair := range primitivePairs {
+		if allocs := testing.AllocsPerRun(100, func() { assert.Equal(pair.got, pair.want) }); allocs != 0 {
+			t.Errorf("Expected Equal on %s to make no allocations, got %g", pair.name, allocs)
+		}
+	}
+
+	var got, want interface{} = 1, 2
+	if allocs := testing.AllocsPerRun(100, func() { assert.NotEqual(got, want) }); allocs != 0 {
+		t.Errorf("Expected NotEqual on ints to make no allocations, got %g", allocs)
+	}
+}
+
+// TestEqualPrimitivesTypeMismatch tests that values of different primitive
+// types are unequal even when they print alike.
+func TestEqualPrimitivesTypeMismatch(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).Equal(int64(5), 5)
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected int64(5) and int 5 to differ, got %d failures", len(mock.errorCalls))
+	}
+
+	mock = &behaviorMockT{}
+	New(mock).NotEqual(uint8(7), 7)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected uint8(7) and int 7 to be unequal, got %v", mock.errorCalls)
+	}
+}
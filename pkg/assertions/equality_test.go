@@ -2,6 +2,7 @@ package assertions
 
 import (
 	"math"
+	"strings"
 	"testing"
 	"time"
 )
@@ -279,3 +280,97 @@ func TestBooleanAssertions(t *testing.T) {
 		}
 	})
 }
+
+// TestEqualElidesUnchangedSubtrees tests that large composite failures show only the changed paths.
+func TestEqualElidesUnchangedSubtrees(t *testing.T) {
+	type address struct {
+		Street, City, Postcode string
+	}
+	type customer struct {
+		Name     string
+		Address  address
+		Orders   []int
+		Metadata map[string]string
+	}
+
+	got := customer{
+		Name:     "Ada Lovelace",
+		Address:  address{"12 St James's Square", "London", "SW1Y 4JH"},
+		Orders:   []int{1001, 1002, 1003, 1004, 1005},
+		Metadata: map[string]string{"tier": "gold", "region": "eu-west"},
+	}
+	want := got
+	want.Address.City = "Oxford"
+
+	mock := &behaviorMockT{}
+	New(mock).Equal(got, want)
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	message := mock.errorCalls[0]
+	for _, expected := range []string{"diff (- got, + want):", "Orders: … (unchanged)", `  -       City: "London"`, `  +       City: "Oxford"`} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected %q in message:\n%s", expected, message)
+		}
+	}
+	if strings.Contains(message, "1004") {
+		t.Errorf("Expected unchanged orders to be elided, got:\n%s", message)
+	}
+}
+
+// TestEqualStructuralDiffIsBounded tests that structural diffs of very large
+// values respect the format limits and cap the differences listed.
+func TestEqualStructuralDiffIsBounded(t *testing.T) {
+	type document struct {
+		ID   int
+		Body string
+	}
+	type series struct {
+		Name   string
+		Points []int
+	}
+	points, shifted := make([]int, 100000), make([]int, 100000)
+	for i := range shifted {
+		shifted[i] = i + 1
+	}
+
+	tests := []struct {
+		name      string
+		got, want interface{}
+		expect    string
+	}{
+		{"large string field", document{1, strings.Repeat("a", 1<<20)}, document{1, strings.Repeat("b", 1<<20)},
+			"(1046576 more bytes)"},
+		{"every element differs", series{"cpu", points}, series{"cpu", shifted},
+			"… (99950 more differing elements)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			New(mock).Equal(tt.got, tt.want)
+
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+			}
+			message := mock.errorCalls[0]
+			if len(message) > 16<<10 {
+				t.Errorf("Expected a bounded message, got %d bytes", len(message))
+			}
+			if !strings.Contains(message, "diff (- got, + want):") || !strings.Contains(message, tt.expect) {
+				t.Errorf("Expected a structural diff containing %q, got:\n%.2000s", tt.expect, message)
+			}
+		})
+	}
+}
+
+// TestEqualSmallCompositeKeepsGotWant tests that values fitting on one line are shown in full.
+func TestEqualSmallCompositeKeepsGotWant(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).Equal([]int{1, 2}, []int{1, 3})
+
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "got:  []int{1, 2}") {
+		t.Errorf("Expected got/want layout for small values, got: %v", mock.errorCalls)
+	}
+}
@@ -0,0 +1,133 @@
+package assertions
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+// maxOutputExcerpt bounds how much of a command's stdout or stderr a failure
+// message shows.
+const maxOutputExcerpt = 1024
+
+// commandResult is what runCommand observed of a finished command.
+type commandResult struct {
+	exitCode int
+	stdout   string
+	stderr   string
+}
+
+// runCommand runs cmd to completion, capturing its stdout and stderr unless
+// the caller already set them, in which case the captured output is empty.
+// A command that cannot be started, or that is killed by a signal, is
+// reported and ok is false; a non-zero exit is not an error here.
+func (a *Assert) runCommand(cmd *exec.Cmd) (result commandResult, ok bool) {
+	if cmd == nil {
+		a.reportMessagef("cannot run nil command")
+		return result, false
+	}
+
+	var stdout, stderr bytes.Buffer
+	if cmd.Stdout == nil {
+		cmd.Stdout = &stdout
+	}
+	if cmd.Stderr == nil {
+		cmd.Stderr = &stderr
+	}
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		a.reportMessagef("failed to run command\n  command: %s\n  error:   %v", cmd, err)
+		return result, false
+	}
+	result = commandResult{
+		exitCode: cmd.ProcessState.ExitCode(),
+		stdout:   stdout.String(),
+		stderr:   stderr.String(),
+	}
+	if result.exitCode == -1 {
+		a.reportMessagef("command did not exit normally\n  command: %s\n  state:   %s%s", cmd, cmd.ProcessState, result.outputExcerpt(a))
+		return result, false
+	}
+	return result, true
+}
+
+// outputExcerpt renders the captured output for a failure message, omitting
+// streams that produced nothing.
+func (r commandResult) outputExcerpt(a *Assert) string {
+	var b strings.Builder
+	for _, stream := range []struct{ name, text string }{{"stdout", r.stdout}, {"stderr", r.stderr}} {
+		if stream.text == "" {
+			continue
+		}
+		text := stream.text
+		if len(text) > maxOutputExcerpt {
+			text = text[:maxOutputExcerpt] + "…"
+		}
+		b.WriteString("\n  " + stream.name + ": " + formatString(text, a.formatOptions))
+	}
+	return b.String()
+}
+
+// ExitsWithCode runs cmd and asserts that it exits with code. The command must
+// not have been started, and each assertion runs it once, so check several
+// things about one run by capturing its output yourself. Stdout and stderr
+// are captured, unless already set, and shown on failure.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.ExitsWithCode(exec.Command("./mytool", "--version"), 0)
+func (a *Assert) ExitsWithCode(cmd *exec.Cmd, code int) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	result, ok := a.runCommand(cmd)
+	if ok && result.exitCode != code {
+		a.reportMessagef("command exit code differs\n  command: %s\n  got:     %d\n  want:    %d%s",
+			cmd, result.exitCode, code, result.outputExcerpt(a))
+	}
+	return a
+}
+
+// StdoutContains runs cmd and asserts that it exits with code 0 and writes
+// substr to stdout. The command must not have been started, must not have its
+// Stdout set, and is run once per assertion.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.StdoutContains(exec.Command("./mytool", "--help"), "Usage:")
+func (a *Assert) StdoutContains(cmd *exec.Cmd, substr string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if cmd != nil && cmd.Stdout != nil {
+		a.reportMessagef("StdoutContains needs to capture stdout, but the command's Stdout is already set\n  command: %s", cmd)
+		return a
+	}
+	result, ok := a.runCommand(cmd)
+	switch {
+	case !ok:
+	case result.exitCode != 0:
+		a.reportMessagef("command failed\n  command:   %s\n  exit code: %d%s", cmd, result.exitCode, result.outputExcerpt(a))
+	case !strings.Contains(result.stdout, substr):
+		a.reportMessagef("expected command stdout to contain substring\n  command:   %s\n  substring: %s%s",
+			cmd, formatString(substr, a.formatOptions), result.outputExcerpt(a))
+	}
+	return a
+}
@@ -0,0 +1,92 @@
+package assertions
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// helperCommand returns a command that re-runs the test binary as
+// TestHelperProcess, which prints stdout and stderr and exits with code.
+func helperCommand(t *testing.T, code int, stdout, stderr string) *exec.Cmd {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperProcess$")
+	cmd.Env = append(os.Environ(),
+		"GOWISE_WANT_HELPER_PROCESS=1",
+		fmt.Sprintf("GOWISE_HELPER_CODE=%d", code),
+		"GOWISE_HELPER_STDOUT="+stdout,
+		"GOWISE_HELPER_STDERR="+stderr,
+	)
+	return cmd
+}
+
+// TestHelperProcess is not a real test: it is the child process run by
+// helperCommand.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GOWISE_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, os.Getenv("GOWISE_HELPER_STDOUT"))
+	fmt.Fprint(os.Stderr, os.Getenv("GOWISE_HELPER_STDERR"))
+	var code int
+	fmt.Sscan(os.Getenv("GOWISE_HELPER_CODE"), &code)
+	os.Exit(code)
+}
+
+// TestProcessAssertions tests ExitsWithCode and StdoutContains against a
+// helper process.
+func TestProcessAssertions(t *testing.T) {
+	started := helperCommand(t, 0, "", "")
+	if err := started.Run(); err != nil {
+		t.Fatalf("helper process failed: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"ExitsWithCode zero", func(a *Assert) { a.ExitsWithCode(helperCommand(t, 0, "ok", ""), 0) }, true, ""},
+		{"ExitsWithCode non-zero", func(a *Assert) { a.ExitsWithCode(helperCommand(t, 3, "", ""), 3) }, true, ""},
+		{"ExitsWithCode differs", func(a *Assert) { a.ExitsWithCode(helperCommand(t, 2, "partial", "bad flag"), 0) }, false,
+			"  got:     2\n  want:    0\n  stdout: \"partial\"\n  stderr: \"bad flag\""},
+		{"ExitsWithCode missing binary", func(a *Assert) { a.ExitsWithCode(exec.Command("/nonexistent/gowise-tool"), 0) }, false,
+			"failed to run command"},
+		{"ExitsWithCode already started", func(a *Assert) { a.ExitsWithCode(started, 0) }, false, "already started"},
+		{"ExitsWithCode nil", func(a *Assert) { a.ExitsWithCode(nil, 0) }, false, "cannot run nil command"},
+
+		{"StdoutContains", func(a *Assert) { a.StdoutContains(helperCommand(t, 0, "Usage: tool [flags]", ""), "Usage:") }, true, ""},
+		{"StdoutContains missing", func(a *Assert) { a.StdoutContains(helperCommand(t, 0, "hello", "warning"), "Usage:") }, false,
+			"expected command stdout to contain substring"},
+		{"StdoutContains ignores stderr", func(a *Assert) { a.StdoutContains(helperCommand(t, 0, "", "Usage:"), "Usage:") }, false,
+			"stderr: \"Usage:\""},
+		{"StdoutContains non-zero exit", func(a *Assert) { a.StdoutContains(helperCommand(t, 1, "Usage:", "boom"), "Usage:") }, false,
+			"command failed"},
+		{"StdoutContains Stdout set", func(a *Assert) {
+			cmd := helperCommand(t, 0, "", "")
+			cmd.Stdout = &strings.Builder{}
+			a.StdoutContains(cmd, "x")
+		}, false, "Stdout is already set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package assertions
+
+// Fail reports a failure with message, for assertions built in other
+// packages. It takes part in fail-fast chaining like any built-in assertion:
+// once a has failed, later failures are not reported.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	func HasStatus(a *assertions.Assert, resp *http.Response, want int) *assertions.Assert {
+//		if h, ok := a.T().(interface{ Helper() }); ok {
+//			h.Helper()
+//		}
+//		if resp.StatusCode != want {
+//			return a.Fail(fmt.Sprintf("expected status %d, got %d", want, resp.StatusCode))
+//		}
+//		return a
+//	}
+//
+//funcgen:skip
+func (a *Assert) Fail(message string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.reportMessageConsistent(message)
+	return a
+}
+
+// T returns the testing context a reports to, so that assertions built in
+// other packages can mark themselves as helpers or register cleanups.
+func (a *Assert) T() interface{} {
+	return a.t
+}
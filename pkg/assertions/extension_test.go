@@ -0,0 +1,24 @@
+package assertions
+
+import (
+	"testing"
+)
+
+// TestFailReportsCustomMessage tests Fail as used by assertions in other packages.
+func TestFailReportsCustomMessage(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	if assert.T() != mock {
+		t.Fatalf("Expected T to return the testing context")
+	}
+
+	assert.Fail("expected Save to be called").Fail("second failure").True(false)
+
+	if len(mock.errorCalls) != 1 || mock.errorCalls[0] != "expected Save to be called" {
+		t.Errorf("Expected only the first failure to be reported verbatim, got: %v", mock.errorCalls)
+	}
+	if !assert.HasFailed() {
+		t.Errorf("Expected Fail to mark the chain as failed")
+	}
+}
@@ -0,0 +1,143 @@
+package assertions
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Failure is a failed assertion as structured data, for reporters and
+// renderers that would otherwise parse the failure message. String renders
+// it as the text reported to the test, and MarshalJSON as a JSON object.
+type Failure struct {
+	Kind      string       // Assertion that failed, such as "Equal"; empty if unknown
+	Message   string       // First line of the report, such as "values differ"
+	Got       interface{}  // Actual value, if HasValues
+	Want      interface{}  // Expected value, if HasValues
+	HasValues bool         // Whether the assertion compared got and want values
+	Diff      string       // Rest of the report: values, diff and notes, indented as reported
+	File      string       // File of the failing call; empty if unknown
+	Line      int          // Line of the failing call
+	Test      string       // Name of the test, if the testing context has one
+	Index     int          // Ordinal of the failing assertion among those evaluated in the chain, from 1
+	Stack     []StackFrame // Calls leading to the assertion, innermost first, with UseStackTraces
+}
+
+// String returns the failure as reported to the test.
+func (f *Failure) String() string {
+	if f.Diff == "" {
+		return f.Message
+	}
+	return f.Message + "\n" + f.Diff
+}
+
+// MarshalJSON encodes the failure as a JSON object. Got and want are
+// rendered as they are in failure messages, since values such as channels
+// and functions have no JSON encoding.
+func (f *Failure) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Kind    string       `json:"kind,omitempty"`
+		Message string       `json:"message"`
+		Got     string       `json:"got,omitempty"`
+		Want    string       `json:"want,omitempty"`
+		Diff    string       `json:"diff,omitempty"`
+		File    string       `json:"file,omitempty"`
+		Line    int          `json:"line,omitempty"`
+		Test    string       `json:"test,omitempty"`
+		Index   int          `json:"index,omitempty"`
+		Stack   []StackFrame `json:"stack,omitempty"`
+	}{Kind: f.Kind, Message: f.Message, Diff: f.Diff, File: f.File, Line: f.Line, Test: f.Test, Index: f.Index, Stack: f.Stack}
+	if f.HasValues {
+		out.Got = formatValue(f.Got, DefaultFormatOptions())
+		out.Want = formatValue(f.Want, DefaultFormatOptions())
+	}
+	return json.Marshal(out)
+}
+
+// setText splits the rendered report text into the failure's message and
+// diff.
+func (f *Failure) setText(text string) {
+	f.Message, f.Diff, _ = strings.Cut(text, "\n")
+}
+
+// LastFailure returns the failure that failed the chain, or nil if no
+// assertion in the chain has failed. As only the first failure of a chain is
+// reported, it is also the last; every Assert derived from the chain with
+// With returns the same one. The result is a copy, safe to keep and modify.
+//
+// Example:
+//
+//	assert.Equal(got, want)
+//	if f := assert.LastFailure(); f != nil {
+//		report.Add(f.Kind, f.File, f.Line, f.Message)
+//	}
+func (a *Assert) LastFailure() *Failure {
+	a.shared.mu.Lock()
+	defer a.shared.mu.Unlock()
+
+	if !a.shared.reported {
+		return nil
+	}
+	a.shared.build()
+	f := a.shared.failure
+	f.Stack = slices.Clone(f.Stack)
+	return &f
+}
+
+// attributed wraps message to end the report with the test's name and the
+// failing assertion's index, so that failures collected from parallel tests,
+// as by a reporter, can be told apart.
+func attributed(message func() string, test string, index int) func() string {
+	return func() string {
+		return fmt.Sprintf("%s\n  test: %s (assertion %d)", message(), test, index)
+	}
+}
+
+// maxFailureFrames bounds the call stack captured for a failure: enough to
+// reach the test through assertions that delegate to one another.
+const maxFailureFrames = 16
+
+// capture starts the chain's failure for an assertion failing now, recording
+// got and want if hasValues is set, and the call stack for build to resolve.
+// The caller holds mu.
+func (s *chainState) capture(got, want interface{}, hasValues bool) {
+	s.failure = Failure{Got: got, Want: want, HasValues: hasValues, Index: int(s.evaluated.Load())}
+	s.frames = runtime.Callers(2, s.stack[:])
+}
+
+// build completes the chain's failure: it renders the text of a failure the
+// testing context did not report, and names and locates the assertion from
+// its call stack. The caller holds mu.
+func (s *chainState) build() {
+	if s.pendingMsg != nil {
+		s.failure.setText(s.pendingMsg())
+		s.pendingMsg = nil
+	}
+	if s.frames > 0 {
+		s.failure.Kind, _ = assertionNameIn(s.stack[:s.frames])
+		s.failure.File, s.failure.Line = callerLocation(s.stack[:s.frames])
+		s.frames = 0
+	}
+}
+
+// libraryPrefix is the symbol prefix of GoWise's packages, whose frames
+// callerLocation skips so that assertions built on this one, such as
+// protoassert.Equal, are located at their caller.
+var libraryPrefix = packagePrefix[:strings.LastIndex(strings.TrimSuffix(packagePrefix, "."), "/")+1]
+
+// callerLocation returns the file and line in the stack captured in pcs of
+// the innermost call into GoWise from outside it, or from one of its tests.
+func callerLocation(pcs []uintptr) (string, int) {
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, libraryPrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			return frame.File, frame.Line
+		}
+		if !more {
+			return "", 0
+		}
+	}
+}
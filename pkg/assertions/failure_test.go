@@ -0,0 +1,184 @@
+package assertions
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestLastFailure tests the structured failure recorded for failing
+// assertions.
+func TestLastFailure(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+	_, _, line, _ := runtime.Caller(0)
+	assert.Equal(1, 2)
+
+	f := assert.LastFailure()
+	if f == nil {
+		t.Fatal("Expected a failure")
+	}
+	if f.Kind != "Equal" || f.Message != "values differ" || f.Diff != "  got:  1\n  want: 2" {
+		t.Errorf("Unexpected failure %+v", f)
+	}
+	if !f.HasValues || f.Got != 1 || f.Want != 2 {
+		t.Errorf("Expected got 1 and want 2, got %+v", f)
+	}
+	if filepath.Base(f.File) != "failure_test.go" || f.Line != line+1 {
+		t.Errorf("Expected the failing call at failure_test.go:%d, got %s:%d", line+1, f.File, f.Line)
+	}
+	if f.String() != mock.errorCalls[0] {
+		t.Errorf("Expected String to render the reported message %q, got %q", mock.errorCalls[0], f.String())
+	}
+}
+
+// TestLastFailureWithoutValues tests failures of assertions that compare no
+// got and want values.
+func TestLastFailureWithoutValues(t *testing.T) {
+	assert := New(&behaviorMockT{})
+	assert.Contains([]int{1}, 3)
+
+	f := assert.LastFailure()
+	if f == nil || f.HasValues || f.Kind != "Contains" || f.Message != "expected to contain element" {
+		t.Errorf("Unexpected failure %+v", f)
+	}
+}
+
+// TestLastFailureOfChain tests that the failure is the chain's first, shared
+// with derived Asserts, and that passing chains have none.
+func TestLastFailureOfChain(t *testing.T) {
+	assert := New(&behaviorMockT{})
+	if assert.Equal(1, 1).LastFailure() != nil {
+		t.Error("Expected no failure for a passing chain")
+	}
+
+	derived := assert.With(UseDiffFormat(DiffFormatUnified))
+	derived.True(false).Equal(1, 2)
+	if f := assert.LastFailure(); f == nil || f.Kind != "True" {
+		t.Errorf("Expected the derived Assert's True failure, got %+v", f)
+	}
+
+	// The result is a copy
+	assert.LastFailure().Message = "changed"
+	if assert.LastFailure().Message == "changed" {
+		t.Error("Expected LastFailure to return a copy")
+	}
+}
+
+// TestLastFailureBuiltWhenConsumed tests that a failure against a context
+// that does not report it is complete when read.
+func TestLastFailureBuiltWhenConsumed(t *testing.T) {
+	assert := New(recordingT{})
+	assert.Equal("shipped", "pending")
+
+	f := assert.LastFailure()
+	if f == nil || f.Kind != "Equal" || f.Message != "values differ" || f.Line == 0 {
+		t.Errorf("Unexpected failure %+v", f)
+	}
+	if f.String() != assert.Error() {
+		t.Errorf("Expected String to match Error, got %q and %q", f.String(), assert.Error())
+	}
+}
+
+// namedT is a testing context with a name, as *testing.T has.
+type namedT struct {
+	behaviorMockT
+	name string
+}
+
+func (n *namedT) Name() string { return n.name }
+
+// TestFailureAttribution tests that failures against a named context end
+// with the test's name and the failing assertion's index.
+func TestFailureAttribution(t *testing.T) {
+	mock := &namedT{name: "TestCheckout/empty_cart"}
+	assert := New(mock)
+	assert.Equal(1, 1).True(true).Equal(len("cart"), 0).True(false)
+
+	want := "values differ\n  got:  4\n  want: 0\n  test: TestCheckout/empty_cart (assertion 3)"
+	if len(mock.errorCalls) != 1 || mock.errorCalls[0] != want {
+		t.Errorf("Expected %q, got %v", want, mock.errorCalls)
+	}
+	if f := assert.LastFailure(); f.Test != "TestCheckout/empty_cart" || f.Index != 3 {
+		t.Errorf("Expected test and index recorded, got %+v", f)
+	}
+
+	// Contexts without a name report no attribution, but still number failures
+	plain := New(&behaviorMockT{})
+	plain.True(true).True(false)
+	if f := plain.LastFailure(); f.Test != "" || f.Index != 2 || strings.Contains(f.String(), "test:") {
+		t.Errorf("Expected an unattributed failure numbered 2, got %+v", f)
+	}
+}
+
+// TestFailureIndexOfDelegatingAssertions tests that an assertion built on
+// another takes one index, as do the assertions chained after it.
+func TestFailureIndexOfDelegatingAssertions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		assert func(a *Assert)
+	}{
+		{"InDelta", func(a *Assert) { a.Equal(1, 1).InDelta(1.0, 2.0, 0.1) }},
+		{"InEpsilon", func(a *Assert) { a.Equal(1, 1).InEpsilon(1.0, 2.0, 0.1) }},
+		{"IsEmpty", func(a *Assert) { a.Equal(1, 1).IsEmpty("x") }},
+		{"DurationWithin", func(a *Assert) { a.Equal(1, 1).DurationWithin(time.Second, time.Minute, time.Millisecond) }},
+		{"DeepDiff", func(a *Assert) { a.Equal(1, 1).DeepDiff([]int{1}, []int{2}) }},
+		{"ReaderJSONEqual", func(a *Assert) { a.Equal(1, 1).ReaderJSONEqual(strings.NewReader(`{"a":1}`), `{"a":2}`) }},
+		{"IsSorted", func(a *Assert) { a.Equal(1, 1).IsSorted([]int{2, 1}) }},
+		{"ResponseTime", func(a *Assert) { a.Equal(1, 1).ResponseTime(server.URL, time.Nanosecond) }},
+		{"chained after delegation", func(a *Assert) { a.InDelta(1.0, 1.0, 0.1).Equal(1, 2) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := New(&behaviorMockT{})
+			tt.assert(assert)
+
+			if f := assert.LastFailure(); f == nil || f.Index != 2 {
+				t.Errorf("Expected the failure numbered 2, got %+v", f)
+			}
+		})
+	}
+}
+
+// TestFailureJSON tests the JSON encoding of failures.
+func TestFailureJSON(t *testing.T) {
+	f := &Failure{Kind: "Equal", Message: "values differ", Got: make(chan int), Want: 2, HasValues: true, File: "x_test.go", Line: 7}
+	data, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("Expected channels to encode, got %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["kind"] != "Equal" || decoded["want"] != "2" || decoded["line"] != 7.0 || decoded["got"] == "" {
+		t.Errorf("Unexpected encoding %s", data)
+	}
+	if _, ok := decoded["diff"]; ok {
+		t.Errorf("Expected an empty diff to be omitted, got %s", data)
+	}
+}
+
+func ExampleAssert_LastFailure() {
+	assert := New(&silentT{})
+	assert.Equal("shipped", "pending")
+
+	f := assert.LastFailure()
+	fmt.Println(f.Kind, "-", f.Message)
+	fmt.Println(f.Got, "vs", f.Want)
+	// Output:
+	// Equal - values differ
+	// shipped vs pending
+}
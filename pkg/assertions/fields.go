@@ -0,0 +1,138 @@
+package assertions
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"strings"
+)
+
+// HasField asserts that obj has the exported field named by path, which may
+// be nested, as in "Address.City", following pointers on the way. A failure
+// lists the exported fields of the struct where the path broke.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.HasField(resp, "Meta.RequestID")
+func (a *Assert) HasField(obj interface{}, path string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if _, problem := lookupField(obj, path); problem != "" {
+		a.reportMessagef("expected field to exist\n%s", problem)
+	}
+	return a
+}
+
+// FieldValue asserts that the exported field of obj named by path, as for
+// HasField, deeply equals want. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.FieldValue(user, "Address.City", "Leeds")
+func (a *Assert) FieldValue(obj interface{}, path string, want interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	field, problem := lookupField(obj, path)
+	if problem != "" {
+		a.reportMessagef("cannot read field value\n%s", problem)
+		return a
+	}
+	if got := field.Interface(); !reflect.DeepEqual(got, want) {
+		a.reportErrorf(got, want, "field value differs\n  field: %s", path)
+	}
+	return a
+}
+
+// FieldSatisfies asserts that predicate holds for the exported field of obj
+// named by path, as for HasField. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.FieldSatisfies(user, "Email", func(v any) bool {
+//		return strings.HasSuffix(v.(string), "@example.com")
+//	})
+func (a *Assert) FieldSatisfies(obj interface{}, path string, predicate func(interface{}) bool) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	field, problem := lookupField(obj, path)
+	if problem != "" {
+		a.reportMessagef("cannot read field value\n%s", problem)
+		return a
+	}
+	if value := field.Interface(); !predicate(value) {
+		a.reportMessagef("expected field to satisfy predicate\n  field: %s\n  value: %s", path, formatValue(value, a.formatOptions))
+	}
+	return a
+}
+
+// lookupField walks the dot-separated path of exported field names from obj,
+// dereferencing pointers and interfaces on the way, and returns the field it
+// names. If the path cannot be followed, it instead returns a description of
+// where and why, listing the available exported fields when a name is not
+// found.
+func lookupField(obj interface{}, path string) (reflect.Value, string) {
+	if obj == nil {
+		return reflect.Value{}, fmt.Sprintf("  field: %s\n  cannot look up fields of nil value", path)
+	}
+
+	v := reflect.ValueOf(obj)
+	names := strings.Split(path, ".")
+	for i, name := range names {
+		at := strings.Join(names[:i], ".")
+		if at == "" {
+			at = "value"
+		}
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, fmt.Sprintf("  field: %s\n  %s is nil (%s)", path, at, v.Type())
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Sprintf("  field: %s\n  %s is %s, not a struct", path, at, v.Type())
+		}
+
+		info := structInfoFor(v.Type())
+		index, ok := info.byName[name]
+		if !ok || !token.IsExported(name) {
+			available := "(none)"
+			if len(info.exported) > 0 {
+				exported := make([]string, len(info.exported))
+				for j, e := range info.exported {
+					exported[j] = info.names[e]
+				}
+				available = strings.Join(exported, ", ")
+			}
+			reason := "no field"
+			if ok {
+				reason = "unexported field"
+			}
+			return reflect.Value{}, fmt.Sprintf("  field: %s\n  %s %q in %s\n  available: %s",
+				path, reason, name, v.Type(), available)
+		}
+		v = v.Field(index)
+	}
+	return v, ""
+}
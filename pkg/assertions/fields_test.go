@@ -0,0 +1,84 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestFieldAssertions tests HasField, FieldValue and FieldSatisfies.
+func TestFieldAssertions(t *testing.T) {
+	type address struct {
+		Street string
+		City   string
+		zip    string
+	}
+	type user struct {
+		Name    string
+		Age     int
+		Email   string
+		Home    *address
+		Tags    []string
+		Extra   any
+		private bool
+	}
+	ann := user{Name: "Ann", Age: 25, Email: "ann@example.com", Home: &address{City: "Leeds"}, Tags: []string{"admin"},
+		Extra: address{City: "York"}}
+	homeless := user{Name: "Bob"}
+	isString := func(v interface{}) bool { _, ok := v.(string); return ok }
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"HasField top level", func(a *Assert) { a.HasField(ann, "Name") }, true, ""},
+		{"HasField through pointer", func(a *Assert) { a.HasField(&ann, "Home.City") }, true, ""},
+		{"HasField through interface", func(a *Assert) { a.HasField(ann, "Extra.City") }, true, ""},
+		{"HasField nil pointer on path passes at the end", func(a *Assert) { a.HasField(homeless, "Home") }, true, ""},
+		{"HasField missing", func(a *Assert) { a.HasField(ann, "Phone") }, false,
+			"expected field to exist\n  field: Phone\n  no field \"Phone\" in assertions.user\n  available: Name, Age, Email, Home, Tags, Extra"},
+		{"HasField missing nested", func(a *Assert) { a.HasField(ann, "Home.Town") }, false,
+			"no field \"Town\" in assertions.address\n  available: Street, City"},
+		{"HasField unexported", func(a *Assert) { a.HasField(ann, "Home.zip") }, false, "unexported field \"zip\" in assertions.address"},
+		{"HasField through nil pointer", func(a *Assert) { a.HasField(homeless, "Home.City") }, false,
+			"  field: Home.City\n  Home is nil (*assertions.address)"},
+		{"HasField not a struct", func(a *Assert) { a.HasField(ann, "Tags.Len") }, false, "Tags is []string, not a struct"},
+		{"HasField on non-struct", func(a *Assert) { a.HasField(42, "X") }, false, "value is int, not a struct"},
+		{"HasField nil", func(a *Assert) { a.HasField(nil, "X") }, false, "cannot look up fields of nil value"},
+
+		{"FieldValue holds", func(a *Assert) { a.FieldValue(ann, "Age", 25) }, true, ""},
+		{"FieldValue nested", func(a *Assert) { a.FieldValue(&ann, "Home.City", "Leeds") }, true, ""},
+		{"FieldValue slice", func(a *Assert) { a.FieldValue(ann, "Tags", []string{"admin"}) }, true, ""},
+		{"FieldValue differs", func(a *Assert) { a.FieldValue(ann, "Age", 26) }, false, "field value differs\n  field: Age"},
+		{"FieldValue type differs", func(a *Assert) { a.FieldValue(ann, "Age", int64(25)) }, false, "field value differs"},
+		{"FieldValue missing", func(a *Assert) { a.FieldValue(ann, "Agee", 25) }, false,
+			"cannot read field value\n  field: Agee\n  no field \"Agee\""},
+
+		{"FieldSatisfies holds", func(a *Assert) {
+			a.FieldSatisfies(ann, "Email", func(v interface{}) bool { return strings.HasSuffix(v.(string), "@example.com") })
+		}, true, ""},
+		{"FieldSatisfies fails", func(a *Assert) { a.FieldSatisfies(ann, "Age", isString) }, false,
+			"expected field to satisfy predicate\n  field: Age\n  value: 25"},
+		{"FieldSatisfies missing", func(a *Assert) { a.FieldSatisfies(ann, "Home.Postcode", isString) }, false,
+			"available: Street, City"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package assertions
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// WithFS returns a new Assert whose file and directory assertions read from
+// fsys instead of the operating system, so they can be exercised against an
+// fstest.MapFS or an embed.FS. Paths are then slash-separated and relative to
+// the root of fsys, as fs.FS requires. Golden files are always read from disk,
+// so that the patches MatchesGolden writes apply to the repository.
+// NOTE: Shares failure state with original for proper fail-fast chaining.
+//
+// Example:
+//
+//	fsys := fstest.MapFS{"config.yaml": {Data: []byte("port: 8080\n")}}
+//	assert.WithFS(fsys).FileContains("config.yaml", "port: 8080")
+//
+//funcgen:skip
+func (a *Assert) WithFS(fsys fs.FS) *Assert {
+	return a.With(UseFS(fsys))
+}
+
+// readFile reads name from the configured filesystem.
+func (a *Assert) readFile(name string) ([]byte, error) {
+	if a.fsys != nil {
+		return fs.ReadFile(a.fsys, name)
+	}
+	return os.ReadFile(name)
+}
+
+// statFile describes name on the configured filesystem.
+func (a *Assert) statFile(name string) (fs.FileInfo, error) {
+	if a.fsys != nil {
+		return fs.Stat(a.fsys, name)
+	}
+	return os.Stat(name)
+}
+
+// readDir lists dir on the configured filesystem, sorted by name.
+func (a *Assert) readDir(dir string) ([]fs.DirEntry, error) {
+	if a.fsys != nil {
+		return fs.ReadDir(a.fsys, dir)
+	}
+	return os.ReadDir(dir)
+}
+
+// joinPath joins path elements using the separator of the configured filesystem.
+func (a *Assert) joinPath(dir, name string) string {
+	if a.fsys != nil {
+		return path.Join(dir, name)
+	}
+	return filepath.Join(dir, name)
+}
+
+// readFileForAssertion reads name, reporting a failure if it cannot be read.
+func (a *Assert) readFileForAssertion(name string) ([]byte, bool) {
+	data, err := a.readFile(name)
+	if err != nil {
+		a.reportMessagef("failed to read file\n  path:  %s\n  error: %v", name, err)
+		return nil, false
+	}
+	return data, true
+}
+
+// isBinary reports whether data should be compared as bytes rather than text:
+// it is not valid UTF-8 or contains a NUL byte.
+func isBinary(data []byte) bool {
+	return !utf8.Valid(data) || bytes.IndexByte(data, 0) >= 0
+}
+
+// FileContains asserts that the file at path contains substring.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.FileContains("out/server.log", "listening on :8080")
+func (a *Assert) FileContains(path, substring string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	data, ok := a.readFileForAssertion(path)
+	if !ok || bytes.Contains(data, []byte(substring)) {
+		return a
+	}
+
+	content := formatValue(string(data), a.formatOptions)
+	if isBinary(data) {
+		content = fmt.Sprintf("<binary, %d bytes>", len(data))
+	}
+	a.reportMessagef("expected file to contain substring\n  path:      %s\n  substring: %q\n  content:   %s",
+		path, substring, content)
+	return a
+}
+
+// FileEqual asserts that the contents of the file at path equal expected.
+// Text files are reported with a line diff; binary files with their sizes and
+// the offset of the first differing byte.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.FileEqual("out/greeting.txt", "Hello, gowise!\n")
+func (a *Assert) FileEqual(path, expected string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	data, ok := a.readFileForAssertion(path)
+	if !ok || string(data) == expected {
+		return a
+	}
+
+	if isBinary(data) || isBinary([]byte(expected)) {
+		a.reportMessagef("file content differs: %s\n  first difference at byte %d\n  got size:  %d bytes\n  want size: %d bytes",
+			path, firstByteDifference(data, []byte(expected)), len(data), len(expected))
+		return a
+	}
+
+	a.reportErrorf(string(data), expected, "file content differs: %s", path)
+	return a
+}
+
+// firstByteDifference returns the offset at which a and b first differ.
+func firstByteDifference(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return min(len(a), len(b))
+}
+
+// FileMatchesGolden asserts that the contents of the file at path equal the
+// golden file at goldenPath, as MatchesGolden does for a string, including
+// patch output when enabled.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.FileMatchesGolden("out/report.html", "testdata/report.golden")
+func (a *Assert) FileMatchesGolden(path, goldenPath string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	data, ok := a.readFileForAssertion(path)
+	if !ok {
+		return a
+	}
+	a.matchGolden(string(data), goldenPath)
+	return a
+}
+
+// maxListedEntries bounds the directory listing shown when a file is missing.
+const maxListedEntries = 20
+
+// DirContainsFile asserts that dir contains an entry called name, which may
+// itself be a directory. On failure the directory's entries are listed.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.DirContainsFile("dist", "index.html")
+func (a *Assert) DirContainsFile(dir, name string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if _, err := a.statFile(a.joinPath(dir, name)); err == nil {
+		return a
+	}
+
+	entries, err := a.readDir(dir)
+	if err != nil {
+		a.reportMessagef("failed to read directory\n  path:  %s\n  error: %v", dir, err)
+		return a
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if len(names) == maxListedEntries {
+			names = append(names, fmt.Sprintf("… (%d more entries)", len(entries)-maxListedEntries))
+			break
+		}
+		entryName := entry.Name()
+		if entry.IsDir() {
+			entryName += "/"
+		}
+		names = append(names, entryName)
+	}
+	listing := "(empty)"
+	if len(names) > 0 {
+		listing = strings.Join(names, ", ")
+	}
+	a.reportMessagef("expected directory to contain %q\n  directory: %s\n  entries:   %s", name, dir, listing)
+	return a
+}
+
+// FilePermissions asserts that the permission bits of the file at path equal
+// perm. Other mode bits, such as the directory flag, are ignored.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.FilePermissions("secrets/token", 0o600)
+func (a *Assert) FilePermissions(path string, perm fs.FileMode) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	info, err := a.statFile(path)
+	if err != nil {
+		a.reportMessagef("failed to stat file\n  path:  %s\n  error: %v", path, err)
+		return a
+	}
+
+	if got := info.Mode().Perm(); got != perm.Perm() {
+		a.reportMessagef("file permissions differ: %s\n  got:  %#o (%s)\n  want: %#o (%s)",
+			path, got, got, perm.Perm(), perm.Perm())
+	}
+	return a
+}
+
+// FileSizeWithin asserts that the size of the file at path, in bytes, lies
+// within [min, max].
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.FileSizeWithin("dist/app.js", 1, 512*1024)
+func (a *Assert) FileSizeWithin(path string, min, max int64) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	info, err := a.statFile(path)
+	if err != nil {
+		a.reportMessagef("failed to stat file\n  path:  %s\n  error: %v", path, err)
+		return a
+	}
+
+	if size := info.Size(); size < min || size > max {
+		a.reportMessagef("expected file size between %d and %d bytes\n  path: %s\n  size: %d bytes",
+			min, max, path, size)
+	}
+	return a
+}
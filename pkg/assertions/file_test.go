@@ -0,0 +1,145 @@
+package assertions
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// testFS is the filesystem the file assertion tests run against.
+var testFS = fstest.MapFS{
+	"config.yaml":       {Data: []byte("name: gowise\nport: 8080\ndebug: false\n"), Mode: 0o644},
+	"secrets/token":     {Data: []byte("s3cr3t"), Mode: 0o600},
+	"dist/index.html":   {Data: []byte("<html></html>")},
+	"dist/app.js":       {Data: []byte(strings.Repeat("x", 2048))},
+	"dist/assets/a.css": {Data: []byte("body{}")},
+	"image.png":         {Data: []byte{0x89, 'P', 'N', 'G', 0x00, 0x01}},
+	"greeting.txt":      {Data: []byte("Hello, gowise!\nVersion: 1\n")},
+}
+
+// TestFileAssertions tests the file and directory assertions against an fs.FS.
+func TestFileAssertions(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"FileContains present", func(a *Assert) { a.FileContains("config.yaml", "port: 8080") }, true, ""},
+		{"FileContains absent", func(a *Assert) { a.FileContains("config.yaml", "port: 9090") }, false, "expected file to contain substring\n  path:      config.yaml\n  substring: \"port: 9090\"\n  content:   \"name: gowise"},
+		{"FileContains binary", func(a *Assert) { a.FileContains("image.png", "JPEG") }, false, "content:   <binary, 6 bytes>"},
+		{"FileContains missing file", func(a *Assert) { a.FileContains("missing.txt", "x") }, false, "failed to read file\n  path:  missing.txt"},
+
+		{"FileEqual equal", func(a *Assert) { a.FileEqual("secrets/token", "s3cr3t") }, true, ""},
+		{"FileEqual text differs", func(a *Assert) { a.FileEqual("config.yaml", "name: gowise\nport: 9090\ndebug: false\n") }, false, "file content differs: config.yaml"},
+		{"FileEqual binary differs", func(a *Assert) { a.FileEqual("image.png", "\x89PNG\x00\x02") }, false, "first difference at byte 5\n  got size:  6 bytes\n  want size: 6 bytes"},
+
+		{"FileMatchesGolden matches", func(a *Assert) { a.FileMatchesGolden("greeting.txt", greetingGolden) }, true, ""},
+		{"FileMatchesGolden differs", func(a *Assert) { a.FileMatchesGolden("config.yaml", greetingGolden) }, false, "output does not match golden file testdata/greeting.golden"},
+
+		{"DirContainsFile file", func(a *Assert) { a.DirContainsFile("dist", "index.html") }, true, ""},
+		{"DirContainsFile subdirectory", func(a *Assert) { a.DirContainsFile("dist", "assets") }, true, ""},
+		{"DirContainsFile missing", func(a *Assert) { a.DirContainsFile("dist", "main.js") }, false, "expected directory to contain \"main.js\"\n  directory: dist\n  entries:   app.js, assets/, index.html"},
+		{"DirContainsFile missing directory", func(a *Assert) { a.DirContainsFile("build", "main.js") }, false, "failed to read directory\n  path:  build"},
+
+		{"FilePermissions match", func(a *Assert) { a.FilePermissions("secrets/token", 0o600) }, true, ""},
+		{"FilePermissions differ", func(a *Assert) { a.FilePermissions("config.yaml", 0o600) }, false, "file permissions differ: config.yaml\n  got:  0644 (-rw-r--r--)\n  want: 0600 (-rw-------)"},
+
+		{"FileSizeWithin inside", func(a *Assert) { a.FileSizeWithin("dist/app.js", 1024, 4096) }, true, ""},
+		{"FileSizeWithin outside", func(a *Assert) { a.FileSizeWithin("dist/app.js", 1, 1024) }, false, "expected file size between 1 and 1024 bytes\n  path: dist/app.js\n  size: 2048 bytes"},
+
+		{"FileExists present", func(a *Assert) { a.FileExists("config.yaml") }, true, ""},
+		{"FileExists missing", func(a *Assert) { a.FileExists("missing.txt") }, false, "file does not exist"},
+		{"DirectoryExists present", func(a *Assert) { a.DirectoryExists("dist/assets") }, true, ""},
+		{"DirectoryExists file", func(a *Assert) { a.DirectoryExists("config.yaml") }, false, "directory does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usePatchDir(t, "")
+			mock := &behaviorMockT{}
+			tt.assert(New(mock).WithFS(testFS))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected assertion to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestFileEqualShowsDiff tests that text file mismatches include a line diff.
+func TestFileEqualShowsDiff(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).WithFS(testFS).FileEqual("config.yaml", "name: gowise\nport: 9090\ndebug: false\n")
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	for _, line := range []string{"port: 8080", "port: 9090"} {
+		if !strings.Contains(mock.errorCalls[0], line) {
+			t.Errorf("Expected diff to show %q, got: %s", line, mock.errorCalls[0])
+		}
+	}
+}
+
+// TestFileAssertionsOnDisk tests that assertions read the operating system's
+// filesystem when no fs.FS is set.
+func TestFileAssertionsOnDisk(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(path, []byte("done\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := &behaviorMockT{}
+	New(mock).
+		FileEqual(path, "done\n").
+		FileContains(path, "done").
+		DirContainsFile(dir, "out.txt").
+		FilePermissions(path, 0o600).
+		FileSizeWithin(path, 5, 5)
+
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected all assertions to pass, got: %v", mock.errorCalls)
+	}
+}
+
+// TestWithFSSharesFailureState tests that WithFS keeps fail-fast chaining.
+func TestWithFSSharesFailureState(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	assert.WithFS(testFS).FileExists("missing.txt")
+	assert.Equal(1, 2)
+
+	if len(mock.errorCalls) != 1 {
+		t.Errorf("Expected only the first failure to be reported, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+	}
+}
+
+// ExampleAssert_WithFS demonstrates file assertions against an in-memory filesystem.
+func ExampleAssert_WithFS() {
+	fsys := fstest.MapFS{
+		"out/report.txt": {Data: []byte("passed: 12\nfailed: 0\n"), Mode: fs.FileMode(0o644)},
+	}
+
+	t := &silentT{}
+	New(t).WithFS(fsys).
+		DirContainsFile("out", "report.txt").
+		FileContains("out/report.txt", "failed: 0").
+		FilePermissions("out/report.txt", 0o644)
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
@@ -0,0 +1,194 @@
+package assertions
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// withinDelta reports whether got and want differ by at most delta. NaN is
+// within no delta of anything, itself included, and equal infinities are
+// within any delta; a plain math.Abs(got-want) > delta check passes both NaN
+// and mismatched infinities, since every comparison with NaN is false.
+func withinDelta(got, want, delta float64) bool {
+	if got == want {
+		return true
+	}
+	return math.Abs(got-want) <= delta
+}
+
+// IsNaN asserts that value is NaN.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.IsNaN(math.Sqrt(-1))
+func (a *Assert) IsNaN(value float64) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !math.IsNaN(value) {
+		a.reportMessagef("expected NaN\n  got: %s", formatNumeric(value, a.formatOptions))
+	}
+	return a
+}
+
+// NotNaN asserts that value is not NaN, as a guard before comparing it.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.NotNaN(stats.Mean).InDelta(stats.Mean, 2.5, 1e-9)
+func (a *Assert) NotNaN(value float64) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if math.IsNaN(value) {
+		a.reportMessagef("expected a number, got NaN")
+	}
+	return a
+}
+
+// IsInf asserts that value is an infinity with the given sign, as for
+// math.IsInf: sign > 0 requires +Inf, sign < 0 requires -Inf and sign == 0
+// accepts either. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.IsInf(1/zero, 1)
+func (a *Assert) IsInf(value float64, sign int) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !math.IsInf(value, sign) {
+		want := "±Inf"
+		if sign > 0 {
+			want = "+Inf"
+		} else if sign < 0 {
+			want = "-Inf"
+		}
+		a.reportMessagef("expected %s\n  got: %s", want, formatNumeric(value, a.formatOptions))
+	}
+	return a
+}
+
+// InDeltaSlice asserts that got and want have the same length and that each
+// element of got is within delta of the element of want at the same index.
+// NaN elements never match. On failure the first element outside delta is
+// reported with its index, beside the number of elements outside delta.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.InDeltaSlice(model.Predict(inputs), []float64{0.25, 0.5, 0.75}, 1e-6)
+func (a *Assert) InDeltaSlice(got, want []float64, delta float64) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if len(got) != len(want) {
+		a.reportMessagef("expected slices of equal length\n  got length:  %d\n  want length: %d", len(got), len(want))
+		return a
+	}
+
+	first, outside := -1, 0
+	for i := range got {
+		if !withinDelta(got[i], want[i], delta) {
+			if first < 0 {
+				first = i
+			}
+			outside++
+		}
+	}
+	if first >= 0 {
+		a.reportDeltaMismatch(fmt.Sprintf("index: %d", first), got[first], want[first], delta, outside, len(got))
+	}
+	return a
+}
+
+// InDeltaMap asserts that got and want have the same keys and that each value
+// of got is within delta of the value of want under the same key. NaN values
+// never match. On failure the first key outside delta, in the order of their
+// formatted names, is reported. Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.InDeltaMap(assert, report.Averages, map[string]float64{"p50": 12.5, "p99": 80}, 0.1)
+func InDeltaMap[K comparable](a *Assert, got, want map[K]float64, delta float64) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	type entry struct {
+		name string
+		key  K
+	}
+	entries := make([]entry, 0, len(want))
+	for k := range want {
+		if _, ok := got[k]; !ok {
+			a.reportMessagef("expected map to contain key\n  key: %s", formatValue(k, a.formatOptions))
+			return a
+		}
+		entries = append(entries, entry{formatValue(k, a.formatOptions), k})
+	}
+	for k := range got {
+		if _, ok := want[k]; !ok {
+			a.reportMessagef("unexpected key in map\n  key: %s", formatValue(k, a.formatOptions))
+			return a
+		}
+	}
+
+	slices.SortFunc(entries, func(x, y entry) int { return cmp.Compare(x.name, y.name) })
+	var first *entry
+	outside := 0
+	for i, e := range entries {
+		if !withinDelta(got[e.key], want[e.key], delta) {
+			if first == nil {
+				first = &entries[i]
+			}
+			outside++
+		}
+	}
+	if first != nil {
+		a.reportDeltaMismatch("key:   "+first.name, got[first.key], want[first.key], delta, outside, len(entries))
+	}
+	return a
+}
+
+// reportDeltaMismatch reports the first element of a collection outside
+// delta, located by where, and how many of total elements were outside.
+func (a *Assert) reportDeltaMismatch(where string, got, want, delta float64, outside, total int) {
+	opts := a.formatOptions
+	a.reportMessagef("expected elements to be within delta %s (%d of %d outside)\n  %s\n  got:   %s\n  want:  %s\n  diff:  %s",
+		formatNumeric(delta, opts), outside, total, where,
+		formatNumeric(got, opts), formatNumeric(want, opts), formatNumeric(math.Abs(got-want), opts))
+}
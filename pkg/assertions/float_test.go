@@ -0,0 +1,75 @@
+package assertions
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestFloatAssertions tests the NaN and infinity assertions, InDeltaSlice and
+// InDeltaMap, and that InDelta no longer passes NaN.
+func TestFloatAssertions(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"IsNaN", func(a *Assert) { a.IsNaN(nan) }, true, ""},
+		{"IsNaN fails", func(a *Assert) { a.IsNaN(1.5) }, false, "expected NaN\n  got: 1.5"},
+		{"NotNaN", func(a *Assert) { a.NotNaN(inf) }, true, ""},
+		{"NotNaN fails", func(a *Assert) { a.NotNaN(nan) }, false, "expected a number, got NaN"},
+		{"IsInf positive", func(a *Assert) { a.IsInf(inf, 1) }, true, ""},
+		{"IsInf either sign", func(a *Assert) { a.IsInf(-inf, 0) }, true, ""},
+		{"IsInf wrong sign", func(a *Assert) { a.IsInf(-inf, 1) }, false, "expected +Inf\n  got: -Inf"},
+		{"IsInf finite", func(a *Assert) { a.IsInf(math.MaxFloat64, 0) }, false, "expected ±Inf"},
+
+		{"InDelta NaN got", func(a *Assert) { a.InDelta(nan, 1, 0.5) }, false, "expected difference to be within tolerance"},
+		{"InDelta NaN both", func(a *Assert) { a.InDelta(nan, nan, 0.5) }, false, "within tolerance"},
+		{"InDelta mismatched infinities", func(a *Assert) { a.InDelta(inf, -inf, 1) }, false, "within tolerance"},
+		{"InDelta equal infinities", func(a *Assert) { a.InDelta(inf, inf, 0) }, true, ""},
+		{"InEpsilon NaN", func(a *Assert) { a.InEpsilon(nan, 1, 0.1) }, false, "within 10.0 percent"},
+		{"InEpsilon zeros", func(a *Assert) { a.InEpsilon(0, 0, 0.1) }, true, ""},
+
+		{"InDeltaSlice", func(a *Assert) { a.InDeltaSlice([]float64{0.1 + 0.2, 1}, []float64{0.3, 1}, 1e-9) }, true, ""},
+		{"InDeltaSlice empty", func(a *Assert) { a.InDeltaSlice(nil, []float64{}, 0) }, true, ""},
+		{"InDeltaSlice fails", func(a *Assert) { a.InDeltaSlice([]float64{1, 2.5, 3, 9}, []float64{1, 2, 3, 4}, 0.1) }, false,
+			"expected elements to be within delta 0.1 (2 of 4 outside)\n  index: 1\n  got:   2.5\n  want:  2\n  diff:  0.5"},
+		{"InDeltaSlice NaN element", func(a *Assert) { a.InDeltaSlice([]float64{nan}, []float64{nan}, 1) }, false, "index: 0"},
+		{"InDeltaSlice lengths", func(a *Assert) { a.InDeltaSlice([]float64{1}, []float64{1, 2}, 1) }, false,
+			"expected slices of equal length\n  got length:  1\n  want length: 2"},
+
+		{"InDeltaMap", func(a *Assert) {
+			InDeltaMap(a, map[string]float64{"p50": 12.49, "p99": 80}, map[string]float64{"p50": 12.5, "p99": 80}, 0.1)
+		}, true, ""},
+		{"InDeltaMap fails", func(a *Assert) {
+			InDeltaMap(a, map[string]float64{"p50": 12, "p90": 70, "p99": 90}, map[string]float64{"p50": 12, "p90": 60, "p99": 80}, 1)
+		}, false, "(2 of 3 outside)\n  key:   \"p90\"\n  got:   70\n  want:  60"},
+		{"InDeltaMap missing key", func(a *Assert) {
+			InDeltaMap(a, map[int]float64{1: 0}, map[int]float64{1: 0, 2: 0}, 1)
+		}, false, "expected map to contain key\n  key: 2"},
+		{"InDeltaMap extra key", func(a *Assert) {
+			InDeltaMap(a, map[int]float64{1: 0, 3: 0}, map[int]float64{1: 0}, 1)
+		}, false, "unexpected key in map\n  key: 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gowise/pkg/diff"
+)
+
+// FormatOptions controls how values are rendered in failure messages.
+// Limits keep a failing assertion on a very large value from flooding the test log.
+// A zero limit disables that particular bound.
+type FormatOptions struct {
+	// MaxStringLength is the maximum number of bytes of a string shown.
+	MaxStringLength int
+	// MaxSliceElements is the maximum number of slice or array elements shown.
+	MaxSliceElements int
+	// MaxMapEntries is the maximum number of map entries shown.
+	MaxMapEntries int
+	// MaxDepth is the maximum nesting depth of structs and containers rendered.
+	MaxDepth int
+	// ShowTypes prefixes got and want with their concrete type, as in int64(5).
+	// Types are always shown when got and want have different types.
+	ShowTypes bool
+	// Numbers controls digit grouping and the decimal separator of numbers
+	// in got/want and ordering failures. Only numbers of 100,000 or more are
+	// grouped, so smaller ones read as Go renders them. The zero value
+	// disables grouping.
+	Numbers NumberFormat
+}
+
+// DefaultFormatOptions returns the limits applied by New.
+func DefaultFormatOptions() FormatOptions {
+	return FormatOptions{
+		MaxStringLength:  2000,
+		MaxSliceElements: 100,
+		MaxMapEntries:    100,
+		MaxDepth:         8,
+		Numbers:          NumberFormatEnglish,
+	}
+}
+
+// WithFormatOptions returns a new Assert instance that renders failure values using the given limits.
+// NOTE: Shares failure state with original for proper fail-fast chaining.
+//
+// Example:
+//
+//	assert.WithFormatOptions(assertions.FormatOptions{MaxStringLength: 64}).Equal(got, want)
+//
+//funcgen:skip
+func (a *Assert) WithFormatOptions(opts FormatOptions) *Assert {
+	return a.With(UseFormatOptions(opts))
+}
+
+// formatValue renders a value in Go syntax, as %#v does, truncating anything
+// that exceeds the configured limits. Values within the limits are rendered
+// by fmt directly so that small failures read exactly as they always have.
+// A time.Duration is humanised, as 1.5s rather than its count of nanoseconds.
+func formatValue(value interface{}, opts FormatOptions) string {
+	if value == nil {
+		return fmt.Sprintf("%#v", value)
+	}
+
+	rv := reflect.ValueOf(value)
+	var rendered string
+	if d, ok := value.(time.Duration); ok {
+		rendered = humaniseDuration(d)
+	} else if withinFormatLimits(rv, opts, 0) {
+		rendered = fmt.Sprintf("%#v", value)
+	} else {
+		var b strings.Builder
+		writeFormattedValue(&b, rv, opts, 0)
+		rendered = b.String()
+	}
+
+	if opts.ShowTypes {
+		return annotateType(rv, rendered)
+	}
+	return rendered
+}
+
+// formatNumeric renders value as formatValue does, except that signed
+// integers and floats are rendered with the configured number format.
+// Durations are integers too, but are humanised by formatValue.
+func formatNumeric(value interface{}, opts FormatOptions) string {
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() || !isNumberKind(rv.Kind()) || rv.Type() == durationType {
+		return formatValue(value, opts)
+	}
+
+	rendered := formatNumber(rv, opts.Numbers)
+	if opts.ShowTypes {
+		return annotateType(rv, rendered)
+	}
+	return rendered
+}
+
+// annotateType wraps the rendering of a basic value in a conversion to its
+// type, as in int64(5) or time.Duration(1000). Composite values already carry
+// their type in Go syntax and are returned unchanged.
+func annotateType(rv reflect.Value, rendered string) string {
+	switch rv.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return fmt.Sprintf("%s(%s)", rv.Type(), rendered)
+	default:
+		return rendered
+	}
+}
+
+// formatString renders a string quoted, as %q does, truncating it to the configured length.
+func formatString(s string, opts FormatOptions) string {
+	if opts.MaxStringLength <= 0 || len(s) <= opts.MaxStringLength {
+		return strconv.Quote(s)
+	}
+
+	// Cut on a rune boundary so the quoted output remains valid UTF-8
+	cut := opts.MaxStringLength
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s… (%d more bytes)", strconv.Quote(s[:cut]), len(s)-cut)
+}
+
+// withinFormatLimits reports whether rendering rv in full stays within opts.
+// It stops at the first exceeded limit so oversized values are detected cheaply.
+func withinFormatLimits(rv reflect.Value, opts FormatOptions, depth int) bool {
+	if opts.MaxDepth > 0 && depth > opts.MaxDepth {
+		return false
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		return opts.MaxStringLength <= 0 || rv.Len() <= opts.MaxStringLength
+	case reflect.Slice, reflect.Array:
+		if opts.MaxSliceElements > 0 && rv.Len() > opts.MaxSliceElements {
+			return false
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if !withinFormatLimits(rv.Index(i), opts, depth+1) {
+				return false
+			}
+		}
+	case reflect.Map:
+		if opts.MaxMapEntries > 0 && rv.Len() > opts.MaxMapEntries {
+			return false
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			if !withinFormatLimits(iter.Key(), opts, depth+1) || !withinFormatLimits(iter.Value(), opts, depth+1) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if !withinFormatLimits(rv.Field(i), opts, depth+1) {
+				return false
+			}
+		}
+	case reflect.Interface:
+		if !rv.IsNil() {
+			return withinFormatLimits(rv.Elem(), opts, depth)
+		}
+	case reflect.Ptr:
+		// fmt only follows the top-level pointer; nested pointers print as addresses
+		if depth == 0 && !rv.IsNil() {
+			return withinFormatLimits(rv.Elem(), opts, depth)
+		}
+	}
+	return true
+}
+
+// formatReflectValue renders rv as formatValue does, including values of
+// unexported fields, which cannot be converted to interfaces.
+func formatReflectValue(rv reflect.Value, opts FormatOptions) string {
+	if rv.CanInterface() {
+		return formatValue(rv.Interface(), opts)
+	}
+	var b strings.Builder
+	writeFormattedValue(&b, rv, opts, 0)
+	return b.String()
+}
+
+// exceedsWidth reports whether rv rendered in Go syntax, as %#v renders it,
+// takes more than width bytes. It estimates the width while walking the value
+// and stops once the estimate exceeds width, so large values are detected
+// without being rendered.
+func exceedsWidth(rv reflect.Value, width int) bool {
+	remaining := width
+	return !fitsWidth(rv, &remaining, 0)
+}
+
+// fitsWidth subtracts the estimated width of rv from remaining and reports
+// whether any is left.
+func fitsWidth(rv reflect.Value, remaining *int, depth int) bool {
+	take := func(n int) bool {
+		*remaining -= n
+		return *remaining >= 0
+	}
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return take(len("<nil>"))
+	case reflect.String:
+		return take(rv.Len() + 2)
+	case reflect.Interface:
+		if rv.IsNil() {
+			return take(len("interface {}(nil)"))
+		}
+		return fitsWidth(rv.Elem(), remaining, depth)
+	case reflect.Ptr:
+		// fmt only follows the top-level pointer; nested pointers print as addresses
+		if rv.IsNil() || depth > 0 {
+			return take(len(rv.Type().String()) + len("()(0xc000000000)"))
+		}
+		return take(1) && fitsWidth(rv.Elem(), remaining, depth)
+	case reflect.Slice, reflect.Map:
+		if rv.IsNil() {
+			return take(len(rv.Type().String()) + len("(nil)"))
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !take(len(rv.Type().String()) + 2) {
+			return false
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if !take(2) || !fitsWidth(rv.Index(i), remaining, depth+1) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if !take(len(rv.Type().String()) + 2) {
+			return false
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			if !take(3) || !fitsWidth(iter.Key(), remaining, depth+1) || !fitsWidth(iter.Value(), remaining, depth+1) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		if !take(len(rv.Type().String()) + 2) {
+			return false
+		}
+		for i := 0; i < rv.NumField(); i++ {
+			if !take(len(rv.Type().Field(i).Name)+3) || !fitsWidth(rv.Field(i), remaining, depth+1) {
+				return false
+			}
+		}
+		return true
+	default:
+		return take(len(formatScalar(rv)))
+	}
+}
+
+// writeFormattedValue writes rv in Go syntax, applying the limits in opts.
+func writeFormattedValue(b *strings.Builder, rv reflect.Value, opts FormatOptions, depth int) {
+	switch rv.Kind() {
+	case reflect.String:
+		b.WriteString(formatString(rv.String(), opts))
+		return
+	case reflect.Interface:
+		if rv.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+		writeFormattedValue(b, rv.Elem(), opts, depth)
+		return
+	case reflect.Ptr:
+		if rv.IsNil() {
+			b.WriteString(fmt.Sprintf("(%s)(nil)", rv.Type()))
+			return
+		}
+		if depth == 0 {
+			b.WriteString("&")
+			writeFormattedValue(b, rv.Elem(), opts, depth)
+			return
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		if rv.Kind() == reflect.Slice && rv.IsNil() || rv.Kind() == reflect.Map && rv.IsNil() {
+			b.WriteString(fmt.Sprintf("%s(nil)", rv.Type()))
+			return
+		}
+		b.WriteString(rv.Type().String())
+		if opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+			b.WriteString("{…}")
+			return
+		}
+		b.WriteString("{")
+		writeFormattedElements(b, rv, opts, depth)
+		b.WriteString("}")
+	default:
+		b.WriteString(formatScalar(rv))
+	}
+}
+
+// writeFormattedElements writes the elements, entries or fields of a composite value.
+func writeFormattedElements(b *strings.Builder, rv reflect.Value, opts FormatOptions, depth int) {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		shown := rv.Len()
+		if opts.MaxSliceElements > 0 && shown > opts.MaxSliceElements {
+			shown = opts.MaxSliceElements
+		}
+		for i := 0; i < shown; i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeFormattedValue(b, rv.Index(i), opts, depth+1)
+		}
+		if remaining := rv.Len() - shown; remaining > 0 {
+			b.WriteString(fmt.Sprintf(", … (%d more elements)", remaining))
+		}
+	case reflect.Map:
+		// Render entries in key order, as fmt does, so output is stable across runs
+		keys := diff.SortedKeys(rv)
+		shown := len(keys)
+		if opts.MaxMapEntries > 0 && shown > opts.MaxMapEntries {
+			shown = opts.MaxMapEntries
+		}
+		for i, key := range keys[:shown] {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeFormattedValue(b, key, opts, depth+1)
+			b.WriteString(":")
+			writeFormattedValue(b, rv.MapIndex(key), opts, depth+1)
+		}
+		if remaining := len(keys) - shown; remaining > 0 {
+			b.WriteString(fmt.Sprintf(", … (%d more entries)", remaining))
+		}
+	case reflect.Struct:
+		names := structInfoFor(rv.Type()).names
+		for i, name := range names {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(name)
+			b.WriteString(":")
+			writeFormattedValue(b, rv.Field(i), opts, depth+1)
+		}
+	}
+}
+
+// formatScalar renders a non-composite value, including unexported struct
+// fields that cannot be converted back to an interface.
+func formatScalar(rv reflect.Value) string {
+	if rv.CanInterface() {
+		return fmt.Sprintf("%#v", rv.Interface())
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(rv.Complex(), 'g', -1, 128)
+	default:
+		return fmt.Sprintf("%s(…)", rv.Type())
+	}
+}
@@ -0,0 +1,225 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestFormatOptionsTruncateLargeValues tests that oversized values are truncated in failure messages.
+func TestFormatOptionsTruncateLargeValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		got, want     interface{}
+		expectMarker  string
+		maxMessageLen int
+	}{
+		{
+			name:          "large string",
+			got:           strings.Repeat("a", 1_000_000),
+			want:          strings.Repeat("b", 1_000_000),
+			expectMarker:  "(998000 more bytes)",
+			maxMessageLen: 10_000,
+		},
+		{
+			name:          "large slice",
+			got:           make([]int, 100_000),
+			want:          []int{1},
+			expectMarker:  "(99900 more elements)",
+			maxMessageLen: 10_000,
+		},
+		{
+			name:          "large map",
+			got:           largeMap(500),
+			want:          map[string]int{},
+			expectMarker:  "(400 more entries)",
+			maxMessageLen: 10_000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			assert := New(mock)
+
+			assert.Equal(tt.got, tt.want)
+
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+			}
+			errorMsg := mock.errorCalls[0]
+			if !strings.Contains(errorMsg, tt.expectMarker) {
+				t.Errorf("Expected truncation marker %q in message, got: %.300s", tt.expectMarker, errorMsg)
+			}
+			if len(errorMsg) > tt.maxMessageLen {
+				t.Errorf("Expected message to be bounded by %d bytes, got %d", tt.maxMessageLen, len(errorMsg))
+			}
+		})
+	}
+}
+
+// TestFormatOptionsSmallValuesUnchanged tests that values within limits render as %#v does.
+func TestFormatOptionsSmallValuesUnchanged(t *testing.T) {
+	type point struct{ X, Y int }
+
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	assert.Equal(point{1, 2}, point{2, 1})
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	wantGot := fmt.Sprintf("got:  %#v", point{1, 2})
+	if !strings.Contains(mock.errorCalls[0], wantGot) {
+		t.Errorf("Expected %q in message, got: %s", wantGot, mock.errorCalls[0])
+	}
+}
+
+// TestWithFormatOptions tests custom limits, including depth limits and disabled limits.
+func TestWithFormatOptions(t *testing.T) {
+	t.Run("custom string limit", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock).WithFormatOptions(FormatOptions{MaxStringLength: 5})
+
+		assert.Equal("hello world", "hello there")
+
+		if len(mock.errorCalls) != 1 {
+			t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+		}
+		if !strings.Contains(mock.errorCalls[0], `"hello"… (6 more bytes)`) {
+			t.Errorf("Expected truncated string in message, got: %s", mock.errorCalls[0])
+		}
+	})
+
+	t.Run("depth limit", func(t *testing.T) {
+		type inner struct{ Value int }
+		type outer struct{ Inner inner }
+
+		mock := &behaviorMockT{}
+		assert := New(mock).WithFormatOptions(FormatOptions{MaxDepth: 1})
+
+		assert.Equal(outer{inner{1}}, outer{inner{2}})
+
+		if len(mock.errorCalls) != 1 {
+			t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+		}
+		if !strings.Contains(mock.errorCalls[0], "Inner:assertions.inner{…}") {
+			t.Errorf("Expected nested struct to be elided, got: %s", mock.errorCalls[0])
+		}
+	})
+
+	t.Run("zero limits disable truncation", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock).WithFormatOptions(FormatOptions{})
+
+		long := strings.Repeat("x", 5000)
+		assert.Equal(long, long+"y")
+
+		if len(mock.errorCalls) != 1 {
+			t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+		}
+		if strings.Contains(mock.errorCalls[0], "more bytes") {
+			t.Errorf("Expected no truncation with zero limits")
+		}
+	})
+
+	t.Run("shares failure state", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+		derived := assert.WithFormatOptions(FormatOptions{MaxStringLength: 5})
+
+		derived.Equal(1, 2)
+
+		if !assert.HasFailed() {
+			t.Errorf("Expected original Assert to observe failure of derived Assert")
+		}
+	})
+}
+
+// TestFormatValueTruncatedMapOrder tests that truncated maps keep fmt's natural key order.
+func TestFormatValueTruncatedMapOrder(t *testing.T) {
+	m := map[int]string{}
+	for i := 1; i <= 12; i++ {
+		m[i] = "v"
+	}
+
+	got := formatValue(m, FormatOptions{MaxMapEntries: 3})
+	want := `map[int]string{1:"v", 2:"v", 3:"v", … (9 more entries)}`
+	if got != want {
+		t.Errorf("formatValue() = %s, want %s", got, want)
+	}
+}
+
+// TestTypeAnnotation tests that concrete types are shown for mismatched types and on request.
+func TestTypeAnnotation(t *testing.T) {
+	type userID int
+
+	withTypes := DefaultFormatOptions()
+	withTypes.ShowTypes = true
+
+	tests := []struct {
+		name          string
+		opts          FormatOptions
+		got, want     interface{}
+		expectMessage string
+	}{
+		{"mismatched integer types", DefaultFormatOptions(), int64(5), 5, "got:  int64(5)\n  want: int(5)"},
+		{"named type", DefaultFormatOptions(), userID(7), 7, "got:  assertions.userID(7)\n  want: int(7)"},
+		{"nil against value", DefaultFormatOptions(), nil, 0, "got:  <nil>\n  want: int(0)"},
+		{"same types unannotated", DefaultFormatOptions(), 1.5, 2.5, "got:  1.5\n  want: 2.5"},
+		{"ShowTypes on request", withTypes, uint8(1), uint8(2), "got:  uint8(0x1)\n  want: uint8(0x2)"},
+		{"composites keep Go syntax", withTypes, []int{1}, []int{2}, "got:  []int{1}\n  want: []int{2}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			New(mock).WithFormatOptions(tt.opts).Equal(tt.got, tt.want)
+
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+func largeMap(n int) map[string]int {
+	m := make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		m[fmt.Sprintf("key%04d", i)] = i
+	}
+	return m
+}
+
+// ExampleAssert_WithFormatOptions demonstrates bounding the size of failure output.
+func ExampleAssert_WithFormatOptions() {
+	mock := &behaviorMockT{}
+	assert := New(mock).WithFormatOptions(FormatOptions{MaxSliceElements: 3})
+
+	assert.Equal([]int{1, 2, 3, 4, 5}, []int{1, 2, 3})
+
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// values differ
+	//   got:  []int{1, 2, 3, … (2 more elements)}
+	//   want: []int{1, 2, 3}
+}
+
+// ExampleAssert_Equal_typeMismatch demonstrates type annotation for values that print identically.
+func ExampleAssert_Equal_typeMismatch() {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	var count int64 = 5
+	assert.Equal(count, 5)
+
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// values differ
+	//   got:  int64(5)
+	//   want: int(5)
+}
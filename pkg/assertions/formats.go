@@ -0,0 +1,373 @@
+package assertions
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The format assertions validate strings against common identifier and
+// encoding formats. Each validator returns "" for a valid value, or a
+// description of the part that failed, which the assertion reports:
+//
+//	expected a valid UUID
+//	  got:     "6ba7b810-9dad-11d1-80b4-00c04fd430cg"
+//	  problem: invalid character 'g' at position 35 in group 5
+
+// IsUUID asserts that s is a UUID in the canonical 8-4-4-4-12 form of
+// hexadecimal digits, in either case. Any version and variant is accepted.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.IsUUID(order.ID)
+func (a *Assert) IsUUID(s string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkFormat("UUID", s, uuidProblem)
+}
+
+// IsULID asserts that s is a ULID: 26 characters of Crockford base32, in
+// either case, whose first character does not overflow the 128-bit value.
+// Returns *Assert to enable method chaining.
+func (a *Assert) IsULID(s string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkFormat("ULID", s, ulidProblem)
+}
+
+// IsSemVer asserts that s is a semantic version as defined by semver.org
+// 2.0.0: MAJOR.MINOR.PATCH with optional pre-release and build metadata, such
+// as 1.4.0-rc.1+build.7. A leading "v" is not part of the format.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.IsSemVer(strings.TrimPrefix(version, "v"))
+func (a *Assert) IsSemVer(s string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkFormat("semantic version", s, semVerProblem)
+}
+
+// IsISO8601 asserts that s is an ISO 8601 date (2006-01-02) or date and time,
+// with optional fractional seconds and an optional Z or ±hh:mm offset, as
+// produced by time.RFC3339Nano. Returns *Assert to enable method chaining.
+func (a *Assert) IsISO8601(s string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkFormat("ISO 8601 timestamp", s, iso8601Problem)
+}
+
+// IsIPv4 asserts that s is an IPv4 address in dotted decimal form.
+// Returns *Assert to enable method chaining.
+func (a *Assert) IsIPv4(s string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkFormat("IPv4 address", s, func(s string) string { return ipProblem(s, true) })
+}
+
+// IsIPv6 asserts that s is an IPv6 address, including IPv4-mapped forms such
+// as ::ffff:192.0.2.1 and an optional zone. Returns *Assert to enable method
+// chaining.
+func (a *Assert) IsIPv6(s string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkFormat("IPv6 address", s, func(s string) string { return ipProblem(s, false) })
+}
+
+// IsBase64 asserts that s is padded standard base64, as encoded by
+// base64.StdEncoding. Returns *Assert to enable method chaining.
+func (a *Assert) IsBase64(s string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkFormat("base64 string", s, base64Problem)
+}
+
+// IsHex asserts that s is a non-empty, even-length string of hexadecimal
+// digits, in either case, as decoded by hex.DecodeString. Returns *Assert to
+// enable method chaining.
+func (a *Assert) IsHex(s string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkFormat("hex string", s, hexProblem)
+}
+
+// checkFormat reports the problem validate finds with s, if any.
+func (a *Assert) checkFormat(format, s string, validate func(string) string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if problem := validate(s); problem != "" {
+		a.reportMessagef("expected a valid %s\n  got:     %s\n  problem: %s", format, formatString(s, a.formatOptions), problem)
+	}
+	return a
+}
+
+// uuidGroups are the lengths of the hyphen-separated groups of a UUID.
+var uuidGroups = [...]int{8, 4, 4, 4, 12}
+
+// uuidProblem validates a canonical UUID.
+func uuidProblem(s string) string {
+	if len(s) != 36 {
+		return fmt.Sprintf("length %d, want 36 (8-4-4-4-12 hex digits)", len(s))
+	}
+	pos := 0
+	for g, n := range uuidGroups {
+		if g > 0 {
+			if s[pos] != '-' {
+				return fmt.Sprintf("%s at position %d, want '-' before group %d", describeByte(s[pos]), pos, g+1)
+			}
+			pos++
+		}
+		for end := pos + n; pos < end; pos++ {
+			if !isHexDigit(s[pos]) {
+				return fmt.Sprintf("invalid character %s at position %d in group %d", describeByte(s[pos]), pos, g+1)
+			}
+		}
+	}
+	return ""
+}
+
+// crockfordBase32 is the ULID alphabet, which omits I, L, O and U.
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ulidProblem validates a ULID.
+func ulidProblem(s string) string {
+	if len(s) != 26 {
+		return fmt.Sprintf("length %d, want 26", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		if !strings.ContainsRune(crockfordBase32, rune(upperASCII(s[i]))) {
+			return fmt.Sprintf("invalid character %s at position %d, not in Crockford base32", describeByte(s[i]), i)
+		}
+	}
+	if s[0] > '7' {
+		return fmt.Sprintf("first character %s overflows the 48-bit timestamp, want 0-7", describeByte(s[0]))
+	}
+	return ""
+}
+
+// semVerProblem validates a semantic version 2.0.0.
+func semVerProblem(s string) string {
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		return `leading "v" is not part of a semantic version`
+	}
+	version, build, hasBuild := strings.Cut(s, "+")
+	version, pre, hasPre := strings.Cut(version, "-")
+
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return fmt.Sprintf("version core %q has %d parts, want MAJOR.MINOR.PATCH", version, len(parts))
+	}
+	for i, name := range []string{"major", "minor", "patch"} {
+		if problem := numericIdentifierProblem(parts[i]); problem != "" {
+			return fmt.Sprintf("%s version %q %s", name, parts[i], problem)
+		}
+	}
+
+	if hasPre {
+		for i, id := range strings.Split(pre, ".") {
+			if problem := identifierProblem(id); problem != "" {
+				return fmt.Sprintf("pre-release identifier %d %q %s", i+1, id, problem)
+			}
+			if isDigits(id) && len(id) > 1 && id[0] == '0' {
+				return fmt.Sprintf("pre-release identifier %d %q has a leading zero", i+1, id)
+			}
+		}
+	}
+	if hasBuild {
+		for i, id := range strings.Split(build, ".") {
+			if problem := identifierProblem(id); problem != "" {
+				return fmt.Sprintf("build metadata identifier %d %q %s", i+1, id, problem)
+			}
+		}
+	}
+	return ""
+}
+
+// numericIdentifierProblem checks a version number: digits with no leading zero.
+func numericIdentifierProblem(id string) string {
+	switch {
+	case id == "":
+		return "is empty"
+	case !isDigits(id):
+		return "is not a number"
+	case len(id) > 1 && id[0] == '0':
+		return "has a leading zero"
+	}
+	return ""
+}
+
+// identifierProblem checks a pre-release or build identifier: one or more
+// ASCII letters, digits and hyphens.
+func identifierProblem(id string) string {
+	if id == "" {
+		return "is empty"
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if !(c == '-' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return "contains invalid character " + describeByte(c)
+		}
+	}
+	return ""
+}
+
+// iso8601Problem validates an ISO 8601 date or date and time, choosing the
+// layout from the shape of s so that the parse error names the bad element.
+func iso8601Problem(s string) string {
+	layout := "2006-01-02"
+	if len(s) > len(layout) {
+		layout = time.RFC3339Nano
+		if !strings.ContainsAny(s[len("2006-01-02"):], "Z+-") {
+			layout = "2006-01-02T15:04:05.999999999"
+		}
+	}
+	if _, err := time.Parse(layout, s); err != nil {
+		var parseErr *time.ParseError
+		switch {
+		case !errors.As(err, &parseErr):
+			return err.Error()
+		case parseErr.Message != "":
+			return strings.TrimPrefix(parseErr.Message, ": ")
+		}
+		return fmt.Sprintf("cannot parse %q as %s", parseErr.ValueElem, describeLayoutElem(parseErr.LayoutElem))
+	}
+	return ""
+}
+
+// layoutElems names the elements of the reference time in the ISO 8601 layouts.
+var layoutElems = map[string]string{
+	"2006": "the year", "01": "the month", "02": "the day", "15": "the hour",
+	"04": "the minute", "05": "the second", "Z07:00": "a Z or ±hh:mm offset",
+	"T": `"T" between date and time`, "-": `"-"`, ":": `":"`,
+}
+
+// describeLayoutElem names a layout element of a time.ParseError.
+func describeLayoutElem(elem string) string {
+	if name, ok := layoutElems[elem]; ok {
+		return name
+	}
+	if elem == "" {
+		return "the end of the value"
+	}
+	return strconv.Quote(elem)
+}
+
+// ipProblem validates an IP address of the family v4 selects.
+func ipProblem(s string, v4 bool) string {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		msg := err.Error()
+		if i := strings.Index(msg, "): "); i >= 0 {
+			msg = msg[i+3:]
+		}
+		return msg
+	}
+	switch {
+	case v4 && !addr.Is4():
+		return "is an IPv6 address"
+	case !v4 && addr.Is4():
+		return "is an IPv4 address"
+	}
+	return ""
+}
+
+// base64Problem validates padded standard base64.
+func base64Problem(s string) string {
+	_, err := base64.StdEncoding.DecodeString(s)
+	var corrupt base64.CorruptInputError
+	if !errors.As(err, &corrupt) {
+		return ""
+	}
+	if i := strings.IndexFunc(s, func(r rune) bool { return r != '=' && !isBase64Rune(r) }); i >= 0 {
+		if s[i] == '-' || s[i] == '_' {
+			return fmt.Sprintf("URL-safe character %s at offset %d; standard base64 uses '+' and '/'", describeByte(s[i]), i)
+		}
+		return fmt.Sprintf("invalid character %s at offset %d", describeByte(s[i]), i)
+	}
+	if len(s)%4 != 0 {
+		return fmt.Sprintf("length %d is not a multiple of 4; padding is missing or incomplete", len(s))
+	}
+	return fmt.Sprintf("misplaced padding at offset %d", int(corrupt))
+}
+
+// isBase64Rune reports whether r is in the standard base64 alphabet.
+func isBase64Rune(r rune) bool {
+	return r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '+' || r == '/'
+}
+
+// hexProblem validates an even-length hex string.
+func hexProblem(s string) string {
+	if s == "" {
+		return "is empty"
+	}
+	_, err := hex.DecodeString(s)
+	var invalid hex.InvalidByteError
+	switch {
+	case errors.As(err, &invalid):
+		return fmt.Sprintf("invalid character %s at offset %d", describeByte(byte(invalid)), strings.IndexByte(s, byte(invalid)))
+	case errors.Is(err, hex.ErrLength):
+		return fmt.Sprintf("odd length %d; each byte takes two hex digits", len(s))
+	}
+	return ""
+}
+
+// describeByte quotes a byte of a validated string for a failure message.
+func describeByte(c byte) string {
+	if c < 0x80 {
+		return strconv.QuoteRune(rune(c))
+	}
+	return fmt.Sprintf("byte 0x%02x", c)
+}
+
+// isHexDigit reports whether c is a hexadecimal digit in either case.
+func isHexDigit(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+// isDigits reports whether s is one or more ASCII digits.
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// upperASCII returns c in upper case if it is an ASCII letter.
+func upperASCII(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	return c
+}
@@ -0,0 +1,99 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestFormatAssertions tests each format validator on valid values and on
+// values broken in different parts, checking the part named in the failure.
+func TestFormatAssertions(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"UUID", func(a *Assert) { a.IsUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8") }, true, ""},
+		{"UUID upper case", func(a *Assert) { a.IsUUID("6BA7B810-9DAD-11D1-80B4-00C04FD430C8") }, true, ""},
+		{"UUID bad digit", func(a *Assert) { a.IsUUID("6ba7b810-9dad-11d1-80b4-00c04fd430cg") }, false,
+			"expected a valid UUID\n  got:     \"6ba7b810-9dad-11d1-80b4-00c04fd430cg\"\n  problem: invalid character 'g' at position 35 in group 5"},
+		{"UUID missing hyphen", func(a *Assert) { a.IsUUID("6ba7b810-9dad_11d1-80b4-00c04fd430c8") }, false,
+			"'_' at position 13, want '-' before group 3"},
+		{"UUID length", func(a *Assert) { a.IsUUID("6ba7b8109dad11d180b400c04fd430c8") }, false, "length 32, want 36"},
+
+		{"ULID", func(a *Assert) { a.IsULID("01ARZ3NDEKTSV4RRFFQ69G5FAV") }, true, ""},
+		{"ULID lower case", func(a *Assert) { a.IsULID("01arz3ndektsv4rrffq69g5fav") }, true, ""},
+		{"ULID excluded letter", func(a *Assert) { a.IsULID("01ARZ3NDEKTSV4RRFFQ69G5FAL") }, false,
+			"invalid character 'L' at position 25, not in Crockford base32"},
+		{"ULID overflow", func(a *Assert) { a.IsULID("81ARZ3NDEKTSV4RRFFQ69G5FAV") }, false, "first character '8' overflows"},
+
+		{"SemVer", func(a *Assert) { a.IsSemVer("1.4.0") }, true, ""},
+		{"SemVer full", func(a *Assert) { a.IsSemVer("1.0.0-alpha-1.0.x-y+build.20240301.sha-5114f85") }, true, ""},
+		{"SemVer v prefix", func(a *Assert) { a.IsSemVer("v1.4.0") }, false, `leading "v" is not part of a semantic version`},
+		{"SemVer two parts", func(a *Assert) { a.IsSemVer("1.4") }, false, `version core "1.4" has 2 parts`},
+		{"SemVer leading zero", func(a *Assert) { a.IsSemVer("1.04.0") }, false, `minor version "04" has a leading zero`},
+		{"SemVer pre-release", func(a *Assert) { a.IsSemVer("1.0.0-rc..1") }, false, `pre-release identifier 2 "" is empty`},
+		{"SemVer numeric pre-release", func(a *Assert) { a.IsSemVer("1.0.0-rc.01") }, false, `pre-release identifier 2 "01" has a leading zero`},
+		{"SemVer build", func(a *Assert) { a.IsSemVer("1.0.0+build_7") }, false,
+			`build metadata identifier 1 "build_7" contains invalid character '_'`},
+
+		{"ISO8601 date", func(a *Assert) { a.IsISO8601("2024-03-01") }, true, ""},
+		{"ISO8601 UTC", func(a *Assert) { a.IsISO8601("2024-03-01T09:30:00Z") }, true, ""},
+		{"ISO8601 offset and fraction", func(a *Assert) { a.IsISO8601("2024-03-01T09:30:00.123+01:00") }, true, ""},
+		{"ISO8601 local", func(a *Assert) { a.IsISO8601("2024-03-01T09:30:00") }, true, ""},
+		{"ISO8601 month", func(a *Assert) { a.IsISO8601("2024-13-01") }, false, "month out of range"},
+		{"ISO8601 separator", func(a *Assert) { a.IsISO8601("2024-03-01 09:30:00Z") }, false, `as "T" between date and time`},
+		{"ISO8601 seconds", func(a *Assert) { a.IsISO8601("2024-03-01T09:30Z") }, false, `cannot parse "Z" as ":"`},
+		{"Base64 misplaced padding", func(a *Assert) { a.IsBase64("aG=sbG8=") }, false, "misplaced padding at offset 2"},
+
+		{"IPv4", func(a *Assert) { a.IsIPv4("192.0.2.1") }, true, ""},
+		{"IPv4 field", func(a *Assert) { a.IsIPv4("192.0.2.256") }, false, "IPv4 field has value >255"},
+		{"IPv4 given IPv6", func(a *Assert) { a.IsIPv4("2001:db8::1") }, false, "is an IPv6 address"},
+		{"IPv6", func(a *Assert) { a.IsIPv6("2001:db8::1") }, true, ""},
+		{"IPv6 mapped", func(a *Assert) { a.IsIPv6("::ffff:192.0.2.1") }, true, ""},
+		{"IPv6 given IPv4", func(a *Assert) { a.IsIPv6("192.0.2.1") }, false, "is an IPv4 address"},
+		{"IPv6 bad group", func(a *Assert) { a.IsIPv6("2001:db8::g") }, false, "expected a valid IPv6 address"},
+
+		{"Base64", func(a *Assert) { a.IsBase64("aGVsbG8gd29ybGQ=") }, true, ""},
+		{"Base64 empty", func(a *Assert) { a.IsBase64("") }, true, ""},
+		{"Base64 URL alphabet", func(a *Assert) { a.IsBase64("a-_b") }, false, "URL-safe character '-' at offset 1"},
+		{"Base64 invalid character", func(a *Assert) { a.IsBase64("aGVs*G8=") }, false, "invalid character '*' at offset 4"},
+		{"Base64 missing padding", func(a *Assert) { a.IsBase64("aGVsbG8") }, false, "length 7 is not a multiple of 4"},
+
+		{"Hex", func(a *Assert) { a.IsHex("deadBEEF") }, true, ""},
+		{"Hex empty", func(a *Assert) { a.IsHex("") }, false, "problem: is empty"},
+		{"Hex invalid character", func(a *Assert) { a.IsHex("deadbexf") }, false, "invalid character 'x' at offset 6"},
+		{"Hex odd length", func(a *Assert) { a.IsHex("abc") }, false, "odd length 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// ExampleAssert_IsUUID shows a format assertion's failure message.
+func ExampleAssert_IsUUID() {
+	assert := New(recordingT{})
+	assert.IsUUID("6ba7b810-9dad-11d1-80b4-00c04fd430cg")
+	fmt.Println(assert.Error())
+	// Output:
+	// expected a valid UUID
+	//   got:     "6ba7b810-9dad-11d1-80b4-00c04fd430cg"
+	//   problem: invalid character 'g' at position 35 in group 5
+}
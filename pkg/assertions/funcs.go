@@ -0,0 +1,19 @@
+package assertions
+
+//go:generate go run ./internal/funcgen
+
+// Each assertion method also has a package-level function, generated into
+// funcs_gen.go, that checks a single assertion against a testing context
+// and reports whether it passed, for tests that do not keep an Assert:
+//
+//	assertions.Equal(t, got.Status, "shipped")
+//	if !assertions.NoError(t, err) {
+//		return
+//	}
+//
+// The functions create a fresh Assert for each call, so they do not chain:
+// every failure is reported. Methods that configure an Assert, such as With,
+// and deprecated methods have no function, nor do methods marked
+// //funcgen:skip, such as Implements, whose name the generic Implements[T]
+// takes. Generic assertions such as Greater and Between take an *Assert
+// rather than a testing context, as they cannot be methods.
@@ -0,0 +1,1391 @@
+// Code generated by funcgen; DO NOT EDIT.
+
+package assertions
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"io/fs"
+	"net/http"
+	"os/exec"
+	"reflect"
+	"time"
+)
+
+// After is Assert.After for a single assertion against t.
+// It reports whether the assertion passed.
+func After(t TestingT, got, bound time.Time) bool {
+	t.Helper()
+	a := New(t)
+	a.After(got, bound)
+	return !a.HasFailed()
+}
+
+// Before is Assert.Before for a single assertion against t.
+// It reports whether the assertion passed.
+func Before(t TestingT, got, bound time.Time) bool {
+	t.Helper()
+	a := New(t)
+	a.Before(got, bound)
+	return !a.HasFailed()
+}
+
+// BodyContains is Assert.BodyContains for a single assertion against t.
+// It reports whether the assertion passed.
+func BodyContains(t TestingT, response *http.Response, expected string) bool {
+	t.Helper()
+	a := New(t)
+	a.BodyContains(response, expected)
+	return !a.HasFailed()
+}
+
+// BodyJsonEqual is Assert.BodyJsonEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func BodyJsonEqual(t TestingT, response *http.Response, expected interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.BodyJsonEqual(response, expected)
+	return !a.HasFailed()
+}
+
+// BodyMatches is Assert.BodyMatches for a single assertion against t.
+// It reports whether the assertion passed.
+func BodyMatches(t TestingT, response *http.Response, pattern string) bool {
+	t.Helper()
+	a := New(t)
+	a.BodyMatches(response, pattern)
+	return !a.HasFailed()
+}
+
+// BytesEqual is Assert.BytesEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func BytesEqual(t TestingT, got, want []byte) bool {
+	t.Helper()
+	a := New(t)
+	a.BytesEqual(got, want)
+	return !a.HasFailed()
+}
+
+// CaptureRegexp is Assert.CaptureRegexp for a single assertion against t.
+// It reports whether the assertion passed.
+func CaptureRegexp(t TestingT, pattern, s string, groups *map[string]string) bool {
+	t.Helper()
+	a := New(t)
+	a.CaptureRegexp(pattern, s, groups)
+	return !a.HasFailed()
+}
+
+// CertificateExpiresAfter is Assert.CertificateExpiresAfter for a single assertion against t.
+// It reports whether the assertion passed.
+func CertificateExpiresAfter(t TestingT, cert *x509.Certificate, d time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.CertificateExpiresAfter(cert, d)
+	return !a.HasFailed()
+}
+
+// CertificateValidFor is Assert.CertificateValidFor for a single assertion against t.
+// It reports whether the assertion passed.
+func CertificateValidFor(t TestingT, cert *x509.Certificate, host string) bool {
+	t.Helper()
+	a := New(t)
+	a.CertificateValidFor(cert, host)
+	return !a.HasFailed()
+}
+
+// CompletesConcurrently is Assert.CompletesConcurrently for a single assertion against t.
+// It reports whether the assertion passed.
+func CompletesConcurrently(t TestingT, fns []func(), timeout time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.CompletesConcurrently(fns, timeout)
+	return !a.HasFailed()
+}
+
+// Condition is Assert.Condition for a single assertion against t.
+// It reports whether the assertion passed.
+func Condition(t TestingT, condition bool) bool {
+	t.Helper()
+	a := New(t)
+	a.Condition(condition)
+	return !a.HasFailed()
+}
+
+// Conditionf is Assert.Conditionf for a single assertion against t.
+// It reports whether the assertion passed.
+func Conditionf(t TestingT, condition bool, format string, args ...interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.Conditionf(condition, format, args...)
+	return !a.HasFailed()
+}
+
+// Contains is Assert.Contains for a single assertion against t.
+// It reports whether the assertion passed.
+func Contains(t TestingT, container, item interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.Contains(container, item)
+	return !a.HasFailed()
+}
+
+// ContentTypeIs is Assert.ContentTypeIs for a single assertion against t.
+// It reports whether the assertion passed.
+func ContentTypeIs(t TestingT, response *http.Response, want string) bool {
+	t.Helper()
+	a := New(t)
+	a.ContentTypeIs(response, want)
+	return !a.HasFailed()
+}
+
+// ContextCancelledWithin is Assert.ContextCancelledWithin for a single assertion against t.
+// It reports whether the assertion passed.
+func ContextCancelledWithin(t TestingT, ctx context.Context, timeout time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.ContextCancelledWithin(ctx, timeout)
+	return !a.HasFailed()
+}
+
+// ContextDone is Assert.ContextDone for a single assertion against t.
+// It reports whether the assertion passed.
+func ContextDone(t TestingT, ctx context.Context) bool {
+	t.Helper()
+	a := New(t)
+	a.ContextDone(ctx)
+	return !a.HasFailed()
+}
+
+// ContextErrIs is Assert.ContextErrIs for a single assertion against t.
+// It reports whether the assertion passed.
+func ContextErrIs(t TestingT, ctx context.Context, target error) bool {
+	t.Helper()
+	a := New(t)
+	a.ContextErrIs(ctx, target)
+	return !a.HasFailed()
+}
+
+// ContextHasValue is Assert.ContextHasValue for a single assertion against t.
+// It reports whether the assertion passed.
+func ContextHasValue(t TestingT, ctx context.Context, key, want any) bool {
+	t.Helper()
+	a := New(t)
+	a.ContextHasValue(ctx, key, want)
+	return !a.HasFailed()
+}
+
+// ContextNotDone is Assert.ContextNotDone for a single assertion against t.
+// It reports whether the assertion passed.
+func ContextNotDone(t TestingT, ctx context.Context) bool {
+	t.Helper()
+	a := New(t)
+	a.ContextNotDone(ctx)
+	return !a.HasFailed()
+}
+
+// CookieExpiresAfter is Assert.CookieExpiresAfter for a single assertion against testingT.
+// It reports whether the assertion passed.
+func CookieExpiresAfter(testingT TestingT, response *http.Response, name string, t time.Time) bool {
+	testingT.Helper()
+	a := New(testingT)
+	a.CookieExpiresAfter(response, name, t)
+	return !a.HasFailed()
+}
+
+// CookieHTTPOnly is Assert.CookieHTTPOnly for a single assertion against t.
+// It reports whether the assertion passed.
+func CookieHTTPOnly(t TestingT, response *http.Response, name string) bool {
+	t.Helper()
+	a := New(t)
+	a.CookieHTTPOnly(response, name)
+	return !a.HasFailed()
+}
+
+// CookieSameSite is Assert.CookieSameSite for a single assertion against t.
+// It reports whether the assertion passed.
+func CookieSameSite(t TestingT, response *http.Response, name string, want http.SameSite) bool {
+	t.Helper()
+	a := New(t)
+	a.CookieSameSite(response, name, want)
+	return !a.HasFailed()
+}
+
+// CookieSecure is Assert.CookieSecure for a single assertion against t.
+// It reports whether the assertion passed.
+func CookieSecure(t TestingT, response *http.Response, name string) bool {
+	t.Helper()
+	a := New(t)
+	a.CookieSecure(response, name)
+	return !a.HasFailed()
+}
+
+// CookieValue is Assert.CookieValue for a single assertion against t.
+// It reports whether the assertion passed.
+func CookieValue(t TestingT, response *http.Response, name, want string) bool {
+	t.Helper()
+	a := New(t)
+	a.CookieValue(response, name, want)
+	return !a.HasFailed()
+}
+
+// DeepDiff is Assert.DeepDiff for a single assertion against t.
+// It reports whether the assertion passed.
+func DeepDiff(t TestingT, got, want any) bool {
+	t.Helper()
+	a := New(t)
+	a.DeepDiff(got, want)
+	return !a.HasFailed()
+}
+
+// DeepEqual is Assert.DeepEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func DeepEqual(t TestingT, got, want interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.DeepEqual(got, want)
+	return !a.HasFailed()
+}
+
+// DirContainsFile is Assert.DirContainsFile for a single assertion against t.
+// It reports whether the assertion passed.
+func DirContainsFile(t TestingT, dir, name string) bool {
+	t.Helper()
+	a := New(t)
+	a.DirContainsFile(dir, name)
+	return !a.HasFailed()
+}
+
+// DirectoryExists is Assert.DirectoryExists for a single assertion against t.
+// It reports whether the assertion passed.
+func DirectoryExists(t TestingT, path string) bool {
+	t.Helper()
+	a := New(t)
+	a.DirectoryExists(path)
+	return !a.HasFailed()
+}
+
+// DurationBetween is Assert.DurationBetween for a single assertion against t.
+// It reports whether the assertion passed.
+func DurationBetween(t TestingT, start, end time.Time, min, max time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.DurationBetween(start, end, min, max)
+	return !a.HasFailed()
+}
+
+// DurationWithin is Assert.DurationWithin for a single assertion against t.
+// It reports whether the assertion passed.
+func DurationWithin(t TestingT, got, want, tolerance time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.DurationWithin(got, want, tolerance)
+	return !a.HasFailed()
+}
+
+// Empty is Assert.Empty for a single assertion against t.
+// It reports whether the assertion passed.
+func Empty(t TestingT, container interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.Empty(container)
+	return !a.HasFailed()
+}
+
+// EnvEqual is Assert.EnvEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func EnvEqual(t TestingT, key, value string) bool {
+	t.Helper()
+	a := New(t)
+	a.EnvEqual(key, value)
+	return !a.HasFailed()
+}
+
+// EnvSet is Assert.EnvSet for a single assertion against t.
+// It reports whether the assertion passed.
+func EnvSet(t TestingT, key string) bool {
+	t.Helper()
+	a := New(t)
+	a.EnvSet(key)
+	return !a.HasFailed()
+}
+
+// EnvUnset is Assert.EnvUnset for a single assertion against t.
+// It reports whether the assertion passed.
+func EnvUnset(t TestingT, key string) bool {
+	t.Helper()
+	a := New(t)
+	a.EnvUnset(key)
+	return !a.HasFailed()
+}
+
+// Equal is Assert.Equal for a single assertion against t.
+// It reports whether the assertion passed.
+func Equal(t TestingT, got, want interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.Equal(got, want)
+	return !a.HasFailed()
+}
+
+// EqualApprox is Assert.EqualApprox for a single assertion against t.
+// It reports whether the assertion passed.
+func EqualApprox(t TestingT, got, want interface{}, opts ...ApproxOption) bool {
+	t.Helper()
+	a := New(t)
+	a.EqualApprox(got, want, opts...)
+	return !a.HasFailed()
+}
+
+// EqualFold is Assert.EqualFold for a single assertion against t.
+// It reports whether the assertion passed.
+func EqualFold(t TestingT, got, want string) bool {
+	t.Helper()
+	a := New(t)
+	a.EqualFold(got, want)
+	return !a.HasFailed()
+}
+
+// EqualIgnoringWhitespace is Assert.EqualIgnoringWhitespace for a single assertion against t.
+// It reports whether the assertion passed.
+func EqualIgnoringWhitespace(t TestingT, got, want string) bool {
+	t.Helper()
+	a := New(t)
+	a.EqualIgnoringWhitespace(got, want)
+	return !a.HasFailed()
+}
+
+// EqualTrimmed is Assert.EqualTrimmed for a single assertion against t.
+// It reports whether the assertion passed.
+func EqualTrimmed(t TestingT, got, want string) bool {
+	t.Helper()
+	a := New(t)
+	a.EqualTrimmed(got, want)
+	return !a.HasFailed()
+}
+
+// ErrorAs is Assert.ErrorAs for a single assertion against t.
+// It reports whether the assertion passed.
+func ErrorAs(t TestingT, err error, target interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.ErrorAs(err, target)
+	return !a.HasFailed()
+}
+
+// ErrorContains is Assert.ErrorContains for a single assertion against t.
+// It reports whether the assertion passed.
+func ErrorContains(t TestingT, err error, substring string) bool {
+	t.Helper()
+	a := New(t)
+	a.ErrorContains(err, substring)
+	return !a.HasFailed()
+}
+
+// ErrorIs is Assert.ErrorIs for a single assertion against t.
+// It reports whether the assertion passed.
+func ErrorIs(t TestingT, err, target error) bool {
+	t.Helper()
+	a := New(t)
+	a.ErrorIs(err, target)
+	return !a.HasFailed()
+}
+
+// ErrorMatches is Assert.ErrorMatches for a single assertion against t.
+// It reports whether the assertion passed.
+func ErrorMatches(t TestingT, err error, pattern string) bool {
+	t.Helper()
+	a := New(t)
+	a.ErrorMatches(err, pattern)
+	return !a.HasFailed()
+}
+
+// ErrorType is Assert.ErrorType for a single assertion against t.
+// It reports whether the assertion passed.
+func ErrorType(t TestingT, expected, actual error) bool {
+	t.Helper()
+	a := New(t)
+	a.ErrorType(expected, actual)
+	return !a.HasFailed()
+}
+
+// Eventually is Assert.Eventually for a single assertion against t.
+// It reports whether the assertion passed.
+func Eventually(t TestingT, condition func() bool, timeout, interval time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.Eventually(condition, timeout, interval)
+	return !a.HasFailed()
+}
+
+// EventuallyWith is Assert.EventuallyWith for a single assertion against t.
+// It reports whether the assertion passed.
+func EventuallyWith(t TestingT, condition func() bool, config EventuallyConfig) bool {
+	t.Helper()
+	a := New(t)
+	a.EventuallyWith(condition, config)
+	return !a.HasFailed()
+}
+
+// ExitsWithCode is Assert.ExitsWithCode for a single assertion against t.
+// It reports whether the assertion passed.
+func ExitsWithCode(t TestingT, cmd *exec.Cmd, code int) bool {
+	t.Helper()
+	a := New(t)
+	a.ExitsWithCode(cmd, code)
+	return !a.HasFailed()
+}
+
+// False is Assert.False for a single assertion against t.
+// It reports whether the assertion passed.
+func False(t TestingT, condition bool) bool {
+	t.Helper()
+	a := New(t)
+	a.False(condition)
+	return !a.HasFailed()
+}
+
+// FieldSatisfies is Assert.FieldSatisfies for a single assertion against t.
+// It reports whether the assertion passed.
+func FieldSatisfies(t TestingT, obj interface{}, path string, predicate func(interface{}) bool) bool {
+	t.Helper()
+	a := New(t)
+	a.FieldSatisfies(obj, path, predicate)
+	return !a.HasFailed()
+}
+
+// FieldValue is Assert.FieldValue for a single assertion against t.
+// It reports whether the assertion passed.
+func FieldValue(t TestingT, obj interface{}, path string, want interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.FieldValue(obj, path, want)
+	return !a.HasFailed()
+}
+
+// FileContains is Assert.FileContains for a single assertion against t.
+// It reports whether the assertion passed.
+func FileContains(t TestingT, path, substring string) bool {
+	t.Helper()
+	a := New(t)
+	a.FileContains(path, substring)
+	return !a.HasFailed()
+}
+
+// FileEqual is Assert.FileEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func FileEqual(t TestingT, path, expected string) bool {
+	t.Helper()
+	a := New(t)
+	a.FileEqual(path, expected)
+	return !a.HasFailed()
+}
+
+// FileExists is Assert.FileExists for a single assertion against t.
+// It reports whether the assertion passed.
+func FileExists(t TestingT, path string) bool {
+	t.Helper()
+	a := New(t)
+	a.FileExists(path)
+	return !a.HasFailed()
+}
+
+// FileMatchesGolden is Assert.FileMatchesGolden for a single assertion against t.
+// It reports whether the assertion passed.
+func FileMatchesGolden(t TestingT, path, goldenPath string) bool {
+	t.Helper()
+	a := New(t)
+	a.FileMatchesGolden(path, goldenPath)
+	return !a.HasFailed()
+}
+
+// FilePermissions is Assert.FilePermissions for a single assertion against t.
+// It reports whether the assertion passed.
+func FilePermissions(t TestingT, path string, perm fs.FileMode) bool {
+	t.Helper()
+	a := New(t)
+	a.FilePermissions(path, perm)
+	return !a.HasFailed()
+}
+
+// FileSizeWithin is Assert.FileSizeWithin for a single assertion against t.
+// It reports whether the assertion passed.
+func FileSizeWithin(t TestingT, path string, min, max int64) bool {
+	t.Helper()
+	a := New(t)
+	a.FileSizeWithin(path, min, max)
+	return !a.HasFailed()
+}
+
+// HasBytePrefix is Assert.HasBytePrefix for a single assertion against t.
+// It reports whether the assertion passed.
+func HasBytePrefix(t TestingT, b, prefix []byte) bool {
+	t.Helper()
+	a := New(t)
+	a.HasBytePrefix(b, prefix)
+	return !a.HasFailed()
+}
+
+// HasByteSuffix is Assert.HasByteSuffix for a single assertion against t.
+// It reports whether the assertion passed.
+func HasByteSuffix(t TestingT, b, suffix []byte) bool {
+	t.Helper()
+	a := New(t)
+	a.HasByteSuffix(b, suffix)
+	return !a.HasFailed()
+}
+
+// HasCookie is Assert.HasCookie for a single assertion against t.
+// It reports whether the assertion passed.
+func HasCookie(t TestingT, response *http.Response, name string) bool {
+	t.Helper()
+	a := New(t)
+	a.HasCookie(response, name)
+	return !a.HasFailed()
+}
+
+// HasEntry is Assert.HasEntry for a single assertion against t.
+// It reports whether the assertion passed.
+func HasEntry(t TestingT, m, key, value interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.HasEntry(m, key, value)
+	return !a.HasFailed()
+}
+
+// HasError is Assert.HasError for a single assertion against t.
+// It reports whether the assertion passed.
+func HasError(t TestingT, err error) bool {
+	t.Helper()
+	a := New(t)
+	a.HasError(err)
+	return !a.HasFailed()
+}
+
+// HasField is Assert.HasField for a single assertion against t.
+// It reports whether the assertion passed.
+func HasField(t TestingT, obj interface{}, path string) bool {
+	t.Helper()
+	a := New(t)
+	a.HasField(obj, path)
+	return !a.HasFailed()
+}
+
+// HasHeader is Assert.HasHeader for a single assertion against t.
+// It reports whether the assertion passed.
+func HasHeader(t TestingT, response *http.Response, header string) bool {
+	t.Helper()
+	a := New(t)
+	a.HasHeader(response, header)
+	return !a.HasFailed()
+}
+
+// HasKey is Assert.HasKey for a single assertion against t.
+// It reports whether the assertion passed.
+func HasKey(t TestingT, m, key interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.HasKey(m, key)
+	return !a.HasFailed()
+}
+
+// HasPrefix is Assert.HasPrefix for a single assertion against t.
+// It reports whether the assertion passed.
+func HasPrefix(t TestingT, s, prefix string) bool {
+	t.Helper()
+	a := New(t)
+	a.HasPrefix(s, prefix)
+	return !a.HasFailed()
+}
+
+// HasSuffix is Assert.HasSuffix for a single assertion against t.
+// It reports whether the assertion passed.
+func HasSuffix(t TestingT, s, suffix string) bool {
+	t.Helper()
+	a := New(t)
+	a.HasSuffix(s, suffix)
+	return !a.HasFailed()
+}
+
+// HasValue is Assert.HasValue for a single assertion against t.
+// It reports whether the assertion passed.
+func HasValue(t TestingT, m, value interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.HasValue(m, value)
+	return !a.HasFailed()
+}
+
+// HeaderContains is Assert.HeaderContains for a single assertion against t.
+// It reports whether the assertion passed.
+func HeaderContains(t TestingT, response *http.Response, header, expected string) bool {
+	t.Helper()
+	a := New(t)
+	a.HeaderContains(response, header, expected)
+	return !a.HasFailed()
+}
+
+// HeaderEqual is Assert.HeaderEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func HeaderEqual(t TestingT, response *http.Response, header, expected string) bool {
+	t.Helper()
+	a := New(t)
+	a.HeaderEqual(response, header, expected)
+	return !a.HasFailed()
+}
+
+// HeaderMatches is Assert.HeaderMatches for a single assertion against t.
+// It reports whether the assertion passed.
+func HeaderMatches(t TestingT, response *http.Response, header, pattern string) bool {
+	t.Helper()
+	a := New(t)
+	a.HeaderMatches(response, header, pattern)
+	return !a.HasFailed()
+}
+
+// HttpStatus is Assert.HttpStatus for a single assertion against t.
+// It reports whether the assertion passed.
+func HttpStatus(t TestingT, response *http.Response, expected int) bool {
+	t.Helper()
+	a := New(t)
+	a.HttpStatus(response, expected)
+	return !a.HasFailed()
+}
+
+// InDeltaSlice is Assert.InDeltaSlice for a single assertion against t.
+// It reports whether the assertion passed.
+func InDeltaSlice(t TestingT, got, want []float64, delta float64) bool {
+	t.Helper()
+	a := New(t)
+	a.InDeltaSlice(got, want, delta)
+	return !a.HasFailed()
+}
+
+// IsBase64 is Assert.IsBase64 for a single assertion against t.
+// It reports whether the assertion passed.
+func IsBase64(t TestingT, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsBase64(s)
+	return !a.HasFailed()
+}
+
+// IsClientError is Assert.IsClientError for a single assertion against t.
+// It reports whether the assertion passed.
+func IsClientError(t TestingT, response *http.Response) bool {
+	t.Helper()
+	a := New(t)
+	a.IsClientError(response)
+	return !a.HasFailed()
+}
+
+// IsHex is Assert.IsHex for a single assertion against t.
+// It reports whether the assertion passed.
+func IsHex(t TestingT, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsHex(s)
+	return !a.HasFailed()
+}
+
+// IsIPv4 is Assert.IsIPv4 for a single assertion against t.
+// It reports whether the assertion passed.
+func IsIPv4(t TestingT, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsIPv4(s)
+	return !a.HasFailed()
+}
+
+// IsIPv6 is Assert.IsIPv6 for a single assertion against t.
+// It reports whether the assertion passed.
+func IsIPv6(t TestingT, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsIPv6(s)
+	return !a.HasFailed()
+}
+
+// IsISO8601 is Assert.IsISO8601 for a single assertion against t.
+// It reports whether the assertion passed.
+func IsISO8601(t TestingT, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsISO8601(s)
+	return !a.HasFailed()
+}
+
+// IsInf is Assert.IsInf for a single assertion against t.
+// It reports whether the assertion passed.
+func IsInf(t TestingT, value float64, sign int) bool {
+	t.Helper()
+	a := New(t)
+	a.IsInf(value, sign)
+	return !a.HasFailed()
+}
+
+// IsKind is Assert.IsKind for a single assertion against t.
+// It reports whether the assertion passed.
+func IsKind(t TestingT, value interface{}, kind reflect.Kind) bool {
+	t.Helper()
+	a := New(t)
+	a.IsKind(value, kind)
+	return !a.HasFailed()
+}
+
+// IsNaN is Assert.IsNaN for a single assertion against t.
+// It reports whether the assertion passed.
+func IsNaN(t TestingT, value float64) bool {
+	t.Helper()
+	a := New(t)
+	a.IsNaN(value)
+	return !a.HasFailed()
+}
+
+// IsNotZero is Assert.IsNotZero for a single assertion against t.
+// It reports whether the assertion passed.
+func IsNotZero(t TestingT, value interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.IsNotZero(value)
+	return !a.HasFailed()
+}
+
+// IsRedirect is Assert.IsRedirect for a single assertion against t.
+// It reports whether the assertion passed.
+func IsRedirect(t TestingT, response *http.Response) bool {
+	t.Helper()
+	a := New(t)
+	a.IsRedirect(response)
+	return !a.HasFailed()
+}
+
+// IsSemVer is Assert.IsSemVer for a single assertion against t.
+// It reports whether the assertion passed.
+func IsSemVer(t TestingT, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsSemVer(s)
+	return !a.HasFailed()
+}
+
+// IsServerError is Assert.IsServerError for a single assertion against t.
+// It reports whether the assertion passed.
+func IsServerError(t TestingT, response *http.Response) bool {
+	t.Helper()
+	a := New(t)
+	a.IsServerError(response)
+	return !a.HasFailed()
+}
+
+// IsSuccess is Assert.IsSuccess for a single assertion against t.
+// It reports whether the assertion passed.
+func IsSuccess(t TestingT, response *http.Response) bool {
+	t.Helper()
+	a := New(t)
+	a.IsSuccess(response)
+	return !a.HasFailed()
+}
+
+// IsULID is Assert.IsULID for a single assertion against t.
+// It reports whether the assertion passed.
+func IsULID(t TestingT, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsULID(s)
+	return !a.HasFailed()
+}
+
+// IsUUID is Assert.IsUUID for a single assertion against t.
+// It reports whether the assertion passed.
+func IsUUID(t TestingT, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsUUID(s)
+	return !a.HasFailed()
+}
+
+// IsValidURL is Assert.IsValidURL for a single assertion against t.
+// It reports whether the assertion passed.
+func IsValidURL(t TestingT, rawURL string) bool {
+	t.Helper()
+	a := New(t)
+	a.IsValidURL(rawURL)
+	return !a.HasFailed()
+}
+
+// IsWithinDuration is Assert.IsWithinDuration for a single assertion against t.
+// It reports whether the assertion passed.
+func IsWithinDuration(t TestingT, t1, t2 time.Time, d time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.IsWithinDuration(t1, t2, d)
+	return !a.HasFailed()
+}
+
+// IsZero is Assert.IsZero for a single assertion against t.
+// It reports whether the assertion passed.
+func IsZero(t TestingT, value interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.IsZero(value)
+	return !a.HasFailed()
+}
+
+// JsonEqual is Assert.JsonEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func JsonEqual(t TestingT, expected, actual string) bool {
+	t.Helper()
+	a := New(t)
+	a.JsonEqual(expected, actual)
+	return !a.HasFailed()
+}
+
+// Len is Assert.Len for a single assertion against t.
+// It reports whether the assertion passed.
+func Len(t TestingT, container interface{}, expectedLen int) bool {
+	t.Helper()
+	a := New(t)
+	a.Len(container, expectedLen)
+	return !a.HasFailed()
+}
+
+// LenBetween is Assert.LenBetween for a single assertion against t.
+// It reports whether the assertion passed.
+func LenBetween(t TestingT, container interface{}, min, max int) bool {
+	t.Helper()
+	a := New(t)
+	a.LenBetween(container, min, max)
+	return !a.HasFailed()
+}
+
+// LenGreaterThan is Assert.LenGreaterThan for a single assertion against t.
+// It reports whether the assertion passed.
+func LenGreaterThan(t TestingT, container interface{}, n int) bool {
+	t.Helper()
+	a := New(t)
+	a.LenGreaterThan(container, n)
+	return !a.HasFailed()
+}
+
+// LenLessThan is Assert.LenLessThan for a single assertion against t.
+// It reports whether the assertion passed.
+func LenLessThan(t TestingT, container interface{}, n int) bool {
+	t.Helper()
+	a := New(t)
+	a.LenLessThan(container, n)
+	return !a.HasFailed()
+}
+
+// LogsContain is Assert.LogsContain for a single assertion against t.
+// It reports whether the assertion passed.
+func LogsContain(t TestingT, logger RedirectableLogger, substr string, fn func()) bool {
+	t.Helper()
+	a := New(t)
+	a.LogsContain(logger, substr, fn)
+	return !a.HasFailed()
+}
+
+// LogsNotContain is Assert.LogsNotContain for a single assertion against t.
+// It reports whether the assertion passed.
+func LogsNotContain(t TestingT, logger RedirectableLogger, substr string, fn func()) bool {
+	t.Helper()
+	a := New(t)
+	a.LogsNotContain(logger, substr, fn)
+	return !a.HasFailed()
+}
+
+// MapDiff is Assert.MapDiff for a single assertion against t.
+// It reports whether the assertion passed.
+func MapDiff(t TestingT, got, want any) bool {
+	t.Helper()
+	a := New(t)
+	a.MapDiff(got, want)
+	return !a.HasFailed()
+}
+
+// MatchRegexp is Assert.MatchRegexp for a single assertion against t.
+// It reports whether the assertion passed.
+func MatchRegexp(t TestingT, pattern, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.MatchRegexp(pattern, s)
+	return !a.HasFailed()
+}
+
+// MatchesGolden is Assert.MatchesGolden for a single assertion against t.
+// It reports whether the assertion passed.
+func MatchesGolden(t TestingT, got, path string) bool {
+	t.Helper()
+	a := New(t)
+	a.MatchesGolden(got, path)
+	return !a.HasFailed()
+}
+
+// MatchesJSONSchema is Assert.MatchesJSONSchema for a single assertion against t.
+// It reports whether the assertion passed.
+func MatchesJSONSchema(t TestingT, jsonStr, schemaStr string) bool {
+	t.Helper()
+	a := New(t)
+	a.MatchesJSONSchema(jsonStr, schemaStr)
+	return !a.HasFailed()
+}
+
+// MatchesPattern is Assert.MatchesPattern for a single assertion against t.
+// It reports whether the assertion passed.
+func MatchesPattern(t TestingT, pattern, s string) bool {
+	t.Helper()
+	a := New(t)
+	a.MatchesPattern(pattern, s)
+	return !a.HasFailed()
+}
+
+// Never is Assert.Never for a single assertion against t.
+// It reports whether the assertion passed.
+func Never(t TestingT, condition func() bool, timeout, interval time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.Never(condition, timeout, interval)
+	return !a.HasFailed()
+}
+
+// NeverWith is Assert.NeverWith for a single assertion against t.
+// It reports whether the assertion passed.
+func NeverWith(t TestingT, condition func() bool, config EventuallyConfig) bool {
+	t.Helper()
+	a := New(t)
+	a.NeverWith(condition, config)
+	return !a.HasFailed()
+}
+
+// Nil is Assert.Nil for a single assertion against t.
+// It reports whether the assertion passed.
+func Nil(t TestingT, value interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.Nil(value)
+	return !a.HasFailed()
+}
+
+// NoDataRace is Assert.NoDataRace for a single assertion against t.
+// It reports whether the assertion passed.
+func NoDataRace(t TestingT, fn func()) bool {
+	t.Helper()
+	a := New(t)
+	a.NoDataRace(fn)
+	return !a.HasFailed()
+}
+
+// NoError is Assert.NoError for a single assertion against t.
+// It reports whether the assertion passed.
+func NoError(t TestingT, err error) bool {
+	t.Helper()
+	a := New(t)
+	a.NoError(err)
+	return !a.HasFailed()
+}
+
+// NoFileExists is Assert.NoFileExists for a single assertion against t.
+// It reports whether the assertion passed.
+func NoFileExists(t TestingT, path string) bool {
+	t.Helper()
+	a := New(t)
+	a.NoFileExists(path)
+	return !a.HasFailed()
+}
+
+// NotContains is Assert.NotContains for a single assertion against t.
+// It reports whether the assertion passed.
+func NotContains(t TestingT, container, item interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.NotContains(container, item)
+	return !a.HasFailed()
+}
+
+// NotEmpty is Assert.NotEmpty for a single assertion against t.
+// It reports whether the assertion passed.
+func NotEmpty(t TestingT, container interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.NotEmpty(container)
+	return !a.HasFailed()
+}
+
+// NotEqual is Assert.NotEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func NotEqual(t TestingT, got, want interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.NotEqual(got, want)
+	return !a.HasFailed()
+}
+
+// NotErrorIs is Assert.NotErrorIs for a single assertion against t.
+// It reports whether the assertion passed.
+func NotErrorIs(t TestingT, err, target error) bool {
+	t.Helper()
+	a := New(t)
+	a.NotErrorIs(err, target)
+	return !a.HasFailed()
+}
+
+// NotHasKey is Assert.NotHasKey for a single assertion against t.
+// It reports whether the assertion passed.
+func NotHasKey(t TestingT, m, key interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.NotHasKey(m, key)
+	return !a.HasFailed()
+}
+
+// NotImplements is Assert.NotImplements for a single assertion against t.
+// It reports whether the assertion passed.
+func NotImplements(t TestingT, object, interfaceObj interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.NotImplements(object, interfaceObj)
+	return !a.HasFailed()
+}
+
+// NotNaN is Assert.NotNaN for a single assertion against t.
+// It reports whether the assertion passed.
+func NotNaN(t TestingT, value float64) bool {
+	t.Helper()
+	a := New(t)
+	a.NotNaN(value)
+	return !a.HasFailed()
+}
+
+// NotNil is Assert.NotNil for a single assertion against t.
+// It reports whether the assertion passed.
+func NotNil(t TestingT, value interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.NotNil(value)
+	return !a.HasFailed()
+}
+
+// NotPanics is Assert.NotPanics for a single assertion against t.
+// It reports whether the assertion passed.
+func NotPanics(t TestingT, f func()) bool {
+	t.Helper()
+	a := New(t)
+	a.NotPanics(f)
+	return !a.HasFailed()
+}
+
+// NotRegexp is Assert.NotRegexp for a single assertion against t.
+// It reports whether the assertion passed.
+func NotRegexp(t TestingT, pattern, str string) bool {
+	t.Helper()
+	a := New(t)
+	a.NotRegexp(pattern, str)
+	return !a.HasFailed()
+}
+
+// NotSame is Assert.NotSame for a single assertion against t.
+// It reports whether the assertion passed.
+func NotSame(t TestingT, got, want interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.NotSame(got, want)
+	return !a.HasFailed()
+}
+
+// NotZero is Assert.NotZero for a single assertion against t.
+// It reports whether the assertion passed.
+func NotZero(t TestingT, value interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.NotZero(value)
+	return !a.HasFailed()
+}
+
+// Panics is Assert.Panics for a single assertion against t.
+// It reports whether the assertion passed.
+func Panics(t TestingT, f func()) bool {
+	t.Helper()
+	a := New(t)
+	a.Panics(f)
+	return !a.HasFailed()
+}
+
+// PanicsWith is Assert.PanicsWith for a single assertion against t.
+// It reports whether the assertion passed.
+func PanicsWith(t TestingT, f func(), expected interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.PanicsWith(f, expected)
+	return !a.HasFailed()
+}
+
+// ReaderContains is Assert.ReaderContains for a single assertion against t.
+// It reports whether the assertion passed.
+func ReaderContains(t TestingT, r io.Reader, substring string) bool {
+	t.Helper()
+	a := New(t)
+	a.ReaderContains(r, substring)
+	return !a.HasFailed()
+}
+
+// ReaderEqual is Assert.ReaderEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func ReaderEqual(t TestingT, r io.Reader, expected string) bool {
+	t.Helper()
+	a := New(t)
+	a.ReaderEqual(r, expected)
+	return !a.HasFailed()
+}
+
+// ReaderJSONEqual is Assert.ReaderJSONEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func ReaderJSONEqual(t TestingT, r io.Reader, expected string) bool {
+	t.Helper()
+	a := New(t)
+	a.ReaderJSONEqual(r, expected)
+	return !a.HasFailed()
+}
+
+// RedirectChain is Assert.RedirectChain for a single assertion against t.
+// It reports whether the assertion passed.
+func RedirectChain(t TestingT, client *http.Client, rawURL string, chain []string, opts ...RedirectOption) bool {
+	t.Helper()
+	a := New(t)
+	a.RedirectChain(client, rawURL, chain, opts...)
+	return !a.HasFailed()
+}
+
+// RedirectsTo is Assert.RedirectsTo for a single assertion against t.
+// It reports whether the assertion passed.
+func RedirectsTo(t TestingT, client *http.Client, rawURL, finalURL string, opts ...RedirectOption) bool {
+	t.Helper()
+	a := New(t)
+	a.RedirectsTo(client, rawURL, finalURL, opts...)
+	return !a.HasFailed()
+}
+
+// Regexp is Assert.Regexp for a single assertion against t.
+// It reports whether the assertion passed.
+func Regexp(t TestingT, pattern, str string) bool {
+	t.Helper()
+	a := New(t)
+	a.Regexp(pattern, str)
+	return !a.HasFailed()
+}
+
+// ResponseTime is Assert.ResponseTime for a single assertion against t.
+// It reports whether the assertion passed.
+func ResponseTime(t TestingT, url string, maxTime time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.ResponseTime(url, maxTime)
+	return !a.HasFailed()
+}
+
+// ResponseTimeWith is Assert.ResponseTimeWith for a single assertion against t.
+// It reports whether the assertion passed.
+func ResponseTimeWith(t TestingT, client *http.Client, req *http.Request, limit time.Duration, opts ...ResponseTimeOption) bool {
+	t.Helper()
+	a := New(t)
+	a.ResponseTimeWith(client, req, limit, opts...)
+	return !a.HasFailed()
+}
+
+// Same is Assert.Same for a single assertion against t.
+// It reports whether the assertion passed.
+func Same(t TestingT, got, want interface{}) bool {
+	t.Helper()
+	a := New(t)
+	a.Same(got, want)
+	return !a.HasFailed()
+}
+
+// SameDate is Assert.SameDate for a single assertion against t.
+// It reports whether the assertion passed.
+func SameDate(t TestingT, got, want time.Time) bool {
+	t.Helper()
+	a := New(t)
+	a.SameDate(got, want)
+	return !a.HasFailed()
+}
+
+// ServesTLSVersionAtLeast is Assert.ServesTLSVersionAtLeast for a single assertion against t.
+// It reports whether the assertion passed.
+func ServesTLSVersionAtLeast(t TestingT, addr string, version uint16) bool {
+	t.Helper()
+	a := New(t)
+	a.ServesTLSVersionAtLeast(addr, version)
+	return !a.HasFailed()
+}
+
+// SliceDiff is Assert.SliceDiff for a single assertion against t.
+// It reports whether the assertion passed.
+func SliceDiff(t TestingT, got, want []int) bool {
+	t.Helper()
+	a := New(t)
+	a.SliceDiff(got, want)
+	return !a.HasFailed()
+}
+
+// SliceDiffGeneric is Assert.SliceDiffGeneric for a single assertion against t.
+// It reports whether the assertion passed.
+func SliceDiffGeneric(t TestingT, got, want any) bool {
+	t.Helper()
+	a := New(t)
+	a.SliceDiffGeneric(got, want)
+	return !a.HasFailed()
+}
+
+// SpyCalled is Assert.SpyCalled for a single assertion against t.
+// It reports whether the assertion passed.
+func SpyCalled(t TestingT, spy CallCounter) bool {
+	t.Helper()
+	a := New(t)
+	a.SpyCalled(spy)
+	return !a.HasFailed()
+}
+
+// SpyCalledTimes is Assert.SpyCalledTimes for a single assertion against t.
+// It reports whether the assertion passed.
+func SpyCalledTimes(t TestingT, spy CallCounter, expected int) bool {
+	t.Helper()
+	a := New(t)
+	a.SpyCalledTimes(spy, expected)
+	return !a.HasFailed()
+}
+
+// SpyNotCalled is Assert.SpyNotCalled for a single assertion against t.
+// It reports whether the assertion passed.
+func SpyNotCalled(t TestingT, spy CallCounter) bool {
+	t.Helper()
+	a := New(t)
+	a.SpyNotCalled(spy)
+	return !a.HasFailed()
+}
+
+// StdoutContains is Assert.StdoutContains for a single assertion against t.
+// It reports whether the assertion passed.
+func StdoutContains(t TestingT, cmd *exec.Cmd, substr string) bool {
+	t.Helper()
+	a := New(t)
+	a.StdoutContains(cmd, substr)
+	return !a.HasFailed()
+}
+
+// StructDiff is Assert.StructDiff for a single assertion against t.
+// It reports whether the assertion passed.
+func StructDiff(t TestingT, got, want any) bool {
+	t.Helper()
+	a := New(t)
+	a.StructDiff(got, want)
+	return !a.HasFailed()
+}
+
+// TimeEqual is Assert.TimeEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func TimeEqual(t TestingT, got, want time.Time) bool {
+	t.Helper()
+	a := New(t)
+	a.TimeEqual(got, want)
+	return !a.HasFailed()
+}
+
+// True is Assert.True for a single assertion against t.
+// It reports whether the assertion passed.
+func True(t TestingT, condition bool) bool {
+	t.Helper()
+	a := New(t)
+	a.True(condition)
+	return !a.HasFailed()
+}
+
+// URLEqual is Assert.URLEqual for a single assertion against t.
+// It reports whether the assertion passed.
+func URLEqual(t TestingT, got, want string) bool {
+	t.Helper()
+	a := New(t)
+	a.URLEqual(got, want)
+	return !a.HasFailed()
+}
+
+// URLHasHost is Assert.URLHasHost for a single assertion against t.
+// It reports whether the assertion passed.
+func URLHasHost(t TestingT, rawURL, host string) bool {
+	t.Helper()
+	a := New(t)
+	a.URLHasHost(rawURL, host)
+	return !a.HasFailed()
+}
+
+// URLHasPath is Assert.URLHasPath for a single assertion against t.
+// It reports whether the assertion passed.
+func URLHasPath(t TestingT, rawURL, path string) bool {
+	t.Helper()
+	a := New(t)
+	a.URLHasPath(rawURL, path)
+	return !a.HasFailed()
+}
+
+// URLHasQueryParam is Assert.URLHasQueryParam for a single assertion against t.
+// It reports whether the assertion passed.
+func URLHasQueryParam(t TestingT, rawURL, key, value string) bool {
+	t.Helper()
+	a := New(t)
+	a.URLHasQueryParam(rawURL, key, value)
+	return !a.HasFailed()
+}
+
+// URLHasScheme is Assert.URLHasScheme for a single assertion against t.
+// It reports whether the assertion passed.
+func URLHasScheme(t TestingT, rawURL, scheme string) bool {
+	t.Helper()
+	a := New(t)
+	a.URLHasScheme(rawURL, scheme)
+	return !a.HasFailed()
+}
+
+// VerifyAll is Assert.VerifyAll for a single assertion against t.
+// It reports whether the assertion passed.
+func VerifyAll(t TestingT, verifiers ...Verifier) bool {
+	t.Helper()
+	a := New(t)
+	a.VerifyAll(verifiers...)
+	return !a.HasFailed()
+}
+
+// WithinPercentage is Assert.WithinPercentage for a single assertion against t.
+// It reports whether the assertion passed.
+func WithinPercentage(t TestingT, expected, actual, percentage float64) bool {
+	t.Helper()
+	a := New(t)
+	a.WithinPercentage(expected, actual, percentage)
+	return !a.HasFailed()
+}
+
+// WithinTimeout is Assert.WithinTimeout for a single assertion against t.
+// It reports whether the assertion passed.
+func WithinTimeout(t TestingT, f func(), timeout time.Duration) bool {
+	t.Helper()
+	a := New(t)
+	a.WithinTimeout(f, timeout)
+	return !a.HasFailed()
+}
+
+// WithinTolerance is Assert.WithinTolerance for a single assertion against t.
+// It reports whether the assertion passed.
+func WithinTolerance(t TestingT, expected, actual, tolerance float64) bool {
+	t.Helper()
+	a := New(t)
+	a.WithinTolerance(expected, actual, tolerance)
+	return !a.HasFailed()
+}
+
+// WithinWindow is Assert.WithinWindow for a single assertion against t.
+// It reports whether the assertion passed.
+func WithinWindow(t TestingT, got, start, end time.Time) bool {
+	t.Helper()
+	a := New(t)
+	a.WithinWindow(got, start, end)
+	return !a.HasFailed()
+}
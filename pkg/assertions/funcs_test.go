@@ -0,0 +1,68 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestPackageFunctions tests the package-level functions generated from the
+// assertion methods.
+func TestPackageFunctions(t *testing.T) {
+	tests := []struct {
+		name          string
+		check         func(t TestingT) bool
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"Equal pass", func(t TestingT) bool { return Equal(t, 2, 2) }, true, ""},
+		{"Equal fail", func(t TestingT) bool { return Equal(t, 1, 2) }, false, "values differ"},
+		{"NoError fail", func(t TestingT) bool { return NoError(t, errors.New("boom")) }, false, "boom"},
+		{"Contains pass", func(t TestingT) bool { return Contains(t, []string{"a", "b"}, "b") }, true, ""},
+		{"no result method", func(t TestingT) bool { return FileExists(t, "testdata/no-such-file") }, false, "no-such-file"},
+		{"variadic", func(t TestingT) bool { return VerifyAll(t) }, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			if passed := tt.check(mock); passed != tt.shouldPass {
+				t.Errorf("Expected the function to return %v, got %v", tt.shouldPass, passed)
+			}
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestPackageFunctionsDoNotChain tests that each call reports its own
+// failure, as each uses a fresh Assert.
+func TestPackageFunctionsDoNotChain(t *testing.T) {
+	mock := &behaviorMockT{}
+	Equal(mock, 1, 2)
+	True(mock, false)
+
+	if len(mock.errorCalls) != 2 {
+		t.Errorf("Expected both failures reported, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+	}
+}
+
+func ExampleEqual() {
+	t := &behaviorMockT{}
+	if !Equal(t, 3, 5) {
+		fmt.Println(t.errorCalls[0])
+	}
+	// Output:
+	// values differ
+	//   got:  3
+	//   want: 5
+}
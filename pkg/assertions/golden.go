@@ -0,0 +1,131 @@
+package assertions
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gowise/pkg/diff"
+)
+
+// WritePatchEnv names the environment variable that enables patch output for
+// failed golden comparisons. Its value is the directory patches are written to.
+const WritePatchEnv = "GOWISE_WRITE_PATCH"
+
+// writePatchDir is set by the -gowise.write-patch test flag.
+var writePatchDir string
+
+func init() {
+	// Only register the flag in test binaries so importing the package never
+	// alters a program's own command line
+	if testing.Testing() {
+		flag.StringVar(&writePatchDir, "gowise.write-patch", "", "write an applyable patch for each failed golden comparison to `dir`")
+	}
+}
+
+// patchOutputDir returns the directory patches are written to, or "" when disabled.
+// The test flag takes precedence over the environment variable.
+func patchOutputDir() string {
+	if writePatchDir != "" {
+		return writePatchDir
+	}
+	return os.Getenv(WritePatchEnv)
+}
+
+// MatchesGolden asserts that got equals the contents of the golden file at path.
+// On mismatch the failure shows the usual multi-line diff. When patch output is
+// enabled, with go test -gowise.write-patch=dir or GOWISE_WRITE_PATCH=dir, a
+// unified patch updating the golden file to got is also written to dir, so the
+// expected output can be refreshed with git apply rather than copied from a log.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.MatchesGolden(rendered, "testdata/report.golden")
+func (a *Assert) MatchesGolden(got, path string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.matchGolden(got, path)
+	return a
+}
+
+// matchGolden compares got with the golden file at path, which is always read
+// from disk, and reports any difference.
+func (a *Assert) matchGolden(got, path string) {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		a.reportMessagef("failed to read golden file\n  path:  %s\n  error: %v", path, err)
+		return
+	}
+
+	want := string(data)
+	if got == want {
+		return
+	}
+
+	message := fmt.Sprintf("output does not match golden file %s", path)
+	if dir := patchOutputDir(); dir != "" {
+		patchPath, err := writeGoldenPatch(dir, path, want, got)
+		if err != nil {
+			message += fmt.Sprintf("\n  failed to write patch: %v", err)
+		} else {
+			message += fmt.Sprintf("\n  patch written to %s (apply with: git apply %s)", patchPath, patchPath)
+		}
+	}
+
+	a.reportErrorConsistent(got, want, message)
+}
+
+// writeGoldenPatch writes a patch updating the golden file at path from want to
+// got and returns the patch file's location. Paths inside the patch are relative
+// to the module root so the patch applies from the repository checkout.
+func writeGoldenPatch(dir, path, want, got string) (string, error) {
+	rel := moduleRelativePath(path)
+	patch := diff.Patch(rel, want, got)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	patchPath := filepath.Join(dir, strings.ReplaceAll(rel, "/", "_")+".patch")
+	if err := os.WriteFile(patchPath, []byte(patch), 0o644); err != nil {
+		return "", err
+	}
+	return patchPath, nil
+}
+
+// moduleRelativePath returns path relative to the nearest enclosing directory
+// containing go.mod, using forward slashes. If no module root is found the path
+// is returned as given.
+func moduleRelativePath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+
+	for dir := filepath.Dir(abs); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			if rel, err := filepath.Rel(dir, abs); err == nil {
+				return filepath.ToSlash(rel)
+			}
+			break
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
+	}
+	return filepath.ToSlash(path)
+}
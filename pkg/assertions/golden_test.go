@@ -0,0 +1,119 @@
+package assertions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gowise/pkg/diff"
+)
+
+const greetingGolden = "testdata/greeting.golden"
+
+// TestMatchesGolden tests matching, mismatching and missing golden files.
+func TestMatchesGolden(t *testing.T) {
+	usePatchDir(t, "")
+
+	tests := []struct {
+		name          string
+		got, path     string
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"matches", "Hello, gowise!\nVersion: 1\n", greetingGolden, true, ""},
+		{"differs", "Hello, gowise!\nVersion: 2\n", greetingGolden, false, "output does not match golden file testdata/greeting.golden"},
+		{"missing file", "anything", "testdata/missing.golden", false, "failed to read golden file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			assert := New(mock)
+
+			assert.MatchesGolden(tt.got, tt.path)
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("MatchesGolden should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("MatchesGolden should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+				if strings.Contains(mock.errorCalls[0], "patch written") {
+					t.Errorf("Expected no patch without %s, got: %s", WritePatchEnv, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestMatchesGoldenWritesPatch tests that a failed comparison writes a patch
+// relative to the module root when patch output is enabled.
+func TestMatchesGoldenWritesPatch(t *testing.T) {
+	dir := t.TempDir()
+	usePatchDir(t, dir)
+
+	got := "Hello, gowise!\nVersion: 2\n"
+	mock := &behaviorMockT{}
+	New(mock).MatchesGolden(got, greetingGolden)
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+	}
+
+	patchPath := filepath.Join(dir, "pkg_assertions_testdata_greeting.golden.patch")
+	if !strings.Contains(mock.errorCalls[0], "patch written to "+patchPath) {
+		t.Errorf("Expected failure to name the patch file, got: %s", mock.errorCalls[0])
+	}
+
+	patch, err := os.ReadFile(patchPath)
+	if err != nil {
+		t.Fatalf("Expected patch file to be written: %v", err)
+	}
+	want := diff.Patch("pkg/assertions/testdata/greeting.golden", "Hello, gowise!\nVersion: 1\n", got)
+	if string(patch) != want {
+		t.Errorf("Patch content mismatch\ngot:\n%s\nwant:\n%s", patch, want)
+	}
+}
+
+// TestMatchesGoldenNoPatchOnPass tests that passing comparisons write nothing.
+func TestMatchesGoldenNoPatchOnPass(t *testing.T) {
+	dir := t.TempDir()
+	usePatchDir(t, dir)
+
+	mock := &behaviorMockT{}
+	New(mock).MatchesGolden("Hello, gowise!\nVersion: 1\n", greetingGolden)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mock.errorCalls) != 0 || len(entries) != 0 {
+		t.Errorf("Expected pass without patch, got %d errors and %d files", len(mock.errorCalls), len(entries))
+	}
+}
+
+// usePatchDir sets patch output for the duration of a test, overriding any
+// -gowise.write-patch flag given to the test binary.
+func usePatchDir(t *testing.T, dir string) {
+	t.Helper()
+	t.Setenv(WritePatchEnv, dir)
+	flagDir := writePatchDir
+	writePatchDir = ""
+	t.Cleanup(func() { writePatchDir = flagDir })
+}
+
+// ExampleAssert_MatchesGolden demonstrates comparing output against a golden file.
+func ExampleAssert_MatchesGolden() {
+	t := &silentT{}
+	assert := New(t)
+
+	assert.MatchesGolden("Hello, gowise!\nVersion: 1\n", "testdata/greeting.golden")
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
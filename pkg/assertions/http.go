@@ -0,0 +1,741 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ResponseTimeOption configures ResponseTimeWith.
+type ResponseTimeOption func(*responseTimeConfig)
+
+// responseTimeConfig holds the settings of ResponseTimeWith.
+type responseTimeConfig struct {
+	warmUp     int     // Untimed requests sent first
+	samples    int     // Timed requests
+	percentile float64 // Percentile of the samples held to the limit, in (0, 100]
+}
+
+// ResponseWarmUp makes ResponseTimeWith send n untimed requests before
+// sampling, so that connection set-up, caches and lazy initialisation on
+// the server do not count against the limit.
+func ResponseWarmUp(n int) ResponseTimeOption {
+	return func(c *responseTimeConfig) { c.warmUp = n }
+}
+
+// ResponseSamples makes ResponseTimeWith time n requests rather than one.
+func ResponseSamples(n int) ResponseTimeOption {
+	return func(c *responseTimeConfig) { c.samples = n }
+}
+
+// ResponsePercentile makes ResponseTimeWith hold the pth percentile of the
+// sampled response times to the limit, by the nearest-rank method, rather
+// than the slowest: with ResponsePercentile(95) and 20 samples, the slowest
+// may exceed it. p is clamped to (0, 100].
+func ResponsePercentile(p float64) ResponseTimeOption {
+	return func(c *responseTimeConfig) { c.percentile = p }
+}
+
+// ResponseTimeWith asserts that client responds to req within limit. Each
+// response is timed from sending the request until its body has been read
+// in full, and is then closed, releasing the connection. By default one
+// request is timed and must be within limit; options add warm-up requests
+// and further samples, and hold a percentile of the samples to the limit
+// instead. A nil client uses http.DefaultClient, and req's context bounds
+// every request. A request with a body must have GetBody set, as
+// http.NewRequest does for in-memory bodies, to be sent more than once.
+// Transport errors fail the assertion. Returns *Assert to enable method
+// chaining.
+//
+// Example:
+//
+//	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/health", nil)
+//	assert.ResponseTimeWith(srv.Client(), req, 50*time.Millisecond,
+//		assertions.ResponseWarmUp(2), assertions.ResponseSamples(20), assertions.ResponsePercentile(95))
+func (a *Assert) ResponseTimeWith(client *http.Client, req *http.Request, limit time.Duration, opts ...ResponseTimeOption) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	if req == nil {
+		a.reportMessagef("cannot time a nil request")
+		return a
+	}
+	defer a.recordDuration(time.Now())
+
+	config := responseTimeConfig{samples: 1, percentile: 100}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config.samples = max(config.samples, 1)
+	config.percentile = min(max(config.percentile, math.SmallestNonzeroFloat64), 100)
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if total := config.warmUp + config.samples; total > 1 && req.Body != nil && req.GetBody == nil {
+		a.reportMessagef("cannot send request %d times: its body has no GetBody to resend it\n  request: %s %s",
+			total, req.Method, req.URL)
+		return a
+	}
+
+	var durations []time.Duration
+	for i := 0; i < config.warmUp+config.samples; i++ {
+		elapsed, err := timeRequest(client, req)
+		if err != nil {
+			a.reportMessagef("error making request\n  request: %s %s\n  attempt: %d of %d\n  error: %v",
+				req.Method, req.URL, i+1, config.warmUp+config.samples, err)
+			return a
+		}
+		if i >= config.warmUp {
+			durations = append(durations, elapsed)
+		}
+	}
+
+	slices.Sort(durations)
+	rank := int(math.Ceil(config.percentile/100*float64(len(durations)))) - 1
+	if observed := durations[max(rank, 0)]; observed > limit {
+		a.reportMessageConsistent(describeResponseTimes(req, durations, observed, limit, config.percentile))
+	}
+	return a
+}
+
+// timeRequest sends a fresh copy of req and returns how long the response
+// took to arrive and be read in full. The body is drained and closed so the
+// connection returns to the client's pool.
+func timeRequest(client *http.Client, req *http.Request) (time.Duration, error) {
+	attempt := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return 0, err
+		}
+		attempt.Body = body
+	}
+
+	start := time.Now()
+	resp, err := client.Do(attempt)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// describeResponseTimes renders a response time failure: the observed time
+// against the limit and, for several samples, their spread.
+func describeResponseTimes(req *http.Request, durations []time.Duration, observed, limit time.Duration, percentile float64) string {
+	if len(durations) == 1 {
+		return fmt.Sprintf("response time exceeded\n  request: %s %s\n  got: %s\n  limit: %s",
+			req.Method, req.URL, humaniseDuration(observed), humaniseDuration(limit))
+	}
+	return fmt.Sprintf("response time exceeded at p%s of %d samples\n  request: %s %s\n  got: %s\n  limit: %s\n  fastest: %s\n  median: %s\n  slowest: %s",
+		formatNumeric(percentile, DefaultFormatOptions()), len(durations), req.Method, req.URL,
+		humaniseDuration(observed), humaniseDuration(limit), humaniseDuration(durations[0]),
+		humaniseDuration(durations[len(durations)/2]), humaniseDuration(durations[len(durations)-1]))
+}
+
+// HasHeader asserts that a HTTP response has a certain header. Header names
+// match case-insensitively, as in HTTP, so "content-type" finds a
+// Content-Type header. On failure the headers it does have are listed.
+// Returns *Assert to enable method chaining.
+func (a *Assert) HasHeader(response *http.Response, header string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.header(response, header)
+	return a
+}
+
+// HeaderEqual asserts that the first value of a HTTP response header, the
+// one Header.Get returns, is expected. Returns *Assert to enable method
+// chaining.
+func (a *Assert) HeaderEqual(response *http.Response, header, expected string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if values, ok := a.header(response, header); ok && (len(values) == 0 || values[0] != expected) {
+		got := ""
+		if len(values) > 0 {
+			got = values[0]
+		}
+		a.reportErrorConsistent(got, expected,
+			fmt.Sprintf("expected different header value for %s", http.CanonicalHeaderKey(header)))
+	}
+	return a
+}
+
+// HeaderContains asserts that one of the values of a HTTP response header
+// is expected. Returns *Assert to enable method chaining.
+func (a *Assert) HeaderContains(response *http.Response, header, expected string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if values, ok := a.header(response, header); ok && !slices.Contains(values, expected) {
+		a.reportMessagef("expected header %s to contain value\n  got: %q\n  want: %q",
+			http.CanonicalHeaderKey(header), values, expected)
+	}
+	return a
+}
+
+// HeaderMatches asserts that a value of a HTTP response header matches the
+// regular expression pattern. As with MatchRegexp, an invalid pattern is
+// reported distinctly from a failed match. Returns *Assert to enable method
+// chaining.
+//
+// Example:
+//
+//	assert.HeaderMatches(resp, "Cache-Control", `max-age=\d+`)
+func (a *Assert) HeaderMatches(response *http.Response, header, pattern string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	re, err := a.compileRegexp(pattern)
+	if err != nil {
+		a.reportInvalidPattern(pattern, err)
+		return a
+	}
+
+	values, ok := a.header(response, header)
+	if ok && !slices.ContainsFunc(values, re.MatchString) {
+		a.reportMessagef("expected header %s to match regular expression\n  pattern: %s\n  got:     %q",
+			http.CanonicalHeaderKey(header), pattern, values)
+	}
+	return a
+}
+
+// ContentTypeIs asserts that the Content-Type of a HTTP response is the
+// media type want. Media types compare case-insensitively and parameters
+// the response adds, such as charset, are ignored, so "application/json"
+// matches "application/json; charset=utf-8". Parameters given in want must
+// be present with the same value; charsets compare case-insensitively.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.HttpStatus(resp, http.StatusOK).ContentTypeIs(resp, "application/json")
+func (a *Assert) ContentTypeIs(response *http.Response, want string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	wantType, wantParams, err := mime.ParseMediaType(want)
+	if err == nil && !strings.Contains(wantType, "/") {
+		err = errors.New("expected a type/subtype such as application/json")
+	}
+	if err != nil {
+		a.reportMessagef("invalid content type\n  want:  %s\n  error: %v", want, err)
+		return a
+	}
+	values, ok := a.header(response, "Content-Type")
+	if !ok {
+		return a
+	}
+
+	got := ""
+	if len(values) > 0 {
+		got = values[0]
+	}
+	gotType, gotParams, err := mime.ParseMediaType(got)
+	if err == nil && gotType == wantType && paramsMatch(gotParams, wantParams) {
+		return a
+	}
+	a.reportMessagef("expected content type %s\n  got:  %s\n  want: %s", want, got, want)
+	return a
+}
+
+// paramsMatch reports whether got has every media type parameter of want,
+// comparing charsets case-insensitively, as charset names are.
+func paramsMatch(got, want map[string]string) bool {
+	for name, value := range want {
+		other, ok := got[name]
+		if !ok || (other != value && !(name == "charset" && strings.EqualFold(other, value))) {
+			return false
+		}
+	}
+	return true
+}
+
+// header returns the values of the named header of response, reporting a
+// failure that lists the headers it does have if there is none. Names match
+// case-insensitively, including keys of a hand-built http.Header that are
+// not in canonical form.
+func (a *Assert) header(response *http.Response, name string) ([]string, bool) {
+	if response == nil {
+		a.reportMessagef("cannot check header %s of a nil response", http.CanonicalHeaderKey(name))
+		return nil, false
+	}
+	if values, ok := response.Header[http.CanonicalHeaderKey(name)]; ok {
+		return values, true
+	}
+	for key, values := range response.Header {
+		if strings.EqualFold(key, name) {
+			return values, true
+		}
+	}
+
+	names := make([]string, 0, len(response.Header))
+	for key := range response.Header {
+		names = append(names, http.CanonicalHeaderKey(key))
+	}
+	slices.Sort(names)
+	present := "(none)"
+	if len(names) > 0 {
+		present = strings.Join(slices.Compact(names), ", ")
+	}
+	a.reportMessagef("expected to have header %s\n  headers present: %s", http.CanonicalHeaderKey(name), present)
+	return nil, false
+}
+
+// HasCookie asserts that a HTTP response sets a certain cookie. On failure
+// the cookies it does set are listed.
+func (a *Assert) HasCookie(response *http.Response, name string) {
+	a.countAssertion()
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.cookie(response, name)
+}
+
+// CookieValue asserts that a HTTP response sets the named cookie to want.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.CookieValue(resp, "theme", "dark").CookieHTTPOnly(resp, "session")
+func (a *Assert) CookieValue(response *http.Response, name, want string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if cookie, ok := a.cookie(response, name); ok && cookie.Value != want {
+		a.reportErrorConsistent(cookie.Value, want, fmt.Sprintf("cookie %q has the wrong value", name))
+	}
+	return a
+}
+
+// CookieSecure asserts that the named cookie of a HTTP response has the
+// Secure attribute, so browsers only send it over HTTPS. Returns *Assert to
+// enable method chaining.
+func (a *Assert) CookieSecure(response *http.Response, name string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if cookie, ok := a.cookie(response, name); ok && !cookie.Secure {
+		a.reportMessagef("expected cookie %q to be Secure\n  cookie: %s", name, describeCookie(cookie))
+	}
+	return a
+}
+
+// CookieHTTPOnly asserts that the named cookie of a HTTP response has the
+// HttpOnly attribute, hiding it from scripts. Returns *Assert to enable
+// method chaining.
+func (a *Assert) CookieHTTPOnly(response *http.Response, name string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if cookie, ok := a.cookie(response, name); ok && !cookie.HttpOnly {
+		a.reportMessagef("expected cookie %q to be HttpOnly\n  cookie: %s", name, describeCookie(cookie))
+	}
+	return a
+}
+
+// CookieSameSite asserts that the named cookie of a HTTP response has the
+// given SameSite attribute. http.SameSiteDefaultMode matches a cookie that
+// sets SameSite without a value. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.CookieSameSite(resp, "session", http.SameSiteStrictMode)
+func (a *Assert) CookieSameSite(response *http.Response, name string, want http.SameSite) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if cookie, ok := a.cookie(response, name); ok && cookie.SameSite != want {
+		a.reportMessagef("cookie %q has the wrong SameSite attribute\n  got: %s\n  want: %s\n  cookie: %s",
+			name, sameSiteName(cookie.SameSite), sameSiteName(want), describeCookie(cookie))
+	}
+	return a
+}
+
+// CookieExpiresAfter asserts that the named cookie of a HTTP response
+// outlives t: by Max-Age counted from now if it sets one, as browsers do,
+// or else by Expires. Session cookies, which set neither, fail. Returns
+// *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.CookieExpiresAfter(resp, "remember_me", time.Now().Add(30*24*time.Hour))
+func (a *Assert) CookieExpiresAfter(response *http.Response, name string, t time.Time) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if h, ok := a.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	cookie, ok := a.cookie(response, name)
+	if !ok {
+		return a
+	}
+	expires, session := cookieExpiry(cookie, time.Now())
+	switch {
+	case session:
+		a.reportMessagef("expected cookie %q to expire after %s, but it is a session cookie\n  cookie: %s",
+			name, t.Format(time.RFC3339), describeCookie(cookie))
+	case !expires.After(t):
+		a.reportMessagef("expected cookie %q to expire after %s\n  expires: %s\n  cookie: %s",
+			name, t.Format(time.RFC3339), expires.Format(time.RFC3339), describeCookie(cookie))
+	}
+	return a
+}
+
+// cookie returns the named cookie set by response, reporting a failure that
+// lists the cookies it does set if there is none.
+func (a *Assert) cookie(response *http.Response, name string) (*http.Cookie, bool) {
+	if response == nil {
+		a.reportMessagef("cannot check cookie %q of a nil response", name)
+		return nil, false
+	}
+	cookies := response.Cookies()
+	for _, cookie := range cookies {
+		if cookie.Name == name {
+			return cookie, true
+		}
+	}
+
+	present := "(none)"
+	if len(cookies) > 0 {
+		described := make([]string, len(cookies))
+		for i, cookie := range cookies {
+			described[i] = describeCookie(cookie)
+		}
+		present = strings.Join(described, "\n    ")
+	}
+	a.reportMessagef("expected to have cookie %q\n  cookies present:\n    %s", name, present)
+	return nil, false
+}
+
+// describeCookie renders a cookie's name and attributes, but not its value,
+// which is often a credential.
+func describeCookie(cookie *http.Cookie) string {
+	attributes := []string{}
+	if cookie.Path != "" {
+		attributes = append(attributes, "Path="+cookie.Path)
+	}
+	if cookie.Domain != "" {
+		attributes = append(attributes, "Domain="+cookie.Domain)
+	}
+	if cookie.MaxAge != 0 {
+		attributes = append(attributes, fmt.Sprintf("Max-Age=%d", cookie.MaxAge))
+	}
+	if !cookie.Expires.IsZero() {
+		attributes = append(attributes, "Expires="+cookie.Expires.Format(time.RFC3339))
+	}
+	if cookie.Secure {
+		attributes = append(attributes, "Secure")
+	}
+	if cookie.HttpOnly {
+		attributes = append(attributes, "HttpOnly")
+	}
+	if cookie.SameSite != 0 {
+		attributes = append(attributes, "SameSite="+sameSiteName(cookie.SameSite))
+	}
+	if len(attributes) == 0 {
+		return cookie.Name
+	}
+	return cookie.Name + " (" + strings.Join(attributes, ", ") + ")"
+}
+
+// sameSiteName names a SameSite mode as it appears in a Set-Cookie header.
+func sameSiteName(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteDefaultMode:
+		return "(no value)"
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	default:
+		return "(not set)"
+	}
+}
+
+// cookieExpiry returns when a cookie received at now expires, and whether
+// it is a session cookie, which has no expiry. Max-Age takes precedence over
+// Expires, and a negative Max-Age expires the cookie at once.
+func cookieExpiry(cookie *http.Cookie, now time.Time) (expires time.Time, session bool) {
+	switch {
+	case cookie.MaxAge < 0:
+		return now, false
+	case cookie.MaxAge > 0:
+		return now.Add(time.Duration(cookie.MaxAge) * time.Second), false
+	case !cookie.Expires.IsZero():
+		return cookie.Expires, false
+	default:
+		return time.Time{}, true
+	}
+}
+
+// RedirectOption configures RedirectsTo and RedirectChain.
+type RedirectOption func(*redirectConfig)
+
+// redirectConfig holds the settings of the redirect assertions.
+type redirectConfig struct {
+	maxHops  int   // Redirects followed before giving up
+	statuses []int // Wanted status of each response, if set
+}
+
+// defaultMaxRedirects matches the limit of http.Client's default policy.
+const defaultMaxRedirects = 10
+
+// RedirectMaxHops makes the redirect assertions follow at most n redirects,
+// rather than 10, failing if the chain is longer.
+func RedirectMaxHops(n int) RedirectOption {
+	return func(c *redirectConfig) { c.maxHops = n }
+}
+
+// RedirectStatuses makes the redirect assertions check the status code of
+// each response in turn: one per redirect, then the final response.
+//
+// Example:
+//
+//	assert.RedirectsTo(nil, srv.URL+"/old", "/new",
+//		assertions.RedirectStatuses(http.StatusMovedPermanently, http.StatusOK))
+func RedirectStatuses(codes ...int) RedirectOption {
+	return func(c *redirectConfig) { c.statuses = codes }
+}
+
+// redirectHop is one response of a redirect chain.
+type redirectHop struct {
+	url    string
+	status int
+}
+
+// RedirectsTo asserts that a GET request for rawURL, sent with client, ends up
+// at finalURL after following any redirects. finalURL may be relative to
+// rawURL, as in "/login". Redirects are followed one at a time, up to 10 by
+// default, so the failure shows every hop with its status code; client's
+// cookie jar and transport are used, but not its CheckRedirect policy. A
+// nil client uses http.DefaultClient. Returns *Assert to enable method
+// chaining.
+//
+// Example:
+//
+//	assert.RedirectsTo(srv.Client(), srv.URL+"/account", "/login?next=%2Faccount")
+func (a *Assert) RedirectsTo(client *http.Client, rawURL, finalURL string, opts ...RedirectOption) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	hops, ok := a.followRedirects(client, rawURL, opts)
+	if !ok {
+		return a
+	}
+	want, err := resolveURL(rawURL, finalURL)
+	if err != nil {
+		a.reportMessagef("invalid final URL %q: %v", finalURL, err)
+		return a
+	}
+	if got := hops[len(hops)-1].url; got != want {
+		a.reportMessagef("expected redirect to %s\n  got: %s\n  chain:\n%s", want, got, describeRedirects(hops))
+	}
+	return a
+}
+
+// RedirectChain asserts that a GET request for rawURL, sent with client, is
+// redirected through exactly the URLs of chain, in order, the last being
+// where it ends up. URLs may be relative to rawURL. An empty chain asserts that
+// rawURL does not redirect. Redirects are followed as for RedirectsTo. Returns
+// *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.RedirectChain(nil, srv.URL+"/old", []string{"/interim", "/new"},
+//		assertions.RedirectStatuses(http.StatusMovedPermanently, http.StatusFound, http.StatusOK))
+func (a *Assert) RedirectChain(client *http.Client, rawURL string, chain []string, opts ...RedirectOption) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	hops, ok := a.followRedirects(client, rawURL, opts)
+	if !ok {
+		return a
+	}
+	for i := 0; i < max(len(chain), len(hops)-1); i++ {
+		got, want := "(none)", "(none)"
+		if i+1 < len(hops) {
+			got = hops[i+1].url
+		}
+		if i < len(chain) {
+			resolved, err := resolveURL(rawURL, chain[i])
+			if err != nil {
+				a.reportMessagef("invalid chain URL %q: %v", chain[i], err)
+				return a
+			}
+			want = resolved
+		}
+		if got != want {
+			a.reportMessagef("redirect chain differs at hop %d\n  got: %s\n  want: %s\n  chain:\n%s",
+				i+1, got, want, describeRedirects(hops))
+			return a
+		}
+	}
+	return a
+}
+
+// followRedirects requests rawURL and each location it redirects to in turn,
+// returning every response as a hop, the first for rawURL itself. Failures to
+// follow the chain, and status codes other than those of RedirectStatuses,
+// are reported.
+func (a *Assert) followRedirects(client *http.Client, rawURL string, opts []RedirectOption) ([]redirectHop, bool) {
+	config := redirectConfig{maxHops: defaultMaxRedirects}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	manual := *client
+	manual.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	var hops []redirectHop
+	for next := rawURL; next != ""; {
+		if len(hops) > config.maxHops {
+			a.reportMessagef("too many redirects\n  limit: %d\n  chain:\n%s", config.maxHops, describeRedirects(hops))
+			return nil, false
+		}
+		resp, err := manual.Get(next)
+		if err != nil {
+			a.reportMessagef("error making request\n  request: GET %s\n  error: %v", next, err)
+			return nil, false
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		hops = append(hops, redirectHop{url: next, status: resp.StatusCode})
+		next = ""
+		if location, err := resp.Location(); err == nil && resp.StatusCode >= 300 && resp.StatusCode < 400 {
+			next = location.String()
+		}
+	}
+
+	if config.statuses != nil {
+		got := make([]int, len(hops))
+		for i, hop := range hops {
+			got[i] = hop.status
+		}
+		if !slices.Equal(got, config.statuses) {
+			a.reportMessagef("redirect status codes differ\n  got: %v\n  want: %v\n  chain:\n%s",
+				got, config.statuses, describeRedirects(hops))
+			return nil, false
+		}
+	}
+	return hops, true
+}
+
+// resolveURL resolves ref against base, so that a wanted location may be
+// given relative to the requested URL.
+func resolveURL(base, ref string) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := u.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	return resolved.String(), nil
+}
+
+// describeRedirects renders a redirect chain, one response per line with
+// its status code.
+func describeRedirects(hops []redirectHop) string {
+	lines := make([]string, len(hops))
+	for i, hop := range hops {
+		lines[i] = fmt.Sprintf("    %d %s", hop.status, hop.url)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,413 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowFirstServer responds after delay to its first slow requests and at once
+// to the rest, counting the requests it receives.
+func slowFirstServer(t *testing.T, slow int64, delay time.Duration) (*httptest.Server, *atomic.Int64) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= slow {
+			time.Sleep(delay)
+		}
+		io.WriteString(w, "ok")
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+// TestResponseTimeWith tests warm-up requests, sampling and percentiles.
+func TestResponseTimeWith(t *testing.T) {
+	const delay, limit = 200 * time.Millisecond, 100 * time.Millisecond
+
+	tests := []struct {
+		name          string
+		slow          int64
+		opts          []ResponseTimeOption
+		shouldPass    bool
+		wantRequests  int64
+		expectMessage string
+	}{
+		{"fast single request", 0, nil, true, 1, ""},
+		{"slow single request", 1, nil, false, 1, "response time exceeded\n  request: GET "},
+		{"warm-up absorbs a slow first request", 1, []ResponseTimeOption{ResponseWarmUp(1)}, true, 2, ""},
+		{"slowest sample is held to the limit", 1, []ResponseTimeOption{ResponseWarmUp(0), ResponseSamples(5)}, false, 5,
+			"response time exceeded at p100 of 5 samples\n  request: GET "},
+		{"percentile tolerates an outlier", 1, []ResponseTimeOption{ResponseSamples(10), ResponsePercentile(90)}, true, 10, ""},
+		{"percentile beyond the outliers fails", 2, []ResponseTimeOption{ResponseSamples(10), ResponsePercentile(90)}, false, 10,
+			"response time exceeded at p90 of 10 samples"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, requests := slowFirstServer(t, tt.slow, delay)
+			req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+
+			mock := &behaviorMockT{}
+			New(mock).ResponseTimeWith(srv.Client(), req, limit, tt.opts...)
+
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("Expected %d requests, server received %d", tt.wantRequests, got)
+			}
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 || !strings.HasPrefix(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected one failure starting %q, got %v", tt.expectMessage, mock.errorCalls)
+			}
+		})
+	}
+}
+
+// TestResponseTimeWithResendsBodies tests that every request carries the
+// original body, and that a body that cannot be resent is reported.
+func TestResponseTimeWithResendsBodies(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"q":1}`))
+	mock := &behaviorMockT{}
+	New(mock).ResponseTimeWith(srv.Client(), req, time.Second, ResponseWarmUp(1), ResponseSamples(2))
+	if len(mock.errorCalls) != 0 || strings.Join(bodies, ",") != `{"q":1},{"q":1},{"q":1}` {
+		t.Errorf("Expected three requests with the body, got %q and failures %v", bodies, mock.errorCalls)
+	}
+
+	req, _ = http.NewRequest(http.MethodPost, srv.URL, io.NopCloser(strings.NewReader("stream")))
+	mock = &behaviorMockT{}
+	New(mock).ResponseTimeWith(srv.Client(), req, time.Second, ResponseSamples(2))
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "no GetBody to resend it") {
+		t.Errorf("Expected a failure for an unrepeatable body, got %v", mock.errorCalls)
+	}
+}
+
+// closeTrackingTransport answers every request with a body that records
+// whether it was read to the end and closed.
+type closeTrackingTransport struct {
+	drained, closed int
+	err             error
+}
+
+type trackedBody struct {
+	io.Reader
+	transport *closeTrackingTransport
+}
+
+func (b *trackedBody) Read(p []byte) (int, error) {
+	n, err := b.Reader.Read(p)
+	if err == io.EOF {
+		b.transport.drained++
+	}
+	return n, err
+}
+
+func (b *trackedBody) Close() error {
+	b.transport.closed++
+	return nil
+}
+
+func (c *closeTrackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: &trackedBody{strings.NewReader("payload"), c}, Request: req}, nil
+}
+
+// TestResponseTimeWithReleasesResponses tests that every response body is
+// drained and closed, and that transport errors are reported.
+func TestResponseTimeWithReleasesResponses(t *testing.T) {
+	transport := &closeTrackingTransport{}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.test/", nil)
+
+	New(&behaviorMockT{}).ResponseTimeWith(&http.Client{Transport: transport}, req, time.Second, ResponseWarmUp(2), ResponseSamples(3))
+	if transport.drained != 5 || transport.closed != 5 {
+		t.Errorf("Expected 5 bodies drained and closed, got %d drained and %d closed", transport.drained, transport.closed)
+	}
+
+	transport.err = errors.New("connection refused")
+	mock := &behaviorMockT{}
+	New(mock).ResponseTimeWith(&http.Client{Transport: transport}, req, time.Second)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "error making request\n  request: GET http://example.test/\n  attempt: 1 of 1") {
+		t.Errorf("Expected a request error, got %v", mock.errorCalls)
+	}
+
+	mock = &behaviorMockT{}
+	New(mock).ResponseTimeWith(nil, nil, time.Second)
+	if len(mock.errorCalls) != 1 || mock.errorCalls[0] != "cannot time a nil request" {
+		t.Errorf("Expected a nil request to be rejected, got %v", mock.errorCalls)
+	}
+}
+
+// cookieResponse returns a response setting the given cookies.
+func cookieResponse(cookies ...*http.Cookie) *http.Response {
+	recorder := httptest.NewRecorder()
+	for _, cookie := range cookies {
+		http.SetCookie(recorder, cookie)
+	}
+	return recorder.Result()
+}
+
+// TestCookieAssertions tests the cookie assertion group.
+func TestCookieAssertions(t *testing.T) {
+	inAYear := time.Now().Add(365 * 24 * time.Hour).UTC().Truncate(time.Second)
+	resp := cookieResponse(
+		&http.Cookie{Name: "session", Value: "s3cret", Path: "/", Secure: true, HttpOnly: true, SameSite: http.SameSiteStrictMode, MaxAge: 3600},
+		&http.Cookie{Name: "theme", Value: "dark", Expires: inAYear},
+		&http.Cookie{Name: "tracking", Value: "1"},
+	)
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"HasCookie present", func(a *Assert) { a.HasCookie(resp, "session") }, true, ""},
+		{"HasCookie lists the cookies present", func(a *Assert) { a.HasCookie(resp, "csrf") }, false,
+			"expected to have cookie \"csrf\"\n  cookies present:\n" +
+				"    session (Path=/, Max-Age=3600, Secure, HttpOnly, SameSite=Strict)\n" +
+				"    theme (Expires=" + inAYear.Format(time.RFC3339) + ")\n" +
+				"    tracking"},
+		{"HasCookie with no cookies", func(a *Assert) { a.HasCookie(cookieResponse(), "session") }, false,
+			"expected to have cookie \"session\"\n  cookies present:\n    (none)"},
+		{"CookieValue", func(a *Assert) { a.CookieValue(resp, "theme", "dark") }, true, ""},
+		{"CookieValue wrong", func(a *Assert) { a.CookieValue(resp, "theme", "light") }, false, "cookie \"theme\" has the wrong value\n"},
+		{"CookieValue missing cookie", func(a *Assert) { a.CookieValue(resp, "csrf", "x") }, false, "expected to have cookie \"csrf\""},
+		{"CookieSecure", func(a *Assert) { a.CookieSecure(resp, "session") }, true, ""},
+		{"CookieSecure missing attribute", func(a *Assert) { a.CookieSecure(resp, "theme") }, false,
+			"expected cookie \"theme\" to be Secure\n  cookie: theme (Expires=" + inAYear.Format(time.RFC3339) + ")"},
+		{"CookieHTTPOnly", func(a *Assert) { a.CookieHTTPOnly(resp, "session") }, true, ""},
+		{"CookieHTTPOnly missing attribute", func(a *Assert) { a.CookieHTTPOnly(resp, "tracking") }, false,
+			"expected cookie \"tracking\" to be HttpOnly\n  cookie: tracking"},
+		{"CookieSameSite", func(a *Assert) { a.CookieSameSite(resp, "session", http.SameSiteStrictMode) }, true, ""},
+		{"CookieSameSite wrong mode", func(a *Assert) { a.CookieSameSite(resp, "tracking", http.SameSiteLaxMode) }, false,
+			"cookie \"tracking\" has the wrong SameSite attribute\n  got: (not set)\n  want: Lax"},
+		{"CookieExpiresAfter by Max-Age", func(a *Assert) { a.CookieExpiresAfter(resp, "session", time.Now().Add(30*time.Minute)) }, true, ""},
+		{"CookieExpiresAfter by Expires", func(a *Assert) { a.CookieExpiresAfter(resp, "theme", time.Now().Add(30*24*time.Hour)) }, true, ""},
+		{"CookieExpiresAfter too soon", func(a *Assert) { a.CookieExpiresAfter(resp, "session", time.Now().Add(2*time.Hour)) }, false,
+			"expected cookie \"session\" to expire after "},
+		{"CookieExpiresAfter session cookie", func(a *Assert) { a.CookieExpiresAfter(resp, "tracking", time.Now()) }, false,
+			"but it is a session cookie"},
+		{"nil response", func(a *Assert) { a.CookieValue(nil, "theme", "dark") }, false, "cannot check cookie \"theme\" of a nil response"},
+		{"fail-fast", func(a *Assert) { a.CookieSecure(resp, "theme").CookieHTTPOnly(resp, "theme") }, false, "expected cookie \"theme\" to be Secure"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+			if strings.Contains(mock.errorCalls[0], "s3cret") {
+				t.Errorf("Expected cookie values to stay out of the message, got:\n%s", mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// TestHeaderAssertions tests the header assertion group.
+func TestHeaderAssertions(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	resp.Header.Add("Vary", "Accept")
+	resp.Header.Add("Vary", "Origin")
+	resp.Header.Set("Cache-Control", "public, max-age=300")
+	handBuilt := &http.Response{Header: http.Header{"x-request-id": {"abc-123"}}}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"HasHeader", func(a *Assert) { a.HasHeader(resp, "Vary") }, true, ""},
+		{"HasHeader non-canonical name", func(a *Assert) { a.HasHeader(resp, "content-type") }, true, ""},
+		{"HasHeader non-canonical key", func(a *Assert) { a.HasHeader(handBuilt, "X-Request-Id") }, true, ""},
+		{"HasHeader lists the headers present", func(a *Assert) { a.HasHeader(resp, "etag") }, false,
+			"expected to have header Etag\n  headers present: Cache-Control, Content-Type, Vary"},
+		{"HasHeader with no headers", func(a *Assert) { a.HasHeader(&http.Response{}, "Etag") }, false,
+			"headers present: (none)"},
+		{"HeaderEqual non-canonical name", func(a *Assert) { a.HeaderEqual(resp, "vary", "Accept") }, true, ""},
+		{"HeaderEqual wrong value", func(a *Assert) { a.HeaderEqual(resp, "vary", "Origin") }, false,
+			"expected different header value for Vary"},
+		{"HeaderContains later value", func(a *Assert) { a.HeaderContains(resp, "VARY", "Origin") }, true, ""},
+		{"HeaderContains missing value", func(a *Assert) { a.HeaderContains(resp, "Vary", "Cookie") }, false,
+			"expected header Vary to contain value\n  got: [\"Accept\" \"Origin\"]\n  want: \"Cookie\""},
+		{"HeaderMatches", func(a *Assert) { a.HeaderMatches(resp, "cache-control", `max-age=\d+`) }, true, ""},
+		{"HeaderMatches no match", func(a *Assert) { a.HeaderMatches(resp, "Cache-Control", `no-store`) }, false,
+			"expected header Cache-Control to match regular expression\n  pattern: no-store"},
+		{"HeaderMatches invalid pattern", func(a *Assert) { a.HeaderMatches(resp, "Cache-Control", `max-age=(`) }, false,
+			"invalid regular expression pattern"},
+		{"HeaderMatches missing header", func(a *Assert) { a.HeaderMatches(resp, "Etag", `.`) }, false,
+			"expected to have header Etag"},
+		{"ContentTypeIs ignores charset", func(a *Assert) { a.ContentTypeIs(resp, "application/json") }, true, ""},
+		{"ContentTypeIs ignores case", func(a *Assert) { a.ContentTypeIs(resp, "Application/JSON; charset=utf-8") }, true, ""},
+		{"ContentTypeIs wrong charset", func(a *Assert) { a.ContentTypeIs(resp, "application/json; charset=iso-8859-1") }, false,
+			"expected content type application/json; charset=iso-8859-1\n  got:  application/json; charset=UTF-8"},
+		{"ContentTypeIs wrong type", func(a *Assert) { a.ContentTypeIs(resp, "text/html") }, false,
+			"expected content type text/html"},
+		{"ContentTypeIs invalid want", func(a *Assert) { a.ContentTypeIs(resp, "json;") }, false, "invalid content type"},
+		{"ContentTypeIs missing header", func(a *Assert) { a.ContentTypeIs(handBuilt, "text/plain") }, false,
+			"expected to have header Content-Type\n  headers present: X-Request-Id"},
+		{"nil response", func(a *Assert) { a.HasHeader(nil, "vary") }, false, "cannot check header Vary of a nil response"},
+		{"fail-fast", func(a *Assert) { a.HasHeader(resp, "Etag").HeaderEqual(resp, "Etag", "x") }, false, "expected to have header Etag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// ExampleAssert_ContentTypeIs checks a media type whatever the charset.
+func ExampleAssert_ContentTypeIs() {
+	resp := &http.Response{Header: http.Header{"Content-Type": {"text/html; charset=utf-8"}}}
+
+	mock := &behaviorMockT{}
+	New(mock).ContentTypeIs(resp, "text/html").ContentTypeIs(resp, "application/json")
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// expected content type application/json
+	//   got:  text/html; charset=utf-8
+	//   want: application/json
+}
+
+// redirectServer serves a chain /old -> /interim -> /new, a relative
+// redirect, and a redirect loop.
+func redirectServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle("/old", http.RedirectHandler("/interim", http.StatusMovedPermanently))
+	mux.Handle("/interim", http.RedirectHandler("/new", http.StatusFound))
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) { _, _ = io.WriteString(w, "new") })
+	mux.HandleFunc("/docs/start", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "intro")
+		w.WriteHeader(http.StatusSeeOther)
+	})
+	mux.HandleFunc("/docs/intro", func(w http.ResponseWriter, r *http.Request) {})
+	mux.Handle("/loop", http.RedirectHandler("/loop", http.StatusFound))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestRedirectAssertions tests RedirectsTo and RedirectChain.
+func TestRedirectAssertions(t *testing.T) {
+	srv := redirectServer(t)
+	chain := "    301 " + srv.URL + "/old\n    302 " + srv.URL + "/interim\n    200 " + srv.URL + "/new"
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"RedirectsTo relative URL", func(a *Assert) { a.RedirectsTo(srv.Client(), srv.URL+"/old", "/new") }, true, ""},
+		{"RedirectsTo absolute URL", func(a *Assert) { a.RedirectsTo(nil, srv.URL+"/old", srv.URL+"/new") }, true, ""},
+		{"RedirectsTo relative Location", func(a *Assert) { a.RedirectsTo(nil, srv.URL+"/docs/start", "/docs/intro") }, true, ""},
+		{"RedirectsTo without redirects", func(a *Assert) { a.RedirectsTo(nil, srv.URL+"/new", "/new") }, true, ""},
+		{"RedirectsTo elsewhere", func(a *Assert) { a.RedirectsTo(nil, srv.URL+"/old", "/login") }, false,
+			"expected redirect to " + srv.URL + "/login\n  got: " + srv.URL + "/new\n  chain:\n" + chain},
+		{"RedirectChain", func(a *Assert) { a.RedirectChain(nil, srv.URL+"/old", []string{"/interim", "/new"}) }, true, ""},
+		{"RedirectChain empty", func(a *Assert) { a.RedirectChain(nil, srv.URL+"/new", nil) }, true, ""},
+		{"RedirectChain wrong hop", func(a *Assert) { a.RedirectChain(nil, srv.URL+"/old", []string{"/new"}) }, false,
+			"redirect chain differs at hop 1\n  got: " + srv.URL + "/interim\n  want: " + srv.URL + "/new"},
+		{"RedirectChain too short", func(a *Assert) { a.RedirectChain(nil, srv.URL+"/old", []string{"/interim", "/new", "/newer"}) }, false,
+			"redirect chain differs at hop 3\n  got: (none)\n  want: " + srv.URL + "/newer"},
+		{"RedirectChain too long", func(a *Assert) { a.RedirectChain(nil, srv.URL+"/old", []string{"/interim"}) }, false,
+			"redirect chain differs at hop 2\n  got: " + srv.URL + "/new\n  want: (none)"},
+		{"RedirectStatuses", func(a *Assert) {
+			a.RedirectChain(nil, srv.URL+"/old", []string{"/interim", "/new"},
+				RedirectStatuses(http.StatusMovedPermanently, http.StatusFound, http.StatusOK))
+		}, true, ""},
+		{"RedirectStatuses differ", func(a *Assert) {
+			a.RedirectsTo(nil, srv.URL+"/old", "/new", RedirectStatuses(http.StatusFound, http.StatusFound, http.StatusOK))
+		}, false, "redirect status codes differ\n  got: [301 302 200]\n  want: [302 302 200]\n  chain:\n" + chain},
+		{"RedirectMaxHops", func(a *Assert) { a.RedirectsTo(nil, srv.URL+"/old", "/new", RedirectMaxHops(1)) }, false,
+			"too many redirects\n  limit: 1\n  chain:\n    301 " + srv.URL + "/old\n    302 " + srv.URL + "/interim"},
+		{"redirect loop", func(a *Assert) { a.RedirectsTo(nil, srv.URL+"/loop", "/new") }, false, "too many redirects\n  limit: 10"},
+		{"request error", func(a *Assert) { a.RedirectsTo(nil, "http://127.0.0.1:0/old", "/new") }, false,
+			"error making request\n  request: GET http://127.0.0.1:0/old"},
+		{"fail-fast", func(a *Assert) {
+			a.RedirectsTo(nil, srv.URL+"/old", "/login").RedirectChain(nil, srv.URL+"/old", nil)
+		}, false, "expected redirect to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// TestRedirectsToLeavesClientPolicy tests that following redirects one at a
+// time does not change the client it is given.
+func TestRedirectsToLeavesClientPolicy(t *testing.T) {
+	srv := redirectServer(t)
+	client := srv.Client()
+
+	mock := &behaviorMockT{}
+	New(mock).RedirectsTo(client, srv.URL+"/old", "/new")
+	if len(mock.errorCalls) != 0 {
+		t.Fatalf("RedirectsTo should pass, got %v", mock.errorCalls)
+	}
+	if client.CheckRedirect != nil {
+		t.Error("Expected the client's CheckRedirect policy to be left unset")
+	}
+}
@@ -0,0 +1,154 @@
+package assertions
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NumberFormat controls how numbers are rendered in numeric failure messages,
+// such as those of Greater, Between and WithinTolerance. Digits are grouped
+// only in numbers of 100,000 or more, where they are hard to count, so
+// smaller numbers read as Go renders them. The zero value renders every
+// number as Go does, without grouping.
+type NumberFormat struct {
+	// ThousandsSeparator is inserted between groups of three integer digits.
+	ThousandsSeparator string
+	// DecimalSeparator separates the integer and fractional parts of a
+	// float. When empty, "." is used.
+	DecimalSeparator string
+}
+
+// Number formats for common locales. See NumberFormatForLocale.
+var (
+	// NumberFormatEnglish renders 1234567.5 as 1,234,567.5.
+	NumberFormatEnglish = NumberFormat{ThousandsSeparator: ",", DecimalSeparator: "."}
+	// NumberFormatEuropean renders 1234567.5 as 1.234.567,5, as in German,
+	// Spanish and Italian.
+	NumberFormatEuropean = NumberFormat{ThousandsSeparator: ".", DecimalSeparator: ","}
+	// NumberFormatSI renders 1234567.5 as 1 234 567,5 with narrow no-break
+	// spaces, as in French, Swedish and Polish.
+	NumberFormatSI = NumberFormat{ThousandsSeparator: "\u202f", DecimalSeparator: ","}
+)
+
+// localeNumberFormats maps language codes to number formats.
+var localeNumberFormats = map[string]NumberFormat{
+	"de": NumberFormatEuropean, "es": NumberFormatEuropean, "it": NumberFormatEuropean,
+	"nl": NumberFormatEuropean, "pt": NumberFormatEuropean, "da": NumberFormatEuropean,
+	"id": NumberFormatEuropean, "tr": NumberFormatEuropean, "el": NumberFormatEuropean,
+	"fr": NumberFormatSI, "sv": NumberFormatSI, "nb": NumberFormatSI, "fi": NumberFormatSI,
+	"pl": NumberFormatSI, "cs": NumberFormatSI, "sk": NumberFormatSI, "hu": NumberFormatSI,
+	"ru": NumberFormatSI, "uk": NumberFormatSI,
+}
+
+// NumberFormatForLocale returns the number format for a locale name such as
+// "de_DE.UTF-8" or "fr-CA", as found in LANG or LC_NUMERIC. Only the
+// language is considered; unknown and empty locales use NumberFormatEnglish.
+//
+// Example:
+//
+//	opts := assertions.DefaultFormatOptions()
+//	opts.Numbers = assertions.NumberFormatForLocale(os.Getenv("LANG"))
+//	assert := assertions.New(t).With(assertions.UseFormatOptions(opts))
+func NumberFormatForLocale(locale string) NumberFormat {
+	language, _, _ := strings.Cut(locale, ".")
+	if i := strings.IndexAny(language, "_-"); i >= 0 {
+		language = language[:i]
+	}
+	if format, ok := localeNumberFormats[strings.ToLower(language)]; ok {
+		return format
+	}
+	return NumberFormatEnglish
+}
+
+// formatNumber renders rv, which must be a signed integer or float, using nf.
+func formatNumber(rv reflect.Value, nf NumberFormat) string {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return groupDigits(strconv.FormatInt(rv.Int(), 10), nf)
+	}
+
+	f := rv.Float()
+	bits := 64
+	if rv.Kind() == reflect.Float32 {
+		bits = 32
+	}
+	if math.IsInf(f, 0) || math.IsNaN(f) || (f != 0 && (math.Abs(f) < 1e-4 || math.Abs(f) >= 1e21)) {
+		return strings.Replace(strconv.FormatFloat(f, 'g', -1, bits), ".", decimalSeparator(nf), 1)
+	}
+
+	s := strconv.FormatFloat(f, 'f', -1, bits)
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+	s = groupDigits(integer, nf)
+	if hasFraction {
+		s += decimalSeparator(nf) + fraction
+	}
+	return s
+}
+
+// minGroupedDigits is the fewest integer digits a number has for its digits
+// to be grouped: 100,000 is grouped but 2024 is not.
+const minGroupedDigits = 6
+
+// groupDigits inserts the thousands separator into a formatted integer of at
+// least minGroupedDigits digits.
+func groupDigits(digits string, nf NumberFormat) string {
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	if nf.ThousandsSeparator == "" || len(digits) < minGroupedDigits {
+		return sign + digits
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	head := len(digits) % 3
+	if head > 0 {
+		b.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if i > 0 {
+			b.WriteString(nf.ThousandsSeparator)
+		}
+		b.WriteString(digits[i : i+3])
+	}
+	return b.String()
+}
+
+// decimalSeparator returns the decimal separator of nf.
+func decimalSeparator(nf NumberFormat) string {
+	if nf.DecimalSeparator == "" {
+		return "."
+	}
+	return nf.DecimalSeparator
+}
+
+// isNumberKind reports whether k is a signed integer or float kind. Unsigned
+// integers keep the hexadecimal rendering of %#v, which suits bytes, flags
+// and masks better than grouped decimal.
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// durationType is rendered by humaniseDuration rather than as an integer.
+var durationType = reflect.TypeFor[time.Duration]()
+
+// humaniseDuration renders a measured duration to four significant digits,
+// so that an elapsed time of 1.002345678s reads as 1.002s and one of 92s as
+// 1m32s.
+func humaniseDuration(d time.Duration) string {
+	unit := time.Duration(1)
+	for magnitude := d.Abs(); magnitude >= 10000; magnitude /= 10 {
+		unit *= 10
+	}
+	return d.Round(unit).String()
+}
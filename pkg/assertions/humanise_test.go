@@ -0,0 +1,121 @@
+package assertions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestNumberFormatting tests digit grouping in numeric failure messages.
+func TestNumberFormatting(t *testing.T) {
+	european := DefaultFormatOptions()
+	european.Numbers = NumberFormatEuropean
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		expectMessage string
+	}{
+		{"grouped integers", func(a *Assert) { Greater(a, 1500000, 2000000) }, "got:   1,500,000\n  bound: 2,000,000"},
+		{"grouped negative integers", func(a *Assert) { Positive(a, int64(-123456)) }, "got: -123,456"},
+		{"small integers unchanged", func(a *Assert) { a.Equal(42, 43) }, "got:  42\n  want: 43"},
+		{"integers below threshold ungrouped", func(a *Assert) { a.Equal(2024, 99999) }, "got:  2024\n  want: 99999"},
+		{"threshold grouped", func(a *Assert) { a.Equal(100000, 0) }, "got:  100,000"},
+		{"grouped floats", func(a *Assert) { a.Equal(1234567.5, 1234568.25) }, "got:  1,234,567.5\n  want: 1,234,568.25"},
+		{"tiny floats keep exponent", func(a *Assert) { a.Equal(1e-9, 2e-9) }, "got:  1e-09\n  want: 2e-09"},
+		{"tolerance grouped", func(a *Assert) { a.WithinTolerance(0, 5000000, 1000000) }, "within tolerance 1,000,000"},
+		{"european format", func(a *Assert) { a.With(UseFormatOptions(european)).Equal(1234567.5, 0.0) }, "got:  1.234.567,5\n  want: 0"},
+		{"grouping disabled", func(a *Assert) { a.With(UseFormatOptions(FormatOptions{})).Equal(1234567, 0) }, "got:  1234567"},
+		{"typed values annotated", func(a *Assert) { a.Equal(int64(250000), 250000) }, "got:  int64(250,000)\n  want: int(250,000)"},
+		{"unsigned stays hexadecimal", func(a *Assert) { a.Equal(uint16(4096), uint16(0)) }, "got:  0x1000"},
+		{"durations use String", func(a *Assert) { Less(a, 92*time.Second, time.Minute) }, "got:   1m32s"},
+		{"durations humanised", func(a *Assert) { a.Equal(1500*time.Millisecond, 2*time.Second) }, "got:  1.5s\n  want: 2s"},
+		{"rounded durations shown exactly", func(a *Assert) { a.Equal(1000100*time.Microsecond, 1000200*time.Microsecond) }, "got:  1.0001s\n  want: 1.0002s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// TestNumberFormatForLocale tests locale name parsing.
+func TestNumberFormatForLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   NumberFormat
+	}{
+		{"en_GB.UTF-8", NumberFormatEnglish},
+		{"de_DE.UTF-8", NumberFormatEuropean},
+		{"fr-CA", NumberFormatSI},
+		{"PT_br", NumberFormatEuropean},
+		{"sv", NumberFormatSI},
+		{"C", NumberFormatEnglish},
+		{"", NumberFormatEnglish},
+	}
+
+	for _, tt := range tests {
+		if got := NumberFormatForLocale(tt.locale); got != tt.want {
+			t.Errorf("NumberFormatForLocale(%q) = %+v, want %+v", tt.locale, got, tt.want)
+		}
+	}
+}
+
+// TestTimingFailuresHumaniseElapsed tests that measured durations are rounded for reading.
+func TestTimingFailuresHumaniseElapsed(t *testing.T) {
+	// At most four significant digits, as in 20.13ms rather than 20.134567ms
+	elapsed := regexp.MustCompile(`elapsed: (\d+(\.\d+)?)(ns|µs|ms|s)\n?`)
+
+	tests := []struct {
+		name   string
+		assert func(a *Assert)
+	}{
+		{"Eventually", func(a *Assert) { a.Eventually(func() bool { return false }, 20*time.Millisecond, 5*time.Millisecond) }},
+		{"WithinTimeout", func(a *Assert) { a.WithinTimeout(func() { time.Sleep(100 * time.Millisecond) }, 20*time.Millisecond) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+			}
+			match := elapsed.FindStringSubmatch(mock.errorCalls[0])
+			if match == nil {
+				t.Fatalf("Expected an elapsed line, got: %s", mock.errorCalls[0])
+			}
+			if digits := strings.ReplaceAll(match[1], ".", ""); len(strings.TrimLeft(digits, "0")) > 4 {
+				t.Errorf("Expected at most four significant digits, got %s", match[0])
+			}
+		})
+	}
+}
+
+// ExampleNumberFormatForLocale demonstrates locale-aware number formatting.
+func ExampleNumberFormatForLocale() {
+	opts := DefaultFormatOptions()
+	opts.Numbers = NumberFormatForLocale("de_DE.UTF-8")
+
+	t := &silentT{}
+	assert := New(t).With(UseFormatOptions(opts))
+	assert.Equal(1234567.5, 1234567.0)
+
+	fmt.Println(assert.Error())
+	// Output:
+	// values differ
+	//   got:  1.234.567,5
+	//   want: 1.234.567
+}
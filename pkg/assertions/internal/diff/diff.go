@@ -0,0 +1,85 @@
+// Package diff is the former home of the GoWise difference engine, kept so
+// that code under pkg/assertions that still imports it keeps building.
+//
+// Deprecated: import gowise/pkg/diff instead. This package only forwards to
+// it and will be removed in a future release.
+package diff
+
+import publicdiff "gowise/pkg/diff"
+
+// MaxHunks bounds the number of hunks collected for a single comparison.
+//
+// Deprecated: use diff.MaxHunks from gowise/pkg/diff.
+const MaxHunks = publicdiff.MaxHunks
+
+// DiffResult represents the result of comparing two strings.
+//
+// Deprecated: use diff.DiffResult from gowise/pkg/diff.
+type DiffResult = publicdiff.DiffResult
+
+// CollectionDiffResult represents the result of comparing two collections.
+//
+// Deprecated: use diff.CollectionDiffResult from gowise/pkg/diff.
+type CollectionDiffResult = publicdiff.CollectionDiffResult
+
+// Hunk describes one contiguous block of differing lines.
+//
+// Deprecated: use diff.Hunk from gowise/pkg/diff.
+type Hunk = publicdiff.Hunk
+
+// EnhancedDiffResult represents enhanced multi-line diff results.
+//
+// Deprecated: use diff.EnhancedDiffResult from gowise/pkg/diff.
+type EnhancedDiffResult = publicdiff.EnhancedDiffResult
+
+// StringDiff compares two strings and reports where they first differ.
+//
+// Deprecated: use diff.StringDiff from gowise/pkg/diff.
+func StringDiff(got, want string) DiffResult {
+	return publicdiff.StringDiff(got, want)
+}
+
+// StringDiffWithContext compares two strings with a context window of
+// contextSize characters around the first difference.
+//
+// Deprecated: use diff.StringDiffWithContext from gowise/pkg/diff.
+func StringDiffWithContext(got, want string, contextSize int) DiffResult {
+	return publicdiff.StringDiffWithContext(got, want, contextSize)
+}
+
+// MultiLineStringDiff compares multi-line strings and reports the first
+// differing line.
+//
+// Deprecated: use diff.MultiLineStringDiff from gowise/pkg/diff.
+func MultiLineStringDiff(got, want string) DiffResult {
+	return publicdiff.MultiLineStringDiff(got, want)
+}
+
+// UnicodeStringDiff compares strings counting runes rather than bytes.
+//
+// Deprecated: use diff.UnicodeStringDiff from gowise/pkg/diff.
+func UnicodeStringDiff(got, want string) DiffResult {
+	return publicdiff.UnicodeStringDiff(got, want)
+}
+
+// CollectionContainsDiff reports whether container holds item.
+//
+// Deprecated: use diff.CollectionContainsDiff from gowise/pkg/diff.
+func CollectionContainsDiff(container, item interface{}) CollectionDiffResult {
+	return publicdiff.CollectionContainsDiff(container, item)
+}
+
+// CollectionLenDiff compares the length of container against expectedLen.
+//
+// Deprecated: use diff.CollectionLenDiff from gowise/pkg/diff.
+func CollectionLenDiff(container interface{}, expectedLen int) CollectionDiffResult {
+	return publicdiff.CollectionLenDiff(container, expectedLen)
+}
+
+// EnhancedMultiLineStringDiff compares multi-line strings with contextLines
+// lines of context around each hunk.
+//
+// Deprecated: use diff.EnhancedMultiLineStringDiff from gowise/pkg/diff.
+func EnhancedMultiLineStringDiff(got, want string, contextLines int) EnhancedDiffResult {
+	return publicdiff.EnhancedMultiLineStringDiff(got, want, contextLines)
+}
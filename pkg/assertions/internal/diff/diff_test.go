@@ -0,0 +1,23 @@
+package diff
+
+import (
+	publicdiff "gowise/pkg/diff"
+	"testing"
+)
+
+// TestForwardsToPublicPackage tests that the deprecated functions return what
+// gowise/pkg/diff does.
+func TestForwardsToPublicPackage(t *testing.T) {
+	if got, want := StringDiff("hello", "help"), publicdiff.StringDiff("hello", "help"); got.Summary != want.Summary || !got.HasDiff {
+		t.Errorf("Expected StringDiff to forward, got %+v, want %+v", got, want)
+	}
+
+	var result publicdiff.EnhancedDiffResult = EnhancedMultiLineStringDiff("a\nb", "a\nc", 1)
+	if want := publicdiff.EnhancedMultiLineStringDiff("a\nb", "a\nc", 1); result.UnifiedDiff != want.UnifiedDiff {
+		t.Errorf("Expected EnhancedMultiLineStringDiff to forward, got %q, want %q", result.UnifiedDiff, want.UnifiedDiff)
+	}
+
+	if got := CollectionLenDiff([]int{1, 2}, 3); !got.HasDiff {
+		t.Errorf("Expected CollectionLenDiff to report a difference, got %+v", got)
+	}
+}
@@ -0,0 +1,254 @@
+// Command funcgen writes funcs_gen.go in package assertions: a package-level
+// function for each assertion method of Assert, which checks one assertion
+// against a testing context without the caller keeping an Assert. Run it with
+// go generate from pkg/assertions after adding or changing an assertion
+// method; funcgen's test fails while funcs_gen.go is out of date.
+//
+// A method gets a function unless it is deprecated, returns something other
+// than an Assert, or is marked with the directive
+//
+//	//funcgen:skip
+//
+// at the end of its doc comment. Mark methods that configure an Assert or
+// build assertions on it, such as With and Check, and methods whose name a
+// hand-written package-level function already has, such as Implements beside
+// the generic Implements[T]. A method sharing its name with a function
+// without the mark stops funcgen with an error naming it, so no method loses
+// its function unnoticed.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// outputFile is the generated file, in the assertions package directory.
+const outputFile = "funcs_gen.go"
+
+// skipDirective marks a method that gets no function.
+const skipDirective = "//funcgen:skip"
+
+func main() {
+	dir := flag.String("dir", ".", "directory of package assertions")
+	flag.Parse()
+
+	src, err := generate(*dir)
+	if err != nil {
+		log.Fatalf("funcgen: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, outputFile), src, 0o644); err != nil {
+		log.Fatalf("funcgen: %v", err)
+	}
+}
+
+// method is an assertion method to mirror.
+type method struct {
+	name    string
+	params  []*ast.Field
+	fileSet *token.FileSet
+	imports map[string]string // Package name to import path, from the method's file
+}
+
+// generate returns the source of funcs_gen.go for the package in dir.
+func generate(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	declared := map[string]bool{}
+	var methods []method
+	for _, path := range paths {
+		base := filepath.Base(path)
+		if strings.HasSuffix(base, "_test.go") || base == outputFile {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		imports := fileImports(file)
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !fn.Name.IsExported() {
+				continue
+			}
+			if fn.Recv == nil {
+				declared[fn.Name.Name] = true
+				continue
+			}
+			if isAssertion(fn) {
+				methods = append(methods, method{fn.Name.Name, fn.Type.Params.List, fset, imports})
+			}
+		}
+	}
+
+	sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+	var clashes []string
+	for _, m := range methods {
+		if declared[m.name] {
+			clashes = append(clashes, m.name)
+		}
+	}
+	if len(clashes) > 0 {
+		return nil, fmt.Errorf("methods %s have no function, as package-level functions take their names; rename them or mark them %s",
+			strings.Join(clashes, ", "), skipDirective)
+	}
+
+	used := map[string]string{}
+	var body bytes.Buffer
+	for _, m := range methods {
+		if err := m.write(&body, used); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by funcgen; DO NOT EDIT.\n\npackage assertions\n")
+	if len(used) > 0 {
+		names := make([]string, 0, len(used))
+		for name := range used {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool { return used[names[i]] < used[names[j]] })
+		out.WriteString("\nimport (\n")
+		for _, name := range names {
+			path := used[name]
+			if filepath.Base(path) == name {
+				fmt.Fprintf(&out, "\t%q\n", path)
+			} else {
+				fmt.Fprintf(&out, "\t%s %q\n", name, path)
+			}
+		}
+		out.WriteString(")\n")
+	}
+	out.Write(body.Bytes())
+	return format.Source(out.Bytes())
+}
+
+// isAssertion reports whether fn is a method of *Assert to mirror: not
+// marked with skipDirective or deprecated, and returning nothing or only the
+// Assert.
+func isAssertion(fn *ast.FuncDecl) bool {
+	if hasSkipDirective(fn) || (fn.Doc != nil && strings.Contains(fn.Doc.Text(), "Deprecated:")) {
+		return false
+	}
+	star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	if recv, ok := star.X.(*ast.Ident); !ok || recv.Name != "Assert" {
+		return false
+	}
+	results := fn.Type.Results
+	if results == nil || len(results.List) == 0 {
+		return true
+	}
+	if len(results.List) != 1 || len(results.List[0].Names) > 1 {
+		return false
+	}
+	result, ok := results.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := result.X.(*ast.Ident)
+	return ok && ident.Name == "Assert"
+}
+
+// hasSkipDirective reports whether the doc comment of fn carries
+// skipDirective.
+func hasSkipDirective(fn *ast.FuncDecl) bool {
+	if fn.Doc == nil {
+		return false
+	}
+	for _, comment := range fn.Doc.List {
+		if comment.Text == skipDirective {
+			return true
+		}
+	}
+	return false
+}
+
+// write writes the function mirroring m, recording in used the imports its
+// parameter types need.
+func (m method) write(w *bytes.Buffer, used map[string]string) error {
+	testing := "t"
+	var params, args []string
+	for _, field := range m.params {
+		for _, name := range field.Names {
+			if name.Name == testing {
+				testing = "testingT"
+			}
+		}
+	}
+	for _, field := range m.params {
+		var typ bytes.Buffer
+		if err := printer.Fprint(&typ, m.fileSet, field.Type); err != nil {
+			return err
+		}
+		var names []string
+		for _, name := range field.Names {
+			names = append(names, name.Name)
+			arg := name.Name
+			if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+				arg += "..."
+			}
+			args = append(args, arg)
+		}
+		params = append(params, strings.Join(names, ", ")+" "+typ.String())
+
+		var missing error
+		ast.Inspect(field.Type, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if pkg, ok := sel.X.(*ast.Ident); ok {
+					path, found := m.imports[pkg.Name]
+					if !found {
+						missing = fmt.Errorf("%s: no import for %s", m.name, pkg.Name)
+					}
+					used[pkg.Name] = path
+				}
+			}
+			return true
+		})
+		if missing != nil {
+			return missing
+		}
+	}
+
+	fmt.Fprintf(w, "\n// %s is Assert.%s for a single assertion against %s.\n// It reports whether the assertion passed.\n", m.name, m.name, testing)
+	params = append([]string{testing + " TestingT"}, params...)
+	fmt.Fprintf(w, "func %s(%s) bool {\n", m.name, strings.Join(params, ", "))
+	fmt.Fprintf(w, "\t%s.Helper()\n\ta := New(%s)\n\ta.%s(%s)\n\treturn !a.HasFailed()\n}\n", testing, testing, m.name, strings.Join(args, ", "))
+	return nil
+}
+
+// fileImports maps the package names file refers to its imports by to their
+// paths.
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := filepath.Base(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGeneratedFileUpToDate fails while funcs_gen.go differs from what
+// funcgen would write, so the functions cannot drift from the methods.
+func TestGeneratedFileUpToDate(t *testing.T) {
+	dir := filepath.Join("..", "..")
+	want, err := generate(dir)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dir, outputFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date; run go generate in pkg/assertions", outputFile)
+	}
+}
+
+// TestSkipsNonAssertions tests which methods are left without a function.
+func TestSkipsNonAssertions(t *testing.T) {
+	src, err := generate(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	for _, present := range []string{"func Equal(t TestingT, got, want interface{}) bool", "func VerifyAll(t TestingT, verifiers ...Verifier) bool"} {
+		if !bytes.Contains(src, []byte(present)) {
+			t.Errorf("Expected %q in the generated source", present)
+		}
+	}
+	// Configuration, deprecated methods, non-Assert results and names taken
+	// by hand-written functions
+	for _, absent := range []string{"func With(", "func IsEmpty(", "func Error(", "func Implements(", "func Run("} {
+		if bytes.Contains(src, []byte(absent)) {
+			t.Errorf("Expected no %q in the generated source", absent)
+		}
+	}
+}
+
+// TestNameClashFails tests that a method whose name a package-level function
+// takes stops generation, unless it is marked to be skipped.
+func TestNameClashFails(t *testing.T) {
+	dir := t.TempDir()
+	src := `package assertions
+
+type Assert struct{}
+
+// Greater asserts that x is greater than y.
+func (a *Assert) Greater(x, y int) *Assert { return a }
+
+// Less asserts that x is less than y.
+//
+//funcgen:skip
+func (a *Assert) Less(x, y int) *Assert { return a }
+
+func Greater(a *Assert, x, y int) *Assert { return a }
+
+func Less(a *Assert, x, y int) *Assert { return a }
+`
+	if err := os.WriteFile(filepath.Join(dir, "assert.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := generate(dir)
+	if err == nil || !strings.Contains(err.Error(), "methods Greater have no function") || strings.Contains(err.Error(), "Less") {
+		t.Errorf("Expected an error naming Greater alone, got %v", err)
+	}
+}
@@ -0,0 +1,277 @@
+package assertions
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonSchema is a compiled JSON Schema, holding the keywords MatchesJSONSchema
+// supports.
+type jsonSchema struct {
+	never      bool                   // The schema false, which nothing matches
+	types      []string               // "type", one or more of the seven JSON Schema types
+	enum       []interface{}          // "enum", if present
+	hasEnum    bool                   // Whether "enum" is present, as it may be empty
+	pattern    *regexp.Regexp         // "pattern" for strings
+	required   []string               // "required" properties of objects
+	properties map[string]*jsonSchema // "properties" of objects
+	items      *jsonSchema            // "items", the schema of every array element
+}
+
+// jsonSchemaTypes are the names "type" may take.
+var jsonSchemaTypes = []string{"null", "boolean", "object", "array", "number", "integer", "string"}
+
+// MatchesJSONSchema asserts that the JSON document jsonStr is valid against
+// the JSON Schema schemaStr, as when checking API payloads against contract
+// schemas. The keywords type, required, properties, enum, items and pattern
+// are supported, as are the boolean schemas true and false; other keywords,
+// including $ref, are ignored. Patterns use Go's RE2 syntax and, as in JSON
+// Schema, match anywhere in the string unless anchored. Every violated
+// constraint is reported with the JSON pointer of the value that violates it.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.MatchesJSONSchema(body, `{
+//		"type": "object",
+//		"required": ["id", "status"],
+//		"properties": {
+//			"id": {"type": "string", "pattern": "^ord_[0-9a-z]+$"},
+//			"status": {"enum": ["open", "paid", "shipped"]},
+//			"lines": {"type": "array", "items": {"type": "object", "required": ["sku"]}}
+//		}
+//	}`)
+func (a *Assert) MatchesJSONSchema(jsonStr, schemaStr string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	var rawSchema, document interface{}
+	if err := json.Unmarshal([]byte(schemaStr), &rawSchema); err != nil {
+		a.reportMessagef("invalid JSON schema: %v", err)
+		return a
+	}
+	schema, err := compileJSONSchema(rawSchema, "")
+	if err != nil {
+		a.reportMessagef("invalid JSON schema: %v", err)
+		return a
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &document); err != nil {
+		a.reportMessagef("invalid JSON: %v", err)
+		return a
+	}
+
+	var violations []string
+	schema.validate(document, "", &violations)
+	switch len(violations) {
+	case 0:
+	case 1:
+		a.reportMessagef("JSON does not match schema\n  %s", violations[0])
+	default:
+		a.reportMessagef("JSON does not match schema: %d violations\n  %s", len(violations), strings.Join(violations, "\n  "))
+	}
+	return a
+}
+
+// compileJSONSchema compiles the decoded schema raw, found at the JSON
+// pointer path within the whole schema, checking the keywords it supports.
+func compileJSONSchema(raw interface{}, path string) (*jsonSchema, error) {
+	switch raw := raw.(type) {
+	case bool:
+		return &jsonSchema{never: !raw}, nil
+	case map[string]interface{}:
+		s := &jsonSchema{}
+		if err := s.compileKeywords(raw, path); err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("#%s: a schema must be an object or a boolean", path)
+	}
+}
+
+// compileKeywords fills in s from the keywords of the schema object raw.
+func (s *jsonSchema) compileKeywords(raw map[string]interface{}, path string) error {
+	if t, ok := raw["type"]; ok {
+		names, _ := t.([]interface{})
+		if name, ok := t.(string); ok {
+			names = []interface{}{name}
+		}
+		for _, name := range names {
+			if name, ok := name.(string); ok && slices.Contains(jsonSchemaTypes, name) {
+				s.types = append(s.types, name)
+				continue
+			}
+			return fmt.Errorf("#%s/type: unknown type %v", path, name)
+		}
+		if len(s.types) == 0 {
+			return fmt.Errorf("#%s/type: must be a type name or an array of them", path)
+		}
+	}
+
+	if enum, ok := raw["enum"]; ok {
+		values, ok := enum.([]interface{})
+		if !ok {
+			return fmt.Errorf("#%s/enum: must be an array", path)
+		}
+		s.enum, s.hasEnum = values, true
+	}
+
+	if pattern, ok := raw["pattern"]; ok {
+		source, ok := pattern.(string)
+		if !ok {
+			return fmt.Errorf("#%s/pattern: must be a string", path)
+		}
+		re, err := regexp.Compile(source)
+		if err != nil {
+			return fmt.Errorf("#%s/pattern: %v", path, err)
+		}
+		s.pattern = re
+	}
+
+	if required, ok := raw["required"]; ok {
+		names, ok := required.([]interface{})
+		if !ok {
+			return fmt.Errorf("#%s/required: must be an array of property names", path)
+		}
+		for _, name := range names {
+			name, ok := name.(string)
+			if !ok {
+				return fmt.Errorf("#%s/required: must be an array of property names", path)
+			}
+			s.required = append(s.required, name)
+		}
+	}
+
+	if properties, ok := raw["properties"]; ok {
+		schemas, ok := properties.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("#%s/properties: must be an object", path)
+		}
+		s.properties = make(map[string]*jsonSchema, len(schemas))
+		for _, name := range slices.Sorted(maps.Keys(schemas)) {
+			property, err := compileJSONSchema(schemas[name], path+"/properties/"+escapeJSONPointer(name))
+			if err != nil {
+				return err
+			}
+			s.properties[name] = property
+		}
+	}
+
+	if items, ok := raw["items"]; ok {
+		schema, err := compileJSONSchema(items, path+"/items")
+		if err != nil {
+			return err
+		}
+		s.items = schema
+	}
+	return nil
+}
+
+// validate appends to violations each constraint of s that v, found at the
+// JSON pointer path in the document, violates.
+func (s *jsonSchema) validate(v interface{}, path string, violations *[]string) {
+	violate := func(format string, args ...interface{}) {
+		at := path
+		if at == "" {
+			at = "(root)"
+		}
+		*violations = append(*violations, at+": "+fmt.Sprintf(format, args...))
+	}
+
+	if s.never {
+		violate("no value is allowed here")
+		return
+	}
+	if len(s.types) > 0 && !slices.ContainsFunc(s.types, func(t string) bool { return jsonTypeMatches(t, v) }) {
+		violate("got %s, want %s", jsonTypeOf(v), strings.Join(s.types, " or "))
+	}
+	if s.hasEnum && !slices.ContainsFunc(s.enum, func(allowed interface{}) bool { return objectsEqual(v, allowed) }) {
+		allowed := make([]string, len(s.enum))
+		for i, value := range s.enum {
+			allowed[i] = renderJSON(value)
+		}
+		violate("%s is not one of %s", renderJSON(v), strings.Join(allowed, ", "))
+	}
+
+	switch v := v.(type) {
+	case string:
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			violate("%s does not match pattern %s", renderJSON(v), s.pattern)
+		}
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				violate("missing required property %s", strconv.Quote(name))
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(s.properties)) {
+			if value, ok := v[name]; ok {
+				s.properties[name].validate(value, path+"/"+escapeJSONPointer(name), violations)
+			}
+		}
+	case []interface{}:
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, path+"/"+strconv.Itoa(i), violations)
+			}
+		}
+	}
+}
+
+// jsonTypeMatches reports whether the decoded JSON value v is of the JSON
+// Schema type t. Integers are numbers with no fractional part, so 1.0 is one.
+func jsonTypeMatches(t string, v interface{}) bool {
+	if n, ok := v.(float64); ok && t == "integer" {
+		return n == math.Trunc(n) && !math.IsInf(n, 0)
+	}
+	return jsonTypeOf(v) == t
+}
+
+// jsonTypeOf names the JSON Schema type of a decoded JSON value, taking
+// every number to be a number.
+func jsonTypeOf(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case float64:
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+// renderJSON renders a decoded JSON value compactly, as it would appear in
+// the document.
+func renderJSON(v interface{}) string {
+	var b strings.Builder
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Sprint(v)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// escapeJSONPointer escapes a property name for use as a JSON pointer token,
+// as RFC 6901 requires.
+func escapeJSONPointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
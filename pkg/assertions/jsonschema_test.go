@@ -0,0 +1,105 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// orderSchema is a contract schema for an order payload.
+const orderSchema = `{
+	"type": "object",
+	"required": ["id", "status", "lines"],
+	"properties": {
+		"id": {"type": "string", "pattern": "^ord_[0-9a-z]+$"},
+		"status": {"enum": ["open", "paid", "shipped"]},
+		"note": {"type": ["string", "null"]},
+		"lines": {
+			"type": "array",
+			"items": {
+				"type": "object",
+				"required": ["sku", "quantity"],
+				"properties": {
+					"sku": {"type": "string"},
+					"quantity": {"type": "integer"},
+					"a/b": {"type": "boolean"}
+				}
+			}
+		}
+	}
+}`
+
+// TestMatchesJSONSchema tests validation against the supported keywords and
+// the reporting of every violation.
+func TestMatchesJSONSchema(t *testing.T) {
+	tests := []struct {
+		name          string
+		json          string
+		schema        string
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"valid order", `{"id": "ord_7f3", "status": "paid", "note": null, "lines": [{"sku": "A1", "quantity": 2.0}]}`,
+			orderSchema, true, ""},
+		{"single violation", `{"id": "ord_7f3", "status": "lost", "lines": []}`, orderSchema, false,
+			"JSON does not match schema\n  /status: \"lost\" is not one of \"open\", \"paid\", \"shipped\""},
+		{"every violation with its pointer", `{"id": "ORD-1", "note": 5, "lines": [{"sku": "A1", "quantity": 1}, {"quantity": 1.5, "a/b": "yes"}]}`,
+			orderSchema, false,
+			"JSON does not match schema: 6 violations\n" +
+				"  (root): missing required property \"status\"\n" +
+				"  /id: \"ORD-1\" does not match pattern ^ord_[0-9a-z]+$\n" +
+				"  /lines/1: missing required property \"sku\"\n" +
+				"  /lines/1/a~1b: got string, want boolean\n" +
+				"  /lines/1/quantity: got number, want integer\n" +
+				"  /note: got number, want string or null"},
+		{"wrong root type", `[1, 2]`, orderSchema, false, "JSON does not match schema\n  (root): got array, want object"},
+		{"enum of objects", `{"unit": "kg"}`, `{"enum": [{"unit": "kg"}, {"unit": "lb"}]}`, true, ""},
+		{"unanchored pattern", `"order ord_1 shipped"`, `{"pattern": "ord_[0-9]+"}`, true, ""},
+		{"true schema", `{"anything": [1, "two"]}`, `true`, true, ""},
+		{"false schema", `{"extra": 1}`, `{"properties": {"extra": false}}`, false, "JSON does not match schema\n  /extra: no value is allowed here"},
+		{"unsupported keywords are ignored", `{"n": 500}`, `{"properties": {"n": {"type": "integer", "maximum": 10}}}`, true, ""},
+		{"invalid JSON", `{"id":`, orderSchema, false, "invalid JSON: "},
+		{"invalid schema JSON", `{}`, `{"type":`, false, "invalid JSON schema: "},
+		{"unknown type", `{}`, `{"properties": {"n": {"type": "float"}}}`, false,
+			"invalid JSON schema: #/properties/n/type: unknown type float"},
+		{"invalid pattern", `{}`, `{"pattern": "("}`, false, "invalid JSON schema: #/pattern: error parsing regexp"},
+		{"schema not an object", `{}`, `{"items": 3}`, false, "invalid JSON schema: #/items: a schema must be an object or a boolean"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			New(mock).MatchesJSONSchema(tt.json, tt.schema)
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.HasPrefix(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message starting:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// ExampleAssert_MatchesJSONSchema reports each violation of a contract schema.
+func ExampleAssert_MatchesJSONSchema() {
+	schema := `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {"tags": {"type": "array", "items": {"type": "string"}}}
+	}`
+
+	mock := &behaviorMockT{}
+	New(mock).MatchesJSONSchema(`{"tags": ["new", 7]}`, schema)
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// JSON does not match schema: 2 violations
+	//   (root): missing required property "name"
+	//   /tags/1: got number, want string
+}
@@ -0,0 +1,126 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// countingValue counts how often it is rendered in a failure message.
+type countingValue struct {
+	id      int
+	renders *int
+}
+
+func (v countingValue) GoString() string {
+	*v.renders++
+	return fmt.Sprintf("countingValue{%d}", v.id)
+}
+
+func (v countingValue) String() string { return v.GoString() }
+
+// recordingT is a testing context with none of TestingT's methods, so
+// failure messages are consumed only through Error, as the test runner does.
+type recordingT struct{}
+
+// TestFailureMessageBuiltWhenConsumed tests that a failure against a context
+// that does not report it is formatted only when Error is called.
+func TestFailureMessageBuiltWhenConsumed(t *testing.T) {
+	renders := 0
+	assert := New(recordingT{})
+	assert.Equal(countingValue{1, &renders}, countingValue{2, &renders})
+
+	if !assert.HasFailed() {
+		t.Fatal("Expected the assertion to fail")
+	}
+	if renders != 0 {
+		t.Errorf("Expected no formatting before the message is consumed, got %d renders", renders)
+	}
+
+	msg := assert.Error()
+	if !strings.Contains(msg, "values differ") || !strings.Contains(msg, "countingValue{2}") {
+		t.Errorf("Expected the full failure message, got %q", msg)
+	}
+	consumed := renders
+	if assert.Error() != msg || renders != consumed {
+		t.Errorf("Expected the message to be built once, got %d more renders", renders-consumed)
+	}
+}
+
+// TestFailureMessageBuiltForTestingT tests that a TestingT receives the
+// message at once.
+func TestFailureMessageBuiltForTestingT(t *testing.T) {
+	renders := 0
+	mock := &behaviorMockT{}
+	assert := New(mock)
+	assert.Equal(countingValue{1, &renders}, countingValue{2, &renders})
+
+	if len(mock.errorCalls) != 1 || renders == 0 {
+		t.Fatalf("Expected the failure to be formatted and reported, got %v", mock.errorCalls)
+	}
+	if assert.Error() != mock.errorCalls[0] {
+		t.Errorf("Expected Error to return the reported message, got %q", assert.Error())
+	}
+}
+
+// TestFormattedMessagesSkippedAfterFailure tests that a chain that has already
+// failed formats nothing for later failures.
+func TestFormattedMessagesSkippedAfterFailure(t *testing.T) {
+	renders := 0
+	mock := &behaviorMockT{}
+	assert := New(mock).True(false)
+
+	value := countingValue{1, &renders}
+	assert.NotEqual(value, value).Equal(value, countingValue{2, &renders})
+	if renders != 0 || len(mock.errorCalls) != 1 {
+		t.Errorf("Expected later failures to be skipped unformatted, got %d renders and %v", renders, mock.errorCalls)
+	}
+}
+
+// BenchmarkFailurePath measures failing assertions whose message is not
+// consumed, skipped by fail-fast or against a context that does not report
+// failures, beside one whose message is. A skipped failure should not
+// allocate, and an unconsumed one should not build its message.
+func BenchmarkFailurePath(b *testing.B) {
+	var got, want interface{} = "shipped", "pending"
+
+	b.Run("SkippedAfterFailure", func(b *testing.B) {
+		assert := New(&mockT{}).True(false)
+		b.ReportAllocs()
+		for b.Loop() {
+			assert.Equal(got, want)
+		}
+	})
+
+	b.Run("NotConsumed", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			New(recordingT{}).Equal(got, want)
+		}
+	})
+
+	b.Run("Consumed", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			New(&silentT{}).Equal(got, want)
+		}
+	})
+}
+
+// TestUnconsumedFailuresDoNotFormat tests, by allocation count, that a
+// failure nothing consumes does not build its message.
+func TestUnconsumedFailuresDoNotFormat(t *testing.T) {
+	var got, want interface{} = "shipped", "pending"
+
+	unconsumed := testing.AllocsPerRun(100, func() { New(recordingT{}).Equal(got, want) })
+	consumed := testing.AllocsPerRun(100, func() { New(&silentT{}).Equal(got, want) })
+
+	// The Assert, its failure state and the pending message closure escape;
+	// nothing else is allocated.
+	if unconsumed > 3 {
+		t.Errorf("Expected an unconsumed failure to make at most 3 allocations, got %g", unconsumed)
+	}
+	if consumed <= unconsumed {
+		t.Errorf("Expected a consumed failure (%g allocs) to cost more than an unconsumed one (%g)", consumed, unconsumed)
+	}
+}
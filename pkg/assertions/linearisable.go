@@ -0,0 +1,216 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// SequentialModel describes the behaviour expected of a concurrent object as
+// a sequential state machine, for Linearisable.
+type SequentialModel[S, In, Out any] struct {
+	// Init returns the initial state.
+	Init func() S
+	// Step applies input to state and returns the new state with the output
+	// a sequential implementation would produce. It must not modify state in
+	// place, as the checker revisits earlier states.
+	Step func(state S, input In) (S, Out)
+}
+
+// maxLinearisationSteps bounds the search for a sequential order, so that a
+// long history fails with an explanation rather than hanging the test.
+const maxLinearisationSteps = 1_000_000
+
+// Linearisable runs each slice of inputs in workers on its own goroutine,
+// passing every input to apply, and asserts that the resulting history is
+// linearisable: that some sequential order of the operations, consistent with
+// the order in which they were observed to start and finish, produces the
+// same outputs from model. Outputs are compared with reflect.DeepEqual.
+//
+// It is a smoke test rather than a proof: one run explores one interleaving,
+// so repeat it, for example with go test -count or under -race, to find rare
+// races. Keep histories to tens of operations, as checking them is
+// exponential in the worst case.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	model := assertions.SequentialModel[int, int, int]{
+//		Init: func() int { return 0 },
+//		Step: func(total, n int) (int, int) { return total + n, total + n },
+//	}
+//	counter := NewCounter()
+//	assertions.Linearisable(assert, model, counter.Add, []int{1, 2, 3}, []int{10, 20, 30})
+func Linearisable[S, In, Out any](a *Assert, model SequentialModel[S, In, Out], apply func(In) Out, workers ...[]In) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	history := runConcurrently(apply, workers)
+	checker := &linearisationChecker[S, In, Out]{
+		model:   model,
+		history: history,
+		done:    make([]bool, len(history)),
+		visited: make(map[string]bool),
+	}
+	if checker.search(model.Init(), 0) {
+		return a
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "expected concurrent history to be linearisable\n")
+	if checker.steps >= maxLinearisationSteps {
+		fmt.Fprintf(&message, "  gave up after %s search steps; use fewer operations\n", formatNumber(reflect.ValueOf(checker.steps), a.formatOptions.Numbers))
+	} else {
+		fmt.Fprintf(&message, "  no sequential order of the %d operations matches the model\n", len(history))
+	}
+	fmt.Fprintf(&message, "  longest matching order (%d of %d):", len(checker.longest), len(history))
+	for _, i := range checker.longest {
+		fmt.Fprintf(&message, "\n    %s", history[i].describe(a.formatOptions))
+	}
+	fmt.Fprintf(&message, "\n  history:")
+	for i, op := range history {
+		if i == maxListedElements {
+			fmt.Fprintf(&message, "\n    … (%d more)", len(history)-maxListedElements)
+			break
+		}
+		fmt.Fprintf(&message, "\n    %s  [call %d, return %d]", op.describe(a.formatOptions), op.call, op.ret)
+	}
+	a.reportMessageConsistent(message.String())
+	return a
+}
+
+// operation is a completed call in a concurrent history. call and ret are
+// positions on a shared clock: an operation that returned before another
+// was called must be ordered before it.
+type operation[In, Out any] struct {
+	worker    int
+	call, ret int64
+	input     In
+	output    Out
+}
+
+// describe renders the operation, as in "g1: 20 → 30".
+func (op operation[In, Out]) describe(opts FormatOptions) string {
+	return fmt.Sprintf("g%d: %s → %s", op.worker, formatValue(op.input, opts), formatValue(op.output, opts))
+}
+
+// runConcurrently runs each worker's inputs on its own goroutine, all
+// released together, and returns the history sorted by call time.
+func runConcurrently[In, Out any](apply func(In) Out, workers [][]In) []operation[In, Out] {
+	var (
+		clock   atomic.Int64
+		mu      sync.Mutex
+		history []operation[In, Out]
+		wg      sync.WaitGroup
+	)
+	start := make(chan struct{})
+
+	for w, inputs := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for _, input := range inputs {
+				call := clock.Add(1)
+				output := apply(input)
+				ret := clock.Add(1)
+
+				mu.Lock()
+				history = append(history, operation[In, Out]{worker: w, call: call, ret: ret, input: input, output: output})
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	sort.Slice(history, func(i, j int) bool { return history[i].call < history[j].call })
+	return history
+}
+
+// linearisationChecker searches for a sequential order of a history that
+// the model accepts, after Wing and Gong: it repeatedly picks an operation
+// that no remaining operation must precede, applies it to the model, and
+// backtracks when outputs differ. Visited combinations of completed
+// operations and model state are not searched twice.
+type linearisationChecker[S, In, Out any] struct {
+	model   SequentialModel[S, In, Out]
+	history []operation[In, Out]
+	done    []bool
+	order   []int
+	longest []int
+	visited map[string]bool
+	steps   int
+}
+
+// search reports whether the operations not yet done can be ordered from
+// state, given that n operations are done.
+func (c *linearisationChecker[S, In, Out]) search(state S, n int) bool {
+	if n == len(c.history) {
+		return true
+	}
+	if len(c.order) > len(c.longest) {
+		c.longest = append(c.longest[:0], c.order...)
+	}
+
+	key := c.key(state)
+	if c.visited[key] {
+		return false
+	}
+	c.visited[key] = true
+
+	// An operation may come next only if it was called before every
+	// remaining operation returned.
+	earliestReturn := int64(-1)
+	for i, op := range c.history {
+		if !c.done[i] && (earliestReturn < 0 || op.ret < earliestReturn) {
+			earliestReturn = op.ret
+		}
+	}
+
+	for i, op := range c.history {
+		if c.done[i] || op.call > earliestReturn {
+			continue
+		}
+		if c.steps++; c.steps >= maxLinearisationSteps {
+			return false
+		}
+
+		next, output := c.model.Step(state, op.input)
+		if !reflect.DeepEqual(output, op.output) {
+			continue
+		}
+
+		c.done[i] = true
+		c.order = append(c.order, i)
+		if c.search(next, n+1) {
+			return true
+		}
+		c.done[i] = false
+		c.order = c.order[:len(c.order)-1]
+	}
+	return false
+}
+
+// key identifies the set of completed operations and the model state.
+func (c *linearisationChecker[S, In, Out]) key(state S) string {
+	var b strings.Builder
+	for _, done := range c.done {
+		if done {
+			b.WriteByte('1')
+		} else {
+			b.WriteByte('0')
+		}
+	}
+	fmt.Fprintf(&b, "|%#v", state)
+	return b.String()
+}
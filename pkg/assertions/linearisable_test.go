@@ -0,0 +1,116 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// counterModel is the sequential model of a counter whose Add returns the
+// new total.
+var counterModel = SequentialModel[int, int, int]{
+	Init: func() int { return 0 },
+	Step: func(total, n int) (int, int) { return total + n, total + n },
+}
+
+// TestLinearisable tests Linearisable against correct and faulty counters.
+func TestLinearisable(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"Mutex counter", func(a *Assert) {
+			var mu sync.Mutex
+			total := 0
+			add := func(n int) int {
+				mu.Lock()
+				defer mu.Unlock()
+				total += n
+				return total
+			}
+			Linearisable(a, counterModel, add, []int{1, 2, 3, 4}, []int{10, 20, 30, 40}, []int{100, 200})
+		}, true, ""},
+		{"No operations", func(a *Assert) {
+			Linearisable(a, counterModel, func(n int) int { return n })
+		}, true, ""},
+		{"Lost update", func(a *Assert) {
+			// Both goroutines read the total before either writes it back.
+			var mu sync.Mutex
+			var read sync.WaitGroup
+			read.Add(2)
+			total := 0
+			add := func(n int) int {
+				mu.Lock()
+				seen := total
+				mu.Unlock()
+				read.Done()
+				read.Wait()
+
+				mu.Lock()
+				defer mu.Unlock()
+				total = seen + n
+				return total
+			}
+			Linearisable(a, counterModel, add, []int{1}, []int{10})
+		}, false, "expected concurrent history to be linearisable\n  no sequential order of the 2 operations matches the model"},
+		{"Real-time order is respected", func(a *Assert) {
+			// The outputs fit the order 2, 1, but 1 returned before 2 was called.
+			outputs := map[int]int{1: 3, 2: 2}
+			Linearisable(a, counterModel, func(n int) int { return outputs[n] }, []int{1, 2})
+		}, false, "longest matching order (0 of 2):\n  history:\n    g0: 1 → 3  [call 1, return 2]\n    g0: 2 → 2  [call 3, return 4]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			a := New(mock)
+			tt.assert(a)
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) > 0 {
+					t.Errorf("expected assertion to pass, got error: %s", mock.errorCalls[0])
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("expected exactly one error, got %d", len(mock.errorCalls))
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("expected message to contain %q, got:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// TestLinearisableFailFast checks that Linearisable does not run operations
+// once the assertion context has failed.
+func TestLinearisableFailFast(t *testing.T) {
+	a := New(&behaviorMockT{})
+	a.True(false)
+
+	calls := 0
+	Linearisable(a, counterModel, func(n int) int { calls++; return n }, []int{1})
+
+	if calls != 0 {
+		t.Errorf("expected no operations to run after a failure, ran %d", calls)
+	}
+}
+
+func ExampleLinearisable() {
+	var mu sync.Mutex
+	total := 0
+	add := func(n int) int {
+		mu.Lock()
+		defer mu.Unlock()
+		total += n
+		return total
+	}
+
+	mock := &silentT{}
+	Linearisable(New(mock), counterModel, add, []int{1, 2, 3}, []int{10, 20, 30})
+	fmt.Println("linearisable:", !mock.failed)
+	// Output: linearisable: true
+}
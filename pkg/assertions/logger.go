@@ -0,0 +1,112 @@
+package assertions
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"gowise/pkg/logging"
+)
+
+// FailureEvent describes a failed assertion, as passed to the logger of an
+// Assert created with NewWithLogger. Its Error method renders the event as
+// key=value pairs on one line, and its LogValue method as a group of
+// attributes for loggers built on log/slog, so log aggregators can index
+// failures by test and assertion.
+type FailureEvent struct {
+	Test       string        // Name of the test, if the testing context has one
+	Assertion  string        // Assert method that failed, such as "Equal"
+	Got        string        // Rendered actual value; empty if the assertion has none
+	Want       string        // Rendered expected value; empty if the assertion has none
+	Message    string        // The failure message reported to the test
+	Index      int           // Ordinal of the failed assertion in its chain, as in Failure
+	SinceStart time.Duration // Time from the logger being set to the failure, not the assertion's own duration
+}
+
+// Error renders the event as key=value pairs, leaving out empty fields.
+func (e *FailureEvent) Error() string {
+	var b strings.Builder
+	b.WriteString("assertion failed")
+	for _, field := range e.fields() {
+		b.WriteString(" " + field.Key + "=" + logfmtValue(field.Value.String()))
+	}
+	return b.String()
+}
+
+// LogValue returns the event's fields as a slog group, leaving out empty
+// fields.
+func (e *FailureEvent) LogValue() slog.Value {
+	return slog.GroupValue(e.fields()...)
+}
+
+// fields returns the event's non-empty fields in a fixed order.
+func (e *FailureEvent) fields() []slog.Attr {
+	var attrs []slog.Attr
+	add := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, slog.String(key, value))
+		}
+	}
+	add("test", e.Test)
+	add("assertion", e.Assertion)
+	if e.Index > 0 {
+		attrs = append(attrs, slog.Int("index", e.Index))
+	}
+	add("got", e.Got)
+	add("want", e.Want)
+	if e.SinceStart > 0 {
+		attrs = append(attrs, slog.Duration("since_start", e.SinceStart))
+	}
+	add("message", e.Message)
+	return attrs
+}
+
+// logfmtValue quotes s if it is empty or contains spaces, quotes, equals
+// signs or control characters.
+func logfmtValue(s string) string {
+	if s == "" || strings.ContainsFunc(s, func(r rune) bool { return r <= ' ' || r == '"' || r == '=' || r == 0x7f }) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// NewWithLogger creates an Assert that also passes every failure to logger's
+// LogError as a *FailureEvent, carrying the test name, the failed assertion,
+// the got and want values, and the time since the Assert was created. The
+// failure is still reported to t as usual. It lets long-running integration
+// suites send their failures to a central log.
+//
+// Example:
+//
+//	logger := logging.NewLogger(logging.ERROR)
+//	assert := assertions.NewWithLogger(t, logger)
+//	assert.Equal(order.Status, "shipped")
+func NewWithLogger(t interface{}, logger logging.LoggerInterface) *Assert {
+	return New(t).With(UseLogger(logger))
+}
+
+// UseLogger sets the logger that receives a *FailureEvent for every failure,
+// and starts the clock for the event's SinceStart. nil stops logging.
+func UseLogger(logger logging.LoggerInterface) Option {
+	return func(a *Assert) {
+		a.logger = logger
+		a.logStart = time.Now()
+	}
+}
+
+// logFailure passes the failure f to the logger.
+func (a *Assert) logFailure(f *Failure) {
+	event := &FailureEvent{
+		Test:       f.Test,
+		Assertion:  f.Kind,
+		Index:      f.Index,
+		Message:    f.String(),
+		SinceStart: time.Since(a.logStart),
+	}
+	if f.HasValues {
+		event.Got = formatValue(f.Got, a.formatOptions)
+		event.Want = formatValue(f.Want, a.formatOptions)
+	}
+	a.logger.LogError(event)
+}
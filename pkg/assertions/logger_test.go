@@ -0,0 +1,124 @@
+package assertions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"gowise/pkg/logging"
+)
+
+// eventLogger records the errors passed to LogError.
+type eventLogger struct {
+	errs []error
+}
+
+func (l *eventLogger) LogInfo(string)     {}
+func (l *eventLogger) LogError(err error) { l.errs = append(l.errs, err) }
+
+// TestNewWithLoggerLogsFailures tests that a failure is logged as a
+// FailureEvent as well as reported to the test.
+func TestNewWithLoggerLogsFailures(t *testing.T) {
+	logger := &eventLogger{}
+	mock := &artifactMockT{name: "TestOrders/ship"}
+	assert := NewWithLogger(mock, logger)
+
+	assert.Equal(2, 2)
+	if len(logger.errs) != 0 {
+		t.Fatalf("Expected a passing assertion to log nothing, got %v", logger.errs)
+	}
+
+	assert.Equal("pending", "shipped")
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected the failure to be reported to the test, got %d reports", len(mock.errorCalls))
+	}
+	if len(logger.errs) != 1 {
+		t.Fatalf("Expected one logged failure, got %d", len(logger.errs))
+	}
+
+	var event *FailureEvent
+	if !errors.As(logger.errs[0], &event) {
+		t.Fatalf("Expected a *FailureEvent, got %T", logger.errs[0])
+	}
+	if event.Test != "TestOrders/ship" || event.Assertion != "Equal" || event.Index != 2 {
+		t.Errorf("Expected test TestOrders/ship, assertion Equal and index 2, got %q, %q and %d", event.Test, event.Assertion, event.Index)
+	}
+	if event.Got != `"pending"` || event.Want != `"shipped"` {
+		t.Errorf("Expected got \"pending\" and want \"shipped\", got %s and %s", event.Got, event.Want)
+	}
+	if event.Message != mock.errorCalls[0] {
+		t.Errorf("Expected the event message to match the reported failure:\n%s\n%s", event.Message, mock.errorCalls[0])
+	}
+	if event.SinceStart <= 0 {
+		t.Errorf("Expected a positive time since start, got %v", event.SinceStart)
+	}
+}
+
+// TestNewWithLoggerWithoutTestingT tests that a failure is logged when the
+// testing context does not report failures itself, as a test runner's does
+// not.
+func TestNewWithLoggerWithoutTestingT(t *testing.T) {
+	logger := &eventLogger{}
+	assert := NewWithLogger(recordingT{}, logger)
+
+	assert.Equal("pending", "shipped")
+	if len(logger.errs) != 1 {
+		t.Fatalf("Expected one logged failure, got %d", len(logger.errs))
+	}
+	event := logger.errs[0].(*FailureEvent)
+	if event.Assertion != "Equal" || event.Message != assert.Error() {
+		t.Errorf("Expected the Equal failure with its message %q, got %+v", assert.Error(), event)
+	}
+}
+
+// TestNewWithLoggerNamesOuterAssertion tests that the logged assertion is
+// the method the test called, not one it delegates to.
+func TestNewWithLoggerNamesOuterAssertion(t *testing.T) {
+	logger := &eventLogger{}
+	NewWithLogger(&behaviorMockT{}, logger).True(false)
+	NewWithLogger(&behaviorMockT{}, logger).Contains([]int{1, 2}, 3)
+
+	if len(logger.errs) != 2 {
+		t.Fatalf("Expected two logged failures, got %d", len(logger.errs))
+	}
+	for i, want := range []string{"True", "Contains"} {
+		if got := logger.errs[i].(*FailureEvent).Assertion; got != want {
+			t.Errorf("Expected assertion %s, got %s", want, got)
+		}
+	}
+}
+
+// TestFailureEventRendering tests the key=value and slog renderings of an
+// event.
+func TestFailureEventRendering(t *testing.T) {
+	event := &FailureEvent{Test: "TestX", Assertion: "Equal", Got: "1", Want: "2", Message: "not equal"}
+
+	if got, want := event.Error(), `assertion failed test=TestX assertion=Equal got=1 want=2 message="not equal"`; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("test failure", "failure", event)
+	var record struct {
+		Failure map[string]string `json:"failure"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %s: %v", buf.String(), err)
+	}
+	if record.Failure["assertion"] != "Equal" || record.Failure["got"] != "1" {
+		t.Errorf("Expected the event's fields as a group, got %v", record.Failure)
+	}
+}
+
+// TestNewWithLoggerMockLogger tests logging to the package's MockLogger.
+func TestNewWithLoggerMockLogger(t *testing.T) {
+	logger := logging.NewMockLogger()
+	NewWithLogger(&behaviorMockT{}, logger).Len([]int{1}, 2)
+
+	if len(logger.ErrorMessages) != 1 || !strings.HasPrefix(logger.ErrorMessages[0], "assertion failed assertion=Len ") {
+		t.Errorf("Expected a logged Len failure, got %v", logger.ErrorMessages)
+	}
+}
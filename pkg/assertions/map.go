@@ -0,0 +1,254 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gowise/pkg/diff"
+)
+
+// maxListedKeys bounds the keys listed when a map assertion fails.
+const maxListedKeys = 10
+
+// HasKey asserts that map m contains key. The key must be assignable to the
+// map's key type, so that a lookup of int64(1) in a map[int]string fails with
+// a type error rather than a silent miss. On failure the map's keys are
+// listed and, for string keys, the closest one is suggested.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.HasKey(headers, "Content-Type")
+func (a *Assert) HasKey(m, key interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	mv, kv, ok := a.mapAndKey(m, key)
+	if ok && !mv.MapIndex(kv).IsValid() {
+		a.reportMissingKey(mv, kv)
+	}
+	return a
+}
+
+// NotHasKey asserts that map m does not contain key.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.NotHasKey(session, "password")
+func (a *Assert) NotHasKey(m, key interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	mv, kv, ok := a.mapAndKey(m, key)
+	if !ok {
+		return a
+	}
+	if value := mv.MapIndex(kv); value.IsValid() {
+		a.reportMessagef("expected map not to contain key\n  key:   %s\n  value: %s",
+			formatValue(key, a.formatOptions), formatValue(value.Interface(), a.formatOptions))
+	}
+	return a
+}
+
+// HasValue asserts that map m contains value under some key, compared with
+// reflect.DeepEqual. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.HasValue(usersByID, expectedUser)
+func (a *Assert) HasValue(m, value interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	mv, ok := a.mapValue(m)
+	if !ok {
+		return a
+	}
+
+	iter := mv.MapRange()
+	for iter.Next() {
+		if reflect.DeepEqual(iter.Value().Interface(), value) {
+			return a
+		}
+	}
+
+	values := make([]string, 0, min(mv.Len(), maxListedKeys))
+	for _, k := range diff.SortedKeys(mv) {
+		if len(values) == maxListedKeys {
+			values = append(values, fmt.Sprintf("… (%d more)", mv.Len()-maxListedKeys))
+			break
+		}
+		values = append(values, formatValue(mv.MapIndex(k).Interface(), a.formatOptions))
+	}
+	a.reportMessagef("expected map to contain value\n  value:  %s\n  values: [%s]",
+		formatValue(value, a.formatOptions), strings.Join(values, ", "))
+	return a
+}
+
+// HasEntry asserts that map m contains key with a value equal to value,
+// compared with reflect.DeepEqual. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.HasEntry(config, "log_level", "debug")
+func (a *Assert) HasEntry(m, key, value interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	mv, kv, ok := a.mapAndKey(m, key)
+	if !ok {
+		return a
+	}
+
+	got := mv.MapIndex(kv)
+	switch {
+	case !got.IsValid():
+		a.reportMissingKey(mv, kv)
+	case !reflect.DeepEqual(got.Interface(), value):
+		a.reportErrorf(got.Interface(), value, "map entry differs for key %s", formatValue(key, a.formatOptions))
+	}
+	return a
+}
+
+// mapValue returns m as a reflect.Value, reporting a failure if it is not a map.
+func (a *Assert) mapValue(m interface{}) (reflect.Value, bool) {
+	mv := reflect.ValueOf(m)
+	if mv.Kind() != reflect.Map {
+		a.reportMessagef("expected a map, got %T", m)
+		return reflect.Value{}, false
+	}
+	return mv, true
+}
+
+// mapAndKey returns m and key as reflect.Values, reporting a failure if m is
+// not a map or key cannot be used to index it.
+func (a *Assert) mapAndKey(m, key interface{}) (reflect.Value, reflect.Value, bool) {
+	mv, ok := a.mapValue(m)
+	if !ok {
+		return reflect.Value{}, reflect.Value{}, false
+	}
+
+	keyType := mv.Type().Key()
+	kv := reflect.ValueOf(key)
+	if !kv.IsValid() {
+		// A nil key is valid for maps keyed by interfaces, pointers and the like
+		switch keyType.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Chan:
+			return mv, reflect.Zero(keyType), true
+		}
+		a.reportMessagef("key type mismatch\n  key type:     <nil>\n  map key type: %s", keyType)
+		return reflect.Value{}, reflect.Value{}, false
+	}
+	if !kv.Type().AssignableTo(keyType) {
+		a.reportMessagef("key type mismatch\n  key type:     %s\n  map key type: %s", kv.Type(), keyType)
+		return reflect.Value{}, reflect.Value{}, false
+	}
+	if !kv.Type().Comparable() {
+		a.reportMessagef("key type %s is not comparable and cannot be a map key", kv.Type())
+		return reflect.Value{}, reflect.Value{}, false
+	}
+	if kv.Type() != keyType {
+		// Box concrete keys for maps keyed by interfaces
+		boxed := reflect.New(keyType).Elem()
+		boxed.Set(kv)
+		kv = boxed
+	}
+	return mv, kv, true
+}
+
+// reportMissingKey reports a key absent from mv, listing the keys present and
+// suggesting the closest string key.
+func (a *Assert) reportMissingKey(mv, kv reflect.Value) {
+	keys := diff.SortedKeys(mv)
+
+	listed := make([]string, 0, min(len(keys), maxListedKeys+1))
+	for i, k := range keys {
+		if i == maxListedKeys {
+			listed = append(listed, fmt.Sprintf("… (%d more)", len(keys)-maxListedKeys))
+			break
+		}
+		listed = append(listed, formatValue(k.Interface(), a.formatOptions))
+	}
+
+	message := fmt.Sprintf("expected map to contain key\n  key:  %s\n  keys: [%s]",
+		formatValue(kv.Interface(), a.formatOptions), strings.Join(listed, ", "))
+	if suggestion, ok := closestKey(kv, keys); ok {
+		message += fmt.Sprintf("\n  did you mean %q?", suggestion)
+	}
+	a.reportMessageConsistent(message)
+}
+
+// closestKey returns the string key nearest to kv by edit distance, if one is
+// close enough to be a plausible typo: within a third of the key's length, and
+// at most three edits.
+func closestKey(kv reflect.Value, keys []reflect.Value) (string, bool) {
+	if kv.Kind() == reflect.Interface {
+		kv = kv.Elem()
+	}
+	if kv.Kind() != reflect.String {
+		return "", false
+	}
+	want := kv.String()
+
+	best, bestDistance := "", min(max(len(want)/3, 1), 3)+1
+	for _, k := range keys {
+		if k.Kind() == reflect.Interface {
+			k = k.Elem()
+		}
+		if k.Kind() != reflect.String {
+			continue
+		}
+		if d := editDistance(strings.ToLower(want), strings.ToLower(k.String())); d < bestDistance {
+			best, bestDistance = k.String(), d
+		}
+	}
+	return best, best != ""
+}
+
+// editDistance returns the Levenshtein distance between a and b, in runes.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
@@ -99,6 +99,25 @@ func TestMapDiff(t *testing.T) {
 	}
 }
 
+// TestMapDiffDeterministicReporting tests that the same key is reported on every run
+// when several keys differ.
+func TestMapDiffDeterministicReporting(t *testing.T) {
+	got := map[string]int{}
+	want := map[string]int{}
+	for i := 0; i < 20; i++ {
+		want[fmt.Sprintf("key%02d", i)] = i
+	}
+
+	for run := 0; run < 20; run++ {
+		mock := &behaviorMockT{}
+		New(mock).MapDiff(got, want)
+
+		if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], `missing key "key00"`) {
+			t.Fatalf("Expected the first sorted key to be reported, got: %v", mock.errorCalls)
+		}
+	}
+}
+
 // ExampleAssert_MapDiff demonstrates proper usage of map diff assertion
 func ExampleAssert_MapDiff() {
 	assert := New(&silentT{})
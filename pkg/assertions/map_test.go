@@ -0,0 +1,82 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestMapAssertions tests HasKey, NotHasKey, HasValue and HasEntry.
+func TestMapAssertions(t *testing.T) {
+	headers := map[string]string{"Content-Type": "application/json", "Accept": "*/*", "X-Request-Id": "abc"}
+	ports := map[int]string{80: "http", 443: "https"}
+	mixed := map[interface{}]int{"one": 1, 2: 2, nil: 0}
+	manyKeys := make(map[int]bool)
+	for i := 0; i < 15; i++ {
+		manyKeys[i] = true
+	}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"HasKey present", func(a *Assert) { a.HasKey(headers, "Accept") }, true, ""},
+		{"HasKey interface map", func(a *Assert) { a.HasKey(mixed, 2) }, true, ""},
+		{"HasKey nil key in interface map", func(a *Assert) { a.HasKey(mixed, nil) }, true, ""},
+		{"HasKey missing with suggestion", func(a *Assert) { a.HasKey(headers, "Content-Typ") }, false,
+			"expected map to contain key\n  key:  \"Content-Typ\"\n  keys: [\"Accept\", \"Content-Type\", \"X-Request-Id\"]\n  did you mean \"Content-Type\"?"},
+		{"HasKey missing case differs", func(a *Assert) { a.HasKey(headers, "accept") }, false, "did you mean \"Accept\"?"},
+		{"HasKey missing without suggestion", func(a *Assert) { a.HasKey(headers, "Authorization") }, false, "keys: [\"Accept\", \"Content-Type\", \"X-Request-Id\"]"},
+		{"HasKey keys listed in order", func(a *Assert) { a.HasKey(ports, 8080) }, false, "keys: [80, 443]"},
+		{"HasKey long key list truncated", func(a *Assert) { a.HasKey(manyKeys, 99) }, false, "keys: [0, 1, 2, 3, 4, 5, 6, 7, 8, 9, … (5 more)]"},
+		{"HasKey wrong key type", func(a *Assert) { a.HasKey(ports, int64(80)) }, false, "key type mismatch\n  key type:     int64\n  map key type: int"},
+		{"HasKey nil key for string map", func(a *Assert) { a.HasKey(headers, nil) }, false, "key type:     <nil>"},
+		{"HasKey uncomparable key", func(a *Assert) { a.HasKey(mixed, []int{1}) }, false, "key type []int is not comparable"},
+		{"HasKey not a map", func(a *Assert) { a.HasKey([]string{"a"}, 0) }, false, "expected a map, got []string"},
+
+		{"NotHasKey absent", func(a *Assert) { a.NotHasKey(headers, "Authorization") }, true, ""},
+		{"NotHasKey present", func(a *Assert) { a.NotHasKey(ports, 443) }, false, "expected map not to contain key\n  key:   443\n  value: \"https\""},
+
+		{"HasValue present", func(a *Assert) { a.HasValue(ports, "https") }, true, ""},
+		{"HasValue absent", func(a *Assert) { a.HasValue(ports, "ftp") }, false, "expected map to contain value\n  value:  \"ftp\"\n  values: [\"http\", \"https\"]"},
+		{"HasValue deep equality", func(a *Assert) { a.HasValue(map[string][]int{"a": {1, 2}}, []int{1, 2}) }, true, ""},
+
+		{"HasEntry matches", func(a *Assert) { a.HasEntry(headers, "Accept", "*/*") }, true, ""},
+		{"HasEntry value differs", func(a *Assert) { a.HasEntry(headers, "Accept", "text/html") }, false, "map entry differs for key \"Accept\""},
+		{"HasEntry key missing", func(a *Assert) { a.HasEntry(headers, "X-Request-ID", "abc") }, false, "did you mean \"X-Request-Id\"?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected assertion to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// ExampleAssert_HasEntry demonstrates map assertions.
+func ExampleAssert_HasEntry() {
+	t := &silentT{}
+	config := map[string]string{"log_level": "debug", "region": "eu-west-2"}
+
+	New(t).
+		HasKey(config, "region").
+		NotHasKey(config, "password").
+		HasEntry(config, "log_level", "debug")
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
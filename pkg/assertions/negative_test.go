@@ -0,0 +1,97 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// TestNegativeAssertions tests the negated counterparts of positive
+// assertions, and that their failures state the negated expectation.
+func TestNegativeAssertions(t *testing.T) {
+	errNotFound := errors.New("not found")
+	wrapped := fmt.Errorf("loading user 7: %w", errNotFound)
+	user, other := &struct{ Name string }{"Ann"}, &struct{ Name string }{"Ann"}
+	fsys := fstest.MapFS{"config.yaml": {Data: []byte("port: 8080\n")}, "cache/entry": {}}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"NotContains slice", func(a *Assert) { a.NotContains([]string{"viewer", "editor"}, "admin") }, true, ""},
+		{"NotContains string", func(a *Assert) { a.NotContains("user=ann", "password") }, true, ""},
+		{"NotContains map key", func(a *Assert) { a.NotContains(map[string]int{"a": 1}, "b") }, true, ""},
+		{"NotContains fails", func(a *Assert) { a.NotContains([]string{"viewer", "admin"}, "admin") }, false,
+			"expected slice not to contain item\n  item:      \"admin\"\n  container: []string{\"viewer\", \"admin\"}"},
+		{"NotContains string fails", func(a *Assert) { a.NotContains("user=ann password=x", "password") }, false,
+			"expected string not to contain item"},
+		{"NotContains nil container", func(a *Assert) { a.NotContains(nil, "admin") }, false,
+			"cannot check containment in nil container"},
+		{"NotContains unsupported item", func(a *Assert) { a.NotContains("text", 42) }, false,
+			"cannot search for int in string"},
+
+		{"NotZero struct", func(a *Assert) { a.NotZero(struct{ ID int }{7}) }, true, ""},
+		{"NotZero string", func(a *Assert) { a.NotZero("ann") }, true, ""},
+		{"NotZero pointer", func(a *Assert) { a.NotZero(user) }, true, ""},
+		{"NotZero fails for empty struct", func(a *Assert) { a.NotZero(struct{ ID int }{}) }, false,
+			"expected a non-zero value\n  got: struct { ID int }{ID:0} (the zero value of struct { ID int })"},
+		{"NotZero fails for nil", func(a *Assert) { a.NotZero(nil) }, false, "expected a non-zero value"},
+		{"NotZero fails for nil slice", func(a *Assert) { a.NotZero([]int(nil)) }, false, "the zero value of []int"},
+
+		{"NotRegexp", func(a *Assert) { a.NotRegexp(`^\d+$`, "v12") }, true, ""},
+		{"NotRegexp fails", func(a *Assert) { a.NotRegexp(`\d+`, "order-42") }, false,
+			"expected not to match regular expression\n  pattern: \\d+\n  string:  \"order-42\"\n  match:   \"42\""},
+		{"NotRegexp invalid pattern", func(a *Assert) { a.NotRegexp(`[a-`, "a") }, false, "invalid regular expression pattern"},
+
+		{"NotErrorIs", func(a *Assert) { a.NotErrorIs(wrapped, io.EOF) }, true, ""},
+		{"NotErrorIs nil", func(a *Assert) { a.NotErrorIs(nil, errNotFound) }, true, ""},
+		{"NotErrorIs fails", func(a *Assert) { a.NotErrorIs(wrapped, errNotFound) }, false,
+			"expected error not to match target"},
+
+		{"NotSame", func(a *Assert) { a.NotSame(user, other) }, true, ""},
+		{"NotSame fails", func(a *Assert) { a.NotSame(user, user) }, false, "expected different pointer identities"},
+
+		{"NotImplements", func(a *Assert) { a.NotImplements(user, (*io.Closer)(nil)) }, true, ""},
+		{"NotImplements fails", func(a *Assert) { a.NotImplements(wrapped, (*error)(nil)) }, false,
+			"expected not to implement interface\n  type:      *fmt.wrapError\n  interface: error"},
+
+		{"NoFileExists", func(a *Assert) { a.WithFS(fsys).NoFileExists("missing.yaml") }, true, ""},
+		{"NoFileExists fails for file", func(a *Assert) { a.WithFS(fsys).NoFileExists("config.yaml") }, false,
+			"expected path not to exist\n  path:  config.yaml\n  found: file"},
+		{"NoFileExists fails for directory", func(a *Assert) { a.WithFS(fsys).NoFileExists("cache") }, false, "found: directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestNoFileExistsOnDisk tests NoFileExists against the operating system.
+func TestNoFileExistsOnDisk(t *testing.T) {
+	dir := t.TempDir()
+
+	mock := &behaviorMockT{}
+	New(mock).NoFileExists(dir + "/absent").NoFileExists(dir)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "found: directory") {
+		t.Errorf("Expected only the existing directory to fail, got %v", mock.errorCalls)
+	}
+}
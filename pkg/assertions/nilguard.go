@@ -0,0 +1,27 @@
+package assertions
+
+import "reflect"
+
+// rejectNil reports a failure, and returns true, when value is an untyped nil.
+// reflect.TypeOf(nil) is nil, so assertions that inspect a value's type call
+// this first rather than panicking; what names the property being checked, as
+// in "cannot check interface implementation of nil value".
+func (a *Assert) rejectNil(value interface{}, what string) bool {
+	if value != nil {
+		return false
+	}
+	a.reportMessagef("cannot check %s of nil value", what)
+	return true
+}
+
+// interfaceTypeOf returns the interface type that ptr points to, as passed to
+// Implements in the form (*io.Reader)(nil). Anything else is reported as a
+// failure and ok is false.
+func (a *Assert) interfaceTypeOf(ptr interface{}) (typ reflect.Type, ok bool) {
+	t := reflect.TypeOf(ptr)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Interface {
+		a.reportMessagef("interface must be given as a nil pointer to it, such as (*io.Reader)(nil)\n  got: %T", ptr)
+		return nil, false
+	}
+	return t.Elem(), true
+}
@@ -0,0 +1,91 @@
+package assertions
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestNilInputs tests that reflection-based assertions given nil inputs fail
+// with a message explaining why, or pass where nil is a valid answer, and
+// never panic.
+func TestNilInputs(t *testing.T) {
+	var nilSlice []int
+	var nilMap map[string]int
+	var nilPointer *os.File
+	var nilErr error
+	var pathErr *os.PathError
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"IsEmpty untyped nil", func(a *Assert) { a.IsEmpty(nil) }, false, "cannot get length of nil container"},
+		{"IsEmpty nil slice", func(a *Assert) { a.IsEmpty(nilSlice) }, true, ""},
+		{"IsEmpty nil map", func(a *Assert) { a.IsEmpty(nilMap) }, true, ""},
+		{"IsEmpty nil pointer", func(a *Assert) { a.IsEmpty(nilPointer) }, false, "unsupported container type for length check"},
+		{"IsNotEmpty untyped nil", func(a *Assert) { a.IsNotEmpty(nil) }, false, "cannot get length of nil container"},
+		{"IsNotEmpty nil slice", func(a *Assert) { a.IsNotEmpty(nilSlice) }, false, "got length: 0, want not empty"},
+		{"Empty untyped nil", func(a *Assert) { a.Empty(nil) }, false, "cannot get length of nil container"},
+		{"LenGreaterThan untyped nil", func(a *Assert) { a.LenGreaterThan(nil, 0) }, false, "cannot get length of nil container"},
+		{"LenBetween nil map", func(a *Assert) { a.LenBetween(nilMap, 0, 2) }, true, ""},
+
+		{"Len untyped nil", func(a *Assert) { a.Len(nil, 0) }, false, "cannot get length of nil container"},
+		{"Len nil slice", func(a *Assert) { a.Len(nilSlice, 0) }, true, ""},
+		{"Len nil map", func(a *Assert) { a.Len(nilMap, 1) }, false, "got length: 0, want length: 1"},
+
+		{"Implements untyped nil", func(a *Assert) { a.Implements(nil, (*io.Reader)(nil)) }, false,
+			"cannot check interface implementation of nil value"},
+		{"Implements nil pointer", func(a *Assert) { a.Implements(nilPointer, (*io.Reader)(nil)) }, true, ""},
+		{"Implements nil interface", func(a *Assert) { a.Implements(os.Stdin, nil) }, false,
+			"interface must be given as a nil pointer to it, such as (*io.Reader)(nil)\n  got: <nil>"},
+		{"Implements non-interface", func(a *Assert) { a.Implements(os.Stdin, &nilSlice) }, false, "got: *[]int"},
+		{"NotImplements untyped nil", func(a *Assert) { a.NotImplements(nil, (*io.Reader)(nil)) }, false,
+			"cannot check interface implementation of nil value"},
+		{"NotImplements nil interface", func(a *Assert) { a.NotImplements(os.Stdin, nil) }, false,
+			"interface must be given as a nil pointer"},
+
+		{"ErrorType nil actual", func(a *Assert) { a.ErrorType(&os.PathError{}, nilErr) }, false,
+			"expected an error of type *fs.PathError, got nil"},
+		{"ErrorType nil expected", func(a *Assert) { a.ErrorType(nilErr, io.EOF) }, false,
+			"cannot check error type against a nil expected error"},
+		{"ErrorType both nil", func(a *Assert) { a.ErrorType(nil, nil) }, false, "nil expected error"},
+
+		{"ErrorAs nil target", func(a *Assert) { a.ErrorAs(io.EOF, nil) }, false, "ErrorAs target must be a non-nil pointer\n  got: <nil>"},
+		{"ErrorAs nil pointer target", func(a *Assert) { a.ErrorAs(io.EOF, (**os.PathError)(nil)) }, false, "got: **fs.PathError"},
+		{"ErrorAs nil error", func(a *Assert) { a.ErrorAs(nilErr, &pathErr) }, false, "expected error to be assignable to target type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestNilInputsKeepChaining tests that a nil-input failure takes part in
+// fail-fast chaining like any other.
+func TestNilInputsKeepChaining(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock).IsEmpty(nil).Implements(nil, nil).ErrorType(nil, errors.New("boom"))
+
+	if !assert.HasFailed() || len(mock.errorCalls) != 1 {
+		t.Errorf("Expected only the first nil input to be reported, got %v", mock.errorCalls)
+	}
+}
@@ -0,0 +1,94 @@
+package assertions
+
+import (
+	"io/fs"
+	"math"
+	"time"
+)
+
+// Option overrides one setting of an Assert derived with With.
+type Option func(*Assert)
+
+// With returns a new Assert sharing the testing context and failure state of a,
+// with opts applied on top of a's settings. It scopes configuration to a block
+// of assertions without affecting the original:
+//
+//	loose := assert.With(assertions.UseFloatTolerance(1e-6), assertions.UseDiffFormat(assertions.DiffFormatUnified))
+//	loose.Equal(result.Mean, 0.3)
+//	assert.Equal(result.Count, 3) // unaffected
+//
+// NOTE: Shares failure state with original for proper fail-fast chaining.
+//
+//funcgen:skip
+func (a *Assert) With(opts ...Option) *Assert {
+	derived := *a
+	for _, opt := range opts {
+		opt(&derived)
+	}
+	return &derived
+}
+
+// UseDiffFormat sets the preferred format for multi-line string diffs.
+func UseDiffFormat(format DiffFormat) Option {
+	return func(a *Assert) { a.diffFormat = format }
+}
+
+// UseFormatOptions sets the limits applied when rendering failure values.
+func UseFormatOptions(opts FormatOptions) Option {
+	return func(a *Assert) { a.formatOptions = opts }
+}
+
+// UseFS sets the filesystem read by file assertions; nil restores the
+// operating system's.
+func UseFS(fsys fs.FS) Option {
+	return func(a *Assert) { a.fsys = fsys }
+}
+
+// UseFloatTolerance makes Equal and NotEqual treat two float64, or two
+// float32, values as equal when they differ by at most tolerance.
+// Other types are compared exactly; zero restores exact comparison.
+func UseFloatTolerance(tolerance float64) Option {
+	return func(a *Assert) { a.floatTolerance = tolerance }
+}
+
+// UseTimeouts sets the timeout and polling interval applied when EventuallyWith,
+// NeverWith or WithinTimeout are given zero values, in place of the built-in
+// 5 second timeout and 100 millisecond interval.
+func UseTimeouts(timeout, interval time.Duration) Option {
+	return func(a *Assert) {
+		a.timeout = timeout
+		a.interval = interval
+	}
+}
+
+// eventuallyDefaults returns the built-in Eventually configuration with any
+// timeouts set through UseTimeouts applied.
+func (a *Assert) eventuallyDefaults() EventuallyConfig {
+	config := defaultEventuallyConfig()
+	if a.timeout > 0 {
+		config.Timeout = a.timeout
+	}
+	if a.interval > 0 {
+		config.Interval = a.interval
+	}
+	return config
+}
+
+// floatsWithinTolerance reports whether got and want are floats of the same
+// type, and if so whether they are equal under the configured tolerance.
+func (a *Assert) floatsWithinTolerance(got, want interface{}) (equal, ok bool) {
+	if a.floatTolerance <= 0 {
+		return false, false
+	}
+	switch g := got.(type) {
+	case float64:
+		if w, isFloat := want.(float64); isFloat {
+			return math.Abs(g-w) <= a.floatTolerance, true
+		}
+	case float32:
+		if w, isFloat := want.(float32); isFloat {
+			return math.Abs(float64(g)-float64(w)) <= a.floatTolerance, true
+		}
+	}
+	return false, false
+}
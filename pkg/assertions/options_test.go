@@ -0,0 +1,118 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// TestWithFloatTolerance tests Equal and NotEqual under a float tolerance.
+func TestWithFloatTolerance(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"float64 within tolerance", func(a *Assert) { a.Equal(0.1+0.2, 0.3) }, true, ""},
+		{"float64 outside tolerance", func(a *Assert) { a.Equal(0.31, 0.3) }, false, "values differ by more than tolerance 1e-06"},
+		{"float32 within tolerance", func(a *Assert) { a.Equal(float32(1.0000001), float32(1)) }, true, ""},
+		{"mixed float types compared exactly", func(a *Assert) { a.Equal(float32(0.5), 0.5) }, false, "values differ"},
+		{"non-floats compared exactly", func(a *Assert) { a.Equal(1, 2) }, false, "values differ"},
+		{"NotEqual within tolerance", func(a *Assert) { a.NotEqual(0.1+0.2, 0.3) }, false, "values should not be equal (within tolerance 1e-06)"},
+		{"NotEqual outside tolerance", func(a *Assert) { a.NotEqual(0.31, 0.3) }, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock).With(UseFloatTolerance(1e-6)))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected assertion to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestWithLeavesOriginalUnchanged tests that overrides are scoped to the derived Assert.
+func TestWithLeavesOriginalUnchanged(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	assert.With(UseFloatTolerance(0.1)).Equal(1.05, 1.0)
+	if len(mock.errorCalls) != 0 {
+		t.Fatalf("Expected derived Assert to apply tolerance, got: %v", mock.errorCalls)
+	}
+
+	assert.Equal(1.05, 1.0)
+	if len(mock.errorCalls) != 1 {
+		t.Errorf("Expected original Assert to compare exactly, got %d Errorf calls", len(mock.errorCalls))
+	}
+}
+
+// TestWithSharesFailureState tests fail-fast chaining across derived instances.
+func TestWithSharesFailureState(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	assert.With(UseDiffFormat(DiffFormatUnified)).Equal(1, 2)
+	assert.Equal("a", "b")
+	assert.With().True(false)
+
+	if len(mock.errorCalls) != 1 {
+		t.Errorf("Expected only the first failure to be reported, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+	}
+}
+
+// TestWithOptionsCompose tests that later options and derivations build on earlier ones.
+func TestWithOptionsCompose(t *testing.T) {
+	fsys := fstest.MapFS{"a.txt": {Data: []byte(strings.Repeat("x", 100))}}
+	mock := &behaviorMockT{}
+
+	scoped := New(mock).With(UseFS(fsys)).With(UseFormatOptions(FormatOptions{MaxStringLength: 10}))
+	scoped.FileContains("a.txt", "y")
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+	}
+	if !strings.Contains(mock.errorCalls[0], "(90 more bytes)") {
+		t.Errorf("Expected both filesystem and format options to apply, got: %s", mock.errorCalls[0])
+	}
+}
+
+// TestWithTimeouts tests that UseTimeouts replaces the built-in defaults.
+func TestWithTimeouts(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock).With(UseTimeouts(30*time.Millisecond, 5*time.Millisecond))
+
+	start := time.Now()
+	assert.EventuallyWith(func() bool { return false }, EventuallyConfig{})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected scoped timeout of 30ms, waited %v", elapsed)
+	}
+	if len(mock.errorCalls) != 1 {
+		t.Errorf("Expected EventuallyWith to fail after the scoped timeout, got %d Errorf calls", len(mock.errorCalls))
+	}
+}
+
+// ExampleAssert_With demonstrates scoping a float tolerance to a block of assertions.
+func ExampleAssert_With() {
+	t := &silentT{}
+	assert := New(t)
+
+	approx := assert.With(UseFloatTolerance(1e-9))
+	approx.Equal(0.1+0.2, 0.3)
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
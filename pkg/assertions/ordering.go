@@ -0,0 +1,193 @@
+package assertions
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Go methods cannot declare type parameters, so the ordering assertions are
+// package-level functions taking the *Assert to report through. They return
+// it so that method chaining can continue:
+//
+//	assertions.Between(assert, resp.StatusCode, 200, 299).NoError(err)
+
+// Number is the set of integer and floating-point types, including named
+// types such as time.Duration, accepted by Positive and Negative.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Greater asserts that got is strictly greater than bound.
+// Values are compared in their own type, so large int64 values keep full precision.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Greater(assert, elapsed, 10*time.Millisecond)
+func Greater[T cmp.Ordered](a *Assert, got, bound T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !(got > bound) {
+		a.reportOrderingError("greater than", got, bound)
+	}
+	return a
+}
+
+// GreaterOrEqual asserts that got is greater than or equal to bound.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.GreaterOrEqual(assert, len(results), 1)
+func GreaterOrEqual[T cmp.Ordered](a *Assert, got, bound T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !(got >= bound) {
+		a.reportOrderingError("greater than or equal to", got, bound)
+	}
+	return a
+}
+
+// Less asserts that got is strictly less than bound.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Less(assert, "alpha", "beta")
+func Less[T cmp.Ordered](a *Assert, got, bound T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !(got < bound) {
+		a.reportOrderingError("less than", got, bound)
+	}
+	return a
+}
+
+// LessOrEqual asserts that got is less than or equal to bound.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.LessOrEqual(assert, retries, maxRetries)
+func LessOrEqual[T cmp.Ordered](a *Assert, got, bound T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !(got <= bound) {
+		a.reportOrderingError("less than or equal to", got, bound)
+	}
+	return a
+}
+
+// Between asserts that low <= value <= high.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Between(assert, resp.StatusCode, 200, 299)
+func Between[T cmp.Ordered](a *Assert, value, low, high T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !(value >= low && value <= high) {
+		a.reportMessagef("expected value to be between low and high (inclusive)\n  got:  %s\n  low:  %s\n  high: %s",
+			a.formatOrdered(value), a.formatOrdered(low), a.formatOrdered(high))
+	}
+	return a
+}
+
+// Positive asserts that value is strictly greater than zero.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Positive(assert, balance)
+func Positive[T Number](a *Assert, value T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	var zero T
+	if !(value > zero) {
+		a.reportMessagef("expected value to be positive\n  got: %s", a.formatOrdered(value))
+	}
+	return a
+}
+
+// Negative asserts that value is strictly less than zero.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Negative(assert, offset)
+func Negative[T Number](a *Assert, value T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	var zero T
+	if !(value < zero) {
+		a.reportMessagef("expected value to be negative\n  got: %s", a.formatOrdered(value))
+	}
+	return a
+}
+
+// reportOrderingError reports a failed comparison between got and bound.
+func (a *Assert) reportOrderingError(relation string, got, bound interface{}) {
+	a.reportMessagef("expected value to be %s bound\n  got:   %s\n  bound: %s",
+		relation, a.formatOrdered(got), a.formatOrdered(bound))
+}
+
+// formatOrdered renders an ordered value, preferring its String method so that
+// types such as time.Duration read naturally, and grouping the digits of numbers.
+func (a *Assert) formatOrdered(value interface{}) string {
+	if s, ok := value.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return formatNumeric(value, a.formatOptions)
+}
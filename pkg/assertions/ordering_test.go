@@ -0,0 +1,103 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestOrderingAssertions tests the generic ordering assertions across ordered types.
+func TestOrderingAssertions(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"Greater int", func(a *Assert) { Greater(a, 5, 3) }, true, ""},
+		{"Greater equal fails", func(a *Assert) { Greater(a, 3, 3) }, false, "expected value to be greater than bound"},
+		{"Greater large int64", func(a *Assert) { Greater(a, int64(math.MaxInt64), int64(math.MaxInt64-1)) }, true, ""},
+		{"Greater string", func(a *Assert) { Greater(a, "beta", "alpha") }, true, ""},
+		{"Greater duration fails", func(a *Assert) { Greater(a, time.Second, 2*time.Second) }, false, "got:   1s\n  bound: 2s"},
+
+		{"GreaterOrEqual equal", func(a *Assert) { GreaterOrEqual(a, 3, 3) }, true, ""},
+		{"GreaterOrEqual fails", func(a *Assert) { GreaterOrEqual(a, 2.5, 3.0) }, false, "greater than or equal to bound"},
+
+		{"Less uint", func(a *Assert) { Less(a, uint8(1), uint8(2)) }, true, ""},
+		{"Less large int64 fails", func(a *Assert) { Less(a, int64(math.MaxInt64), int64(math.MaxInt64-1)) }, false, "expected value to be less than bound"},
+
+		{"LessOrEqual equal", func(a *Assert) { LessOrEqual(a, "a", "a") }, true, ""},
+		{"LessOrEqual fails", func(a *Assert) { LessOrEqual(a, "b", "a") }, false, `got:   "b"`},
+
+		{"Between inside", func(a *Assert) { Between(a, 250, 200, 299) }, true, ""},
+		{"Between bounds inclusive", func(a *Assert) { Between(a, 200, 200, 299) }, true, ""},
+		{"Between outside", func(a *Assert) { Between(a, 404, 200, 299) }, false, "got:  404\n  low:  200\n  high: 299"},
+
+		{"Positive", func(a *Assert) { Positive(a, 0.1) }, true, ""},
+		{"Positive zero fails", func(a *Assert) { Positive(a, 0) }, false, "expected value to be positive"},
+		{"Positive duration", func(a *Assert) { Positive(a, time.Millisecond) }, true, ""},
+		{"Negative", func(a *Assert) { Negative(a, int32(-1)) }, true, ""},
+		{"Negative fails", func(a *Assert) { Negative(a, -time.Second+time.Second) }, false, "expected value to be negative\n  got: 0s"},
+		{"Negative NaN fails", func(a *Assert) { Negative(a, math.NaN()) }, false, "expected value to be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestOrderingChaining tests that ordering functions share fail-fast state with the Assert.
+func TestOrderingChaining(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	Less(assert, 2, 1).Equal(1, 2)
+	Greater(assert, 1, 2)
+
+	if len(mock.errorCalls) != 1 {
+		t.Errorf("Expected only the first failure to be reported, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+	}
+}
+
+// TestDeprecatedFloatOrdering tests that the float64 methods still behave as before.
+func TestDeprecatedFloatOrdering(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).Greater(2, 1).Less(1, 2)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected no failures, got %v", mock.errorCalls)
+	}
+
+	New(mock).Less(2, 1)
+	if len(mock.errorCalls) != 1 {
+		t.Errorf("Expected Less to fail once, got %d", len(mock.errorCalls))
+	}
+}
+
+// ExampleBetween demonstrates the generic ordering assertions.
+func ExampleBetween() {
+	t := &silentT{}
+	assert := New(t)
+
+	Between(assert, 204, 200, 299)
+	Greater(assert, 1500*time.Millisecond, time.Second)
+	LessOrEqual(assert, "alpha", "beta")
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
@@ -0,0 +1,170 @@
+package assertions
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// RedirectableLogger is a logger whose output can be replaced for a while and
+// restored, as LogsContain and LogsNotContain do. *log.Logger and
+// *logging.Logger satisfy it.
+type RedirectableLogger interface {
+	Writer() io.Writer
+	SetOutput(w io.Writer)
+}
+
+// CapturesOutput runs fn with os.Stdout and os.Stderr redirected, restores
+// them, even if fn panics, and passes what fn wrote to check, which asserts on
+// it. Only writes through os.Stdout and os.Stderr are captured: loggers keep
+// the file they were created with, so check their output with LogsContain.
+// The standard streams belong to the whole process, so CapturesOutput must
+// not be used from parallel tests. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.CapturesOutput(func() { cli.PrintUsage() }, func(stdout, stderr string) {
+//		assert.Contains(stdout, "Usage: mytool").Empty(stderr)
+//	})
+//
+//funcgen:skip
+func (a *Assert) CapturesOutput(fn func(), check func(stdout, stderr string)) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	stdout, stderr, err := captureStandardStreams(fn)
+	if err != nil {
+		a.reportMessagef("failed to capture output\n  error: %v", err)
+		return a
+	}
+	check(stdout, stderr)
+	return a
+}
+
+// captureStandardStreams runs fn with os.Stdout and os.Stderr replaced by
+// pipes and returns what was written to each. The pipes are drained while fn
+// runs, so fn cannot block on a full pipe.
+func captureStandardStreams(fn func()) (stdout, stderr string, err error) {
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		return "", "", err
+	}
+	errR, errW, err := os.Pipe()
+	if err != nil {
+		outR.Close()
+		outW.Close()
+		return "", "", err
+	}
+
+	var outBuf, errBuf bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); io.Copy(&outBuf, outR) }()
+	go func() { defer wg.Done(); io.Copy(&errBuf, errR) }()
+
+	savedOut, savedErr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = outW, errW
+	// Closing the write ends lets the copies finish before the output is read
+	restore := sync.OnceFunc(func() {
+		os.Stdout, os.Stderr = savedOut, savedErr
+		outW.Close()
+		errW.Close()
+		wg.Wait()
+		outR.Close()
+		errR.Close()
+	})
+	defer restore()
+
+	fn()
+	restore()
+	return outBuf.String(), errBuf.String(), nil
+}
+
+// LogsContain runs fn with the output of logger redirected, restores it, even
+// if fn panics, and asserts that fn logged substr. Pass log.Default() for the
+// standard logger. The logger is shared with any other goroutine using it, so
+// LogsContain must not be used while other tests log through it.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	logger := log.New(os.Stderr, "", 0)
+//	client := api.NewClient(unreachableURL, logger)
+//	assert.LogsContain(logger, "connection refused", func() { client.Ping() })
+func (a *Assert) LogsContain(logger RedirectableLogger, substr string, fn func()) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	logged, ok := a.captureLogs(logger, fn)
+	if ok && !strings.Contains(logged, substr) {
+		a.reportMessagef("expected log output to contain substring\n  substring: %s\n  logged:    %s",
+			formatString(substr, a.formatOptions), logExcerpt(logged, a))
+	}
+	return a
+}
+
+// LogsNotContain runs fn with the output of logger redirected, restores it,
+// even if fn panics, and asserts that fn did not log substr, as when checking
+// that secrets stay out of logs. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.LogsNotContain(logger, password, func() { auth.Login(user, password) })
+func (a *Assert) LogsNotContain(logger RedirectableLogger, substr string, fn func()) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	logged, ok := a.captureLogs(logger, fn)
+	if ok && strings.Contains(logged, substr) {
+		a.reportMessagef("expected log output not to contain substring\n  substring: %s\n  logged:    %s",
+			formatString(substr, a.formatOptions), logExcerpt(logged, a))
+	}
+	return a
+}
+
+// captureLogs runs fn with the output of logger redirected to a buffer and
+// returns what was logged. A nil logger is reported and ok is false.
+func (a *Assert) captureLogs(logger RedirectableLogger, fn func()) (logged string, ok bool) {
+	if logger == nil {
+		a.reportMessagef("cannot capture the output of a nil logger")
+		return "", false
+	}
+
+	var buf bytes.Buffer
+	previous := logger.Writer()
+	logger.SetOutput(&buf)
+	defer logger.SetOutput(previous)
+	fn()
+	return buf.String(), true
+}
+
+// logExcerpt renders captured log output for a failure message.
+func logExcerpt(logged string, a *Assert) string {
+	if logged == "" {
+		return "(nothing)"
+	}
+	if len(logged) > maxOutputExcerpt {
+		logged = logged[:maxOutputExcerpt] + "…"
+	}
+	return formatString(logged, a.formatOptions)
+}
@@ -0,0 +1,150 @@
+package assertions
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"gowise/pkg/logging"
+)
+
+// TestCapturesOutput tests that CapturesOutput passes what was written to the
+// standard streams to its check and restores them.
+func TestCapturesOutput(t *testing.T) {
+	savedOut, savedErr := os.Stdout, os.Stderr
+
+	t.Run("captures", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+		var stdout, stderr string
+		assert.CapturesOutput(func() {
+			fmt.Println("Usage: mytool [flags]")
+			fmt.Fprint(os.Stderr, "warning: no config")
+		}, func(out, errOut string) { stdout, stderr = out, errOut })
+
+		if stdout != "Usage: mytool [flags]\n" || stderr != "warning: no config" {
+			t.Errorf("Expected the written output, got stdout %q and stderr %q", stdout, stderr)
+		}
+		if len(mock.errorCalls) != 0 {
+			t.Errorf("Expected no failures, got %v", mock.errorCalls)
+		}
+	})
+
+	t.Run("large output", func(t *testing.T) {
+		line := strings.Repeat("x", 1023) + "\n"
+		var stdout string
+		New(&behaviorMockT{}).CapturesOutput(func() {
+			for i := 0; i < 256; i++ { // Well beyond a pipe's buffer
+				fmt.Print(line)
+			}
+		}, func(out, _ string) { stdout = out })
+
+		if len(stdout) != 256*len(line) {
+			t.Errorf("Expected %d bytes of stdout, got %d", 256*len(line), len(stdout))
+		}
+	})
+
+	t.Run("check asserts", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		New(mock).CapturesOutput(func() { fmt.Print("done") }, func(stdout, stderr string) {
+			New(mock).Contains(stdout, "Usage:")
+		})
+
+		if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "Usage:") {
+			t.Errorf("Expected the check's failure, got %v", mock.errorCalls)
+		}
+	})
+
+	t.Run("restores after panic", func(t *testing.T) {
+		func() {
+			defer func() { recover() }()
+			New(&behaviorMockT{}).CapturesOutput(func() { panic("boom") }, func(string, string) {
+				t.Error("Expected check not to run after fn panics")
+			})
+		}()
+	})
+
+	t.Run("skipped after failure", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		New(mock).True(false).CapturesOutput(func() { t.Error("Expected fn not to run") }, func(string, string) {})
+	})
+
+	if os.Stdout != savedOut || os.Stderr != savedErr {
+		t.Error("Expected os.Stdout and os.Stderr to be restored")
+	}
+}
+
+// TestLogsContain tests LogsContain and LogsNotContain with loggers that can
+// be redirected.
+func TestLogsContain(t *testing.T) {
+	var original strings.Builder
+	stdLogger := log.New(&original, "client: ", 0)
+	levelled := logging.NewLogger(logging.INFO)
+	levelled.SetOutput(io.Discard)
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"contains", func(a *Assert) {
+			a.LogsContain(stdLogger, "connection refused", func() { stdLogger.Print("dial tcp: connection refused") })
+		}, true, ""},
+		{"logging.Logger", func(a *Assert) {
+			a.LogsContain(levelled, "INFO: started", func() { levelled.LogInfo("started") })
+		}, true, ""},
+		{"missing", func(a *Assert) {
+			a.LogsContain(stdLogger, "connection refused", func() { stdLogger.Print("retrying") })
+		}, false, "expected log output to contain substring\n  substring: \"connection refused\"\n  logged:    \"client: retrying\\n\""},
+		{"nothing logged", func(a *Assert) { a.LogsContain(stdLogger, "retrying", func() {}) }, false,
+			"\n  logged:    (nothing)"},
+		{"not contains", func(a *Assert) {
+			a.LogsNotContain(stdLogger, "hunter2", func() { stdLogger.Print("login ok") })
+		}, true, ""},
+		{"not contains fails", func(a *Assert) {
+			a.LogsNotContain(stdLogger, "hunter2", func() { stdLogger.Print("password=hunter2") })
+		}, false, "expected log output not to contain substring\n  substring: \"hunter2\""},
+		{"nil logger", func(a *Assert) { a.LogsContain(nil, "x", func() {}) }, false, "cannot capture the output of a nil logger"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+
+	if stdLogger.Writer() != &original || levelled.Writer() != io.Discard || original.Len() != 0 {
+		t.Errorf("Expected the loggers' outputs to be restored and untouched, got %q", original.String())
+	}
+}
+
+// ExampleAssert_LogsContain shows the log output when the expected message is
+// missing.
+func ExampleAssert_LogsContain() {
+	logger := log.New(io.Discard, "", 0)
+	connect := func() { logger.Print("retrying in 1s") }
+
+	mock := &behaviorMockT{}
+	New(mock).LogsContain(logger, "connection refused", connect)
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// expected log output to contain substring
+	//   substring: "connection refused"
+	//   logged:    "retrying in 1s\n"
+}
@@ -0,0 +1,139 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxListedElements bounds the elements listed when a predicate assertion fails.
+const maxListedElements = 10
+
+// All asserts that predicate holds for every element of slice. On failure
+// the elements that violate it are listed with their indices.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.All(assert, users, func(u User) bool { return u.Active })
+func All[T any](a *Assert, slice []T, predicate func(T) bool) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if violations := matchingIndices(slice, predicate, false); len(violations) > 0 {
+		a.reportMessagef("expected all elements to satisfy predicate\n  violations: %d of %d\n%s",
+			len(violations), len(slice), listElements(a, slice, violations))
+	}
+	return a
+}
+
+// Any asserts that predicate holds for at least one element of slice.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Any(assert, events, func(e Event) bool { return e.Type == "deploy" })
+func Any[T any](a *Assert, slice []T, predicate func(T) bool) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	for _, v := range slice {
+		if predicate(v) {
+			return a
+		}
+	}
+	a.reportMessagef("expected at least one element to satisfy predicate\n  elements: %s",
+		formatValue(slice, a.formatOptions))
+	return a
+}
+
+// None asserts that predicate holds for no element of slice. On failure the
+// matching elements are listed with their indices.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.None(assert, orders, func(o Order) bool { return o.Total < 0 })
+func None[T any](a *Assert, slice []T, predicate func(T) bool) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if matches := matchingIndices(slice, predicate, true); len(matches) > 0 {
+		a.reportMessagef("expected no elements to satisfy predicate\n  matches: %d of %d\n%s",
+			len(matches), len(slice), listElements(a, slice, matches))
+	}
+	return a
+}
+
+// CountWhere asserts that predicate holds for exactly expected elements of
+// slice. On failure the matching elements are listed with their indices.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.CountWhere(assert, jobs, func(j Job) bool { return j.Failed }, 1)
+func CountWhere[T any](a *Assert, slice []T, predicate func(T) bool, expected int) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	matches := matchingIndices(slice, predicate, true)
+	if len(matches) == expected {
+		return a
+	}
+
+	message := fmt.Sprintf("expected %d elements to satisfy predicate, got %d of %d", expected, len(matches), len(slice))
+	if len(matches) > 0 {
+		message += "\n" + listElements(a, slice, matches)
+	}
+	a.reportMessageConsistent(message)
+	return a
+}
+
+// matchingIndices returns the indices of the elements of slice for which
+// predicate returns want.
+func matchingIndices[T any](slice []T, predicate func(T) bool, want bool) []int {
+	var indices []int
+	for i, v := range slice {
+		if predicate(v) == want {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// listElements renders the elements of slice at indices, one per line, up to
+// maxListedElements.
+func listElements[T any](a *Assert, slice []T, indices []int) string {
+	lines := make([]string, 0, min(len(indices), maxListedElements+1))
+	for n, i := range indices {
+		if n == maxListedElements {
+			lines = append(lines, fmt.Sprintf("  … (%d more)", len(indices)-maxListedElements))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  [%d]: %s", i, formatValue(slice[i], a.formatOptions)))
+	}
+	return strings.Join(lines, "\n")
+}
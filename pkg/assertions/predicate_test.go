@@ -0,0 +1,77 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestPredicateAssertions tests All, Any, None and CountWhere.
+func TestPredicateAssertions(t *testing.T) {
+	even := func(n int) bool { return n%2 == 0 }
+	many := make([]int, 25)
+	for i := range many {
+		many[i] = 2*i + 1
+	}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"All holds", func(a *Assert) { All(a, []int{2, 4, 6}, even) }, true, ""},
+		{"All empty", func(a *Assert) { All(a, []int{}, even) }, true, ""},
+		{"All fails", func(a *Assert) { All(a, []int{2, 3, 4, 5}, even) }, false,
+			"expected all elements to satisfy predicate\n  violations: 2 of 4\n  [1]: 3\n  [3]: 5"},
+		{"All lists at most ten", func(a *Assert) { All(a, many, even) }, false, "  [9]: 19\n  … (15 more)"},
+
+		{"Any holds", func(a *Assert) { Any(a, []int{1, 3, 4}, even) }, true, ""},
+		{"Any fails", func(a *Assert) { Any(a, []int{1, 3}, even) }, false,
+			"expected at least one element to satisfy predicate\n  elements: []int{1, 3}"},
+		{"Any empty fails", func(a *Assert) { Any(a, []int(nil), even) }, false, "expected at least one element"},
+
+		{"None holds", func(a *Assert) { None(a, []string{"a", "b"}, func(s string) bool { return s == "" }) }, true, ""},
+		{"None fails", func(a *Assert) { None(a, []string{"a", "", "b"}, func(s string) bool { return s == "" }) }, false,
+			"expected no elements to satisfy predicate\n  matches: 1 of 3\n  [1]: \"\""},
+
+		{"CountWhere holds", func(a *Assert) { CountWhere(a, []int{1, 2, 4}, even, 2) }, true, ""},
+		{"CountWhere zero", func(a *Assert) { CountWhere(a, []int{1, 3}, even, 0) }, true, ""},
+		{"CountWhere fails", func(a *Assert) { CountWhere(a, []int{1, 2, 4}, even, 1) }, false,
+			"expected 1 elements to satisfy predicate, got 2 of 3\n  [1]: 2\n  [2]: 4"},
+		{"CountWhere none matching", func(a *Assert) { CountWhere(a, []int{1, 3}, even, 1) }, false, "got 0 of 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// ExampleAll demonstrates asserting a property over every element.
+func ExampleAll() {
+	type user struct {
+		Name   string
+		Active bool
+	}
+	users := []user{{"ann", true}, {"bob", false}}
+
+	t := &silentT{}
+	All(New(t), users, func(u user) bool { return u.Active })
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: true
+}
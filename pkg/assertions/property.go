@@ -0,0 +1,159 @@
+package assertions
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+)
+
+// DefaultPropertyChecks is the number of generated inputs each property
+// assertion checks unless UsePropertyChecks sets another.
+const DefaultPropertyChecks = 100
+
+// UsePropertyChecks sets the number of generated inputs checked by property
+// assertions such as Commutative.
+func UsePropertyChecks(n int) Option {
+	return func(a *Assert) { a.propertyChecks = n }
+}
+
+// UsePropertySeed fixes the seed from which property assertions generate
+// inputs, to reproduce a failure; the seed is shown in every property
+// failure. Zero restores a fresh seed per assertion.
+func UsePropertySeed(seed uint64) Option {
+	return func(a *Assert) { a.propertySeed = seed }
+}
+
+// propertyRand returns the configured number of checks and a source of
+// random inputs, with the seed it was created from.
+func (a *Assert) propertyRand() (int, *rand.Rand, uint64) {
+	checks := a.propertyChecks
+	if checks <= 0 {
+		checks = DefaultPropertyChecks
+	}
+	seed := a.propertySeed
+	if seed == 0 {
+		seed = rand.Uint64() | 1
+	}
+	return checks, rand.New(rand.NewPCG(seed, seed)), seed
+}
+
+// Commutative asserts that f(x, y) equals f(y, x), compared with
+// reflect.DeepEqual, for inputs drawn from gen. On failure the inputs and
+// both results are reported with the seed that reproduces them.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Commutative(assert, Merge, func(r *rand.Rand) Set { return randomSet(r) })
+func Commutative[T, R any](a *Assert, f func(x, y T) R, gen func(*rand.Rand) T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	checks, r, seed := a.propertyRand()
+	for i := 0; i < checks; i++ {
+		x, y := gen(r), gen(r)
+		if xy, yx := f(x, y), f(y, x); !reflect.DeepEqual(xy, yx) {
+			a.reportPropertyFailure("commutative", i, seed,
+				"x", x, "y", y, "f(x, y)", xy, "f(y, x)", yx)
+			break
+		}
+	}
+	return a
+}
+
+// Associative asserts that f(f(x, y), z) equals f(x, f(y, z)), compared with
+// reflect.DeepEqual, for inputs drawn from gen. On failure the inputs and
+// both groupings are reported with the seed that reproduces them.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Associative(assert, Concat, func(r *rand.Rand) []byte { return randomBytes(r) })
+func Associative[T any](a *Assert, f func(x, y T) T, gen func(*rand.Rand) T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	checks, r, seed := a.propertyRand()
+	for i := 0; i < checks; i++ {
+		x, y, z := gen(r), gen(r), gen(r)
+		if left, right := f(f(x, y), z), f(x, f(y, z)); !reflect.DeepEqual(left, right) {
+			a.reportPropertyFailure("associative", i, seed,
+				"x", x, "y", y, "z", z, "f(f(x, y), z)", left, "f(x, f(y, z))", right)
+			break
+		}
+	}
+	return a
+}
+
+// RoundTrips asserts that decode(encode(v)) returns v, compared with
+// reflect.DeepEqual, for values drawn from gen, and that neither step
+// returns an error. On failure the value, its encoding and the decoded
+// result are reported with the seed that reproduces them.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.RoundTrips(assert, EncodeOrder, DecodeOrder, randomOrder)
+func RoundTrips[T, E any](a *Assert, encode func(T) (E, error), decode func(E) (T, error), gen func(*rand.Rand) T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	checks, r, seed := a.propertyRand()
+	for i := 0; i < checks; i++ {
+		v := gen(r)
+		encoded, err := encode(v)
+		if err != nil {
+			a.reportPropertyFailure("round-tripping", i, seed, "value", v, "encode error", err)
+			break
+		}
+		decoded, err := decode(encoded)
+		if err != nil {
+			a.reportPropertyFailure("round-tripping", i, seed, "value", v, "encoded", encoded, "decode error", err)
+			break
+		}
+		if !reflect.DeepEqual(decoded, v) {
+			a.reportPropertyFailure("round-tripping", i, seed, "value", v, "encoded", encoded, "decoded", decoded)
+			break
+		}
+	}
+	return a
+}
+
+// reportPropertyFailure reports a counterexample to property, found on
+// check i, given as alternating labels and values.
+func (a *Assert) reportPropertyFailure(property string, i int, seed uint64, labelled ...interface{}) {
+	width := 0
+	for j := 0; j < len(labelled); j += 2 {
+		width = max(width, len(labelled[j].(string)))
+	}
+
+	message := fmt.Sprintf("expected function to be %s, found a counterexample on check %d", property, i+1)
+	for j := 0; j < len(labelled); j += 2 {
+		value := labelled[j+1]
+		rendered := formatValue(value, a.formatOptions)
+		if err, ok := value.(error); ok {
+			rendered = err.Error()
+		}
+		message += fmt.Sprintf("\n  %-*s %s", width+1, labelled[j].(string)+":", rendered)
+	}
+	message += fmt.Sprintf("\n  reproduce with UsePropertySeed(%d)", seed)
+	a.reportMessageConsistent(message)
+}
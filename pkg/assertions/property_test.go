@@ -0,0 +1,129 @@
+package assertions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestPropertyAssertions tests Commutative, Associative and RoundTrips.
+func TestPropertyAssertions(t *testing.T) {
+	smallInt := func(r *rand.Rand) int { return r.IntN(100) }
+	word := func(r *rand.Rand) string { return strconv.Itoa(r.IntN(1000)) }
+	add := func(x, y int) int { return x + y }
+	subtract := func(x, y int) int { return x - y }
+	concat := func(x, y string) string { return x + y }
+
+	type point struct{ X, Y int }
+	randomPoint := func(r *rand.Rand) point { return point{r.IntN(50), r.IntN(50)} }
+	encodeJSON := func(p point) ([]byte, error) { return json.Marshal(p) }
+	decodeJSON := func(b []byte) (point, error) {
+		var p point
+		err := json.Unmarshal(b, &p)
+		return p, err
+	}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"Commutative addition", func(a *Assert) { Commutative(a, add, smallInt) }, true, ""},
+		{"Commutative subtraction fails", func(a *Assert) { Commutative(a, subtract, smallInt) }, false,
+			"expected function to be commutative, found a counterexample on check"},
+		{"Commutative concatenation fails", func(a *Assert) { Commutative(a, concat, word) }, false, "\n  f(x, y): \""},
+
+		{"Associative concatenation", func(a *Assert) { Associative(a, concat, word) }, true, ""},
+		{"Associative subtraction fails", func(a *Assert) { Associative(a, subtract, smallInt) }, false,
+			"expected function to be associative"},
+
+		{"RoundTrips JSON", func(a *Assert) { RoundTrips(a, encodeJSON, decodeJSON, randomPoint) }, true, ""},
+		{"RoundTrips lossy decode fails", func(a *Assert) {
+			RoundTrips(a, encodeJSON, func(b []byte) (point, error) {
+				p, err := decodeJSON(b)
+				p.Y = 0
+				return p, err
+			}, func(r *rand.Rand) point { return point{1, 1 + r.IntN(5)} })
+		}, false, "expected function to be round-tripping"},
+		{"RoundTrips encode error", func(a *Assert) {
+			RoundTrips(a, func(point) ([]byte, error) { return nil, errors.New("unsupported") }, decodeJSON, randomPoint)
+		}, false, "encode error: unsupported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+				if !strings.Contains(mock.errorCalls[0], "reproduce with UsePropertySeed(") {
+					t.Errorf("Expected the seed in the message, got: %s", mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestPropertySeedReproducesFailure tests that a fixed seed gives the same counterexample.
+func TestPropertySeedReproducesFailure(t *testing.T) {
+	subtract := func(x, y int) int { return x - y }
+	gen := func(r *rand.Rand) int { return r.IntN(1000) }
+
+	run := func() string {
+		mock := &behaviorMockT{}
+		Commutative(New(mock).With(UsePropertySeed(42)), subtract, gen)
+		if len(mock.errorCalls) != 1 {
+			t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+		}
+		return mock.errorCalls[0]
+	}
+
+	first, second := run(), run()
+	if first != second {
+		t.Errorf("Expected identical counterexamples for a fixed seed, got:\n%s\nand:\n%s", first, second)
+	}
+	if !strings.Contains(first, "UsePropertySeed(42)") {
+		t.Errorf("Expected the fixed seed in the message, got: %s", first)
+	}
+}
+
+// TestUsePropertyChecks tests the number of generated inputs.
+func TestUsePropertyChecks(t *testing.T) {
+	generated := 0
+	gen := func(r *rand.Rand) int { generated++; return r.IntN(10) }
+
+	Commutative(New(&behaviorMockT{}).With(UsePropertyChecks(7)), func(x, y int) int { return x * y }, gen)
+	if generated != 14 {
+		t.Errorf("Expected 7 checks of 2 inputs each, generated %d", generated)
+	}
+}
+
+// ExampleCommutative demonstrates algebraic property assertions.
+func ExampleCommutative() {
+	t := &silentT{}
+	assert := New(t)
+	gen := func(r *rand.Rand) int { return r.IntN(1000) - 500 }
+
+	Commutative(assert, func(x, y int) int { return x + y }, gen)
+	Associative(assert, func(x, y int) int { return max(x, y) }, gen)
+	RoundTrips(assert,
+		func(n int) (string, error) { return strconv.Itoa(n), nil },
+		strconv.Atoi,
+		gen)
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
@@ -0,0 +1,145 @@
+package assertions
+
+import (
+	"bytes"
+	"io"
+)
+
+// DefaultMaxReadBytes bounds how much the reader assertions consume from a
+// stream unless overridden with UseMaxReadBytes.
+const DefaultMaxReadBytes = 10 * 1024 * 1024
+
+// UseMaxReadBytes sets the maximum number of bytes the reader assertions read
+// from a stream. Zero restores DefaultMaxReadBytes.
+func UseMaxReadBytes(n int64) Option {
+	return func(a *Assert) { a.maxReadBytes = n }
+}
+
+// readLimit returns the configured read limit.
+func (a *Assert) readLimit() int64 {
+	if a.maxReadBytes > 0 {
+		return a.maxReadBytes
+	}
+	return DefaultMaxReadBytes
+}
+
+// readBounded reads r up to the read limit, reporting a failure if r cannot be
+// read or holds more than the limit. ok is false once a failure is reported.
+func (a *Assert) readBounded(r io.Reader) (data []byte, ok bool) {
+	limit := a.readLimit()
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		a.reportMessagef("failed to read from reader after %d bytes\n  error: %v", len(data), err)
+		return nil, false
+	}
+	if int64(len(data)) > limit {
+		a.reportMessagef("reader exceeded read limit of %d bytes; raise it with UseMaxReadBytes\n  start: %s",
+			limit, formatValue(string(data[:min(int64(len(data)), 200)]), a.formatOptions))
+		return nil, false
+	}
+	return data, true
+}
+
+// ReaderContains asserts that the stream r contains substring. Reading stops as
+// soon as the substring is found, so only the read limit bounds memory and the
+// rest of the stream is left unread.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.ReaderContains(resp.Body, `"status":"ok"`)
+func (a *Assert) ReaderContains(r io.Reader, substring string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	limit := a.readLimit()
+	source := io.LimitReader(r, limit+1) // One byte past the limit detects overrun
+	needle := []byte(substring)
+	chunk := make([]byte, 32*1024)
+	window := make([]byte, 0, len(chunk)+len(needle))
+	var total int64
+
+	for {
+		n, err := source.Read(chunk)
+		overrun := total+int64(n) > limit
+		if overrun {
+			n = int(limit - total)
+		}
+		total += int64(n)
+
+		window = append(window, chunk[:n]...)
+		if bytes.Contains(window, needle) {
+			return a
+		}
+		// Keep just enough of the tail to match a substring spanning reads
+		if keep := len(needle) - 1; len(window) > keep {
+			window = append(window[:0], window[len(window)-keep:]...)
+		}
+
+		switch {
+		case overrun:
+			a.reportMessagef("read limit of %d bytes reached before finding substring; raise it with UseMaxReadBytes\n  substring: %q",
+				limit, substring)
+			return a
+		case err == io.EOF:
+			a.reportMessagef("expected reader to contain substring\n  substring: %q\n  read:      %d bytes", substring, total)
+			return a
+		case err != nil:
+			a.reportMessagef("failed to read from reader after %d bytes\n  error: %v", total, err)
+			return a
+		}
+	}
+}
+
+// ReaderEqual asserts that the stream r holds exactly expected, showing the
+// usual string diff on mismatch.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.ReaderEqual(file, "line one\nline two\n")
+func (a *Assert) ReaderEqual(r io.Reader, expected string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	data, ok := a.readBounded(r)
+	if ok && string(data) != expected {
+		a.reportErrorConsistent(string(data), expected, "reader content differs")
+	}
+	return a
+}
+
+// ReaderJSONEqual asserts that the stream r holds JSON equivalent to expected,
+// ignoring formatting and object key order, as JsonEqual does.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.ReaderJSONEqual(resp.Body, `{"id": 42, "name": "gowise"}`)
+func (a *Assert) ReaderJSONEqual(r io.Reader, expected string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if data, ok := a.readBounded(r); ok {
+		a.delegate().JsonEqual(expected, string(data))
+	}
+	return a
+}
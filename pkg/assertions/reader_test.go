@@ -0,0 +1,127 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// endlessReader yields an unbounded stream of the same byte.
+type endlessReader struct{ read int64 }
+
+func (r *endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'x'
+	}
+	r.read += int64(len(p))
+	return len(p), nil
+}
+
+// TestReaderAssertions tests the reader assertions against finite streams.
+func TestReaderAssertions(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"ReaderContains present", func(a *Assert) { a.ReaderContains(strings.NewReader("status: ok\n"), "ok") }, true, ""},
+		{"ReaderContains across reads", func(a *Assert) {
+			a.ReaderContains(iotest.OneByteReader(strings.NewReader("abc-needle-xyz")), "needle")
+		}, true, ""},
+		{"ReaderContains absent", func(a *Assert) { a.ReaderContains(strings.NewReader("status: ok\n"), "error") }, false, "expected reader to contain substring\n  substring: \"error\"\n  read:      11 bytes"},
+		{"ReaderContains read error", func(a *Assert) {
+			a.ReaderContains(iotest.ErrReader(errors.New("connection reset")), "ok")
+		}, false, "failed to read from reader after 0 bytes\n  error: connection reset"},
+
+		{"ReaderEqual equal", func(a *Assert) { a.ReaderEqual(strings.NewReader("a\nb\n"), "a\nb\n") }, true, ""},
+		{"ReaderEqual differs", func(a *Assert) { a.ReaderEqual(strings.NewReader("a\nb\n"), "a\nc\n") }, false, "reader content differs"},
+		{"ReaderEqual read error", func(a *Assert) {
+			a.ReaderEqual(io.MultiReader(strings.NewReader("abc"), iotest.ErrReader(errors.New("boom"))), "abc")
+		}, false, "failed to read from reader after 3 bytes\n  error: boom"},
+
+		{"ReaderJSONEqual equivalent", func(a *Assert) {
+			a.ReaderJSONEqual(strings.NewReader(`{"b": 2, "a": 1}`), `{"a":1,"b":2}`)
+		}, true, ""},
+		{"ReaderJSONEqual differs", func(a *Assert) {
+			a.ReaderJSONEqual(strings.NewReader(`{"a": 1}`), `{"a": 2}`)
+		}, false, "JSON objects differ"},
+		{"ReaderJSONEqual invalid", func(a *Assert) { a.ReaderJSONEqual(strings.NewReader(`{"a":`), `{"a": 1}`) }, false, "actual JSON is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected assertion to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestReaderAssertionsBoundReads tests that unbounded streams stop at the read limit.
+func TestReaderAssertionsBoundReads(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert, r io.Reader)
+		expectMessage string
+	}{
+		{"ReaderContains", func(a *Assert, r io.Reader) { a.ReaderContains(r, "y") }, "read limit of 4096 bytes reached before finding substring"},
+		{"ReaderEqual", func(a *Assert, r io.Reader) { a.ReaderEqual(r, "x") }, "reader exceeded read limit of 4096 bytes"},
+		{"ReaderJSONEqual", func(a *Assert, r io.Reader) { a.ReaderJSONEqual(r, "{}") }, "reader exceeded read limit of 4096 bytes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			stream := &endlessReader{}
+
+			tt.assert(New(mock).With(UseMaxReadBytes(4096)), stream)
+
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Expected 1 Errorf call, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+			}
+			if stream.read > 64*1024 {
+				t.Errorf("Expected reads to stop near the limit, read %d bytes", stream.read)
+			}
+		})
+	}
+}
+
+// TestReaderContainsStopsAtMatch tests that the stream is not consumed past the match.
+func TestReaderContainsStopsAtMatch(t *testing.T) {
+	mock := &behaviorMockT{}
+	stream := io.MultiReader(strings.NewReader("ready\n"), &endlessReader{})
+
+	New(mock).ReaderContains(stream, "ready")
+
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected ReaderContains to pass without draining the stream, got: %v", mock.errorCalls)
+	}
+}
+
+// ExampleAssert_ReaderJSONEqual demonstrates comparing a streamed JSON body.
+func ExampleAssert_ReaderJSONEqual() {
+	t := &silentT{}
+	body := strings.NewReader(`{"name": "gowise", "tags": ["fast", "stdlib"]}`)
+
+	New(t).ReaderJSONEqual(body, `{"tags":["fast","stdlib"],"name":"gowise"}`)
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
@@ -0,0 +1,119 @@
+package assertions
+
+import "regexp"
+
+// maxCachedPatterns bounds the compiled patterns a chain keeps.
+const maxCachedPatterns = 64
+
+// compileRegexp returns the compiled form of pattern, compiling it once per
+// chain, so a table-driven test that checks every case with one Assert does
+// not recompile the same expression. The cache belongs to the chain, and is
+// bounded, so patterns built at run time are not kept beyond the test.
+func (a *Assert) compileRegexp(pattern string) (*regexp.Regexp, error) {
+	s := a.shared
+	s.patternsMu.Lock()
+	re, ok := s.patterns[pattern]
+	s.patternsMu.Unlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	s.patternsMu.Lock()
+	defer s.patternsMu.Unlock()
+	if s.patterns == nil {
+		s.patterns = make(map[string]*regexp.Regexp)
+	}
+	if len(s.patterns) < maxCachedPatterns {
+		s.patterns[pattern] = re
+	}
+	return re, nil
+}
+
+// reportInvalidPattern reports a pattern that failed to compile, keeping it
+// distinct from a pattern that compiled but did not match.
+func (a *Assert) reportInvalidPattern(pattern string, err error) {
+	a.reportMessagef("invalid regular expression pattern\n  pattern: %s\n  error:   %v", pattern, err)
+}
+
+// MatchRegexp asserts that s matches the regular expression pattern.
+// The pattern is validated first; compile errors are reported distinctly from
+// failed matches. Compiled patterns are cached by the chain for repeated use.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.MatchRegexp(`^v\d+\.\d+\.\d+$`, version)
+func (a *Assert) MatchRegexp(pattern, s string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	re, err := a.compileRegexp(pattern)
+	if err != nil {
+		a.reportInvalidPattern(pattern, err)
+		return a
+	}
+
+	if !re.MatchString(s) {
+		a.reportMessagef("expected to match regular expression\n  pattern: %s\n  string:  %s", pattern, formatString(s, a.formatOptions))
+	}
+	return a
+}
+
+// CaptureRegexp asserts that s matches the regular expression pattern and stores
+// the named capture groups of the first match in groups, keyed by group name.
+// Named groups that did not participate in the match map to an empty string.
+// On failure groups is left untouched. Returns *Assert to enable method chaining.
+//
+// Assert on the groups in a separate statement: in a chained call such as
+// CaptureRegexp(...).Equal(groups["domain"], ...), Go may evaluate
+// groups["domain"] before CaptureRegexp fills the map.
+//
+// Example:
+//
+//	var groups map[string]string
+//	assert.CaptureRegexp(`^(?P<user>\w+)@(?P<domain>[\w.]+)$`, email, &groups)
+//	assert.Equal(groups["domain"], "example.com")
+func (a *Assert) CaptureRegexp(pattern, s string, groups *map[string]string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	re, err := a.compileRegexp(pattern)
+	if err != nil {
+		a.reportInvalidPattern(pattern, err)
+		return a
+	}
+
+	match := re.FindStringSubmatch(s)
+	if match == nil {
+		a.reportMessagef("expected to match regular expression\n  pattern: %s\n  string:  %s", pattern, formatString(s, a.formatOptions))
+		return a
+	}
+
+	if groups != nil {
+		captured := make(map[string]string)
+		for i, name := range re.SubexpNames() {
+			if name != "" {
+				captured[name] = match[i]
+			}
+		}
+		*groups = captured
+	}
+	return a
+}
@@ -0,0 +1,179 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestMatchRegexp tests matching, non-matching and invalid patterns.
+func TestMatchRegexp(t *testing.T) {
+	tests := []struct {
+		name          string
+		pattern, s    string
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"matches", `^v\d+\.\d+\.\d+$`, "v1.2.3", true, ""},
+		{"does not match", `^v\d+$`, "version", false, "expected to match regular expression"},
+		{"invalid pattern", `(unclosed`, "anything", false, "invalid regular expression pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			assert := New(mock)
+
+			assert.MatchRegexp(tt.pattern, tt.s)
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("MatchRegexp should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("MatchRegexp should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestRegexpReportsInvalidPattern tests that Regexp no longer swallows compile errors.
+func TestRegexpReportsInvalidPattern(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	assert.Regexp(`[a-`, "a")
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	if !strings.Contains(mock.errorCalls[0], "invalid regular expression pattern") {
+		t.Errorf("Expected invalid pattern message, got: %s", mock.errorCalls[0])
+	}
+}
+
+// TestMatchRegexpTableReuse tests repeated use of the same pattern across many cases.
+func TestMatchRegexpTableReuse(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	for i := 0; i < 100; i++ {
+		assert.MatchRegexp(`^id-\d+$`, fmt.Sprintf("id-%d", i))
+	}
+
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected all matches to pass, got %v", mock.errorCalls)
+	}
+}
+
+// TestRegexpCacheIsPerChainAndBounded tests that compiled patterns are
+// reused within a chain only, and that a chain keeps a bounded number.
+func TestRegexpCacheIsPerChainAndBounded(t *testing.T) {
+	assert := New(&behaviorMockT{})
+	first, _ := assert.compileRegexp(`^id-\d+$`)
+	again, _ := assert.With().compileRegexp(`^id-\d+$`)
+	if first != again {
+		t.Error("Expected the chain to reuse its compiled pattern")
+	}
+	other, _ := New(&behaviorMockT{}).compileRegexp(`^id-\d+$`)
+	if other == first {
+		t.Error("Expected another chain to compile its own pattern")
+	}
+
+	for i := 0; i < 2*maxCachedPatterns; i++ {
+		if _, err := assert.compileRegexp(fmt.Sprintf(`^order-%d$`, i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := len(assert.shared.patterns); n != maxCachedPatterns {
+		t.Errorf("Expected the cache bounded at %d patterns, got %d", maxCachedPatterns, n)
+	}
+}
+
+// TestCaptureRegexp tests extraction of named capture groups.
+func TestCaptureRegexp(t *testing.T) {
+	t.Run("extracts named groups", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+
+		var groups map[string]string
+		assert.CaptureRegexp(`^(?P<user>\w+)@(?P<domain>[\w.]+)(?P<port>:\d+)?$`, "alice@example.com", &groups)
+
+		if len(mock.errorCalls) != 0 {
+			t.Fatalf("Expected no Errorf calls, got %v", mock.errorCalls)
+		}
+		if groups["user"] != "alice" || groups["domain"] != "example.com" {
+			t.Errorf("Unexpected groups: %v", groups)
+		}
+		if value, ok := groups["port"]; !ok || value != "" {
+			t.Errorf("Expected unmatched optional group to be present and empty, got %q (present=%v)", value, ok)
+		}
+	})
+
+	t.Run("no match leaves groups untouched", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+
+		groups := map[string]string{"existing": "value"}
+		assert.CaptureRegexp(`^(?P<digits>\d+)$`, "abc", &groups)
+
+		if len(mock.errorCalls) != 1 {
+			t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+		}
+		if groups["existing"] != "value" || len(groups) != 1 {
+			t.Errorf("Expected groups to be untouched, got %v", groups)
+		}
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+
+		assert.CaptureRegexp(`(?P<bad`, "abc", nil)
+
+		if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "invalid regular expression pattern") {
+			t.Errorf("Expected invalid pattern failure, got %v", mock.errorCalls)
+		}
+	})
+
+	t.Run("groups checked by a later assertion", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+
+		var groups map[string]string
+		assert.CaptureRegexp(`^(?P<major>\d+)\.(?P<minor>\d+)$`, "2.7", &groups)
+		assert.Equal(groups["major"], "2")
+
+		if len(mock.errorCalls) != 0 {
+			t.Errorf("Expected no Errorf calls, got %v", mock.errorCalls)
+		}
+	})
+}
+
+// ExampleAssert_MatchRegexp demonstrates pattern matching with validated patterns.
+func ExampleAssert_MatchRegexp() {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	assert.MatchRegexp(`^[a-z]+(`, "hello")
+
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// invalid regular expression pattern
+	//   pattern: ^[a-z]+(
+	//   error:   error parsing regexp: missing closing ): `^[a-z]+(`
+}
+
+// ExampleAssert_CaptureRegexp demonstrates extracting named capture groups.
+func ExampleAssert_CaptureRegexp() {
+	assert := New(&silentT{})
+
+	var groups map[string]string
+	assert.CaptureRegexp(`^(?P<method>[A-Z]+) (?P<path>\S+)$`, "GET /users/42", &groups)
+
+	fmt.Println(groups["method"], groups["path"])
+	// Output: GET /users/42
+}
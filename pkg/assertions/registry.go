@@ -0,0 +1,190 @@
+package assertions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CheckResult is the outcome of a custom assertion, built with Pass, Failf
+// or Mismatch and reported with Report, or returned by a Checker registered
+// with Register. Reporting through an Assert rather than calling t.Errorf
+// gives a custom assertion the built-in machinery: fail-fast chaining,
+// got/want diffs, attachments, failure logging and statistics.
+type CheckResult struct {
+	failed     bool
+	message    string
+	got, want  interface{}
+	showValues bool
+}
+
+// Pass returns a CheckResult for a check that held.
+func Pass() CheckResult {
+	return CheckResult{}
+}
+
+// Failf returns a CheckResult for a failed check, with a message formatted
+// as by fmt.Sprintf.
+//
+// Example:
+//
+//	return assertions.Failf("invalid email format\n  got: %q", email)
+func Failf(format string, args ...interface{}) CheckResult {
+	return CheckResult{failed: true, message: fmt.Sprintf(format, args...)}
+}
+
+// Mismatch returns a CheckResult for a failed check whose value differs from
+// an expected one. The failure shows got and want as Equal shows them, with
+// a diff for strings and composite values.
+//
+// Example:
+//
+//	if domain != want {
+//		return assertions.Mismatch(domain, want, "wrong email domain")
+//	}
+func Mismatch(got, want interface{}, message string) CheckResult {
+	return CheckResult{failed: true, message: message, got: got, want: want, showValues: true}
+}
+
+// Failed reports whether the check failed.
+func (r CheckResult) Failed() bool {
+	return r.failed
+}
+
+// Message returns the failure message given to Failf or Mismatch, without
+// the got and want values.
+func (r CheckResult) Message() string {
+	return r.message
+}
+
+// Report reports result through a, if it failed, as a built-in assertion
+// reports its failures. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	func (d *DomainAssert) HasSKU(p Product) *DomainAssert {
+//		d.Report(checkSKU(p.SKU))
+//		return d
+//	}
+//
+//funcgen:skip
+func (a *Assert) Report(result CheckResult) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	a.reportResult(result, "")
+	return a
+}
+
+// reportResult reports a failed result, its message headed by prefix.
+func (a *Assert) reportResult(result CheckResult, prefix string) {
+	if !result.failed {
+		return
+	}
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	message := prefix + result.message
+	if result.showValues {
+		a.reportErrorConsistent(result.got, result.want, message)
+	} else {
+		a.reportMessageConsistent(message)
+	}
+}
+
+// Checker is a custom assertion registered with Register. It receives the
+// arguments given to Check.
+type Checker func(args ...interface{}) CheckResult
+
+// registry holds the assertions registered with Register, by name.
+var registry = struct {
+	sync.RWMutex
+	checks map[string]Checker
+}{checks: make(map[string]Checker)}
+
+// Register makes check available to every Assert as Check(name, args...),
+// for domain packages that share assertions without wrapping Assert.
+// Register it from an init function. It panics if name is empty, check is
+// nil, or name is already registered, as database/sql.Register does.
+//
+// Example:
+//
+//	func init() {
+//		assertions.Register("IsValidEmail", func(args ...any) assertions.CheckResult {
+//			email, _ := args[0].(string)
+//			if !emailPattern.MatchString(email) {
+//				return assertions.Failf("invalid email format\n  got: %q", email)
+//			}
+//			return assertions.Pass()
+//		})
+//	}
+func Register(name string, check Checker) {
+	if name == "" {
+		panic("assertions: Register called with an empty name")
+	}
+	if check == nil {
+		panic("assertions: Register called with a nil Checker for " + name)
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.checks[name]; dup {
+		panic("assertions: Register called twice for " + name)
+	}
+	registry.checks[name] = check
+}
+
+// Check runs the assertion registered as name with args, and reports its
+// failure headed by the name. An unknown name fails, listing the registered
+// ones. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.Check("IsValidEmail", user.Email).Equal(user.Active, true)
+//
+//funcgen:skip
+func (a *Assert) Check(name string, args ...interface{}) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	registry.RLock()
+	check, ok := registry.checks[name]
+	registry.RUnlock()
+	if !ok {
+		a.reportMessagef("no assertion registered as %q\n  registered: %s", name, registeredNames())
+		return a
+	}
+
+	a.reportResult(check(args...), name+": ")
+	return a
+}
+
+// registeredNames lists the registered assertions in sorted order.
+func registeredNames() string {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	if len(registry.checks) == 0 {
+		return "(none)"
+	}
+	names := make([]string, 0, len(registry.checks))
+	for name := range registry.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
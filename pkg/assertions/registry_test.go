@@ -0,0 +1,114 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func init() {
+	Register("registryTestPositive", func(args ...interface{}) CheckResult {
+		n, ok := args[0].(int)
+		if !ok {
+			return Failf("want an int, got %T", args[0])
+		}
+		if n <= 0 {
+			return Failf("expected a positive number\n  got: %d", n)
+		}
+		return Pass()
+	})
+	Register("registryTestGreeting", func(args ...interface{}) CheckResult {
+		if got := args[0].(string); got != "hello, world" {
+			return Mismatch(got, "hello, world", "wrong greeting")
+		}
+		return Pass()
+	})
+}
+
+// TestCustomAssertions tests Report and Check with registered assertions.
+func TestCustomAssertions(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"Report pass", func(a *Assert) { a.Report(Pass()) }, true, ""},
+		{"Report Failf", func(a *Assert) { a.Report(Failf("invalid SKU %q", "x-1")) }, false, `invalid SKU "x-1"`},
+		{"Report Mismatch uses diffs", func(a *Assert) { a.Report(Mismatch("hello", "help", "wrong word")) }, false,
+			"wrong word\n  string values differ at position 3"},
+		{"Check pass", func(a *Assert) { a.Check("registryTestPositive", 3) }, true, ""},
+		{"Check fail prefixed with name", func(a *Assert) { a.Check("registryTestPositive", -1) }, false,
+			"registryTestPositive: expected a positive number\n  got: -1"},
+		{"Check mismatch", func(a *Assert) { a.Check("registryTestGreeting", "hello") }, false,
+			"registryTestGreeting: wrong greeting\n  string values differ at position 5"},
+		{"Check unknown", func(a *Assert) { a.Check("registryTestMissing") }, false,
+			"no assertion registered as \"registryTestMissing\"\n  registered: "},
+		{"Check fail-fast", func(a *Assert) {
+			a.Check("registryTestPositive", 0).Check("registryTestGreeting", "bye")
+		}, false, "registryTestPositive: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestCustomAssertionsUseStats checks that custom assertions are counted
+// like built-in ones.
+func TestCustomAssertionsUseStats(t *testing.T) {
+	a := New(&behaviorMockT{}).With(UseStats())
+	a.Check("registryTestPositive", 1).Report(Failf("boom")).Check("registryTestPositive", 1)
+
+	if got := a.Stats(); got.Total != 2 || got.Failed != 1 || got.Skipped != 1 {
+		t.Errorf("expected 2 assertions run, 1 failed and 1 skipped, got %+v", got)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	for name, register := range map[string]func(){
+		"empty name": func() { Register("", func(...interface{}) CheckResult { return Pass() }) },
+		"nil check":  func() { Register("registryTestNil", nil) },
+		"duplicate":  func() { Register("registryTestPositive", func(...interface{}) CheckResult { return Pass() }) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected Register to panic")
+				}
+			}()
+			register()
+		})
+	}
+}
+
+func ExampleRegister() {
+	// Usually called from an init function of a domain package
+	Register("IsEven", func(args ...interface{}) CheckResult {
+		if n := args[0].(int); n%2 != 0 {
+			return Failf("expected an even number\n  got: %d", n)
+		}
+		return Pass()
+	})
+
+	mock := &behaviorMockT{}
+	New(mock).Check("IsEven", 4).Check("IsEven", 7)
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// IsEven: expected an even number
+	//   got: 7
+}
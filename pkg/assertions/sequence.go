@@ -0,0 +1,154 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InOrder asserts that events contains every item of expected in the same
+// relative order, not necessarily adjacent: other events may come before,
+// between and after them. Items are compared with reflect.DeepEqual. On
+// failure each expected item is listed with where it was found, up to the one
+// at which the sequence broke, noting if that item appears earlier, out of
+// order. Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.InOrder(assert, auditLog.Actions(), []string{"login", "update-email", "logout"})
+func InOrder[T any](a *Assert, events, expected []T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if broken, found := sequenceBreak(events, expected); broken >= 0 {
+		a.reportMessagef("expected items in order, but the sequence broke at expected[%d]\n%s",
+			broken, describeSequence(a, events, expected, broken, found))
+	}
+	return a
+}
+
+// EventuallyInOrder polls fetch, as EventuallyWith polls its condition, until
+// the events it returns contain expected in order as InOrder checks. Zero
+// fields of config take the defaults EventuallyWith uses. On timeout the
+// events from the last poll are described as InOrder describes them.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.EventuallyInOrder(assert, bus.Published, []Event{created, paid, shipped},
+//		assertions.EventuallyConfig{Timeout: 2 * time.Second})
+func EventuallyInOrder[T any](a *Assert, fetch func() []T, expected []T, config EventuallyConfig) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+	defer a.recordDuration(time.Now())
+
+	if fetch == nil {
+		a.reportMessagef("EventuallyInOrder needs a function to fetch events")
+		return a
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = a.eventuallyDefaults().Timeout
+	}
+	if config.Interval <= 0 {
+		config.Interval = a.eventuallyDefaults().Interval
+	}
+	if config.BackoffFactor < 1.0 {
+		config.BackoffFactor = 1.0
+	}
+
+	var (
+		events []T
+		broken int
+		found  []int
+	)
+	poll := pollUntil(func() bool {
+		events = fetch()
+		broken, found = sequenceBreak(events, expected)
+		return broken < 0
+	}, config, a.currentClock())
+	if !poll.met {
+		a.reportMessagef("EventuallyInOrder: sequence not seen within timeout, breaking at expected[%d]\n  timeout: %v\n  attempts: %s\n%s",
+			broken, config.Timeout, groupDigits(strconv.Itoa(poll.attempts), a.formatOptions.Numbers),
+			describeSequence(a, events, expected, broken, found))
+	}
+	return a
+}
+
+// sequenceBreak matches expected against events greedily, which finds a
+// subsequence whenever one exists. It returns the index of the first expected
+// item with no match, or -1 if all matched, and the index in events of each
+// item matched before it.
+func sequenceBreak[T any](events, expected []T) (int, []int) {
+	found := make([]int, 0, len(expected))
+	next := 0
+	for i, want := range expected {
+		at := indexEqual(events, want, next)
+		if at < 0 {
+			return i, found
+		}
+		found = append(found, at)
+		next = at + 1
+	}
+	return -1, found
+}
+
+// indexEqual returns the index of the first element of events at or after
+// from that is deeply equal to want, or -1.
+func indexEqual[T any](events []T, want T, from int) int {
+	for i := from; i < len(events); i++ {
+		if reflect.DeepEqual(events[i], want) {
+			return i
+		}
+	}
+	return -1
+}
+
+// describeSequence renders where each expected item up to broken was found
+// in events, why broken was not, and the events themselves.
+func describeSequence[T any](a *Assert, events, expected []T, broken int, found []int) string {
+	var b strings.Builder
+	b.WriteString("  sequence:")
+
+	first := 0
+	if broken > maxListedElements {
+		first = broken - maxListedElements
+		fmt.Fprintf(&b, "\n    … (%d earlier items found in order)", first)
+	}
+	for i := first; i < broken; i++ {
+		fmt.Fprintf(&b, "\n    [%d] %s found at events[%d]", i, formatValue(expected[i], a.formatOptions), found[i])
+	}
+
+	after := -1
+	if broken > 0 {
+		after = found[broken-1]
+	}
+	fmt.Fprintf(&b, "\n    [%d] %s ", broken, formatValue(expected[broken], a.formatOptions))
+	switch earlier := indexEqual(events[:after+1], expected[broken], 0); {
+	case after < 0:
+		b.WriteString("missing")
+	case earlier >= 0:
+		fmt.Fprintf(&b, "missing after events[%d], but found earlier at events[%d]", after, earlier)
+	default:
+		fmt.Fprintf(&b, "missing after events[%d]", after)
+	}
+	if rest := len(expected) - broken - 1; rest > 0 {
+		fmt.Fprintf(&b, "\n    … (%d more not reached)", rest)
+	}
+
+	fmt.Fprintf(&b, "\n  events (%d): %s", len(events), formatValue(events, a.formatOptions))
+	return b.String()
+}
@@ -0,0 +1,101 @@
+package assertions
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSequenceAssertions tests InOrder and EventuallyInOrder.
+func TestSequenceAssertions(t *testing.T) {
+	type event struct {
+		Kind string
+		ID   int
+	}
+	log := []string{"created", "viewed", "paid", "viewed", "shipped"}
+	quick := EventuallyConfig{Timeout: 30 * time.Millisecond, Interval: 5 * time.Millisecond}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"InOrder adjacent", func(a *Assert) { InOrder(a, log, []string{"created", "viewed", "paid"}) }, true, ""},
+		{"InOrder with gaps", func(a *Assert) { InOrder(a, log, []string{"created", "paid", "shipped"}) }, true, ""},
+		{"InOrder repeated item", func(a *Assert) { InOrder(a, log, []string{"viewed", "viewed"}) }, true, ""},
+		{"InOrder empty expected", func(a *Assert) { InOrder(a, []string(nil), []string{}) }, true, ""},
+		{"InOrder structs", func(a *Assert) {
+			InOrder(a, []event{{"a", 1}, {"b", 2}, {"c", 3}}, []event{{"a", 1}, {"c", 3}})
+		}, true, ""},
+		{"InOrder out of order", func(a *Assert) { InOrder(a, log, []string{"paid", "created"}) }, false,
+			"expected items in order, but the sequence broke at expected[1]\n  sequence:\n" +
+				"    [0] \"paid\" found at events[2]\n" +
+				"    [1] \"created\" missing after events[2], but found earlier at events[0]\n" +
+				"  events (5): "},
+		{"InOrder missing item", func(a *Assert) { InOrder(a, log, []string{"created", "refunded", "shipped"}) }, false,
+			"    [1] \"refunded\" missing after events[0]\n    … (1 more not reached)"},
+		{"InOrder first item missing", func(a *Assert) { InOrder(a, log, []string{"deleted"}) }, false,
+			"    [0] \"deleted\" missing\n"},
+		{"InOrder too few repeats", func(a *Assert) { InOrder(a, log, []string{"viewed", "viewed", "viewed"}) }, false,
+			"    [2] \"viewed\" missing after events[3], but found earlier at events[1]"},
+
+		{"EventuallyInOrder already in order", func(a *Assert) {
+			EventuallyInOrder(a, func() []string { return log }, []string{"paid", "shipped"}, quick)
+		}, true, ""},
+		{"EventuallyInOrder times out", func(a *Assert) {
+			EventuallyInOrder(a, func() []string { return log }, []string{"shipped", "paid"}, quick)
+		}, false, "EventuallyInOrder: sequence not seen within timeout, breaking at expected[1]\n  timeout: 30ms\n  attempts: "},
+		{"EventuallyInOrder nil fetch", func(a *Assert) {
+			EventuallyInOrder(a, nil, []string{"paid"}, quick)
+		}, false, "EventuallyInOrder needs a function to fetch events"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestEventuallyInOrderWaitsForEvents checks that events arriving while
+// EventuallyInOrder polls satisfy it.
+func TestEventuallyInOrderWaitsForEvents(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	go func() {
+		for _, e := range []string{"started", "progress", "finished"} {
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		}
+	}()
+	fetch := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), events...)
+	}
+
+	mock := &behaviorMockT{}
+	EventuallyInOrder(New(mock), fetch, []string{"started", "finished"},
+		EventuallyConfig{Timeout: 2 * time.Second, Interval: 2 * time.Millisecond})
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("expected the sequence to be seen, got %v", mock.errorCalls)
+	}
+}
@@ -0,0 +1,142 @@
+package assertions
+
+import "cmp"
+
+// Sorted asserts that slice is in ascending order. Equal neighbours are
+// allowed, and NaN sorts before every other float, as in slices.Sort.
+// On failure the first out-of-order pair is reported with its indices.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Sorted(assert, timestamps)
+func Sorted[T cmp.Ordered](a *Assert, slice []T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	for i := 1; i < len(slice); i++ {
+		if cmp.Less(slice[i], slice[i-1]) {
+			a.reportUnsorted("ascending order", i, a.formatOrdered(slice[i-1]), a.formatOrdered(slice[i]))
+			break
+		}
+	}
+	return a
+}
+
+// NotSorted asserts that slice is not in ascending order: some element is
+// less than the one before it. Slices of fewer than two elements are always
+// sorted, so they fail. Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.NotSorted(assert, shuffled)
+func NotSorted[T cmp.Ordered](a *Assert, slice []T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	for i := 1; i < len(slice); i++ {
+		if cmp.Less(slice[i], slice[i-1]) {
+			return a
+		}
+	}
+	a.reportMessagef("expected slice not to be sorted in ascending order\n  slice: %s", formatValue(slice, a.formatOptions))
+	return a
+}
+
+// SortedDescending asserts that slice is in descending order. Equal
+// neighbours are allowed. Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.SortedDescending(assert, scores)
+func SortedDescending[T cmp.Ordered](a *Assert, slice []T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	for i := 1; i < len(slice); i++ {
+		if cmp.Less(slice[i-1], slice[i]) {
+			a.reportUnsorted("descending order", i, a.formatOrdered(slice[i-1]), a.formatOrdered(slice[i]))
+			break
+		}
+	}
+	return a
+}
+
+// SortedBy asserts that slice is ordered by less, which reports whether x
+// must sort before y, as for sort.Slice. Equal neighbours are allowed.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.SortedBy(assert, users, func(x, y User) bool { return x.Name < y.Name })
+func SortedBy[T any](a *Assert, slice []T, less func(x, y T) bool) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	for i := 1; i < len(slice); i++ {
+		if less(slice[i], slice[i-1]) {
+			a.reportUnsorted("the given order", i, formatValue(slice[i-1], a.formatOptions), formatValue(slice[i], a.formatOptions))
+			break
+		}
+	}
+	return a
+}
+
+// Unique asserts that slice contains no duplicate elements. On failure the
+// first duplicated value is reported with the indices of both occurrences.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Unique(assert, orderIDs)
+func Unique[T comparable](a *Assert, slice []T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	seen := make(map[T]int, len(slice))
+	for i, v := range slice {
+		if first, ok := seen[v]; ok {
+			a.reportMessagef("expected slice elements to be unique\n  duplicate: %s\n  indices:   %d and %d",
+				formatValue(v, a.formatOptions), first, i)
+			break
+		}
+		seen[v] = i
+	}
+	return a
+}
+
+// reportUnsorted reports the first out-of-order pair, at indices i-1 and i.
+func (a *Assert) reportUnsorted(order string, i int, prev, next string) {
+	a.reportMessagef("expected slice to be sorted in %s\n  first out-of-order pair at indices %d and %d\n  [%d]: %s\n  [%d]: %s",
+		order, i-1, i, i-1, prev, i, next)
+}
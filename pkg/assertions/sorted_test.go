@@ -0,0 +1,85 @@
+package assertions
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSortedAssertions tests Sorted, NotSorted, SortedDescending, SortedBy and Unique.
+func TestSortedAssertions(t *testing.T) {
+	type user struct {
+		Name string
+		Age  int
+	}
+	byAge := func(x, y user) bool { return x.Age < y.Age }
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"Sorted ints", func(a *Assert) { Sorted(a, []int{1, 2, 2, 5}) }, true, ""},
+		{"Sorted empty", func(a *Assert) { Sorted(a, []string{}) }, true, ""},
+		{"Sorted strings", func(a *Assert) { Sorted(a, []string{"alpha", "beta", "gamma"}) }, true, ""},
+		{"Sorted durations", func(a *Assert) { Sorted(a, []time.Duration{time.Millisecond, time.Second}) }, true, ""},
+		{"Sorted NaN first", func(a *Assert) { Sorted(a, []float64{math.NaN(), 1, 2}) }, true, ""},
+		{"Sorted fails", func(a *Assert) { Sorted(a, []int{1, 3, 7, 5, 9}) }, false,
+			"expected slice to be sorted in ascending order\n  first out-of-order pair at indices 2 and 3\n  [2]: 7\n  [3]: 5"},
+		{"Sorted strings fails", func(a *Assert) { Sorted(a, []string{"b", "a"}) }, false, "[0]: \"b\"\n  [1]: \"a\""},
+
+		{"NotSorted", func(a *Assert) { NotSorted(a, []int{1, 3, 2}) }, true, ""},
+		{"NotSorted fails", func(a *Assert) { NotSorted(a, []int{1, 2, 2}) }, false,
+			"expected slice not to be sorted in ascending order\n  slice: []int{1, 2, 2}"},
+		{"NotSorted single element fails", func(a *Assert) { NotSorted(a, []string{"a"}) }, false, "not to be sorted"},
+
+		{"SortedDescending", func(a *Assert) { SortedDescending(a, []float64{9.5, 3, 3, -1}) }, true, ""},
+		{"SortedDescending fails", func(a *Assert) { SortedDescending(a, []int{3, 2, 4}) }, false,
+			"expected slice to be sorted in descending order\n  first out-of-order pair at indices 1 and 2"},
+
+		{"SortedBy", func(a *Assert) { SortedBy(a, []user{{"Ann", 20}, {"Bob", 30}}, byAge) }, true, ""},
+		{"SortedBy fails", func(a *Assert) { SortedBy(a, []user{{"Ann", 40}, {"Bob", 30}}, byAge) }, false,
+			"expected slice to be sorted in the given order\n  first out-of-order pair at indices 0 and 1"},
+
+		{"Unique", func(a *Assert) { Unique(a, []string{"a", "b", "c"}) }, true, ""},
+		{"Unique fails", func(a *Assert) { Unique(a, []int{4, 8, 15, 16, 8}) }, false,
+			"expected slice elements to be unique\n  duplicate: 8\n  indices:   1 and 4"},
+
+		{"IsSorted deprecated", func(a *Assert) { a.IsSorted([]int{2, 1}) }, false, "first out-of-order pair at indices 0 and 1"},
+		{"IsSortedFloat64 deprecated", func(a *Assert) { a.IsSortedFloat64([]float64{1.5, 2.5}) }, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// ExampleSorted demonstrates the sorted and uniqueness assertions.
+func ExampleSorted() {
+	t := &silentT{}
+	assert := New(t)
+
+	ids := []int{101, 102, 105, 110}
+	Sorted(assert, ids)
+	Unique(assert, ids)
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
@@ -0,0 +1,84 @@
+package assertions
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// UseStackTraces makes failures include the call stack that led to the
+// failing assertion, in the message and in the Failure's Stack. GoWise's own
+// frames and the testing package's are left out, so the stack starts at the
+// call of the assertion, in a helper or deep in a call chain, and ends in
+// the test.
+func UseStackTraces(enabled bool) Option {
+	return func(a *Assert) { a.stackTraces = enabled }
+}
+
+// WithStackTraces returns a new Assert whose failures include a trimmed call
+// stack, as set by UseStackTraces.
+//
+// Example:
+//
+//	assert := assertions.New(t).WithStackTraces(true)
+//	checkInvoice(assert, invoice) // a failure in the helper names it and its caller
+//	// invoice total differs
+//	//   got:  120
+//	//   want: 100
+//	//   stack:
+//	//     example.com/shop/billing_test.checkInvoice
+//	//         /src/shop/billing/invoice_test.go:42
+//	//     example.com/shop/billing_test.TestMonthlyInvoices
+//	//         /src/shop/billing/invoice_test.go:18
+//
+//funcgen:skip
+func (a *Assert) WithStackTraces(enabled bool) *Assert {
+	return a.With(UseStackTraces(enabled))
+}
+
+// StackFrame is one call in the stack of a Failure.
+type StackFrame struct {
+	Function string `json:"function"` // Qualified function name, such as "example.com/shop.TestCheckout"
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// maxStackFrames bounds the stack captured for UseStackTraces.
+const maxStackFrames = 64
+
+// callStack returns the call stack of the failing assertion's caller, skip
+// frames above it, without GoWise's frames above the first call from outside
+// it, and without the testing package's and runtime's frames below the test.
+func callStack(skip int) []StackFrame {
+	pcs := make([]uintptr, maxStackFrames)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip+1, pcs)])
+
+	var stack []StackFrame
+	for {
+		frame, more := frames.Next()
+		inLibrary := strings.HasPrefix(frame.Function, libraryPrefix) && !strings.HasSuffix(frame.File, "_test.go")
+		switch {
+		case len(stack) == 0 && inLibrary:
+		case strings.HasPrefix(frame.Function, "testing.") || strings.HasPrefix(frame.Function, "runtime."):
+			return stack
+		default:
+			stack = append(stack, StackFrame{frame.Function, frame.File, frame.Line})
+		}
+		if !more {
+			return stack
+		}
+	}
+}
+
+// withStack wraps message to end the report with stack.
+func withStack(message func() string, stack []StackFrame) func() string {
+	return func() string {
+		var b strings.Builder
+		b.WriteString(message())
+		b.WriteString("\n  stack:")
+		for _, frame := range stack {
+			fmt.Fprintf(&b, "\n    %s\n        %s:%d", frame.Function, frame.File, frame.Line)
+		}
+		return b.String()
+	}
+}
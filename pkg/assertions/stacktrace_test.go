@@ -0,0 +1,98 @@
+package assertions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// checkTotal and checkInvoice are helpers a test calls through, as
+// integration tests do, for the stack to show.
+func checkTotal(a *Assert, got, want int) {
+	a.Equal(got, want)
+}
+
+func checkInvoice(a *Assert, total int) {
+	checkTotal(a, total, 100)
+}
+
+// TestStackTraces tests that failures carry the trimmed stack only when
+// enabled.
+func TestStackTraces(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		checkInvoice(New(mock).WithStackTraces(true), 120)
+
+		if len(mock.errorCalls) != 1 {
+			t.Fatalf("Expected one failure, got %v", mock.errorCalls)
+		}
+		message := mock.errorCalls[0]
+		helper := strings.Index(message, "\n    gowise/pkg/assertions.checkTotal\n        ")
+		caller := strings.Index(message, "\n    gowise/pkg/assertions.checkInvoice\n        ")
+		if !strings.Contains(message, "values differ\n  got:  120\n  want: 100\n  stack:") || helper < 0 || caller < helper {
+			t.Errorf("Expected the stack from the helper to its caller, got:\n%s", message)
+		}
+		for _, hidden := range []string{"(*Assert).Equal", "failWith", "testing.tRunner", "runtime.goexit"} {
+			if strings.Contains(message, hidden) {
+				t.Errorf("Expected %s to be trimmed from the stack, got:\n%s", hidden, message)
+			}
+		}
+	})
+
+	t.Run("structured failure", func(t *testing.T) {
+		assert := New(&recordingT{}).WithStackTraces(true)
+		checkInvoice(assert, 120)
+
+		f := assert.LastFailure()
+		if f == nil || len(f.Stack) < 3 {
+			t.Fatalf("Expected a failure with the stack down to the test, got %+v", f)
+		}
+		if f.Stack[0].Function != "gowise/pkg/assertions.checkTotal" || !strings.HasSuffix(f.Stack[0].File, "stacktrace_test.go") || f.Stack[0].Line == 0 {
+			t.Errorf("Expected the innermost frame to be the helper, got %+v", f.Stack[0])
+		}
+		if last := f.Stack[len(f.Stack)-1]; !strings.Contains(last.Function, "TestStackTraces") {
+			t.Errorf("Expected the outermost frame to be the test, got %+v", last)
+		}
+
+		f.Stack[0].Line = -1
+		if assert.LastFailure().Stack[0].Line == -1 {
+			t.Error("Expected LastFailure to return a copy of the stack")
+		}
+
+		encoded, err := json.Marshal(f)
+		if err != nil || !strings.Contains(string(encoded), `"stack":[{"function":"gowise/pkg/assertions.checkTotal"`) {
+			t.Errorf("Expected the stack in the JSON encoding, got %s (%v)", encoded, err)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		mock := &behaviorMockT{}
+		assert := New(mock)
+		checkInvoice(assert, 120)
+
+		if strings.Contains(mock.errorCalls[0], "stack:") || assert.LastFailure().Stack != nil {
+			t.Errorf("Expected no stack without WithStackTraces, got:\n%s", mock.errorCalls[0])
+		}
+	})
+}
+
+// ExampleAssert_WithStackTraces shows where a failure in a helper came from.
+func ExampleAssert_WithStackTraces() {
+	mock := &behaviorMockT{}
+	checkInvoice(New(mock).WithStackTraces(true), 120)
+
+	for _, line := range strings.Split(mock.errorCalls[0], "\n") {
+		if !strings.HasPrefix(line, "        ") { // Skip file paths, which vary by checkout
+			fmt.Println(line)
+		}
+	}
+	// Output:
+	// values differ
+	//   got:  120
+	//   want: 100
+	//   stack:
+	//     gowise/pkg/assertions.checkTotal
+	//     gowise/pkg/assertions.checkInvoice
+	//     gowise/pkg/assertions.ExampleAssert_WithStackTraces
+}
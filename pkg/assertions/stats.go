@@ -0,0 +1,372 @@
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MaxSlowest is the number of slowest assertions a Stats snapshot keeps.
+const MaxSlowest = 5
+
+// Stats is a snapshot of the assertions evaluated since statistics collection
+// was enabled: across every Assert in the process for GlobalStats, or across
+// one Assert and those derived from it for Assert.Stats.
+type Stats struct {
+	Total       int64                     // Assertions evaluated, excluding skipped ones
+	Failed      int64                     // Assertions that reported a failure
+	Skipped     int64                     // Assertions skipped by fail-fast after an earlier failure
+	ByAssertion map[string]AssertionStats // Counts keyed by assertion name, e.g. "Equal"
+	Slowest     []TimedAssertion          // Longest waiting assertions, slowest first, at most MaxSlowest
+}
+
+// TimedAssertion records how long one evaluation of a waiting assertion, such
+// as Eventually, WithinTimeout or ReceivedWithin, took.
+type TimedAssertion struct {
+	Name     string
+	Duration time.Duration
+}
+
+// AssertionStats holds the counts for a single kind of assertion.
+type AssertionStats struct {
+	Total   int64
+	Failed  int64
+	Skipped int64
+}
+
+// Names returns the assertion names in Stats.ByAssertion in sorted order.
+func (s Stats) Names() []string {
+	names := make([]string, 0, len(s.ByAssertion))
+	for name := range s.ByAssertion {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Metadata flattens the snapshot into string pairs suitable for report
+// metadata: "assertions.total", "assertions.failed", "assertions.skipped" and,
+// for each assertion, "assertions.<Name>.total" and so on.
+//
+// Example:
+//
+//	for key, value := range assertions.GlobalStats().Metadata() {
+//		report.AddMetadata(key, value)
+//	}
+func (s Stats) Metadata() map[string]string {
+	metadata := make(map[string]string, 3+3*len(s.ByAssertion))
+	metadata["assertions.total"] = strconv.FormatInt(s.Total, 10)
+	metadata["assertions.failed"] = strconv.FormatInt(s.Failed, 10)
+	metadata["assertions.skipped"] = strconv.FormatInt(s.Skipped, 10)
+	for name, counts := range s.ByAssertion {
+		prefix := "assertions." + name + "."
+		metadata[prefix+"total"] = strconv.FormatInt(counts.Total, 10)
+		metadata[prefix+"failed"] = strconv.FormatInt(counts.Failed, 10)
+		metadata[prefix+"skipped"] = strconv.FormatInt(counts.Skipped, 10)
+	}
+	if len(s.Slowest) > 0 {
+		metadata["assertions.slowest"] = s.describeSlowest()
+	}
+	return metadata
+}
+
+// Summary describes the snapshot in one line, for a test log or a reporter
+// message.
+//
+// Example:
+//
+//	fmt.Println(assert.Stats().Summary())
+//	// 12 assertions: 11 passed, 1 failed, 2 skipped; slowest: Eventually 1.2s, ReceivedWithin 30ms
+func (s Stats) Summary() string {
+	summary := fmt.Sprintf("%d assertions: %d passed, %d failed, %d skipped", s.Total, s.Total-s.Failed, s.Failed, s.Skipped)
+	if len(s.Slowest) > 0 {
+		summary += "; slowest: " + s.describeSlowest()
+	}
+	return summary
+}
+
+// describeSlowest lists the slowest assertions with their durations.
+func (s Stats) describeSlowest() string {
+	parts := make([]string, len(s.Slowest))
+	for i, timed := range s.Slowest {
+		parts[i] = timed.Name + " " + humaniseDuration(timed.Duration)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// statsEnabled gates collection, so that suites which never ask for
+// statistics pay a single atomic load per assertion.
+var statsEnabled atomic.Bool
+
+// globalStats holds the counters for every Assert in the process.
+var globalStats = newStatsCollector()
+
+type assertionCounters struct {
+	total, failed, skipped atomic.Int64
+}
+
+// statsCollector counts assertions by name. Counters are created under the
+// lock and updated atomically, so concurrent tests only contend when they
+// evaluate an assertion kind for the first time, or finish a waiting one.
+type statsCollector struct {
+	mu       sync.RWMutex
+	counters map[string]*assertionCounters
+	slowest  []TimedAssertion // Slowest first, at most MaxSlowest
+}
+
+func newStatsCollector() *statsCollector {
+	return &statsCollector{counters: make(map[string]*assertionCounters)}
+}
+
+func (c *statsCollector) lookup(name string) *assertionCounters {
+	c.mu.RLock()
+	counters, ok := c.counters[name]
+	c.mu.RUnlock()
+	if ok {
+		return counters
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if counters, ok = c.counters[name]; !ok {
+		counters = &assertionCounters{}
+		c.counters[name] = counters
+	}
+	return counters
+}
+
+// count records an evaluated or skipped assertion.
+func (c *statsCollector) count(name string, skipped bool) {
+	counters := c.lookup(name)
+	if skipped {
+		counters.skipped.Add(1)
+	} else {
+		counters.total.Add(1)
+	}
+}
+
+// observe records how long an assertion took, keeping the MaxSlowest longest.
+func (c *statsCollector) observe(name string, d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	i := sort.Search(len(c.slowest), func(i int) bool { return c.slowest[i].Duration < d })
+	if i == MaxSlowest {
+		return
+	}
+	c.slowest = append(c.slowest, TimedAssertion{})
+	copy(c.slowest[i+1:], c.slowest[i:])
+	c.slowest[i] = TimedAssertion{Name: name, Duration: d}
+	if len(c.slowest) > MaxSlowest {
+		c.slowest = c.slowest[:MaxSlowest]
+	}
+}
+
+// snapshot returns the statistics collected so far.
+func (c *statsCollector) snapshot() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := Stats{ByAssertion: make(map[string]AssertionStats, len(c.counters))}
+	for name, counters := range c.counters {
+		counts := AssertionStats{
+			Total:   counters.total.Load(),
+			Failed:  counters.failed.Load(),
+			Skipped: counters.skipped.Load(),
+		}
+		stats.ByAssertion[name] = counts
+		stats.Total += counts.Total
+		stats.Failed += counts.Failed
+		stats.Skipped += counts.Skipped
+	}
+	stats.Slowest = append([]TimedAssertion(nil), c.slowest...)
+	return stats
+}
+
+// UseStats makes an Assert collect statistics of its own, whether or not
+// EnableStats is on: the assertions evaluated, failed and skipped, by name,
+// and the slowest waiting assertions. Asserts derived from it, with With or
+// For, add to the same statistics, so a parent Assert's Stats cover its
+// subtests. Read them with Assert.Stats.
+//
+// Example:
+//
+//	assert := assertions.New(t).With(assertions.UseStats())
+//	t.Cleanup(func() {
+//		if assert.Stats().Total == 0 {
+//			t.Error("test made no assertions")
+//		}
+//	})
+func UseStats() Option {
+	return func(a *Assert) { a.stats = newStatsCollector() }
+}
+
+// Stats returns a snapshot of the statistics collected for a and the Asserts
+// sharing its statistics since UseStats was applied. Without UseStats the
+// snapshot is empty. Safe to call while assertions are running in other
+// goroutines.
+func (a *Assert) Stats() Stats {
+	if a.stats == nil {
+		return Stats{ByAssertion: map[string]AssertionStats{}}
+	}
+	return a.stats.snapshot()
+}
+
+// EnableStats starts collecting assertion statistics for the whole process.
+// Collection is off by default; enable it once, typically from TestMain:
+//
+//	func TestMain(m *testing.M) {
+//		assertions.EnableStats()
+//		code := m.Run()
+//		fmt.Println(assertions.GlobalStats().Total, "assertions")
+//		os.Exit(code)
+//	}
+func EnableStats() {
+	statsEnabled.Store(true)
+}
+
+// DisableStats stops collecting assertion statistics. Counts gathered so far
+// are kept until ResetStats is called.
+func DisableStats() {
+	statsEnabled.Store(false)
+}
+
+// ResetStats discards all collected statistics.
+func ResetStats() {
+	globalStats.mu.Lock()
+	defer globalStats.mu.Unlock()
+	globalStats.counters = make(map[string]*assertionCounters)
+	globalStats.slowest = nil
+}
+
+// GlobalStats returns a snapshot of the statistics collected so far.
+// Safe to call while assertions are running in other goroutines.
+func GlobalStats() Stats {
+	return globalStats.snapshot()
+}
+
+// recordAssertion counts an assertion as evaluated, or as skipped when the
+// chain has already failed, globally when EnableStats is on and in local, the
+// Assert's own statistics, unless it is nil. Assertions built from other
+// assertions, such as InDelta on top of WithinTolerance, are counted once
+// under the outer name.
+func recordAssertion(local *statsCollector, skipped bool) {
+	global := statsEnabled.Load()
+	if !global && local == nil {
+		return
+	}
+	name, outermost := assertionName()
+	if name == "" || !outermost {
+		return
+	}
+	if global {
+		globalStats.count(name, skipped)
+	}
+	if local != nil {
+		local.count(name, skipped)
+	}
+}
+
+// recordFailure counts a failure against the assertion being evaluated.
+func recordFailure(local *statsCollector) {
+	global := statsEnabled.Load()
+	if !global && local == nil {
+		return
+	}
+	name, _ := assertionName()
+	if name == "" {
+		return
+	}
+	if global {
+		globalStats.lookup(name).failed.Add(1)
+	}
+	if local != nil {
+		local.lookup(name).failed.Add(1)
+	}
+}
+
+// recordDuration records the time since start against the waiting assertion
+// being evaluated. Waiting assertions defer it on entry:
+//
+//	defer a.recordDuration(time.Now())
+func (a *Assert) recordDuration(start time.Time) {
+	global := statsEnabled.Load()
+	if !global && a.stats == nil {
+		return
+	}
+	name, _ := assertionName()
+	if name == "" {
+		return
+	}
+	d := time.Since(start)
+	if global {
+		globalStats.observe(name, d)
+	}
+	if a.stats != nil {
+		a.stats.observe(name, d)
+	}
+}
+
+// packagePrefix is the qualified name prefix of functions in this package,
+// e.g. "gowise/pkg/assertions.".
+var packagePrefix = func() string {
+	name := runtime.FuncForPC(reflect.ValueOf(New).Pointer()).Name()
+	return name[:strings.LastIndex(name, ".")+1]
+}()
+
+// assertionName walks the stack to find the public assertion being
+// evaluated: the outermost exported function of this package in the run of
+// frames above the caller. It also reports whether that function is the
+// innermost exported one, which is false while an assertion delegates to
+// another.
+func assertionName() (name string, outermost bool) {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	return assertionNameIn(pcs[:n])
+}
+
+// assertionNameIn is assertionName for the stack captured in pcs.
+func assertionNameIn(pcs []uintptr) (name string, outermost bool) {
+	frames := runtime.CallersFrames(pcs)
+
+	innermost := ""
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) || strings.HasSuffix(frame.File, "_test.go") {
+			break
+		}
+		if exported := exportedName(frame.Function[len(packagePrefix):]); exported != "" {
+			if innermost == "" {
+				innermost = exported
+			}
+			name = exported
+		}
+		if !more {
+			break
+		}
+	}
+	return name, name == innermost
+}
+
+// exportedName extracts the function or method name from a symbol relative to
+// the package, e.g. "(*Assert).Equal" or "Greater[...]", returning "" for
+// unexported functions and closures.
+func exportedName(symbol string) string {
+	symbol = strings.TrimPrefix(symbol, "(*Assert).")
+	if i := strings.IndexAny(symbol, ".["); i >= 0 {
+		if symbol[i] == '.' {
+			return "" // closure or method of another type
+		}
+		symbol = symbol[:i]
+	}
+	if symbol == "" || symbol[0] < 'A' || symbol[0] > 'Z' {
+		return ""
+	}
+	return symbol
+}
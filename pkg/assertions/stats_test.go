@@ -0,0 +1,234 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// collectStats enables statistics from a clean slate for the duration of a test.
+func collectStats(t *testing.T) {
+	t.Helper()
+	ResetStats()
+	EnableStats()
+	t.Cleanup(func() {
+		DisableStats()
+		ResetStats()
+	})
+}
+
+// TestStatsCountsAssertions tests totals, failures and per-assertion counts.
+func TestStatsCountsAssertions(t *testing.T) {
+	collectStats(t)
+
+	New(&behaviorMockT{}).Equal(1, 1).True(true).Equal("a", "a")
+	New(&behaviorMockT{}).Equal(1, 2).Equal(3, 3)
+	New(&behaviorMockT{}).Contains([]int{1}, 2)
+
+	stats := GlobalStats()
+	if stats.Total != 5 || stats.Failed != 2 || stats.Skipped != 1 {
+		t.Errorf("Expected total 5, failed 2, skipped 1, got %+v", stats)
+	}
+
+	want := map[string]AssertionStats{
+		"Equal":    {Total: 3, Failed: 1, Skipped: 1},
+		"True":     {Total: 1},
+		"Contains": {Total: 1, Failed: 1},
+	}
+	for name, counts := range want {
+		if got := stats.ByAssertion[name]; got != counts {
+			t.Errorf("Expected %s counts %+v, got %+v", name, counts, got)
+		}
+	}
+	if len(stats.ByAssertion) != len(want) {
+		t.Errorf("Expected assertions %v, got %v", len(want), stats.Names())
+	}
+}
+
+// TestStatsCountsDelegatingAssertionsOnce tests that assertions built on other
+// assertions are counted once, under the name the caller used.
+func TestStatsCountsDelegatingAssertionsOnce(t *testing.T) {
+	collectStats(t)
+
+	assert := New(&behaviorMockT{})
+	assert.InDelta(1.0, 1.05, 0.1)
+	assert.DeepDiff(map[string]int{"a": 1}, map[string]int{"a": 1})
+	Greater(assert, 2, 1)
+	assert.Greater(2, 1)
+
+	stats := GlobalStats()
+	if stats.Total != 4 {
+		t.Errorf("Expected 4 assertions, got %d: %v", stats.Total, stats.Names())
+	}
+	for _, name := range []string{"InDelta", "DeepDiff"} {
+		if stats.ByAssertion[name].Total != 1 {
+			t.Errorf("Expected %s to be counted once, got %+v", name, stats.ByAssertion)
+		}
+	}
+	if stats.ByAssertion["Greater"].Total != 2 {
+		t.Errorf("Expected Greater to be counted twice, got %+v", stats.ByAssertion)
+	}
+}
+
+// TestStatsDisabledByDefault tests that nothing is collected unless enabled.
+func TestStatsDisabledByDefault(t *testing.T) {
+	ResetStats()
+
+	New(&behaviorMockT{}).Equal(1, 2)
+
+	if stats := GlobalStats(); stats.Total != 0 || stats.Failed != 0 {
+		t.Errorf("Expected no statistics while disabled, got %+v", stats)
+	}
+}
+
+// TestStatsConcurrentAssertions tests that counts are exact under concurrent
+// use; run with -race to check the collector itself.
+func TestStatsConcurrentAssertions(t *testing.T) {
+	collectStats(t)
+
+	const goroutines, perGoroutine = 8, 100
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				New(&behaviorMockT{}).Equal(j, j%50)
+				_ = GlobalStats()
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := GlobalStats()
+	if stats.Total != goroutines*perGoroutine {
+		t.Errorf("Expected %d assertions, got %d", goroutines*perGoroutine, stats.Total)
+	}
+	if stats.Failed != goroutines*perGoroutine/2 {
+		t.Errorf("Expected %d failures, got %d", goroutines*perGoroutine/2, stats.Failed)
+	}
+}
+
+// TestStatsMetadata tests the flattened form used in report metadata.
+func TestStatsMetadata(t *testing.T) {
+	collectStats(t)
+
+	New(&behaviorMockT{}).Equal(1, 2)
+
+	metadata := GlobalStats().Metadata()
+	want := map[string]string{
+		"assertions.total":         "1",
+		"assertions.failed":        "1",
+		"assertions.skipped":       "0",
+		"assertions.Equal.total":   "1",
+		"assertions.Equal.failed":  "1",
+		"assertions.Equal.skipped": "0",
+	}
+	for key, value := range want {
+		if metadata[key] != value {
+			t.Errorf("Expected %s=%s, got %q", key, value, metadata[key])
+		}
+	}
+}
+
+// TestAssertStats tests statistics collected by a single Assert with
+// UseStats, independently of the global collector.
+func TestAssertStats(t *testing.T) {
+	ResetStats()
+
+	assert := New(&behaviorMockT{}).With(UseStats())
+	other := New(&behaviorMockT{}).With(UseStats())
+	assert.Equal(1, 1).True(true)
+	assert.With(UseDiffs(false)).Contains([]int{1}, 2).Equal(1, 1)
+	assert.For(&behaviorMockT{}).Equal("a", "a")
+	other.Equal(1, 1)
+
+	stats := assert.Stats()
+	if stats.Total != 4 || stats.Failed != 1 || stats.Skipped != 1 {
+		t.Errorf("Expected total 4, failed 1, skipped 1, got %+v", stats)
+	}
+	if got := stats.ByAssertion["Equal"]; got != (AssertionStats{Total: 2, Skipped: 1}) {
+		t.Errorf("Expected derived Asserts to share counts, got Equal %+v", got)
+	}
+	if got := other.Stats().Total; got != 1 {
+		t.Errorf("Expected another Assert's statistics to be separate, got %d", got)
+	}
+	if got := GlobalStats().Total; got != 0 {
+		t.Errorf("Expected UseStats not to enable global statistics, got %d", got)
+	}
+	if stats := New(&behaviorMockT{}).Stats(); stats.Total != 0 || stats.ByAssertion == nil {
+		t.Errorf("Expected empty statistics without UseStats, got %+v", stats)
+	}
+}
+
+// TestStatsSlowest tests that waiting assertions are timed and the slowest
+// kept, slowest first.
+func TestStatsSlowest(t *testing.T) {
+	assert := New(&behaviorMockT{}).With(UseStats())
+	assert.Equal(1, 1)
+	assert.WithinTimeout(func() { time.Sleep(20 * time.Millisecond) }, time.Second)
+	assert.Eventually(func() bool { return true }, time.Second, time.Millisecond)
+	for range MaxSlowest {
+		assert.WithinTimeout(func() {}, time.Second)
+	}
+
+	slowest := assert.Stats().Slowest
+	if len(slowest) != MaxSlowest {
+		t.Fatalf("Expected %d slowest assertions, got %+v", MaxSlowest, slowest)
+	}
+	if slowest[0].Name != "WithinTimeout" || slowest[0].Duration < 20*time.Millisecond {
+		t.Errorf("Expected the sleeping WithinTimeout first, got %+v", slowest[0])
+	}
+	for i := 1; i < len(slowest); i++ {
+		if slowest[i].Duration > slowest[i-1].Duration {
+			t.Errorf("Expected slowest first, got %+v", slowest)
+		}
+	}
+	for _, timed := range slowest {
+		if timed.Name == "Equal" {
+			t.Errorf("Expected only waiting assertions to be timed, got %+v", slowest)
+		}
+	}
+}
+
+// TestStatsSummary tests the one-line description used in reports.
+func TestStatsSummary(t *testing.T) {
+	stats := Stats{Total: 12, Failed: 1, Skipped: 2, Slowest: []TimedAssertion{
+		{Name: "Eventually", Duration: 1200 * time.Millisecond},
+		{Name: "ReceivedWithin", Duration: 30 * time.Millisecond},
+	}}
+	want := "12 assertions: 11 passed, 1 failed, 2 skipped; slowest: Eventually 1.2s, ReceivedWithin 30ms"
+	if got := stats.Summary(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got := stats.Metadata()["assertions.slowest"]; got != "Eventually 1.2s, ReceivedWithin 30ms" {
+		t.Errorf("Expected slowest assertions in metadata, got %q", got)
+	}
+	if got := (Stats{}).Summary(); !strings.HasPrefix(got, "0 assertions") {
+		t.Errorf("Expected a summary of no assertions, got %q", got)
+	}
+}
+
+// ExampleGlobalStats demonstrates collecting assertion statistics for a suite.
+func ExampleGlobalStats() {
+	ResetStats()
+	EnableStats()
+	defer DisableStats()
+
+	assert := New(&silentT{})
+	assert.Equal(2+2, 4)
+	assert.True(len("gowise") == 6)
+	assert.Equal("got", "want")
+
+	stats := GlobalStats()
+	fmt.Println("Total:", stats.Total, "Failed:", stats.Failed)
+	for _, name := range stats.Names() {
+		fmt.Printf("%s: %+v\n", name, stats.ByAssertion[name])
+	}
+	// Output:
+	// Total: 3 Failed: 1
+	// Equal: {Total:2 Failed:1 Skipped:0}
+	// True: {Total:1 Failed:0 Skipped:0}
+}
@@ -375,3 +375,21 @@ func (t *capturingT) Fatalf(format string, args ...interface{}) {
 	t.failed = true
 	// We don't actually log since our assertions use Error() method
 }
+
+// TestMultiLineDiffReportsEveryHunk tests that failures list all differing hunks, not just the first.
+func TestMultiLineDiffReportsEveryHunk(t *testing.T) {
+	mock := &behaviorMockT{}
+	assert := New(mock)
+
+	assert.Equal("a\nb\nc\nd\ne\nf", "a\nB\nc\nd\ne\nF")
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	errorMsg := mock.errorCalls[0]
+	for _, want := range []string{"difference at line 2", "2 hunks differ at lines 2, 6", "unified diff:", "@@ -6,1 +6,1 @@"} {
+		if !strings.Contains(errorMsg, want) {
+			t.Errorf("Expected error message to contain %q, got:\n%s", want, errorMsg)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package assertions
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// TempDir returns a new directory for the test's files, removed when the
+// test finishes. With a testing context that has its own TempDir, as
+// *testing.T does, it is that; otherwise it is created under os.TempDir and
+// removed through the context's Cleanup, or left in place if the context has
+// none. If the directory cannot be created, a fails and TempDir returns "".
+//
+// Example:
+//
+//	dir := assert.TempDir()
+//	writeConfig(dir)
+//	assert.DirContainsFile(dir, "config.yaml")
+func (a *Assert) TempDir() string {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if t, ok := a.t.(interface{ TempDir() string }); ok {
+		return t.TempDir()
+	}
+	dir, err := os.MkdirTemp("", "gowise-"+sanitiseFileName(a.testName())+"-*")
+	if err != nil {
+		a.countAssertion()
+		a.reportMessagef("TempDir: cannot create directory: %v", err)
+		return ""
+	}
+	if t, ok := a.t.(interface{ Cleanup(func()) }); ok {
+		t.Cleanup(func() { os.RemoveAll(dir) })
+	}
+	return dir
+}
+
+// TempFile creates a file holding content in a new TempDir, named by pattern
+// as os.CreateTemp names files, and returns its path. The file is removed
+// with the directory when the test finishes. If the file cannot be written, a
+// fails and TempFile returns "".
+//
+// Example:
+//
+//	path := assert.TempFile("config-*.yaml", []byte("port: 8080\n"))
+//	cfg, err := LoadConfig(path)
+//	assert.NoError(err).Equal(cfg.Port, 8080)
+func (a *Assert) TempFile(pattern string, content []byte) string {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	dir := a.TempDir()
+	if dir == "" {
+		return ""
+	}
+	file, err := os.CreateTemp(dir, pattern)
+	if err == nil {
+		_, err = file.Write(content)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		a.countAssertion()
+		a.reportMessagef("TempFile: cannot write %s: %v", filepath.Join(dir, pattern), err)
+		return ""
+	}
+	return file.Name()
+}
+
+// testName returns the name of the test a reports to, or "test" if the
+// testing context has none.
+func (a *Assert) testName() string {
+	if t, ok := a.t.(interface{ Name() string }); ok {
+		return t.Name()
+	}
+	return "test"
+}
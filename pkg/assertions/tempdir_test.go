@@ -0,0 +1,84 @@
+package assertions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cleanupT is a testing context with Cleanup but no TempDir of its own.
+type cleanupT struct {
+	behaviorMockT
+	cleanups []func()
+}
+
+func (c *cleanupT) Cleanup(f func()) { c.cleanups = append(c.cleanups, f) }
+
+// runCleanups runs the registered cleanups, last first, as testing does.
+func (c *cleanupT) runCleanups() {
+	for i := len(c.cleanups) - 1; i >= 0; i-- {
+		c.cleanups[i]()
+	}
+}
+
+// TestTempDirUsesTestingTempDir tests that a *testing.T provides the
+// directory.
+func TestTempDirUsesTestingTempDir(t *testing.T) {
+	dir := New(t).TempDir()
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Fatalf("Expected a directory at %q, got %v", dir, err)
+	}
+	if !strings.Contains(dir, "TestTempDirUsesTestingTempDir") {
+		t.Errorf("Expected the testing package's directory, got %q", dir)
+	}
+}
+
+// TestTempDirRemovedOnCleanup tests that contexts with Cleanup but no
+// TempDir have the directory removed when the test finishes.
+func TestTempDirRemovedOnCleanup(t *testing.T) {
+	ct := &cleanupT{}
+	assert := New(ct)
+	path := assert.TempFile("config-*.yaml", []byte("port: 8080\n"))
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "port: 8080\n" {
+		t.Fatalf("Expected the file written, got %q and %v", data, err)
+	}
+	if base := filepath.Base(path); !strings.HasPrefix(base, "config-") || !strings.HasSuffix(base, ".yaml") {
+		t.Errorf("Expected the file named by the pattern, got %q", base)
+	}
+
+	ct.runCleanups()
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Errorf("Expected the directory removed on cleanup, got %v", err)
+	}
+	if len(ct.errorCalls) != 0 {
+		t.Errorf("Expected no failures, got %v", ct.errorCalls)
+	}
+}
+
+// TestTempDirWithoutCleanup tests that contexts without Cleanup still get a
+// directory, left for the caller.
+func TestTempDirWithoutCleanup(t *testing.T) {
+	dir := New(&behaviorMockT{}).TempDir()
+	defer os.RemoveAll(dir)
+
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Errorf("Expected a directory at %q, got %v", dir, err)
+	}
+}
+
+// TestTempFileFailure tests that a file that cannot be created fails the
+// chain.
+func TestTempFileFailure(t *testing.T) {
+	ct := &cleanupT{}
+	defer ct.runCleanups()
+
+	if path := New(ct).TempFile("nested/config-*", nil); path != "" {
+		t.Errorf("Expected no path, got %q", path)
+	}
+	if len(ct.errorCalls) != 1 || !strings.Contains(ct.errorCalls[0], "TempFile: cannot write") {
+		t.Errorf("Expected a TempFile failure, got %v", ct.errorCalls)
+	}
+}
@@ -0,0 +1,128 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// whitespaceLegend explains the markers substituted by markWhitespace.
+const whitespaceLegend = "whitespace shown as · (space) and → (tab)"
+
+// whitespaceMarker substitutes visible markers for spaces and tabs.
+var whitespaceMarker = strings.NewReplacer(" ", "·", "\t", "→")
+
+// markWhitespace makes spaces and tabs visible so that failures caused by
+// runs of whitespace can be read from the diff. Newlines are kept so that
+// multi-line values still get a line-based diff.
+func markWhitespace(s string) string {
+	return whitespaceMarker.Replace(s)
+}
+
+// EqualFold asserts that got and want are equal under Unicode case folding,
+// as strings.EqualFold reports. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.EqualFold(resp.Header.Get("Content-Type"), "application/JSON")
+func (a *Assert) EqualFold(got, want string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !strings.EqualFold(got, want) {
+		// A character diff would stop at the first case difference, so report the folded position instead
+		a.reportMessagef("strings are not equal ignoring case\n  first difference at character %d\n  got:  %s\n  want: %s",
+			foldDifference(got, want)+1, formatString(got, a.formatOptions), formatString(want, a.formatOptions))
+	}
+	return a
+}
+
+// EqualIgnoringWhitespace asserts that got and want contain the same words,
+// treating any run of whitespace, including newlines, as a single separator and
+// ignoring leading and trailing whitespace. Whitespace is marked in the failure
+// diff. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.EqualIgnoringWhitespace(renderedSQL, "SELECT id FROM users WHERE active")
+func (a *Assert) EqualIgnoringWhitespace(got, want string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if detail := wordDifference(strings.Fields(got), strings.Fields(want)); detail != "" {
+		a.reportWhitespaceError(got, want, "strings are not equal ignoring whitespace\n  "+detail)
+	}
+	return a
+}
+
+// EqualTrimmed asserts that got and want are equal once leading and trailing
+// whitespace is removed, as strings.TrimSpace does. Whitespace is marked in
+// the failure diff. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.EqualTrimmed(string(output), "done")
+func (a *Assert) EqualTrimmed(got, want string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if strings.TrimSpace(got) != strings.TrimSpace(want) {
+		a.reportWhitespaceError(strings.TrimSpace(got), strings.TrimSpace(want), "strings are not equal after trimming surrounding whitespace")
+	}
+	return a
+}
+
+// reportWhitespaceError reports a string mismatch with spaces and tabs made visible.
+func (a *Assert) reportWhitespaceError(got, want, message string) {
+	a.reportErrorConsistent(markWhitespace(got), markWhitespace(want), message+"\n  "+whitespaceLegend)
+}
+
+// foldDifference returns the index, in characters, of the first position at
+// which got and want differ under case folding.
+func foldDifference(got, want string) int {
+	index := 0
+	for got != "" && want != "" {
+		g, gSize := utf8.DecodeRuneInString(got)
+		w, wSize := utf8.DecodeRuneInString(want)
+		if !strings.EqualFold(string(g), string(w)) {
+			break
+		}
+		got, want = got[gSize:], want[wSize:]
+		index++
+	}
+	return index
+}
+
+// wordDifference describes the first word at which got and want differ, or
+// returns "" when the word lists are equal.
+func wordDifference(got, want []string) string {
+	for i := 0; i < len(got) || i < len(want); i++ {
+		switch {
+		case i >= len(got):
+			return fmt.Sprintf("missing word %d: %q", i+1, want[i])
+		case i >= len(want):
+			return fmt.Sprintf("unexpected word %d: %q", i+1, got[i])
+		case got[i] != want[i]:
+			return fmt.Sprintf("word %d differs: got %q, want %q", i+1, got[i], want[i])
+		}
+	}
+	return ""
+}
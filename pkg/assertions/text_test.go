@@ -0,0 +1,95 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestTextEqualityAssertions tests the case- and whitespace-insensitive string comparisons.
+func TestTextEqualityAssertions(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"EqualFold same case", func(a *Assert) { a.EqualFold("gowise", "gowise") }, true, ""},
+		{"EqualFold different case", func(a *Assert) { a.EqualFold("Content-Type", "content-type") }, true, ""},
+		{"EqualFold unicode", func(a *Assert) { a.EqualFold("STRASSE Ω", "strasse ω") }, true, ""},
+		{"EqualFold differs", func(a *Assert) { a.EqualFold("GoWise", "gowize") }, false, "first difference at character 5"},
+
+		{"IgnoringWhitespace collapses runs", func(a *Assert) { a.EqualIgnoringWhitespace("SELECT  id\n\tFROM users ", "SELECT id FROM users") }, true, ""},
+		{"IgnoringWhitespace differing word", func(a *Assert) { a.EqualIgnoringWhitespace("SELECT id FROM user", "SELECT id FROM users") }, false, `word 4 differs: got "user", want "users"`},
+		{"IgnoringWhitespace missing word", func(a *Assert) { a.EqualIgnoringWhitespace("a b", "a b c") }, false, `missing word 3: "c"`},
+		{"IgnoringWhitespace unexpected word", func(a *Assert) { a.EqualIgnoringWhitespace("a b c", "a b") }, false, `unexpected word 3: "c"`},
+		{"IgnoringWhitespace joined words", func(a *Assert) { a.EqualIgnoringWhitespace("helloworld", "hello world") }, false, "strings are not equal ignoring whitespace"},
+
+		{"EqualTrimmed surrounding whitespace", func(a *Assert) { a.EqualTrimmed("\n  done\t\n", "done") }, true, ""},
+		{"EqualTrimmed inner whitespace", func(a *Assert) { a.EqualTrimmed("a  b", "a b") }, false, "strings are not equal after trimming surrounding whitespace"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestWhitespaceMarkersInDiff tests that whitespace runs are visible in failure output.
+func TestWhitespaceMarkersInDiff(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).EqualTrimmed("key:\tvalue  x", "key: value x")
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	message := mock.errorCalls[0]
+	for _, expected := range []string{"key:→value··x", "key:·value·x", whitespaceLegend} {
+		if !strings.Contains(message, expected) {
+			t.Errorf("Expected message containing %q, got: %s", expected, message)
+		}
+	}
+}
+
+// TestWhitespaceMarkersMultiLine tests that marked multi-line values keep their line diff.
+func TestWhitespaceMarkersMultiLine(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).EqualIgnoringWhitespace("line one\nline  two\n", "line one\nline three\n")
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+	}
+	if !strings.Contains(mock.errorCalls[0], "difference at line 2") || !strings.Contains(mock.errorCalls[0], "- line··two") {
+		t.Errorf("Expected line diff with whitespace markers, got: %s", mock.errorCalls[0])
+	}
+}
+
+// ExampleAssert_EqualIgnoringWhitespace demonstrates comparing templated output.
+func ExampleAssert_EqualIgnoringWhitespace() {
+	t := &silentT{}
+	assert := New(t)
+
+	rendered := `
+		<ul>
+		  <li>gowise</li>
+		</ul>`
+	assert.EqualIgnoringWhitespace(rendered, "<ul> <li>gowise</li> </ul>").
+		EqualFold("GoWise", "gowise").
+		EqualTrimmed("  done\n", "done")
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
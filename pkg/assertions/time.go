@@ -0,0 +1,167 @@
+package assertions
+
+import (
+	"fmt"
+	"time"
+)
+
+// formatTime renders a timestamp without its monotonic clock reading, which
+// only matters within a single process and clutters failure output.
+func formatTime(t time.Time) string {
+	return t.Round(0).Format(time.RFC3339Nano)
+}
+
+// TimeEqual asserts that got and want represent the same instant.
+// Monotonic clock readings and locations are ignored, so a time read from
+// time.Now compares equal to the same instant parsed from a string.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.TimeEqual(event.CreatedAt, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC))
+func (a *Assert) TimeEqual(got, want time.Time) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !got.Round(0).Equal(want.Round(0)) {
+		a.reportMessagef("times differ\n  got:        %s\n  want:       %s\n  difference: %s",
+			formatTime(got), formatTime(want), got.Round(0).Sub(want.Round(0)))
+	}
+	return a
+}
+
+// Before asserts that got is strictly before bound.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.Before(token.IssuedAt, token.ExpiresAt)
+func (a *Assert) Before(got, bound time.Time) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !got.Before(bound) {
+		a.reportMessagef("expected time to be before bound\n  got:   %s\n  bound: %s (%s later)",
+			formatTime(got), formatTime(bound), got.Sub(bound))
+	}
+	return a
+}
+
+// After asserts that got is strictly after bound.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.After(order.ShippedAt, order.PlacedAt)
+func (a *Assert) After(got, bound time.Time) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !got.After(bound) {
+		a.reportMessagef("expected time to be after bound\n  got:   %s\n  bound: %s (%s earlier)",
+			formatTime(got), formatTime(bound), bound.Sub(got))
+	}
+	return a
+}
+
+// WithinWindow asserts that start <= got <= end.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	before := time.Now()
+//	record := store.Save(item)
+//	assert.WithinWindow(record.UpdatedAt, before, time.Now())
+func (a *Assert) WithinWindow(got, start, end time.Time) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	var outside string
+	switch {
+	case got.Before(start):
+		outside = fmt.Sprintf("%s before start", start.Sub(got))
+	case got.After(end):
+		outside = fmt.Sprintf("%s after end", got.Sub(end))
+	default:
+		return a
+	}
+
+	a.reportMessagef("expected time to be within window (%s)\n  got:   %s\n  start: %s\n  end:   %s",
+		outside, formatTime(got), formatTime(start), formatTime(end))
+	return a
+}
+
+// SameDate asserts that got falls on the same calendar date as want.
+// Both are compared in want's location, so a timestamp late in the evening in
+// one zone can fall on the following date in another.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.SameDate(invoice.IssuedAt, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC))
+func (a *Assert) SameDate(got, want time.Time) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	gotYear, gotMonth, gotDay := got.In(want.Location()).Date()
+	wantYear, wantMonth, wantDay := want.Date()
+	if gotYear != wantYear || gotMonth != wantMonth || gotDay != wantDay {
+		a.reportMessagef("expected the same date in %s\n  got:  %04d-%02d-%02d (%s)\n  want: %04d-%02d-%02d (%s)",
+			want.Location(), gotYear, gotMonth, gotDay, formatTime(got), wantYear, wantMonth, wantDay, formatTime(want))
+	}
+	return a
+}
+
+// DurationBetween asserts that the time elapsed from start to end lies within
+// [min, max]. A negative elapsed time, where end precedes start, fails unless
+// min allows it. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.DurationBetween(job.StartedAt, job.FinishedAt, time.Second, 5*time.Second)
+func (a *Assert) DurationBetween(start, end time.Time, min, max time.Duration) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if elapsed := end.Sub(start); elapsed < min || elapsed > max {
+		a.reportMessagef("expected elapsed time between %s and %s\n  elapsed: %s\n  start:   %s\n  end:     %s",
+			min, max, elapsed, formatTime(start), formatTime(end))
+	}
+	return a
+}
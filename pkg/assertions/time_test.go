@@ -0,0 +1,89 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTimeAssertions tests the time.Time assertion suite.
+func TestTimeAssertions(t *testing.T) {
+	base := time.Date(2024, 3, 31, 22, 30, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"TimeEqual same instant", func(a *Assert) { a.TimeEqual(base, base.In(tokyo)) }, true, ""},
+		{"TimeEqual strips monotonic", func(a *Assert) {
+			now := time.Now()
+			a.TimeEqual(now, now.Round(0))
+		}, true, ""},
+		{"TimeEqual differs", func(a *Assert) { a.TimeEqual(base, base.Add(time.Second)) }, false,
+			"got:        2024-03-31T22:30:00Z\n  want:       2024-03-31T22:30:01Z\n  difference: -1s"},
+
+		{"Before", func(a *Assert) { a.Before(base, base.Add(time.Nanosecond)) }, true, ""},
+		{"Before equal fails", func(a *Assert) { a.Before(base, base) }, false, "expected time to be before bound"},
+		{"After", func(a *Assert) { a.After(base.Add(time.Minute), base) }, true, ""},
+		{"After fails", func(a *Assert) { a.After(base, base.Add(time.Minute)) }, false, "(1m0s earlier)"},
+
+		{"WithinWindow inside", func(a *Assert) { a.WithinWindow(base, base.Add(-time.Hour), base.Add(time.Hour)) }, true, ""},
+		{"WithinWindow inclusive", func(a *Assert) { a.WithinWindow(base, base, base) }, true, ""},
+		{"WithinWindow early", func(a *Assert) { a.WithinWindow(base, base.Add(time.Hour), base.Add(2*time.Hour)) }, false, "1h0m0s before start"},
+		{"WithinWindow late", func(a *Assert) { a.WithinWindow(base, base.Add(-2*time.Hour), base.Add(-time.Hour)) }, false, "1h0m0s after end"},
+
+		{"SameDate", func(a *Assert) { a.SameDate(base, base.Add(-22*time.Hour)) }, true, ""},
+		{"SameDate other zone", func(a *Assert) { a.SameDate(base, time.Date(2024, 4, 1, 0, 0, 0, 0, tokyo)) }, true, ""},
+		{"SameDate differs", func(a *Assert) { a.SameDate(base, time.Date(2024, 3, 31, 0, 0, 0, 0, tokyo)) }, false,
+			"got:  2024-04-01"},
+
+		{"DurationBetween", func(a *Assert) { a.DurationBetween(base, base.Add(2*time.Second), time.Second, 5*time.Second) }, true, ""},
+		{"DurationBetween too long", func(a *Assert) { a.DurationBetween(base, base.Add(time.Minute), time.Second, 5*time.Second) }, false,
+			"expected elapsed time between 1s and 5s\n  elapsed: 1m0s"},
+		{"DurationBetween negative", func(a *Assert) { a.DurationBetween(base, base.Add(-time.Second), 0, time.Second) }, false, "elapsed: -1s"},
+
+		{"IsWithinDuration either direction", func(a *Assert) { a.IsWithinDuration(base, base.Add(time.Second), 2*time.Second) }, true, ""},
+		{"IsWithinDuration shows both times", func(a *Assert) { a.IsWithinDuration(base, base.Add(-time.Minute), time.Second) }, false,
+			"got:        2024-03-31T22:30:00Z\n  want:       2024-03-31T22:29:00Z"},
+		{"IsWithinDuration earlier fails", func(a *Assert) { a.IsWithinDuration(base, base.Add(time.Minute), time.Second) }, false, "difference: -1m0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// ExampleAssert_WithinWindow demonstrates checking a timestamp set during a test.
+func ExampleAssert_WithinWindow() {
+	t := &silentT{}
+	assert := New(t)
+
+	start := time.Now()
+	updatedAt := time.Now()
+	end := time.Now()
+
+	assert.WithinWindow(updatedAt, start, end).
+		TimeEqual(updatedAt, updatedAt.UTC()).
+		DurationBetween(start, end, 0, time.Minute)
+
+	fmt.Println("Failed:", t.failed)
+	// Output: Failed: false
+}
@@ -0,0 +1,143 @@
+package assertions
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strings"
+	"time"
+)
+
+// tlsDialTimeout bounds each connection ServesTLSVersionAtLeast makes.
+const tlsDialTimeout = 10 * time.Second
+
+// CertificateValidFor asserts that cert is valid for host, a DNS name or IP
+// address, by its subject alternative names as x509.Certificate.VerifyHostname
+// checks them; wildcard names match a single label. The chain and validity
+// period are not checked. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	cert := resp.TLS.PeerCertificates[0]
+//	assert.CertificateValidFor(cert, "api.example.com").CertificateExpiresAfter(cert, 30*24*time.Hour)
+func (a *Assert) CertificateValidFor(cert *x509.Certificate, host string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if cert == nil {
+		a.reportMessagef("cannot check a nil certificate")
+		return a
+	}
+	if err := cert.VerifyHostname(host); err != nil {
+		a.reportMessagef("expected certificate to be valid for %s\n  subject: %s\n  names: %s",
+			host, cert.Subject, certificateNames(cert))
+	}
+	return a
+}
+
+// CertificateExpiresAfter asserts that cert remains valid for at least d
+// from now, as in a smoke test that fails while there is still time to renew.
+// Returns *Assert to enable method chaining.
+func (a *Assert) CertificateExpiresAfter(cert *x509.Certificate, d time.Duration) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if cert == nil {
+		a.reportMessagef("cannot check a nil certificate")
+		return a
+	}
+	now := time.Now()
+	if !cert.NotAfter.After(now.Add(d)) {
+		remaining := "remaining: " + humaniseDuration(cert.NotAfter.Sub(now))
+		if !cert.NotAfter.After(now) {
+			remaining = "expired: " + humaniseDuration(now.Sub(cert.NotAfter)) + " ago"
+		}
+		a.reportMessagef("expected certificate to remain valid for %s\n  subject: %s\n  not after: %s\n  %s",
+			humaniseDuration(d), cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339), remaining)
+	}
+	return a
+}
+
+// ServesTLSVersionAtLeast asserts that the server at addr, a host:port,
+// negotiates version or later with a client offering every version, and
+// refuses a client that offers only earlier ones. version is a constant
+// such as tls.VersionTLS12. The server's certificate is not verified; check
+// it with CertificateValidFor. Each connection must be made within ten
+// seconds. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.ServesTLSVersionAtLeast("api.example.com:443", tls.VersionTLS12)
+func (a *Assert) ServesTLSVersionAtLeast(addr string, version uint16) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	negotiated, err := negotiateTLS(addr, tls.VersionTLS10, tls.VersionTLS13)
+	if err != nil {
+		a.reportMessagef("error connecting over TLS\n  address: %s\n  error: %v", addr, err)
+		return a
+	}
+	if negotiated < version {
+		a.reportMessagef("expected %s to serve %s or later\n  negotiated: %s",
+			addr, tls.VersionName(version), tls.VersionName(negotiated))
+		return a
+	}
+	if version > tls.VersionTLS10 {
+		if older, err := negotiateTLS(addr, tls.VersionTLS10, version-1); err == nil {
+			a.reportMessagef("expected %s to refuse versions before %s\n  accepted: %s",
+				addr, tls.VersionName(version), tls.VersionName(older))
+		}
+	}
+	return a
+}
+
+// negotiateTLS completes a handshake with addr offering the versions from
+// minVersion to maxVersion, and returns the version the server chose.
+func negotiateTLS(addr string, minVersion, maxVersion uint16) (uint16, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: tlsDialTimeout},
+		Config: &tls.Config{
+			MinVersion: minVersion,
+			MaxVersion: maxVersion,
+			// Only the protocol version is under test; CertificateValidFor checks the certificate
+			InsecureSkipVerify: true,
+		},
+	}
+	conn, err := dialer.Dial("tcp", addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	return conn.(*tls.Conn).ConnectionState().Version, nil
+}
+
+// certificateNames lists the DNS names and IP addresses a certificate is
+// valid for.
+func certificateNames(cert *x509.Certificate) string {
+	names := append([]string{}, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+	if len(names) == 0 {
+		return "(none)"
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,172 @@
+package assertions
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// selfSigned returns a certificate for names, valid until notAfter.
+func selfSigned(t testing.TB, notAfter time.Time, names ...string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gowise test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	for _, name := range names {
+		if ip := net.ParseIP(name); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// tlsServer starts a TLS server accepting the versions from minVersion to
+// maxVersion and returns its address.
+func tlsServer(t *testing.T, minVersion, maxVersion uint16) string {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	srv.TLS = &tls.Config{MinVersion: minVersion, MaxVersion: maxVersion}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv.Listener.Addr().String()
+}
+
+// TestCertificateAssertions tests CertificateValidFor and
+// CertificateExpiresAfter.
+func TestCertificateAssertions(t *testing.T) {
+	inAYear := time.Now().Add(365 * 24 * time.Hour)
+	cert := selfSigned(t, inAYear, "example.com", "*.api.example.com", "10.0.0.1")
+	expired := selfSigned(t, time.Now().Add(-48*time.Hour), "example.com")
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"valid for DNS name", func(a *Assert) { a.CertificateValidFor(cert, "example.com") }, true, ""},
+		{"valid for wildcard", func(a *Assert) { a.CertificateValidFor(cert, "eu.api.example.com") }, true, ""},
+		{"valid for IP address", func(a *Assert) { a.CertificateValidFor(cert, "10.0.0.1") }, true, ""},
+		{"wildcard matches one label", func(a *Assert) { a.CertificateValidFor(cert, "a.eu.api.example.com") }, false,
+			"expected certificate to be valid for a.eu.api.example.com\n  subject: CN=gowise test\n  names: example.com, *.api.example.com, 10.0.0.1"},
+		{"other host", func(a *Assert) { a.CertificateValidFor(cert, "example.org") }, false,
+			"expected certificate to be valid for example.org"},
+		{"expires after", func(a *Assert) { a.CertificateExpiresAfter(cert, 30*24*time.Hour) }, true, ""},
+		{"expires too soon", func(a *Assert) { a.CertificateExpiresAfter(cert, 400*24*time.Hour) }, false,
+			"expected certificate to remain valid for 9600h0m0s\n  subject: CN=gowise test\n  not after: " +
+				inAYear.UTC().Format(time.RFC3339) + "\n  remaining: "},
+		{"already expired", func(a *Assert) { a.CertificateExpiresAfter(expired, 0) }, false, "\n  expired: 48h0m0s ago"},
+		{"nil certificate", func(a *Assert) { a.CertificateValidFor(nil, "example.com") }, false, "cannot check a nil certificate"},
+		{"fail-fast", func(a *Assert) {
+			a.CertificateExpiresAfter(expired, 0).CertificateValidFor(cert, "example.org")
+		}, false, "expected certificate to remain valid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// TestServesTLSVersionAtLeast tests the negotiated version and the refusal
+// of earlier versions.
+func TestServesTLSVersionAtLeast(t *testing.T) {
+	modern := tlsServer(t, tls.VersionTLS12, tls.VersionTLS13)
+	only12 := tlsServer(t, tls.VersionTLS12, tls.VersionTLS12)
+	only13 := tlsServer(t, tls.VersionTLS13, tls.VersionTLS13)
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"refuses earlier versions", func(a *Assert) { a.ServesTLSVersionAtLeast(modern, tls.VersionTLS12) }, true, ""},
+		{"TLS 1.3 only", func(a *Assert) { a.ServesTLSVersionAtLeast(only13, tls.VersionTLS13) }, true, ""},
+		{"accepts an earlier version", func(a *Assert) { a.ServesTLSVersionAtLeast(modern, tls.VersionTLS13) }, false,
+			"expected " + modern + " to refuse versions before TLS 1.3\n  accepted: TLS 1.2"},
+		{"negotiates an earlier version", func(a *Assert) { a.ServesTLSVersionAtLeast(only12, tls.VersionTLS13) }, false,
+			"expected " + only12 + " to serve TLS 1.3 or later\n  negotiated: TLS 1.2"},
+		{"not listening", func(a *Assert) { a.ServesTLSVersionAtLeast("127.0.0.1:0", tls.VersionTLS12) }, false,
+			"error connecting over TLS\n  address: 127.0.0.1:0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 {
+				t.Fatalf("Assertion should fail once, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			}
+			if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected message containing:\n%s\ngot:\n%s", tt.expectMessage, mock.errorCalls[0])
+			}
+		})
+	}
+}
+
+// ExampleAssert_CertificateValidFor reports the names a certificate covers.
+func ExampleAssert_CertificateValidFor() {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "shop"},
+		DNSNames: []string{"shop.example.com", "www.shop.example.com"},
+	}
+
+	mock := &behaviorMockT{}
+	New(mock).CertificateValidFor(cert, "shop.example.com").CertificateValidFor(cert, "admin.shop.example.com")
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// expected certificate to be valid for admin.shop.example.com
+	//   subject: CN=shop
+	//   names: shop.example.com, www.shop.example.com
+}
@@ -0,0 +1,82 @@
+package assertions
+
+import (
+	"strconv"
+	"time"
+)
+
+// WithinDelta asserts that got is within delta of want, in their own type.
+// Integers, including named types such as time.Duration, are compared
+// exactly, without the precision lost by converting to float64, and without
+// overflow however far apart got and want are. Floats follow InDelta, so NaN
+// is within no delta. Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.WithinDelta(assert, stats.Requests, int64(10_000), 50)
+func WithinDelta[T Number](a *Assert, got, want, delta T) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if !numberWithinDelta(got, want, delta) {
+		a.reportMessagef("expected value to be within delta of want\n  got:   %s\n  want:  %s\n  delta: %s\n  diff:  %s",
+			a.formatOrdered(got), a.formatOrdered(want), a.formatOrdered(delta), formatDistance(a, got, want))
+	}
+	return a
+}
+
+// DurationWithin asserts that got is within tolerance of want, for latency
+// and timeout checks. It is WithinDelta for time.Duration.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.DurationWithin(elapsed, 100*time.Millisecond, 20*time.Millisecond)
+func (a *Assert) DurationWithin(got, want, tolerance time.Duration) *Assert {
+	a.countAssertion()
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	WithinDelta(a.delegate(), got, want, tolerance)
+	return a
+}
+
+// numberWithinDelta reports whether got and want differ by at most delta.
+func numberWithinDelta[T Number](got, want, delta T) bool {
+	if isFloatType[T]() {
+		return withinDelta(float64(got), float64(want), float64(delta))
+	}
+	return got == want || (delta >= 0 && integerDistance(got, want) <= uint64(delta))
+}
+
+// isFloatType reports whether T is a floating-point type: integer division
+// truncates 1/2 to zero, float division does not.
+func isFloatType[T Number]() bool {
+	var one T = 1
+	return one/2 != 0
+}
+
+// integerDistance returns |got - want| for integer types. Two's complement
+// subtraction in uint64 is exact once the larger value comes first, even
+// where the difference overflows T.
+func integerDistance[T Number](got, want T) uint64 {
+	return uint64(max(got, want)) - uint64(min(got, want))
+}
+
+// formatDistance renders |got - want| in their own type where it fits, so a
+// time.Duration reads as one.
+func formatDistance[T Number](a *Assert, got, want T) string {
+	d := max(got, want) - min(got, want)
+	if isFloatType[T]() || (d >= 0 && uint64(d) == integerDistance(got, want)) {
+		return a.formatOrdered(d)
+	}
+	return groupDigits(strconv.FormatUint(integerDistance(got, want), 10), a.formatOptions.Numbers)
+}
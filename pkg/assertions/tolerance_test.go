@@ -0,0 +1,56 @@
+package assertions
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWithinDelta tests WithinDelta across integer, float and duration types,
+// including values too far apart to subtract in their own type.
+func TestWithinDelta(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"int", func(a *Assert) { WithinDelta(a, 1_000_050, 1_000_000, 50) }, true, ""},
+		{"int fails", func(a *Assert) { WithinDelta(a, 1_000_051, 1_000_000, 50) }, false,
+			"expected value to be within delta of want\n  got:   1,000,051\n  want:  1,000,000\n  delta: 50\n  diff:  51"},
+		{"int64 beyond float64 precision", func(a *Assert) {
+			WithinDelta(a, int64(1<<62)+3, int64(1<<62), 2)
+		}, false, "diff:  3"},
+		{"int64 extremes", func(a *Assert) { WithinDelta(a, int64(math.MinInt64), int64(math.MaxInt64), 1) }, false,
+			"diff:  18,446,744,073,709,551,615"},
+		{"int8 across zero", func(a *Assert) { WithinDelta(a, int8(-100), int8(100), 127) }, false, "diff:  200"},
+		{"uint order", func(a *Assert) { WithinDelta(a, uint(3), uint(10), 7) }, true, ""},
+		{"negative delta", func(a *Assert) { WithinDelta(a, 5, 5, -1) }, true, ""},
+		{"negative delta fails", func(a *Assert) { WithinDelta(a, 5, 6, -1) }, false, "delta: -1"},
+		{"float", func(a *Assert) { WithinDelta(a, 0.1+0.2, 0.3, 1e-12) }, true, ""},
+		{"float NaN", func(a *Assert) { WithinDelta(a, math.NaN(), 0, 1) }, false, "within delta"},
+		{"duration", func(a *Assert) { WithinDelta(a, 95*time.Millisecond, 100*time.Millisecond, 5*time.Millisecond) }, true, ""},
+		{"DurationWithin fails", func(a *Assert) {
+			a.DurationWithin(135*time.Millisecond, 100*time.Millisecond, 20*time.Millisecond)
+		}, false, "  got:   135ms\n  want:  100ms\n  delta: 20ms\n  diff:  35ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
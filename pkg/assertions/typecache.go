@@ -0,0 +1,42 @@
+package assertions
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structInfo is the field metadata of a struct type that assertions walk,
+// computed once per type. reflect.Type.Field builds a StructField, with a
+// freshly allocated index slice, on every call, so table-driven tests that
+// compare the same struct type thousands of times would otherwise rebuild it
+// for every field of every comparison.
+//
+// Comparability and nil-ability are not cached: reflect answers them from the
+// type descriptor in constant time, faster than a cache lookup.
+type structInfo struct {
+	names    []string       // Name of every field, by index
+	exported []int          // Indices of the exported fields, in declaration order
+	byName   map[string]int // Index of every field, by name
+}
+
+// structInfoCache maps a struct reflect.Type to its *structInfo.
+var structInfoCache sync.Map
+
+// structInfoFor returns the field metadata of struct type t.
+func structInfoFor(t reflect.Type) *structInfo {
+	if info, ok := structInfoCache.Load(t); ok {
+		return info.(*structInfo)
+	}
+
+	info := &structInfo{names: make([]string, t.NumField()), byName: make(map[string]int, t.NumField())}
+	for i := range info.names {
+		field := t.Field(i)
+		info.names[i] = field.Name
+		info.byName[field.Name] = i
+		if field.IsExported() {
+			info.exported = append(info.exported, i)
+		}
+	}
+	actual, _ := structInfoCache.LoadOrStore(t, info)
+	return actual.(*structInfo)
+}
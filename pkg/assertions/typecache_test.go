@@ -0,0 +1,101 @@
+package assertions
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// order is a struct with a mix of exported and unexported fields, as the
+// values of a table-driven test might be.
+type order struct {
+	ID       int
+	Customer string
+	total    float64
+	Items    []string
+	Shipped  bool
+	note     string
+}
+
+// TestStructInfoFor tests that the cached metadata lists field names and the
+// exported fields in declaration order, and is shared by concurrent callers.
+func TestStructInfoFor(t *testing.T) {
+	typ := reflect.TypeOf(order{})
+
+	var wg sync.WaitGroup
+	infos := make([]*structInfo, 8)
+	for i := range infos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			infos[i] = structInfoFor(typ)
+		}()
+	}
+	wg.Wait()
+
+	info := infos[0]
+	if want := []string{"ID", "Customer", "total", "Items", "Shipped", "note"}; !slices.Equal(info.names, want) {
+		t.Errorf("Expected names %v, got %v", want, info.names)
+	}
+	if want := []int{0, 1, 3, 4}; !slices.Equal(info.exported, want) {
+		t.Errorf("Expected exported indices %v, got %v", want, info.exported)
+	}
+	for _, other := range infos[1:] {
+		if other != info {
+			t.Fatal("Expected every caller to get the same cached metadata")
+		}
+	}
+}
+
+// TestStructDiffUsesFieldNames tests that StructDiff still names the first
+// differing exported field.
+func TestStructDiffUsesFieldNames(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).StructDiff(order{ID: 1, total: 1, Shipped: true}, order{ID: 1, total: 2, Shipped: false})
+
+	if len(mock.errorCalls) != 1 || !strings.HasPrefix(mock.errorCalls[0], `structs differ at field "Shipped"`) {
+		t.Errorf("Expected a difference at Shipped, ignoring unexported total, got %v", mock.errorCalls)
+	}
+}
+
+// BenchmarkStructFieldMetadata compares walking a struct's exported fields
+// through the cache with building each reflect.StructField, as StructDiff did
+// before the cache.
+func BenchmarkStructFieldMetadata(b *testing.B) {
+	typ := reflect.TypeOf(order{})
+
+	b.Run("Cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for _, i := range structInfoFor(typ).exported {
+				_ = i
+			}
+		}
+	})
+
+	b.Run("Uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for i := 0; i < typ.NumField(); i++ {
+				if field := typ.Field(i); field.IsExported() {
+					_ = field.Name
+				}
+			}
+		}
+	})
+}
+
+// BenchmarkStructDiff measures the success path of StructDiff on a struct
+// type compared repeatedly, as in a table-driven test.
+func BenchmarkStructDiff(b *testing.B) {
+	got := order{ID: 7, Customer: "Ada", Items: []string{"tea"}, Shipped: true}
+	want := got
+	assert := New(&mockT{})
+
+	b.ReportAllocs()
+	for b.Loop() {
+		assert.StructDiff(got, want)
+	}
+}
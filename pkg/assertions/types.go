@@ -0,0 +1,103 @@
+package assertions
+
+import "reflect"
+
+// Type assertions are generic package-level functions, like the channel
+// assertions, so that the type under test is a type parameter rather than a
+// value standing in for it:
+//
+//	file := assertions.IsType[*os.File](assert, resource)
+//	assertions.Implements[io.Closer](assert, resource)
+
+// IsType asserts that value holds a T, as the type assertion value.(T)
+// would, and returns it. T may be an interface, in which case any value
+// implementing it passes. On failure it returns the zero value of T and
+// names both types.
+//
+// Example:
+//
+//	pathErr := assertions.IsType[*fs.PathError](assert, errors.Unwrap(err))
+//	assert.Equal(pathErr.Op, "open")
+func IsType[T any](a *Assert, value any) T {
+	var zero T
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return zero
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		a.reportMessagef("value has the wrong type\n  got:  %s\n  want: %s", typeName(value), reflect.TypeFor[T]())
+		return zero
+	}
+	return typed
+}
+
+// Implements asserts that value implements the interface T, without the
+// (*io.Reader)(nil) idiom the Implements method needs. It fails if T is not
+// an interface type. Returns a to enable method chaining.
+//
+// Example:
+//
+//	assertions.Implements[io.ReadCloser](assert, resp.Body)
+func Implements[T any](a *Assert, value any) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	interfaceType := reflect.TypeFor[T]()
+	if interfaceType.Kind() != reflect.Interface {
+		a.reportMessagef("Implements needs an interface type, got %s", interfaceType)
+		return a
+	}
+	if a.rejectNil(value, "interface implementation") {
+		return a
+	}
+	if _, ok := value.(T); !ok {
+		a.reportMessagef("expected to implement interface\n  type:      %T\n  interface: %s", value, interfaceType)
+	}
+	return a
+}
+
+// IsKind asserts that value's reflect.Kind is kind, for checks that hold
+// across many types, such as "is a pointer" or "is a map".
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.IsKind(cfg.Handler, reflect.Func)
+func (a *Assert) IsKind(value interface{}, kind reflect.Kind) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if a.rejectNil(value, "kind") {
+		return a
+	}
+	if got := reflect.TypeOf(value).Kind(); got != kind {
+		a.reportMessagef("value has the wrong kind\n  got:  %s (%T)\n  want: %s", got, value, kind)
+	}
+	return a
+}
+
+// typeName names the dynamic type of value, or "nil" for an untyped nil.
+func typeName(value any) string {
+	if value == nil {
+		return "nil"
+	}
+	return reflect.TypeOf(value).String()
+}
@@ -0,0 +1,72 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestTypeAssertions tests IsType, Implements and IsKind.
+func TestTypeAssertions(t *testing.T) {
+	var reader io.Reader = strings.NewReader("x")
+	pathErr := &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist}
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"IsType concrete", func(a *Assert) { IsType[*strings.Reader](a, reader) }, true, ""},
+		{"IsType interface", func(a *Assert) { IsType[io.Seeker](a, reader) }, true, ""},
+		{"IsType wrong type", func(a *Assert) { IsType[*fs.PathError](a, errors.New("boom")) }, false,
+			"value has the wrong type\n  got:  *errors.errorString\n  want: *fs.PathError"},
+		{"IsType unimplemented interface", func(a *Assert) { IsType[io.Writer](a, reader) }, false,
+			"  got:  *strings.Reader\n  want: io.Writer"},
+		{"IsType nil", func(a *Assert) { IsType[int](a, nil) }, false, "  got:  nil\n  want: int"},
+
+		{"Implements holds", func(a *Assert) { Implements[io.ReaderAt](a, reader) }, true, ""},
+		{"Implements fails", func(a *Assert) { Implements[io.Closer](a, reader) }, false,
+			"expected to implement interface\n  type:      *strings.Reader\n  interface: io.Closer"},
+		{"Implements needs interface", func(a *Assert) { Implements[string](a, reader) }, false,
+			"Implements needs an interface type, got string"},
+		{"Implements nil", func(a *Assert) { Implements[io.Reader](a, nil) }, false,
+			"cannot check interface implementation of nil value"},
+
+		{"IsKind holds", func(a *Assert) { a.IsKind(map[string]int{}, reflect.Map) }, true, ""},
+		{"IsKind fails", func(a *Assert) { a.IsKind(pathErr, reflect.Struct) }, false,
+			"value has the wrong kind\n  got:  ptr (*fs.PathError)\n  want: struct"},
+		{"IsKind nil", func(a *Assert) { a.IsKind(nil, reflect.Pointer) }, false, "cannot check kind of nil value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+func ExampleIsType() {
+	assert := New(&silentT{})
+	var err error = &fs.PathError{Op: "open", Path: "config.yaml", Err: fs.ErrNotExist}
+
+	pathErr := IsType[*fs.PathError](assert, err)
+	fmt.Println(pathErr.Path)
+	// Output: config.yaml
+}
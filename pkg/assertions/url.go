@@ -0,0 +1,234 @@
+package assertions
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// parseURL parses rawURL for a URL assertion, reporting a failure if it
+// cannot be parsed.
+func (a *Assert) parseURL(rawURL string) (*url.URL, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		a.reportMessagef("invalid URL\n  url:   %s\n  error: %v", formatString(rawURL, a.formatOptions), unwrapURLError(err))
+		return nil, false
+	}
+	return u, true
+}
+
+// unwrapURLError drops the operation and URL that *url.Error repeats.
+func unwrapURLError(err error) error {
+	if urlErr, ok := err.(*url.Error); ok {
+		return urlErr.Err
+	}
+	return err
+}
+
+// IsValidURL asserts that rawURL parses as an absolute URL: one with a scheme
+// and either a host, as in https://example.com, or an opaque part, as in
+// mailto:ann@example.com. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.IsValidURL(resp.Header.Get("Location"))
+func (a *Assert) IsValidURL(rawURL string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	u, ok := a.parseURL(rawURL)
+	if !ok {
+		return a
+	}
+	switch {
+	case u.Scheme == "":
+		a.reportMessagef("expected an absolute URL\n  url:     %s\n  problem: no scheme", formatString(rawURL, a.formatOptions))
+	case u.Host == "" && u.Opaque == "":
+		a.reportMessagef("expected an absolute URL\n  url:     %s\n  problem: no host", formatString(rawURL, a.formatOptions))
+	}
+	return a
+}
+
+// URLHasScheme asserts that rawURL has the given scheme, compared without
+// regard to case. Returns *Assert to enable method chaining.
+func (a *Assert) URLHasScheme(rawURL, scheme string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkURLComponent(rawURL, "scheme", scheme, func(u *url.URL) string { return u.Scheme }, strings.EqualFold)
+}
+
+// URLHasHost asserts that rawURL has the given host, including any port, as
+// in "api.example.com:8443". Hosts are compared without regard to case.
+// Returns *Assert to enable method chaining.
+func (a *Assert) URLHasHost(rawURL, host string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkURLComponent(rawURL, "host", host, func(u *url.URL) string { return u.Host }, strings.EqualFold)
+}
+
+// URLHasPath asserts that rawURL has the given path after percent-decoding,
+// so "/files/a%20b" has the path "/files/a b". Returns *Assert to enable
+// method chaining.
+//
+// Example:
+//
+//	assert.URLHasPath(redirect, "/login")
+func (a *Assert) URLHasPath(rawURL, path string) *Assert {
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	return a.checkURLComponent(rawURL, "path", path, func(u *url.URL) string { return u.Path }, func(x, y string) bool { return x == y })
+}
+
+// checkURLComponent parses rawURL and compares the component get extracts
+// with want using equal.
+func (a *Assert) checkURLComponent(rawURL, name, want string, get func(*url.URL) string, equal func(x, y string) bool) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	u, ok := a.parseURL(rawURL)
+	if !ok {
+		return a
+	}
+	if got := get(u); !equal(got, want) {
+		a.reportMessagef("expected URL %s %q, got %q\n  url: %s", name, want, got, formatString(rawURL, a.formatOptions))
+	}
+	return a
+}
+
+// URLHasQueryParam asserts that rawURL's query has the parameter key with
+// value, after percent-decoding. A key repeated in the query passes if any of
+// its values matches. Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.URLHasQueryParam(next, "page", "2")
+func (a *Assert) URLHasQueryParam(rawURL, key, value string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	u, ok := a.parseURL(rawURL)
+	if !ok {
+		return a
+	}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		a.reportMessagef("invalid URL query\n  url:   %s\n  error: %v", formatString(rawURL, a.formatOptions), err)
+		return a
+	}
+
+	values, present := query[key]
+	switch {
+	case !present:
+		a.reportMessagef("expected URL query parameter %q\n  url:  %s\n  keys: %s",
+			key, formatString(rawURL, a.formatOptions), formatValue(slices.Sorted(maps.Keys(query)), a.formatOptions))
+	case !slices.Contains(values, value):
+		a.reportMessagef("expected URL query parameter %q to be %q\n  url:    %s\n  values: %s",
+			key, value, formatString(rawURL, a.formatOptions), formatValue(values, a.formatOptions))
+	}
+	return a
+}
+
+// URLEqual asserts that two URLs are equivalent: schemes and hosts are
+// compared without regard to case, paths, user information and fragments
+// after percent-decoding, and queries as parameters regardless of their
+// order, so "?b=2&a=1" equals "?a=1&b=2". Values repeated under one key must
+// appear in the same order. On failure every differing component is listed.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.URLEqual(resp.Header.Get("Location"), "https://example.com/orders?status=open&page=2")
+func (a *Assert) URLEqual(got, want string) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	gotURL, ok := a.parseURL(got)
+	if !ok {
+		return a
+	}
+	wantURL, ok := a.parseURL(want)
+	if !ok {
+		return a
+	}
+
+	if diffs := urlDifferences(gotURL, wantURL); len(diffs) > 0 {
+		a.reportMessagef("URLs differ\n  %s\n\n  got:  %s\n  want: %s",
+			strings.Join(diffs, "\n  "), formatString(got, a.formatOptions), formatString(want, a.formatOptions))
+	}
+	return a
+}
+
+// urlDifferences describes each component in which got and want differ.
+func urlDifferences(got, want *url.URL) []string {
+	var diffs []string
+	component := func(name, g, w string, equal bool) {
+		if !equal {
+			diffs = append(diffs, fmt.Sprintf("%-9s got %q, want %q", name+":", g, w))
+		}
+	}
+
+	component("scheme", got.Scheme, want.Scheme, strings.EqualFold(got.Scheme, want.Scheme))
+	component("user", got.User.String(), want.User.String(), got.User.String() == want.User.String())
+	component("host", got.Host, want.Host, strings.EqualFold(got.Host, want.Host))
+	component("opaque", got.Opaque, want.Opaque, got.Opaque == want.Opaque)
+	component("path", got.Path, want.Path, got.Path == want.Path)
+	component("fragment", got.Fragment, want.Fragment, got.Fragment == want.Fragment)
+
+	gotQuery, gotErr := url.ParseQuery(got.RawQuery)
+	wantQuery, wantErr := url.ParseQuery(want.RawQuery)
+	if gotErr != nil || wantErr != nil {
+		component("query", got.RawQuery, want.RawQuery, got.RawQuery == want.RawQuery)
+		return diffs
+	}
+	keys := slices.Collect(maps.Keys(gotQuery))
+	for k := range wantQuery {
+		if _, ok := gotQuery[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		g, w := gotQuery[k], wantQuery[k]
+		switch {
+		case w == nil:
+			diffs = append(diffs, fmt.Sprintf("query %q: unexpected, got %q", k, g))
+		case g == nil:
+			diffs = append(diffs, fmt.Sprintf("query %q: missing, want %q", k, w))
+		case !slices.Equal(g, w):
+			diffs = append(diffs, fmt.Sprintf("query %q: got %q, want %q", k, g, w))
+		}
+	}
+	return diffs
+}
@@ -0,0 +1,78 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestURLAssertions tests the URL assertions on matching and differing URLs,
+// including percent-encoding and query parameter order.
+func TestURLAssertions(t *testing.T) {
+	const orders = "https://API.example.com:8443/v1/orders/a%20b?status=open&page=2&tag=x&tag=y#top"
+
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"IsValidURL", func(a *Assert) { a.IsValidURL(orders) }, true, ""},
+		{"IsValidURL opaque", func(a *Assert) { a.IsValidURL("mailto:ann@example.com") }, true, ""},
+		{"IsValidURL relative", func(a *Assert) { a.IsValidURL("/v1/orders") }, false,
+			"expected an absolute URL\n  url:     \"/v1/orders\"\n  problem: no scheme"},
+		{"IsValidURL no host", func(a *Assert) { a.IsValidURL("https:///orders") }, false, "problem: no host"},
+		{"IsValidURL unparsable", func(a *Assert) { a.IsValidURL("https://example.com/%zz") }, false,
+			"invalid URL\n  url:   \"https://example.com/%zz\"\n  error: invalid URL escape \"%zz\""},
+
+		{"URLHasScheme", func(a *Assert) { a.URLHasScheme(orders, "HTTPS") }, true, ""},
+		{"URLHasScheme fails", func(a *Assert) { a.URLHasScheme(orders, "http") }, false, `expected URL scheme "http", got "https"`},
+		{"URLHasHost", func(a *Assert) { a.URLHasHost(orders, "api.example.com:8443") }, true, ""},
+		{"URLHasHost fails", func(a *Assert) { a.URLHasHost(orders, "api.example.com") }, false,
+			`expected URL host "api.example.com", got "API.example.com:8443"`},
+		{"URLHasPath decoded", func(a *Assert) { a.URLHasPath(orders, "/v1/orders/a b") }, true, ""},
+		{"URLHasPath fails", func(a *Assert) { a.URLHasPath(orders, "/v1/orders") }, false, `expected URL path "/v1/orders", got "/v1/orders/a b"`},
+
+		{"URLHasQueryParam", func(a *Assert) { a.URLHasQueryParam(orders, "page", "2") }, true, ""},
+		{"URLHasQueryParam repeated", func(a *Assert) { a.URLHasQueryParam(orders, "tag", "y") }, true, ""},
+		{"URLHasQueryParam decoded", func(a *Assert) { a.URLHasQueryParam("https://x.test/?q=caf%C3%A9+au+lait", "q", "café au lait") }, true, ""},
+		{"URLHasQueryParam missing", func(a *Assert) { a.URLHasQueryParam(orders, "sort", "asc") }, false,
+			"expected URL query parameter \"sort\"\n  url:  " + `"` + orders + `"` + "\n  keys: []string{\"page\", \"status\", \"tag\"}"},
+		{"URLHasQueryParam wrong value", func(a *Assert) { a.URLHasQueryParam(orders, "tag", "z") }, false,
+			"expected URL query parameter \"tag\" to be \"z\""},
+
+		{"URLEqual reordered query", func(a *Assert) {
+			a.URLEqual("https://Example.com/a%2Fb?b=2&a=1", "HTTPS://example.com/a%2fb?a=1&b=2")
+		}, true, ""},
+		{"URLEqual encoded path", func(a *Assert) { a.URLEqual("https://x.test/a%20b", "https://x.test/a b") }, true, ""},
+		{"URLEqual components", func(a *Assert) {
+			a.URLEqual("http://x.test/orders?page=2&extra=1#top", "https://x.test/orders?page=3&status=open")
+		}, false, "URLs differ\n" +
+			"  scheme:   got \"http\", want \"https\"\n" +
+			"  fragment: got \"top\", want \"\"\n" +
+			"  query \"extra\": unexpected, got [\"1\"]\n" +
+			"  query \"page\": got [\"2\"], want [\"3\"]\n" +
+			"  query \"status\": missing, want [\"open\"]\n\n" +
+			"  got:  \"http://x.test/orders?page=2&extra=1#top\"\n" +
+			"  want: \"https://x.test/orders?page=3&status=open\""},
+		{"URLEqual repeated value order", func(a *Assert) { a.URLEqual("https://x.test/?t=a&t=b", "https://x.test/?t=b&t=a") }, false,
+			`query "t": got ["a" "b"], want ["b" "a"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected to pass, got %d Errorf calls: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected to fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package assertions
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Verifier is implemented by test doubles, such as spies and mocks, that can
+// check their expectations once the code under test has run.
+type Verifier interface {
+	// Verify returns an error describing any unmet expectations.
+	Verify() error
+}
+
+// VerifyAll registers verifiers to be checked when the test finishes, through
+// the testing context's Cleanup, so that a mock whose expectations were not
+// met fails the test without an explicit call at the end of it. Verification
+// reports through its own failure state, so it runs even when earlier
+// assertions on a have failed. When the testing context has no Cleanup
+// method, verifiers are checked immediately.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	store := mock.NewRecorder()
+//	assert.VerifyAll(store, notifier)
+func (a *Assert) VerifyAll(verifiers ...Verifier) *Assert {
+	a.countAssertion()
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	verifier := a.For(a.t)
+	if t, ok := a.t.(interface{ Cleanup(func()) }); ok {
+		t.Cleanup(func() { verifier.verify(verifiers) })
+	} else {
+		verifier.verify(verifiers)
+	}
+	return a
+}
+
+// verify checks each verifier, reporting every unmet expectation in a
+// single failure.
+func (a *Assert) verify(verifiers []Verifier) {
+	var failures []string
+	for i, v := range verifiers {
+		if err := v.Verify(); err != nil {
+			message := strings.ReplaceAll(err.Error(), "\n", "\n    ")
+			failures = append(failures, fmt.Sprintf("  [%d] %T: %s", i, v, message))
+		}
+	}
+
+	if len(failures) > 0 {
+		a.reportMessagef("expected test doubles to meet their expectations\n  %d of %d failed verification\n%s",
+			len(failures), len(verifiers), strings.Join(failures, "\n"))
+	}
+}
+
+// CallCounter is implemented by spies that count their invocations, such as
+// mock.Spy.
+type CallCounter interface {
+	// CallCount returns the number of calls made so far.
+	CallCount() int
+}
+
+// SpyCalled asserts that spy has been called at least once.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	onEvent := mock.NewSpy(func(e Event) {})
+//	bus.Subscribe(onEvent.Func)
+//	bus.Publish(event)
+//	assert.SpyCalled(onEvent)
+func (a *Assert) SpyCalled(spy CallCounter) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if spy.CallCount() == 0 {
+		a.reportMessagef("expected spy to be called, but it was not\n  spy: %T", spy)
+	}
+	return a
+}
+
+// SpyNotCalled asserts that spy has not been called.
+// Returns *Assert to enable method chaining.
+func (a *Assert) SpyNotCalled(spy CallCounter) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if n := spy.CallCount(); n != 0 {
+		a.reportMessagef("expected spy not to be called, got %s\n  spy: %T", pluralTimes(n), spy)
+	}
+	return a
+}
+
+// SpyCalledTimes asserts that spy has been called exactly expected times.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	assert.SpyCalledTimes(retry, 3)
+func (a *Assert) SpyCalledTimes(spy CallCounter, expected int) *Assert {
+	// Fail-fast: if already failed, return immediately
+	if a.shouldSkipDueToFailure() {
+		return a
+	}
+
+	if t, ok := a.t.(interface{ Helper() }); ok {
+		t.Helper()
+	}
+
+	if n := spy.CallCount(); n != expected {
+		a.reportMessagef("expected spy to be called %s, got %s\n  spy: %T", pluralTimes(expected), pluralTimes(n), spy)
+	}
+	return a
+}
+
+// pluralTimes renders a call count, as in "1 time" or "3 times".
+func pluralTimes(n int) string {
+	if n == 1 {
+		return "1 time"
+	}
+	return fmt.Sprintf("%d times", n)
+}
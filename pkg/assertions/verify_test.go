@@ -0,0 +1,136 @@
+package assertions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// cleanupMockT is a behaviorMockT that collects cleanup functions, run by runCleanups.
+type cleanupMockT struct {
+	behaviorMockT
+	cleanups []func()
+}
+
+func (m *cleanupMockT) Cleanup(f func()) { m.cleanups = append(m.cleanups, f) }
+
+// runCleanups runs the registered cleanups in reverse order, as testing does.
+func (m *cleanupMockT) runCleanups() {
+	for i := len(m.cleanups) - 1; i >= 0; i-- {
+		m.cleanups[i]()
+	}
+}
+
+// fakeDouble is a test double whose verification result is fixed.
+type fakeDouble struct{ err error }
+
+func (d *fakeDouble) Verify() error { return d.err }
+
+// TestVerifyAllAtCleanup tests that verification is deferred to Cleanup.
+func TestVerifyAllAtCleanup(t *testing.T) {
+	mock := &cleanupMockT{}
+	double := &fakeDouble{}
+
+	New(mock).VerifyAll(double)
+	double.err = errors.New("expected Save to be called once, got 0 calls")
+
+	if len(mock.errorCalls) != 0 {
+		t.Fatalf("Expected no verification before cleanup, got: %v", mock.errorCalls)
+	}
+	mock.runCleanups()
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected 1 Errorf call at cleanup, got %d", len(mock.errorCalls))
+	}
+	want := "expected test doubles to meet their expectations\n  1 of 1 failed verification\n  [0] *assertions.fakeDouble: expected Save to be called once, got 0 calls"
+	if mock.errorCalls[0] != want {
+		t.Errorf("Expected message:\n%s\ngot:\n%s", want, mock.errorCalls[0])
+	}
+}
+
+// TestVerifyAllReportsEveryFailure tests that all unmet expectations are
+// reported together, even after an earlier assertion failed.
+func TestVerifyAllReportsEveryFailure(t *testing.T) {
+	mock := &cleanupMockT{}
+	assert := New(mock)
+
+	assert.VerifyAll(
+		&fakeDouble{err: errors.New("first\nsecond")},
+		&fakeDouble{},
+		&fakeDouble{err: errors.New("unexpected call to Delete")},
+	).True(false)
+	mock.runCleanups()
+
+	if len(mock.errorCalls) != 2 {
+		t.Fatalf("Expected the earlier failure and the verification failure, got: %v", mock.errorCalls)
+	}
+	message := mock.errorCalls[1]
+	for _, want := range []string{"2 of 3 failed verification", "[0] *assertions.fakeDouble: first\n    second", "[2] *assertions.fakeDouble: unexpected call to Delete"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected message containing %q, got: %s", want, message)
+		}
+	}
+}
+
+// TestVerifyAllWithoutCleanup tests immediate verification for contexts without Cleanup.
+func TestVerifyAllWithoutCleanup(t *testing.T) {
+	mock := &behaviorMockT{}
+	New(mock).VerifyAll(&fakeDouble{err: errors.New("not called")})
+
+	if len(mock.errorCalls) != 1 {
+		t.Errorf("Expected immediate verification, got %d Errorf calls", len(mock.errorCalls))
+	}
+}
+
+// ExampleAssert_VerifyAll demonstrates verifying test doubles at cleanup.
+func ExampleAssert_VerifyAll() {
+	t := &cleanupMockT{}
+	store := &fakeDouble{}
+
+	New(t).VerifyAll(store)
+	t.runCleanups() // testing runs cleanups when the test finishes
+
+	fmt.Println("Failed:", len(t.errorCalls) > 0)
+	// Output: Failed: false
+}
+
+// countingSpy is a CallCounter with a fixed count.
+type countingSpy int
+
+func (s countingSpy) CallCount() int { return int(s) }
+
+// TestSpyCallCounts tests SpyCalled, SpyNotCalled and SpyCalledTimes.
+func TestSpyCallCounts(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(a *Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"SpyCalled", func(a *Assert) { a.SpyCalled(countingSpy(1)) }, true, ""},
+		{"SpyCalled fails", func(a *Assert) { a.SpyCalled(countingSpy(0)) }, false, "expected spy to be called, but it was not\n  spy: assertions.countingSpy"},
+		{"SpyNotCalled", func(a *Assert) { a.SpyNotCalled(countingSpy(0)) }, true, ""},
+		{"SpyNotCalled fails", func(a *Assert) { a.SpyNotCalled(countingSpy(1)) }, false, "expected spy not to be called, got 1 time"},
+		{"SpyCalledTimes", func(a *Assert) { a.SpyCalledTimes(countingSpy(3), 3) }, true, ""},
+		{"SpyCalledTimes fails", func(a *Assert) { a.SpyCalledTimes(countingSpy(0), 1) }, false, "expected spy to be called 1 time, got 0 times"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			tt.assert(New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Expected assertion to pass, got: %v", mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Expected 1 Errorf call, got %d", len(mock.errorCalls))
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package assertions
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// status is a custom type whose zero value has no special meaning to IsZero.
+type status string
+
+// TestIsZeroAnyType tests IsZero and IsNotZero across numbers, times,
+// structs, pointers, collections and custom types.
+func TestIsZeroAnyType(t *testing.T) {
+	type account struct {
+		ID    int
+		Owner string
+	}
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		london = time.FixedZone("BST", 3600)
+	}
+	id := 7
+
+	tests := []struct {
+		name  string
+		value interface{}
+		zero  bool
+	}{
+		{"nil", nil, true},
+		{"int", 0, true},
+		{"int8", int8(0), true},
+		{"uint16 non-zero", uint16(3), false},
+		{"float64", 0.0, true},
+		{"float32 non-zero", float32(0.5), false},
+		{"complex128", complex(0, 0), true},
+		{"string", "", true},
+		{"string non-zero", "ann", false},
+		{"bool", false, true},
+		{"time", time.Time{}, true},
+		{"time in location", time.Time{}.In(london), true},
+		{"time non-zero", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"duration", time.Duration(0), true},
+		{"struct", account{}, true},
+		{"struct non-zero", account{Owner: "ann"}, false},
+		{"nil pointer", (*account)(nil), true},
+		{"pointer to zero struct", &account{}, false},
+		{"nil slice", []int(nil), true},
+		{"empty slice", []int{}, false},
+		{"nil map", map[string]int(nil), true},
+		{"nil func", (func())(nil), true},
+		{"custom type", status(""), true},
+		{"custom type non-zero", status("shipped"), false},
+		{"array", [2]int{}, true},
+		{"pointer to int", &id, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &behaviorMockT{}
+			New(mock).IsZero(tt.value)
+			if failed := len(mock.errorCalls) > 0; failed == tt.zero {
+				t.Errorf("IsZero(%#v): expected pass=%t, got failures %v", tt.value, tt.zero, mock.errorCalls)
+			}
+
+			mock = &behaviorMockT{}
+			New(mock).IsNotZero(tt.value)
+			if failed := len(mock.errorCalls) > 0; failed != tt.zero {
+				t.Errorf("IsNotZero(%#v): expected pass=%t, got failures %v", tt.value, !tt.zero, mock.errorCalls)
+			}
+		})
+	}
+}
+
+// TestIsZeroMessages tests that failures show the value and, for IsZero, the
+// zero value of its type.
+func TestIsZeroMessages(t *testing.T) {
+	type account struct{ ID int }
+
+	mock := &behaviorMockT{}
+	New(mock).IsZero(account{ID: 7})
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected to be zero") ||
+		!strings.Contains(mock.errorCalls[0], "ID:0") || !strings.Contains(mock.errorCalls[0], "ID:7") {
+		t.Errorf("Expected the value and its zero value, got %v", mock.errorCalls)
+	}
+
+	mock = &behaviorMockT{}
+	New(mock).IsNotZero(status(""))
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected to be not zero") {
+		t.Errorf("Expected a not-zero failure, got %v", mock.errorCalls)
+	}
+}
+
+// TestIsZeroNumbersDoNotAllocate tests that the fast path for numbers makes
+// no allocations when the assertion passes.
+func TestIsZeroNumbersDoNotAllocate(t *testing.T) {
+	assert := New(&mockT{})
+	var zero, one interface{} = 0, 1.5
+
+	if allocs := testing.AllocsPerRun(100, func() { assert.IsZero(zero).IsNotZero(one) }); allocs != 0 {
+		t.Errorf("Expected no allocations, got %g", allocs)
+	}
+}
@@ -0,0 +1,161 @@
+// Package benchassert provides performance assertions for benchmarks and
+// regression tests: bounds on allocations, heap growth and time per call,
+// and comparisons between two implementations.
+//
+// Each function takes the *assertions.Assert to report to, so it takes part
+// in fail-fast chaining like a built-in assertion:
+//
+//	func TestEncodeBudget(t *testing.T) {
+//		assert := assertions.New(t)
+//		benchassert.MaxAllocsPerRun(assert, 0, func() { encode(buf, payload) })
+//		benchassert.CompletesWithinPerOp(assert, func() { encode(buf, payload) }, 50*time.Nanosecond, 10000)
+//		benchassert.FasterThan(assert, func() { encode(buf, payload) }, func() { json.Marshal(payload) }, 0.2)
+//	}
+//
+// Timings vary with the machine and its load, and the race detector slows
+// code several times over, so give timing bounds generous headroom or keep
+// them out of -race runs.
+package benchassert
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gowise/pkg/assertions"
+)
+
+// AllocRuns is the number of calls over which MaxAllocsPerRun averages
+// allocations.
+const AllocRuns = 100
+
+// comparisonRounds is the number of rounds in which FasterThan measures each
+// function, alternating between them. Their fastest rounds are compared, so a
+// pause during one round does not decide the result.
+const comparisonRounds = 5
+
+// roundTarget is the time a measurement round aims to take, long enough for
+// timer resolution not to matter.
+const roundTarget = 10 * time.Millisecond
+
+// MaxAllocsPerRun fails unless fn allocates at most max times per call, on
+// average over AllocRuns calls, as testing.AllocsPerRun measures it. Use 0 to
+// check that a hot path does not allocate.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	benchassert.MaxAllocsPerRun(assert, 0, func() { buf = strconv.AppendInt(buf[:0], 42, 10) })
+func MaxAllocsPerRun(a *assertions.Assert, max float64, fn func()) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	allocs := testing.AllocsPerRun(AllocRuns, fn)
+	if allocs > max {
+		return a.Fail(fmt.Sprintf("expected at most %g allocations per run, got %g (averaged over %d runs)", max, allocs, AllocRuns))
+	}
+	return a
+}
+
+// CompletesWithinPerOp fails unless fn takes at most perOp per call, on
+// average over n calls. A first call, not counted, warms caches and lazy
+// initialisation.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	benchassert.CompletesWithinPerOp(assert, func() { cache.Get("key") }, 50*time.Nanosecond, 100000)
+func CompletesWithinPerOp(a *assertions.Assert, fn func(), perOp time.Duration, n int) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+	if n <= 0 {
+		return a.Fail(fmt.Sprintf("CompletesWithinPerOp needs a positive number of calls, got %d", n))
+	}
+
+	fn()
+	got := timePerOp(fn, n)
+	if got > perOp {
+		return a.Fail(fmt.Sprintf("expected at most %v per call, got %v (averaged over %d calls)", perOp, got, n))
+	}
+	return a
+}
+
+// FasterThan fails unless fnA is faster than fnB by at least margin, a
+// fraction of fnB's time per call: with margin 0.2, fnA must take at most 80%
+// of fnB's time. A margin of 0 requires fnA to be no slower. Both functions
+// are calibrated to run for a comparable time and are measured alternately,
+// so changes in machine load affect both.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	benchassert.FasterThan(assert,
+//		func() { fastParse(input) },
+//		func() { reflectParse(input) },
+//		0.5)
+func FasterThan(a *assertions.Assert, fnA, fnB func(), margin float64) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+	if margin < 0 || margin >= 1 {
+		return a.Fail(fmt.Sprintf("FasterThan needs a margin from 0 up to but excluding 1, got %g", margin))
+	}
+
+	nA, nB := calibrate(fnA), calibrate(fnB)
+	bestA, bestB := time.Duration(-1), time.Duration(-1)
+	for range comparisonRounds {
+		if d := timePerOp(fnA, nA); bestA < 0 || d < bestA {
+			bestA = d
+		}
+		if d := timePerOp(fnB, nB); bestB < 0 || d < bestB {
+			bestB = d
+		}
+	}
+
+	limit := time.Duration(float64(bestB) * (1 - margin))
+	if bestA > limit {
+		return a.Fail(fmt.Sprintf("expected the first function to take at most %v per call (%g%% faster than the second's %v), got %v",
+			limit, margin*100, bestB, bestA))
+	}
+	return a
+}
+
+// calibrate returns how many calls of fn take about roundTarget.
+func calibrate(fn func()) int {
+	n := 1
+	for {
+		start := time.Now()
+		for range n {
+			fn()
+		}
+		elapsed := time.Since(start)
+		if elapsed >= roundTarget/10 || n >= 1<<30 {
+			// Scale to the target from a run long enough to time reliably.
+			if elapsed <= 0 {
+				return n
+			}
+			return max(1, int(float64(n)*float64(roundTarget)/float64(elapsed)))
+		}
+		n *= 2
+	}
+}
+
+// timePerOp returns the mean time of a call of fn over n calls.
+func timePerOp(fn func(), n int) time.Duration {
+	start := time.Now()
+	for range n {
+		fn()
+	}
+	return time.Since(start) / time.Duration(n)
+}
@@ -0,0 +1,103 @@
+package benchassert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gowise/pkg/assertions"
+)
+
+// mockT records failures reported through an Assert.
+type mockT struct {
+	errorCalls []string
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *mockT) FailNow() {}
+func (m *mockT) Helper()  {}
+
+var sink []byte
+
+func TestMaxAllocsPerRun(t *testing.T) {
+	mock := &mockT{}
+	buf := make([]byte, 0, 32)
+	MaxAllocsPerRun(assertions.New(mock), 0, func() { buf = strconv.AppendInt(buf[:0], 42, 10) })
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected an allocation-free function to pass, got %v", mock.errorCalls)
+	}
+
+	MaxAllocsPerRun(assertions.New(mock), 0, func() { sink = make([]byte, 64) })
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected at most 0 allocations per run, got 1") {
+		t.Errorf("Expected an allocating function to fail, got %v", mock.errorCalls)
+	}
+}
+
+func TestCompletesWithinPerOp(t *testing.T) {
+	mock := &mockT{}
+	CompletesWithinPerOp(assertions.New(mock), func() {}, time.Second, 100)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected a fast function to pass, got %v", mock.errorCalls)
+	}
+
+	CompletesWithinPerOp(assertions.New(mock), func() { time.Sleep(time.Millisecond) }, time.Microsecond, 3)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected at most 1µs per call") {
+		t.Errorf("Expected a slow function to fail, got %v", mock.errorCalls)
+	}
+
+	mock = &mockT{}
+	CompletesWithinPerOp(assertions.New(mock), func() {}, time.Second, 0)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "positive number of calls") {
+		t.Errorf("Expected zero calls to be rejected, got %v", mock.errorCalls)
+	}
+}
+
+func TestFasterThan(t *testing.T) {
+	fast := func() {}
+	slow := func() { time.Sleep(100 * time.Microsecond) }
+
+	mock := &mockT{}
+	FasterThan(assertions.New(mock), fast, slow, 0.5)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected the faster function to pass, got %v", mock.errorCalls)
+	}
+
+	FasterThan(assertions.New(mock), slow, fast, 0)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected the first function to take at most") {
+		t.Errorf("Expected the slower function to fail, got %v", mock.errorCalls)
+	}
+
+	mock = &mockT{}
+	FasterThan(assertions.New(mock), fast, slow, 1)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "margin") {
+		t.Errorf("Expected a margin of 1 to be rejected, got %v", mock.errorCalls)
+	}
+}
+
+func TestSkipsAfterFailure(t *testing.T) {
+	mock := &mockT{}
+	assert := assertions.New(mock).True(false)
+
+	calls := 0
+	MaxAllocsPerRun(assert, 0, func() { calls++ })
+	CompletesWithinPerOp(assert, func() { calls++ }, time.Second, 10)
+	FasterThan(assert, func() { calls++ }, func() { calls++ }, 0)
+
+	if calls != 0 || len(mock.errorCalls) != 1 {
+		t.Errorf("Expected nothing to run or report after a failure, got %d calls and %v", calls, mock.errorCalls)
+	}
+}
+
+func ExampleMaxAllocsPerRun() {
+	mock := &mockT{}
+	assert := assertions.New(mock)
+
+	MaxAllocsPerRun(assert, 0, func() { sink = make([]byte, 64) })
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// expected at most 0 allocations per run, got 1 (averaged over 100 runs)
+}
@@ -0,0 +1,96 @@
+package benchassert
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// NoAllocs fails if fn allocates, on average over AllocRuns calls, as
+// testing.AllocsPerRun measures it. The failure gives the allocations and
+// bytes allocated per call. Use it to keep a hot path allocation-free.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	benchassert.NoAllocs(assert, func() { buf = strconv.AppendInt(buf[:0], 42, 10) })
+func NoAllocs(a *assertions.Assert, fn func()) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	allocs := testing.AllocsPerRun(AllocRuns, fn)
+	if allocs == 0 {
+		return a
+	}
+	before := settledHeap()
+	for range AllocRuns {
+		fn()
+	}
+	after := settledHeap()
+	return a.Fail(fmt.Sprintf("expected no allocations, got %g per run\n  bytes per run: %s\n  runs: %d",
+		allocs, formatBytes((after.TotalAlloc-before.TotalAlloc)/AllocRuns), AllocRuns))
+}
+
+// MaxHeapGrowth fails if the live heap grows by more than maxBytes over a
+// call of fn: memory fn allocates and keeps reachable, such as entries added
+// to a cache. Garbage is collected before and after the call, so memory fn
+// allocates and drops does not count. Allocations by other goroutines
+// running at the same time do, so give maxBytes some headroom.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	benchassert.MaxHeapGrowth(assert, func() { cache.Load(fixtures) }, 1<<20)
+func MaxHeapGrowth(a *assertions.Assert, fn func(), maxBytes uint64) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	before := settledHeap()
+	fn()
+	after := settledHeap()
+
+	var growth uint64
+	if after.HeapAlloc > before.HeapAlloc {
+		growth = after.HeapAlloc - before.HeapAlloc
+	}
+	if growth > maxBytes {
+		return a.Fail(fmt.Sprintf("expected the heap to grow by at most %s, grew by %s\n  allocated during the call: %s in %d objects",
+			formatBytes(maxBytes), formatBytes(growth),
+			formatBytes(after.TotalAlloc-before.TotalAlloc), after.Mallocs-before.Mallocs))
+	}
+	return a
+}
+
+// settledHeap collects garbage and returns the memory statistics after it.
+// The second collection frees objects whose finalisers ran during the first.
+func settledHeap() runtime.MemStats {
+	runtime.GC()
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m
+}
+
+// formatBytes renders a byte count in binary units, such as 1.5 MiB.
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,71 @@
+package benchassert
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// retained keeps memory reachable across MaxHeapGrowth's measurements.
+var retained [][]byte
+
+func TestNoAllocs(t *testing.T) {
+	mock := &mockT{}
+	buf := make([]byte, 0, 32)
+	NoAllocs(assertions.New(mock), func() { buf = strconv.AppendInt(buf[:0], 42, 10) })
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected an allocation-free function to pass, got %v", mock.errorCalls)
+	}
+
+	NoAllocs(assertions.New(mock), func() { sink = make([]byte, 64) })
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected no allocations, got 1 per run\n  bytes per run: 64 B\n  runs: 100") {
+		t.Errorf("Expected an allocating function to fail, got %v", mock.errorCalls)
+	}
+}
+
+func TestMaxHeapGrowth(t *testing.T) {
+	defer func() { retained = nil }()
+
+	mock := &mockT{}
+	MaxHeapGrowth(assertions.New(mock), func() {
+		for range 64 {
+			sink = make([]byte, 64<<10) // Garbage once the next one replaces it
+		}
+	}, 512<<10)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected memory that is dropped not to count, got %v", mock.errorCalls)
+	}
+
+	failed := assertions.New(mock)
+	MaxHeapGrowth(failed, func() { retained = append(retained, make([]byte, 4<<20)) }, 1<<20)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected the heap to grow by at most 1.0 MiB, grew by 4.") {
+		t.Errorf("Expected retained memory to fail, got %v", mock.errorCalls)
+	}
+
+	calls := 0
+	MaxHeapGrowth(failed, func() { calls++ }, 0)
+	if calls != 0 {
+		t.Errorf("Expected a failed Assert to skip the measurement, got %d calls", calls)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	for n, want := range map[uint64]string{0: "0 B", 1023: "1023 B", 1024: "1.0 KiB", 1536: "1.5 KiB", 5 << 30: "5.0 GiB"} {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func ExampleMaxHeapGrowth() {
+	mock := &mockT{}
+	assert := assertions.New(mock)
+
+	MaxHeapGrowth(assert, func() { retained = append(retained, make([]byte, 8<<20)) }, 1<<20)
+	retained = nil
+	fmt.Println(strings.SplitN(mock.errorCalls[0], ",", 2)[0])
+	// Output: expected the heap to grow by at most 1.0 MiB
+}
@@ -0,0 +1,336 @@
+// Package cliassert tests command-line tools: it runs a command, or a main
+// function in-process, under a timeout, captures its stdout, stderr and exit
+// code, and asserts on the result.
+//
+// Run an external command with Command, or a main function with Main, then
+// chain assertions on the returned Result:
+//
+//	func TestHelp(t *testing.T) {
+//		assert := assertions.New(t)
+//		cliassert.Command(assert, exec.Command("./mytool", "--help"), 5*time.Second).
+//			ExitCode(0).
+//			OutputContains("Usage:").
+//			OutputMatchesGolden("testdata/help.golden").
+//			CompletesWithin(time.Second)
+//	}
+//
+// In-process runs are faster and count towards coverage, but the main function
+// must take its arguments and writers as parameters rather than using os.Args,
+// os.Stdout and os.Exit:
+//
+//	cliassert.Main(assert, mytool.Run, 0, "--version").
+//		ExitCode(0).
+//		OutputContains("mytool 1.")
+//
+// Result assertions report through the Assert, so they take part in its
+// fail-fast chaining like a built-in assertion.
+package cliassert
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"gowise/pkg/assertions"
+)
+
+// DefaultTimeout is how long Command and Main let a run take when given a
+// timeout of zero.
+const DefaultTimeout = 30 * time.Second
+
+// waitDelay is how long Command waits for output after killing a command that
+// timed out, in case a child process still holds its stdout or stderr open.
+const waitDelay = time.Second
+
+// maxExcerpt bounds how much of stdout or stderr a failure message shows.
+const maxExcerpt = 1024
+
+// MainFunc is a command's main function made testable: it reads its arguments
+// from args, writes to stdout and stderr, and returns its exit code.
+type MainFunc func(args []string, stdout, stderr io.Writer) int
+
+// Result is the outcome of a run: its output, exit code and duration. Its
+// assertion methods report to the Assert the run was started with.
+type Result struct {
+	a *assertions.Assert
+
+	// Name describes the run in failure messages: the command line, or the
+	// arguments given to a MainFunc.
+	Name string
+	// Stdout and Stderr hold everything the run wrote.
+	Stdout, Stderr string
+	// ExitStatus is the exit code, or -1 if the run did not exit normally.
+	ExitStatus int
+	// Duration is how long the run took.
+	Duration time.Duration
+	// TimedOut reports whether the run was stopped for exceeding its timeout.
+	TimedOut bool
+}
+
+// Command runs cmd, which must not have been started, with a timeout, killing
+// it if it takes longer; a timeout of zero means DefaultTimeout. Stdout and
+// stderr are captured, so cmd must not have either set. Failing to start the
+// command, or its timing out, fails a.
+//
+// Example:
+//
+//	res := cliassert.Command(assert, exec.Command("./mytool", "build"), time.Minute)
+func Command(a *assertions.Assert, cmd *exec.Cmd, timeout time.Duration) *Result {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	r := &Result{a: a, Name: "<nil command>", ExitStatus: -1}
+	if a.HasFailed() {
+		return r
+	}
+	if cmd == nil {
+		a.Fail("cannot run nil command")
+		return r
+	}
+	r.Name = cmd.String()
+	if cmd.Stdout != nil || cmd.Stderr != nil {
+		a.Fail(fmt.Sprintf("cliassert captures stdout and stderr, but the command already sets them\n  command: %s", r.Name))
+		return r
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if cmd.WaitDelay == 0 {
+		cmd.WaitDelay = waitDelay
+	}
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		a.Fail(fmt.Sprintf("failed to start command\n  command: %s\n  error:   %v", r.Name, err))
+		return r
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	var err error
+	timer := time.NewTimer(orDefault(timeout))
+	defer timer.Stop()
+	select {
+	case err = <-done:
+	case <-timer.C:
+		r.TimedOut = true
+		_ = cmd.Process.Kill()
+		err = <-done
+	}
+	r.Duration = time.Since(start)
+	r.Stdout, r.Stderr = stdout.String(), stderr.String()
+	r.ExitStatus = cmd.ProcessState.ExitCode()
+
+	var exitErr *exec.ExitError
+	switch {
+	case r.TimedOut:
+		a.Fail(fmt.Sprintf("command timed out after %v\n  command: %s%s", orDefault(timeout), r.Name, r.excerpt()))
+	case err != nil && !errors.As(err, &exitErr):
+		a.Fail(fmt.Sprintf("command failed to run\n  command: %s\n  error:   %v%s", r.Name, err, r.excerpt()))
+	}
+	return r
+}
+
+// Main calls main in-process with args, capturing what it writes, with a
+// timeout; a timeout of zero means DefaultTimeout. A main that panics fails a
+// with the panic value. A main that times out also fails a, but cannot be
+// stopped: it is left running and its later output is discarded.
+//
+// Example:
+//
+//	res := cliassert.Main(assert, mytool.Run, 0, "--config", "testdata/app.toml")
+func Main(a *assertions.Assert, main MainFunc, timeout time.Duration, args ...string) *Result {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	r := &Result{a: a, Name: "main " + strings.Join(args, " "), ExitStatus: -1}
+	if a.HasFailed() {
+		return r
+	}
+	if main == nil {
+		a.Fail("cannot run nil main function")
+		return r
+	}
+
+	stdout, stderr := &syncBuffer{}, &syncBuffer{}
+	type outcome struct {
+		code     int
+		panicked any
+	}
+	done := make(chan outcome, 1)
+	start := time.Now()
+	go func() {
+		o := outcome{code: -1}
+		defer func() {
+			if p := recover(); p != nil {
+				o.panicked = p
+			}
+			done <- o
+		}()
+		o.code = main(args, stdout, stderr)
+	}()
+
+	timer := time.NewTimer(orDefault(timeout))
+	defer timer.Stop()
+	var o outcome
+	select {
+	case o = <-done:
+	case <-timer.C:
+		r.TimedOut = true
+		stdout.close()
+		stderr.close()
+	}
+	r.Duration = time.Since(start)
+	r.Stdout, r.Stderr = stdout.String(), stderr.String()
+
+	switch {
+	case r.TimedOut:
+		a.Fail(fmt.Sprintf("main timed out after %v\n  args: %q%s", orDefault(timeout), args, r.excerpt()))
+	case o.panicked != nil:
+		a.Fail(fmt.Sprintf("main panicked: %v\n  args: %q%s", o.panicked, args, r.excerpt()))
+	default:
+		r.ExitStatus = o.code
+	}
+	return r
+}
+
+// ExitCode asserts that the run exited with code.
+// Returns the Result to enable method chaining.
+func (r *Result) ExitCode(code int) *Result {
+	if h, ok := r.a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if r.a.HasFailed() {
+		return r
+	}
+
+	if r.ExitStatus != code {
+		r.a.Fail(fmt.Sprintf("exit code differs\n  command: %s\n  got:     %d\n  want:    %d%s", r.Name, r.ExitStatus, code, r.excerpt()))
+	}
+	return r
+}
+
+// OutputContains asserts that the run wrote substr to stdout.
+// Returns the Result to enable method chaining.
+func (r *Result) OutputContains(substr string) *Result {
+	if h, ok := r.a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if r.a.HasFailed() {
+		return r
+	}
+
+	if !strings.Contains(r.Stdout, substr) {
+		r.a.Fail(fmt.Sprintf("expected stdout to contain %q\n  command: %s%s", substr, r.Name, r.excerpt()))
+	}
+	return r
+}
+
+// ErrorOutputContains asserts that the run wrote substr to stderr.
+// Returns the Result to enable method chaining.
+func (r *Result) ErrorOutputContains(substr string) *Result {
+	if h, ok := r.a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if r.a.HasFailed() {
+		return r
+	}
+
+	if !strings.Contains(r.Stderr, substr) {
+		r.a.Fail(fmt.Sprintf("expected stderr to contain %q\n  command: %s%s", substr, r.Name, r.excerpt()))
+	}
+	return r
+}
+
+// OutputMatchesGolden asserts that stdout equals the golden file at path, as
+// Assert.MatchesGolden does, including writing a patch for a mismatch when
+// patch output is enabled.
+// Returns the Result to enable method chaining.
+func (r *Result) OutputMatchesGolden(path string) *Result {
+	if h, ok := r.a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	r.a.MatchesGolden(r.Stdout, path)
+	return r
+}
+
+// CompletesWithin asserts that the run took at most limit. Unlike the timeout
+// given to Command or Main, it does not stop the run; use it to check a
+// performance budget tighter than the timeout.
+// Returns the Result to enable method chaining.
+func (r *Result) CompletesWithin(limit time.Duration) *Result {
+	if h, ok := r.a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if r.a.HasFailed() {
+		return r
+	}
+
+	if r.Duration > limit {
+		r.a.Fail(fmt.Sprintf("expected run to complete within %v, took %v\n  command: %s", limit, r.Duration, r.Name))
+	}
+	return r
+}
+
+// excerpt renders the captured output for a failure message, omitting
+// streams that produced nothing.
+func (r *Result) excerpt() string {
+	var b strings.Builder
+	for _, stream := range []struct{ name, text string }{{"stdout", r.Stdout}, {"stderr", r.Stderr}} {
+		if stream.text == "" {
+			continue
+		}
+		text := stream.text
+		if len(text) > maxExcerpt {
+			text = text[:maxExcerpt] + "…"
+		}
+		fmt.Fprintf(&b, "\n  %s: %q", stream.name, text)
+	}
+	return b.String()
+}
+
+// orDefault returns timeout, or DefaultTimeout if it is zero.
+func orDefault(timeout time.Duration) time.Duration {
+	if timeout == 0 {
+		return DefaultTimeout
+	}
+	return timeout
+}
+
+// syncBuffer is a bytes.Buffer safe for a main function to write to while the
+// test reads it. Once closed, writes are discarded, so a main that timed out
+// cannot change output already reported.
+type syncBuffer struct {
+	mu     sync.Mutex
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func (b *syncBuffer) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+}
@@ -0,0 +1,185 @@
+package cliassert
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gowise/pkg/assertions"
+)
+
+// mockT records failures reported through an Assert.
+type mockT struct {
+	errorCalls []string
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *mockT) FailNow() {}
+func (m *mockT) Helper()  {}
+
+// helperCommand returns a command that re-runs the test binary as
+// TestHelperProcess, which prints stdout and stderr, sleeps and exits with
+// code.
+func helperCommand(code int, stdout, stderr string, sleep time.Duration) *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperProcess$")
+	cmd.Env = append(os.Environ(),
+		"GOWISE_WANT_HELPER_PROCESS=1",
+		"GOWISE_HELPER_CODE="+strconv.Itoa(code),
+		"GOWISE_HELPER_STDOUT="+stdout,
+		"GOWISE_HELPER_STDERR="+stderr,
+		"GOWISE_HELPER_SLEEP="+sleep.String(),
+	)
+	return cmd
+}
+
+// TestHelperProcess is not a real test: it is the child process run by
+// helperCommand.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GOWISE_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	fmt.Fprint(os.Stdout, os.Getenv("GOWISE_HELPER_STDOUT"))
+	fmt.Fprint(os.Stderr, os.Getenv("GOWISE_HELPER_STDERR"))
+	sleep, _ := time.ParseDuration(os.Getenv("GOWISE_HELPER_SLEEP"))
+	time.Sleep(sleep)
+	code, _ := strconv.Atoi(os.Getenv("GOWISE_HELPER_CODE"))
+	os.Exit(code)
+}
+
+// greet is a MainFunc for in-process tests.
+func greet(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: greet NAME")
+		return 2
+	}
+	switch args[0] {
+	case "--panic":
+		panic("greet: boom")
+	case "--hang":
+		time.Sleep(time.Hour)
+	}
+	fmt.Fprintf(stdout, "Hello, %s!\n", args[0])
+	return 0
+}
+
+func TestCommand(t *testing.T) {
+	mock := &mockT{}
+	res := Command(assertions.New(mock), helperCommand(0, "Usage: tool [flags]\n", "", 0), 0).
+		ExitCode(0).
+		OutputContains("Usage:").
+		OutputMatchesGolden("testdata/help.golden").
+		CompletesWithin(time.Minute)
+	if len(mock.errorCalls) != 0 {
+		t.Fatalf("Expected a successful command to pass, got %v", mock.errorCalls)
+	}
+	if res.ExitStatus != 0 || res.Stdout != "Usage: tool [flags]\n" || res.Duration <= 0 {
+		t.Errorf("Unexpected result: %+v", res)
+	}
+
+	tests := []struct {
+		name          string
+		run           func(a *assertions.Assert)
+		expectMessage string
+	}{
+		{"exit code differs", func(a *assertions.Assert) {
+			Command(a, helperCommand(2, "", "unknown flag", 0), 0).ExitCode(0)
+		}, "exit code differs\n  command: "},
+		{"exit code shows stderr", func(a *assertions.Assert) {
+			Command(a, helperCommand(2, "", "unknown flag", 0), 0).ExitCode(0)
+		}, "  got:     2\n  want:    0\n  stderr: \"unknown flag\""},
+		{"output missing", func(a *assertions.Assert) {
+			Command(a, helperCommand(0, "hello", "Usage:", 0), 0).OutputContains("Usage:")
+		}, "expected stdout to contain \"Usage:\""},
+		{"error output missing", func(a *assertions.Assert) {
+			Command(a, helperCommand(0, "ok", "", 0), 0).ErrorOutputContains("warning")
+		}, "expected stderr to contain \"warning\""},
+		{"golden mismatch", func(a *assertions.Assert) {
+			Command(a, helperCommand(0, "Usage: tool\n", "", 0), 0).OutputMatchesGolden("testdata/help.golden")
+		}, "output does not match golden file testdata/help.golden"},
+		{"too slow", func(a *assertions.Assert) {
+			Command(a, helperCommand(0, "", "", 50*time.Millisecond), 0).CompletesWithin(time.Millisecond)
+		}, "expected run to complete within 1ms"},
+		{"timeout", func(a *assertions.Assert) {
+			Command(a, helperCommand(0, "started", "", time.Minute), 100*time.Millisecond).ExitCode(0)
+		}, "command timed out after 100ms"},
+		{"missing binary", func(a *assertions.Assert) {
+			Command(a, exec.Command("/nonexistent/gowise-tool"), 0)
+		}, "failed to start command"},
+		{"output already set", func(a *assertions.Assert) {
+			cmd := helperCommand(0, "", "", 0)
+			cmd.Stdout = io.Discard
+			Command(a, cmd, 0)
+		}, "already sets them"},
+		{"nil command", func(a *assertions.Assert) { Command(a, nil, 0).ExitCode(0) }, "cannot run nil command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockT{}
+			tt.run(assertions.New(mock))
+			if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected one failure containing %q, got %v", tt.expectMessage, mock.errorCalls)
+			}
+		})
+	}
+}
+
+func TestMainFunc(t *testing.T) {
+	mock := &mockT{}
+	Main(assertions.New(mock), greet, 0, "Ada").
+		ExitCode(0).
+		OutputContains("Hello, Ada!").
+		CompletesWithin(time.Minute)
+	if len(mock.errorCalls) != 0 {
+		t.Fatalf("Expected a successful main to pass, got %v", mock.errorCalls)
+	}
+
+	tests := []struct {
+		name          string
+		run           func(a *assertions.Assert)
+		expectMessage string
+	}{
+		{"exit code", func(a *assertions.Assert) {
+			Main(a, greet, 0).ExitCode(0)
+		}, "exit code differs\n  command: main \n  got:     2\n  want:    0\n  stderr: \"usage: greet NAME\\n\""},
+		{"panic", func(a *assertions.Assert) {
+			Main(a, greet, 0, "--panic").ExitCode(0)
+		}, "main panicked: greet: boom\n  args: [\"--panic\"]"},
+		{"timeout", func(a *assertions.Assert) {
+			Main(a, greet, 50*time.Millisecond, "--hang").ExitCode(0)
+		}, "main timed out after 50ms"},
+		{"nil main", func(a *assertions.Assert) { Main(a, nil, 0) }, "cannot run nil main function"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockT{}
+			tt.run(assertions.New(mock))
+			if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected one failure containing %q, got %v", tt.expectMessage, mock.errorCalls)
+			}
+		})
+	}
+}
+
+func TestFailedAssertSkipsRun(t *testing.T) {
+	mock := &mockT{}
+	a := assertions.New(mock)
+	a.Fail("earlier failure")
+
+	ran := false
+	Main(a, func([]string, io.Writer, io.Writer) int { ran = true; return 0 }, 0).ExitCode(1)
+	if ran {
+		t.Error("Expected a failed chain not to run main")
+	}
+	if len(mock.errorCalls) != 1 {
+		t.Errorf("Expected only the earlier failure, got %v", mock.errorCalls)
+	}
+}
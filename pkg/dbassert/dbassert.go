@@ -0,0 +1,284 @@
+// Package dbassert provides assertions on the contents of SQL databases for
+// integration tests, working through database/sql with any driver.
+//
+// Each function takes the *assertions.Assert to report to, so it takes part
+// in fail-fast chaining like a built-in assertion, and a Querier, which
+// *sql.DB, *sql.Tx and *sql.Conn all satisfy:
+//
+//	func TestSignup(t *testing.T) {
+//		assert := assertions.New(t)
+//		db := openTestDB(t)
+//		signup(db, "ann@example.com")
+//
+//		dbassert.TableExists(assert, db, "users")
+//		dbassert.RowCount(assert, db, "users", 1)
+//		dbassert.QueryReturns(assert, db, "SELECT email, verified FROM users", [][]any{
+//			{"ann@example.com", false},
+//		})
+//		dbassert.NoOpenTransactions(assert, db)
+//	}
+//
+// Values are compared after normalisation, so an int in the expected rows
+// matches the int64 a driver returns, sql.NullString{} matches NULL, and a
+// []byte column matches a string.
+package dbassert
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"gowise/pkg/assertions"
+	"gowise/pkg/diff"
+)
+
+// Querier runs queries. *sql.DB, *sql.Tx and *sql.Conn implement it.
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// RowCount fails unless table has want rows.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	dbassert.RowCount(assert, db, "users", 3)
+func RowCount(a *assertions.Assert, q Querier, table string, want int) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+	if !validTableName(table) {
+		return a.Fail(fmt.Sprintf("invalid table name %q: use letters, digits, underscores and dots", table))
+	}
+
+	rows, err := queryRows(q, "SELECT COUNT(*) FROM "+table)
+	if err != nil {
+		return a.Fail(fmt.Sprintf("failed to count rows\n  table: %s\n  error: %v", table, err))
+	}
+	var got int64
+	if len(rows) == 1 && len(rows[0]) == 1 {
+		got, _ = rows[0][0].(int64)
+	}
+	if got != int64(want) {
+		return a.Fail(fmt.Sprintf("row count differs\n  table: %s\n  got:   %d\n  want:  %d", table, got, want))
+	}
+	return a
+}
+
+// QueryReturns fails unless query, run with args, returns exactly the rows in
+// want, in order, as a diff of the two result sets shows. Add ORDER BY to
+// queries whose row order is not otherwise defined.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	dbassert.QueryReturns(assert, db, "SELECT id, name FROM users WHERE team = ? ORDER BY id", [][]any{
+//		{1, "ann"},
+//		{2, sql.NullString{}},
+//	}, "core")
+func QueryReturns(a *assertions.Assert, q Querier, query string, want [][]any, args ...any) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	got, err := queryRows(q, query, args...)
+	if err != nil {
+		return a.Fail(fmt.Sprintf("query failed\n  query: %s\n  error: %v", query, err))
+	}
+	normalised := make([][]any, len(want))
+	for i, row := range want {
+		normalised[i] = make([]any, len(row))
+		for j, v := range row {
+			if normalised[i][j], err = normalise(v); err != nil {
+				return a.Fail(fmt.Sprintf("cannot compare expected value\n  row:    %d\n  column: %d\n  error:  %v", i, j, err))
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(got, normalised) {
+		gotText, wantText := renderRows(got), renderRows(normalised)
+		result := diff.Compare(gotText, wantText, diff.DefaultOptions())
+		return a.Fail(fmt.Sprintf("query returned different rows (got %d, want %d)\n  query: %s\n\n%s",
+			len(got), len(normalised), query, result.Render(diff.FormatUnified)))
+	}
+	return a
+}
+
+// TableExists fails unless table exists and can be queried. It selects no
+// rows from the table rather than reading a catalogue, so it works with any
+// database.
+// Returns *Assert to enable method chaining.
+func TableExists(a *assertions.Assert, q Querier, table string) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+	if !validTableName(table) {
+		return a.Fail(fmt.Sprintf("invalid table name %q: use letters, digits, underscores and dots", table))
+	}
+
+	if _, err := queryRows(q, "SELECT 1 FROM "+table+" WHERE 1 = 0"); err != nil {
+		return a.Fail(fmt.Sprintf("expected table to exist\n  table: %s\n  error: %v", table, err))
+	}
+	return a
+}
+
+// NoOpenTransactions fails if db has connections in use: an unfinished
+// transaction, or Rows that were never closed, each hold one. Call it at the
+// end of a test to catch a code path that forgets to Commit, Rollback or
+// Close.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	t.Cleanup(func() { dbassert.NoOpenTransactions(assert, db) })
+func NoOpenTransactions(a *assertions.Assert, db *sql.DB) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+	if db == nil {
+		return a.Fail("cannot check transactions of nil *sql.DB")
+	}
+
+	if inUse := db.Stats().InUse; inUse > 0 {
+		return a.Fail(fmt.Sprintf("expected no open transactions or rows, but %d connection(s) are in use", inUse))
+	}
+	return a
+}
+
+// queryRows runs query and reads every row, normalising each value.
+func queryRows(q Querier, query string, args ...any) ([][]any, error) {
+	if q == nil {
+		return nil, errors.New("nil Querier")
+	}
+	rows, err := q.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := [][]any{}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			if values[i], err = normalise(v); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, values)
+	}
+	return result, rows.Err()
+}
+
+// normalise converts v to the form drivers return, so that expected and
+// actual values compare equal whatever Go type they were written with: nil,
+// int64, float64, bool, string or time.Time. sql.Null* and other
+// driver.Valuer types are replaced by their value.
+func normalise(v any) (any, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		var err error
+		if v, err = valuer.Value(); err != nil {
+			return nil, err
+		}
+	}
+
+	switch v := v.(type) {
+	case nil, int64, float64, bool, string:
+		return v, nil
+	case time.Time:
+		// Compare instants, not locations or monotonic clock readings
+		return v.UTC().Round(0), nil
+	case []byte:
+		return string(v), nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case float32:
+		return float64(v), nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+// renderRows formats rows one per line for diffing.
+func renderRows(rows [][]any) string {
+	var b strings.Builder
+	for _, row := range rows {
+		b.WriteByte('(')
+		for i, v := range row {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			switch v := v.(type) {
+			case nil:
+				b.WriteString("NULL")
+			case string:
+				fmt.Fprintf(&b, "%q", v)
+			case time.Time:
+				b.WriteString(v.Format(time.RFC3339Nano))
+			default:
+				fmt.Fprint(&b, v)
+			}
+		}
+		b.WriteString(")\n")
+	}
+	return b.String()
+}
+
+// validTableName reports whether table is a plain, optionally
+// schema-qualified identifier, which RowCount and TableExists can safely
+// insert into a query.
+func validTableName(table string) bool {
+	if table == "" {
+		return false
+	}
+	for _, part := range strings.Split(table, ".") {
+		if part == "" {
+			return false
+		}
+		for i, r := range part {
+			letter := r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+			if !letter && (i == 0 || r < '0' || r > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,235 @@
+package dbassert
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"gowise/pkg/assertions"
+)
+
+// mockT records failures reported through an Assert.
+type mockT struct {
+	errorCalls []string
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *mockT) FailNow() {}
+func (m *mockT) Helper()  {}
+
+// fakeResult is the answer the fake driver gives to one query.
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeDriver answers queries by looking them up in results. A query it does
+// not know fails like a missing table would.
+type fakeDriver struct {
+	results map[string]fakeResult
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{c.d, query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("fake driver does not support Exec")
+}
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	key := s.query
+	for _, arg := range args {
+		key += fmt.Sprintf(" [%v]", arg)
+	}
+	result, ok := s.d.results[key]
+	if !ok {
+		return nil, fmt.Errorf("no such table or query: %s", key)
+	}
+	return &fakeRows{result: result}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	next   int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
+
+// fakeConnector lets each test open a database with its own results.
+type fakeConnector struct{ d *fakeDriver }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.d.Open("") }
+func (c fakeConnector) Driver() driver.Driver                        { return c.d }
+
+var created = time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+
+func openFakeDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(fakeConnector{&fakeDriver{results: map[string]fakeResult{
+		"SELECT COUNT(*) FROM users":          {[]string{"count"}, [][]driver.Value{{int64(3)}}},
+		"SELECT 1 FROM users WHERE 1 = 0":     {[]string{"1"}, nil},
+		"SELECT 1 FROM app.users WHERE 1 = 0": {[]string{"1"}, nil},
+		"SELECT id, name, score FROM users [ops]": {[]string{"id", "name", "score"}, [][]driver.Value{
+			{int64(1), []byte("ann"), 9.5},
+			{int64(2), nil, 7.0},
+		}},
+		"SELECT created FROM users": {[]string{"created"}, [][]driver.Value{{created}}},
+	}}})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestRowCount(t *testing.T) {
+	db := openFakeDB(t)
+
+	mock := &mockT{}
+	RowCount(assertions.New(mock), db, "users", 3)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected matching row count to pass, got %v", mock.errorCalls)
+	}
+
+	RowCount(assertions.New(mock), db, "users", 2)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "row count differs\n  table: users\n  got:   3\n  want:  2") {
+		t.Errorf("Expected differing row count to fail, got %v", mock.errorCalls)
+	}
+
+	mock = &mockT{}
+	RowCount(assertions.New(mock), db, "users; DROP TABLE users", 0)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "invalid table name") {
+		t.Errorf("Expected an unsafe table name to be rejected, got %v", mock.errorCalls)
+	}
+}
+
+func TestQueryReturns(t *testing.T) {
+	db := openFakeDB(t)
+	query := "SELECT id, name, score FROM users"
+
+	mock := &mockT{}
+	QueryReturns(assertions.New(mock), db, query, [][]any{
+		{1, "ann", 9.5},
+		{int32(2), sql.NullString{}, sql.NullFloat64{Float64: 7, Valid: true}},
+	}, "ops")
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected normalised rows to match, got %v", mock.errorCalls)
+	}
+
+	QueryReturns(assertions.New(mock), db, query, [][]any{
+		{1, "ann", 9.5},
+		{2, "bob", 7.0},
+	}, "ops")
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("Expected differing rows to fail, got %v", mock.errorCalls)
+	}
+	for _, want := range []string{"query returned different rows (got 2, want 2)", `-(2, NULL, 7)`, `+(2, "bob", 7)`} {
+		if !strings.Contains(mock.errorCalls[0], want) {
+			t.Errorf("Expected message containing %q, got: %s", want, mock.errorCalls[0])
+		}
+	}
+
+	mock = &mockT{}
+	QueryReturns(assertions.New(mock), db, "SELECT created FROM users", [][]any{{created.In(time.FixedZone("BST", 3600))}})
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected the same instant in another zone to match, got %v", mock.errorCalls)
+	}
+
+	QueryReturns(assertions.New(mock), db, "SELECT nothing", nil)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "query failed\n  query: SELECT nothing") {
+		t.Errorf("Expected a failing query to be reported, got %v", mock.errorCalls)
+	}
+
+	mock = &mockT{}
+	QueryReturns(assertions.New(mock), db, query, [][]any{{struct{}{}}}, "ops")
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "unsupported type struct {}") {
+		t.Errorf("Expected an unsupported expected value to be reported, got %v", mock.errorCalls)
+	}
+}
+
+func TestTableExists(t *testing.T) {
+	db := openFakeDB(t)
+
+	mock := &mockT{}
+	TableExists(assertions.New(mock), db, "users")
+	TableExists(assertions.New(mock), db, "app.users")
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected existing tables to pass, got %v", mock.errorCalls)
+	}
+
+	TableExists(assertions.New(mock), db, "orders")
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected table to exist\n  table: orders") {
+		t.Errorf("Expected a missing table to fail, got %v", mock.errorCalls)
+	}
+}
+
+func TestNoOpenTransactions(t *testing.T) {
+	db := openFakeDB(t)
+
+	mock := &mockT{}
+	NoOpenTransactions(assertions.New(mock), db)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected an idle database to pass, got %v", mock.errorCalls)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	NoOpenTransactions(assertions.New(mock), db)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "1 connection(s) are in use") {
+		t.Errorf("Expected an open transaction to fail, got %v", mock.errorCalls)
+	}
+
+	// Assertions run inside the transaction too
+	mock = &mockT{}
+	RowCount(assertions.New(mock), tx, "users", 3)
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	NoOpenTransactions(assertions.New(mock), db)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected a finished transaction to pass, got %v", mock.errorCalls)
+	}
+}
+
+func TestValidTableName(t *testing.T) {
+	for name, want := range map[string]bool{
+		"users": true, "app.users": true, "_t1": true,
+		"": false, "1users": false, "app.": false, "users--": false, `"users"`: false,
+	} {
+		if got := validTableName(name); got != want {
+			t.Errorf("validTableName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// bytesPerRow is the number of bytes shown on each side of a hex dump row.
+// Eight keeps got and want side by side within a typical terminal width.
+const bytesPerRow = 8
+
+// hexContextRows is the number of rows shown before and after the first difference.
+const hexContextRows = 2
+
+// BytesDiffResult represents the result of comparing two byte slices.
+type BytesDiffResult struct {
+	HasDiff bool   // Whether the slices differ
+	Offset  int    // Offset of the first differing byte, or the length of the shorter slice
+	Dump    string // Side-by-side hex dump of the rows around Offset
+}
+
+// Bytes compares got and want and renders a side-by-side hex dump of the rows
+// around the first difference, with offset, hex and ASCII columns for each
+// side. The row holding the first difference is marked with ">", and bytes
+// beyond the end of the shorter slice are left blank:
+//
+//	  offset    got                               want
+//	  00000000  47 45 54 20 2f 20 48 54  GET / HT  47 45 54 20 2f 20 48 54  GET / HT
+//	> 00000008  54 50 2f 31 2e 31 0d 0a  TP/1.1..  54 50 2f 31 2e 30 0d 0a  TP/1.0..
+//
+// Bytes outside the printable ASCII range are shown as "." in the ASCII column.
+func Bytes(got, want []byte) BytesDiffResult {
+	if bytes.Equal(got, want) {
+		return BytesDiffResult{HasDiff: false}
+	}
+
+	offset := 0
+	for offset < len(got) && offset < len(want) && got[offset] == want[offset] {
+		offset++
+	}
+
+	diffRow := offset / bytesPerRow
+	firstRow := max(diffRow-hexContextRows, 0)
+	lastRow := diffRow + hexContextRows
+	if rows := (max(len(got), len(want)) + bytesPerRow - 1) / bytesPerRow; lastRow >= rows {
+		lastRow = max(rows-1, 0)
+	}
+
+	b := getBuffer()
+	fmt.Fprintf(b, "  %-8s  %-*s  %s\n", "offset", bytesPerRow*3+bytesPerRow+1, "got", "want")
+	for row := firstRow; row <= lastRow; row++ {
+		marker := "  "
+		if row == diffRow {
+			marker = "> "
+		}
+		fmt.Fprintf(b, "%s%08x  ", marker, row*bytesPerRow)
+		writeHexRow(b, got, row*bytesPerRow)
+		b.WriteString("  ")
+		writeHexRow(b, want, row*bytesPerRow)
+		b.Truncate(len(bytes.TrimRight(b.Bytes(), " "))) // Padding after a short want
+		b.WriteByte('\n')
+	}
+
+	return BytesDiffResult{
+		HasDiff: true,
+		Offset:  offset,
+		Dump:    bufferString(b),
+	}
+}
+
+// writeHexRow writes the hex and ASCII columns for the row of data starting at
+// start, padding past the end of data so that columns stay aligned.
+func writeHexRow(b *bytes.Buffer, data []byte, start int) {
+	for i := start; i < start+bytesPerRow; i++ {
+		if i < len(data) {
+			fmt.Fprintf(b, "%02x ", data[i])
+		} else {
+			b.WriteString("   ")
+		}
+	}
+	b.WriteByte(' ')
+	for i := start; i < start+bytesPerRow; i++ {
+		switch {
+		case i >= len(data):
+			b.WriteByte(' ')
+		case data[i] >= 0x20 && data[i] < 0x7f:
+			b.WriteByte(data[i])
+		default:
+			b.WriteByte('.')
+		}
+	}
+}
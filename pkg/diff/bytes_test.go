@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestBytesOffset tests the reported offset of the first difference.
+func TestBytesOffset(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want []byte
+		hasDiff   bool
+		offset    int
+	}{
+		{"equal", []byte{1, 2, 3}, []byte{1, 2, 3}, false, 0},
+		{"both empty", nil, []byte{}, false, 0},
+		{"first byte", []byte{9, 2}, []byte{1, 2}, true, 0},
+		{"middle byte", []byte("abcdefghij"), []byte("abcdefgXij"), true, 7},
+		{"got shorter", []byte("abc"), []byte("abcd"), true, 3},
+		{"want shorter", []byte("abcd"), []byte("ab"), true, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Bytes(tt.got, tt.want)
+			if result.HasDiff != tt.hasDiff {
+				t.Fatalf("HasDiff = %v, want %v", result.HasDiff, tt.hasDiff)
+			}
+			if result.HasDiff && result.Offset != tt.offset {
+				t.Errorf("Offset = %d, want %d", result.Offset, tt.offset)
+			}
+			if !result.HasDiff && result.Dump != "" {
+				t.Errorf("Expected no dump for equal slices, got:\n%s", result.Dump)
+			}
+		})
+	}
+}
+
+// TestBytesDumpWindow tests that only the rows around the difference are shown.
+func TestBytesDumpWindow(t *testing.T) {
+	got := make([]byte, 256)
+	want := make([]byte, 256)
+	want[100] = 0xff
+
+	lines := strings.Split(Bytes(got, want).Dump, "\n")
+
+	// Header plus two rows either side of the marked row
+	if len(lines) != 6 {
+		t.Fatalf("Expected 6 lines, got %d:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	if !strings.HasPrefix(lines[3], "> 00000060") {
+		t.Errorf("Expected row 0x60 to be marked, got %q", lines[3])
+	}
+	if !strings.HasPrefix(lines[1], "  00000050") || !strings.HasPrefix(lines[5], "  00000070") {
+		t.Errorf("Expected rows 0x50 to 0x70, got:\n%s", strings.Join(lines, "\n"))
+	}
+	if !strings.Contains(lines[3], "00 00 00 00 ff 00 00 00") {
+		t.Errorf("Expected want column to show the changed byte, got %q", lines[3])
+	}
+}
+
+// TestBytesNonPrintable tests that the ASCII column masks non-printable bytes.
+func TestBytesNonPrintable(t *testing.T) {
+	dump := Bytes([]byte("a\x00\tb\x7f"), []byte("a\x00\tc\x7f")).Dump
+
+	if !strings.Contains(dump, "61 00 09 62 7f           a..b.") {
+		t.Errorf("Expected masked ASCII column, got:\n%s", dump)
+	}
+}
+
+// ExampleBytes demonstrates the side-by-side hex dump of two payloads.
+func ExampleBytes() {
+	got := []byte("GET / HTTP/1.1\r\n")
+	want := []byte("GET / HTTP/1.0\r\n")
+
+	result := Bytes(got, want)
+	fmt.Printf("first difference at offset %d\n", result.Offset)
+	fmt.Println(result.Dump)
+	// Output:
+	// first difference at offset 13
+	//   offset    got                                want
+	//   00000000  47 45 54 20 2f 20 48 54  GET / HT  47 45 54 20 2f 20 48 54  GET / HT
+	// > 00000008  54 50 2f 31 2e 31 0d 0a  TP/1.1..  54 50 2f 31 2e 30 0d 0a  TP/1.0..
+}
@@ -3,7 +3,6 @@ package diff
 import (
 	"fmt"
 	"reflect"
-	"sort"
 	"strings"
 )
 
@@ -14,6 +13,7 @@ type CollectionDiffResult struct {
 	Detail         string // Detailed breakdown of differences
 	CollectionType string // "slice", "array", "map", "string"
 	Truncated      bool   // Whether the display was truncated due to size
+	Invalid        bool   // Whether the check could not be made, for an unsupported container or item
 }
 
 // CollectionContainsDiff compares a collection and item to determine if the item is missing.
@@ -26,6 +26,7 @@ func CollectionContainsDiff(container, item interface{}) CollectionDiffResult {
 			Detail:         "",
 			CollectionType: "nil",
 			Truncated:      false,
+			Invalid:        true,
 		}
 	}
 
@@ -45,6 +46,7 @@ func CollectionContainsDiff(container, item interface{}) CollectionDiffResult {
 			Detail:         "",
 			CollectionType: containerValue.Kind().String(),
 			Truncated:      false,
+			Invalid:        true,
 		}
 	}
 }
@@ -52,6 +54,22 @@ func CollectionContainsDiff(container, item interface{}) CollectionDiffResult {
 // CollectionLenDiff compares the length of a collection against expected length.
 // Returns enhanced diff information showing collection contents.
 func CollectionLenDiff(container interface{}, expectedLen int) CollectionDiffResult {
+	return collectionLenDiff(container,
+		func(length int) bool { return length == expectedLen },
+		func(length int) string { return fmt.Sprintf("got length: %d, want length: %d", length, expectedLen) })
+}
+
+// CollectionLenMatch checks the length of a collection against a bound,
+// satisfied, described by want, such as "length greater than 3" or "empty".
+// Returns enhanced diff information showing collection contents.
+func CollectionLenMatch(container interface{}, satisfied func(length int) bool, want string) CollectionDiffResult {
+	return collectionLenDiff(container, satisfied,
+		func(length int) string { return fmt.Sprintf("got length: %d, want %s", length, want) })
+}
+
+// collectionLenDiff checks the length of a collection with satisfied, and
+// describes a mismatch with summary and the collection's contents.
+func collectionLenDiff(container interface{}, satisfied func(length int) bool, summary func(length int) string) CollectionDiffResult {
 	if container == nil {
 		return CollectionDiffResult{
 			HasDiff:        true,
@@ -59,6 +77,7 @@ func CollectionLenDiff(container interface{}, expectedLen int) CollectionDiffRes
 			Detail:         "",
 			CollectionType: "nil",
 			Truncated:      false,
+			Invalid:        true,
 		}
 	}
 
@@ -76,12 +95,13 @@ func CollectionLenDiff(container interface{}, expectedLen int) CollectionDiffRes
 			Detail:         fmt.Sprintf("container must be string, slice, array, map, or channel, got: %T", container),
 			CollectionType: containerKind.String(),
 			Truncated:      false,
+			Invalid:        true,
 		}
 	}
 
 	actualLen := containerValue.Len()
 
-	if actualLen == expectedLen {
+	if satisfied(actualLen) {
 		return CollectionDiffResult{
 			HasDiff:        false,
 			Summary:        "",
@@ -94,9 +114,6 @@ func CollectionLenDiff(container interface{}, expectedLen int) CollectionDiffRes
 	// Generate collection content display
 	collectionDisplay, truncated := formatCollectionContent(containerValue, 10) // Show first 10 elements
 
-	var summary strings.Builder
-	summary.WriteString(fmt.Sprintf("got length: %d, want length: %d", actualLen, expectedLen))
-
 	var detail strings.Builder
 	if actualLen == 0 {
 		detail.WriteString("collection is empty")
@@ -109,7 +126,7 @@ func CollectionLenDiff(container interface{}, expectedLen int) CollectionDiffRes
 
 	return CollectionDiffResult{
 		HasDiff:        true,
-		Summary:        summary.String(),
+		Summary:        summary(actualLen),
 		Detail:         detail.String(),
 		CollectionType: containerValue.Kind().String(),
 		Truncated:      truncated,
@@ -170,6 +187,7 @@ func mapContainsDiff(containerValue reflect.Value, item interface{}) CollectionD
 			Detail:         fmt.Sprintf("expected key type: %s, got: %s", containerType.Key(), itemValue.Type()),
 			CollectionType: "map",
 			Truncated:      false,
+			Invalid:        true,
 		}
 	}
 
@@ -185,12 +203,11 @@ func mapContainsDiff(containerValue reflect.Value, item interface{}) CollectionD
 	}
 
 	// Key not found - generate diff
-	keys := containerValue.MapKeys()
+	keys := SortedKeys(containerValue) // Sort for consistent output
 	var keyStrings []string
 	for _, key := range keys {
 		keyStrings = append(keyStrings, fmt.Sprintf("%v", key.Interface()))
 	}
-	sort.Strings(keyStrings) // Sort for consistent output
 
 	var summary strings.Builder
 	summary.WriteString("expected to contain key")
@@ -237,6 +254,7 @@ func stringContainsDiff(container string, item interface{}) CollectionDiffResult
 			Detail:         fmt.Sprintf("item must be string, rune, or byte for string containers, got: %T", item),
 			CollectionType: "string",
 			Truncated:      false,
+			Invalid:        true,
 		}
 	}
 
@@ -283,9 +301,12 @@ func formatCollectionContent(containerValue reflect.Value, maxElements int) (str
 	}
 
 	switch containerValue.Kind() {
+	case reflect.Chan:
+		// Buffered values cannot be read without receiving them
+		return fmt.Sprintf("<%d buffered values>", length), false
 	case reflect.Map:
-		// Handle maps specially
-		keys := containerValue.MapKeys()
+		// Handle maps specially, in sorted key order so truncation is stable
+		keys := SortedKeys(containerValue)
 		for i := 0; i < displayCount && i < len(keys); i++ {
 			key := keys[i]
 			value := containerValue.MapIndex(key)
@@ -298,7 +319,7 @@ func formatCollectionContent(containerValue reflect.Value, maxElements int) (str
 			elements = append(elements, fmt.Sprintf("%q", str[i:i+1]))
 		}
 	default:
-		// Handle slices, arrays, and channels
+		// Handle slices and arrays
 		for i := 0; i < displayCount; i++ {
 			element := containerValue.Index(i).Interface()
 			elements = append(elements, fmt.Sprintf("%v", element))
@@ -0,0 +1,16 @@
+package diff
+
+import "fmt"
+
+// ExampleCollectionContainsDiff demonstrates rendering a containment failure
+// in a custom assertion as Contains renders it.
+func ExampleCollectionContainsDiff() {
+	result := CollectionContainsDiff([]string{"admin", "editor"}, "owner")
+	if result.HasDiff {
+		fmt.Printf("%s\n%s\n", result.Summary, result.Detail)
+	}
+	// Output:
+	// expected to contain element
+	// missing from collection: owner
+	// collection content: [admin editor]
+}
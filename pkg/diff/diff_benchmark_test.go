@@ -0,0 +1,91 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchmarkDocument builds a multi-line document with changes every interval lines.
+func benchmarkDocument(lines, interval int, changed bool) string {
+	var b strings.Builder
+	for i := 0; i < lines; i++ {
+		if changed && i%interval == 0 {
+			fmt.Fprintf(&b, "line %d: changed value\n", i)
+			continue
+		}
+		fmt.Fprintf(&b, "line %d: the quick brown fox jumps over the lazy dog\n", i)
+	}
+	return b.String()
+}
+
+// BenchmarkEnhancedMultiLineStringDiff measures the failure path for multi-line strings.
+func BenchmarkEnhancedMultiLineStringDiff(b *testing.B) {
+	for _, size := range []int{20, 500, 5000} {
+		got := benchmarkDocument(size, 10, false)
+		want := benchmarkDocument(size, 10, true)
+
+		b.Run(fmt.Sprintf("Lines%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				EnhancedMultiLineStringDiff(got, want, 3)
+			}
+		})
+	}
+}
+
+// BenchmarkLargeSuiteFailures simulates a suite in which many small assertions fail,
+// where buffer reuse across comparisons matters most.
+func BenchmarkLargeSuiteFailures(b *testing.B) {
+	cases := make([][2]string, 100)
+	for i := range cases {
+		cases[i] = [2]string{
+			fmt.Sprintf("id: %d\nname: user%d\nactive: true\n", i, i),
+			fmt.Sprintf("id: %d\nname: user%d\nactive: false\n", i, i),
+		}
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, c := range cases {
+			result := Compare(c[0], c[1], DefaultOptions())
+			_ = result.Render(FormatUnified)
+		}
+	}
+}
+
+// BenchmarkStringDiff measures single-line string diffs with and without context.
+func BenchmarkStringDiff(b *testing.B) {
+	got := strings.Repeat("abcdefghij", 20) + "X" + strings.Repeat("klmnopqrst", 20)
+	want := strings.Repeat("abcdefghij", 20) + "Y" + strings.Repeat("klmnopqrst", 20)
+
+	b.Run("Plain", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			StringDiff(got, want)
+		}
+	})
+
+	b.Run("WithContext", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			StringDiffWithContext(got, want, 10)
+		}
+	})
+}
+
+// BenchmarkValueDiff measures structural diffs of nested values.
+func BenchmarkValueDiff(b *testing.B) {
+	got := make([]config, 200)
+	for i := range got {
+		got[i] = baseConfig()
+	}
+	want := make([]config, len(got))
+	copy(want, got)
+	want[100].Server.Port = 1
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValueDiff(got, want)
+	}
+}
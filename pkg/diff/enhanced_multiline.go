@@ -0,0 +1,351 @@
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxHunks bounds the number of hunks collected for a single comparison so that
+// pathological inputs cannot produce an unbounded failure message.
+const MaxHunks = 50
+
+// anchorWindow bounds how far ahead the hunk finder looks for a pair of equal
+// lines after a mismatch. Keeping it small keeps huge inputs linear.
+const anchorWindow = 100
+
+// Hunk describes one contiguous block of differing lines.
+type Hunk struct {
+	GotStart  int      // First line of the block in got (1-indexed)
+	GotCount  int      // Number of lines from got in the block
+	WantStart int      // First line of the block in want (1-indexed)
+	WantCount int      // Number of lines from want in the block
+	Removed   []string // Lines present only in got, without line endings
+	Added     []string // Lines present only in want, without line endings
+}
+
+// EnhancedDiffResult represents enhanced multi-line diff results with context and unified output
+type EnhancedDiffResult struct {
+	HasDiff        bool   // Whether the strings differ
+	LineNumber     *int   // Line number where strings first differ (1-indexed, nil if no difference)
+	ContextLines   string // Lines around the difference with context window
+	UnifiedDiff    string // Unified diff format output
+	SideBySideDiff string // Side-by-side diff format output
+	Hunks          []Hunk // Every differing block, bounded by MaxHunks
+	OmittedHunks   int    // Number of further hunks found beyond MaxHunks
+}
+
+// EnhancedMultiLineStringDiff compares multi-line strings with enhanced context and formatting
+func EnhancedMultiLineStringDiff(got, want string, contextLines int) EnhancedDiffResult {
+	return compareLines(got, want, contextLines, MaxHunks)
+}
+
+// compareLines implements EnhancedMultiLineStringDiff with a configurable hunk bound.
+func compareLines(got, want string, contextLines, maxHunks int) EnhancedDiffResult {
+	// Fast path: identical strings (avoids expensive line splitting)
+	if got == want {
+		// Still generate side-by-side for consistency, but with minimal work
+		gotLines := getLines(got)
+		defer putLines(gotLines)
+		sideBySideDiff := generateSideBySideDiff(*gotLines, *gotLines) // Same lines for both sides
+
+		return EnhancedDiffResult{
+			HasDiff:        false,
+			LineNumber:     nil,
+			ContextLines:   "",
+			UnifiedDiff:    "",
+			SideBySideDiff: sideBySideDiff,
+		}
+	}
+
+	// Split into lines for comparison (only when strings differ). The pooled
+	// slices are released on return; results only hold substrings of the input.
+	gotPooled, wantPooled := getLines(got), getLines(want)
+	defer putLines(gotPooled)
+	defer putLines(wantPooled)
+	gotLines, wantLines := *gotPooled, *wantPooled
+
+	// Hunk detection is linear in the input size, so it runs over the whole
+	// input regardless of size; only the side-by-side view is skipped for
+	// very large inputs as it grows with every line rather than every change.
+	const maxStringLength = 50000 // 50KB limit for side-by-side rendering
+	const maxLines = 1000
+	sideBySideDiff := "Diff too large for side-by-side display"
+	if len(got) <= maxStringLength && len(want) <= maxStringLength &&
+		len(gotLines) <= maxLines && len(wantLines) <= maxLines {
+		sideBySideDiff = generateSideBySideDiff(gotLines, wantLines)
+	}
+
+	// Find first differing line
+	minLines := len(gotLines)
+	if len(wantLines) < minLines {
+		minLines = len(wantLines)
+	}
+
+	lineNum := 0
+	for i := 0; i < minLines; i++ {
+		if gotLines[i] != wantLines[i] {
+			lineNum = i + 1 // 1-indexed line numbers
+			break
+		}
+	}
+
+	// If all lines match but lengths differ
+	if lineNum == 0 && len(gotLines) != len(wantLines) {
+		lineNum = minLines + 1
+	}
+
+	// Generate context window around the difference
+	contextStr := generateContextWindow(gotLines, wantLines, lineNum-1, contextLines)
+
+	// Collect every hunk and render them as a unified diff
+	hunks, omitted := findHunks(gotLines, wantLines, maxHunks, sameLine)
+	unifiedDiff := generateUnifiedDiff(hunks, omitted)
+
+	return EnhancedDiffResult{
+		HasDiff:        true,
+		LineNumber:     &lineNum,
+		ContextLines:   contextStr,
+		UnifiedDiff:    unifiedDiff,
+		SideBySideDiff: sideBySideDiff,
+		Hunks:          hunks,
+		OmittedHunks:   omitted,
+	}
+}
+
+// findHunks walks both inputs and groups differing lines into hunks. After a
+// mismatch it looks ahead for the nearest pair of equal lines, so a single
+// inserted or removed line re-anchors the comparison instead of misaligning
+// everything that follows. At most limit hunks are returned; the number of
+// further hunks is reported separately. Lines are compared with equal.
+func findHunks(gotLines, wantLines []string, limit int, equal func(a, b string) bool) ([]Hunk, int) {
+	var hunks []Hunk
+	omitted := 0
+
+	i, j := 0, 0
+	for i < len(gotLines) || j < len(wantLines) {
+		// Skip identical lines
+		for i < len(gotLines) && j < len(wantLines) && equal(gotLines[i], wantLines[j]) {
+			i++
+			j++
+		}
+
+		if i >= len(gotLines) && j >= len(wantLines) {
+			break
+		}
+
+		endI, endJ := nextAnchor(gotLines, wantLines, i, j, equal)
+		if len(hunks) < limit {
+			hunks = append(hunks, newHunk(gotLines, wantLines, i, endI, j, endJ))
+		} else {
+			omitted++
+		}
+
+		i, j = endI, endJ
+	}
+
+	return hunks, omitted
+}
+
+// nextAnchor returns the closest position past (i, j) at which both inputs
+// agree again, or where both are exhausted. Positions are searched in order of
+// total distance so the smallest plausible hunk wins.
+func nextAnchor(gotLines, wantLines []string, i, j int, equal func(a, b string) bool) (int, int) {
+	for d := 1; d <= anchorWindow; d++ {
+		for di := 0; di <= d; di++ {
+			gi, wj := i+di, j+d-di
+			if gi > len(gotLines) || wj > len(wantLines) {
+				continue
+			}
+			if gi == len(gotLines) && wj == len(wantLines) {
+				return gi, wj
+			}
+			if gi < len(gotLines) && wj < len(wantLines) && equal(gotLines[gi], wantLines[wj]) {
+				return gi, wj
+			}
+		}
+	}
+
+	// No anchor within the window: consume a window's worth of both inputs
+	endI, endJ := i+anchorWindow, j+anchorWindow
+	if endI > len(gotLines) {
+		endI = len(gotLines)
+	}
+	if endJ > len(wantLines) {
+		endJ = len(wantLines)
+	}
+	return endI, endJ
+}
+
+// newHunk builds a Hunk from the half-open line ranges [i, endI) and [j, endJ).
+func newHunk(gotLines, wantLines []string, i, endI, j, endJ int) Hunk {
+	hunk := Hunk{
+		GotStart:  i + 1,
+		GotCount:  endI - i,
+		WantStart: j + 1,
+		WantCount: endJ - j,
+	}
+	if endI > i {
+		hunk.Removed = make([]string, 0, endI-i)
+	}
+	if endJ > j {
+		hunk.Added = make([]string, 0, endJ-j)
+	}
+	for k := i; k < endI; k++ {
+		hunk.Removed = append(hunk.Removed, strings.TrimSuffix(gotLines[k], "\n"))
+	}
+	for k := j; k < endJ; k++ {
+		hunk.Added = append(hunk.Added, strings.TrimSuffix(wantLines[k], "\n"))
+	}
+	return hunk
+}
+
+// sameLine compares two lines while ignoring their line endings.
+func sameLine(a, b string) bool {
+	return strings.TrimSuffix(a, "\n") == strings.TrimSuffix(b, "\n")
+}
+
+// generateContextWindow creates a context window around the differing line
+func generateContextWindow(gotLines, wantLines []string, diffLineIdx, contextLines int) string {
+	if len(gotLines) == 0 && len(wantLines) == 0 {
+		return ""
+	}
+
+	// Calculate the range of lines to show
+	start := diffLineIdx - contextLines
+	if start < 0 {
+		start = 0
+	}
+
+	maxLines := len(gotLines)
+	if len(wantLines) > maxLines {
+		maxLines = len(wantLines)
+	}
+
+	end := diffLineIdx + contextLines + 1
+	if end > maxLines {
+		end = maxLines
+	}
+
+	contextBuilder := getBuffer()
+
+	// Show context lines
+	for i := start; i < end; i++ {
+		var gotLine, wantLine string
+
+		if i < len(gotLines) {
+			gotLine = strings.TrimSuffix(gotLines[i], "\n")
+		}
+		if i < len(wantLines) {
+			wantLine = strings.TrimSuffix(wantLines[i], "\n")
+		}
+
+		// Add line to context
+		if i == diffLineIdx {
+			// This is the differing line - show both versions
+			if gotLine != "" {
+				writeLine(contextBuilder, "- ", gotLine)
+			}
+			if wantLine != "" {
+				writeLine(contextBuilder, "+ ", wantLine)
+			}
+		} else if gotLine == wantLine {
+			// Identical context line
+			writeLine(contextBuilder, "  ", gotLine)
+		} else {
+			// Different context line
+			if gotLine != "" {
+				writeLine(contextBuilder, "- ", gotLine)
+			}
+			if wantLine != "" {
+				writeLine(contextBuilder, "+ ", wantLine)
+			}
+		}
+	}
+
+	return bufferString(contextBuilder)
+}
+
+// generateUnifiedDiff creates a unified diff format output from the collected hunks
+func generateUnifiedDiff(hunks []Hunk, omitted int) string {
+	result := getBuffer()
+
+	// Header
+	result.WriteString("--- got\n")
+	result.WriteString("+++ want\n")
+
+	for _, hunk := range hunks {
+		// Output hunk header
+		fmt.Fprintf(result, "@@ -%d,%d +%d,%d @@\n", hunk.GotStart, hunk.GotCount, hunk.WantStart, hunk.WantCount)
+
+		// Output removed lines
+		for _, line := range hunk.Removed {
+			writeLine(result, "-", line)
+		}
+
+		// Output added lines
+		for _, line := range hunk.Added {
+			writeLine(result, "+", line)
+		}
+	}
+
+	if omitted > 0 {
+		fmt.Fprintf(result, "... (%d more hunks omitted)\n", omitted)
+	}
+
+	return bufferString(result)
+}
+
+// generateSideBySideDiff creates a side-by-side diff format output
+func generateSideBySideDiff(gotLines, wantLines []string) string {
+	result := getBuffer()
+
+	// Headers
+	result.WriteString("Got                           | Want\n")
+	result.WriteString("------------------------------|------------------------------\n")
+
+	// Calculate max lines to process
+	maxLines := len(gotLines)
+	if len(wantLines) > maxLines {
+		maxLines = len(wantLines)
+	}
+
+	// Process each line
+	for i := 0; i < maxLines; i++ {
+		var gotLine, wantLine string
+
+		// Get lines, using empty string if beyond array bounds
+		if i < len(gotLines) {
+			gotLine = strings.TrimSuffix(gotLines[i], "\n")
+		}
+		if i < len(wantLines) {
+			wantLine = strings.TrimSuffix(wantLines[i], "\n")
+		}
+
+		// Truncate lines if too long for display (keep first 29 chars)
+		if len(gotLine) > 29 {
+			gotLine = gotLine[:26] + "..."
+		}
+		if len(wantLine) > 29 {
+			wantLine = wantLine[:26] + "..."
+		}
+
+		// Format the line pair with proper alignment, as "%-29s | %s" would
+		result.WriteString(gotLine)
+		for pad := utf8.RuneCountInString(gotLine); pad < 29; pad++ {
+			result.WriteByte(' ')
+		}
+		result.WriteString(" | ")
+		writeLine(result, "", wantLine)
+	}
+
+	return bufferString(result)
+}
+
+// writeLine writes prefix, line and a newline without formatting overhead.
+func writeLine(b *bytes.Buffer, prefix, line string) {
+	b.WriteString(prefix)
+	b.WriteString(line)
+	b.WriteByte('\n')
+}
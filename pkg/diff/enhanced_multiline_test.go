@@ -0,0 +1,141 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestEnhancedMultiLineHunks tests that every differing block is reported as a hunk.
+func TestEnhancedMultiLineHunks(t *testing.T) {
+	tests := []struct {
+		name        string
+		got, want   string
+		expectHunks []Hunk
+	}{
+		{
+			name: "single changed line",
+			got:  "a\nb\nc",
+			want: "a\nB\nc",
+			expectHunks: []Hunk{
+				{GotStart: 2, GotCount: 1, WantStart: 2, WantCount: 1, Removed: []string{"b"}, Added: []string{"B"}},
+			},
+		},
+		{
+			name: "two separate changes",
+			got:  "a\nb\nc\nd\ne",
+			want: "a\nB\nc\nd\nE",
+			expectHunks: []Hunk{
+				{GotStart: 2, GotCount: 1, WantStart: 2, WantCount: 1, Removed: []string{"b"}, Added: []string{"B"}},
+				{GotStart: 5, GotCount: 1, WantStart: 5, WantCount: 1, Removed: []string{"e"}, Added: []string{"E"}},
+			},
+		},
+		{
+			name: "inserted line re-anchors the remainder",
+			got:  "a\nb\nc\nd",
+			want: "a\nx\nb\nc\nD",
+			expectHunks: []Hunk{
+				{GotStart: 2, GotCount: 0, WantStart: 2, WantCount: 1, Added: []string{"x"}},
+				{GotStart: 4, GotCount: 1, WantStart: 5, WantCount: 1, Removed: []string{"d"}, Added: []string{"D"}},
+			},
+		},
+		{
+			name: "removed trailing lines",
+			got:  "a\nb\nc",
+			want: "a",
+			expectHunks: []Hunk{
+				{GotStart: 2, GotCount: 2, WantStart: 2, WantCount: 0, Removed: []string{"b", "c"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EnhancedMultiLineStringDiff(tt.got, tt.want, 3)
+
+			if !result.HasDiff {
+				t.Fatalf("Expected a difference")
+			}
+			if result.OmittedHunks != 0 {
+				t.Errorf("Expected no omitted hunks, got %d", result.OmittedHunks)
+			}
+			if len(result.Hunks) != len(tt.expectHunks) {
+				t.Fatalf("Expected %d hunks, got %d: %+v", len(tt.expectHunks), len(result.Hunks), result.Hunks)
+			}
+			for i, want := range tt.expectHunks {
+				got := result.Hunks[i]
+				if got.GotStart != want.GotStart || got.GotCount != want.GotCount ||
+					got.WantStart != want.WantStart || got.WantCount != want.WantCount {
+					t.Errorf("Hunk %d: expected ranges %+v, got %+v", i, want, got)
+				}
+				if strings.Join(got.Removed, "|") != strings.Join(want.Removed, "|") {
+					t.Errorf("Hunk %d: expected removed %q, got %q", i, want.Removed, got.Removed)
+				}
+				if strings.Join(got.Added, "|") != strings.Join(want.Added, "|") {
+					t.Errorf("Hunk %d: expected added %q, got %q", i, want.Added, got.Added)
+				}
+			}
+		})
+	}
+}
+
+// TestEnhancedMultiLineUnifiedShowsEveryHunk tests that the unified output lists all hunks.
+func TestEnhancedMultiLineUnifiedShowsEveryHunk(t *testing.T) {
+	result := EnhancedMultiLineStringDiff("a\nb\nc\nd\ne", "a\nB\nc\nd\nE", 3)
+
+	for _, header := range []string{"@@ -2,1 +2,1 @@", "@@ -5,1 +5,1 @@"} {
+		if !strings.Contains(result.UnifiedDiff, header) {
+			t.Errorf("Expected unified diff to contain %q, got:\n%s", header, result.UnifiedDiff)
+		}
+	}
+}
+
+// TestEnhancedMultiLineHugeInput tests that inputs beyond the side-by-side limits still report hunks.
+func TestEnhancedMultiLineHugeInput(t *testing.T) {
+	var gotBuilder, wantBuilder strings.Builder
+	for i := 1; i <= 5000; i++ {
+		line := fmt.Sprintf("line %d with some padding to make the input large", i)
+		gotBuilder.WriteString(line + "\n")
+		if i == 10 || i == 4500 {
+			line = "changed"
+		}
+		wantBuilder.WriteString(line + "\n")
+	}
+
+	result := EnhancedMultiLineStringDiff(gotBuilder.String(), wantBuilder.String(), 3)
+
+	if result.LineNumber == nil || *result.LineNumber != 10 {
+		t.Errorf("Expected first difference at line 10, got %v", result.LineNumber)
+	}
+	if len(result.Hunks) != 2 {
+		t.Fatalf("Expected 2 hunks across the whole input, got %d", len(result.Hunks))
+	}
+	if result.Hunks[1].GotStart != 4500 {
+		t.Errorf("Expected second hunk at line 4500, got %d", result.Hunks[1].GotStart)
+	}
+	if !strings.Contains(result.SideBySideDiff, "too large") {
+		t.Errorf("Expected side-by-side output to be skipped for huge input, got %q", result.SideBySideDiff[:40])
+	}
+}
+
+// TestEnhancedMultiLineHunkLimit tests that hunks beyond MaxHunks are counted rather than listed.
+func TestEnhancedMultiLineHunkLimit(t *testing.T) {
+	var gotBuilder, wantBuilder strings.Builder
+	total := MaxHunks + 7
+	for i := 0; i < total; i++ {
+		gotBuilder.WriteString(fmt.Sprintf("same %d\ngot %d\n", i, i))
+		wantBuilder.WriteString(fmt.Sprintf("same %d\nwant %d\n", i, i))
+	}
+
+	result := EnhancedMultiLineStringDiff(gotBuilder.String(), wantBuilder.String(), 3)
+
+	if len(result.Hunks) != MaxHunks {
+		t.Errorf("Expected %d hunks, got %d", MaxHunks, len(result.Hunks))
+	}
+	if result.OmittedHunks != 7 {
+		t.Errorf("Expected 7 omitted hunks, got %d", result.OmittedHunks)
+	}
+	if !strings.Contains(result.UnifiedDiff, "7 more hunks omitted") {
+		t.Errorf("Expected unified diff to note omitted hunks")
+	}
+}
@@ -0,0 +1,88 @@
+// Package diff provides the difference engine behind GoWise failure messages.
+//
+// The same comparisons that power assertions such as Equal, Contains and Len are
+// exported here so that custom assertions and tooling can produce output that
+// reads exactly like GoWise's own:
+//
+//	result := diff.Compare(got, want, diff.DefaultOptions())
+//	if result.HasDiff {
+//		t.Errorf("config differs:\n%s", result.Render(diff.FormatUnified))
+//	}
+//
+// Lower-level helpers (StringDiff, StringDiffWithContext, UnicodeStringDiff,
+// MultiLineStringDiff, EnhancedMultiLineStringDiff, CollectionContainsDiff and
+// CollectionLenDiff) remain available for callers needing a specific algorithm.
+package diff
+
+// Format selects how a multi-line diff is rendered.
+type Format int
+
+const (
+	// FormatContext shows lines around the first difference with +/- indicators
+	FormatContext Format = iota
+	// FormatUnified shows every hunk in unified diff format with @@ headers
+	FormatUnified
+	// FormatSideBySide shows got and want in two aligned columns
+	FormatSideBySide
+)
+
+// String returns the name of the format.
+func (f Format) String() string {
+	switch f {
+	case FormatContext:
+		return "context"
+	case FormatUnified:
+		return "unified"
+	case FormatSideBySide:
+		return "side-by-side"
+	default:
+		return "unknown"
+	}
+}
+
+// Options configures Compare.
+type Options struct {
+	// ContextLines is the number of unchanged lines shown around the first difference.
+	ContextLines int
+	// MaxHunks bounds the number of hunks collected; zero uses MaxHunks.
+	MaxHunks int
+	// MaxLineBytes bounds a single line read by Readers; zero allows up to 1MB.
+	MaxLineBytes int
+}
+
+// DefaultOptions returns the options GoWise assertions use for typical inputs.
+func DefaultOptions() Options {
+	return Options{
+		ContextLines: 3,
+		MaxHunks:     MaxHunks,
+	}
+}
+
+// Compare diffs two multi-line strings using the given options.
+func Compare(got, want string, opts Options) EnhancedDiffResult {
+	if opts.ContextLines < 0 {
+		opts.ContextLines = 0
+	}
+	if opts.MaxHunks <= 0 {
+		opts.MaxHunks = MaxHunks
+	}
+
+	return compareLines(got, want, opts.ContextLines, opts.MaxHunks)
+}
+
+// Render returns the diff in the requested format, or an empty string when
+// the compared values were identical.
+func (r EnhancedDiffResult) Render(format Format) string {
+	if !r.HasDiff {
+		return ""
+	}
+
+	switch format {
+	case FormatUnified:
+		return r.UnifiedDiff
+	case FormatSideBySide:
+		return r.SideBySideDiff
+	default:
+		return r.ContextLines
+	}
+}
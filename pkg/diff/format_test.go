@@ -0,0 +1,82 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestCompare tests the public Compare entry point and its options.
+func TestCompare(t *testing.T) {
+	t.Run("identical input", func(t *testing.T) {
+		result := Compare("a\nb", "a\nb", DefaultOptions())
+		if result.HasDiff {
+			t.Errorf("Expected no difference for identical input")
+		}
+		if rendered := result.Render(FormatUnified); rendered != "" {
+			t.Errorf("Expected empty rendering for identical input, got %q", rendered)
+		}
+	})
+
+	t.Run("custom hunk bound", func(t *testing.T) {
+		result := Compare("a\nb\nc\nd\ne", "A\nb\nC\nd\nE", Options{ContextLines: 1, MaxHunks: 2})
+		if len(result.Hunks) != 2 {
+			t.Errorf("Expected 2 hunks, got %d", len(result.Hunks))
+		}
+		if result.OmittedHunks != 1 {
+			t.Errorf("Expected 1 omitted hunk, got %d", result.OmittedHunks)
+		}
+	})
+
+	t.Run("negative context is treated as zero", func(t *testing.T) {
+		result := Compare("a\nb\nc", "a\nB\nc", Options{ContextLines: -5})
+		if result.ContextLines != "- b\n+ B" {
+			t.Errorf("Expected only the differing line in context, got %q", result.ContextLines)
+		}
+	})
+}
+
+// TestRender tests that each format returns the corresponding rendering.
+func TestRender(t *testing.T) {
+	result := Compare("a\nb\nc", "a\nB\nc", DefaultOptions())
+
+	tests := []struct {
+		format   Format
+		contains string
+	}{
+		{FormatContext, "- b"},
+		{FormatUnified, "@@ -2,1 +2,1 @@"},
+		{FormatSideBySide, "Got"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			if rendered := result.Render(tt.format); !strings.Contains(rendered, tt.contains) {
+				t.Errorf("Expected %s rendering to contain %q, got:\n%s", tt.format, tt.contains, rendered)
+			}
+		})
+	}
+}
+
+// TestFormatString tests the names of the formats.
+func TestFormatString(t *testing.T) {
+	if FormatContext.String() != "context" || FormatUnified.String() != "unified" ||
+		FormatSideBySide.String() != "side-by-side" || Format(99).String() != "unknown" {
+		t.Errorf("Unexpected format names")
+	}
+}
+
+// ExampleCompare demonstrates reusing the GoWise diff engine in a custom assertion.
+func ExampleCompare() {
+	got := "name: gowise\nversion: 1\nlicence: MIT"
+	want := "name: gowise\nversion: 2\nlicence: MIT"
+
+	result := Compare(got, want, DefaultOptions())
+	fmt.Println(result.Render(FormatUnified))
+	// Output:
+	// --- got
+	// +++ want
+	// @@ -2,1 +2,1 @@
+	// -version: 1
+	// +version: 2
+}
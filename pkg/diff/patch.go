@@ -0,0 +1,97 @@
+package diff
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// patchContextLines is the number of unchanged lines kept around each change,
+// matching the default of diff -u and git diff.
+const patchContextLines = 3
+
+// Patch returns a unified diff that turns oldText into newText, in the format
+// accepted by git apply and patch -p1. path names the file in the a/ and b/
+// headers and should be relative to the directory the patch is applied from.
+// Unlike the diffs used in failure messages, lines are compared exactly, so a
+// missing final newline is a change. Patch returns "" for identical texts.
+func Patch(path, oldText, newText string) string {
+	if oldText == newText {
+		return ""
+	}
+
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+	hunks, _ := findHunks(oldLines, newLines, math.MaxInt, exactLine)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+
+	for k := 0; k < len(hunks); {
+		// Merge changes whose surrounding context would overlap
+		last := k
+		for last+1 < len(hunks) && hunks[last+1].GotStart-(hunks[last].GotStart+hunks[last].GotCount) <= 2*patchContextLines {
+			last++
+		}
+		first, end := hunks[k], hunks[last]
+
+		oldStart := first.GotStart - 1 - patchContextLines
+		if oldStart < 0 {
+			oldStart = 0
+		}
+		oldEnd := end.GotStart - 1 + end.GotCount + patchContextLines
+		if oldEnd > len(oldLines) {
+			oldEnd = len(oldLines)
+		}
+		newStart := oldStart + first.WantStart - first.GotStart
+		newEnd := oldEnd + (end.WantStart + end.WantCount) - (end.GotStart + end.GotCount)
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", patchRange(oldStart, oldEnd-oldStart), patchRange(newStart, newEnd-newStart))
+
+		pos := oldStart
+		for _, hunk := range hunks[k : last+1] {
+			for ; pos < hunk.GotStart-1; pos++ {
+				writePatchLine(&b, ' ', oldLines[pos])
+			}
+			for x := hunk.GotStart - 1; x < hunk.GotStart-1+hunk.GotCount; x++ {
+				writePatchLine(&b, '-', oldLines[x])
+			}
+			for y := hunk.WantStart - 1; y < hunk.WantStart-1+hunk.WantCount; y++ {
+				writePatchLine(&b, '+', newLines[y])
+			}
+			pos = hunk.GotStart - 1 + hunk.GotCount
+		}
+		for ; pos < oldEnd; pos++ {
+			writePatchLine(&b, ' ', oldLines[pos])
+		}
+
+		k = last + 1
+	}
+
+	return b.String()
+}
+
+// patchRange formats a hunk range from a 0-based start. By convention an empty
+// range names the line before it.
+func patchRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// writePatchLine writes one prefixed line, marking a missing final newline.
+func writePatchLine(b *strings.Builder, prefix byte, line string) {
+	b.WriteByte(prefix)
+	b.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		b.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+// exactLine compares two lines including their line endings.
+func exactLine(a, b string) bool {
+	return a == b
+}
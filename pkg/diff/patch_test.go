@@ -0,0 +1,140 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestPatchRoundTrip tests that applying the patch to the old text yields the new text.
+func TestPatchRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+	}{
+		{"changed line", "a\nb\nc\n", "a\nB\nc\n"},
+		{"add final newline", "a\nb\nc", "a\nb\nc\n"},
+		{"remove final newline", "a\nb\nc\n", "a\nb\nc"},
+		{"from empty", "", "x\n"},
+		{"to empty", "x\ny\n", ""},
+		{"distant changes", numberedLines(1, 15), strings.Replace(strings.Replace(numberedLines(1, 15), "2\n", "X\n", 1), "14\n", "Y\n", 1) + "16\n"},
+		{"nearby changes merge", numberedLines(1, 8), "0\n1\n2\n4\n5\n6\n8\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch := Patch("file.txt", tt.old, tt.new)
+
+			applied, err := applyPatch(tt.old, patch)
+			if err != nil {
+				t.Fatalf("Patch did not apply: %v\n%s", err, patch)
+			}
+			if applied != tt.new {
+				t.Errorf("Applying patch produced %q, want %q\n%s", applied, tt.new, patch)
+			}
+		})
+	}
+}
+
+// TestPatchIdentical tests that identical texts produce no patch.
+func TestPatchIdentical(t *testing.T) {
+	if patch := Patch("file.txt", "same\n", "same\n"); patch != "" {
+		t.Errorf("Expected empty patch, got %q", patch)
+	}
+}
+
+// TestPatchSeparateHunks tests that distant changes produce separate hunks with context.
+func TestPatchSeparateHunks(t *testing.T) {
+	old := numberedLines(1, 20)
+	updated := strings.Replace(strings.Replace(old, "\n3\n", "\nthree\n", 1), "\n18\n", "\neighteen\n", 1)
+
+	patch := Patch("testdata/numbers.golden", old, updated)
+
+	for _, header := range []string{"@@ -1,6 +1,6 @@", "@@ -15,6 +15,6 @@"} {
+		if !strings.Contains(patch, header) {
+			t.Errorf("Expected hunk header %q in patch:\n%s", header, patch)
+		}
+	}
+}
+
+func numberedLines(from, to int) string {
+	var b strings.Builder
+	for i := from; i <= to; i++ {
+		b.WriteString(strconv.Itoa(i) + "\n")
+	}
+	return b.String()
+}
+
+// applyPatch applies a single-file unified diff to old, verifying every context
+// and removed line, as git apply does.
+func applyPatch(old, patch string) (string, error) {
+	oldLines := splitLines(old)
+	var out []string
+	pos := 0
+
+	lines := strings.SplitAfter(patch, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if !strings.HasPrefix(line, "@@ ") {
+			continue
+		}
+
+		var oldStart, oldCount, newStart, newCount int
+		if _, err := fmt.Sscanf(line, "@@ -%d,%d +%d,%d @@", &oldStart, &oldCount, &newStart, &newCount); err != nil {
+			return "", fmt.Errorf("bad hunk header %q: %v", line, err)
+		}
+		start := oldStart - 1
+		if oldCount == 0 {
+			start = oldStart
+		}
+		for ; pos < start; pos++ {
+			out = append(out, oldLines[pos])
+		}
+
+		for i+1 < len(lines) && lines[i+1] != "" && !strings.HasPrefix(lines[i+1], "@@ ") {
+			i++
+			body := lines[i]
+			if strings.HasPrefix(body, "\\") {
+				out[len(out)-1] = strings.TrimSuffix(out[len(out)-1], "\n")
+				continue
+			}
+			text := body[1:]
+			if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\\") {
+				text = strings.TrimSuffix(text, "\n")
+				i++
+			}
+			switch body[0] {
+			case ' ', '-':
+				if pos >= len(oldLines) || oldLines[pos] != text {
+					return "", fmt.Errorf("line %d does not match %q", pos+1, text)
+				}
+				if body[0] == ' ' {
+					out = append(out, text)
+				}
+				pos++
+			case '+':
+				out = append(out, text)
+			}
+		}
+	}
+	out = append(out, oldLines[pos:]...)
+
+	return strings.Join(out, ""), nil
+}
+
+// ExamplePatch demonstrates producing a patch that updates an expected-output file.
+func ExamplePatch() {
+	golden := "name: gowise\nversion: 1\n"
+	actual := "name: gowise\nversion: 2\n"
+
+	fmt.Print(Patch("testdata/config.golden", golden, actual))
+	// Output:
+	// diff --git a/testdata/config.golden b/testdata/config.golden
+	// --- a/testdata/config.golden
+	// +++ b/testdata/config.golden
+	// @@ -1,2 +1,2 @@
+	//  name: gowise
+	// -version: 1
+	// +version: 2
+}
@@ -0,0 +1,70 @@
+package diff
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBuffer bounds the capacity of buffers returned to the pool, so that
+// one huge diff does not pin its memory for the rest of the test run.
+const maxPooledBuffer = 64 * 1024
+
+// maxPooledLines bounds the capacity of line slices returned to the pool.
+const maxPooledLines = 4096
+
+// bufferPool holds buffers used to render diff output. A failing suite renders
+// many diffs of similar size, so reusing buffers avoids regrowing a builder
+// from empty for each one.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer returns b to the pool. The buffer must not be used afterwards.
+func putBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBuffer {
+		return
+	}
+	b.Reset()
+	bufferPool.Put(b)
+}
+
+// bufferString returns the contents of b without a trailing newline and
+// releases b to the pool.
+func bufferString(b *bytes.Buffer) string {
+	s := string(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+	putBuffer(b)
+	return s
+}
+
+// linePool holds the slices that inputs are split into for comparison. Lines
+// are substrings of the input, so the slice can be reused once the comparison
+// no longer indexes it.
+var linePool = sync.Pool{
+	New: func() interface{} {
+		lines := make([]string, 0, 64)
+		return &lines
+	},
+}
+
+// getLines splits s into lines, as splitLines does, using a pooled slice.
+// The slice must be released with putLines once no longer referenced.
+func getLines(s string) *[]string {
+	lines := linePool.Get().(*[]string)
+	*lines = appendLines((*lines)[:0], s)
+	return lines
+}
+
+// putLines returns a slice obtained from getLines to the pool.
+func putLines(lines *[]string) {
+	if cap(*lines) > maxPooledLines {
+		return
+	}
+	clear(*lines) // Drop references to the input so it can be collected
+	*lines = (*lines)[:0]
+	linePool.Put(lines)
+}
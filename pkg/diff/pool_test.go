@@ -0,0 +1,48 @@
+package diff
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestPooledBuffersConcurrentUse tests that reused buffers never leak output between comparisons.
+func TestPooledBuffersConcurrentUse(t *testing.T) {
+	inputs := make([][2]string, 50)
+	expected := make([]EnhancedDiffResult, len(inputs))
+	for i := range inputs {
+		inputs[i] = [2]string{
+			fmt.Sprintf("header\nvalue %d\nfooter\n", i),
+			fmt.Sprintf("header\nvalue %d\nfooter\n", i+1000),
+		}
+		expected[i] = Compare(inputs[i][0], inputs[i][1], DefaultOptions())
+	}
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for round := 0; round < 20; round++ {
+				for i, in := range inputs {
+					got := Compare(in[0], in[1], DefaultOptions())
+					if got.UnifiedDiff != expected[i].UnifiedDiff || got.ContextLines != expected[i].ContextLines || got.SideBySideDiff != expected[i].SideBySideDiff {
+						t.Errorf("Result %d changed under concurrent use:\n%s\nwant:\n%s", i, got.UnifiedDiff, expected[i].UnifiedDiff)
+						return
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestPooledLinesRetainHunks tests that hunks remain valid after the line slices are reused.
+func TestPooledLinesRetainHunks(t *testing.T) {
+	first := Compare("a\nb\nc\n", "a\nX\nc\n", DefaultOptions())
+	Compare("1\n2\n3\n", "1\n2\n4\n", DefaultOptions())
+
+	if len(first.Hunks) != 1 || first.Hunks[0].Removed[0] != "b" || first.Hunks[0].Added[0] != "X" {
+		t.Errorf("Expected first result to be unaffected by later comparisons, got %+v", first.Hunks)
+	}
+}
@@ -0,0 +1,188 @@
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// defaultMaxLineBytes bounds a single line read by Readers when Options.MaxLineBytes is zero.
+const defaultMaxLineBytes = 1024 * 1024
+
+// Readers diffs two streams line by line without loading either into memory.
+// Memory use is bounded by the anchor window, the context size and the hunk
+// limit rather than by the size of the input, so it is suitable for large
+// files and HTTP bodies. Lines longer than Options.MaxLineBytes cause an error.
+//
+// The result carries the same hunks, context and unified output as Compare;
+// side-by-side output is not produced for streamed input.
+func Readers(got, want io.Reader, opts Options) (EnhancedDiffResult, error) {
+	if opts.ContextLines < 0 {
+		opts.ContextLines = 0
+	}
+	if opts.MaxHunks <= 0 {
+		opts.MaxHunks = MaxHunks
+	}
+	if opts.MaxLineBytes <= 0 {
+		opts.MaxLineBytes = defaultMaxLineBytes
+	}
+
+	gotLines := newLineStream(got, opts.MaxLineBytes)
+	wantLines := newLineStream(want, opts.MaxLineBytes)
+	state := streamDiff{opts: opts}
+
+	for {
+		if err := gotLines.fill(1); err != nil {
+			return EnhancedDiffResult{}, fmt.Errorf("reading got: %w", err)
+		}
+		if err := wantLines.fill(1); err != nil {
+			return EnhancedDiffResult{}, fmt.Errorf("reading want: %w", err)
+		}
+
+		if len(gotLines.pending) == 0 && len(wantLines.pending) == 0 {
+			break
+		}
+
+		// Matching lines only feed the context window
+		if len(gotLines.pending) > 0 && len(wantLines.pending) > 0 && gotLines.pending[0] == wantLines.pending[0] {
+			state.equalLine(gotLines.pending[0])
+			gotLines.pop(1)
+			wantLines.pop(1)
+			continue
+		}
+
+		// Buffer enough of both streams to look for the next anchor
+		if err := gotLines.fill(anchorWindow + 1); err != nil {
+			return EnhancedDiffResult{}, fmt.Errorf("reading got: %w", err)
+		}
+		if err := wantLines.fill(anchorWindow + 1); err != nil {
+			return EnhancedDiffResult{}, fmt.Errorf("reading want: %w", err)
+		}
+
+		endI, endJ := nextAnchor(gotLines.pending, wantLines.pending, 0, 0, sameLine)
+		state.hunk(newHunk(gotLines.pending, wantLines.pending, 0, endI, 0, endJ), gotLines.consumed, wantLines.consumed)
+		gotLines.pop(endI)
+		wantLines.pop(endJ)
+	}
+
+	return state.result(), nil
+}
+
+// lineStream buffers lines from a reader on demand.
+type lineStream struct {
+	scanner  *bufio.Scanner
+	pending  []string
+	consumed int // Lines popped so far
+	eof      bool
+}
+
+func newLineStream(r io.Reader, maxLineBytes int) *lineStream {
+	scanner := bufio.NewScanner(r)
+	initial := 64 * 1024
+	if maxLineBytes < initial {
+		initial = maxLineBytes
+	}
+	scanner.Buffer(make([]byte, 0, initial), maxLineBytes)
+	scanner.Split(scanRawLines)
+	return &lineStream{scanner: scanner}
+}
+
+// fill reads until at least n lines are pending or the stream is exhausted.
+func (s *lineStream) fill(n int) error {
+	for !s.eof && len(s.pending) < n {
+		if !s.scanner.Scan() {
+			s.eof = true
+			return s.scanner.Err()
+		}
+		s.pending = append(s.pending, s.scanner.Text())
+	}
+	return nil
+}
+
+// pop discards the first n pending lines.
+func (s *lineStream) pop(n int) {
+	s.pending = s.pending[n:]
+	s.consumed += n
+}
+
+// scanRawLines splits on '\n' only, so that a carriage return remains part of
+// the line and CRLF input differs from LF input as it does in Compare.
+func scanRawLines(data []byte, atEOF bool) (int, []byte, error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// streamDiff accumulates the bounded state needed to build a result.
+type streamDiff struct {
+	opts    Options
+	before  []string // Equal lines preceding the first hunk, at most ContextLines
+	after   []string // Equal lines following the first hunk, at most ContextLines
+	hunks   []Hunk
+	omitted int
+}
+
+func (d *streamDiff) equalLine(line string) {
+	if len(d.hunks) == 0 && d.omitted == 0 {
+		d.before = append(d.before, line)
+		if len(d.before) > d.opts.ContextLines {
+			d.before = d.before[1:]
+		}
+		return
+	}
+	if len(d.hunks) == 1 && len(d.after) < d.opts.ContextLines {
+		d.after = append(d.after, line)
+	}
+}
+
+// hunk records a hunk whose ranges are relative to the lines consumed so far.
+func (d *streamDiff) hunk(h Hunk, gotOffset, wantOffset int) {
+	h.GotStart += gotOffset
+	h.WantStart += wantOffset
+
+	if len(d.hunks) >= d.opts.MaxHunks {
+		d.omitted++
+		return
+	}
+	d.hunks = append(d.hunks, h)
+}
+
+func (d *streamDiff) result() EnhancedDiffResult {
+	if len(d.hunks) == 0 {
+		return EnhancedDiffResult{HasDiff: false}
+	}
+
+	var context strings.Builder
+	for _, line := range d.before {
+		context.WriteString("  " + line + "\n")
+	}
+	for _, line := range d.hunks[0].Removed {
+		context.WriteString("- " + line + "\n")
+	}
+	for _, line := range d.hunks[0].Added {
+		context.WriteString("+ " + line + "\n")
+	}
+	for _, line := range d.after {
+		context.WriteString("  " + line + "\n")
+	}
+
+	lineNum := d.hunks[0].GotStart
+	return EnhancedDiffResult{
+		HasDiff:        true,
+		LineNumber:     &lineNum,
+		ContextLines:   strings.TrimSuffix(context.String(), "\n"),
+		UnifiedDiff:    generateUnifiedDiff(d.hunks, d.omitted),
+		SideBySideDiff: "Side-by-side display is not available for streamed input",
+		Hunks:          d.hunks,
+		OmittedHunks:   d.omitted,
+	}
+}
@@ -0,0 +1,142 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// TestReadersMatchesCompare tests that streamed diffs agree with in-memory diffs.
+func TestReadersMatchesCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want string
+	}{
+		{"identical", "a\nb\nc\n", "a\nb\nc\n"},
+		{"changed line", "a\nb\nc", "a\nB\nc"},
+		{"separate changes", "a\nb\nc\nd\ne", "a\nB\nc\nd\nE"},
+		{"insertion", "a\nb\nc\nd", "a\nx\nb\nc\nD"},
+		{"removed tail", "a\nb\nc", "a\n"},
+		{"empty got", "", "a\nb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := Compare(tt.got, tt.want, DefaultOptions())
+
+			got, err := Readers(strings.NewReader(tt.got), strings.NewReader(tt.want), DefaultOptions())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if got.HasDiff != want.HasDiff {
+				t.Fatalf("Expected HasDiff=%v, got %v", want.HasDiff, got.HasDiff)
+			}
+			if got.UnifiedDiff != want.UnifiedDiff {
+				t.Errorf("Unified diff differs from Compare\n  streamed:\n%s\n  in-memory:\n%s", got.UnifiedDiff, want.UnifiedDiff)
+			}
+			if want.HasDiff && *got.LineNumber != *want.LineNumber {
+				t.Errorf("Expected first difference at line %d, got %d", *want.LineNumber, *got.LineNumber)
+			}
+		})
+	}
+}
+
+// TestReadersContext tests the context window around the first difference.
+func TestReadersContext(t *testing.T) {
+	got := "1\n2\n3\n4\n5\n6\n7"
+	want := "1\n2\n3\nfour\n5\n6\n7"
+
+	result, err := Readers(strings.NewReader(got), strings.NewReader(want), Options{ContextLines: 2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "  2\n  3\n- 4\n+ four\n  5\n  6"
+	if result.ContextLines != expected {
+		t.Errorf("Expected context:\n%s\ngot:\n%s", expected, result.ContextLines)
+	}
+}
+
+// TestReadersLargeStream tests diffing streams far larger than any buffer used.
+func TestReadersLargeStream(t *testing.T) {
+	const lines = 200_000
+	got := &generatedLines{total: lines}
+	want := &generatedLines{total: lines, replace: map[int]string{150_000: "changed"}}
+
+	result, err := Readers(got, want, DefaultOptions())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Hunks) != 1 {
+		t.Fatalf("Expected 1 hunk, got %d", len(result.Hunks))
+	}
+	if result.Hunks[0].GotStart != 150_001 {
+		t.Errorf("Expected hunk at line 150001, got %d", result.Hunks[0].GotStart)
+	}
+}
+
+// TestReadersErrors tests read failures and over-long lines.
+func TestReadersErrors(t *testing.T) {
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		_, err := Readers(iotest.ErrReader(readErr), strings.NewReader("a"), DefaultOptions())
+		if !errors.Is(err, readErr) {
+			t.Errorf("Expected wrapped read error, got %v", err)
+		}
+	})
+
+	t.Run("line too long", func(t *testing.T) {
+		long := strings.Repeat("x", 100)
+		_, err := Readers(strings.NewReader(long), strings.NewReader("x"), Options{MaxLineBytes: 10})
+		if err == nil || !strings.Contains(err.Error(), "reading got") {
+			t.Errorf("Expected error for over-long line, got %v", err)
+		}
+	})
+}
+
+// generatedLines is an io.Reader producing numbered lines on demand.
+type generatedLines struct {
+	total   int
+	next    int
+	buf     []byte
+	replace map[int]string
+}
+
+func (g *generatedLines) Read(p []byte) (int, error) {
+	for len(g.buf) == 0 {
+		if g.next >= g.total {
+			return 0, io.EOF
+		}
+		line, ok := g.replace[g.next]
+		if !ok {
+			line = fmt.Sprintf("line %d", g.next)
+		}
+		g.buf = []byte(line + "\n")
+		g.next++
+	}
+	n := copy(p, g.buf)
+	g.buf = g.buf[n:]
+	return n, nil
+}
+
+// ExampleReaders demonstrates diffing two streams without reading them into memory.
+func ExampleReaders() {
+	got := strings.NewReader("status: ok\ncount: 3\n")
+	want := strings.NewReader("status: ok\ncount: 4\n")
+
+	result, err := Readers(got, want, DefaultOptions())
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(result.Render(FormatContext))
+	// Output:
+	//   status: ok
+	// - count: 3
+	// + count: 4
+}
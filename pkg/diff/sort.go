@@ -0,0 +1,127 @@
+package diff
+
+import (
+	"reflect"
+	"sort"
+)
+
+// SortedKeys returns the keys of map m in a deterministic order, so that
+// failure output built by iterating a map is stable across runs. The order
+// matches the one fmt uses when printing maps: numbers, strings and booleans
+// sort naturally (so 2 precedes 10), pointers and channels by address, structs
+// and arrays element by element, and interface values by type before value.
+// It panics if m is not a map.
+func SortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sortValues(keys)
+	return keys
+}
+
+// sortValues sorts values of a single type into the order used by SortedKeys.
+func sortValues(values []reflect.Value) {
+	sort.SliceStable(values, func(i, j int) bool {
+		return compareValues(values[i], values[j]) < 0
+	})
+}
+
+// compareValues orders two values of the same type, returning -1, 0 or 1.
+// Kinds that cannot be map keys compare as equal.
+func compareValues(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareOrdered(a.Uint(), b.Uint())
+	case reflect.String:
+		return compareOrdered(a.String(), b.String())
+	case reflect.Float32, reflect.Float64:
+		return compareFloats(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := compareFloats(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+		return compareFloats(imag(a.Complex()), imag(b.Complex()))
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case a.Bool():
+			return 1
+		default:
+			return -1
+		}
+	case reflect.Ptr, reflect.UnsafePointer, reflect.Chan:
+		return compareOrdered(a.Pointer(), b.Pointer())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return compareNil(a, b)
+		}
+		// Order by dynamic type first so that mixed-type keys group together
+		if c := compareOrdered(a.Elem().Type().String(), b.Elem().Type().String()); c != 0 {
+			return c
+		}
+		if a.Elem().Type() != b.Elem().Type() {
+			// Distinct types sharing a name, e.g. from different packages
+			return compareOrdered(reflect.ValueOf(a.Elem().Type()).Pointer(), reflect.ValueOf(b.Elem().Type()).Pointer())
+		}
+		return compareValues(a.Elem(), b.Elem())
+	default:
+		return 0
+	}
+}
+
+// compareOrdered compares two values of an ordered type.
+func compareOrdered[T int64 | uint64 | uintptr | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareFloats compares two floats, ordering NaN before every other value.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case a == b:
+		return 0
+	case a != a && b != b:
+		return 0
+	case a != a:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareNil orders nil interface values before non-nil ones.
+func compareNil(a, b reflect.Value) int {
+	switch {
+	case a.IsNil() && b.IsNil():
+		return 0
+	case a.IsNil():
+		return -1
+	default:
+		return 1
+	}
+}
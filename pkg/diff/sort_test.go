@@ -0,0 +1,71 @@
+package diff
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// TestSortedKeys tests deterministic ordering for the supported key kinds.
+func TestSortedKeys(t *testing.T) {
+	type point struct{ X, Y int }
+
+	tests := []struct {
+		name string
+		m    interface{}
+		want string
+	}{
+		{"ints sort numerically", map[int]string{10: "", 2: "", -1: "", 33: ""}, "[-1 2 10 33]"},
+		{"strings", map[string]int{"b": 0, "a": 0, "c": 0}, "[a b c]"},
+		{"bools", map[bool]int{true: 0, false: 0}, "[false true]"},
+		{"floats with NaN first", map[float64]int{2.5: 0, math.NaN(): 0, -1: 0}, "[NaN -1 2.5]"},
+		{"structs field by field", map[point]int{{2, 1}: 0, {1, 2}: 0, {1, 1}: 0}, "[{1 1} {1 2} {2 1}]"},
+		{"arrays element by element", map[[2]int]int{{1, 2}: 0, {0, 9}: 0}, "[[0 9] [1 2]]"},
+		{"interfaces by type then value", map[interface{}]int{"b": 0, 2: 0, "a": 0, 1: 0, nil: 0}, "[<nil> 1 2 a b]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeat to catch reliance on map iteration order
+			for i := 0; i < 20; i++ {
+				keys := SortedKeys(reflect.ValueOf(tt.m))
+				rendered := make([]interface{}, len(keys))
+				for k, key := range keys {
+					rendered[k] = key.Interface()
+				}
+				if got := fmt.Sprint(rendered); got != tt.want {
+					t.Fatalf("SortedKeys order = %s, want %s", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestCollectionDisplayIsStable tests that map content in collection diffs does not vary between runs.
+func TestCollectionDisplayIsStable(t *testing.T) {
+	m := map[string]int{}
+	for i := 0; i < 20; i++ {
+		m[fmt.Sprintf("key%02d", i)] = i
+	}
+
+	first := CollectionContainsDiff(m, "missing").Detail
+	for i := 0; i < 20; i++ {
+		if got := CollectionContainsDiff(m, "missing").Detail; got != first {
+			t.Fatalf("Collection detail changed between runs:\n%s\nvs\n%s", first, got)
+		}
+	}
+}
+
+// ExampleSortedKeys demonstrates iterating a map in a stable order for output.
+func ExampleSortedKeys() {
+	retries := map[int]string{10: "ten", 2: "two", 1: "one"}
+
+	for _, key := range SortedKeys(reflect.ValueOf(retries)) {
+		fmt.Println(key.Int(), retries[int(key.Int())])
+	}
+	// Output:
+	// 1 one
+	// 2 two
+	// 10 ten
+}
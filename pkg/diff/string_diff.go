@@ -1,6 +1,9 @@
 package diff
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // DiffResult represents the result of comparing two strings.
 type DiffResult struct {
@@ -208,23 +211,26 @@ func splitLines(s string) []string {
 	if s == "" {
 		return []string{}
 	}
+	return appendLines(make([]string, 0, strings.Count(s, "\n")+1), s)
+}
 
-	var lines []string
+// appendLines appends the lines of s, including their line endings, to dst.
+func appendLines(dst []string, s string) []string {
 	start := 0
 
 	for i := 0; i < len(s); i++ {
 		if s[i] == '\n' {
-			lines = append(lines, s[start:i+1])
+			dst = append(dst, s[start:i+1])
 			start = i + 1
 		}
 	}
 
 	// Add remaining content if it doesn't end with newline
 	if start < len(s) {
-		lines = append(lines, s[start:])
+		dst = append(dst, s[start:])
 	}
 
-	return lines
+	return dst
 }
 
 // UnicodeStringDiff compares strings with Unicode-safe character counting,
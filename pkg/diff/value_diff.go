@@ -0,0 +1,471 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// unchangedMarker replaces a subtree that is identical in both values.
+const unchangedMarker = "… (unchanged)"
+
+// cycleMarker replaces a pair of references back to values enclosing them,
+// whose differences are already rendered above.
+const cycleMarker = "… (cycle)"
+
+// ValueDiffResult represents the result of comparing two values structurally.
+type ValueDiffResult struct {
+	HasDiff bool   // Whether the values differ
+	Diff    string // Annotated rendering: "- " lines from got, "+ " lines from want
+	Changes int    // Number of differing leaves rendered
+	Elided  int    // Number of identical subtrees collapsed
+	Omitted int    // Number of differing fields, elements and entries left out by MaxChanges
+}
+
+// ValueDiffOptions configures ValueDiffWith.
+type ValueDiffOptions struct {
+	// Format renders a changed value, a map key or an extra element. Nil
+	// renders values in Go syntax, as %#v does, in full.
+	Format func(v reflect.Value) string
+	// MaxChanges bounds the differing leaves rendered. Once it is reached,
+	// the remaining differing fields, elements and entries of each value are
+	// counted on a single "… (N more differing …)" line. Zero renders every
+	// difference.
+	MaxChanges int
+}
+
+// ValueDiff compares got and want field by field, element by element and key
+// by key, rendering only the paths that lead to a difference. Identical
+// subtrees collapse to "… (unchanged)", references back to an enclosing
+// value in self-referential structures to "… (cycle)", and runs of identical elements or map
+// entries to a count, so output grows with the size of the change rather
+// than with the size of the values:
+//
+//	  main.Config{
+//	    Name: … (unchanged)
+//	    Server: main.Server{
+//	      Host: … (unchanged)
+//	-     Port: 8080
+//	+     Port: 9090
+//	    }
+//	    Features: … (unchanged)
+//	  }
+//
+// Values of different types are reported as a single changed leaf. Structs
+// without exported fields, such as time.Time, are compared as a whole.
+func ValueDiff(got, want interface{}) ValueDiffResult {
+	return ValueDiffWith(got, want, ValueDiffOptions{})
+}
+
+// ValueDiffWith is ValueDiff with the rendering of values and the number of
+// differences shown controlled by opts, so the diff of very large values
+// stays bounded.
+func ValueDiffWith(got, want interface{}, opts ValueDiffOptions) ValueDiffResult {
+	gv, wv := reflect.ValueOf(got), reflect.ValueOf(want)
+	if valuesEqual(gv, wv, make(map[visit]bool)) {
+		return ValueDiffResult{HasDiff: false}
+	}
+
+	d := valueDiffer{path: make(map[visit]bool), opts: opts}
+	d.node("", gv, wv, 0)
+
+	b := getBuffer()
+	for _, line := range d.lines {
+		b.WriteString(line.marker)
+		for i := 0; i <= line.depth; i++ {
+			b.WriteString("  ")
+		}
+		writeLine(b, "", line.text)
+	}
+
+	return ValueDiffResult{
+		HasDiff: true,
+		Diff:    bufferString(b),
+		Changes: d.changes,
+		Elided:  d.elided,
+		Omitted: d.omitted,
+	}
+}
+
+// maxValueDiffDepth bounds recursion through deeply nested values, such as
+// long linked lists.
+const maxValueDiffDepth = 32
+
+type diffLine struct {
+	marker string // "  ", "- " or "+ "
+	depth  int
+	text   string
+}
+
+// valueDiffer accumulates the rendered lines of a structural diff.
+type valueDiffer struct {
+	lines   []diffLine
+	changes int
+	elided  int
+	omitted int
+	path    map[visit]bool // Pointer, map and slice pairs enclosing the node being rendered
+	opts    ValueDiffOptions
+}
+
+// full reports whether MaxChanges differing leaves have been rendered.
+func (d *valueDiffer) full() bool {
+	return d.opts.MaxChanges > 0 && d.changes >= d.opts.MaxChanges
+}
+
+// format renders a value with the configured Format.
+func (d *valueDiffer) format(v reflect.Value) string {
+	if d.opts.Format != nil && v.IsValid() {
+		return d.opts.Format(v)
+	}
+	return formatReflectValue(v)
+}
+
+// omit records n differing fields, elements or entries left out once the
+// diff is full.
+func (d *valueDiffer) omit(n int, noun string, depth int) {
+	if n == 0 {
+		return
+	}
+	d.omitted += n
+	if n > 1 {
+		noun = pluralNouns[noun]
+	}
+	d.add("  ", depth, fmt.Sprintf("… (%d more differing %s)", n, noun))
+}
+
+var pluralNouns = map[string]string{"field": "fields", "element": "elements", "entry": "entries"}
+
+func (d *valueDiffer) add(marker string, depth int, text string) {
+	d.lines = append(d.lines, diffLine{marker: marker, depth: depth, text: text})
+}
+
+// node renders one position in the tree. label is the field name, index or
+// key prefix ("Port: ", "[3]: "), empty at the root.
+func (d *valueDiffer) node(label string, got, want reflect.Value, depth int) {
+	if valuesEqual(got, want, make(map[visit]bool)) {
+		d.elided++
+		d.add("  ", depth, label+unchangedMarker)
+		return
+	}
+
+	if !got.IsValid() || !want.IsValid() || got.Type() != want.Type() || depth >= maxValueDiffDepth {
+		d.leaf(label, got, want, depth)
+		return
+	}
+
+	// A pair of references already on the path from the root closes a cycle:
+	// following it again would render the same differences without end
+	switch got.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !got.IsNil() && !want.IsNil() {
+			v := visit{got.Pointer(), want.Pointer(), got.Type()}
+			if d.path[v] {
+				d.add("  ", depth, label+cycleMarker)
+				return
+			}
+			d.path[v] = true
+			defer delete(d.path, v)
+		}
+	}
+
+	switch got.Kind() {
+	case reflect.Struct:
+		if !hasExportedFields(got.Type()) {
+			d.leaf(label, got, want, depth)
+			return
+		}
+		d.add("  ", depth, label+got.Type().String()+"{")
+		for i := 0; i < got.NumField(); i++ {
+			if d.full() {
+				d.omit(countDiffering(i, got.NumField(), func(j int) bool {
+					return !valuesEqual(got.Field(j), want.Field(j), make(map[visit]bool))
+				}), "field", depth+1)
+				break
+			}
+			d.node(got.Type().Field(i).Name+": ", got.Field(i), want.Field(i), depth+1)
+		}
+		d.add("  ", depth, "}")
+	case reflect.Slice, reflect.Array:
+		if got.Kind() == reflect.Slice && (got.IsNil() || want.IsNil()) {
+			d.leaf(label, got, want, depth)
+			return
+		}
+		d.add("  ", depth, label+got.Type().String()+"{")
+		d.elements(got, want, depth+1)
+		d.add("  ", depth, "}")
+	case reflect.Map:
+		if got.IsNil() || want.IsNil() {
+			d.leaf(label, got, want, depth)
+			return
+		}
+		d.add("  ", depth, label+got.Type().String()+"{")
+		d.entries(got, want, depth+1)
+		d.add("  ", depth, "}")
+	case reflect.Ptr:
+		if got.IsNil() || want.IsNil() {
+			d.leaf(label, got, want, depth)
+			return
+		}
+		d.node(label+"&", got.Elem(), want.Elem(), depth)
+	case reflect.Interface:
+		if got.IsNil() || want.IsNil() {
+			d.leaf(label, got, want, depth)
+			return
+		}
+		d.node(label, got.Elem(), want.Elem(), depth)
+	default:
+		d.leaf(label, got, want, depth)
+	}
+}
+
+// leaf renders a changed value as a removed and an added line.
+func (d *valueDiffer) leaf(label string, got, want reflect.Value, depth int) {
+	d.changes++
+	if got.IsValid() || !want.IsValid() {
+		d.add("- ", depth, label+d.format(got))
+	}
+	if want.IsValid() || !got.IsValid() {
+		d.add("+ ", depth, label+d.format(want))
+	}
+}
+
+// elements renders slice or array elements, collapsing runs of equal ones.
+func (d *valueDiffer) elements(got, want reflect.Value, depth int) {
+	common := got.Len()
+	if want.Len() < common {
+		common = want.Len()
+	}
+
+	run := 0
+	for i := 0; i < common; i++ {
+		if valuesEqual(got.Index(i), want.Index(i), make(map[visit]bool)) {
+			run++
+			continue
+		}
+		d.unchangedRun(run, "element", depth)
+		run = 0
+		if d.full() {
+			d.omit(countDiffering(i, common, func(j int) bool {
+				return !valuesEqual(got.Index(j), want.Index(j), make(map[visit]bool))
+			}), "element", depth)
+			break
+		}
+		d.node(fmt.Sprintf("[%d]: ", i), got.Index(i), want.Index(i), depth)
+	}
+	d.unchangedRun(run, "element", depth)
+
+	d.extraElements("- ", got, common, depth)
+	d.extraElements("+ ", want, common, depth)
+}
+
+// maxExtraElements bounds the trailing elements listed when lengths differ.
+const maxExtraElements = 10
+
+// extraElements lists the elements of v from index start, which have no
+// counterpart in the other value.
+func (d *valueDiffer) extraElements(marker string, v reflect.Value, start, depth int) {
+	if start < v.Len() {
+		d.changes += v.Len() - start
+	}
+	for i := start; i < v.Len(); i++ {
+		if i-start == maxExtraElements {
+			d.add(marker, depth, fmt.Sprintf("… (%d more elements)", v.Len()-i))
+			return
+		}
+		d.add(marker, depth, fmt.Sprintf("[%d]: %s", i, d.format(v.Index(i))))
+	}
+}
+
+// entries renders map entries in sorted key order, collapsing runs of equal ones.
+func (d *valueDiffer) entries(got, want reflect.Value, depth int) {
+	keys := got.MapKeys()
+	for _, key := range want.MapKeys() {
+		if !got.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+	sortValues(keys)
+
+	differs := func(key reflect.Value) bool {
+		gotValue, wantValue := got.MapIndex(key), want.MapIndex(key)
+		return !gotValue.IsValid() || !wantValue.IsValid() || !valuesEqual(gotValue, wantValue, make(map[visit]bool))
+	}
+
+	run := 0
+	for i, key := range keys {
+		if !differs(key) {
+			run++
+			continue
+		}
+		d.unchangedRun(run, "entry", depth)
+		run = 0
+		if d.full() {
+			d.omit(countDiffering(i, len(keys), func(j int) bool { return differs(keys[j]) }), "entry", depth)
+			break
+		}
+
+		gotValue, wantValue := got.MapIndex(key), want.MapIndex(key)
+		label := d.format(key) + ": "
+		switch {
+		case !wantValue.IsValid():
+			d.changes++
+			d.add("- ", depth, label+d.format(gotValue))
+		case !gotValue.IsValid():
+			d.changes++
+			d.add("+ ", depth, label+d.format(wantValue))
+		default:
+			d.node(label, gotValue, wantValue, depth)
+		}
+	}
+	d.unchangedRun(run, "entry", depth)
+}
+
+// unchangedRun collapses n consecutive identical elements or entries.
+func (d *valueDiffer) unchangedRun(n int, noun string, depth int) {
+	if n == 0 {
+		return
+	}
+	d.elided++
+	if n > 1 {
+		noun = pluralNouns[noun]
+	}
+	d.add("  ", depth, fmt.Sprintf("… (%d unchanged %s)", n, noun))
+}
+
+// countDiffering counts the indexes from start up to end for which differs
+// reports true.
+func countDiffering(start, end int, differs func(i int) bool) int {
+	n := 0
+	for i := start; i < end; i++ {
+		if differs(i) {
+			n++
+		}
+	}
+	return n
+}
+
+// hasExportedFields reports whether a struct type has any exported fields.
+func hasExportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// visit records a pair of pointers already being compared, so that cyclic
+// values terminate, in the manner of reflect.DeepEqual.
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+// valuesEqual is reflect.DeepEqual for reflect.Values, including unexported
+// fields that cannot be converted back to interfaces.
+func valuesEqual(a, b reflect.Value, visited map[visit]bool) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if a.IsNil() != b.IsNil() {
+			return false
+		}
+		if a.Kind() != reflect.Slice && a.Pointer() == b.Pointer() {
+			return true
+		}
+		if a.Kind() == reflect.Slice && a.Len() == b.Len() && (a.Len() == 0 || a.Pointer() == b.Pointer()) {
+			return true
+		}
+		v := visit{a.Pointer(), b.Pointer(), a.Type()}
+		if visited[v] {
+			return true
+		}
+		visited[v] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return valuesEqual(a.Elem(), b.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !valuesEqual(a.Field(i), b.Field(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !valuesEqual(a.Index(i), b.Index(i), visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			other := b.MapIndex(iter.Key())
+			if !other.IsValid() || !valuesEqual(iter.Value(), other, visited) {
+				return false
+			}
+		}
+		return true
+	case reflect.Func:
+		// As with reflect.DeepEqual, only nil functions are equal
+		return a.IsNil() && b.IsNil()
+	default:
+		return a.Pointer() == b.Pointer()
+	}
+}
+
+// formatReflectValue renders a value in Go syntax, falling back to a kind-based
+// rendering for unexported fields that cannot be converted to interfaces.
+func formatReflectValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	if v.CanInterface() {
+		return fmt.Sprintf("%#v", v.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.String:
+		return strconv.Quote(v.String())
+	default:
+		return fmt.Sprintf("%s{…}", v.Type())
+	}
+}
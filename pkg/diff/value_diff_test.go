@@ -0,0 +1,264 @@
+package diff
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type server struct {
+	Host string
+	Port int
+}
+
+type config struct {
+	Name     string
+	Server   server
+	Features []string
+	Limits   map[string]int
+	Started  time.Time
+	revision int
+}
+
+func baseConfig() config {
+	return config{
+		Name:     "gowise",
+		Server:   server{Host: "localhost", Port: 8080},
+		Features: []string{"diff", "patch", "golden", "regexp"},
+		Limits:   map[string]int{"cpu": 2, "memory": 512, "disk": 10},
+		Started:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		revision: 1,
+	}
+}
+
+// TestValueDiffEqual tests that equal values, including unexported fields, report no diff.
+func TestValueDiffEqual(t *testing.T) {
+	if result := ValueDiff(baseConfig(), baseConfig()); result.HasDiff {
+		t.Errorf("Expected no diff for equal values, got:\n%s", result.Diff)
+	}
+}
+
+// TestValueDiffElidesUnchangedSubtrees tests that only paths to differences are expanded.
+func TestValueDiffElidesUnchangedSubtrees(t *testing.T) {
+	got := baseConfig()
+	want := baseConfig()
+	want.Server.Port = 9090
+
+	result := ValueDiff(got, want)
+	expected := strings.Join([]string{
+		"    diff.config{",
+		"      Name: … (unchanged)",
+		"      Server: diff.server{",
+		"        Host: … (unchanged)",
+		"-       Port: 8080",
+		"+       Port: 9090",
+		"      }",
+		"      Features: … (unchanged)",
+		"      Limits: … (unchanged)",
+		"      Started: … (unchanged)",
+		"      revision: … (unchanged)",
+		"    }",
+	}, "\n")
+
+	if result.Diff != expected {
+		t.Errorf("ValueDiff output mismatch\ngot:\n%s\nwant:\n%s", result.Diff, expected)
+	}
+	if result.Changes != 1 || result.Elided != 6 {
+		t.Errorf("Expected 1 change and 6 elided subtrees, got %d and %d", result.Changes, result.Elided)
+	}
+}
+
+// TestValueDiffCollections tests element runs, map entries and unexported fields.
+func TestValueDiffCollections(t *testing.T) {
+	got := baseConfig()
+	want := baseConfig()
+	want.Features = []string{"diff", "patch", "GOLDEN", "regexp", "spy"}
+	want.Limits = map[string]int{"cpu": 4, "memory": 512, "disk": 10, "gpu": 1}
+	want.revision = 2
+
+	result := ValueDiff(&got, &want)
+
+	for _, expected := range []string{
+		"    &diff.config{",
+		"        … (2 unchanged elements)",
+		`-       [2]: "golden"`,
+		`+       [2]: "GOLDEN"`,
+		"        … (1 unchanged element)",
+		`+       [4]: "spy"`,
+		`-       "cpu": 2`,
+		`+       "cpu": 4`,
+		"        … (1 unchanged entry)",
+		`+       "gpu": 1`,
+		"-     revision: 1",
+		"+     revision: 2",
+	} {
+		if !strings.Contains(result.Diff, expected+"\n") {
+			t.Errorf("Expected line %q in diff:\n%s", expected, result.Diff)
+		}
+	}
+	if result.Changes != 5 {
+		t.Errorf("Expected 5 changes, got %d", result.Changes)
+	}
+}
+
+// TestValueDiffProportionalToChange tests that output size does not grow with unchanged data.
+func TestValueDiffProportionalToChange(t *testing.T) {
+	got := make([]config, 10_000)
+	for i := range got {
+		got[i] = baseConfig()
+	}
+	want := make([]config, len(got))
+	copy(want, got)
+	want[5_000].Name = "changed"
+
+	result := ValueDiff(got, want)
+	if lines := strings.Count(result.Diff, "\n") + 1; lines > 15 {
+		t.Errorf("Expected a short diff for a single change, got %d lines:\n%s", lines, result.Diff)
+	}
+
+	longer := append(append([]config{}, got...), make([]config, 1_000)...)
+	result = ValueDiff(got, longer)
+	if !strings.Contains(result.Diff, "… (990 more elements)") {
+		t.Errorf("Expected extra elements to be truncated, got:\n%s", result.Diff)
+	}
+}
+
+// TestValueDiffWithOptions tests that Format renders changed values and keys
+// and that MaxChanges counts the differences it leaves out.
+func TestValueDiffWithOptions(t *testing.T) {
+	type limits struct {
+		A, B, C, D int
+		Labels     map[string]string
+	}
+	got := limits{1, 2, 3, 4, map[string]string{"a": "1", "b": "2", "c": "3"}}
+	want := limits{1, 20, 30, 40, map[string]string{"a": "10", "b": "20", "c": "3"}}
+	short := func(v reflect.Value) string { return fmt.Sprintf("<%v>", v) }
+
+	result := ValueDiffWith(got, want, ValueDiffOptions{Format: short, MaxChanges: 2})
+	for _, expected := range []string{"-     B: <2>", "+     C: <30>", "      … (2 more differing fields)"} {
+		if !strings.Contains(result.Diff, expected) {
+			t.Errorf("Expected %q in diff:\n%s", expected, result.Diff)
+		}
+	}
+	if strings.Contains(result.Diff, "D:") || result.Changes != 2 || result.Omitted != 2 {
+		t.Errorf("Expected 2 changes shown and 2 omitted, got %d and %d:\n%s", result.Changes, result.Omitted, result.Diff)
+	}
+
+	result = ValueDiffWith(got.Labels, want.Labels, ValueDiffOptions{Format: short, MaxChanges: 1})
+	if !strings.Contains(result.Diff, "-     <a>: <1>") || !strings.Contains(result.Diff, "      … (1 more differing entry)") {
+		t.Errorf("Expected one entry and a count of the rest, got:\n%s", result.Diff)
+	}
+}
+
+// TestValueDiffTypeMismatch tests that differing types are reported as one leaf.
+func TestValueDiffTypeMismatch(t *testing.T) {
+	result := ValueDiff([]interface{}{1, "a"}, []interface{}{1, 2})
+	if !strings.Contains(result.Diff, `-     [1]: "a"`) || !strings.Contains(result.Diff, "+     [1]: 2") {
+		t.Errorf("Expected mismatched element types to be shown as a leaf, got:\n%s", result.Diff)
+	}
+}
+
+// TestValueDiffCycle tests that self-referential values terminate.
+func TestValueDiffCycle(t *testing.T) {
+	type node struct {
+		Value int
+		Next  *node
+	}
+	a := &node{Value: 1}
+	a.Next = a
+	b := &node{Value: 1}
+	b.Next = b
+
+	if result := ValueDiff(a, b); result.HasDiff {
+		t.Errorf("Expected equal cyclic values, got:\n%s", result.Diff)
+	}
+
+	c := &node{Value: 2}
+	c.Next = c
+	result := ValueDiff(a, c)
+	if result.Changes != 1 || strings.Count(result.Diff, "Value:") != 2 {
+		t.Errorf("Expected the differing value once, got %d changes:\n%s", result.Changes, result.Diff)
+	}
+	if !strings.Contains(result.Diff, "Next: "+cycleMarker) {
+		t.Errorf("Expected the back reference to be marked as a cycle, got:\n%s", result.Diff)
+	}
+}
+
+// TestValueDiffCycleThroughCollections tests that cycles through slices and
+// maps, as in parent/child graphs, terminate with each difference rendered
+// once.
+func TestValueDiffCycleThroughCollections(t *testing.T) {
+	type node struct {
+		Name     string
+		Parent   *node
+		Children []*node
+		Index    map[string]*node
+	}
+	tree := func(childName string) *node {
+		root := &node{Name: "root", Index: map[string]*node{}}
+		child := &node{Name: childName, Parent: root}
+		root.Children = []*node{child}
+		root.Index[childName] = child
+		root.Index["self"] = root
+		return root
+	}
+
+	if result := ValueDiff(tree("leaf"), tree("leaf")); result.HasDiff {
+		t.Errorf("Expected equal cyclic graphs, got:\n%s", result.Diff)
+	}
+
+	result := ValueDiff(tree("leaf"), tree("branch"))
+	if !result.HasDiff || !strings.Contains(result.Diff, cycleMarker) {
+		t.Fatalf("Expected a diff with the cycles marked, got:\n%s", result.Diff)
+	}
+	if got := strings.Count(result.Diff, `Name: "leaf"`); got != 1 {
+		t.Errorf("Expected the child's name once, rendered %d times:\n%s", got, result.Diff)
+	}
+}
+
+// TestValueDiffSharedReferencesAreNotCycles tests that a value referenced
+// twice, but not from within itself, is rendered in both places.
+func TestValueDiffSharedReferencesAreNotCycles(t *testing.T) {
+	type pair struct {
+		Left, Right *server
+	}
+	gotShared := &server{Host: "a", Port: 1}
+	wantShared := &server{Host: "a", Port: 2}
+
+	result := ValueDiff(pair{gotShared, gotShared}, pair{wantShared, wantShared})
+	if result.Changes != 2 || strings.Contains(result.Diff, cycleMarker) {
+		t.Errorf("Expected both references rendered as changes, got %d changes:\n%s", result.Changes, result.Diff)
+	}
+}
+
+// ExampleValueDiff demonstrates a structural diff of nested values.
+func ExampleValueDiff() {
+	type Server struct {
+		Host string
+		Port int
+	}
+	type Config struct {
+		Name   string
+		Server Server
+		Tags   []string
+	}
+
+	got := Config{Name: "api", Server: Server{Host: "localhost", Port: 8080}, Tags: []string{"a", "b", "c"}}
+	want := Config{Name: "api", Server: Server{Host: "localhost", Port: 9090}, Tags: []string{"a", "b", "c"}}
+
+	fmt.Println("diff (- got, + want):")
+	fmt.Println(ValueDiff(got, want).Diff)
+	// Output:
+	// diff (- got, + want):
+	//     diff.Config{
+	//       Name: … (unchanged)
+	//       Server: diff.Server{
+	//         Host: … (unchanged)
+	// -       Port: 8080
+	// +       Port: 9090
+	//       }
+	//       Tags: … (unchanged)
+	//     }
+}
@@ -0,0 +1,117 @@
+package doctor
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// ConsumedBody reports BodyContains and BodyMatches assertions on a response
+// whose body was already read, by io.ReadAll, a json.Decoder or an earlier
+// body assertion. A body can be read once, so the assertion sees an empty
+// body and fails, or, worse, passes for a pattern that matches nothing.
+var ConsumedBody = &Analyzer{
+	Name: "consumedbody",
+	Doc:  "report HTTP body assertions on a response whose body was already read",
+	Run:  runConsumedBody,
+}
+
+// bodyFix is the fix suggested for an assertion on a consumed body.
+const bodyFix = "read the body once and assert on the bytes, as in assert.Contains(string(body), want), or make every body assertion before reading it elsewhere"
+
+// bodyEvent is a read of a response's body, or an assignment to the
+// response, in source order.
+type bodyEvent struct {
+	pos      token.Pos
+	response string // The response expression, as in resp
+	read     string // How the body was read, or "" for an assignment
+	assert   bool   // Whether the read is a body assertion
+}
+
+func runConsumedBody(pass *Pass) {
+	for _, file := range pass.Files {
+		pkgs := map[string]string{
+			"io":     importName(file, "io"),
+			"ioutil": importName(file, "io/ioutil"),
+			"json":   importName(file, "encoding/json"),
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			checkBodyReads(pass, bodyEvents(fn.Body, pkgs))
+		}
+	}
+}
+
+// bodyEvents collects the body reads and response assignments in body,
+// ordered as they are evaluated.
+func bodyEvents(body *ast.BlockStmt, pkgs map[string]string) []bodyEvent {
+	var events []bodyEvent
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			// The assignment takes effect after its right-hand side.
+			for _, lhs := range n.Lhs {
+				events = append(events, bodyEvent{pos: n.End(), response: types.ExprString(lhs)})
+			}
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || len(n.Args) == 0 {
+				return true
+			}
+			// Chained assertions are evaluated in the order their method
+			// names appear.
+			at := sel.Sel.Pos()
+			switch {
+			case (sel.Sel.Name == "BodyContains" || sel.Sel.Name == "BodyMatches") && len(n.Args) == 2:
+				events = append(events, bodyEvent{pos: at, response: types.ExprString(n.Args[0]), read: sel.Sel.Name, assert: true})
+			case isPackageMember(sel, pkgs["io"], "ReadAll"), isPackageMember(sel, pkgs["ioutil"], "ReadAll"), isPackageMember(sel, pkgs["json"], "NewDecoder"):
+				if response, ok := responseOf(n.Args[0]); ok {
+					events = append(events, bodyEvent{pos: at, response: response, read: types.ExprString(sel)})
+				}
+			case isPackageMember(sel, pkgs["io"], "Copy") && len(n.Args) == 2:
+				if response, ok := responseOf(n.Args[1]); ok {
+					events = append(events, bodyEvent{pos: at, response: response, read: types.ExprString(sel)})
+				}
+			}
+		}
+		return true
+	})
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].pos < events[j].pos })
+	return events
+}
+
+// responseOf returns the response whose body expr is, as in resp.Body.
+func responseOf(expr ast.Expr) (string, bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Body" {
+		return "", false
+	}
+	return types.ExprString(sel.X), true
+}
+
+// checkBodyReads reports body assertions that follow another read of the
+// same response's body with no assignment to the response between them.
+func checkBodyReads(pass *Pass, events []bodyEvent) {
+	consumed := make(map[string]bodyEvent)
+	for _, event := range events {
+		if event.read == "" {
+			delete(consumed, event.response)
+			continue
+		}
+		if earlier, ok := consumed[event.response]; ok && event.assert {
+			pass.Reportf(event.pos, bodyFix,
+				"%s reads %s.Body, which was already read by %s at line %d, so it sees an empty body",
+				event.read, event.response, earlier.read, pass.Fset.Position(earlier.pos).Line)
+			continue
+		}
+		if _, ok := consumed[event.response]; !ok {
+			consumed[event.response] = event
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Package doctor inspects Go source for common misuses of GoWise, such as an
+// Assert shared across subtests or an Eventually that polls less often than
+// it times out, and suggests a fix for each, and reports which assertions a
+// codebase uses. It backs the gowise doctor and gowise usage commands.
+//
+// Each check is an Analyzer with the shape of a golang.org/x/tools
+// go/analysis pass: a name, documentation and a Run function over a Pass.
+// GoWise depends on the standard library only, so passes work on the syntax
+// tree from go/parser rather than on type-checked packages; they recognise
+// GoWise calls by their import paths and method names, and evaluate only
+// constant expressions.
+//
+// Example:
+//
+//	diagnostics, err := doctor.Dir("./...", doctor.Analyzers...)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	for _, d := range diagnostics {
+//		fmt.Println(d)
+//	}
+package doctor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Analyzer is a check run over the files of a package.
+type Analyzer struct {
+	// Name identifies the analyzer in diagnostics.
+	Name string
+	// Doc describes what the analyzer reports.
+	Doc string
+	// Run inspects pass.Files and reports problems with pass.Reportf.
+	Run func(pass *Pass)
+}
+
+// Pass is the input of an Analyzer: the parsed files of one package.
+type Pass struct {
+	Analyzer *Analyzer
+	Fset     *token.FileSet
+	Files    []*ast.File
+
+	diagnostics *[]Diagnostic
+}
+
+// Reportf reports a problem at pos, with a suggested fix.
+func (p *Pass) Reportf(pos token.Pos, fix, format string, args ...interface{}) {
+	*p.diagnostics = append(*p.diagnostics, Diagnostic{
+		Pos:      p.Fset.Position(pos),
+		Analyzer: p.Analyzer.Name,
+		Message:  fmt.Sprintf(format, args...),
+		Fix:      fix,
+	})
+}
+
+// Diagnostic is a problem found by an Analyzer.
+type Diagnostic struct {
+	Pos      token.Position
+	Analyzer string
+	Message  string
+	// Fix describes how to correct the problem.
+	Fix string
+}
+
+// String renders the diagnostic as the compiler and go vet do, followed by
+// the suggested fix on an indented line.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s [%s]\n\tfix: %s", d.Pos, d.Message, d.Analyzer, d.Fix)
+}
+
+// Analyzers are the checks gowise doctor runs.
+var Analyzers = []*Analyzer{SubtestAssert, EventuallyInterval, ConsumedBody}
+
+// Check runs analyzers over the files of one package and returns their
+// diagnostics, sorted by position.
+//
+// Example:
+//
+//	fset := token.NewFileSet()
+//	file, _ := parser.ParseFile(fset, "user_test.go", src, parser.ParseComments)
+//	for _, d := range doctor.Check(fset, []*ast.File{file}, doctor.Analyzers...) {
+//		fmt.Println(d)
+//	}
+func Check(fset *token.FileSet, files []*ast.File, analyzers ...*Analyzer) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, analyzer := range analyzers {
+		analyzer.Run(&Pass{Analyzer: analyzer, Fset: fset, Files: files, diagnostics: &diagnostics})
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		pi, pj := diagnostics[i].Pos, diagnostics[j].Pos
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		if pi.Line != pj.Line {
+			return pi.Line < pj.Line
+		}
+		return pi.Column < pj.Column
+	})
+	return diagnostics
+}
+
+// Dir parses the Go files in dir, including tests, and runs analyzers over
+// each package. A dir ending in "/..." is searched recursively, skipping
+// testdata, vendor and directories whose names begin with "." or "_", as
+// the go command does.
+// The function returns an error if a directory cannot be read or a file
+// cannot be parsed.
+func Dir(dir string, analyzers ...*Analyzer) ([]Diagnostic, error) {
+	packages, err := load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics []Diagnostic
+	for _, pkg := range packages {
+		diagnostics = append(diagnostics, Check(pkg.fset, pkg.files, analyzers...)...)
+	}
+	return diagnostics, nil
+}
+
+// sourcePackage is a package parsed from a directory. The package under
+// test and its external _test package are separate packages.
+type sourcePackage struct {
+	dir   string
+	fset  *token.FileSet
+	files []*ast.File
+}
+
+// load parses the packages in dir, or, if dir ends in "/...", in dir and
+// its subdirectories, as Dir describes.
+func load(dir string) ([]sourcePackage, error) {
+	root, recursive := strings.CutSuffix(filepath.ToSlash(dir), "/...")
+	if root == "" {
+		root = "."
+	}
+	root = filepath.FromSlash(root)
+
+	dirs := []string{root}
+	if recursive {
+		dirs = nil
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			name := d.Name()
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var packages []sourcePackage
+	for _, d := range dirs {
+		found, err := loadDir(d)
+		if err != nil {
+			return nil, err
+		}
+		packages = append(packages, found...)
+	}
+	return packages, nil
+}
+
+// loadDir parses the packages in a single directory.
+func loadDir(dir string) ([]sourcePackage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	files := make(map[string][]*ast.File)
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		name := file.Name.Name
+		if _, ok := files[name]; !ok {
+			names = append(names, name)
+		}
+		files[name] = append(files[name], file)
+	}
+
+	var packages []sourcePackage
+	for _, name := range names {
+		packages = append(packages, sourcePackage{dir: dir, fset: fset, files: files[name]})
+	}
+	return packages, nil
+}
+
+// importName returns the name under which file imports the package with the
+// given path, or "" if it does not. A path beginning with "/" matches any
+// import path with that suffix, so that "/pkg/assertions" matches GoWise
+// however the module is named.
+func importName(file *ast.File, path string) string {
+	for _, spec := range file.Imports {
+		imported, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if imported != path && !(strings.HasPrefix(path, "/") && strings.HasSuffix(imported, path)) {
+			continue
+		}
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return imported[strings.LastIndex(imported, "/")+1:]
+	}
+	return ""
+}
+
+// assertionsPath matches the import path of pkg/assertions, whatever the
+// module is named.
+const assertionsPath = "/pkg/assertions"
+
+// isPackageMember reports whether expr is a reference to name in the package
+// imported as pkg, as in time.Second.
+func isPackageMember(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || pkg == "" {
+		return false
+	}
+	id, ok := sel.X.(*ast.Ident)
+	return ok && id.Name == pkg && id.Obj == nil && sel.Sel.Name == name
+}
+
+// methodName returns the name of the method or function call calls, or "".
+func methodName(call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		return fun.Sel.Name
+	case *ast.Ident:
+		return fun.Name
+	}
+	return ""
+}
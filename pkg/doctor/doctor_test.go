@@ -0,0 +1,242 @@
+package doctor_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+	"gowise/pkg/doctor"
+)
+
+// check parses src as user_test.go and runs analyzer over it.
+func check(t *testing.T, analyzer *doctor.Analyzer, src string) []doctor.Diagnostic {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "user_test.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("parsing source: %v", err)
+	}
+	return doctor.Check(fset, []*ast.File{file}, analyzer)
+}
+
+// lines returns the line of each diagnostic.
+func lines(diagnostics []doctor.Diagnostic) []int {
+	var result []int
+	for _, d := range diagnostics {
+		result = append(result, d.Pos.Line)
+	}
+	return result
+}
+
+func TestSubtestAssert(t *testing.T) {
+	assert := assertions.New(t)
+
+	diagnostics := check(t, doctor.SubtestAssert, `package user_test
+
+import (
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+func TestUsers(t *testing.T) {
+	assert := assertions.New(t)
+	assert.True(true)
+
+	t.Run("create", func(t *testing.T) {
+		assert.Equal(1, 1)
+		assert.Equal(2, 2)
+	})
+	t.Run("derived", func(t *testing.T) {
+		assert := assert.For(t)
+		assert.Equal(1, 1)
+	})
+	assertions.Run(t, "wrapped", func(t *testing.T, assert *assertions.Assert) {
+		assert.Equal(1, 1)
+	})
+	t.Run("outer", func(t *testing.T) {
+		inner := assertions.New(t)
+		t.Run("inner", func(t *testing.T) {
+			inner.Equal(1, 1)
+		})
+	})
+}
+`)
+
+	assert.Equal(lines(diagnostics), []int{14, 27})
+	if len(diagnostics) == 2 {
+		assert.Contains(diagnostics[0].Message, `outside subtest "create"`)
+		assert.Contains(diagnostics[0].Fix, "assertions.Run")
+		assert.Equal(diagnostics[0].Analyzer, "subtestassert")
+		assert.Contains(diagnostics[1].Message, `inner is created with assertions.New outside subtest "inner"`)
+	}
+}
+
+func TestEventuallyInterval(t *testing.T) {
+	assert := assertions.New(t)
+
+	diagnostics := check(t, doctor.EventuallyInterval, `package user_test
+
+import (
+	"testing"
+	"time"
+
+	"gowise/pkg/assertions"
+)
+
+func TestReady(t *testing.T) {
+	assert := assertions.New(t)
+	ready := func() bool { return true }
+
+	assert.Eventually(ready, 100*time.Millisecond, 2*time.Second)
+	assert.Eventually(ready, 5*time.Second, 100*time.Millisecond)
+	assert.Never(ready, time.Duration(500), (time.Second))
+	assert.Eventually(ready, timeout, time.Second)
+	assert.EventuallyWith(ready, assertions.EventuallyConfig{
+		Timeout:  time.Second,
+		Interval: 3 * time.Second,
+	})
+	assert.EventuallyWith(ready, assertions.EventuallyConfig{Interval: time.Hour})
+}
+`)
+
+	assert.Equal(lines(diagnostics), []int{14, 16, 20})
+	if len(diagnostics) == 3 {
+		assert.Equal(diagnostics[0].Message, "Eventually polls every 2s but times out after 100ms, so the condition is checked only once")
+		assert.Contains(diagnostics[1].Message, "Never polls every 1s but times out after 500ns")
+		assert.Contains(diagnostics[2].Message, "EventuallyConfig polls every 3s")
+	}
+}
+
+func TestConsumedBody(t *testing.T) {
+	assert := assertions.New(t)
+
+	diagnostics := check(t, doctor.ConsumedBody, `package user_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+func TestGetUser(t *testing.T) {
+	assert := assertions.New(t)
+
+	resp, _ := http.Get(url)
+	body, _ := io.ReadAll(resp.Body)
+	assert.BodyContains(resp, "alice")
+
+	resp, _ = http.Get(url)
+	assert.BodyContains(resp, "alice").BodyMatches(resp, "bob")
+
+	resp, _ = http.Get(url)
+	assert.HttpStatus(resp, 200).BodyContains(resp, "alice")
+
+	other, _ := http.Get(url)
+	json.NewDecoder(other.Body).Decode(&user)
+	assert.BodyMatches(other, "alice")
+}
+`)
+
+	assert.Equal(lines(diagnostics), []int{17, 20, 27})
+	if len(diagnostics) == 3 {
+		assert.Equal(diagnostics[0].Message, "BodyContains reads resp.Body, which was already read by io.ReadAll at line 16, so it sees an empty body")
+		assert.Contains(diagnostics[1].Message, "BodyMatches reads resp.Body, which was already read by BodyContains at line 20")
+		assert.Contains(diagnostics[2].Message, "already read by json.NewDecoder at line 26")
+	}
+}
+
+func TestDiagnosticString(t *testing.T) {
+	assert := assertions.New(t)
+
+	d := doctor.Diagnostic{
+		Pos:      token.Position{Filename: "user_test.go", Line: 12, Column: 3},
+		Analyzer: "consumedbody",
+		Message:  "body read twice",
+		Fix:      "read it once",
+	}
+
+	assert.Equal(d.String(), "user_test.go:12:3: body read twice [consumedbody]\n\tfix: read it once")
+}
+
+func TestDir(t *testing.T) {
+	assert := assertions.New(t)
+
+	root := t.TempDir()
+	write := func(name, src string) {
+		path := filepath.Join(root, name)
+		assert.NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(os.WriteFile(path, []byte(src), 0o644))
+	}
+	const shared = `package p
+
+import (
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+func TestP(t *testing.T) {
+	assert := assertions.New(t)
+	t.Run("sub", func(t *testing.T) { assert.True(true) })
+}
+`
+	write("p_test.go", shared)
+	write("sub/q_test.go", strings.Replace(shared, "package p", "package q", 1))
+	write("testdata/r_test.go", strings.Replace(shared, "package p", "package r", 1))
+
+	diagnostics, err := doctor.Dir(root, doctor.Analyzers...)
+	assert.NoError(err)
+	assert.Len(diagnostics, 1)
+
+	diagnostics, err = doctor.Dir(root+"/...", doctor.Analyzers...)
+	assert.NoError(err)
+	var files []string
+	for _, d := range diagnostics {
+		rel, _ := filepath.Rel(root, d.Pos.Filename)
+		files = append(files, filepath.ToSlash(rel))
+	}
+	assert.Equal(files, []string{"p_test.go", "sub/q_test.go"})
+
+	write("broken/b.go", "package b\nfunc {")
+	_, err = doctor.Dir(root+"/...", doctor.Analyzers...)
+	assert.HasError(err)
+}
+
+func ExampleCheck() {
+	const src = `package user_test
+
+import (
+	"testing"
+	"time"
+
+	"gowise/pkg/assertions"
+)
+
+func TestReady(t *testing.T) {
+	assert := assertions.New(t)
+	assert.Eventually(ready, time.Second, 5*time.Second)
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "user_test.go", src, parser.ParseComments)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, d := range doctor.Check(fset, []*ast.File{file}, doctor.Analyzers...) {
+		fmt.Println(d)
+	}
+	// Output:
+	// user_test.go:12:40: Eventually polls every 5s but times out after 1s, so the condition is checked only once [eventuallyinterval]
+	// 	fix: swap the arguments if they are reversed (timeout comes before interval), or poll at a fraction of the timeout
+}
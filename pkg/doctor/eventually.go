@@ -0,0 +1,151 @@
+package doctor
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EventuallyInterval reports Eventually and Never calls, and
+// EventuallyConfig literals, whose polling interval exceeds their timeout.
+// The condition is then checked once, when the call starts, and the
+// assertion never polls.
+var EventuallyInterval = &Analyzer{
+	Name: "eventuallyinterval",
+	Doc:  "report Eventually and Never calls whose interval exceeds their timeout",
+	Run:  runEventuallyInterval,
+}
+
+// eventuallyFix is the fix suggested for an interval longer than a timeout.
+const eventuallyFix = "swap the arguments if they are reversed (timeout comes before interval), or poll at a fraction of the timeout"
+
+func runEventuallyInterval(pass *Pass) {
+	for _, file := range pass.Files {
+		timePkg := importName(file, "time")
+		if importName(file, assertionsPath) == "" && file.Name.Name != "assertions" {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				name := methodName(n)
+				if (name != "Eventually" && name != "Never") || len(n.Args) != 3 {
+					return true
+				}
+				timeout, ok1 := constDuration(n.Args[1], timePkg)
+				interval, ok2 := constDuration(n.Args[2], timePkg)
+				if ok1 && ok2 && interval > timeout {
+					pass.Reportf(n.Args[2].Pos(), eventuallyFix,
+						"%s polls every %v but times out after %v, so the condition is checked only once", name, interval, timeout)
+				}
+			case *ast.CompositeLit:
+				if !isEventuallyConfig(n.Type) {
+					return true
+				}
+				var timeout, interval time.Duration
+				var hasTimeout, hasInterval bool
+				var at token.Pos
+				for _, elt := range n.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					key, ok := kv.Key.(*ast.Ident)
+					if !ok {
+						continue
+					}
+					switch key.Name {
+					case "Timeout":
+						timeout, hasTimeout = constDuration(kv.Value, timePkg)
+					case "Interval":
+						interval, hasInterval = constDuration(kv.Value, timePkg)
+						at = kv.Pos()
+					}
+				}
+				if hasTimeout && hasInterval && interval > timeout {
+					pass.Reportf(at, eventuallyFix,
+						"EventuallyConfig polls every %v but times out after %v, so the condition is checked only once", interval, timeout)
+				}
+			}
+			return true
+		})
+	}
+}
+
+// isEventuallyConfig reports whether expr names the EventuallyConfig type.
+func isEventuallyConfig(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name == "EventuallyConfig"
+	case *ast.SelectorExpr:
+		return expr.Sel.Name == "EventuallyConfig"
+	}
+	return false
+}
+
+// durationUnits are the time package's duration constants.
+var durationUnits = map[string]time.Duration{
+	"Nanosecond":  time.Nanosecond,
+	"Microsecond": time.Microsecond,
+	"Millisecond": time.Millisecond,
+	"Second":      time.Second,
+	"Minute":      time.Minute,
+	"Hour":        time.Hour,
+}
+
+// constDuration evaluates expr if it is a constant duration built from
+// numeric literals and the time package's units, as in 2*time.Second. It
+// reports false for any other expression.
+func constDuration(expr ast.Expr, timePkg string) (time.Duration, bool) {
+	value, ok := constValue(expr, timePkg)
+	return time.Duration(value), ok
+}
+
+// constValue evaluates a constant numeric expression for constDuration.
+func constValue(expr ast.Expr, timePkg string) (float64, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind != token.INT && expr.Kind != token.FLOAT {
+			return 0, false
+		}
+		if expr.Kind == token.INT {
+			n, err := strconv.ParseInt(strings.ReplaceAll(expr.Value, "_", ""), 0, 64)
+			return float64(n), err == nil
+		}
+		f, err := strconv.ParseFloat(strings.ReplaceAll(expr.Value, "_", ""), 64)
+		return f, err == nil
+	case *ast.ParenExpr:
+		return constValue(expr.X, timePkg)
+	case *ast.SelectorExpr:
+		for name, unit := range durationUnits {
+			if isPackageMember(expr, timePkg, name) {
+				return float64(unit), true
+			}
+		}
+	case *ast.CallExpr:
+		// A conversion, as in time.Duration(500).
+		if len(expr.Args) == 1 && isPackageMember(expr.Fun, timePkg, "Duration") {
+			return constValue(expr.Args[0], timePkg)
+		}
+	case *ast.BinaryExpr:
+		x, ok1 := constValue(expr.X, timePkg)
+		y, ok2 := constValue(expr.Y, timePkg)
+		if !ok1 || !ok2 {
+			return 0, false
+		}
+		switch expr.Op {
+		case token.MUL:
+			return x * y, true
+		case token.QUO:
+			return x / y, y != 0
+		case token.ADD:
+			return x + y, true
+		case token.SUB:
+			return x - y, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,150 @@
+package doctor
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// SubtestAssert reports an Assert created with assertions.New that is used
+// inside a subtest's function. Its failures are attributed to the parent
+// test, and through fail-fast chaining one subtest's failure silences the
+// assertions of the next.
+var SubtestAssert = &Analyzer{
+	Name: "subtestassert",
+	Doc:  "report Asserts created outside a subtest and used inside it",
+	Run:  runSubtestAssert,
+}
+
+// subtestFix is the fix suggested for an Assert shared with a subtest.
+const subtestFix = "give the subtest its own Assert with assertions.Run(t, name, func(t *testing.T, assert *assertions.Assert) { ... }), or derive one inside it with assert.For(t)"
+
+// derivingMethods are the methods through which an Assert may be used in a
+// subtest, as they derive a new Assert for it.
+var derivingMethods = map[string]bool{"For": true, "Run": true}
+
+func runSubtestAssert(pass *Pass) {
+	for _, file := range pass.Files {
+		assertionsPkg := importName(file, assertionsPath)
+		testingPkg := importName(file, "testing")
+		if assertionsPkg == "" || testingPkg == "" {
+			continue
+		}
+
+		// Find the variables assigned an Assert from assertions.New.
+		asserts := make(map[*ast.Object]bool)
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if len(n.Lhs) == len(n.Rhs) {
+					for i, rhs := range n.Rhs {
+						if id, ok := n.Lhs[i].(*ast.Ident); ok && id.Obj != nil && fromNew(rhs, assertionsPkg) {
+							asserts[id.Obj] = true
+						}
+					}
+				}
+			case *ast.ValueSpec:
+				if len(n.Names) == len(n.Values) {
+					for i, value := range n.Values {
+						if n.Names[i].Obj != nil && fromNew(value, assertionsPkg) {
+							asserts[n.Names[i].Obj] = true
+						}
+					}
+				}
+			}
+			return true
+		})
+		if len(asserts) == 0 {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || methodName(call) != "Run" || len(call.Args) < 2 {
+				return true
+			}
+			if lit := subtestFunc(call.Args[len(call.Args)-1], testingPkg); lit != nil {
+				reportSharedAsserts(pass, lit, subtestName(call), asserts, testingPkg)
+			}
+			return true
+		})
+	}
+}
+
+// reportSharedAsserts reports, once per variable, the Asserts declared
+// outside the subtest function lit and used within it. Subtests nested in
+// lit are checked on their own.
+func reportSharedAsserts(pass *Pass, lit *ast.FuncLit, name string, asserts map[*ast.Object]bool, testingPkg string) {
+	reported := make(map[*ast.Object]bool)
+	var inspect func(n ast.Node) bool
+	inspect = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if methodName(n) == "Run" && len(n.Args) >= 2 && subtestFunc(n.Args[len(n.Args)-1], testingPkg) != nil {
+				ast.Inspect(n.Fun, inspect)
+				for _, arg := range n.Args[:len(n.Args)-1] {
+					ast.Inspect(arg, inspect)
+				}
+				return false
+			}
+		case *ast.SelectorExpr:
+			if id, ok := n.X.(*ast.Ident); ok && derivingMethods[n.Sel.Name] && asserts[id.Obj] {
+				return false
+			}
+		case *ast.Ident:
+			if !asserts[n.Obj] || reported[n.Obj] || declaredWithin(n.Obj, lit) {
+				return true
+			}
+			reported[n.Obj] = true
+			pass.Reportf(n.Pos(), subtestFix,
+				"%s is created with assertions.New outside subtest %s and used inside it; its failures are attributed to the parent test, and once it fails, assertions in later subtests are skipped",
+				n.Name, name)
+		}
+		return true
+	}
+	ast.Inspect(lit.Body, inspect)
+}
+
+// fromNew reports whether expr is a call to assertions.New, possibly
+// followed by calls such as With that return the same Assert.
+func fromNew(expr ast.Expr, assertionsPkg string) bool {
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		if isPackageMember(call.Fun, assertionsPkg, "New") {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || derivingMethods[sel.Sel.Name] {
+			return false
+		}
+		expr = sel.X
+	}
+}
+
+// subtestFunc returns expr if it is a function literal taking a *testing.T,
+// as passed to t.Run or assertions.Run.
+func subtestFunc(expr ast.Expr, testingPkg string) *ast.FuncLit {
+	lit, ok := expr.(*ast.FuncLit)
+	if !ok {
+		return nil
+	}
+	for _, field := range lit.Type.Params.List {
+		if star, ok := field.Type.(*ast.StarExpr); ok && isPackageMember(star.X, testingPkg, "T") {
+			return lit
+		}
+	}
+	return nil
+}
+
+// subtestName returns the name argument of call, which starts a subtest.
+func subtestName(call *ast.CallExpr) string {
+	return types.ExprString(call.Args[len(call.Args)-2])
+}
+
+// declaredWithin reports whether obj is declared inside lit.
+func declaredWithin(obj *ast.Object, lit *ast.FuncLit) bool {
+	decl, ok := obj.Decl.(ast.Node)
+	return ok && decl.Pos() >= lit.Pos() && decl.End() <= lit.End()
+}
@@ -0,0 +1,408 @@
+package doctor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"gowise/pkg/assertions"
+)
+
+// Usage is a report of the GoWise assertions a codebase uses, and of hand
+// written checks that an assertion could replace.
+type Usage struct {
+	// Packages holds the assertion counts of each directory, sorted by
+	// directory.
+	Packages []PackageUsage
+	// Hints are hand-written checks found in tests, most frequent first.
+	Hints []Hint
+}
+
+// PackageUsage counts the assertions used in a directory's packages,
+// including its tests. Methods of Assert are counted by name, as in "Equal",
+// and package functions by qualified name, as in "assertions.Greater".
+type PackageUsage struct {
+	Dir    string
+	Counts map[string]int
+}
+
+// Hint is a hand-written check, found in tests, that an assertion could
+// replace.
+type Hint struct {
+	// Suggestion describes the check and the assertion to use instead.
+	Suggestion string
+	// Positions are where the check was found.
+	Positions []token.Position
+}
+
+// Totals sums the assertion counts of every package.
+func (u *Usage) Totals() map[string]int {
+	totals := make(map[string]int)
+	for _, pkg := range u.Packages {
+		for name, count := range pkg.Counts {
+			totals[name] += count
+		}
+	}
+	return totals
+}
+
+// maxHintPositions is the number of positions WriteText lists for a hint.
+const maxHintPositions = 5
+
+// WriteText writes the report as aligned text: each package's assertions,
+// most used first, the totals, and the hints with where they were found.
+func (u *Usage) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, pkg := range u.Packages {
+		fmt.Fprintf(tw, "%s (%d calls)\n", pkg.Dir, sum(pkg.Counts))
+		writeCounts(tw, pkg.Counts)
+		fmt.Fprintln(tw)
+	}
+
+	totals := u.Totals()
+	fmt.Fprintf(tw, "Totals (%d calls in %d packages)\n", sum(totals), len(u.Packages))
+	writeCounts(tw, totals)
+
+	if len(u.Hints) > 0 {
+		fmt.Fprintln(tw, "\nHints")
+		for _, hint := range u.Hints {
+			fmt.Fprintf(tw, "  %d %s\n", len(hint.Positions), hint.Suggestion)
+			for i, pos := range hint.Positions {
+				if i == maxHintPositions {
+					fmt.Fprintf(tw, "      … and %d more\n", len(hint.Positions)-i)
+					break
+				}
+				fmt.Fprintf(tw, "      %s\n", pos)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+// writeCounts writes counts, most used first and then by name.
+func writeCounts(w io.Writer, counts map[string]int) {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\t%d\n", name, counts[name])
+	}
+}
+
+// sum adds up counts.
+func sum(counts map[string]int) int {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	return total
+}
+
+// ScanUsage parses the Go files in dir, as Dir does, and reports the GoWise
+// assertions each directory uses and the hand-written checks in its tests
+// that an assertion could replace: sleep-and-check loops (Eventually),
+// err != nil checks that fail the test (NoError), reflect.DeepEqual
+// (Equal) and comparisons that fail the test (Equal or NotEqual).
+// Like the analyzers, it works on syntax trees, so it recognises Asserts by
+// how they are created and declared: from assertions.New, NewB, NewF and
+// FromContext, methods returning an Assert, and variables and parameters of
+// type *assertions.Assert.
+// The function returns an error if a directory cannot be read or a file
+// cannot be parsed.
+//
+// Example:
+//
+//	usage, err := doctor.ScanUsage("./...")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	usage.WriteText(os.Stdout)
+func ScanUsage(dir string) (*Usage, error) {
+	packages, err := load(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &Usage{}
+	byDir := make(map[string]*PackageUsage)
+	hints := make(map[string]*Hint)
+	for _, pkg := range packages {
+		for _, file := range pkg.files {
+			counts := countAssertions(file)
+			if len(counts) > 0 {
+				pu, ok := byDir[pkg.dir]
+				if !ok {
+					pu = &PackageUsage{Dir: pkg.dir, Counts: make(map[string]int)}
+					byDir[pkg.dir] = pu
+				}
+				for name, count := range counts {
+					pu.Counts[name] += count
+				}
+			}
+
+			if strings.HasSuffix(pkg.fset.Position(file.Pos()).Filename, "_test.go") {
+				findHints(pkg.fset, file, hints)
+			}
+		}
+	}
+
+	for _, pu := range byDir {
+		usage.Packages = append(usage.Packages, *pu)
+	}
+	sort.Slice(usage.Packages, func(i, j int) bool { return usage.Packages[i].Dir < usage.Packages[j].Dir })
+
+	for _, hint := range hints {
+		usage.Hints = append(usage.Hints, *hint)
+	}
+	sort.Slice(usage.Hints, func(i, j int) bool {
+		if len(usage.Hints[i].Positions) != len(usage.Hints[j].Positions) {
+			return len(usage.Hints[i].Positions) > len(usage.Hints[j].Positions)
+		}
+		return usage.Hints[i].Suggestion < usage.Hints[j].Suggestion
+	})
+	return usage, nil
+}
+
+// assertMethods are the assertion methods of Assert, and chainMethods the
+// methods returning an Assert, by name.
+var assertMethods, chainMethods = assertMethodSets()
+
+// nonAssertions are methods of Assert that configure, derive or report
+// rather than assert.
+var nonAssertions = map[string]bool{"For": true, "Run": true, "T": true, "Fail": true, "Error": true, "HasFailed": true}
+
+// assertMethodSets lists the methods of Assert.
+func assertMethodSets() (assertMethods, chainMethods map[string]bool) {
+	assertMethods, chainMethods = make(map[string]bool), make(map[string]bool)
+	typ := reflect.TypeOf(&assertions.Assert{})
+	for i := 0; i < typ.NumMethod(); i++ {
+		method := typ.Method(i)
+		if !nonAssertions[method.Name] && !strings.HasPrefix(method.Name, "With") {
+			assertMethods[method.Name] = true
+		}
+		if method.Type.NumOut() == 1 && method.Type.Out(0) == typ {
+			chainMethods[method.Name] = true
+		}
+	}
+	return assertMethods, chainMethods
+}
+
+// gowisePackages are the GoWise packages whose functions take an Assert as
+// their first argument, by import path suffix.
+var gowisePackages = []string{assertionsPath, "/pkg/fsm", "/pkg/mock"}
+
+// countAssertions counts the assertions used in file.
+func countAssertions(file *ast.File) map[string]int {
+	assertionsPkg := importName(file, assertionsPath)
+	if assertionsPkg == "" {
+		return nil
+	}
+	pkgs := make(map[string]bool)
+	for _, path := range gowisePackages {
+		if name := importName(file, path); name != "" {
+			pkgs[name] = true
+		}
+	}
+
+	asserts := assertObjects(file, assertionsPkg)
+	isAssert := func(expr ast.Expr) bool { return isAssertExpr(expr, asserts, assertionsPkg) }
+
+	counts := make(map[string]int)
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil && pkgs[id.Name] {
+			if len(call.Args) > 0 && isAssert(call.Args[0]) {
+				counts[id.Name+"."+sel.Sel.Name]++
+			}
+			return true
+		}
+		if assertMethods[sel.Sel.Name] && isAssert(sel.X) {
+			counts[sel.Sel.Name]++
+		}
+		return true
+	})
+	return counts
+}
+
+// assertObjects finds the variables and parameters in file that hold an
+// Assert.
+func assertObjects(file *ast.File, assertionsPkg string) map[*ast.Object]bool {
+	asserts := make(map[*ast.Object]bool)
+	isAssertType := func(expr ast.Expr) bool {
+		star, ok := expr.(*ast.StarExpr)
+		return ok && isPackageMember(star.X, assertionsPkg, "Assert")
+	}
+
+	// Assignments may use Asserts assigned earlier, so the file is
+	// inspected until no more are found.
+	for found := true; found; {
+		found = false
+		add := func(id *ast.Ident) {
+			if id != nil && id.Obj != nil && !asserts[id.Obj] {
+				asserts[id.Obj] = true
+				found = true
+			}
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.Field:
+				if isAssertType(n.Type) {
+					for _, name := range n.Names {
+						add(name)
+					}
+				}
+			case *ast.ValueSpec:
+				for i, name := range n.Names {
+					if n.Type != nil && isAssertType(n.Type) || i < len(n.Values) && isAssertExpr(n.Values[i], asserts, assertionsPkg) {
+						add(name)
+					}
+				}
+			case *ast.AssignStmt:
+				if len(n.Lhs) == len(n.Rhs) {
+					for i, rhs := range n.Rhs {
+						if id, ok := n.Lhs[i].(*ast.Ident); ok && isAssertExpr(rhs, asserts, assertionsPkg) {
+							add(id)
+						}
+					}
+				} else if len(n.Rhs) == 1 {
+					// assert, ok := assertions.FromContext(ctx)
+					if call, ok := n.Rhs[0].(*ast.CallExpr); ok && isPackageMember(call.Fun, assertionsPkg, "FromContext") {
+						id, _ := n.Lhs[0].(*ast.Ident)
+						add(id)
+					}
+				}
+			}
+			return true
+		})
+	}
+	return asserts
+}
+
+// isAssertExpr reports whether expr evaluates to an Assert: a variable in
+// asserts, a constructor call, or a call of a method returning an Assert.
+func isAssertExpr(expr ast.Expr, asserts map[*ast.Object]bool, assertionsPkg string) bool {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Obj != nil && asserts[expr.Obj]
+	case *ast.ParenExpr:
+		return isAssertExpr(expr.X, asserts, assertionsPkg)
+	case *ast.CallExpr:
+		for _, constructor := range []string{"New", "NewB", "NewF"} {
+			if isPackageMember(expr.Fun, assertionsPkg, constructor) {
+				return true
+			}
+		}
+		sel, ok := expr.Fun.(*ast.SelectorExpr)
+		return ok && chainMethods[sel.Sel.Name] && isAssertExpr(sel.X, asserts, assertionsPkg)
+	}
+	return false
+}
+
+// Hint suggestions, by the check they replace.
+const (
+	sleepLoopHint  = "manual sleep-and-check loops could use Eventually"
+	errCheckHint   = "if err != nil checks failing the test could use NoError"
+	deepEqualHint  = "reflect.DeepEqual calls could use Equal, which shows a diff"
+	comparisonHint = "comparisons failing the test could use Equal or NotEqual"
+)
+
+// testFailures are the methods of testing.TB that fail a test with a
+// message.
+var testFailures = map[string]bool{"Error": true, "Errorf": true, "Fatal": true, "Fatalf": true}
+
+// findHints adds the hand-written checks in file to hints.
+func findHints(fset *token.FileSet, file *ast.File, hints map[string]*Hint) {
+	timePkg := importName(file, "time")
+	reflectPkg := importName(file, "reflect")
+	add := func(suggestion string, pos token.Pos) {
+		hint, ok := hints[suggestion]
+		if !ok {
+			hint = &Hint{Suggestion: suggestion}
+			hints[suggestion] = hint
+		}
+		hint.Positions = append(hint.Positions, fset.Position(pos))
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ForStmt:
+			if callsWithin(n.Body, func(call *ast.CallExpr) bool { return isPackageMember(call.Fun, timePkg, "Sleep") }) {
+				add(sleepLoopHint, n.Pos())
+			}
+		case *ast.CallExpr:
+			if isPackageMember(n.Fun, reflectPkg, "DeepEqual") {
+				add(deepEqualHint, n.Pos())
+			}
+		case *ast.IfStmt:
+			cond, ok := n.Cond.(*ast.BinaryExpr)
+			if !ok || (cond.Op != token.EQL && cond.Op != token.NEQ) || !failsTest(n.Body) {
+				return true
+			}
+			if id, ok := cond.X.(*ast.Ident); ok && id.Name == "err" && isNil(cond.Y) && cond.Op == token.NEQ {
+				add(errCheckHint, n.Pos())
+			} else if !isNil(cond.X) && !isNil(cond.Y) {
+				add(comparisonHint, n.Pos())
+			}
+		}
+		return true
+	})
+}
+
+// callsWithin reports whether body calls a function matching match outside
+// any function literal.
+func callsWithin(body *ast.BlockStmt, match func(*ast.CallExpr) bool) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			found = found || match(n)
+		}
+		return !found
+	})
+	return found
+}
+
+// failsTest reports whether body begins by failing the test, as in
+// t.Errorf(...).
+func failsTest(body *ast.BlockStmt) bool {
+	if len(body.List) == 0 {
+		return false
+	}
+	stmt, ok := body.List[0].(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := stmt.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && testFailures[sel.Sel.Name]
+}
+
+// isNil reports whether expr is the identifier nil.
+func isNil(expr ast.Expr) bool {
+	id, ok := expr.(*ast.Ident)
+	return ok && id.Name == "nil"
+}
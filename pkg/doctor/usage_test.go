@@ -0,0 +1,116 @@
+package doctor_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gowise/pkg/assertions"
+	"gowise/pkg/doctor"
+)
+
+const usageSource = `package cart_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"gowise/pkg/assertions"
+	"gowise/pkg/mock"
+)
+
+func TestCart(t *testing.T) {
+	assert := assertions.New(t).With(assertions.UseFloatTolerance(1e-9))
+	assert.Equal(1, 1).NoError(nil)
+	assertions.Greater(assert, 2, 1)
+	checkTotal(assert, 3)
+
+	var stored *assertions.Assert = assert.For(t)
+	stored.True(true)
+
+	t.Run("sub", func(t *testing.T) {
+		other := assertions.New(t)
+		other.Equal(2, 2)
+		t.Errorf("not an assertion")
+	})
+
+	for !ready() {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if got := total(); got != 3 {
+		t.Errorf("total = %d", got)
+	}
+	if !reflect.DeepEqual(items(), []string{"a"}) {
+		t.Error("items differ")
+	}
+	mock.InOrder(assert, nil)
+}
+
+func checkTotal(a *assertions.Assert, want int) {
+	a.Equal(total(), want)
+}
+
+func fromContext(ctx context.Context) {
+	if a, ok := assertions.FromContext(ctx); ok {
+		a.NotNil(ctx)
+	}
+}
+`
+
+func TestScanUsage(t *testing.T) {
+	assert := assertions.New(t)
+
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "cart_test.go"), []byte(usageSource), 0o644))
+	assert.NoError(os.MkdirAll(filepath.Join(dir, "plain"), 0o755))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "plain", "plain.go"), []byte("package plain\n\nfunc Equal(a, b int) bool { return a == b }\n"), 0o644))
+
+	usage, err := doctor.ScanUsage(dir + "/...")
+	assert.NoError(err)
+
+	assert.Len(usage.Packages, 1)
+	assert.Equal(usage.Packages[0].Dir, dir)
+	assert.Equal(usage.Packages[0].Counts, map[string]int{
+		"Equal":              3,
+		"NoError":            1,
+		"True":               1,
+		"NotNil":             1,
+		"assertions.Greater": 1,
+		"mock.InOrder":       1,
+	})
+	assert.Equal(usage.Totals()["Equal"], 3)
+
+	hints := make(map[string]int)
+	for _, hint := range usage.Hints {
+		hints[hint.Suggestion] = len(hint.Positions)
+	}
+	assert.Equal(hints, map[string]int{
+		"manual sleep-and-check loops could use Eventually":           1,
+		"if err != nil checks failing the test could use NoError":     1,
+		"comparisons failing the test could use Equal or NotEqual":    1,
+		"reflect.DeepEqual calls could use Equal, which shows a diff": 1,
+	})
+}
+
+func TestUsageWriteText(t *testing.T) {
+	assert := assertions.New(t)
+
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "cart_test.go"), []byte(usageSource), 0o644))
+	usage, err := doctor.ScanUsage(dir)
+	assert.NoError(err)
+
+	var buf bytes.Buffer
+	assert.NoError(usage.WriteText(&buf))
+	text := buf.String()
+
+	assert.Contains(text, dir+" (8 calls)\n  Equal               3\n")
+	assert.Contains(text, "Totals (8 calls in 1 packages)")
+	assert.Contains(text, "Hints\n  1 comparisons failing the test could use Equal or NotEqual\n      "+filepath.Join(dir, "cart_test.go")+":34:2\n")
+}
@@ -0,0 +1,87 @@
+// Package fixture builds file trees for tests that read, write or process
+// files. Each tree is created in a directory from t.TempDir, so it is removed
+// when the test finishes, and a tree that cannot be created stops the test
+// with t.Fatal.
+//
+// Trees combine with the file assertions for end-to-end tests of code that
+// processes files:
+//
+//	func TestBuildSite(t *testing.T) {
+//		root := fixture.TempDirWithFiles(t, map[string]string{
+//			"content/index.md":     "# Home",
+//			"content/about/bio.md": "# About",
+//			"public/":              "",
+//		})
+//		assert := assertions.New(t)
+//		assert.NoError(site.Build(root))
+//		assert.FileContains(filepath.Join(root, "public/index.html"), "<h1>Home</h1>").
+//			DirContainsFile(filepath.Join(root, "public/about"), "bio.html")
+//	}
+package fixture
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TempDirWithFiles creates a directory holding files and returns its root.
+// Each key is a path relative to the root, with forward slashes, and each
+// value the content of the file; parent directories are created as needed,
+// and a key ending in "/" creates an empty directory. Paths that would leave
+// the root, such as "../x" or "/etc/x", stop the test.
+//
+// Example:
+//
+//	root := fixture.TempDirWithFiles(t, map[string]string{
+//		"config.yaml":        "port: 8080\n",
+//		"migrations/001.sql": "CREATE TABLE users (id INTEGER);",
+//	})
+func TempDirWithFiles(t testing.TB, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, content := range files {
+		path := filepath.FromSlash(strings.TrimSuffix(name, "/"))
+		if !filepath.IsLocal(path) {
+			t.Fatalf("fixture: %q is not a path within the directory", name)
+		}
+		path = filepath.Join(root, path)
+
+		if strings.HasSuffix(name, "/") {
+			if content != "" {
+				t.Fatalf("fixture: %q names a directory but has content", name)
+			}
+			if err := os.MkdirAll(path, 0o755); err != nil {
+				t.Fatalf("fixture: %v", err)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("fixture: %v", err)
+		}
+	}
+	return root
+}
+
+// CopyDir copies the directory src, such as a tree under testdata, into a
+// new directory and returns the copy's root, so a test can change files
+// without touching the originals. Files keep their permission bits; symbolic
+// links and other irregular files stop the test, as os.CopyFS reports them.
+//
+// Example:
+//
+//	root := fixture.CopyDir(t, "testdata/legacy-project")
+//	assert.NoError(migrate.Run(root))
+//	assert.FileMatchesGolden(filepath.Join(root, "go.mod"), "testdata/migrated.go.mod.golden")
+func CopyDir(t testing.TB, src string) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.CopyFS(root, os.DirFS(src)); err != nil {
+		t.Fatalf("fixture: copying %s: %v", src, err)
+	}
+	return root
+}
@@ -0,0 +1,124 @@
+package fixture
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fatalT records a Fatalf and stops the goroutine, as testing.T does.
+type fatalT struct {
+	testing.TB
+	fatal string
+}
+
+func (f *fatalT) Fatalf(format string, args ...any) {
+	f.fatal = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+// fatalOf runs fn with a fatalT and returns what it reported.
+func fatalOf(t *testing.T, fn func(t testing.TB)) string {
+	f := &fatalT{TB: t}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(f)
+	}()
+	<-done
+	return f.fatal
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestTempDirWithFiles(t *testing.T) {
+	var root string
+	t.Run("create", func(t *testing.T) {
+		root = TempDirWithFiles(t, map[string]string{
+			"config.yaml":        "port: 8080\n",
+			"migrations/001.sql": "CREATE TABLE users (id INTEGER);",
+			"empty.txt":          "",
+			"uploads/":           "",
+		})
+
+		if got := readFile(t, filepath.Join(root, "config.yaml")); got != "port: 8080\n" {
+			t.Errorf("config.yaml = %q", got)
+		}
+		if got := readFile(t, filepath.Join(root, "migrations", "001.sql")); got != "CREATE TABLE users (id INTEGER);" {
+			t.Errorf("migrations/001.sql = %q", got)
+		}
+		if got := readFile(t, filepath.Join(root, "empty.txt")); got != "" {
+			t.Errorf("empty.txt = %q", got)
+		}
+		if info, err := os.Stat(filepath.Join(root, "uploads")); err != nil || !info.IsDir() {
+			t.Errorf("Expected uploads to be an empty directory, got %v, %v", info, err)
+		}
+	})
+
+	if _, err := os.Stat(root); !os.IsNotExist(err) {
+		t.Errorf("Expected the directory to be removed after the test, got %v", err)
+	}
+}
+
+func TestTempDirWithFilesRejectsPaths(t *testing.T) {
+	for name, want := range map[string]string{
+		"../escape.txt": `fixture: "../escape.txt" is not a path within the directory`,
+		"/etc/passwd":   `fixture: "/etc/passwd" is not a path within the directory`,
+		"":              `fixture: "" is not a path within the directory`,
+		"logs/":         `fixture: "logs/" names a directory but has content`,
+		"a/../../b.txt": `fixture: "a/../../b.txt" is not a path within the directory`,
+	} {
+		got := fatalOf(t, func(t testing.TB) { TempDirWithFiles(t, map[string]string{name: "x"}) })
+		if !strings.Contains(got, want) {
+			t.Errorf("TempDirWithFiles with %q: fatal %q, want one containing %q", name, got, want)
+		}
+	}
+}
+
+func TestCopyDir(t *testing.T) {
+	src := TempDirWithFiles(t, map[string]string{
+		"go.mod":         "module legacy\n",
+		"cmd/main.go":    "package main\n",
+		"scripts/run.sh": "#!/bin/sh\n",
+		"vendor/":        "",
+	})
+	if err := os.Chmod(filepath.Join(src, "scripts", "run.sh"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	root := CopyDir(t, src)
+	if root == src {
+		t.Fatal("Expected a new directory")
+	}
+	if got := readFile(t, filepath.Join(root, "cmd", "main.go")); got != "package main\n" {
+		t.Errorf("cmd/main.go = %q", got)
+	}
+	if info, err := os.Stat(filepath.Join(root, "vendor")); err != nil || !info.IsDir() {
+		t.Errorf("Expected the empty directory to be copied, got %v, %v", info, err)
+	}
+	if info, err := os.Stat(filepath.Join(root, "scripts", "run.sh")); err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("Expected the executable bit to be kept, got %v, %v", info, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module migrated\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := readFile(t, filepath.Join(src, "go.mod")); got != "module legacy\n" {
+		t.Errorf("Expected the original to be untouched, got %q", got)
+	}
+
+	missing := filepath.Join(src, "missing")
+	if got := fatalOf(t, func(t testing.TB) { CopyDir(t, missing) }); !strings.HasPrefix(got, "fixture: copying "+missing+": ") {
+		t.Errorf("Expected a missing directory to stop the test, got %q", got)
+	}
+}
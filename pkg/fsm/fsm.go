@@ -0,0 +1,312 @@
+// Package fsm provides a state machine test harness: it runs random sequences
+// of commands against a system under test and a model of its expected state,
+// checking postconditions and invariants after every step, and shrinks any
+// failing sequence to a short reproduction.
+//
+// Define the commands a system accepts and how each changes the model:
+//
+//	machine := fsm.Machine[[]int, *Stack]{
+//		Init: func() ([]int, *Stack) { return nil, NewStack() },
+//		Commands: []fsm.Command[[]int, *Stack]{
+//			{
+//				Name: "Push",
+//				Gen:  func(r *rand.Rand, _ []int) any { return r.IntN(100) },
+//				Run: func(a *assertions.Assert, model []int, s *Stack, arg any) []int {
+//					s.Push(arg.(int))
+//					return append(slices.Clone(model), arg.(int))
+//				},
+//			},
+//			{
+//				Name: "Pop",
+//				Pre:  func(model []int) bool { return len(model) > 0 },
+//				Run: func(a *assertions.Assert, model []int, s *Stack, _ any) []int {
+//					a.Equal(s.Pop(), model[len(model)-1])
+//					return model[:len(model)-1]
+//				},
+//			},
+//		},
+//		Invariants: []fsm.Invariant[[]int, *Stack]{
+//			{Name: "length", Check: func(a *assertions.Assert, model []int, s *Stack) {
+//				a.Equal(s.Len(), len(model))
+//			}},
+//		},
+//	}
+//	fsm.Check(assert, machine)
+package fsm
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"strings"
+
+	"gowise/pkg/assertions"
+)
+
+// DefaultSteps is the number of commands in each generated sequence unless
+// Machine.Steps sets another.
+const DefaultSteps = 20
+
+// Machine describes a system under test of type S and a model of its state
+// of type M.
+type Machine[M, S any] struct {
+	// Init returns the initial model and a fresh system under test. It is
+	// called once per sequence, including while shrinking.
+	Init func() (M, S)
+	// Cleanup, if set, releases a system under test once its sequence has
+	// run.
+	Cleanup func(sys S)
+	// Commands are the operations sequences are built from.
+	Commands []Command[M, S]
+	// Invariants are checked after every step.
+	Invariants []Invariant[M, S]
+
+	// Runs is the number of sequences to generate; zero means
+	// assertions.DefaultPropertyChecks.
+	Runs int
+	// Steps is the length of each sequence; zero means DefaultSteps.
+	Steps int
+	// Seed fixes the random source, to reproduce a failure; the seed is
+	// shown in every failure. Zero means a fresh seed per Check.
+	Seed uint64
+}
+
+// Command is an operation on the system under test.
+type Command[M, S any] struct {
+	// Name identifies the command in failure messages.
+	Name string
+	// Pre, if set, reports whether the command may run in model state m.
+	Pre func(model M) bool
+	// Gen, if set, generates the command's argument from the current model.
+	Gen func(r *rand.Rand, model M) any
+	// Run applies the command to sys, checks its postconditions through a,
+	// and returns the next model. It must not modify model in place, as
+	// shrinking replays sequences from the start.
+	Run func(a *assertions.Assert, model M, sys S, arg any) M
+}
+
+// Invariant is a property that must hold after every step.
+type Invariant[M, S any] struct {
+	// Name identifies the invariant in failure messages.
+	Name string
+	// Check asserts the property through a.
+	Check func(a *assertions.Assert, model M, sys S)
+}
+
+// step is a command with the argument generated for it.
+type step struct {
+	command int
+	arg     any
+}
+
+// failure describes why a sequence failed.
+type failure struct {
+	at      int    // Index of the failing step
+	check   string // The command or invariant that failed
+	message string
+}
+
+// Check generates random command sequences for machine, runs each against a
+// fresh system under test, and asserts that every command's postconditions
+// and every invariant hold. The first failing sequence is shrunk by removing
+// steps while it still fails, and reported with the seed that reproduces it.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	fsm.Check(assert, machine)
+func Check[M, S any](a *assertions.Assert, machine Machine[M, S]) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	runs := machine.Runs
+	if runs <= 0 {
+		runs = assertions.DefaultPropertyChecks
+	}
+	steps := machine.Steps
+	if steps <= 0 {
+		steps = DefaultSteps
+	}
+	seed := machine.Seed
+	if seed == 0 {
+		seed = rand.Uint64() | 1
+	}
+	r := rand.New(rand.NewPCG(seed, seed))
+
+	for run := 0; run < runs; run++ {
+		sequence, f := machine.generate(a, r, steps)
+		if f == nil {
+			continue
+		}
+
+		original := len(sequence)
+		sequence, f = machine.shrink(a, sequence[:f.at+1], f)
+		return a.Fail(machine.describe(sequence, f, run, original, seed))
+	}
+	return a
+}
+
+// generate builds and runs a random sequence of up to n steps, stopping at
+// the first failure or when no command's precondition holds.
+func (m Machine[M, S]) generate(a *assertions.Assert, r *rand.Rand, n int) ([]step, *failure) {
+	model, sys := m.Init()
+	if m.Cleanup != nil {
+		defer m.Cleanup(sys)
+	}
+
+	var sequence []step
+	for i := 0; i < n; i++ {
+		var enabled []int
+		for c, command := range m.Commands {
+			if command.Pre == nil || command.Pre(model) {
+				enabled = append(enabled, c)
+			}
+		}
+		if len(enabled) == 0 {
+			break
+		}
+
+		s := step{command: enabled[r.IntN(len(enabled))]}
+		if gen := m.Commands[s.command].Gen; gen != nil {
+			s.arg = gen(r, model)
+		}
+		sequence = append(sequence, s)
+
+		var f *failure
+		if model, f = m.apply(a, model, sys, s); f != nil {
+			f.at = i
+			return sequence, f
+		}
+	}
+	return sequence, nil
+}
+
+// replay runs sequence against a fresh system under test. It reports
+// whether every precondition held and, if so, the failure, if any.
+func (m Machine[M, S]) replay(a *assertions.Assert, sequence []step) (bool, *failure) {
+	model, sys := m.Init()
+	if m.Cleanup != nil {
+		defer m.Cleanup(sys)
+	}
+
+	for i, s := range sequence {
+		if pre := m.Commands[s.command].Pre; pre != nil && !pre(model) {
+			return false, nil
+		}
+
+		var f *failure
+		if model, f = m.apply(a, model, sys, s); f != nil {
+			f.at = i
+			return true, f
+		}
+	}
+	return true, nil
+}
+
+// shrink removes steps from a failing sequence while it still fails, first
+// in large chunks and then one at a time.
+func (m Machine[M, S]) shrink(a *assertions.Assert, sequence []step, f *failure) ([]step, *failure) {
+	for chunk := len(sequence) / 2; chunk >= 1; {
+		removed := false
+		for start := 0; start+chunk <= len(sequence); start++ {
+			candidate := append(append([]step(nil), sequence[:start]...), sequence[start+chunk:]...)
+			if valid, cf := m.replay(a, candidate); valid && cf != nil {
+				sequence, f, removed = candidate[:cf.at+1], cf, true
+				break
+			}
+		}
+		if !removed {
+			chunk /= 2
+		}
+		chunk = min(chunk, len(sequence)/2, len(sequence)-1)
+	}
+	return sequence, f
+}
+
+// apply runs one step and checks the invariants, returning the next model
+// and the first failure, if any.
+func (m Machine[M, S]) apply(a *assertions.Assert, model M, sys S, s step) (M, *failure) {
+	command := m.Commands[s.command]
+
+	next := model
+	if message := capture(a, func(ca *assertions.Assert) { next = command.Run(ca, model, sys, s.arg) }); message != "" {
+		return model, &failure{check: command.Name, message: message}
+	}
+
+	for _, invariant := range m.Invariants {
+		if message := capture(a, func(ca *assertions.Assert) { invariant.Check(ca, next, sys) }); message != "" {
+			return next, &failure{check: "invariant " + invariant.Name, message: message}
+		}
+	}
+	return next, nil
+}
+
+// describe renders a failing sequence.
+func (m Machine[M, S]) describe(sequence []step, f *failure, run, original int, seed uint64) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "expected state machine to hold its invariants, found a failing sequence on run %d", run+1)
+	if original != len(sequence) {
+		fmt.Fprintf(&b, "\n  shrunk from %d to %d steps:", original, len(sequence))
+	} else {
+		fmt.Fprintf(&b, "\n  %d steps:", len(sequence))
+	}
+	for i, s := range sequence {
+		fmt.Fprintf(&b, "\n    %d. %s", i+1, m.render(s))
+	}
+	fmt.Fprintf(&b, "\n  %s failed after step %d:\n    %s", f.check, f.at+1, strings.ReplaceAll(f.message, "\n", "\n    "))
+	fmt.Fprintf(&b, "\n  reproduce with Seed: %d", seed)
+	return b.String()
+}
+
+// render renders a step, as in Push(42).
+func (m Machine[M, S]) render(s step) string {
+	name := m.Commands[s.command].Name
+	if s.arg == nil {
+		return name
+	}
+	return fmt.Sprintf("%s(%#v)", name, s.arg)
+}
+
+// capture runs fn with an Assert derived from a whose failures are recorded
+// rather than reported, and returns the failure message, if any. FailNow and
+// panics stop fn and count as failures.
+func capture(a *assertions.Assert, fn func(a *assertions.Assert)) string {
+	t := &captureT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				t.Errorf("panic: %v", r)
+			}
+		}()
+		fn(a.For(t))
+	}()
+	<-done
+
+	if t.stopped && len(t.messages) == 0 {
+		return "FailNow called"
+	}
+	return strings.Join(t.messages, "\n")
+}
+
+// captureT is a testing context that records failures.
+type captureT struct {
+	messages []string
+	stopped  bool
+}
+
+func (t *captureT) Errorf(format string, args ...interface{}) {
+	t.messages = append(t.messages, fmt.Sprintf(format, args...))
+}
+
+func (t *captureT) FailNow() {
+	t.stopped = true
+	runtime.Goexit()
+}
+
+func (t *captureT) Helper() {}
@@ -0,0 +1,169 @@
+package fsm
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// mockT records failures reported through an Assert.
+type mockT struct {
+	errorCalls []string
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *mockT) FailNow() {}
+func (m *mockT) Helper()  {}
+
+// stack is a system under test. With a non-zero limit it silently drops
+// pushes beyond it.
+type stack struct {
+	items []int
+	limit int
+}
+
+func (s *stack) Push(v int) {
+	if s.limit > 0 && len(s.items) == s.limit {
+		return
+	}
+	s.items = append(s.items, v)
+}
+
+func (s *stack) Pop() int {
+	v := s.items[len(s.items)-1]
+	s.items = s.items[:len(s.items)-1]
+	return v
+}
+
+func (s *stack) Len() int { return len(s.items) }
+
+// stackMachine models a stack as a slice of its items.
+func stackMachine(limit int) Machine[[]int, *stack] {
+	return Machine[[]int, *stack]{
+		Init: func() ([]int, *stack) { return nil, &stack{limit: limit} },
+		Commands: []Command[[]int, *stack]{
+			{
+				Name: "Push",
+				Gen:  func(r *rand.Rand, _ []int) any { return r.IntN(100) },
+				Run: func(a *assertions.Assert, model []int, s *stack, arg any) []int {
+					s.Push(arg.(int))
+					return append(slices.Clone(model), arg.(int))
+				},
+			},
+			{
+				Name: "Pop",
+				Pre:  func(model []int) bool { return len(model) > 0 },
+				Run: func(a *assertions.Assert, model []int, s *stack, _ any) []int {
+					a.Equal(s.Pop(), model[len(model)-1])
+					return model[:len(model)-1]
+				},
+			},
+		},
+		Invariants: []Invariant[[]int, *stack]{
+			{Name: "length", Check: func(a *assertions.Assert, model []int, s *stack) {
+				a.Equal(s.Len(), len(model))
+			}},
+		},
+		Seed: 42,
+	}
+}
+
+func TestCheckPasses(t *testing.T) {
+	mock := &mockT{}
+	Check(assertions.New(mock), stackMachine(0))
+
+	if len(mock.errorCalls) != 0 {
+		t.Fatalf("expected a correct stack to pass, got:\n%s", mock.errorCalls[0])
+	}
+}
+
+func TestCheckShrinksFailingSequence(t *testing.T) {
+	mock := &mockT{}
+	Check(assertions.New(mock), stackMachine(3))
+
+	if len(mock.errorCalls) != 1 {
+		t.Fatalf("expected exactly one error, got %d", len(mock.errorCalls))
+	}
+	message := mock.errorCalls[0]
+	for _, want := range []string{
+		"expected state machine to hold its invariants, found a failing sequence on run",
+		"to 4 steps:",
+		"\n    1. Push(", "\n    4. Push(",
+		"invariant length failed after step 4:",
+		"reproduce with Seed: 42",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("expected message to contain %q, got:\n%s", want, message)
+		}
+	}
+	if strings.Contains(message, "5. ") || strings.Contains(message, "Pop") {
+		t.Errorf("expected the sequence to shrink to four pushes, got:\n%s", message)
+	}
+}
+
+func TestCheckCapturesFailNowAndPanics(t *testing.T) {
+	tests := []struct {
+		name          string
+		run           func(a *assertions.Assert)
+		expectMessage string
+	}{
+		{"FailNow", func(a *assertions.Assert) { a.With(assertions.UseFatal(true)).True(false) }, "Boom failed after step 1:"},
+		{"panic", func(a *assertions.Assert) { panic("kaboom") }, "Boom failed after step 1:\n    panic: kaboom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanups := 0
+			machine := Machine[int, *int]{
+				Init:    func() (int, *int) { return 0, new(int) },
+				Cleanup: func(*int) { cleanups++ },
+				Commands: []Command[int, *int]{{
+					Name: "Boom",
+					Run: func(a *assertions.Assert, model int, _ *int, _ any) int {
+						tt.run(a)
+						return model
+					},
+				}},
+				Runs: 1,
+			}
+
+			mock := &mockT{}
+			Check(assertions.New(mock), machine)
+
+			if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Fatalf("expected message to contain %q, got %v", tt.expectMessage, mock.errorCalls)
+			}
+			if cleanups == 0 {
+				t.Errorf("expected Cleanup to be called")
+			}
+		})
+	}
+}
+
+func TestCheckFailFast(t *testing.T) {
+	assert := assertions.New(&mockT{})
+	assert.True(false)
+
+	inits := 0
+	machine := stackMachine(0)
+	machine.Init = func() ([]int, *stack) { inits++; return nil, &stack{} }
+	Check(assert, machine)
+
+	if inits != 0 {
+		t.Errorf("expected no sequences to run after a failure, ran %d", inits)
+	}
+}
+
+func ExampleCheck() {
+	assert := assertions.New(&mockT{})
+	Check(assert, stackMachine(0))
+
+	fmt.Println("Failed:", assert.HasFailed())
+	// Output: Failed: false
+}
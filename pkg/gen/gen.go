@@ -0,0 +1,289 @@
+// Package gen generates random test data from a seed, so that a failing test
+// can be rerun with exactly the same data.
+//
+// Create a Gen per test with New. The seed is fresh each run unless SeedEnv
+// is set, and is logged if the test fails:
+//
+//	func TestSignup(t *testing.T) {
+//		g := gen.New(t)
+//		email := g.Email()
+//		user := gen.Value[User](g)
+//		...
+//	}
+//
+// A failure then logs a line such as
+//
+//	gen: random data seed 8349815129; rerun with GOWISE_SEED=8349815129 to reproduce
+//
+// FromRand wraps the *rand.Rand a property assertion such as
+// assertions.RoundTrips hands its generator, so the property's own seed
+// reproduces the data:
+//
+//	assertions.RoundTrips(assert, encode, decode, func(r *rand.Rand) User {
+//		return gen.Value[User](gen.FromRand(r))
+//	})
+package gen
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// SeedEnv names the environment variable that fixes the seed of every Gen
+// created with New, to reproduce a failure.
+const SeedEnv = "GOWISE_SEED"
+
+// maxFillDepth bounds how deeply Fill follows pointers, slices and maps, so
+// that recursive types such as trees end.
+const maxFillDepth = 4
+
+// alphanumeric is the alphabet of String.
+const alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// emailAlphabet is the alphabet of the local part of Email, letters first.
+const emailAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// emailDomains are reserved for documentation and testing, so generated
+// addresses never reach a real mailbox.
+var emailDomains = []string{"example.com", "example.org", "example.net"}
+
+// TB is the part of testing.TB that New uses. *testing.T, *testing.B and
+// *testing.F implement it.
+type TB interface {
+	Helper()
+	Cleanup(func())
+	Failed() bool
+	Logf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// Gen is a seeded source of random test data. It is not safe for concurrent
+// use; give each goroutine a Gen of its own with NewSeeded.
+type Gen struct {
+	r    *rand.Rand
+	seed uint64
+}
+
+// New returns a Gen for the test t, seeded from SeedEnv if it is set or
+// freshly otherwise. If t fails, the seed is logged with how to rerun the
+// test with it. An invalid SeedEnv stops the test.
+func New(t TB) *Gen {
+	t.Helper()
+
+	seed := rand.Uint64()
+	if value, ok := os.LookupEnv(SeedEnv); ok {
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			t.Fatalf("gen: invalid %s %q: %v", SeedEnv, value, err)
+			return NewSeeded(0)
+		}
+		seed = parsed
+	}
+
+	g := NewSeeded(seed)
+	t.Cleanup(func() {
+		if t.Failed() {
+			t.Logf("gen: random data seed %d; rerun with %s=%d to reproduce", seed, SeedEnv, seed)
+		}
+	})
+	return g
+}
+
+// NewSeeded returns a Gen that always produces the same data for seed.
+func NewSeeded(seed uint64) *Gen {
+	return &Gen{r: rand.New(rand.NewPCG(seed, seed)), seed: seed}
+}
+
+// FromRand returns a Gen drawing from r, for generators that are given a
+// source rather than a seed, such as those of the property assertions. Its
+// Seed is 0, as r's seed is not known.
+func FromRand(r *rand.Rand) *Gen {
+	return &Gen{r: r}
+}
+
+// Seed returns the seed g was created from.
+func (g *Gen) Seed() uint64 {
+	return g.seed
+}
+
+// Rand returns g's source of randomness, for generators written against
+// math/rand/v2.
+func (g *Gen) Rand() *rand.Rand {
+	return g.r
+}
+
+// Int returns an int in [min, max]. It panics if max < min.
+func (g *Gen) Int(min, max int) int {
+	if max < min {
+		panic(fmt.Sprintf("gen: Int called with max %d < min %d", max, min))
+	}
+	span := uint64(max) - uint64(min)
+	if span == math.MaxUint64 {
+		return int(g.r.Uint64())
+	}
+	return min + int(g.r.Uint64N(span+1))
+}
+
+// Float64 returns a float64 in [min, max). It panics if max < min.
+func (g *Gen) Float64(min, max float64) float64 {
+	if max < min {
+		panic(fmt.Sprintf("gen: Float64 called with max %g < min %g", max, min))
+	}
+	return min + g.r.Float64()*(max-min)
+}
+
+// Bool returns true or false with equal probability.
+func (g *Gen) Bool() bool {
+	return g.r.IntN(2) == 1
+}
+
+// String returns a string of ASCII letters and digits whose length is in
+// [minLen, maxLen].
+func (g *Gen) String(minLen, maxLen int) string {
+	b := make([]byte, g.Int(minLen, maxLen))
+	for i := range b {
+		b[i] = alphanumeric[g.r.IntN(len(alphanumeric))]
+	}
+	return string(b)
+}
+
+// Email returns an address at a domain reserved for examples, such as
+// "k3xq9w@example.org", so generated addresses cannot reach a real mailbox.
+func (g *Gen) Email() string {
+	local := make([]byte, g.Int(4, 12))
+	local[0] = emailAlphabet[g.r.IntN(26)] // Start with a letter
+	for i := 1; i < len(local); i++ {
+		local[i] = emailAlphabet[g.r.IntN(len(emailAlphabet))]
+	}
+	return string(local) + "@" + OneOf(g, emailDomains...)
+}
+
+// Time returns a time in [from, to], in UTC. The span must fit in a
+// time.Duration, about 292 years. It panics if to is before from.
+func (g *Gen) Time(from, to time.Time) time.Time {
+	return from.Add(g.Duration(0, to.Sub(from))).UTC()
+}
+
+// Duration returns a duration in [min, max]. It panics if max < min.
+func (g *Gen) Duration(min, max time.Duration) time.Duration {
+	return time.Duration(g.Int(int(min), int(max)))
+}
+
+// OneOf returns one of items, chosen uniformly. It panics if there are none.
+func OneOf[T any](g *Gen, items ...T) T {
+	if len(items) == 0 {
+		panic("gen: OneOf called with no items")
+	}
+	return items[g.r.IntN(len(items))]
+}
+
+// SliceOf returns a slice whose length is in [minLen, maxLen], with each
+// element produced by elem.
+//
+// Example:
+//
+//	tags := gen.SliceOf(g, 1, 5, func(g *gen.Gen) string { return g.String(3, 8) })
+func SliceOf[T any](g *Gen, minLen, maxLen int, elem func(*Gen) T) []T {
+	s := make([]T, g.Int(minLen, maxLen))
+	for i := range s {
+		s[i] = elem(g)
+	}
+	return s
+}
+
+// Value returns a T filled with random data by Fill.
+//
+// Example:
+//
+//	order := gen.Value[Order](g)
+func Value[T any](g *Gen) T {
+	var v T
+	g.Fill(&v)
+	return v
+}
+
+// Fill sets the value ptr points to, and every exported field of a struct
+// within it, to random data: numbers of either sign within ±100 for signed
+// types and up to 200 for unsigned ones, alphanumeric strings of up to 12
+// characters, times between 2000 and 2030, and slices and maps of up to 3
+// elements. Pointers, slices and maps are followed to a depth of 4, so
+// recursive types end. Interfaces, channels and functions are left as they
+// are. It panics if ptr is not a non-nil pointer.
+func (g *Gen) Fill(ptr any) {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		panic(fmt.Sprintf("gen: Fill needs a non-nil pointer, got %T", ptr))
+	}
+	g.fill(v.Elem(), 0)
+}
+
+var (
+	timeType  = reflect.TypeFor[time.Time]()
+	fillStart = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	fillEnd   = time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// fill sets v to random data, depth levels below the value given to Fill.
+func (g *Gen) fill(v reflect.Value, depth int) {
+	if v.Type() == timeType {
+		v.Set(reflect.ValueOf(g.Time(fillStart, fillEnd)))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(g.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(g.Int(-100, 100)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(g.Int(0, 200)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(g.Float64(-100, 100))
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(g.Float64(-100, 100), g.Float64(-100, 100)))
+	case reflect.String:
+		v.SetString(g.String(0, 12))
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if field := v.Field(i); field.CanSet() {
+				g.fill(field, depth)
+			}
+		}
+	case reflect.Array:
+		for i := range v.Len() {
+			g.fill(v.Index(i), depth)
+		}
+	case reflect.Pointer:
+		if depth < maxFillDepth {
+			v.Set(reflect.New(v.Type().Elem()))
+			g.fill(v.Elem(), depth+1)
+		}
+	case reflect.Slice:
+		n := 0
+		if depth < maxFillDepth {
+			n = g.Int(0, 3)
+		}
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := range n {
+			g.fill(s.Index(i), depth+1)
+		}
+		v.Set(s)
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		if depth < maxFillDepth {
+			for range g.Int(0, 3) {
+				key := reflect.New(v.Type().Key()).Elem()
+				elem := reflect.New(v.Type().Elem()).Elem()
+				g.fill(key, depth+1)
+				g.fill(elem, depth+1)
+				m.SetMapIndex(key, elem)
+			}
+		}
+		v.Set(m)
+	}
+}
@@ -0,0 +1,242 @@
+package gen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeTB records what New logs and registers, and fails on demand.
+type fakeTB struct {
+	failed   bool
+	logs     []string
+	fatals   []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper()           { /* no-op */ }
+func (f *fakeTB) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *fakeTB) Failed() bool      { return f.failed }
+func (f *fakeTB) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+func (f *fakeTB) Fatalf(format string, args ...any) {
+	f.fatals = append(f.fatals, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) runCleanups() {
+	for _, fn := range f.cleanups {
+		fn()
+	}
+}
+
+type address struct {
+	Street string
+	Zip    uint16
+}
+
+type customer struct {
+	Name       string
+	Age        int
+	Score      float64
+	Active     bool
+	Joined     time.Time
+	Home       *address
+	Tags       []string
+	Limits     map[string]int
+	Codes      [2]int8
+	OnChange   func()
+	unexported int
+}
+
+type node struct {
+	Value    int
+	Children []*node
+	Next     *node
+}
+
+func TestSameSeedSameData(t *testing.T) {
+	draw := func(g *Gen) []any {
+		return []any{g.Int(-5, 5), g.String(0, 10), g.Email(), g.Bool(),
+			g.Time(fillStart, fillEnd), Value[customer](g)}
+	}
+	first, second := draw(NewSeeded(42)), draw(NewSeeded(42))
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same data for the same seed\n  first:  %v\n  second: %v", first, second)
+	}
+	if other := draw(NewSeeded(43)); reflect.DeepEqual(other, first) {
+		t.Errorf("expected different data for a different seed, got %v for both", first)
+	}
+}
+
+func TestRanges(t *testing.T) {
+	g := NewSeeded(1)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	for range 1000 {
+		if n := g.Int(-3, 3); n < -3 || n > 3 {
+			t.Fatalf("Int(-3, 3) returned %d", n)
+		}
+		if f := g.Float64(1, 2); f < 1 || f >= 2 {
+			t.Fatalf("Float64(1, 2) returned %g", f)
+		}
+		if s := g.String(2, 4); len(s) < 2 || len(s) > 4 {
+			t.Fatalf("String(2, 4) returned %q", s)
+		}
+		if tm := g.Time(from, to); tm.Before(from) || tm.After(to) {
+			t.Fatalf("Time returned %v, outside [%v, %v]", tm, from, to)
+		}
+		if d := g.Duration(time.Second, 2*time.Second); d < time.Second || d > 2*time.Second {
+			t.Fatalf("Duration returned %v", d)
+		}
+		if s := SliceOf(g, 1, 3, func(g *Gen) bool { return g.Bool() }); len(s) < 1 || len(s) > 3 {
+			t.Fatalf("SliceOf(1, 3) returned %d elements", len(s))
+		}
+	}
+	if n := g.Int(7, 7); n != 7 {
+		t.Errorf("Int(7, 7) returned %d", n)
+	}
+}
+
+func TestEmail(t *testing.T) {
+	g := NewSeeded(7)
+	for range 100 {
+		email := g.Email()
+		local, domain, ok := strings.Cut(email, "@")
+		if !ok || len(local) < 4 || local[0] < 'a' || local[0] > 'z' {
+			t.Fatalf("unexpected address %q", email)
+		}
+		if domain != "example.com" && domain != "example.org" && domain != "example.net" {
+			t.Fatalf("address %q uses a domain not reserved for examples", email)
+		}
+	}
+}
+
+func TestFill(t *testing.T) {
+	g := NewSeeded(3)
+	var filled int
+	for range 50 {
+		c := Value[customer](g)
+		if c.OnChange != nil || c.unexported != 0 {
+			t.Fatalf("expected functions and unexported fields to be left alone, got %+v", c)
+		}
+		if c.Age < -100 || c.Age > 100 {
+			t.Fatalf("Age %d out of range", c.Age)
+		}
+		if c.Joined.Before(fillStart) || c.Joined.After(fillEnd) {
+			t.Fatalf("Joined %v out of range", c.Joined)
+		}
+		if c.Home == nil || c.Limits == nil || c.Tags == nil {
+			t.Fatalf("expected pointers, maps and slices to be set, got %+v", c)
+		}
+		if c.Name != "" && c.Home.Street != "" {
+			filled++
+		}
+	}
+	if filled == 0 {
+		t.Error("expected some strings to be non-empty")
+	}
+}
+
+func TestFillRecursiveType(t *testing.T) {
+	g := NewSeeded(5)
+	var depth func(n *node) int
+	depth = func(n *node) int {
+		if n == nil {
+			return 0
+		}
+		deepest := depth(n.Next)
+		for _, c := range n.Children {
+			deepest = max(deepest, depth(c))
+		}
+		return deepest + 1
+	}
+	for range 20 {
+		n := Value[node](g)
+		if d := depth(&n); d > maxFillDepth+1 {
+			t.Fatalf("expected recursion to stop at depth %d, got %d", maxFillDepth+1, d)
+		}
+	}
+}
+
+func TestFillNeedsPointer(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "non-nil pointer") {
+			t.Errorf("expected a panic asking for a pointer, got %v", r)
+		}
+	}()
+	NewSeeded(1).Fill(customer{})
+}
+
+func TestNewSeedLogging(t *testing.T) {
+	t.Run("env seed reproduces data", func(t *testing.T) {
+		t.Setenv(SeedEnv, "1234")
+		g := New(&fakeTB{})
+		if g.Seed() != 1234 {
+			t.Fatalf("expected seed 1234 from %s, got %d", SeedEnv, g.Seed())
+		}
+		if got, want := g.String(5, 5), NewSeeded(1234).String(5, 5); got != want {
+			t.Errorf("expected the data of seed 1234, got %q want %q", got, want)
+		}
+	})
+
+	t.Run("seed logged on failure", func(t *testing.T) {
+		tb := &fakeTB{failed: true}
+		g := New(tb)
+		tb.runCleanups()
+		want := fmt.Sprintf("rerun with %s=%d to reproduce", SeedEnv, g.Seed())
+		if len(tb.logs) != 1 || !strings.Contains(tb.logs[0], want) {
+			t.Errorf("expected a log containing %q, got %v", want, tb.logs)
+		}
+	})
+
+	t.Run("silent on success", func(t *testing.T) {
+		tb := &fakeTB{}
+		New(tb)
+		tb.runCleanups()
+		if len(tb.logs) != 0 {
+			t.Errorf("expected no logs for a passing test, got %v", tb.logs)
+		}
+	})
+
+	t.Run("invalid env seed", func(t *testing.T) {
+		t.Setenv(SeedEnv, "soon")
+		tb := &fakeTB{}
+		New(tb)
+		if len(tb.fatals) != 1 || !strings.Contains(tb.fatals[0], `invalid GOWISE_SEED "soon"`) {
+			t.Errorf("expected the test to stop on an invalid seed, got %v", tb.fatals)
+		}
+	})
+}
+
+func TestOneOf(t *testing.T) {
+	g := NewSeeded(9)
+	seen := map[string]bool{}
+	for range 100 {
+		seen[OneOf(g, "red", "green", "blue")] = true
+	}
+	if want := map[string]bool{"red": true, "green": true, "blue": true}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("expected every item to be chosen, got %v", seen)
+	}
+}
+
+func ExampleNewSeeded() {
+	g := NewSeeded(2024)
+	again := NewSeeded(2024)
+
+	fmt.Println(g.Email() == again.Email())
+	fmt.Println(g.Int(1, 6) == again.Int(1, 6))
+	// Output:
+	// true
+	// true
+}
+
+func TestFromRand(t *testing.T) {
+	got := Value[customer](FromRand(NewSeeded(11).Rand()))
+	want := Value[customer](NewSeeded(11))
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected FromRand to draw from the given source\n  got:  %+v\n  want: %+v", got, want)
+	}
+}
@@ -12,6 +12,8 @@ type Result int
 const (
 	Passed Result = iota
 	Failed
+	Errored // The test could not run, for example because its fixture setup failed
+	Skipped // The test was deliberately not run
 )
 
 // GetResult returns the string representation of the test result.
@@ -21,6 +23,10 @@ func (r Result) GetResult() string {
 		return "Passed"
 	case Failed:
 		return "Failed"
+	case Errored:
+		return "Errored"
+	case Skipped:
+		return "Skipped"
 	default:
 		return "Unknown"
 	}
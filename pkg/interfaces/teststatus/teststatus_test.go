@@ -17,6 +17,14 @@ func TestResultGetResult(t *testing.T) {
 	failed := Result(Failed)
 	assert.Equal("Failed", failed.GetResult())
 
+	// Test for Errored result
+	errored := Result(Errored)
+	assert.Equal("Errored", errored.GetResult())
+
+	// Test for Skipped result
+	skipped := Result(Skipped)
+	assert.Equal("Skipped", skipped.GetResult())
+
 	// Test for Unknown result
 	unknown := Result(42) // Some unknown result
 	assert.Equal("Unknown", unknown.GetResult())
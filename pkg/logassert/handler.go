@@ -0,0 +1,158 @@
+package logassert
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is a log record captured by a Handler.
+type Record struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	// Attrs are the record's attributes, after those added with WithAttrs,
+	// resolved and flattened: an attribute in a group has the key
+	// "group.key".
+	Attrs []slog.Attr
+}
+
+// Attr returns the value of the attribute key, qualified by its groups as in
+// Attrs, and whether the record has it. If the key repeats, the last value
+// wins, as it would for a handler writing JSON.
+func (r Record) Attr(key string) (slog.Value, bool) {
+	for i := len(r.Attrs) - 1; i >= 0; i-- {
+		if r.Attrs[i].Key == key {
+			return r.Attrs[i].Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+// String renders the record as slog.TextHandler would, without the time.
+func (r Record) String() string {
+	var b strings.Builder
+	b.WriteString("level=" + r.Level.String() + " msg=" + quoteIfNeeded(r.Message))
+	for _, attr := range r.Attrs {
+		b.WriteString(" " + quoteIfNeeded(attr.Key) + "=" + quoteIfNeeded(attr.Value.String()))
+	}
+	return b.String()
+}
+
+// Handler is a slog.Handler that captures every record, at every level, for
+// assertions. Handlers derived from it with WithAttrs and WithGroup capture
+// into the same records. It is safe for concurrent use.
+type Handler struct {
+	log    *recordLog
+	attrs  []slog.Attr // Attributes from WithAttrs, flattened
+	prefix string      // Groups from WithGroup, each followed by "."
+}
+
+// recordLog holds the records shared by a Handler and those derived from it.
+type recordLog struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewHandler returns a Handler with no records.
+//
+// Example:
+//
+//	h := logassert.NewHandler()
+//	svc := payments.NewService(slog.New(h))
+func NewHandler() *Handler {
+	return &Handler{log: &recordLog{}}
+}
+
+// Enabled reports that records at every level are captured.
+func (h *Handler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+// Handle captures r.
+func (h *Handler) Handle(_ context.Context, r slog.Record) error {
+	record := Record{
+		Time:    r.Time,
+		Level:   r.Level,
+		Message: r.Message,
+		Attrs:   slices.Clone(h.attrs),
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		record.Attrs = flatten(record.Attrs, h.prefix, attr)
+		return true
+	})
+
+	h.log.mu.Lock()
+	defer h.log.mu.Unlock()
+	h.log.records = append(h.log.records, record)
+	return nil
+}
+
+// WithAttrs returns a Handler that adds attrs to every record it captures.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	derived := *h
+	derived.attrs = slices.Clone(h.attrs)
+	for _, attr := range attrs {
+		derived.attrs = flatten(derived.attrs, h.prefix, attr)
+	}
+	return &derived
+}
+
+// WithGroup returns a Handler that qualifies the keys of later attributes
+// with name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	derived := *h
+	derived.prefix = h.prefix + name + "."
+	return &derived
+}
+
+// Records returns a copy of the records captured so far, oldest first.
+func (h *Handler) Records() []Record {
+	h.log.mu.Lock()
+	defer h.log.mu.Unlock()
+	return slices.Clone(h.log.records)
+}
+
+// Reset discards the records captured so far.
+func (h *Handler) Reset() {
+	h.log.mu.Lock()
+	defer h.log.mu.Unlock()
+	h.log.records = nil
+}
+
+// flatten appends attr to attrs with its key qualified by prefix, resolving
+// its value and replacing a group by its members, as the slog.Handler rules
+// require: empty attributes are dropped, and a group with an empty key is
+// inlined.
+func flatten(attrs []slog.Attr, prefix string, attr slog.Attr) []slog.Attr {
+	attr.Value = attr.Value.Resolve()
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, member := range attr.Value.Group() {
+			attrs = flatten(attrs, prefix, member)
+		}
+		return attrs
+	}
+	if attr.Equal(slog.Attr{}) {
+		return attrs
+	}
+	return append(attrs, slog.Attr{Key: prefix + attr.Key, Value: attr.Value})
+}
+
+// quoteIfNeeded quotes s if it would be ambiguous unquoted in a key=value
+// pair.
+func quoteIfNeeded(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\n\r") || !strconv.CanBackquote(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
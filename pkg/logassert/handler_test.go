@@ -0,0 +1,80 @@
+package logassert
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// clientAddr resolves to a group, as a slog.LogValuer may.
+type clientAddr struct{ host string }
+
+func (c clientAddr) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("host", c.host), slog.Int("port", 443))
+}
+
+func TestHandlerCapturesRecords(t *testing.T) {
+	h := NewHandler()
+	logger := slog.New(h).With("service", "payments").WithGroup("request")
+
+	logger.Debug("lookup")
+	logger.Error("upstream failed",
+		"method", "POST",
+		slog.Group("retry", "attempt", 3),
+		slog.Group("", "inlined", true),
+		slog.Group("empty"),
+		"client", clientAddr{"api.example.com"})
+
+	records := h.Records()
+	if len(records) != 2 {
+		t.Fatalf("Expected both records, at every level, got %d", len(records))
+	}
+	if records[0].Level != slog.LevelDebug || records[0].Message != "lookup" {
+		t.Errorf("Expected the debug record first, got %v", records[0])
+	}
+
+	got := records[1].String()
+	want := "level=ERROR msg=\"upstream failed\" service=payments request.method=POST request.retry.attempt=3 " +
+		"request.inlined=true request.client.host=api.example.com request.client.port=443"
+	if got != want {
+		t.Errorf("Expected the attributes flattened and resolved:\n got  %s\n want %s", got, want)
+	}
+	if v, ok := records[1].Attr("request.retry.attempt"); !ok || v.Int64() != 3 {
+		t.Errorf("Expected Attr to find a grouped attribute, got %v, %v", v, ok)
+	}
+	if _, ok := records[1].Attr("method"); ok {
+		t.Error("Expected Attr to need the qualified key")
+	}
+	if records[1].Time.IsZero() {
+		t.Error("Expected the record's time to be kept")
+	}
+
+	h.Reset()
+	if len(h.Records()) != 0 {
+		t.Error("Expected Reset to discard the records")
+	}
+}
+
+func TestHandlerDerivedHandlersShareRecords(t *testing.T) {
+	h := NewHandler()
+	if h.WithGroup("") != h {
+		t.Error("Expected WithGroup with an empty name to return the handler")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slog.New(h).With("worker", i).Info("done")
+		}()
+	}
+	wg.Wait()
+	_ = h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelWarn, "direct", 0))
+
+	if got := len(h.Records()); got != 11 {
+		t.Errorf("Expected 11 records, got %d", got)
+	}
+}
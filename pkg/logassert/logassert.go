@@ -0,0 +1,132 @@
+// Package logassert checks what code logs through log/slog. A Handler
+// captures records, with their levels and attributes, and the assertions
+// check them, so a service's logging contract can be verified without
+// scraping its output with regular expressions.
+//
+// Each assertion takes the *assertions.Assert to report to, so it takes part
+// in fail-fast chaining like a built-in assertion:
+//
+//	func TestDeclinedPaymentIsLogged(t *testing.T) {
+//		h := logassert.NewHandler()
+//		svc := payments.NewService(slog.New(h))
+//		svc.Charge(ctx, declinedCard, 500)
+//
+//		assert := assertions.New(t)
+//		records := h.Records()
+//		logassert.LoggedAtLevel(assert, records, slog.LevelWarn)
+//		logassert.LoggedMessageContaining(assert, records, "card declined")
+//		logassert.LoggedWithAttr(assert, records, "payment.amount", 500)
+//	}
+package logassert
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gowise/pkg/assertions"
+)
+
+// maxListedRecords bounds the records a failure message lists.
+const maxListedRecords = 20
+
+// LoggedAtLevel fails unless one of records is at level.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	logassert.LoggedAtLevel(assert, h.Records(), slog.LevelError)
+func LoggedAtLevel(a *assertions.Assert, records []Record, level slog.Level) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	for _, r := range records {
+		if r.Level == level {
+			return a
+		}
+	}
+	return a.Fail(fmt.Sprintf("expected a record at level %s%s", level, listRecords(records)))
+}
+
+// LoggedMessageContaining fails unless the message of one of records
+// contains substr. Attributes are not searched; check them with
+// LoggedWithAttr.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	logassert.LoggedMessageContaining(assert, h.Records(), "connection refused")
+func LoggedMessageContaining(a *assertions.Assert, records []Record, substr string) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	for _, r := range records {
+		if strings.Contains(r.Message, substr) {
+			return a
+		}
+	}
+	return a.Fail(fmt.Sprintf("expected a record with a message containing %s%s", strconv.Quote(substr), listRecords(records)))
+}
+
+// LoggedWithAttr fails unless one of records has the attribute key with
+// value. Keys in groups are qualified by the group, as in "request.method".
+// Values are compared as slog.AnyValue makes them, so the int 500 matches an
+// attribute logged as slog.Int64("status", 500).
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	logassert.LoggedWithAttr(assert, h.Records(), "status", 503)
+func LoggedWithAttr(a *assertions.Assert, records []Record, key string, value any) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	want := slog.AnyValue(value).Resolve()
+	for _, r := range records {
+		if got, ok := r.Attr(key); ok && valuesEqual(got, want) {
+			return a
+		}
+	}
+	return a.Fail(fmt.Sprintf("expected a record with attribute %s=%s%s", key, want, listRecords(records)))
+}
+
+// valuesEqual reports whether two resolved attribute values are equal.
+// slog.Value.Equal compares values of kind Any with ==, which panics for
+// slices and maps, so those are compared with reflect.DeepEqual.
+func valuesEqual(got, want slog.Value) bool {
+	if got.Kind() == slog.KindAny && want.Kind() == slog.KindAny {
+		return reflect.DeepEqual(got.Any(), want.Any())
+	}
+	return got.Equal(want)
+}
+
+// listRecords renders records for a failure message.
+func listRecords(records []Record) string {
+	if len(records) == 0 {
+		return "\n  records: (none)"
+	}
+	var b strings.Builder
+	b.WriteString("\n  records:")
+	for i, r := range records {
+		if i == maxListedRecords {
+			fmt.Fprintf(&b, "\n    ... and %d more", len(records)-i)
+			break
+		}
+		b.WriteString("\n    " + r.String())
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+package logassert
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// mockT records failures reported through an Assert.
+type mockT struct {
+	errorCalls []string
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *mockT) FailNow() {}
+func (m *mockT) Helper()  {}
+
+// paymentRecords logs as a payment service declining a card would.
+func paymentRecords() []Record {
+	h := NewHandler()
+	logger := slog.New(h)
+	logger.Info("charging card", "amount", 500)
+	logger.Warn("card declined", slog.Group("payment", slog.Int64("amount", 500), "currency", "GBP"),
+		"tags", []string{"fraud-check"})
+	return h.Records()
+}
+
+func TestAssertions(t *testing.T) {
+	records := paymentRecords()
+
+	tests := []struct {
+		name          string
+		assert        func(a *assertions.Assert)
+		expectMessage string // Empty if the assertion passes
+	}{
+		{"at level", func(a *assertions.Assert) { LoggedAtLevel(a, records, slog.LevelWarn) }, ""},
+		{"no record at level", func(a *assertions.Assert) { LoggedAtLevel(a, records, slog.LevelError) },
+			"expected a record at level ERROR\n  records:\n    level=INFO msg=\"charging card\" amount=500\n    level=WARN msg=\"card declined\""},
+		{"no records", func(a *assertions.Assert) { LoggedAtLevel(a, nil, slog.LevelError) },
+			"expected a record at level ERROR\n  records: (none)"},
+		{"message", func(a *assertions.Assert) { LoggedMessageContaining(a, records, "declined") }, ""},
+		{"message missing", func(a *assertions.Assert) { LoggedMessageContaining(a, records, "GBP") },
+			"expected a record with a message containing \"GBP\"\n  records:"},
+		{"attribute", func(a *assertions.Assert) { LoggedWithAttr(a, records, "payment.currency", "GBP") }, ""},
+		{"attribute of another kind of int", func(a *assertions.Assert) { LoggedWithAttr(a, records, "payment.amount", 500) }, ""},
+		{"attribute slice", func(a *assertions.Assert) { LoggedWithAttr(a, records, "tags", []string{"fraud-check"}) }, ""},
+		{"attribute value differs", func(a *assertions.Assert) { LoggedWithAttr(a, records, "amount", 499) },
+			"expected a record with attribute amount=499\n  records:"},
+		{"attribute needs its group", func(a *assertions.Assert) { LoggedWithAttr(a, records, "currency", "GBP") },
+			"expected a record with attribute currency=GBP"},
+		{"fail-fast", func(a *assertions.Assert) {
+			LoggedAtLevel(a, records, slog.LevelError)
+			LoggedMessageContaining(a, records, "GBP")
+		}, "expected a record at level ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockT{}
+			tt.assert(assertions.New(mock))
+
+			if tt.expectMessage == "" {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Expected to pass, got %v", mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected one failure containing:\n%s\ngot: %v", tt.expectMessage, mock.errorCalls)
+			}
+		})
+	}
+}
+
+func TestListRecordsIsBounded(t *testing.T) {
+	h := NewHandler()
+	for i := 0; i < maxListedRecords+5; i++ {
+		slog.New(h).Info("tick")
+	}
+	listed := listRecords(h.Records())
+	if strings.Count(listed, "msg=tick") != maxListedRecords || !strings.HasSuffix(listed, "\n    ... and 5 more") {
+		t.Errorf("Expected %d records and a count of the rest, got:%s", maxListedRecords, listed)
+	}
+}
+
+// ExampleLoggedWithAttr shows the records listed when no record has the
+// attribute.
+func ExampleLoggedWithAttr() {
+	h := NewHandler()
+	slog.New(h).Error("upstream failed", "status", 502)
+
+	mock := &mockT{}
+	LoggedWithAttr(assertions.New(mock), h.Records(), "status", 503)
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// expected a record with attribute status=503
+	//   records:
+	//     level=ERROR msg="upstream failed" status=502
+}
@@ -3,6 +3,7 @@
 package logging
 
 import (
+	"io"
 	"log"
 	"os"
 )
@@ -44,6 +45,17 @@ func NewLogger(logLevel LogLevel) *Logger {
 	}
 }
 
+// SetOutput sets the output stream of the logger.
+func (l *Logger) SetOutput(w io.Writer) {
+	l.logger.SetOutput(w)
+}
+
+// Writer returns the output stream of the logger, os.Stdout unless changed
+// with SetOutput.
+func (l *Logger) Writer() io.Writer {
+	return l.logger.Writer()
+}
+
 // LogInfo logs an informational message if the log level is INFO or lower.
 // The message is prefixed with "INFO: ".
 func (l *Logger) LogInfo(message string) {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 )
@@ -55,3 +56,18 @@ func TestLogError(t *testing.T) {
 		t.Errorf("Expected log output %q, got %q", expectedOutput, buf.String())
 	}
 }
+
+func TestSetOutput(t *testing.T) {
+	logger := NewLogger(INFO)
+	if logger.Writer() != os.Stdout {
+		t.Fatalf("Expected the logger to write to os.Stdout, got %v", logger.Writer())
+	}
+
+	var buf bytes.Buffer
+	logger.SetOutput(&buf)
+	logger.LogInfo("redirected")
+
+	if logger.Writer() != &buf || !bytes.HasSuffix(buf.Bytes(), []byte("INFO: redirected\n")) {
+		t.Errorf("Expected the message in the new output, got %q", buf.String())
+	}
+}
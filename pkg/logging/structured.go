@@ -0,0 +1,139 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// Format selects how a StructuredLogger encodes its records.
+type Format int
+
+const (
+	// TextFormat writes records as key=value pairs, one record per line.
+	TextFormat Format = iota
+	// JSONFormat writes records as JSON objects, one record per line.
+	JSONFormat
+)
+
+// StructuredLogger is a levelled logger that writes records with key-value
+// fields, as text or JSON, to an io.Writer, for consumption by log pipelines.
+// It is built on log/slog and implements LoggerInterface, so it can be given
+// to a TestRunner. It is safe for concurrent use.
+type StructuredLogger struct {
+	logger *slog.Logger
+	level  LogLevel
+}
+
+// StructuredOption configures a StructuredLogger.
+type StructuredOption func(*structuredConfig)
+
+// structuredConfig holds the settings of a new StructuredLogger.
+type structuredConfig struct {
+	level  LogLevel
+	format Format
+	fields []any
+}
+
+// WithLevel sets the lowest level the logger writes. The default is INFO.
+func WithLevel(level LogLevel) StructuredOption {
+	return func(c *structuredConfig) { c.level = level }
+}
+
+// WithFormat sets the logger's encoding. The default is TextFormat.
+func WithFormat(format Format) StructuredOption {
+	return func(c *structuredConfig) { c.format = format }
+}
+
+// WithFields adds fields, given as alternating keys and values as for
+// slog.Logger.Info, to every record the logger writes.
+func WithFields(args ...any) StructuredOption {
+	return func(c *structuredConfig) { c.fields = append(c.fields, args...) }
+}
+
+// NewStructuredLogger creates a StructuredLogger writing to w.
+//
+// Example:
+//
+//	logger := logging.NewStructuredLogger(os.Stderr,
+//		logging.WithFormat(logging.JSONFormat),
+//		logging.WithFields("suite", "checkout"))
+//	tr := testrunner.NewTestRunner(t, logger, true, rep)
+func NewStructuredLogger(w io.Writer, opts ...StructuredOption) *StructuredLogger {
+	c := structuredConfig{level: INFO}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: c.level.slogLevel()}
+	var handler slog.Handler
+	if c.format == JSONFormat {
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	} else {
+		handler = slog.NewTextHandler(w, handlerOpts)
+	}
+	return &StructuredLogger{logger: slog.New(handler).With(c.fields...), level: c.level}
+}
+
+// With returns a logger that adds fields, given as alternating keys and
+// values, to every record, as well as the fields of l.
+func (l *StructuredLogger) With(args ...any) *StructuredLogger {
+	return &StructuredLogger{logger: l.logger.With(args...), level: l.level}
+}
+
+// Debug writes message with fields at DEBUG level.
+func (l *StructuredLogger) Debug(message string, args ...any) {
+	l.logger.Debug(message, args...)
+}
+
+// Info writes message with fields at INFO level.
+func (l *StructuredLogger) Info(message string, args ...any) {
+	l.logger.Info(message, args...)
+}
+
+// Warn writes message with fields at WARN level.
+func (l *StructuredLogger) Warn(message string, args ...any) {
+	l.logger.Warn(message, args...)
+}
+
+// Error writes message with fields at ERROR level.
+func (l *StructuredLogger) Error(message string, args ...any) {
+	l.logger.Error(message, args...)
+}
+
+// Enabled reports whether the logger writes records at level.
+func (l *StructuredLogger) Enabled(level LogLevel) bool {
+	return level >= l.level
+}
+
+// LogInfo writes message at INFO level.
+func (l *StructuredLogger) LogInfo(message string) {
+	l.logger.Info(message)
+}
+
+// LogError writes err at ERROR level, as the field "error". An error that
+// implements slog.LogValuer, such as an assertions.FailureEvent, is written
+// as a group of the fields it returns.
+func (l *StructuredLogger) LogError(err error) {
+	l.logger.LogAttrs(context.Background(), slog.LevelError, "error", slog.Any("error", err))
+}
+
+// Slog returns the underlying slog.Logger, for code that logs through
+// log/slog directly.
+func (l *StructuredLogger) Slog() *slog.Logger {
+	return l.logger
+}
+
+// slogLevel returns the slog level matching l.
+func (l LogLevel) slogLevel() slog.Level {
+	switch l {
+	case DEBUG:
+		return slog.LevelDebug
+	case WARN:
+		return slog.LevelWarn
+	case ERROR:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
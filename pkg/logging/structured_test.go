@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestStructuredLoggerText(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLogger(&buf, WithFields("suite", "checkout"))
+
+	logger.Info("test passed", "test", "TestPay", "attempts", 2)
+
+	line := buf.String()
+	for _, want := range []string{"level=INFO", `msg="test passed"`, "suite=checkout", "test=TestPay", "attempts=2"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %q in %q", want, line)
+		}
+	}
+}
+
+func TestStructuredLoggerJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLogger(&buf, WithFormat(JSONFormat)).With("run", 7)
+
+	logger.Warn("slow test", "test", "TestExport")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Expected a JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["level"] != "WARN" || record["msg"] != "slow test" || record["test"] != "TestExport" || record["run"] != float64(7) {
+		t.Errorf("Unexpected record %v", record)
+	}
+}
+
+func TestStructuredLoggerLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLogger(&buf, WithLevel(WARN))
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.LogInfo("info")
+	if buf.Len() != 0 {
+		t.Errorf("Expected records below WARN to be dropped, got %q", buf.String())
+	}
+	if logger.Enabled(INFO) || !logger.Enabled(ERROR) {
+		t.Error("Expected Enabled to report WARN and above only")
+	}
+
+	logger.Error("error")
+	if !strings.Contains(buf.String(), "level=ERROR") {
+		t.Errorf("Expected an ERROR record, got %q", buf.String())
+	}
+}
+
+// failureEvent is an error with structured fields, as assertions.FailureEvent is.
+type failureEvent struct{}
+
+func (failureEvent) Error() string { return "assertion failed" }
+func (failureEvent) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("assertion", "Equal"), slog.String("got", "1"))
+}
+
+func TestStructuredLoggerLogError(t *testing.T) {
+	var buf bytes.Buffer
+	var logger LoggerInterface = NewStructuredLogger(&buf, WithFormat(JSONFormat))
+
+	logger.LogError(errors.New("connection refused"))
+	logger.LogError(failureEvent{})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected two records, got %q", buf.String())
+	}
+	var plain struct {
+		Level string `json:"level"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &plain); err != nil || plain.Level != "ERROR" || plain.Error != "connection refused" {
+		t.Errorf("Expected the error as a string field, got %s", lines[0])
+	}
+	var structured struct {
+		Error map[string]string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &structured); err != nil || structured.Error["assertion"] != "Equal" {
+		t.Errorf("Expected the error's fields as a group, got %s", lines[1])
+	}
+}
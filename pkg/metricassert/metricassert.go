@@ -0,0 +1,252 @@
+// Package metricassert checks the metrics a service exposes, in the
+// Prometheus text exposition format or as expvar variables, so integration
+// tests can confirm that counters and gauges moved as expected.
+//
+// Each assertion reads a Source, such as an HTTP endpoint, and takes the
+// *assertions.Assert to report to, so it takes part in fail-fast chaining
+// like a built-in assertion:
+//
+//	func TestFailedChargeIsCounted(t *testing.T) {
+//		assert := assertions.New(t)
+//		metrics := metricassert.Endpoint(nil, srv.URL+"/metrics")
+//
+//		metricassert.MetricExists(assert, metrics, "payments_total", nil)
+//		metricassert.MetricIncreasedBy(assert, metrics, "payments_total", map[string]string{"outcome": "declined"}, 1,
+//			func() { client.Charge(declinedCard, 500) })
+//		metricassert.MetricEquals(assert, metrics, "payment_queue_depth", nil, 0)
+//	}
+//
+// Labels select series: a series matches if it has every label given, with
+// the value given, whatever its other labels. Where several series match,
+// their values are summed, as sum() would in a Prometheus query.
+package metricassert
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"gowise/pkg/assertions"
+)
+
+// Source reads a snapshot of metrics.
+type Source func() (Metrics, error)
+
+// Endpoint returns a Source that fetches url with client, or
+// http.DefaultClient if client is nil. A response whose Content-Type is
+// application/json is read as expvar's /debug/vars; any other as Prometheus
+// text.
+func Endpoint(client *http.Client, url string) Source {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func() (Metrics, error) {
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+		}
+		if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+			return ParseExpvar(resp.Body)
+		}
+		return ParsePrometheus(resp.Body)
+	}
+}
+
+// Text returns a Source that parses exposition, Prometheus text, as when a
+// test already holds the output of a registry.
+func Text(exposition string) Source {
+	return func() (Metrics, error) {
+		return ParsePrometheus(strings.NewReader(exposition))
+	}
+}
+
+// Expvar returns a Source that reads the variables this process publishes
+// with expvar, as served at /debug/vars, without an HTTP server.
+func Expvar() Source {
+	return readExpvar
+}
+
+// Option configures how MetricEquals and MetricIncreasedBy compare values.
+type Option func(*config)
+
+type config struct {
+	tolerance         float64
+	relativeTolerance float64
+}
+
+// Tolerance accepts a value within delta of the one expected, for gauges
+// that fluctuate.
+func Tolerance(delta float64) Option {
+	return func(c *config) { c.tolerance = delta }
+}
+
+// RelativeTolerance accepts a value within fraction of the one expected,
+// so 0.05 accepts 5% either side.
+func RelativeTolerance(fraction float64) Option {
+	return func(c *config) { c.relativeTolerance = fraction }
+}
+
+// MetricExists fails unless source has a series named name with labels.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	metricassert.MetricExists(assert, metrics, "http_requests_total", map[string]string{"handler": "/checkout"})
+func MetricExists(a *assertions.Assert, source Source, name string, labels map[string]string) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	read(a, source, name, labels)
+	return a
+}
+
+// MetricEquals fails unless the value of the series named name with labels
+// is value, within any tolerance given.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	metricassert.MetricEquals(assert, metrics, "process_open_fds", nil, 12, metricassert.Tolerance(4))
+func MetricEquals(a *assertions.Assert, source Source, name string, labels map[string]string, value float64, opts ...Option) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	got, ok := read(a, source, name, labels)
+	if !ok {
+		return a
+	}
+	if c := newConfig(opts); !c.accepts(got, value) {
+		return a.Fail(fmt.Sprintf("metric value differs\n  metric: %s\n  got:    %s\n  want:   %s%s",
+			seriesName(name, labels), formatValue(got), formatValue(value), c.describe(value)))
+	}
+	return a
+}
+
+// MetricIncreasedBy reads source, runs fn and reads it again, and fails
+// unless the value of the series named name with labels rose by delta,
+// within any tolerance given. A series absent before fn counts as 0, as
+// labelled counters often appear at their first increment.
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	metricassert.MetricIncreasedBy(assert, metrics, "jobs_processed_total", nil, 3,
+//		func() { worker.RunOnce(ctx) })
+func MetricIncreasedBy(a *assertions.Assert, source Source, name string, labels map[string]string, delta float64, fn func(), opts ...Option) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	before, err := source()
+	if err != nil {
+		return a.Fail(fmt.Sprintf("failed to read metrics before the call\n  error: %v", err))
+	}
+	fn()
+	after, ok := read(a, source, name, labels)
+	if !ok {
+		return a
+	}
+
+	start := sum(before.Find(name, labels))
+	if c := newConfig(opts); !c.accepts(after-start, delta) {
+		return a.Fail(fmt.Sprintf("metric increase differs\n  metric: %s\n  before: %s\n  after:  %s\n  got:    %s\n  want:   %s%s",
+			seriesName(name, labels), formatValue(start), formatValue(after), formatValue(after-start), formatValue(delta), c.describe(delta)))
+	}
+	return a
+}
+
+// read reads source and returns the summed value of the series named name
+// with labels. A source that cannot be read, or has no such series, is
+// reported and ok is false.
+func read(a *assertions.Assert, source Source, name string, labels map[string]string) (value float64, ok bool) {
+	m, err := source()
+	if err != nil {
+		a.Fail(fmt.Sprintf("failed to read metrics\n  error: %v", err))
+		return 0, false
+	}
+	samples := m.Find(name, labels)
+	if len(samples) == 0 {
+		a.Fail(fmt.Sprintf("expected metric %s to exist%s", seriesName(name, labels), listSeries(m.Find(name, nil), name)))
+		return 0, false
+	}
+	return sum(samples), true
+}
+
+// sum adds the values of samples.
+func sum(samples []Sample) float64 {
+	total := 0.0
+	for _, s := range samples {
+		total += s.Value
+	}
+	return total
+}
+
+// maxListedSeries bounds the series of a metric a failure message lists.
+const maxListedSeries = 20
+
+// listSeries renders the series of the metric name that exist, for a failure
+// message about one that does not.
+func listSeries(samples []Sample, name string) string {
+	if len(samples) == 0 {
+		return "\n  no series named " + name
+	}
+	var b strings.Builder
+	b.WriteString("\n  series:")
+	for i, s := range samples {
+		if i == maxListedSeries {
+			fmt.Fprintf(&b, "\n    ... and %d more", len(samples)-i)
+			break
+		}
+		b.WriteString("\n    " + s.String())
+	}
+	return b.String()
+}
+
+func newConfig(opts []Option) config {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// accepts reports whether got is close enough to want. NaN matches NaN, as
+// a gauge may legitimately be NaN.
+func (c config) accepts(got, want float64) bool {
+	if math.IsNaN(got) || math.IsNaN(want) {
+		return math.IsNaN(got) && math.IsNaN(want)
+	}
+	if got == want {
+		return true
+	}
+	return math.Abs(got-want) <= c.allowed(want)
+}
+
+// allowed returns the largest difference from want accepted.
+func (c config) allowed(want float64) float64 {
+	return math.Max(c.tolerance, c.relativeTolerance*math.Abs(want))
+}
+
+// describe renders the tolerance for a failure message, if there is one.
+func (c config) describe(want float64) string {
+	if allowed := c.allowed(want); allowed > 0 {
+		return "\n  tolerance: ±" + formatValue(allowed)
+	}
+	return ""
+}
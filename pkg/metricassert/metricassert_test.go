@@ -0,0 +1,165 @@
+package metricassert
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// mockT records failures reported through an Assert.
+type mockT struct {
+	errorCalls []string
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *mockT) FailNow() {}
+func (m *mockT) Helper()  {}
+
+// counter serves a labelled Prometheus counter of declined payments.
+type counter struct {
+	declined int
+}
+
+func (c *counter) source() Source {
+	return func() (Metrics, error) {
+		exposition := `payments_total{outcome="paid"} 10` + "\n"
+		if c.declined > 0 { // Labelled series appear at their first increment
+			exposition += fmt.Sprintf("payments_total{outcome=\"declined\"} %d\n", c.declined)
+		}
+		return Text(exposition)()
+	}
+}
+
+func TestAssertions(t *testing.T) {
+	gauges := Text(`queue_depth{queue="email"} 4
+queue_depth{queue="sms"} 1
+temperature 20.4
+`)
+	broken := Source(func() (Metrics, error) { return nil, errors.New("connection refused") })
+	declined := map[string]string{"outcome": "declined"}
+
+	tests := []struct {
+		name          string
+		assert        func(a *assertions.Assert)
+		expectMessage string // Empty if the assertion passes
+	}{
+		{"exists", func(a *assertions.Assert) { MetricExists(a, gauges, "queue_depth", map[string]string{"queue": "sms"}) }, ""},
+		{"series missing", func(a *assertions.Assert) { MetricExists(a, gauges, "queue_depth", map[string]string{"queue": "push"}) },
+			"expected metric queue_depth{queue=\"push\"} to exist\n  series:\n    queue_depth{queue=\"email\"} 4\n    queue_depth{queue=\"sms\"} 1"},
+		{"metric missing", func(a *assertions.Assert) { MetricExists(a, gauges, "uptime_seconds", nil) },
+			"expected metric uptime_seconds to exist\n  no series named uptime_seconds"},
+		{"source fails", func(a *assertions.Assert) { MetricExists(a, broken, "up", nil) },
+			"failed to read metrics\n  error: connection refused"},
+		{"equals", func(a *assertions.Assert) {
+			MetricEquals(a, gauges, "queue_depth", map[string]string{"queue": "email"}, 4)
+		}, ""},
+		{"equals sums series", func(a *assertions.Assert) { MetricEquals(a, gauges, "queue_depth", nil, 5) }, ""},
+		{"value differs", func(a *assertions.Assert) { MetricEquals(a, gauges, "temperature", nil, 21) },
+			"metric value differs\n  metric: temperature\n  got:    20.4\n  want:   21"},
+		{"within tolerance", func(a *assertions.Assert) { MetricEquals(a, gauges, "temperature", nil, 21, Tolerance(0.75)) }, ""},
+		{"within relative tolerance", func(a *assertions.Assert) { MetricEquals(a, gauges, "temperature", nil, 21, RelativeTolerance(0.05)) }, ""},
+		{"outside tolerance", func(a *assertions.Assert) { MetricEquals(a, gauges, "temperature", nil, 22, Tolerance(0.5)) },
+			"  want:   22\n  tolerance: ±0.5"},
+		{"fail-fast", func(a *assertions.Assert) {
+			MetricExists(a, broken, "up", nil)
+			MetricEquals(a, gauges, "temperature", nil, 0)
+		}, "failed to read metrics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockT{}
+			tt.assert(assertions.New(mock))
+
+			if tt.expectMessage == "" {
+				if len(mock.errorCalls) != 0 {
+					t.Errorf("Expected to pass, got %v", mock.errorCalls)
+				}
+				return
+			}
+			if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+				t.Errorf("Expected one failure containing:\n%s\ngot: %v", tt.expectMessage, mock.errorCalls)
+			}
+		})
+	}
+
+	t.Run("increased by", func(t *testing.T) {
+		c := &counter{}
+		mock := &mockT{}
+		assert := assertions.New(mock)
+		MetricIncreasedBy(assert, c.source(), "payments_total", declined, 2, func() { c.declined += 2 })
+		MetricIncreasedBy(assert, c.source(), "payments_total", nil, 1, func() { c.declined++ })
+		if len(mock.errorCalls) != 0 {
+			t.Errorf("Expected a new series to count from 0, got %v", mock.errorCalls)
+		}
+
+		MetricIncreasedBy(assert, c.source(), "payments_total", declined, 1, func() {})
+		if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0],
+			"metric increase differs\n  metric: payments_total{outcome=\"declined\"}\n  before: 3\n  after:  3\n  got:    0\n  want:   1") {
+			t.Errorf("Expected an unchanged counter to fail, got %v", mock.errorCalls)
+		}
+	})
+
+	t.Run("increased by before fails", func(t *testing.T) {
+		mock := &mockT{}
+		MetricIncreasedBy(assertions.New(mock), broken, "up", nil, 1, func() { t.Error("Expected fn not to run") })
+		if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "failed to read metrics before the call") {
+			t.Errorf("Expected the read before the call to fail, got %v", mock.errorCalls)
+		}
+	})
+}
+
+func TestEndpoint(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprintln(w, `up{job="api"} 1`)
+	})
+	mux.HandleFunc("/debug/vars", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprintln(w, `{"requests": 42}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	mock := &mockT{}
+	assert := assertions.New(mock)
+	MetricEquals(assert, Endpoint(nil, srv.URL+"/metrics"), "up", map[string]string{"job": "api"}, 1)
+	MetricEquals(assert, Endpoint(srv.Client(), srv.URL+"/debug/vars"), "requests", nil, 42)
+	if len(mock.errorCalls) != 0 {
+		t.Errorf("Expected both formats to be read, got %v", mock.errorCalls)
+	}
+
+	MetricExists(assert, Endpoint(nil, srv.URL+"/missing"), "up", nil)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "/missing: 404 Not Found") {
+		t.Errorf("Expected an error status to fail, got %v", mock.errorCalls)
+	}
+}
+
+// ExampleMetricIncreasedBy shows the counts before and after the call when
+// a counter did not move as expected.
+func ExampleMetricIncreasedBy() {
+	retries := 0
+	metrics := Source(func() (Metrics, error) {
+		return Text(fmt.Sprintf("http_client_retries_total{host=\"payments\"} %d\n", retries))()
+	})
+
+	mock := &mockT{}
+	MetricIncreasedBy(assertions.New(mock), metrics, "http_client_retries_total", nil, 3,
+		func() { retries += 2 })
+	fmt.Println(mock.errorCalls[0])
+	// Output:
+	// metric increase differs
+	//   metric: http_client_retries_total
+	//   before: 0
+	//   after:  2
+	//   got:    2
+	//   want:   3
+}
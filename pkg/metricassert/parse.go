@@ -0,0 +1,207 @@
+package metricassert
+
+import (
+	"bufio"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Sample is one value of a metric series.
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// String renders the sample as a line of Prometheus text exposition.
+func (s Sample) String() string {
+	return seriesName(s.Name, s.Labels) + " " + formatValue(s.Value)
+}
+
+// Metrics is a snapshot of metric samples, in the order they were read.
+type Metrics []Sample
+
+// Find returns the samples named name whose labels include labels. Labels
+// not given match any value, so a nil labels matches every series of name.
+func (m Metrics) Find(name string, labels map[string]string) []Sample {
+	var found []Sample
+	for _, s := range m {
+		if s.Name != name {
+			continue
+		}
+		matches := true
+		for k, v := range labels {
+			if got, ok := s.Labels[k]; !ok || got != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// ParsePrometheus reads metrics in the Prometheus text exposition format.
+// Comments, including HELP and TYPE lines, and timestamps are ignored;
+// histograms and summaries are read as their _bucket, _sum and _count
+// series.
+func ParsePrometheus(r io.Reader) (Metrics, error) {
+	var m Metrics
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		s, err := parseSample(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		m = append(m, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// parseSample parses a sample line: a name, optional labels in braces, a
+// value and an optional timestamp.
+func parseSample(text string) (Sample, error) {
+	end := strings.IndexAny(text, "{ \t")
+	if end <= 0 {
+		return Sample{}, fmt.Errorf("malformed sample %q", text)
+	}
+	s := Sample{Name: text[:end], Labels: map[string]string{}}
+	rest := text[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		if rest, err = parseLabels(rest[1:], s.Labels); err != nil {
+			return Sample{}, fmt.Errorf("%s: %w", s.Name, err)
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Sample{}, fmt.Errorf("%s: expected a value and an optional timestamp, got %q", s.Name, strings.TrimSpace(rest))
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("%s: invalid value %q", s.Name, fields[0])
+	}
+	s.Value = value
+	return s, nil
+}
+
+// parseLabels parses the label pairs following an opening brace into labels
+// and returns the text after the closing brace.
+func parseLabels(text string, labels map[string]string) (string, error) {
+	for {
+		text = strings.TrimLeft(text, " \t")
+		if strings.HasPrefix(text, "}") {
+			return text[1:], nil
+		}
+		eq := strings.IndexByte(text, '=')
+		if eq <= 0 || len(text) < eq+2 || text[eq+1] != '"' {
+			return "", fmt.Errorf("malformed labels")
+		}
+		name := strings.TrimSpace(text[:eq])
+
+		var value strings.Builder
+		i := eq + 2
+		for ; i < len(text) && text[i] != '"'; i++ {
+			if text[i] == '\\' && i+1 < len(text) {
+				i++
+				switch text[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default: // \\ and \"
+					value.WriteByte(text[i])
+				}
+				continue
+			}
+			value.WriteByte(text[i])
+		}
+		if i == len(text) {
+			return "", fmt.Errorf("unterminated value of label %s", name)
+		}
+		labels[name] = value.String()
+
+		text = strings.TrimLeft(text[i+1:], " \t")
+		if strings.HasPrefix(text, ",") {
+			text = text[1:]
+		} else if !strings.HasPrefix(text, "}") {
+			return "", fmt.Errorf("malformed labels after %s", name)
+		}
+	}
+}
+
+// ParseExpvar reads metrics from the JSON served by expvar's /debug/vars
+// handler. Numbers become samples without labels; the numbers in nested
+// objects are named by their path, so the HeapAlloc field of memstats is
+// the metric "memstats.HeapAlloc". Strings, booleans and arrays are skipped.
+func ParseExpvar(r io.Reader) (Metrics, error) {
+	var vars map[string]any
+	if err := json.NewDecoder(r).Decode(&vars); err != nil {
+		return nil, fmt.Errorf("invalid expvar JSON: %w", err)
+	}
+	var m Metrics
+	flattenExpvar(&m, "", vars)
+	return m, nil
+}
+
+// flattenExpvar appends the numbers in vars to m, named with prefix.
+func flattenExpvar(m *Metrics, prefix string, vars map[string]any) {
+	for _, key := range slices.Sorted(maps.Keys(vars)) {
+		switch v := vars[key].(type) {
+		case float64:
+			*m = append(*m, Sample{Name: prefix + key, Value: v})
+		case map[string]any:
+			flattenExpvar(m, prefix+key+".", v)
+		}
+	}
+}
+
+// readExpvar reads the variables published in this process with expvar,
+// as ParseExpvar would read them from /debug/vars.
+func readExpvar() (Metrics, error) {
+	var b strings.Builder
+	b.WriteString("{")
+	first := true
+	expvar.Do(func(kv expvar.KeyValue) {
+		if !first {
+			b.WriteString(",")
+		}
+		first = false
+		fmt.Fprintf(&b, "%q:%s", kv.Key, kv.Value)
+	})
+	b.WriteString("}")
+	return ParseExpvar(strings.NewReader(b.String()))
+}
+
+// seriesName renders a series as in Prometheus text, with its labels sorted.
+func seriesName(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, k+"="+strconv.Quote(labels[k]))
+	}
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatValue renders a metric value as briefly as it can be read back.
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,103 @@
+package metricassert
+
+import (
+	"expvar"
+	"math"
+	"strings"
+	"testing"
+)
+
+const exposition = `# HELP http_requests_total Requests handled.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",code="200"} 1027 1395066363000
+http_requests_total{method="POST",code="200"} 3
+http_requests_total{method="POST", code="500",} 2
+
+# A histogram is read as its series
+request_seconds_bucket{le="+Inf"} 14
+request_seconds_sum 8.5
+queue_depth NaN
+temperature{room="quote \" slash \\ newline \n"} -1.5e1
+`
+
+func TestParsePrometheus(t *testing.T) {
+	m, err := ParsePrometheus(strings.NewReader(exposition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 7 {
+		t.Fatalf("Expected 7 samples, got %d: %v", len(m), m)
+	}
+
+	if got := m.Find("http_requests_total", map[string]string{"code": "200"}); len(got) != 2 || got[0].Value != 1027 {
+		t.Errorf("Expected both series with code 200, got %v", got)
+	}
+	if got := m.Find("http_requests_total", map[string]string{"method": "POST", "code": "500"}); len(got) != 1 || got[0].Value != 2 {
+		t.Errorf("Expected the series despite spaces and a trailing comma, got %v", got)
+	}
+	if got := m.Find("request_seconds_bucket", map[string]string{"le": "+Inf"}); len(got) != 1 || got[0].Value != 14 {
+		t.Errorf("Expected the bucket series, got %v", got)
+	}
+	if got := m.Find("queue_depth", nil); len(got) != 1 || !math.IsNaN(got[0].Value) {
+		t.Errorf("Expected NaN, got %v", got)
+	}
+	if got := m.Find("temperature", nil); len(got) != 1 || got[0].Labels["room"] != "quote \" slash \\ newline \n" || got[0].Value != -15 {
+		t.Errorf("Expected escapes in label values to be read, got %v", got)
+	}
+	if got := m[0].String(); got != `http_requests_total{code="200",method="GET"} 1027` {
+		t.Errorf("Sample.String() = %s", got)
+	}
+}
+
+func TestParsePrometheusErrors(t *testing.T) {
+	for input, want := range map[string]string{
+		"ok 1\nbroken":                 "line 2: malformed sample \"broken\"",
+		`up{job="api"}`:                "up: expected a value",
+		`up{job="api} 1`:               "up: unterminated value of label job",
+		`up{job=api} 1`:                "up: malformed labels",
+		"up one":                       `up: invalid value "one"`,
+		"up 1 1395066363000 extra":     "up: expected a value and an optional timestamp",
+		`up{job="api" instance="a"} 1`: "up: malformed labels after job",
+		"{job=\"api\"} 1":              "malformed sample",
+	} {
+		if _, err := ParsePrometheus(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ParsePrometheus(%q) error = %v, want one containing %q", input, err, want)
+		}
+	}
+}
+
+func TestParseExpvar(t *testing.T) {
+	m, err := ParseExpvar(strings.NewReader(`{"cmdline": ["server"], "requests": 42, "ready": true,
+		"memstats": {"HeapAlloc": 1024, "BySize": [{"Size": 8}]}, "cache": {"hits": 7, "misses": 2}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "cache.hits 7\ncache.misses 2\nmemstats.HeapAlloc 1024\nrequests 42"
+	var got []string
+	for _, s := range m {
+		got = append(got, s.String())
+	}
+	if strings.Join(got, "\n") != want {
+		t.Errorf("Expected the numbers named by their paths:\n%s\ngot:\n%s", want, strings.Join(got, "\n"))
+	}
+
+	if _, err := ParseExpvar(strings.NewReader("requests 42")); err == nil || !strings.Contains(err.Error(), "invalid expvar JSON") {
+		t.Errorf("Expected an error for text that is not JSON, got %v", err)
+	}
+}
+
+var jobsProcessed = expvar.NewInt("metricassert_test_jobs")
+
+func TestExpvarSource(t *testing.T) {
+	jobsProcessed.Set(5)
+	m, err := Expvar()()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Find("metricassert_test_jobs", nil); len(got) != 1 || got[0].Value != 5 {
+		t.Errorf("Expected the published variable, got %v", got)
+	}
+	if len(m.Find("memstats.HeapAlloc", nil)) != 1 {
+		t.Error("Expected the variables expvar publishes itself")
+	}
+}
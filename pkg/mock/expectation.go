@@ -0,0 +1,72 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Expectation is a call declared with Recorder.Expect.
+type Expectation struct {
+	method string
+	args   []interface{}
+	times  int // exact number of calls required; -1 means at least one
+}
+
+// Times requires exactly n matching calls.
+func (e *Expectation) Times(n int) *Expectation {
+	e.times = n
+	return e
+}
+
+// Once requires exactly one matching call.
+func (e *Expectation) Once() *Expectation {
+	return e.Times(1)
+}
+
+// Never requires that no matching call is made.
+func (e *Expectation) Never() *Expectation {
+	return e.Times(0)
+}
+
+// String renders the expected call, as in Save(mock.Anything).
+func (e *Expectation) String() string {
+	return e.method + formatArgs(e.args)
+}
+
+// Matcher matches an argument in Expect and the Assert methods of Recorder
+// in place of a literal value.
+type Matcher interface {
+	// Match reports whether arg is acceptable.
+	Match(arg interface{}) bool
+	// String describes the matcher in failure messages.
+	String() string
+}
+
+// Anything matches any argument, including nil.
+var Anything Matcher = anything{}
+
+type anything struct{}
+
+func (anything) Match(interface{}) bool { return true }
+func (anything) String() string         { return "mock.Anything" }
+
+// MatchedBy returns a Matcher accepting arguments of type T for which fn
+// returns true.
+//
+// Example:
+//
+//	store.AssertCalled(assert, "Save", mock.MatchedBy(func(u User) bool { return u.Active }))
+func MatchedBy[T any](fn func(T) bool) Matcher {
+	return matchedBy[T]{fn: fn}
+}
+
+type matchedBy[T any] struct{ fn func(T) bool }
+
+func (m matchedBy[T]) Match(arg interface{}) bool {
+	v, ok := arg.(T)
+	return ok && m.fn(v)
+}
+
+func (m matchedBy[T]) String() string {
+	return fmt.Sprintf("mock.MatchedBy(func(%s) bool)", reflect.TypeOf((*T)(nil)).Elem())
+}
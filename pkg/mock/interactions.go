@@ -0,0 +1,194 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"gowise/pkg/assertions"
+)
+
+// Interactions is a log shared by several recorders, holding every call made
+// to any of them in a single global order. It lets InOrder verify sequences
+// that span collaborators, such as a saga that saves to a database and then
+// publishes to a queue. It is safe for concurrent use.
+//
+// Example:
+//
+//	interactions := mock.NewInteractions()
+//	db := &fakeDB{interactions.Recorder("db")}
+//	queue := &fakeQueue{interactions.Recorder("queue")}
+//	saga.Run(db, queue)
+//	mock.InOrder(assert, db.Step("Save", order), queue.Step("Publish", mock.Anything))
+type Interactions struct {
+	mu      sync.Mutex
+	entries []interaction
+}
+
+// interaction is a call in a shared log, with the recorder it was made to.
+type interaction struct {
+	recorder *Recorder
+	call     Call
+}
+
+// NewInteractions creates an empty shared log.
+func NewInteractions() *Interactions {
+	return &Interactions{}
+}
+
+// Recorder creates a Recorder whose calls are also appended to the shared
+// log. The name prefixes its calls in failure messages, as in db.Save(…).
+func (in *Interactions) Recorder(name string) *Recorder {
+	return &Recorder{name: name, interactions: in}
+}
+
+// Calls returns every call in the log, in the order the calls were made,
+// with each method prefixed by its recorder's name.
+func (in *Interactions) Calls() []Call {
+	return qualifiedCalls(in.snapshot())
+}
+
+// record appends a call made to r.
+func (in *Interactions) record(r *Recorder, call Call) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	in.entries = append(in.entries, interaction{recorder: r, call: call})
+}
+
+// snapshot returns a copy of the log.
+func (in *Interactions) snapshot() []interaction {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	return append([]interaction(nil), in.entries...)
+}
+
+// qualified returns the call with its method prefixed by the recorder's name.
+func (e interaction) qualified() Call {
+	return Call{Method: e.recorder.qualify(e.call.Method), Args: e.call.Args}
+}
+
+// Step identifies the calls to a method of one recorder, for InOrder.
+type Step struct {
+	recorder *Recorder
+	method   string
+	args     []interface{}
+}
+
+// Step returns a Step matching calls to method with arguments matching args,
+// which may include matchers such as Anything. With no args, any call to
+// method matches.
+func (r *Recorder) Step(method string, args ...interface{}) Step {
+	return Step{recorder: r, method: method, args: args}
+}
+
+// String renders the step, as in db.Save(mock.Anything).
+func (s Step) String() string {
+	if len(s.args) == 0 {
+		return s.recorder.qualify(s.method)
+	}
+	return s.recorder.qualify(s.method) + formatArgs(s.args)
+}
+
+// matches reports whether e is a call the step describes.
+func (s Step) matches(e interaction) bool {
+	return e.recorder == s.recorder && e.call.Method == s.method &&
+		(len(s.args) == 0 || argsMatch(s.args, e.call.Args))
+}
+
+// qualify prefixes method with the recorder's name, if it has one.
+func (r *Recorder) qualify(method string) string {
+	if r.name == "" {
+		return method
+	}
+	return r.name + "." + method
+}
+
+// InOrder asserts that calls matching steps were made in the given order.
+// Other calls may come between them. Steps on different recorders require
+// recorders created from the same Interactions; steps on a single recorder
+// need no shared log.
+// Returns a to enable method chaining.
+//
+// Example:
+//
+//	mock.InOrder(assert, db.Step("Begin"), db.Step("Save", order), queue.Step("Publish", mock.Anything), db.Step("Commit"))
+func InOrder(a *assertions.Assert, steps ...Step) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if len(steps) == 0 {
+		return a
+	}
+
+	log, err := sharedLog(steps)
+	if err != nil {
+		return a.Fail(err.Error())
+	}
+
+	next := 0
+	for i, step := range steps {
+		found := false
+		for ; next < len(log); next++ {
+			if step.matches(log[next]) {
+				found, next = true, next+1
+				break
+			}
+		}
+		if !found {
+			return a.Fail(fmt.Sprintf("expected calls in order\n  want: %s\n  missing: %s (step %d)%s\n%s",
+				describeSteps(steps), step, i+1, afterStep(steps, i), describe("interactions", qualifiedCalls(log))))
+		}
+	}
+	return a
+}
+
+// sharedLog returns the log that orders the calls of every step's recorder.
+func sharedLog(steps []Step) ([]interaction, error) {
+	first := steps[0].recorder
+	for _, s := range steps[1:] {
+		if s.recorder == first {
+			continue
+		}
+		if first.interactions == nil || s.recorder.interactions != first.interactions {
+			return nil, fmt.Errorf("InOrder: steps %s and %s are on recorders that do not share an Interactions log; create them with the same Interactions", steps[0], s)
+		}
+	}
+
+	if first.interactions != nil {
+		return first.interactions.snapshot(), nil
+	}
+	calls := first.Calls()
+	log := make([]interaction, len(calls))
+	for i, c := range calls {
+		log[i] = interaction{recorder: first, call: c}
+	}
+	return log, nil
+}
+
+// describeSteps renders steps as a sequence.
+func describeSteps(steps []Step) string {
+	rendered := make([]string, len(steps))
+	for i, s := range steps {
+		rendered[i] = s.String()
+	}
+	return strings.Join(rendered, " then ")
+}
+
+// afterStep explains where step i was looked for.
+func afterStep(steps []Step, i int) string {
+	if i == 0 {
+		return ""
+	}
+	return fmt.Sprintf(", after %s", steps[i-1])
+}
+
+// qualifiedCalls returns the calls of log with qualified method names.
+func qualifiedCalls(log []interaction) []Call {
+	calls := make([]Call, len(log))
+	for i, e := range log {
+		calls[i] = e.qualified()
+	}
+	return calls
+}
@@ -0,0 +1,109 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// fakeQueue is a second fake, for verifying order across collaborators.
+type fakeQueue struct{ *Recorder }
+
+func (f *fakeQueue) Publish(topic string) {
+	f.Record("Publish", topic)
+}
+
+// TestInOrder tests ordered verification within and across recorders.
+func TestInOrder(t *testing.T) {
+	ann := user{Name: "ann"}
+
+	newFakes := func() (*fakeStore, *fakeQueue) {
+		interactions := NewInteractions()
+		db := &fakeStore{interactions.Recorder("db")}
+		queue := &fakeQueue{interactions.Recorder("queue")}
+
+		db.Save(ann)
+		queue.Publish("user.created")
+		db.Delete("ann")
+		queue.Publish("user.deleted")
+		return db, queue
+	}
+
+	tests := []struct {
+		name          string
+		assert        func(db *fakeStore, queue *fakeQueue, a *assertions.Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"across recorders", func(db *fakeStore, queue *fakeQueue, a *assertions.Assert) {
+			InOrder(a, db.Step("Save", ann), queue.Step("Publish", "user.created"), db.Step("Delete"))
+		}, true, ""},
+		{"gaps allowed", func(db *fakeStore, queue *fakeQueue, a *assertions.Assert) {
+			InOrder(a, db.Step("Save"), queue.Step("Publish", "user.deleted"))
+		}, true, ""},
+		{"same method twice", func(db *fakeStore, queue *fakeQueue, a *assertions.Assert) {
+			InOrder(a, queue.Step("Publish", Anything), queue.Step("Publish", Anything))
+		}, true, ""},
+		{"out of order", func(db *fakeStore, queue *fakeQueue, a *assertions.Assert) {
+			InOrder(a, queue.Step("Publish", "user.deleted"), db.Step("Delete"))
+		}, false, "expected calls in order\n  want: queue.Publish(\"user.deleted\") then db.Delete\n  missing: db.Delete (step 2), after queue.Publish(\"user.deleted\")\n  interactions (4):\n    [0] db.Save("},
+		{"step never called", func(db *fakeStore, queue *fakeQueue, a *assertions.Assert) {
+			InOrder(a, db.Step("Load"))
+		}, false, "missing: db.Load (step 1)\n"},
+		{"separate logs", func(db *fakeStore, queue *fakeQueue, a *assertions.Assert) {
+			other := &fakeQueue{NewInteractions().Recorder("other")}
+			InOrder(a, db.Step("Save"), other.Step("Publish"))
+		}, false, "do not share an Interactions log"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockT{}
+			db, queue := newFakes()
+			tt.assert(db, queue, assertions.New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestInOrderSingleRecorder tests ordering on a recorder without a shared log.
+func TestInOrderSingleRecorder(t *testing.T) {
+	mock := &mockT{}
+	store := &fakeStore{NewRecorder()}
+	store.Delete("a")
+	store.Save(user{Name: "a"})
+
+	assert := assertions.New(mock)
+	InOrder(assert, store.Step("Delete"), store.Step("Save"))
+	InOrder(assert, store.Step("Save"), store.Step("Delete"))
+
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "missing: Delete (step 2), after Save") {
+		t.Errorf("Expected only the reversed order to fail, got: %v", mock.errorCalls)
+	}
+}
+
+// ExampleInOrder demonstrates verifying a sequence across collaborators.
+func ExampleInOrder() {
+	interactions := NewInteractions()
+	db := &fakeStore{interactions.Recorder("db")}
+	queue := &fakeQueue{interactions.Recorder("queue")}
+
+	db.Save(user{Name: "ann"})
+	queue.Publish("user.created")
+
+	InOrder(assertions.New(&mockT{}), db.Step("Save"), queue.Step("Publish", "user.created"))
+	fmt.Println(interactions.Calls())
+	// Output: [db.Save(mock.user{Name:"ann", Active:false}) queue.Publish("user.created")]
+}
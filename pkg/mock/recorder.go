@@ -0,0 +1,315 @@
+// Package mock provides call recording and interaction assertions for
+// hand-written fakes.
+//
+// Embed a *Recorder in a fake, record each call, then assert on the calls
+// through an assertions.Assert:
+//
+//	type fakeStore struct{ *mock.Recorder }
+//
+//	func (f *fakeStore) Save(u User) error {
+//		f.Record("Save", u)
+//		return nil
+//	}
+//
+//	store := &fakeStore{mock.NewRecorder()}
+//	service := NewService(store)
+//	service.Register(user)
+//	store.AssertCalled(assert, "Save", user)
+//
+// Expectations declared up front with Expect are checked by
+// AssertExpectations, or automatically at the end of the test by passing the
+// fake to assert.VerifyAll.
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gowise/pkg/assertions"
+)
+
+// Call is a recorded call to a method of a fake.
+type Call struct {
+	// Method is the name the call was recorded under.
+	Method string
+	// Args holds the call's arguments, in order.
+	Args []interface{}
+}
+
+// String renders the call as it would appear in source, as in Save("ann", 3).
+func (c Call) String() string {
+	return c.Method + formatArgs(c.Args)
+}
+
+// Recorder records the calls made to a fake and the expectations placed on
+// it. It is safe for concurrent use.
+type Recorder struct {
+	mu           sync.Mutex
+	calls        []Call
+	expectations []*Expectation
+
+	name         string        // Prefix for calls in a shared log, e.g. "db"
+	interactions *Interactions // Shared log across recorders, if any
+}
+
+// NewRecorder creates an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Record records a call to method with args.
+func (r *Recorder) Record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	call := Call{Method: method, Args: args}
+	r.calls = append(r.calls, call)
+	if r.interactions != nil {
+		r.interactions.record(r, call)
+	}
+}
+
+// Calls returns every recorded call, in the order the calls were made.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Call(nil), r.calls...)
+}
+
+// CallsTo returns the recorded calls to method, in the order they were made.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var calls []Call
+	for _, c := range r.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset discards all recorded calls and expectations.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls = nil
+	r.expectations = nil
+}
+
+// Expect declares that method will be called with arguments matching args,
+// which may include matchers such as Anything. By default the call is
+// expected at least once; use Times or Once to require an exact count.
+//
+// Example:
+//
+//	store.Expect("Save", mock.Anything).Once()
+//	store.Expect("Close")
+func (r *Recorder) Expect(method string, args ...interface{}) *Expectation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := &Expectation{method: method, args: args, times: -1}
+	r.expectations = append(r.expectations, e)
+	return e
+}
+
+// Verify reports the expectations declared with Expect that the recorded
+// calls do not meet, implementing assertions.Verifier.
+func (r *Recorder) Verify() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unmet []string
+	for _, e := range r.expectations {
+		count := 0
+		for _, c := range r.calls {
+			if c.Method == e.method && argsMatch(e.args, c.Args) {
+				count++
+			}
+		}
+
+		switch {
+		case e.times < 0 && count == 0:
+			unmet = append(unmet, fmt.Sprintf("expected %s to be called, got no matching calls", e))
+		case e.times >= 0 && count != e.times:
+			unmet = append(unmet, fmt.Sprintf("expected %s to be called %s, got %s", e, pluralCalls(e.times), pluralCalls(count)))
+		}
+	}
+
+	if len(unmet) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(unmet, "\n"))
+}
+
+// AssertCalled asserts that method was called at least once with arguments
+// matching args, which may include matchers such as Anything. With no args,
+// any call to method matches.
+// Returns a to enable method chaining.
+func (r *Recorder) AssertCalled(a *assertions.Assert, method string, args ...interface{}) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	calls := r.CallsTo(method)
+	for _, c := range calls {
+		if len(args) == 0 || argsMatch(args, c.Args) {
+			return a
+		}
+	}
+
+	if len(calls) == 0 {
+		return a.Fail(fmt.Sprintf("expected %s to be called, but it was not\n%s", method, r.describeCalls()))
+	}
+	return a.Fail(fmt.Sprintf("expected %s to be called with matching arguments\n  want: %s\n%s",
+		method, Call{Method: method, Args: args}, describe("calls to "+method, calls)))
+}
+
+// AssertNotCalled asserts that method was never called with arguments
+// matching args. With no args, any call to method fails the assertion.
+// Returns a to enable method chaining.
+func (r *Recorder) AssertNotCalled(a *assertions.Assert, method string, args ...interface{}) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	var matching []Call
+	for _, c := range r.CallsTo(method) {
+		if len(args) == 0 || argsMatch(args, c.Args) {
+			matching = append(matching, c)
+		}
+	}
+
+	if len(matching) > 0 {
+		return a.Fail(fmt.Sprintf("expected %s not to be called\n%s", method, describe("matching calls", matching)))
+	}
+	return a
+}
+
+// AssertNumberOfCalls asserts that method was called exactly expected times,
+// with any arguments.
+// Returns a to enable method chaining.
+func (r *Recorder) AssertNumberOfCalls(a *assertions.Assert, method string, expected int) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if calls := r.CallsTo(method); len(calls) != expected {
+		message := fmt.Sprintf("expected %s to be called %s, got %s", method, pluralCalls(expected), pluralCalls(len(calls)))
+		if len(calls) > 0 {
+			message += "\n" + describe("calls to "+method, calls)
+		}
+		return a.Fail(message)
+	}
+	return a
+}
+
+// AssertCalledWith asserts that the most recent call to method had arguments
+// matching args, which may include matchers such as Anything. It suits
+// methods called repeatedly whose final state matters, such as a progress
+// callback.
+// Returns a to enable method chaining.
+func (r *Recorder) AssertCalledWith(a *assertions.Assert, method string, args ...interface{}) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	calls := r.CallsTo(method)
+	if len(calls) == 0 {
+		return a.Fail(fmt.Sprintf("expected %s to be called, but it was not\n%s", method, r.describeCalls()))
+	}
+
+	if last := calls[len(calls)-1]; !argsMatch(args, last.Args) {
+		return a.Fail(fmt.Sprintf("expected the last call to %s to have matching arguments\n  got:  %s\n  want: %s",
+			method, last, Call{Method: method, Args: args}))
+	}
+	return a
+}
+
+// AssertExpectations asserts that every expectation declared with Expect
+// has been met.
+// Returns a to enable method chaining.
+func (r *Recorder) AssertExpectations(a *assertions.Assert) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	if err := r.Verify(); err != nil {
+		return a.Fail(fmt.Sprintf("%s\n%s", err, r.describeCalls()))
+	}
+	return a
+}
+
+// describeCalls lists every recorded call, for failures where the expected
+// method was not called at all.
+func (r *Recorder) describeCalls() string {
+	calls := r.Calls()
+	if len(calls) == 0 {
+		return "  no calls were recorded"
+	}
+	return describe("recorded calls", calls)
+}
+
+// maxListedCalls bounds the calls listed in a failure message.
+const maxListedCalls = 10
+
+// describe renders calls under a heading, one per line.
+func describe(heading string, calls []Call) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "  %s (%d):", heading, len(calls))
+	for i, c := range calls {
+		if i == maxListedCalls {
+			fmt.Fprintf(&b, "\n    … (%d more)", len(calls)-maxListedCalls)
+			break
+		}
+		fmt.Fprintf(&b, "\n    [%d] %s", i, c)
+	}
+	return b.String()
+}
+
+// argsMatch reports whether got matches want, argument by argument. Matchers
+// in want are applied; other values are compared with reflect.DeepEqual.
+func argsMatch(want, got []interface{}) bool {
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if m, ok := want[i].(Matcher); ok {
+			if !m.Match(got[i]) {
+				return false
+			}
+			continue
+		}
+		if !reflect.DeepEqual(want[i], got[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatArgs renders arguments as a parenthesised list in Go syntax.
+func formatArgs(args []interface{}) string {
+	rendered := make([]string, len(args))
+	for i, arg := range args {
+		if m, ok := arg.(Matcher); ok {
+			rendered[i] = m.String()
+		} else {
+			rendered[i] = fmt.Sprintf("%#v", arg)
+		}
+	}
+	return "(" + strings.Join(rendered, ", ") + ")"
+}
+
+// pluralCalls renders a call count, as in "1 time" or "3 times".
+func pluralCalls(n int) string {
+	if n == 1 {
+		return "1 time"
+	}
+	return fmt.Sprintf("%d times", n)
+}
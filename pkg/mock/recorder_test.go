@@ -0,0 +1,171 @@
+package mock
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// mockT records failures reported through an Assert.
+type mockT struct {
+	errorCalls []string
+	cleanups   []func()
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *mockT) FailNow()         {}
+func (m *mockT) Helper()          {}
+func (m *mockT) Cleanup(f func()) { m.cleanups = append(m.cleanups, f) }
+
+type user struct {
+	Name   string
+	Active bool
+}
+
+// fakeStore is a hand-written fake built on a Recorder.
+type fakeStore struct{ *Recorder }
+
+func (f *fakeStore) Save(u user) error {
+	f.Record("Save", u)
+	return nil
+}
+
+func (f *fakeStore) Delete(name string) {
+	f.Record("Delete", name)
+}
+
+// TestRecorderAssertions tests the interaction assertions of Recorder.
+func TestRecorderAssertions(t *testing.T) {
+	ann := user{Name: "ann", Active: true}
+	bob := user{Name: "bob"}
+
+	newStore := func() *fakeStore {
+		store := &fakeStore{NewRecorder()}
+		store.Save(ann)
+		store.Save(bob)
+		store.Delete("eve")
+		return store
+	}
+
+	tests := []struct {
+		name          string
+		assert        func(store *fakeStore, a *assertions.Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"AssertCalled any args", func(s *fakeStore, a *assertions.Assert) { s.AssertCalled(a, "Save") }, true, ""},
+		{"AssertCalled with args", func(s *fakeStore, a *assertions.Assert) { s.AssertCalled(a, "Save", bob) }, true, ""},
+		{"AssertCalled with matcher", func(s *fakeStore, a *assertions.Assert) {
+			s.AssertCalled(a, "Save", MatchedBy(func(u user) bool { return u.Active }))
+		}, true, ""},
+		{"AssertCalled with Anything", func(s *fakeStore, a *assertions.Assert) { s.AssertCalled(a, "Delete", Anything) }, true, ""},
+		{"AssertCalled args differ", func(s *fakeStore, a *assertions.Assert) { s.AssertCalled(a, "Save", user{Name: "eve"}) }, false,
+			"expected Save to be called with matching arguments\n  want: Save(mock.user{Name:\"eve\", Active:false})\n  calls to Save (2):\n    [0] Save(mock.user{Name:\"ann\", Active:true})"},
+		{"AssertCalled never called", func(s *fakeStore, a *assertions.Assert) { s.AssertCalled(a, "Load") }, false,
+			"expected Load to be called, but it was not\n  recorded calls (3):\n    [0] Save("},
+		{"AssertCalled wrong arity", func(s *fakeStore, a *assertions.Assert) { s.AssertCalled(a, "Delete", "eve", true) }, false, "with matching arguments"},
+
+		{"AssertNotCalled", func(s *fakeStore, a *assertions.Assert) { s.AssertNotCalled(a, "Load") }, true, ""},
+		{"AssertNotCalled other args", func(s *fakeStore, a *assertions.Assert) { s.AssertNotCalled(a, "Delete", "ann") }, true, ""},
+		{"AssertNotCalled fails", func(s *fakeStore, a *assertions.Assert) { s.AssertNotCalled(a, "Delete") }, false,
+			"expected Delete not to be called\n  matching calls (1):\n    [0] Delete(\"eve\")"},
+
+		{"AssertNumberOfCalls", func(s *fakeStore, a *assertions.Assert) { s.AssertNumberOfCalls(a, "Save", 2) }, true, ""},
+		{"AssertNumberOfCalls zero", func(s *fakeStore, a *assertions.Assert) { s.AssertNumberOfCalls(a, "Load", 0) }, true, ""},
+		{"AssertNumberOfCalls fails", func(s *fakeStore, a *assertions.Assert) { s.AssertNumberOfCalls(a, "Delete", 2) }, false,
+			"expected Delete to be called 2 times, got 1 time"},
+
+		{"AssertCalledWith last call", func(s *fakeStore, a *assertions.Assert) { s.AssertCalledWith(a, "Save", bob) }, true, ""},
+		{"AssertCalledWith earlier call fails", func(s *fakeStore, a *assertions.Assert) { s.AssertCalledWith(a, "Save", ann) }, false,
+			"expected the last call to Save to have matching arguments\n  got:  Save(mock.user{Name:\"bob\", Active:false})"},
+
+		{"AssertExpectations met", func(s *fakeStore, a *assertions.Assert) {
+			s.Expect("Save", Anything).Times(2)
+			s.Expect("Delete", "eve").Once()
+			s.Expect("Load").Never()
+			s.AssertExpectations(a)
+		}, true, ""},
+		{"AssertExpectations unmet", func(s *fakeStore, a *assertions.Assert) {
+			s.Expect("Save", ann).Times(2)
+			s.Expect("Close")
+			s.AssertExpectations(a)
+		}, false, "expected Save(mock.user{Name:\"ann\", Active:true}) to be called 2 times, got 1 time\nexpected Close() to be called, got no matching calls\n  recorded calls (3):"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockT{}
+			tt.assert(newStore(), assertions.New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// TestRecorderWithVerifyAll tests that unmet expectations fail the test at cleanup.
+func TestRecorderWithVerifyAll(t *testing.T) {
+	mock := &mockT{}
+	store := &fakeStore{NewRecorder()}
+	store.Expect("Save", Anything).Once()
+
+	assertions.New(mock).VerifyAll(store)
+	for _, cleanup := range mock.cleanups {
+		cleanup()
+	}
+
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "expected Save(mock.Anything) to be called 1 time, got 0 times") {
+		t.Errorf("Expected verification failure at cleanup, got: %v", mock.errorCalls)
+	}
+}
+
+// TestRecorderConcurrentCalls tests recording from several goroutines.
+func TestRecorderConcurrentCalls(t *testing.T) {
+	store := &fakeStore{NewRecorder()}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			store.Delete("x")
+		}()
+	}
+	wg.Wait()
+
+	if got := len(store.CallsTo("Delete")); got != 50 {
+		t.Errorf("Expected 50 recorded calls, got %d", got)
+	}
+	store.Reset()
+	if got := len(store.Calls()); got != 0 {
+		t.Errorf("Expected no calls after Reset, got %d", got)
+	}
+}
+
+// ExampleRecorder demonstrates verifying interactions with a fake.
+func ExampleRecorder() {
+	t := &mockT{}
+	assert := assertions.New(t)
+
+	store := &fakeStore{NewRecorder()}
+	store.Save(user{Name: "ann"})
+
+	store.AssertCalled(assert, "Save", user{Name: "ann"})
+	store.AssertNumberOfCalls(assert, "Delete", 0)
+
+	fmt.Println("Failed:", assert.HasFailed())
+	// Output: Failed: false
+}
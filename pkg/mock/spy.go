@@ -0,0 +1,148 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"gowise/pkg/assertions"
+)
+
+// SpyCall is a recorded invocation of a Spy.
+type SpyCall struct {
+	// Args holds the arguments; a variadic parameter is recorded as a slice.
+	Args []interface{}
+	// Results holds the returned values, or nil if the call panicked.
+	Results []interface{}
+}
+
+// Spy wraps a function value, recording the arguments and results of every
+// call made through Func. It suits callback-heavy APIs where a full
+// interface fake would be overkill. It is safe for concurrent use, and
+// implements assertions.CallCounter for assert.SpyCalledTimes and friends.
+type Spy[Fn any] struct {
+	// Func calls the wrapped function, recording the call. Pass it wherever
+	// the original function would go.
+	Func Fn
+
+	mu    sync.Mutex
+	calls []SpyCall
+}
+
+// NewSpy wraps fn, which must be a function. A nil fn makes a stub that
+// returns zero values.
+//
+// Example:
+//
+//	onRetry := mock.NewSpy(func(attempt int, err error) {})
+//	client := NewClient(WithRetryHook(onRetry.Func))
+//	client.Get(url)
+//	assert.SpyCalledTimes(onRetry, 2)
+//	onRetry.AssertCalledWith(assert, 2, mock.Anything)
+func NewSpy[Fn any](fn Fn) *Spy[Fn] {
+	fnType := reflect.TypeOf((*Fn)(nil)).Elem()
+	if fnType.Kind() != reflect.Func {
+		panic(fmt.Sprintf("mock.NewSpy: %s is not a function type", fnType))
+	}
+
+	s := &Spy[Fn]{}
+	target := reflect.ValueOf(fn)
+	wrapped := reflect.MakeFunc(fnType, func(in []reflect.Value) []reflect.Value {
+		index := s.record(in)
+
+		var out []reflect.Value
+		switch {
+		case target.IsNil():
+			out = make([]reflect.Value, fnType.NumOut())
+			for i := range out {
+				out[i] = reflect.Zero(fnType.Out(i))
+			}
+		case fnType.IsVariadic():
+			out = target.CallSlice(in)
+		default:
+			out = target.Call(in)
+		}
+
+		s.recordResults(index, out)
+		return out
+	})
+	s.Func = wrapped.Interface().(Fn)
+	return s
+}
+
+// record appends a call with arguments in, returning its index.
+func (s *Spy[Fn]) record(in []reflect.Value) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, SpyCall{Args: interfaces(in)})
+	return len(s.calls) - 1
+}
+
+// recordResults stores the results of the call at index.
+func (s *Spy[Fn]) recordResults(index int, out []reflect.Value) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if index < len(s.calls) {
+		s.calls[index].Results = interfaces(out)
+	}
+}
+
+// CallCount returns the number of calls made through Func.
+func (s *Spy[Fn]) CallCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.calls)
+}
+
+// Calls returns every recorded call, in the order the calls were made.
+func (s *Spy[Fn]) Calls() []SpyCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]SpyCall(nil), s.calls...)
+}
+
+// Reset discards the recorded calls.
+func (s *Spy[Fn]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = nil
+}
+
+// AssertCalledWith asserts that the spy was called at least once with
+// arguments matching args, which may include matchers such as Anything.
+// Returns a to enable method chaining.
+func (s *Spy[Fn]) AssertCalledWith(a *assertions.Assert, args ...interface{}) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+
+	calls := s.Calls()
+	recorded := make([]Call, len(calls))
+	for i, c := range calls {
+		if argsMatch(args, c.Args) {
+			return a
+		}
+		recorded[i] = Call{Method: "spy", Args: c.Args}
+	}
+
+	if len(calls) == 0 {
+		return a.Fail(fmt.Sprintf("expected spy to be called with matching arguments, but it was not called\n  want: %s\n  spy:  %T",
+			Call{Method: "spy", Args: args}, s))
+	}
+	return a.Fail(fmt.Sprintf("expected spy to be called with matching arguments\n  want: %s\n%s",
+		Call{Method: "spy", Args: args}, describe("calls", recorded)))
+}
+
+// interfaces converts reflected values to interface values.
+func interfaces(values []reflect.Value) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v.Interface()
+	}
+	return out
+}
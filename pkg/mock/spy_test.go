@@ -0,0 +1,132 @@
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// TestSpyRecordsCalls tests that a spy forwards calls and records them.
+func TestSpyRecordsCalls(t *testing.T) {
+	spy := NewSpy(func(name string, n int) (string, error) {
+		if n < 0 {
+			return "", errors.New("negative")
+		}
+		return strings.Repeat(name, n), nil
+	})
+
+	got, err := spy.Func("ab", 2)
+	if got != "abab" || err != nil {
+		t.Fatalf("Expected the wrapped function's results, got %q, %v", got, err)
+	}
+	spy.Func("x", -1)
+
+	calls := spy.Calls()
+	if spy.CallCount() != 2 || len(calls) != 2 {
+		t.Fatalf("Expected 2 calls, got %d", spy.CallCount())
+	}
+	if !reflect.DeepEqual(calls[0].Args, []interface{}{"ab", 2}) {
+		t.Errorf("Expected recorded arguments, got %#v", calls[0].Args)
+	}
+	if calls[1].Results[1] == nil || calls[1].Results[1].(error).Error() != "negative" {
+		t.Errorf("Expected recorded error result, got %#v", calls[1].Results)
+	}
+
+	spy.Reset()
+	if spy.CallCount() != 0 {
+		t.Errorf("Expected no calls after Reset, got %d", spy.CallCount())
+	}
+}
+
+// TestSpyStubAndVariadic tests nil stubs and variadic functions.
+func TestSpyStubAndVariadic(t *testing.T) {
+	stub := NewSpy[func() (int, error)](nil)
+	if n, err := stub.Func(); n != 0 || err != nil {
+		t.Errorf("Expected zero results from a stub, got %d, %v", n, err)
+	}
+
+	sum := NewSpy(func(xs ...int) int {
+		total := 0
+		for _, x := range xs {
+			total += x
+		}
+		return total
+	})
+	if got := sum.Func(1, 2, 3); got != 6 {
+		t.Errorf("Expected 6, got %d", got)
+	}
+	if args := sum.Calls()[0].Args; !reflect.DeepEqual(args, []interface{}{[]int{1, 2, 3}}) {
+		t.Errorf("Expected variadic arguments recorded as a slice, got %#v", args)
+	}
+}
+
+// TestSpyPanicsOnNonFunction tests the type check in NewSpy.
+func TestSpyPanicsOnNonFunction(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "int is not a function type") {
+			t.Errorf("Expected panic naming the type, got %v", r)
+		}
+	}()
+	NewSpy(42)
+}
+
+// TestSpyAssertions tests spies with the Assert spy methods and AssertCalledWith.
+func TestSpyAssertions(t *testing.T) {
+	tests := []struct {
+		name          string
+		assert        func(spy *Spy[func(int, error)], a *assertions.Assert)
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"SpyCalledTimes", func(s *Spy[func(int, error)], a *assertions.Assert) { a.SpyCalledTimes(s, 2) }, true, ""},
+		{"SpyCalledTimes fails", func(s *Spy[func(int, error)], a *assertions.Assert) { a.SpyCalledTimes(s, 3) }, false,
+			"expected spy to be called 3 times, got 2 times\n  spy: *mock.Spy[func(int, error)]"},
+		{"SpyCalled", func(s *Spy[func(int, error)], a *assertions.Assert) { a.SpyCalled(s) }, true, ""},
+		{"SpyNotCalled fails", func(s *Spy[func(int, error)], a *assertions.Assert) { a.SpyNotCalled(s) }, false, "expected spy not to be called, got 2 times"},
+		{"AssertCalledWith", func(s *Spy[func(int, error)], a *assertions.Assert) { s.AssertCalledWith(a, 2, Anything) }, true, ""},
+		{"AssertCalledWith fails", func(s *Spy[func(int, error)], a *assertions.Assert) { s.AssertCalledWith(a, 3, nil) }, false,
+			"expected spy to be called with matching arguments\n  want: spy(3, <nil>)\n  calls (2):\n    [0] spy(1, <nil>)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			onRetry := NewSpy(func(attempt int, err error) {})
+			onRetry.Func(1, nil)
+			onRetry.Func(2, errors.New("timeout"))
+
+			mock := &mockT{}
+			tt.assert(onRetry, assertions.New(mock))
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("Assertion should pass (no Errorf calls), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("Assertion should fail (1 Errorf call), got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("Expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+// ExampleNewSpy demonstrates spying on a callback.
+func ExampleNewSpy() {
+	onEvent := NewSpy(func(name string) {})
+
+	for _, name := range []string{"started", "finished"} {
+		onEvent.Func(name)
+	}
+
+	assert := assertions.New(&mockT{})
+	assert.SpyCalledTimes(onEvent, 2)
+	onEvent.AssertCalledWith(assert, "finished")
+
+	fmt.Println("Failed:", assert.HasFailed())
+	// Output: Failed: false
+}
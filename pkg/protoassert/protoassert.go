@@ -0,0 +1,233 @@
+// Package protoassert compares protocol buffer messages generated by
+// protoc-gen-go without depending on the protobuf module, so that the
+// standard-library-only policy holds.
+//
+// reflect.DeepEqual, and so assertions.Equal, compares a generated message's
+// internal state along with its fields: a message that has been marshalled
+// caches its size, and one that has been read through reflection records it,
+// so two messages with the same fields can differ. Equal instead compares
+// only the exported fields the generator emits for the message's proto
+// fields, and reports the differing fields by their proto names:
+//
+//	func TestGetUser(t *testing.T) {
+//		assert := assertions.New(t)
+//		got, err := client.GetUser(ctx, &pb.GetUserRequest{Id: 7})
+//		assert.NoError(err)
+//		protoassert.Equal(assert, got, &pb.User{Id: 7, DisplayName: "Ann"})
+//	}
+//
+// Equal follows proto semantics where they differ from Go's: an empty
+// repeated, map or bytes field equals an unset one. Unknown fields and
+// extensions, kept in unexported state, are ignored, and a
+// google.protobuf.Any is compared by its type URL and encoded bytes rather
+// than by the message it holds. Where those matter, compare with proto.Equal
+// from google.golang.org/protobuf in the test itself.
+package protoassert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gowise/pkg/assertions"
+)
+
+// maxListedDifferences bounds the differing fields a failure lists.
+const maxListedDifferences = 10
+
+// Equal fails unless got and want, pointers to generated messages of the same
+// type, have equal proto fields. Two nil messages are equal. A failure lists
+// each differing field by its path of proto field names, such as
+// address.city or tags[2].
+// Returns *Assert to enable method chaining.
+//
+// Example:
+//
+//	protoassert.Equal(assert, resp.User, &pb.User{Id: 7, DisplayName: "Ann"})
+func Equal(a *assertions.Assert, got, want any) *assertions.Assert {
+	if h, ok := a.T().(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if a.HasFailed() {
+		return a
+	}
+
+	for _, m := range []any{got, want} {
+		if !isMessage(reflect.ValueOf(m)) {
+			return a.Fail(fmt.Sprintf("cannot compare %T as a proto message: want a pointer to a generated message struct", m))
+		}
+	}
+	gv, wv := reflect.ValueOf(got), reflect.ValueOf(want)
+	if gv.Type() != wv.Type() {
+		return a.Fail(fmt.Sprintf("proto messages have different types\n  got:  %s\n  want: %s", gv.Type(), wv.Type()))
+	}
+
+	var c comparer
+	c.value("", gv, wv)
+	if len(c.differences) == 0 {
+		return a
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "proto messages differ in %d field(s)\n  type: %s", len(c.differences), gv.Type())
+	for i, d := range c.differences {
+		if i == maxListedDifferences {
+			fmt.Fprintf(&b, "\n  … (%d more)", len(c.differences)-maxListedDifferences)
+			break
+		}
+		b.WriteString("\n  ")
+		b.WriteString(d)
+	}
+	return a.Fail(b.String())
+}
+
+// isMessage reports whether v is a pointer to a struct, as generated
+// messages are.
+func isMessage(v reflect.Value) bool {
+	return v.IsValid() && v.Kind() == reflect.Pointer && v.Type().Elem().Kind() == reflect.Struct
+}
+
+// comparer collects the differences between two messages, one line per
+// differing field.
+type comparer struct {
+	differences []string
+}
+
+// differ records that the field at path differs.
+func (c *comparer) differ(path string, got, want string) {
+	if path == "" {
+		path = "(message)"
+	}
+	c.differences = append(c.differences, fmt.Sprintf("%s: got %s, want %s", path, got, want))
+}
+
+// value compares got and want, of the same type, found at path.
+func (c *comparer) value(path string, got, want reflect.Value) {
+	switch got.Kind() {
+	case reflect.Pointer:
+		if got.IsNil() || want.IsNil() {
+			if got.IsNil() != want.IsNil() {
+				c.differ(path, describePresence(got), describePresence(want))
+			}
+			return
+		}
+		c.value(path, got.Elem(), want.Elem())
+	case reflect.Interface:
+		// A oneof: the interface holds a pointer to a wrapper struct for the
+		// field that is set
+		if got.IsNil() || want.IsNil() || got.Elem().Type() != want.Elem().Type() {
+			if !(got.IsNil() && want.IsNil()) {
+				c.differ(path, describeOneof(got), describeOneof(want))
+			}
+			return
+		}
+		c.value(path, got.Elem(), want.Elem())
+	case reflect.Struct:
+		c.fields(path, got, want)
+	case reflect.Slice:
+		if got.Type().Elem().Kind() == reflect.Uint8 {
+			if string(got.Bytes()) != string(want.Bytes()) {
+				c.differ(path, fmt.Sprintf("%q", got.Bytes()), fmt.Sprintf("%q", want.Bytes()))
+			}
+			return
+		}
+		if got.Len() != want.Len() {
+			c.differ(path, fmt.Sprintf("%d elements", got.Len()), fmt.Sprintf("%d elements", want.Len()))
+			return
+		}
+		for i := range got.Len() {
+			c.value(fmt.Sprintf("%s[%d]", path, i), got.Index(i), want.Index(i))
+		}
+	case reflect.Map:
+		c.entries(path, got, want)
+	default:
+		if got.Interface() != want.Interface() {
+			c.differ(path, fmt.Sprintf("%#v", got.Interface()), fmt.Sprintf("%#v", want.Interface()))
+		}
+	}
+}
+
+// fields compares the exported fields of two structs of the same type.
+// Generated messages keep their internal state in unexported fields.
+func (c *comparer) fields(path string, got, want reflect.Value) {
+	t := got.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := protoName(field)
+		if path != "" {
+			name = path + "." + name
+		}
+		c.value(name, got.Field(i), want.Field(i))
+	}
+}
+
+// entries compares two maps of the same type, key by key in sorted order.
+func (c *comparer) entries(path string, got, want reflect.Value) {
+	keys := map[string]reflect.Value{}
+	for _, m := range []reflect.Value{got, want} {
+		for _, k := range m.MapKeys() {
+			keys[fmt.Sprintf("%#v", k.Interface())] = k
+		}
+	}
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := keys[name]
+		entryPath := fmt.Sprintf("%s[%s]", path, name)
+		g, w := got.MapIndex(key), want.MapIndex(key)
+		switch {
+		case !g.IsValid():
+			c.differ(entryPath, "no entry", fmt.Sprintf("%#v", w.Interface()))
+		case !w.IsValid():
+			c.differ(entryPath, fmt.Sprintf("%#v", g.Interface()), "no entry")
+		default:
+			c.value(entryPath, g, w)
+		}
+	}
+}
+
+// protoName returns the proto name of a generated field, from the name= key
+// of its protobuf struct tag, or the oneof name of a oneof field. Fields
+// without either, as in hand-written structs, keep their Go name.
+func protoName(field reflect.StructField) string {
+	if oneof := field.Tag.Get("protobuf_oneof"); oneof != "" {
+		return oneof
+	}
+	for _, part := range strings.Split(field.Tag.Get("protobuf"), ",") {
+		if name, ok := strings.CutPrefix(part, "name="); ok {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// describePresence renders a message field for a failure: unset, or set to
+// its message.
+func describePresence(v reflect.Value) string {
+	if v.IsNil() {
+		return "unset"
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// describeOneof renders a oneof for a failure: unset, or which field is set
+// and to what.
+func describeOneof(v reflect.Value) string {
+	if v.IsNil() {
+		return "unset"
+	}
+	wrapper := v.Elem()
+	if wrapper.Kind() == reflect.Pointer && !wrapper.IsNil() && wrapper.Elem().Kind() == reflect.Struct && wrapper.Elem().NumField() == 1 {
+		field := wrapper.Elem().Type().Field(0)
+		return fmt.Sprintf("%s = %#v", protoName(field), wrapper.Elem().Field(0).Interface())
+	}
+	return fmt.Sprintf("%#v", wrapper.Interface())
+}
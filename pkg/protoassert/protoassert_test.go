@@ -0,0 +1,158 @@
+package protoassert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// mockT records failures reported through an Assert.
+type mockT struct {
+	errorCalls []string
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.errorCalls = append(m.errorCalls, fmt.Sprintf(format, args...))
+}
+func (m *mockT) FailNow() {}
+func (m *mockT) Helper()  {}
+
+// The types below are shaped as protoc-gen-go generates messages: internal
+// state in unexported fields, proto names in struct tags, and oneofs as
+// interfaces holding wrapper structs.
+
+type messageState struct{ sizeCached bool }
+
+type Address struct {
+	state         messageState
+	sizeCache     int32
+	unknownFields []byte
+
+	City     string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Postcode string `protobuf:"bytes,2,opt,name=postcode,proto3" json:"postcode,omitempty"`
+}
+
+type User struct {
+	state         messageState
+	sizeCache     int32
+	unknownFields []byte
+
+	Id          int64             `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DisplayName string            `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Home        *Address          `protobuf:"bytes,3,opt,name=home,proto3" json:"home,omitempty"`
+	Tags        []string          `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	Labels      map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty"`
+	Avatar      []byte            `protobuf:"bytes,6,opt,name=avatar,proto3" json:"avatar,omitempty"`
+	// Types that are valid to be assigned to Contact:
+	//
+	//	*User_Email
+	//	*User_Phone
+	Contact isUser_Contact `protobuf_oneof:"contact"`
+}
+
+type isUser_Contact interface{ isUser_Contact() }
+
+type User_Email struct {
+	Email string `protobuf:"bytes,7,opt,name=email,proto3,oneof"`
+}
+
+type User_Phone struct {
+	Phone string `protobuf:"bytes,8,opt,name=phone,proto3,oneof"`
+}
+
+func (*User_Email) isUser_Contact() {}
+func (*User_Phone) isUser_Contact() {}
+
+type Group struct {
+	state messageState
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func ann() *User {
+	return &User{
+		Id:          7,
+		DisplayName: "Ann",
+		Home:        &Address{City: "Leeds"},
+		Tags:        []string{"admin", "beta"},
+		Labels:      map[string]string{"team": "core"},
+		Contact:     &User_Email{Email: "ann@example.com"},
+	}
+}
+
+func TestEqual(t *testing.T) {
+	marshalled := ann()
+	marshalled.sizeCache = 42
+	marshalled.state.sizeCached = true
+	marshalled.Home.unknownFields = []byte{0x08, 0x01}
+
+	tests := []struct {
+		name          string
+		got, want     any
+		shouldPass    bool
+		expectMessage string
+	}{
+		{"identical", ann(), ann(), true, ""},
+		{"internal state ignored", marshalled, ann(), true, ""},
+		{"empty repeated equals unset", &User{Tags: []string{}, Labels: map[string]string{}, Avatar: []byte{}}, &User{}, true, ""},
+		{"both nil", (*User)(nil), (*User)(nil), true, ""},
+		{"scalar differs", &User{Id: 7, DisplayName: "Ann"}, &User{Id: 7, DisplayName: "Anne"}, false,
+			"proto messages differ in 1 field(s)\n  type: *protoassert.User\n  display_name: got \"Ann\", want \"Anne\""},
+		{"nested field", ann(), func() *User { u := ann(); u.Home.City = "York"; return u }(), false,
+			"  home.city: got \"Leeds\", want \"York\""},
+		{"message unset", &User{Home: &Address{}}, &User{}, false, "  home: got "},
+		{"repeated length", ann(), func() *User { u := ann(); u.Tags = u.Tags[:1]; return u }(), false,
+			"  tags: got 2 elements, want 1 elements"},
+		{"repeated element", ann(), func() *User { u := ann(); u.Tags[1] = "alpha"; return u }(), false,
+			"  tags[1]: got \"beta\", want \"alpha\""},
+		{"map entry", ann(), func() *User { u := ann(); u.Labels = map[string]string{"env": "prod"}; return u }(), false,
+			"  labels[\"env\"]: got no entry, want \"prod\"\n  labels[\"team\"]: got \"core\", want no entry"},
+		{"bytes", &User{Avatar: []byte("a")}, &User{Avatar: []byte("b")}, false, "  avatar: got \"a\", want \"b\""},
+		{"oneof case", ann(), func() *User { u := ann(); u.Contact = &User_Phone{Phone: "0113"}; return u }(), false,
+			"  contact: got email = \"ann@example.com\", want phone = \"0113\""},
+		{"oneof value", ann(), func() *User { u := ann(); u.Contact = &User_Email{Email: "a@example.org"}; return u }(), false,
+			"  contact.email: got \"ann@example.com\", want \"a@example.org\""},
+		{"oneof unset", ann(), func() *User { u := ann(); u.Contact = nil; return u }(), false,
+			"  contact: got email = \"ann@example.com\", want unset"},
+		{"one nil", ann(), (*User)(nil), false, "  (message): got "},
+		{"different types", &User{}, &Group{}, false,
+			"proto messages have different types\n  got:  *protoassert.User\n  want: *protoassert.Group"},
+		{"not a message", User{}, &User{}, false, "cannot compare protoassert.User as a proto message"},
+		{"untyped nil", ann(), nil, false, "cannot compare <nil> as a proto message"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockT{}
+			Equal(assertions.New(mock), tt.got, tt.want)
+
+			if tt.shouldPass && len(mock.errorCalls) != 0 {
+				t.Errorf("expected to pass, got %v", mock.errorCalls)
+			} else if !tt.shouldPass {
+				if len(mock.errorCalls) != 1 {
+					t.Fatalf("expected 1 failure, got %d: %v", len(mock.errorCalls), mock.errorCalls)
+				}
+				if !strings.Contains(mock.errorCalls[0], tt.expectMessage) {
+					t.Errorf("expected message containing %q, got: %s", tt.expectMessage, mock.errorCalls[0])
+				}
+			}
+		})
+	}
+}
+
+func TestEqualListsAtMostTen(t *testing.T) {
+	got, want := &User{}, &User{}
+	for i := range 12 {
+		got.Tags = append(got.Tags, fmt.Sprint(i))
+		want.Tags = append(want.Tags, fmt.Sprint(-i-1))
+	}
+
+	mock := &mockT{}
+	Equal(assertions.New(mock), got, want)
+	if len(mock.errorCalls) != 1 || !strings.Contains(mock.errorCalls[0], "differ in 12 field(s)") ||
+		!strings.Contains(mock.errorCalls[0], "\n  … (2 more)") {
+		t.Errorf("expected ten differences and a count of the rest, got %v", mock.errorCalls)
+	}
+}
@@ -0,0 +1,326 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+	"gowise/pkg/interfaces/teststatus"
+)
+
+// Verbosity controls how much a ConsoleReporter writes as tests run.
+type Verbosity int
+
+const (
+	// Quiet writes only failed tests, their failure messages and the summary.
+	Quiet Verbosity = iota
+	// Normal also writes a line for every passed or skipped test.
+	Normal
+	// Verbose also writes a line as each test starts, and every message and
+	// attachment.
+	Verbose
+)
+
+// ANSI escape sequences used by ConsoleReporter when colour is enabled.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiDim    = "\x1b[2m"
+	ansiBold   = "\x1b[1m"
+)
+
+// ConsoleReporter writes results for people watching a terminal: a line per
+// test as it finishes, marked ✓, ✗ or – and followed by its elapsed time,
+// failure messages indented beneath the failing test, and, on Close, a
+// summary table with the counts, the failed tests and the slowest tests.
+// It is safe for concurrent use.
+type ConsoleReporter struct {
+	mu        sync.Mutex
+	writer    io.Writer
+	verbosity Verbosity
+	colour    bool
+	slowest   int
+	now       func() time.Time
+	start     time.Time
+	tests     []consoleTest
+	failed    map[string]bool // IDs of tests that did not pass, for failure messages
+}
+
+// consoleTest is a test reported to a ConsoleReporter.
+type consoleTest struct {
+	name     string
+	status   string
+	duration time.Duration
+}
+
+// ConsoleOption configures a ConsoleReporter.
+type ConsoleOption func(*ConsoleReporter)
+
+// WithVerbosity sets how much the reporter writes as tests run. The default
+// is Normal.
+func WithVerbosity(v Verbosity) ConsoleOption {
+	return func(r *ConsoleReporter) {
+		r.verbosity = v
+	}
+}
+
+// WithColour turns coloured output on or off. By default it is on when the
+// writer is a terminal and the NO_COLOR environment variable is not set.
+func WithColour(on bool) ConsoleOption {
+	return func(r *ConsoleReporter) {
+		r.colour = on
+	}
+}
+
+// WithSlowest sets how many of the slowest tests the summary lists. The
+// default is 5; 0 leaves the list out.
+func WithSlowest(n int) ConsoleOption {
+	return func(r *ConsoleReporter) {
+		r.slowest = n
+	}
+}
+
+// NewConsoleReporter creates a ConsoleReporter writing to w.
+//
+// Example:
+//
+//	verbosity := reporter.Normal
+//	if testing.Verbose() {
+//		verbosity = reporter.Verbose
+//	}
+//	rep := reporter.NewConsoleReporter(os.Stdout, reporter.WithVerbosity(verbosity))
+//	defer rep.Close()
+//	tr := testrunner.NewTestRunner(t, logger, true, rep)
+func NewConsoleReporter(w io.Writer, opts ...ConsoleOption) *ConsoleReporter {
+	r := &ConsoleReporter{
+		writer:    w,
+		verbosity: Normal,
+		colour:    isTerminal(w) && os.Getenv("NO_COLOR") == "",
+		slowest:   5,
+		now:       time.Now,
+		failed:    make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// ReportTestStart writes a line for testName when the verbosity is Verbose.
+// The method returns an error if the line could not be written.
+func (r *ConsoleReporter) ReportTestStart(testName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.begin()
+	if r.verbosity < Verbose {
+		return nil
+	}
+	return r.write(r.paint(ansiDim, "  ▶ "+testName) + "\n")
+}
+
+// ReportTestOutput writes the line for a finished test. Passed and skipped
+// tests are left out when the verbosity is Quiet.
+// The method returns an error if the line could not be written.
+func (r *ConsoleReporter) ReportTestOutput(to testoutput.TestOutput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.begin()
+	// The runner records the test's duration as the output text.
+	d, _ := time.ParseDuration(to.Text)
+	r.tests = append(r.tests, consoleTest{name: to.TestName, status: to.Status, duration: d})
+
+	var line string
+	switch to.Status {
+	case teststatus.Passed.GetResult():
+		if r.verbosity == Quiet {
+			return nil
+		}
+		line = r.paint(ansiGreen, "✓") + " " + to.TestName + " " + r.paint(ansiDim, "("+d.String()+")")
+	case teststatus.Skipped.GetResult():
+		if r.verbosity == Quiet {
+			return nil
+		}
+		line = r.paint(ansiYellow, "–") + " " + to.TestName + " " + r.paint(ansiDim, "(skipped)")
+	default:
+		if to.TestID != "" {
+			r.failed[to.TestID] = true
+		}
+		label := "(" + d.String() + ")"
+		if to.Status != teststatus.Failed.GetResult() {
+			label = "(" + strings.ToLower(to.Status) + ", " + d.String() + ")"
+		}
+		line = r.paint(ansiRed, "✗ "+to.TestName) + " " + r.paint(ansiDim, label)
+	}
+	return r.write("  " + line + "\n")
+}
+
+// ReportTestMessage writes a failure message indented beneath its test.
+// Other messages are written only when the verbosity is Verbose.
+// The method returns an error if the message could not be written.
+func (r *ConsoleReporter) ReportTestMessage(tm testmessage.TestMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if tm.Destination == FailureDestination && r.failed[tm.TestID] {
+		return r.write(indent(tm.Message, "      ", func(s string) string { return r.paint(ansiRed, s) }))
+	}
+	if r.verbosity < Verbose {
+		return nil
+	}
+	return r.write(indent(tm.ToString(), "      ", func(s string) string { return s }))
+}
+
+// ReportTestAttachment writes a line describing the attachment when the
+// verbosity is Verbose.
+// The method returns an error if the line could not be written.
+func (r *ConsoleReporter) ReportTestAttachment(ta testattachment.TestAttachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.verbosity < Verbose {
+		return nil
+	}
+	text := fmt.Sprintf("attachment: %s (%d bytes)", ta.FilePath, ta.FileSize)
+	if ta.Description != "" {
+		text += ": " + ta.Description
+	}
+	return r.write("      " + r.paint(ansiDim, text) + "\n")
+}
+
+// Close writes the summary table and closes the writer if it implements the
+// io.Closer interface, unless it is os.Stdout or os.Stderr.
+// The method returns an error if the summary could not be written or the
+// writer could not be closed.
+func (r *ConsoleReporter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.write(r.summary()); err != nil {
+		return err
+	}
+
+	if r.writer == os.Stdout || r.writer == os.Stderr {
+		return nil
+	}
+	if closer, ok := r.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// summary renders the summary table. The caller must hold r.mu.
+func (r *ConsoleReporter) summary() string {
+	var passed, failed, skipped []consoleTest
+	for _, test := range r.tests {
+		switch test.status {
+		case teststatus.Passed.GetResult():
+			passed = append(passed, test)
+		case teststatus.Skipped.GetResult():
+			skipped = append(skipped, test)
+		default:
+			failed = append(failed, test)
+		}
+	}
+	var elapsed time.Duration
+	if !r.start.IsZero() {
+		elapsed = r.now().Sub(r.start)
+	}
+
+	var b strings.Builder
+	b.WriteString("\n" + r.paint(ansiBold, "Summary") + "\n")
+	rows := [][2]string{
+		{"Passed", fmt.Sprint(len(passed))},
+		{"Failed", fmt.Sprint(len(failed))},
+		{"Skipped", fmt.Sprint(len(skipped))},
+		{"Total", fmt.Sprint(len(r.tests))},
+		{"Time", elapsed.Round(time.Millisecond).String()},
+	}
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row[1]))
+	}
+	for _, row := range rows {
+		fmt.Fprintf(&b, "  %-8s %*s\n", row[0], width, row[1])
+	}
+
+	if len(failed) > 0 {
+		b.WriteString("\n" + r.paint(ansiBold, "Failed") + "\n")
+		for _, test := range failed {
+			fmt.Fprintf(&b, "  %s\n", r.paint(ansiRed, "✗ "+test.name))
+		}
+	}
+
+	slowest := append([]consoleTest(nil), r.tests...)
+	sort.SliceStable(slowest, func(i, j int) bool { return slowest[i].duration > slowest[j].duration })
+	if len(slowest) > r.slowest {
+		slowest = slowest[:r.slowest]
+	}
+	if len(slowest) > 0 {
+		b.WriteString("\n" + r.paint(ansiBold, "Slowest") + "\n")
+		width = 0
+		for _, test := range slowest {
+			width = max(width, len(test.duration.String()))
+		}
+		for _, test := range slowest {
+			fmt.Fprintf(&b, "  %*s  %s\n", width, test.duration, test.name)
+		}
+	}
+
+	if len(failed) > 0 {
+		b.WriteString("\n" + r.paint(ansiRed+ansiBold, "FAIL") + "\n")
+	} else {
+		b.WriteString("\n" + r.paint(ansiGreen+ansiBold, "PASS") + "\n")
+	}
+	return b.String()
+}
+
+// begin records the time of the first event. The caller must hold r.mu.
+func (r *ConsoleReporter) begin() {
+	if r.start.IsZero() {
+		r.start = r.now()
+	}
+}
+
+// paint wraps s in the ANSI sequence code if colour is enabled.
+func (r *ConsoleReporter) paint(code, s string) string {
+	if !r.colour {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// write writes s. The caller must hold r.mu.
+func (r *ConsoleReporter) write(s string) error {
+	_, err := io.WriteString(r.writer, s)
+	return err
+}
+
+// indent prefixes each line of text with prefix, styled by style.
+func indent(text, prefix string, style func(string) string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(prefix + style(line) + "\n")
+	}
+	return b.String()
+}
+
+// isTerminal reports whether w is a character device, such as a terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,109 @@
+package reporter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+)
+
+// reportConsoleRun reports a small run to r: a pass, a failure with its
+// failure message, a skip and a log message.
+func reportConsoleRun(r *ConsoleReporter) {
+	clock := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r.now = func() time.Time {
+		clock = clock.Add(300 * time.Millisecond)
+		return clock
+	}
+
+	r.ReportTestStart("TestAdd")
+	r.ReportTestOutput(testoutput.NewTestOutput("20ms", "Passed", "id-1", "TestAdd", "Passed"))
+	r.ReportTestOutput(testoutput.NewTestOutput("40ms", "Failed", "id-2", "TestRemove", "Failed"))
+	r.ReportTestMessage(testmessage.NewTestMessage(FailureDestination, "values differ\n  got:  1\n  want: 2", "id-2"))
+	r.ReportTestOutput(testoutput.NewTestOutput("0s", "Skipped", "id-3", "TestRefund", "Skipped"))
+	r.ReportTestMessage(testmessage.NewTestMessage("stdout", "seeded 3 carts", ""))
+	r.ReportTestAttachment(testattachment.TestAttachment{FilePath: "/tmp/cart.json", FileSize: 12})
+}
+
+func TestConsoleReporterNormal(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewConsoleReporter(&buf, WithSlowest(2))
+	reportConsoleRun(r)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	want := `  ✓ TestAdd (20ms)
+  ✗ TestRemove (40ms)
+      values differ
+        got:  1
+        want: 2
+  – TestRefund (skipped)
+
+Summary
+  Passed       1
+  Failed       1
+  Skipped      1
+  Total        3
+  Time     300ms
+
+Failed
+  ✗ TestRemove
+
+Slowest
+  40ms  TestRemove
+  20ms  TestAdd
+
+FAIL
+`
+	if buf.String() != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestConsoleReporterVerbosity(t *testing.T) {
+	var quiet bytes.Buffer
+	r := NewConsoleReporter(&quiet, WithVerbosity(Quiet), WithSlowest(0))
+	reportConsoleRun(r)
+	r.Close()
+	if strings.Contains(quiet.String(), "TestAdd") || strings.Contains(quiet.String(), "Slowest") {
+		t.Errorf("Expected Quiet to leave out passed tests and slowest list, got:\n%s", quiet.String())
+	}
+	if !strings.Contains(quiet.String(), "  ✗ TestRemove (40ms)\n      values differ\n") {
+		t.Errorf("Expected Quiet to keep failures, got:\n%s", quiet.String())
+	}
+
+	var verbose bytes.Buffer
+	r = NewConsoleReporter(&verbose, WithVerbosity(Verbose))
+	reportConsoleRun(r)
+	r.Close()
+	for _, want := range []string{"  ▶ TestAdd\n", "      stdout: seeded 3 carts\n", "      attachment: /tmp/cart.json (12 bytes)\n"} {
+		if !strings.Contains(verbose.String(), want) {
+			t.Errorf("Expected Verbose output to contain %q, got:\n%s", want, verbose.String())
+		}
+	}
+}
+
+func TestConsoleReporterColour(t *testing.T) {
+	var plain bytes.Buffer
+	r := NewConsoleReporter(&plain)
+	reportConsoleRun(r)
+	r.Close()
+	if strings.Contains(plain.String(), "\x1b[") {
+		t.Errorf("Expected no colour when writing to a buffer, got:\n%q", plain.String())
+	}
+
+	var coloured bytes.Buffer
+	r = NewConsoleReporter(&coloured, WithColour(true))
+	r.ReportTestOutput(testoutput.NewTestOutput("1ms", "Passed", "id-1", "TestAdd", "Passed"))
+	r.ReportTestOutput(testoutput.NewTestOutput("1ms", "Errored", "id-2", "TestSetup", "Errored"))
+	for _, want := range []string{"\x1b[32m✓\x1b[0m TestAdd", "\x1b[31m✗ TestSetup\x1b[0m \x1b[2m(errored, 1ms)\x1b[0m"} {
+		if !strings.Contains(coloured.String(), want) {
+			t.Errorf("Expected coloured output to contain %q, got:\n%q", want, coloured.String())
+		}
+	}
+}
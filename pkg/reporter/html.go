@@ -0,0 +1,386 @@
+package reporter
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+	"gowise/pkg/interfaces/teststatus"
+)
+
+// MaxInlineAttachment is the size, in bytes, up to which HTMLReporter embeds
+// image and text attachments in the page. Larger attachments, and those of
+// other types, are linked by file path.
+const MaxInlineAttachment = 256 << 10
+
+// HTMLReporter writes a self-contained HTML page summarising a run, for
+// readers who will not read test logs: a pass/fail summary, each test with
+// its status and time, failure messages in expandable sections with diff
+// lines highlighted, attachments embedded or linked, and a chart of test
+// times. The page has no scripts or external resources.
+// Results are collected as they are reported and the page is written by
+// Close. It is safe for concurrent use.
+type HTMLReporter struct {
+	mu          sync.Mutex
+	writer      io.Writer
+	title       string
+	tests       []*htmlTest
+	messages    []testmessage.TestMessage
+	attachments []testattachment.TestAttachment
+}
+
+// htmlTest is a test reported to an HTMLReporter.
+type htmlTest struct {
+	id       string
+	name     string
+	status   string
+	duration time.Duration
+	known    bool // Whether the duration was reported
+}
+
+// NewHTMLReporter creates an HTMLReporter writing a page titled title to w
+// when it is closed.
+//
+// Example:
+//
+//	file, err := os.Create("report.html")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	rep := reporter.NewHTMLReporter(file, "Checkout service")
+//	defer rep.Close()
+//	tr := testrunner.NewTestRunner(t, logger, true, rep)
+func NewHTMLReporter(w io.Writer, title string) *HTMLReporter {
+	return &HTMLReporter{writer: w, title: title}
+}
+
+// ReportTestOutput records the result of a test.
+// The method always returns nil.
+func (r *HTMLReporter) ReportTestOutput(to testoutput.TestOutput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The runner records the test's duration as the output text.
+	d, err := time.ParseDuration(to.Text)
+	r.tests = append(r.tests, &htmlTest{id: to.TestID, name: to.TestName, status: to.Status, duration: d, known: err == nil})
+	return nil
+}
+
+// ReportTestMessage records a message. Messages whose TestID matches a
+// reported test are shown with that test, and the rest with the run.
+// The method always returns nil.
+func (r *HTMLReporter) ReportTestMessage(tm testmessage.TestMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.messages = append(r.messages, tm)
+	return nil
+}
+
+// ReportTestAttachment records an attachment, to be embedded in the page if
+// it is a small image or text file and linked otherwise.
+// The method always returns nil.
+func (r *HTMLReporter) ReportTestAttachment(ta testattachment.TestAttachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.attachments = append(r.attachments, ta)
+	return nil
+}
+
+// Close writes the page and closes the writer if it implements the io.Closer
+// interface.
+// The method returns an error if the page could not be written or the writer
+// could not be closed.
+func (r *HTMLReporter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := htmlReportTemplate.Execute(r.writer, r.page()); err != nil {
+		return fmt.Errorf("error writing HTML report: %w", err)
+	}
+	if closer, ok := r.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// htmlPage is the data the report template renders.
+type htmlPage struct {
+	Title       string
+	Generated   string
+	Total       int
+	Counts      []htmlCount
+	Elapsed     string
+	Tests       []htmlPageTest
+	Messages    []htmlMessage
+	Attachments []htmlAttachment
+}
+
+// htmlCount is the number of tests with a status.
+type htmlCount struct {
+	Status string
+	Class  string
+	Count  int
+}
+
+// htmlPageTest is a test as rendered.
+type htmlPageTest struct {
+	Name     string
+	Status   string
+	Class    string
+	Duration string
+	Width    float64 // Of the timing bar, as a percentage of the slowest test's
+	Messages []htmlMessage
+}
+
+// htmlMessage is a message as rendered, one line at a time.
+type htmlMessage struct {
+	Destination string
+	Lines       []htmlLine
+}
+
+// htmlLine is a line of a message, with a class marking diff lines.
+type htmlLine struct {
+	Class string
+	Text  string
+}
+
+// htmlAttachment is an attachment as rendered: an embedded image, embedded
+// text, or a link.
+type htmlAttachment struct {
+	Name        string
+	Description string
+	Size        int64
+	Image       template.URL
+	Text        string
+	Link        template.URL
+}
+
+// statusOrder lists statuses in the order the summary shows them.
+var statusOrder = []string{
+	teststatus.Passed.GetResult(),
+	teststatus.Failed.GetResult(),
+	teststatus.Errored.GetResult(),
+	teststatus.Skipped.GetResult(),
+}
+
+// page prepares the report for rendering. The caller must hold r.mu.
+func (r *HTMLReporter) page() htmlPage {
+	p := htmlPage{Title: r.title, Generated: time.Now().Format(time.RFC1123), Total: len(r.tests)}
+
+	counts := make(map[string]int)
+	var slowest, total time.Duration
+	for _, test := range r.tests {
+		counts[test.status]++
+		slowest = max(slowest, test.duration)
+		total += test.duration
+	}
+	for _, status := range statusOrder {
+		p.Counts = append(p.Counts, htmlCount{Status: status, Class: statusClass(status), Count: counts[status]})
+	}
+	p.Elapsed = total.String()
+
+	byID := make(map[string]*htmlPageTest)
+	p.Tests = make([]htmlPageTest, len(r.tests))
+	for i, test := range r.tests {
+		pt := &p.Tests[i]
+		*pt = htmlPageTest{Name: test.name, Status: test.status, Class: statusClass(test.status)}
+		if test.known {
+			pt.Duration = test.duration.String()
+		}
+		if slowest > 0 {
+			pt.Width = math.Round(float64(test.duration)/float64(slowest)*1000) / 10
+		}
+		if test.id != "" {
+			byID[test.id] = pt
+		}
+	}
+
+	for _, tm := range r.messages {
+		message := htmlMessage{Destination: tm.Destination, Lines: diffLines(tm.Message)}
+		if pt, ok := byID[tm.TestID]; ok {
+			pt.Messages = append(pt.Messages, message)
+		} else {
+			p.Messages = append(p.Messages, message)
+		}
+	}
+
+	for _, ta := range r.attachments {
+		p.Attachments = append(p.Attachments, renderAttachment(ta))
+	}
+	return p
+}
+
+// statusClass returns the CSS class of a status.
+func statusClass(status string) string {
+	switch status {
+	case teststatus.Passed.GetResult():
+		return "passed"
+	case teststatus.Skipped.GetResult():
+		return "skipped"
+	}
+	return "failed"
+}
+
+// diffLines splits a message into lines, marking the removed, added and hunk
+// header lines of unified and context diffs.
+func diffLines(message string) []htmlLine {
+	var lines []htmlLine
+	for _, text := range strings.Split(strings.TrimRight(message, "\n"), "\n") {
+		line := htmlLine{Text: text}
+		trimmed := strings.TrimLeft(text, " \t")
+		switch {
+		case strings.HasPrefix(trimmed, "@@"), strings.HasPrefix(trimmed, "--- "), strings.HasPrefix(trimmed, "+++ "):
+			line.Class = "hunk"
+		case trimmed == "-", strings.HasPrefix(trimmed, "- "):
+			line.Class = "del"
+		case trimmed == "+", strings.HasPrefix(trimmed, "+ "):
+			line.Class = "ins"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// inlineImageTypes maps the extensions of images embedded in the page to
+// their media types.
+var inlineImageTypes = map[string]string{
+	".png":  "image/png",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".gif":  "image/gif",
+	".svg":  "image/svg+xml",
+	".webp": "image/webp",
+}
+
+// inlineTextTypes are the extensions of text attachments embedded in the
+// page.
+var inlineTextTypes = map[string]bool{
+	".txt": true, ".log": true, ".json": true, ".xml": true, ".csv": true,
+	".yaml": true, ".yml": true, ".md": true, ".diff": true, ".patch": true,
+}
+
+// renderAttachment embeds a small image or text attachment, and links any
+// other or any that cannot be read.
+func renderAttachment(ta testattachment.TestAttachment) htmlAttachment {
+	a := htmlAttachment{Name: filepath.Base(ta.FilePath), Description: ta.Description, Size: ta.FileSize}
+
+	ext := strings.ToLower(filepath.Ext(ta.FilePath))
+	mediaType, image := inlineImageTypes[ext]
+	if (image || inlineTextTypes[ext]) && ta.FileSize <= MaxInlineAttachment {
+		if data, err := os.ReadFile(ta.FilePath); err == nil && len(data) <= MaxInlineAttachment {
+			if image {
+				a.Image = template.URL("data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data))
+			} else {
+				a.Text = string(data)
+			}
+			return a
+		}
+	}
+
+	path := ta.FilePath
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	a.Link = template.URL((&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String())
+	return a
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font: 14px/1.4 system-ui, sans-serif; margin: 2em auto; max-width: 72em; padding: 0 1em; color: #222; }
+h1 { margin-bottom: 0.2em; }
+.generated { color: #666; margin-top: 0; }
+.summary { display: flex; gap: 1em; margin: 1.5em 0; }
+.count { border-radius: 6px; padding: 0.6em 1.2em; background: #f3f3f3; }
+.count strong { display: block; font-size: 1.6em; }
+.passed { color: #1a7f37; }
+.failed { color: #cf222e; }
+.skipped { color: #9a6700; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.4em 0.6em; border-bottom: 1px solid #e5e5e5; vertical-align: top; }
+td.time { white-space: nowrap; text-align: right; }
+.bar { background: #e5e5e5; height: 0.9em; min-width: 1px; margin-top: 0.25em; }
+.bar.failed { background: #f0a8ad; }
+.bar.passed { background: #9fd8ad; }
+.bar.skipped { background: #ebd38a; }
+details { margin-top: 0.4em; }
+summary { cursor: pointer; color: #555; }
+pre { background: #f6f8fa; padding: 0.8em; overflow-x: auto; font: 12px/1.45 ui-monospace, monospace; }
+pre span { display: block; white-space: pre; }
+.del { background: #ffebe9; }
+.ins { background: #dafbe1; }
+.hunk { color: #0550ae; }
+img { max-width: 100%; border: 1px solid #e5e5e5; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="generated">Generated {{.Generated}}</p>
+
+<div class="summary">
+<div class="count"><strong>{{.Total}}</strong>tests</div>
+{{- range .Counts}}
+<div class="count {{.Class}}"><strong>{{.Count}}</strong>{{.Status}}</div>
+{{- end}}
+<div class="count"><strong>{{.Elapsed}}</strong>in tests</div>
+</div>
+
+<h2>Tests</h2>
+<table>
+<tr><th>Test</th><th>Status</th><th>Time</th></tr>
+{{- range .Tests}}
+<tr>
+<td>{{.Name}}
+{{- range .Messages}}
+<details{{if eq .Destination "failure"}} open{{end}}><summary>{{.Destination}}</summary><pre>{{range .Lines}}<span{{with .Class}} class="{{.}}"{{end}}>{{.Text}}</span>{{end}}</pre></details>
+{{- end}}
+</td>
+<td class="{{.Class}}">{{.Status}}</td>
+<td class="time">{{.Duration}}<div class="bar {{.Class}}" style="width: {{.Width}}%"></div></td>
+</tr>
+{{- end}}
+</table>
+{{- if .Messages}}
+
+<h2>Messages</h2>
+{{- range .Messages}}
+<details open><summary>{{.Destination}}</summary><pre>{{range .Lines}}<span{{with .Class}} class="{{.}}"{{end}}>{{.Text}}</span>{{end}}</pre></details>
+{{- end}}
+{{- end}}
+{{- if .Attachments}}
+
+<h2>Attachments</h2>
+{{- range .Attachments}}
+<h3>{{.Name}}</h3>
+{{- with .Description}}
+<p>{{.}}</p>
+{{- end}}
+{{- if .Image}}
+<img src="{{.Image}}" alt="{{.Name}}">
+{{- else if .Text}}
+<pre>{{.Text}}</pre>
+{{- else}}
+<p><a href="{{.Link}}">{{.Name}}</a> ({{.Size}} bytes)</p>
+{{- end}}
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
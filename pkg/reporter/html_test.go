@@ -0,0 +1,124 @@
+package reporter
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+)
+
+func TestHTMLReporterPage(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewHTMLReporter(&buf, "Checkout <service>")
+
+	r.ReportTestOutput(testoutput.NewTestOutput("20ms", "Passed", "id-1", "TestAdd", "Passed"))
+	r.ReportTestOutput(testoutput.NewTestOutput("40ms", "Failed", "id-2", "TestRemove", "Failed"))
+	r.ReportTestOutput(testoutput.NewTestOutput("0s", "Skipped", "id-3", "TestRefund", "Skipped"))
+	r.ReportTestMessage(testmessage.NewTestMessage(FailureDestination, "values differ\n  context:\n    - line <two>\n    + line 2", "id-2"))
+	r.ReportTestMessage(testmessage.NewTestMessage("stdout", "seeded 3 carts", ""))
+
+	if buf.Len() != 0 {
+		t.Fatalf("Expected nothing to be written before Close, got %q", buf.String())
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	page := buf.String()
+
+	for _, want := range []string{
+		"<title>Checkout &lt;service&gt;</title>",
+		"<strong>3</strong>tests",
+		`<div class="count passed"><strong>1</strong>Passed</div>`,
+		`<div class="count failed"><strong>1</strong>Failed</div>`,
+		`<div class="count skipped"><strong>1</strong>Skipped</div>`,
+		`<details open><summary>failure</summary>`,
+		`<span class="del">    - line &lt;two&gt;</span>`,
+		`<span class="ins">    &#43; line 2</span>`,
+		`<td class="time">40ms<div class="bar failed" style="width: 100%"></div></td>`,
+		`<td class="time">20ms<div class="bar passed" style="width: 50%"></div></td>`,
+		"<h2>Messages</h2>",
+		"seeded 3 carts",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected the page to contain %q, got:\n%s", want, page)
+		}
+	}
+
+	// The failure is shown with its test, between its name and its status.
+	name, failure, status := strings.Index(page, "TestRemove"), strings.Index(page, "values differ"), strings.Index(page, `<td class="failed">Failed</td>`)
+	if !(name < failure && failure < status) {
+		t.Errorf("Expected the failure message within TestRemove's row, got:\n%s", page)
+	}
+	if strings.Contains(page, "<script") || strings.Contains(page, "http://") || strings.Contains(page, "https://") {
+		t.Errorf("Expected a self-contained page, got:\n%s", page)
+	}
+}
+
+func TestHTMLReporterAttachments(t *testing.T) {
+	dir := t.TempDir()
+	attach := func(name string, data []byte) testattachment.TestAttachment {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		ta, err := testattachment.NewTestAttachment(path, "the "+name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ta
+	}
+
+	var buf bytes.Buffer
+	r := NewHTMLReporter(&buf, "Attachments")
+	r.ReportTestAttachment(attach("screenshot.png", []byte("\x89PNG")))
+	r.ReportTestAttachment(attach("server.log", []byte("listening on :8080")))
+	r.ReportTestAttachment(attach("dump.bin", []byte{0, 1, 2}))
+	r.ReportTestAttachment(attach("large.log", bytes.Repeat([]byte("x"), MaxInlineAttachment+1)))
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	page := buf.String()
+
+	for _, want := range []string{
+		`<img src="data:image/png;base64,iVBORw==" alt="screenshot.png">`,
+		"<pre>listening on :8080</pre>",
+		"<p>the server.log</p>",
+		`<a href="file://`,
+		`/dump.bin">dump.bin</a> (3 bytes)`,
+		`/large.log">large.log</a>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected the page to contain %q, got:\n%s", want, page)
+		}
+	}
+}
+
+func TestHTMLReporterCloseClosesWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewHTMLReporter(file, "Run")
+	r.ReportTestOutput(testoutput.NewTestOutput("1ms", "Errored", "id-1", "TestSetup", "Errored"))
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `<div class="count failed"><strong>1</strong>Errored</div>`) {
+		t.Errorf("Expected the page to count the errored test, got:\n%s", data)
+	}
+	if err := file.Close(); err == nil {
+		t.Errorf("Expected Close to have closed the file")
+	}
+}
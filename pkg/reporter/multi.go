@@ -0,0 +1,82 @@
+package reporter
+
+import (
+	"errors"
+	"fmt"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+)
+
+// MultiReporter passes every call on to several reporters, so that one run
+// can produce, for example, console output, an HTML page and a JSON stream.
+// A reporter that returns an error does not stop the others: each call is
+// made on every reporter, and the errors are returned together.
+type MultiReporter struct {
+	reporters []ReporterInterface
+}
+
+// NewMultiReporter creates a MultiReporter passing calls on to reporters, in
+// order.
+//
+// Example:
+//
+//	rep := reporter.NewMultiReporter(
+//		reporter.NewTAPReporter(os.Stdout),
+//		reporter.NewHTMLReporter(htmlFile, "Checkout service"),
+//		reporter.NewNDJSONReporter(jsonFile, "example.com/shop/checkout"),
+//	)
+//	defer rep.Close()
+//	tr := testrunner.NewTestRunner(t, logger, true, rep)
+func NewMultiReporter(reporters ...ReporterInterface) *MultiReporter {
+	return &MultiReporter{reporters: reporters}
+}
+
+// ReportTestStart passes the start of a test on to the reporters that
+// implement StartReporter.
+// The method returns the errors of the reporters that failed, joined.
+func (m *MultiReporter) ReportTestStart(testName string) error {
+	return m.each(func(r ReporterInterface) error {
+		if starter, ok := r.(StartReporter); ok {
+			return starter.ReportTestStart(testName)
+		}
+		return nil
+	})
+}
+
+// ReportTestOutput passes test output on to every reporter.
+// The method returns the errors of the reporters that failed, joined.
+func (m *MultiReporter) ReportTestOutput(to testoutput.TestOutput) error {
+	return m.each(func(r ReporterInterface) error { return r.ReportTestOutput(to) })
+}
+
+// ReportTestMessage passes a test message on to every reporter.
+// The method returns the errors of the reporters that failed, joined.
+func (m *MultiReporter) ReportTestMessage(tm testmessage.TestMessage) error {
+	return m.each(func(r ReporterInterface) error { return r.ReportTestMessage(tm) })
+}
+
+// ReportTestAttachment passes a test attachment on to every reporter.
+// The method returns the errors of the reporters that failed, joined.
+func (m *MultiReporter) ReportTestAttachment(ta testattachment.TestAttachment) error {
+	return m.each(func(r ReporterInterface) error { return r.ReportTestAttachment(ta) })
+}
+
+// Close closes every reporter, even if closing one fails.
+// The method returns the errors of the reporters that failed, joined.
+func (m *MultiReporter) Close() error {
+	return m.each(func(r ReporterInterface) error { return r.Close() })
+}
+
+// each calls fn on every reporter and joins the errors, each naming the
+// reporter that returned it.
+func (m *MultiReporter) each(fn func(r ReporterInterface) error) error {
+	var errs []error
+	for i, r := range m.reporters {
+		if err := fn(r); err != nil {
+			errs = append(errs, fmt.Errorf("reporter %d (%T): %w", i, r, err))
+		}
+	}
+	return errors.Join(errs...)
+}
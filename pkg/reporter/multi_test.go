@@ -0,0 +1,62 @@
+package reporter
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+)
+
+// failingReporter returns err from every call and counts the calls.
+type failingReporter struct {
+	err    error
+	calls  int
+	closed bool
+}
+
+func (f *failingReporter) ReportTestOutput(testoutput.TestOutput) error { f.calls++; return f.err }
+func (f *failingReporter) ReportTestMessage(testmessage.TestMessage) error {
+	f.calls++
+	return f.err
+}
+func (f *failingReporter) ReportTestAttachment(testattachment.TestAttachment) error {
+	f.calls++
+	return f.err
+}
+func (f *failingReporter) Close() error { f.closed = true; return f.err }
+
+func TestMultiReporterFansOut(t *testing.T) {
+	var tap, ndjson bytes.Buffer
+	broken := &failingReporter{err: errors.New("disk full")}
+	m := NewMultiReporter(NewTAPReporter(&tap), broken, NewNDJSONReporter(&ndjson, "example.com/cart"))
+
+	if err := m.ReportTestStart("TestAdd"); err != nil {
+		t.Errorf("Expected reporters without start events to be skipped, got %v", err)
+	}
+	err := m.ReportTestOutput(testoutput.NewTestOutput("1ms", "Passed", "id-1", "TestAdd", "Passed"))
+	if err == nil || !strings.Contains(err.Error(), "reporter 1 (*reporter.failingReporter): disk full") {
+		t.Errorf("Expected the failing reporter's error, naming it, got %v", err)
+	}
+	m.ReportTestMessage(testmessage.NewTestMessage("stdout", "done", ""))
+	m.ReportTestAttachment(testattachment.TestAttachment{FilePath: "/tmp/log.txt"})
+
+	if err := m.Close(); err == nil || !errors.Is(err, broken.err) {
+		t.Errorf("Expected Close to return the failing reporter's error, got %v", err)
+	}
+
+	if broken.calls != 3 || !broken.closed {
+		t.Errorf("Expected every call to reach the failing reporter, got %d calls, closed %v", broken.calls, broken.closed)
+	}
+	if !strings.Contains(tap.String(), "ok 1 - TestAdd\n# stdout: done\n") || !strings.HasSuffix(tap.String(), "1..1\n") {
+		t.Errorf("Expected the TAP reporter to receive every call, got:\n%s", tap.String())
+	}
+	for _, action := range []string{`"Action":"run"`, `"Action":"pass"`, `"Output":"stdout: done\n"`} {
+		if !strings.Contains(ndjson.String(), action) {
+			t.Errorf("Expected the NDJSON reporter to receive %s, got:\n%s", action, ndjson.String())
+		}
+	}
+}
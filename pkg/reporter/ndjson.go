@@ -0,0 +1,169 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+	"gowise/pkg/interfaces/teststatus"
+)
+
+// StartReporter is implemented by reporters that stream an event when a test
+// starts, such as NDJSONReporter. The test runner calls ReportTestStart
+// before running each test if its reporter implements it.
+type StartReporter interface {
+	ReportTestStart(testName string) error
+}
+
+// TestEvent is a line of NDJSONReporter output. Its fields match the events
+// of go test -json, so tools that consume that stream can read it.
+type TestEvent struct {
+	Time    time.Time `json:",omitempty"`
+	Action  string
+	Package string  `json:",omitempty"`
+	Test    string  `json:",omitempty"`
+	Elapsed float64 `json:",omitempty"` // Seconds
+	Output  string  `json:",omitempty"`
+}
+
+// NDJSONReporter writes one JSON event per line as tests run, in the format
+// of go test -json: a "run" event when a test starts, "output" events for its
+// messages, attachments and result line, and a final "pass", "fail" or
+// "skip" event with the elapsed time. Dashboards and tools can ingest the
+// stream while the tests are still running. It is safe for concurrent use.
+type NDJSONReporter struct {
+	mu      sync.Mutex
+	writer  io.Writer
+	pkg     string
+	started map[string]bool
+	names   map[string]string // Test names by test ID, for messages
+}
+
+// NewNDJSONReporter creates an NDJSONReporter writing to w. pkg is recorded
+// as the Package of every event, normally the import path of the package
+// under test.
+//
+// Example:
+//
+//	rep := reporter.NewNDJSONReporter(os.Stdout, "example.com/shop/cart")
+//	tr := testrunner.NewTestRunner(t, logger, true, rep)
+func NewNDJSONReporter(w io.Writer, pkg string) *NDJSONReporter {
+	return &NDJSONReporter{
+		writer:  w,
+		pkg:     pkg,
+		started: make(map[string]bool),
+		names:   make(map[string]string),
+	}
+}
+
+// ReportTestStart writes a "run" event for testName.
+// The method returns an error if the event could not be written.
+func (r *NDJSONReporter) ReportTestStart(testName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.started[testName] = true
+	return r.emit(
+		TestEvent{Action: "run", Test: testName},
+		TestEvent{Action: "output", Test: testName, Output: fmt.Sprintf("=== RUN   %s\n", testName)},
+	)
+}
+
+// ReportTestOutput writes the result of a test: a "run" event if
+// ReportTestStart was not called for it, an "output" event with the result
+// line, and a "pass", "fail" or "skip" event. Errored tests are reported as
+// failures.
+// The method returns an error if the events could not be written.
+func (r *NDJSONReporter) ReportTestOutput(to testoutput.TestOutput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var events []TestEvent
+	if !r.started[to.TestName] {
+		events = append(events,
+			TestEvent{Action: "run", Test: to.TestName},
+			TestEvent{Action: "output", Test: to.TestName, Output: fmt.Sprintf("=== RUN   %s\n", to.TestName)})
+	}
+	delete(r.started, to.TestName)
+	if to.TestID != "" {
+		r.names[to.TestID] = to.TestName
+	}
+
+	// The runner records the test's duration as the output text.
+	var elapsed float64
+	if d, err := time.ParseDuration(to.Text); err == nil {
+		elapsed = d.Seconds()
+	}
+
+	action := "fail"
+	switch to.Status {
+	case teststatus.Passed.GetResult():
+		action = "pass"
+	case teststatus.Skipped.GetResult():
+		action = "skip"
+	}
+
+	events = append(events,
+		TestEvent{Action: "output", Test: to.TestName, Output: fmt.Sprintf("--- %s: %s (%.2fs)\n", actionLabels[action], to.TestName, elapsed)},
+		TestEvent{Action: action, Test: to.TestName, Elapsed: elapsed})
+	return r.emit(events...)
+}
+
+// actionLabels are the result lines go test prints for each final action.
+var actionLabels = map[string]string{"pass": "PASS", "fail": "FAIL", "skip": "SKIP"}
+
+// ReportTestMessage writes an "output" event with the message, attributed
+// to its test if the test's output has been reported.
+// The method returns an error if the event could not be written.
+func (r *NDJSONReporter) ReportTestMessage(tm testmessage.TestMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.emit(TestEvent{Action: "output", Test: r.names[tm.TestID], Output: tm.ToString() + "\n"})
+}
+
+// ReportTestAttachment writes an "output" event describing the attachment.
+// The method returns an error if the event could not be written.
+func (r *NDJSONReporter) ReportTestAttachment(ta testattachment.TestAttachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	output := fmt.Sprintf("attachment: %s (%d bytes)", ta.FilePath, ta.FileSize)
+	if ta.Description != "" {
+		output += ": " + ta.Description
+	}
+	return r.emit(TestEvent{Action: "output", Output: output + "\n"})
+}
+
+// Close closes the writer if it implements the io.Closer interface.
+// The method returns an error if the writer could not be closed.
+func (r *NDJSONReporter) Close() error {
+	if closer, ok := r.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// emit writes events, one per line, stamped with the current time and the
+// package. The caller must hold r.mu.
+func (r *NDJSONReporter) emit(events ...TestEvent) error {
+	now := time.Now()
+	for _, event := range events {
+		event.Time = now
+		event.Package = r.pkg
+
+		line, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("error encoding test event: %w", err)
+		}
+		if _, err := r.writer.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+)
+
+// decodeEvents parses NDJSON output, failing the test on malformed lines.
+func decodeEvents(t *testing.T, output string) []TestEvent {
+	t.Helper()
+	var events []TestEvent
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		var event TestEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("Expected a JSON event per line, got %q: %v", line, err)
+		}
+		if event.Time.IsZero() || event.Package != "example.com/cart" {
+			t.Fatalf("Expected every event to carry a time and package, got %+v", event)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// describeEvents renders events compactly for comparison.
+func describeEvents(events []TestEvent) string {
+	var lines []string
+	for _, e := range events {
+		line := e.Action + " " + e.Test
+		if e.Output != "" {
+			line += " " + strings.TrimSuffix(e.Output, "\n")
+		}
+		if e.Elapsed != 0 {
+			line += fmt.Sprintf(" %.2f", e.Elapsed)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestNDJSONReporterTestLifecycle(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewNDJSONReporter(&buf, "example.com/cart")
+
+	if err := r.ReportTestStart("TestAdd"); err != nil {
+		t.Fatalf("ReportTestStart failed: %v", err)
+	}
+	if err := r.ReportTestMessage(testmessage.NewTestMessage("stdout", "adding item", "id-1")); err != nil {
+		t.Fatalf("ReportTestMessage failed: %v", err)
+	}
+	if err := r.ReportTestOutput(testoutput.NewTestOutput("1.5s", "Passed", "id-1", "TestAdd", "Passed")); err != nil {
+		t.Fatalf("ReportTestOutput failed: %v", err)
+	}
+	if err := r.ReportTestMessage(testmessage.NewTestMessage("stderr", "late message", "id-1")); err != nil {
+		t.Fatalf("ReportTestMessage failed: %v", err)
+	}
+
+	want := strings.Join([]string{
+		"run TestAdd",
+		"output TestAdd === RUN   TestAdd",
+		"output  stdout: adding item",
+		"output TestAdd --- PASS: TestAdd (1.50s)",
+		"pass TestAdd 1.50",
+		"output TestAdd stderr: late message",
+	}, "\n")
+	if got := describeEvents(decodeEvents(t, buf.String())); got != want {
+		t.Errorf("Expected events:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestNDJSONReporterResults(t *testing.T) {
+	tests := []struct {
+		status string
+		want   string
+	}{
+		{"Passed", "run TestX\noutput TestX === RUN   TestX\noutput TestX --- PASS: TestX (0.25s)\npass TestX 0.25"},
+		{"Failed", "run TestX\noutput TestX === RUN   TestX\noutput TestX --- FAIL: TestX (0.25s)\nfail TestX 0.25"},
+		{"Errored", "run TestX\noutput TestX === RUN   TestX\noutput TestX --- FAIL: TestX (0.25s)\nfail TestX 0.25"},
+		{"Skipped", "run TestX\noutput TestX === RUN   TestX\noutput TestX --- SKIP: TestX (0.25s)\nskip TestX 0.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			var buf bytes.Buffer
+			r := NewNDJSONReporter(&buf, "example.com/cart")
+			if err := r.ReportTestOutput(testoutput.NewTestOutput("250ms", tt.status, "id", "TestX", tt.status)); err != nil {
+				t.Fatalf("ReportTestOutput failed: %v", err)
+			}
+			if got := describeEvents(decodeEvents(t, buf.String())); got != tt.want {
+				t.Errorf("Expected events:\n%s\ngot:\n%s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNDJSONReporterAttachment(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewNDJSONReporter(&buf, "example.com/cart")
+	attachment := testattachment.TestAttachment{FilePath: "/tmp/cart.png", Description: "screenshot", FileSize: 2048}
+
+	if err := r.ReportTestAttachment(attachment); err != nil {
+		t.Fatalf("ReportTestAttachment failed: %v", err)
+	}
+
+	want := "output  attachment: /tmp/cart.png (2048 bytes): screenshot"
+	if got := describeEvents(decodeEvents(t, buf.String())); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func ExampleNDJSONReporter() {
+	var buf bytes.Buffer
+	r := NewNDJSONReporter(&buf, "example.com/cart")
+	r.ReportTestOutput(testoutput.NewTestOutput("10ms", "Passed", "id", "TestAdd", "Passed"))
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event TestEvent
+		json.Unmarshal([]byte(line), &event)
+		fmt.Println(event.Action, event.Test)
+	}
+	// Output:
+	// run TestAdd
+	// output TestAdd
+	// output TestAdd
+	// pass TestAdd
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"gowise/pkg/interfaces/testattachment"
 	"gowise/pkg/interfaces/testmessage"
@@ -74,13 +75,22 @@ func (r *Reporter) Close() error {
 // TestReport represents a test report.
 // Total is the total number of tests.
 // Passed is the number of tests that passed.
-// Failed is the number of tests that failed.
+// Failed is the number of tests that failed or errored.
+// Skipped is the number of tests that were skipped.
 // Results is a slice of the results of all tests.
+// Durations holds the wall-clock duration of each result, when known.
+// Elapsed is the wall-clock time from the first test starting to the last finishing; with tests run in parallel it is less than the sum of Durations.
+// Metadata holds free-form key/value pairs describing the run, such as
+// assertion statistics.
 type TestReport struct {
-	Total   int
-	Passed  int
-	Failed  int
-	Results []teststatus.TestStatus
+	Total     int
+	Passed    int
+	Failed    int
+	Skipped   int
+	Results   []teststatus.TestStatus
+	Durations []time.Duration
+	Elapsed   time.Duration
+	Metadata  map[string]string
 }
 
 // NewTestReport creates a new TestReport.
@@ -96,17 +106,70 @@ func NewTestReport() *TestReport {
 
 // AddResult adds a test result to the report.
 // result is the result of a test.
-// The method increments Total by 1, increments Passed by 1 if the test passed, increments Failed by 1 if the test failed, and appends the result to Results.
+// The method increments Total by 1, increments Passed by 1 if the test passed, Skipped by 1 if it was skipped, and Failed by 1 otherwise, and appends the result to Results.
 func (r *TestReport) AddResult(result teststatus.TestStatus) {
 	r.Total++
-	if result.GetResult() == teststatus.Passed.GetResult() {
+	switch result.GetResult() {
+	case teststatus.Passed.GetResult():
 		r.Passed++
-	} else {
+	case teststatus.Skipped.GetResult():
+		r.Skipped++
+	default:
 		r.Failed++
 	}
 	r.Results = append(r.Results, result)
 }
 
+// Summary returns a one-line summary of the report, with its elapsed time and, when it differs, the total time spent in tests.
+//
+// Example:
+//
+//	fmt.Println(report.Summary())
+//	// 12 tests: 10 passed, 1 failed, 1 skipped in 1.2s (3.9s in tests)
+func (r *TestReport) Summary() string {
+	var total time.Duration
+	for _, d := range r.Durations {
+		total += d
+	}
+
+	summary := fmt.Sprintf("%d tests: %d passed, %d failed, %d skipped in %s", r.Total, r.Passed, r.Failed, r.Skipped, r.Elapsed)
+	if total != r.Elapsed {
+		summary += fmt.Sprintf(" (%s in tests)", total)
+	}
+	return summary
+}
+
+// AddMetadata records a key/value pair describing the run, replacing any
+// existing value for key.
+//
+// Example:
+//
+//	for key, value := range assertions.GlobalStats().Metadata() {
+//		report.AddMetadata(key, value)
+//	}
+func (r *TestReport) AddMetadata(key, value string) {
+	if r.Metadata == nil {
+		r.Metadata = make(map[string]string)
+	}
+	r.Metadata[key] = value
+}
+
+// FailureDestination is the destination of the TestMessage through which the
+// test runner reports the first failed assertion of a failing test. The
+// message's TestID is that of the test's output.
+const FailureDestination = "failure"
+
+// SkipDestination is the destination of the TestMessage through which the
+// test runner reports why a test was skipped. The message's TestID is that
+// of the test's output.
+const SkipDestination = "skip"
+
+// StatsDestination is the destination of the TestMessage through which the
+// test runner reports a test's assertion statistics, as summarised by
+// assertions.Stats.Summary, when created with testrunner.ReportAssertionStats.
+// The message's TestID is that of the test's output.
+const StatsDestination = "assertion-stats"
+
 // ReporterInterface represents the interface for a reporter.
 // It includes methods for reporting a TestOutput, a TestMessage, and a TestAttachment, and for closing the reporter.
 type ReporterInterface interface {
@@ -6,10 +6,12 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"gowise/pkg/interfaces/testattachment"
 	"gowise/pkg/interfaces/testmessage"
 	"gowise/pkg/interfaces/testoutput"
+	"gowise/pkg/interfaces/teststatus"
 )
 
 func TestNewReporter(t *testing.T) {
@@ -85,3 +87,43 @@ func TestReportTestAttachment(t *testing.T) {
 		t.Fatalf("Expected file to contain test attachment, got %s", content)
 	}
 }
+
+func TestTestReportAddMetadata(t *testing.T) {
+	report := NewTestReport()
+	report.AddMetadata("assertions.total", "3")
+	report.AddMetadata("assertions.total", "4")
+
+	if got := report.Metadata["assertions.total"]; got != "4" {
+		t.Fatalf("Expected latest metadata value 4, got %q", got)
+	}
+}
+
+func TestTestReportAddResult(t *testing.T) {
+	report := NewTestReport()
+	for _, result := range []teststatus.Result{teststatus.Passed, teststatus.Failed, teststatus.Errored, teststatus.Skipped, teststatus.Passed} {
+		report.AddResult(result)
+	}
+
+	if report.Total != 5 || report.Passed != 2 || report.Failed != 2 || report.Skipped != 1 {
+		t.Fatalf("Expected 5 total, 2 passed, 2 failed and 1 skipped, got %+v", report)
+	}
+}
+
+func TestTestReportSummary(t *testing.T) {
+	report := NewTestReport()
+	report.AddResult(teststatus.Passed)
+	report.AddResult(teststatus.Failed)
+	report.Durations = []time.Duration{300 * time.Millisecond, 500 * time.Millisecond}
+	report.Elapsed = 500 * time.Millisecond
+
+	want := "2 tests: 1 passed, 1 failed, 0 skipped in 500ms (800ms in tests)"
+	if got := report.Summary(); got != want {
+		t.Fatalf("Expected summary %q, got %q", want, got)
+	}
+
+	report.Elapsed = 800 * time.Millisecond
+	want = "2 tests: 1 passed, 1 failed, 0 skipped in 800ms"
+	if got := report.Summary(); got != want {
+		t.Fatalf("Expected summary %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,232 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+	"gowise/pkg/interfaces/teststatus"
+)
+
+// TAPReporter writes results in TAP version 13, the Test Anything Protocol,
+// for consumers such as prove and tap-junit. Each test is a numbered "ok" or
+// "not ok" line; a test that did not pass is followed by a YAML diagnostic
+// block with its status, duration and messages, including the runner's
+// failure message. Skipped tests carry a SKIP directive with the runner's
+// reason, and tests marked with Todo a TODO directive. The plan line,
+// "1..N", is written by Close.
+// It is safe for concurrent use.
+type TAPReporter struct {
+	mu      sync.Mutex
+	writer  io.Writer
+	count   int
+	started bool
+	pending *tapTest // The last test, held until its messages are reported
+	todo    map[string]string
+}
+
+// tapTest is a test whose line and diagnostics have not yet been written.
+type tapTest struct {
+	number   int
+	id       string
+	name     string
+	status   string
+	duration string
+	messages []testmessage.TestMessage
+}
+
+// NewTAPReporter creates a TAPReporter writing to w.
+//
+// Example:
+//
+//	rep := reporter.NewTAPReporter(os.Stdout)
+//	defer rep.Close()
+//	tr := testrunner.NewTestRunner(t, logger, true, rep)
+func NewTAPReporter(w io.Writer) *TAPReporter {
+	return &TAPReporter{writer: w, todo: make(map[string]string)}
+}
+
+// Todo marks the test named testName as a known failure, such as the
+// reproduction of an open bug: its line carries a TODO directive with the
+// reason, so TAP consumers do not count it as failing. Call it before the
+// test's result is reported.
+func (r *TAPReporter) Todo(testName, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.todo[testName] = reason
+}
+
+// ReportTestOutput writes the line for a test. The line of a test that did
+// not pass is held until the next result or Close, so that messages reported
+// for it are written in its diagnostic block.
+// The method returns an error if output could not be written.
+func (r *TAPReporter) ReportTestOutput(to testoutput.TestOutput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.flush(); err != nil {
+		return err
+	}
+	if !r.started {
+		r.started = true
+		if _, err := io.WriteString(r.writer, "TAP version 13\n"); err != nil {
+			return err
+		}
+	}
+
+	r.count++
+	r.pending = &tapTest{number: r.count, id: to.TestID, name: to.TestName, status: to.Status, duration: to.Text}
+	if to.Status == teststatus.Passed.GetResult() {
+		return r.flush()
+	}
+	return nil
+}
+
+// ReportTestMessage adds a message to the diagnostic block of the held test
+// if its TestID matches, and otherwise writes it as a comment.
+// The method returns an error if the comment could not be written.
+func (r *TAPReporter) ReportTestMessage(tm testmessage.TestMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pending != nil && tm.TestID != "" && tm.TestID == r.pending.id {
+		r.pending.messages = append(r.pending.messages, tm)
+		return nil
+	}
+	return r.comment(tm.ToString())
+}
+
+// ReportTestAttachment writes a comment describing the attachment.
+// The method returns an error if the comment could not be written.
+func (r *TAPReporter) ReportTestAttachment(ta testattachment.TestAttachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	text := fmt.Sprintf("attachment: %s (%d bytes)", ta.FilePath, ta.FileSize)
+	if ta.Description != "" {
+		text += ": " + ta.Description
+	}
+	return r.comment(text)
+}
+
+// Close writes the held test and the plan, and closes the writer if it
+// implements the io.Closer interface.
+// The method returns an error if output could not be written or the writer
+// could not be closed.
+func (r *TAPReporter) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.flush(); err != nil {
+		return err
+	}
+	var b strings.Builder
+	if !r.started {
+		b.WriteString("TAP version 13\n")
+	}
+	fmt.Fprintf(&b, "1..%d\n", r.count)
+	if _, err := io.WriteString(r.writer, b.String()); err != nil {
+		return err
+	}
+
+	if closer, ok := r.writer.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// flush writes the held test, if any. The caller must hold r.mu.
+func (r *TAPReporter) flush() error {
+	test := r.pending
+	if test == nil {
+		return nil
+	}
+	r.pending = nil
+
+	var b strings.Builder
+	passed := test.status == teststatus.Passed.GetResult()
+	skipped := test.status == teststatus.Skipped.GetResult()
+	if passed || skipped {
+		b.WriteString("ok")
+	} else {
+		b.WriteString("not ok")
+	}
+	fmt.Fprintf(&b, " %d - %s", test.number, tapEscape(test.name))
+	if reason, ok := r.todo[test.name]; ok {
+		b.WriteString(" # TODO")
+		if reason != "" {
+			b.WriteString(" " + tapEscape(reason))
+		}
+	} else if skipped {
+		b.WriteString(" # SKIP")
+		for _, tm := range test.messages {
+			if tm.Destination == SkipDestination {
+				b.WriteString(" " + tapEscape(tm.Message))
+				break
+			}
+		}
+	}
+	b.WriteString("\n")
+
+	if !passed && !skipped {
+		b.WriteString("  ---\n")
+		fmt.Fprintf(&b, "  status: %s\n", yamlQuote(test.status))
+		if d, err := time.ParseDuration(test.duration); err == nil {
+			fmt.Fprintf(&b, "  duration_ms: %g\n", float64(d)/float64(time.Millisecond))
+		}
+		if len(test.messages) > 0 {
+			b.WriteString("  data:\n")
+			for _, tm := range test.messages {
+				fmt.Fprintf(&b, "    %s: |-\n", yamlQuote(tm.Destination))
+				for _, line := range strings.Split(tm.Message, "\n") {
+					b.WriteString("      " + line + "\n")
+				}
+			}
+		}
+		b.WriteString("  ...\n")
+	}
+
+	_, err := io.WriteString(r.writer, b.String())
+	return err
+}
+
+// comment writes text as TAP comment lines, after any held test. The caller
+// must hold r.mu.
+func (r *TAPReporter) comment(text string) error {
+	if err := r.flush(); err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString("# " + line + "\n")
+	}
+	_, err := io.WriteString(r.writer, b.String())
+	return err
+}
+
+// tapEscape makes s safe for a test line: on one line, with "#" escaped so
+// that it does not start a directive.
+func tapEscape(s string) string {
+	return strings.NewReplacer("\r\n", " ", "\n", " ", "\\", "\\\\", "#", "\\#").Replace(s)
+}
+
+// yamlQuote quotes s as a YAML double-quoted scalar if it is not a plain one.
+func yamlQuote(s string) string {
+	plain := s != ""
+	for _, c := range s {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == ' ' || c == '_' || c == '-' || c == '.') {
+			plain = false
+			break
+		}
+	}
+	if plain && s[0] != ' ' && s[len(s)-1] != ' ' && s[0] != '-' {
+		return s
+	}
+	return fmt.Sprintf("%q", s)
+}
@@ -0,0 +1,68 @@
+package reporter
+
+import (
+	"bytes"
+	"testing"
+
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+)
+
+func TestTAPReporterStream(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewTAPReporter(&buf)
+	r.Todo("TestRefund", "refunds not implemented")
+
+	r.ReportTestOutput(testoutput.NewTestOutput("20ms", "Passed", "id-1", "TestAdd", "Passed"))
+	r.ReportTestOutput(testoutput.NewTestOutput("1.5ms", "Failed", "id-2", "TestRemove #2", "Failed"))
+	r.ReportTestMessage(testmessage.NewTestMessage(FailureDestination, "values differ\n  got:  1\n  want: 2", "id-2"))
+	r.ReportTestOutput(testoutput.NewTestOutput("0s", "Skipped", "id-3", "TestDiscount", "Skipped"))
+	r.ReportTestMessage(testmessage.NewTestMessage(SkipDestination, "discounts disabled", "id-3"))
+	r.ReportTestMessage(testmessage.NewTestMessage("stdout", "seeded 3 carts", ""))
+	r.ReportTestOutput(testoutput.NewTestOutput("2ms", "Failed", "id-4", "TestRefund", "Failed"))
+	r.ReportTestOutput(testoutput.NewTestOutput("3ms", "Errored", "id-5", "TestCheckout", "Errored"))
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	want := `TAP version 13
+ok 1 - TestAdd
+not ok 2 - TestRemove \#2
+  ---
+  status: Failed
+  duration_ms: 1.5
+  data:
+    failure: |-
+      values differ
+        got:  1
+        want: 2
+  ...
+ok 3 - TestDiscount # SKIP discounts disabled
+# stdout: seeded 3 carts
+not ok 4 - TestRefund # TODO refunds not implemented
+  ---
+  status: Failed
+  duration_ms: 2
+  ...
+not ok 5 - TestCheckout
+  ---
+  status: Errored
+  duration_ms: 3
+  ...
+1..5
+`
+	if got := buf.String(); got != want {
+		t.Errorf("Expected TAP stream:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestTAPReporterEmptyRun(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewTAPReporter(&buf)
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if got := buf.String(); got != "TAP version 13\n1..0\n" {
+		t.Errorf("Expected a version and empty plan, got %q", got)
+	}
+}
@@ -0,0 +1,271 @@
+// Package suite runs xUnit-style test suites: structs whose TestXxx methods
+// are the tests, with optional methods to set up and tear down the suite
+// and each test.
+//
+// A suite is run by a testrunner.TestRunner, so its tests are reported,
+// filtered and timed as other runner tests are, and each test method gets
+// an *assertions.Assert of its own.
+//
+// Example:
+//
+//	type OrderSuite struct {
+//		db *sql.DB
+//	}
+//
+//	func (s *OrderSuite) SetupSuite() (err error) {
+//		s.db, err = startDatabase()
+//		return err
+//	}
+//
+//	func (s *OrderSuite) TearDownSuite() error { return s.db.Close() }
+//
+//	func (s *OrderSuite) TestCreate(assert *assertions.Assert) {
+//		_, err := CreateOrder(s.db, "SKU-1")
+//		assert.NoError(err)
+//	}
+//
+//	func TestOrders(t *testing.T) {
+//		suite.Run(t, &OrderSuite{})
+//	}
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/logging"
+	"gowise/pkg/reporter"
+	"gowise/pkg/testrunner"
+)
+
+// Option configures how Run runs a suite.
+type Option func(*config)
+
+// config holds the settings of a suite run.
+type config struct {
+	logger     logging.LoggerInterface
+	reporter   reporter.ReporterInterface
+	runnerOpts []testrunner.Option
+}
+
+// WithReporter reports the suite's tests to rep. By default results are
+// reported only through the testing context.
+func WithReporter(rep reporter.ReporterInterface) Option {
+	return func(c *config) { c.reporter = rep }
+}
+
+// WithLogger logs the suite's results to logger. By default nothing is
+// logged.
+func WithLogger(logger logging.LoggerInterface) Option {
+	return func(c *config) { c.logger = logger }
+}
+
+// WithRunnerOptions configures the suite's TestRunner, for example with
+// testrunner.WithTimeout or testrunner.ExcludeTags.
+func WithRunnerOptions(opts ...testrunner.Option) Option {
+	return func(c *config) { c.runnerOpts = append(c.runnerOpts, opts...) }
+}
+
+// Run runs the tests of suite s, which is normally a pointer to a struct,
+// as subtests of t.
+//
+// Every exported method of s named like a test function, TestXxx, is a
+// test. It takes an *assertions.Assert and may return a
+// teststatus.TestStatus; without one, or if it returns nil, the test passes
+// unless an assertion failed. Methods are run in name order.
+//
+// s may also have any of these methods, each taking no arguments and
+// returning nothing or an error:
+//   - SetupSuite, run once before the first test
+//   - TearDownSuite, run once after the last test, even if a test panics
+//   - SetupTest, run before each test
+//   - TearDownTest, run after each test, however it finishes
+//
+// They become the runner's BeforeAll, AfterAll, BeforeEach and AfterEach
+// hooks, so their failures are reported apart from test failures.
+func Run(t *testing.T, s any, opts ...Option) {
+	t.Helper()
+	run(testingT{t}, s, opts...)
+}
+
+// run runs suite s with a runner on t.
+func run(t testrunner.TestInterface, s any, opts ...Option) {
+	c := &config{logger: discardLogger{}, reporter: discardReporter{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	tests, err := testMethods(s)
+	if err != nil {
+		t.Errorf("suite: %v", err)
+		return
+	}
+	tr := testrunner.NewTestRunner(t, c.logger, true, c.reporter, c.runnerOpts...)
+
+	v := reflect.ValueOf(s)
+	hooks := []struct {
+		name     string
+		register func(fn func() error)
+	}{
+		{"SetupSuite", tr.BeforeAll},
+		{"TearDownSuite", tr.AfterAll},
+		{"SetupTest", func(fn func() error) { tr.BeforeEach(func(string) error { return fn() }) }},
+		{"TearDownTest", func(fn func() error) { tr.AfterEach(func(string) error { return fn() }) }},
+	}
+	for _, hook := range hooks {
+		fn, err := hookMethod(v, hook.name)
+		if err != nil {
+			t.Errorf("suite: %v", err)
+			return
+		}
+		if fn != nil {
+			hook.register(fn)
+		}
+	}
+
+	for _, test := range tests {
+		method := v.MethodByName(test)
+		tr.RunTest(test, func(assert *assertions.Assert) teststatus.TestStatus {
+			out := method.Call([]reflect.Value{reflect.ValueOf(assert)})
+			if len(out) == 1 && !out[0].IsNil() {
+				return out[0].Interface().(teststatus.TestStatus)
+			}
+			if assert.HasFailed() {
+				return teststatus.Failed
+			}
+			return teststatus.Passed
+		})
+	}
+	tr.Finish()
+}
+
+var (
+	assertType = reflect.TypeOf((*assertions.Assert)(nil))
+	statusType = reflect.TypeOf((*teststatus.TestStatus)(nil)).Elem()
+	errorType  = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// testMethods returns the names of the test methods of s, in name order,
+// or an error if one has the wrong signature.
+func testMethods(s any) ([]string, error) {
+	if s == nil {
+		return nil, errors.New("suite is nil")
+	}
+
+	typ := reflect.TypeOf(s)
+	var tests []string
+	for i := range typ.NumMethod() {
+		m := typ.Method(i)
+		if !isTestName(m.Name) {
+			continue
+		}
+		ft := m.Func.Type() // Includes the receiver
+		if ft.NumIn() != 2 || ft.In(1) != assertType || ft.NumOut() > 1 || (ft.NumOut() == 1 && ft.Out(0) != statusType) {
+			return nil, fmt.Errorf("method %s must be func(*assertions.Assert) or func(*assertions.Assert) teststatus.TestStatus, got %s", m.Name, methodSignature(ft))
+		}
+		tests = append(tests, m.Name)
+	}
+	if len(tests) == 0 {
+		return nil, fmt.Errorf("%T has no TestXxx methods", s)
+	}
+	return tests, nil
+}
+
+// hookMethod returns the method of v named name as a hook, nil if v has no
+// such method, or an error if it has the wrong signature.
+func hookMethod(v reflect.Value, name string) (func() error, error) {
+	m := v.MethodByName(name)
+	if !m.IsValid() {
+		return nil, nil
+	}
+
+	ft := m.Type()
+	if ft.NumIn() != 0 || ft.NumOut() > 1 || (ft.NumOut() == 1 && ft.Out(0) != errorType) {
+		return nil, fmt.Errorf("method %s must be func() or func() error, got %s", name, ft)
+	}
+	return func() error {
+		out := m.Call(nil)
+		if len(out) == 1 && !out[0].IsNil() {
+			return out[0].Interface().(error)
+		}
+		return nil
+	}, nil
+}
+
+// isTestName reports whether name is a test name as go test recognises
+// one: "Test" followed by nothing or by a character that is not lower case.
+func isTestName(name string) bool {
+	rest, ok := strings.CutPrefix(name, "Test")
+	if !ok {
+		return false
+	}
+	if rest == "" {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(rest)
+	return !unicode.IsLower(r)
+}
+
+// methodSignature renders the method type ft, whose first parameter is the
+// receiver, without the receiver.
+func methodSignature(ft reflect.Type) string {
+	in := make([]reflect.Type, ft.NumIn()-1)
+	for i := range in {
+		in[i] = ft.In(i + 1)
+	}
+	out := make([]reflect.Type, ft.NumOut())
+	for i := range out {
+		out[i] = ft.Out(i)
+	}
+	return reflect.FuncOf(in, out, ft.IsVariadic()).String()
+}
+
+// testingT adapts a *testing.T to testrunner.TestInterface. Its other
+// methods, such as Helper, FailNow, Skip and Cleanup, are those of the
+// embedded *testing.T.
+type testingT struct {
+	*testing.T
+}
+
+// Run runs f as a subtest of t.
+func (t testingT) Run(name string, f func(t testrunner.TestInterface)) bool {
+	return t.T.Run(name, func(t *testing.T) {
+		f(testingT{t})
+	})
+}
+
+// discardLogger is the default logger of a suite run. It logs nothing, as
+// go test already prints each failure.
+type discardLogger struct{}
+
+// LogInfo does nothing.
+func (discardLogger) LogInfo(string) {}
+
+// LogError does nothing.
+func (discardLogger) LogError(error) {}
+
+// discardReporter is the default reporter of a suite run. It reports
+// nothing.
+type discardReporter struct{}
+
+// ReportTestOutput does nothing.
+func (discardReporter) ReportTestOutput(testoutput.TestOutput) error { return nil }
+
+// ReportTestMessage does nothing.
+func (discardReporter) ReportTestMessage(testmessage.TestMessage) error { return nil }
+
+// ReportTestAttachment does nothing.
+func (discardReporter) ReportTestAttachment(testattachment.TestAttachment) error { return nil }
+
+// Close does nothing.
+func (discardReporter) Close() error { return nil }
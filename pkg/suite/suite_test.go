@@ -0,0 +1,157 @@
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/testrunner"
+)
+
+// orderSuite records the calls made to it.
+type orderSuite struct {
+	calls []string
+}
+
+func (s *orderSuite) SetupSuite()          { s.calls = append(s.calls, "SetupSuite") }
+func (s *orderSuite) TearDownSuite() error { s.calls = append(s.calls, "TearDownSuite"); return nil }
+func (s *orderSuite) SetupTest() error     { s.calls = append(s.calls, "SetupTest"); return nil }
+func (s *orderSuite) TearDownTest()        { s.calls = append(s.calls, "TearDownTest") }
+
+func (s *orderSuite) TestCreate(assert *assertions.Assert) {
+	s.calls = append(s.calls, "TestCreate")
+	assert.Equal(len("SKU-1"), 5)
+}
+
+func (s *orderSuite) TestCancel(assert *assertions.Assert) teststatus.TestStatus {
+	s.calls = append(s.calls, "TestCancel")
+	return teststatus.Passed
+}
+
+// Testify is not a test: the character after "Test" is lower case.
+func (s *orderSuite) Testify(assert *assertions.Assert) { s.calls = append(s.calls, "Testify") }
+
+func TestRunCallsMethodsInOrder(t *testing.T) {
+	s := &orderSuite{}
+	var rep recordingReporter
+	Run(t, s, WithReporter(&rep))
+
+	want := "[SetupSuite SetupTest TestCancel TearDownTest SetupTest TestCreate TearDownTest TearDownSuite]"
+	if fmt.Sprint(s.calls) != want {
+		t.Errorf("Expected calls %s, got %v", want, s.calls)
+	}
+	if want := "[TestCancel Passed TestCreate Passed]"; fmt.Sprint(rep.outputs) != want {
+		t.Errorf("Expected reported outputs %s, got %v", want, rep.outputs)
+	}
+}
+
+// failingSuite has a failing test, a test returning Failed and a failing
+// SetupTest for one of its tests.
+type failingSuite struct {
+	setups int
+}
+
+func (s *failingSuite) SetupTest() error {
+	if s.setups++; s.setups == 2 {
+		return errors.New("no fixture")
+	}
+	return nil
+}
+
+func (s *failingSuite) TestA(assert *assertions.Assert) {
+	assert.Equal(2+2, 5)
+}
+
+func (s *failingSuite) TestB(assert *assertions.Assert) {}
+
+func (s *failingSuite) TestC(assert *assertions.Assert) teststatus.TestStatus {
+	return teststatus.Failed
+}
+
+func TestRunReportsFailures(t *testing.T) {
+	var rep recordingReporter
+	ft := &fakeT{}
+	run(ft, &failingSuite{}, WithReporter(&rep))
+
+	if want := "[TestA Failed TestB Errored TestC Failed]"; fmt.Sprint(rep.outputs) != want {
+		t.Errorf("Expected reported outputs %s, got %v", want, rep.outputs)
+	}
+	if len(ft.errors) == 0 || !strings.Contains(ft.errors[0], "values differ") {
+		t.Errorf("Expected the assertion failure to reach the testing context, got %v", ft.errors)
+	}
+}
+
+// badSuite has a test method with the wrong signature.
+type badSuite struct{}
+
+func (badSuite) TestWrong(t *testing.T) {}
+
+func TestRunRejectsBadSuites(t *testing.T) {
+	for _, tc := range []struct {
+		suite any
+		want  string
+	}{
+		{nil, "suite: suite is nil"},
+		{struct{}{}, "suite: struct {} has no TestXxx methods"},
+		{badSuite{}, "suite: method TestWrong must be func(*assertions.Assert) or func(*assertions.Assert) teststatus.TestStatus, got func(*testing.T)"},
+	} {
+		ft := &fakeT{}
+		run(ft, tc.suite)
+		if fmt.Sprint(ft.errors) != "["+tc.want+"]" {
+			t.Errorf("Expected %q, got %v", tc.want, ft.errors)
+		}
+	}
+}
+
+// fakeT is a testrunner.TestInterface that records failures. Like
+// *testing.T, it runs subtests on their own goroutines and stops them with
+// runtime.Goexit on FailNow.
+type fakeT struct {
+	errors []string
+}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	f.Errorf(format, args...)
+	f.FailNow()
+}
+
+func (f *fakeT) FailNow() { runtime.Goexit() }
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Run(name string, fn func(t testrunner.TestInterface)) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(f)
+	}()
+	<-done
+	return true
+}
+
+// recordingReporter records the name and status of each reported output.
+type recordingReporter struct {
+	outputs []string
+}
+
+func (r *recordingReporter) ReportTestOutput(to testoutput.TestOutput) error {
+	r.outputs = append(r.outputs, to.TestName+" "+to.Status)
+	return nil
+}
+
+func (r *recordingReporter) ReportTestMessage(testmessage.TestMessage) error { return nil }
+
+func (r *recordingReporter) ReportTestAttachment(testattachment.TestAttachment) error { return nil }
+
+func (r *recordingReporter) Close() error { return nil }
@@ -0,0 +1,131 @@
+package testrunner
+
+import (
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"time"
+)
+
+// RunTestWithFixtures executes a test that needs a fixture, such as a
+// temporary database or a running server.
+// setup creates the fixture and returns it with a teardown function, which
+// may be nil. If setup returns an error, the test function is not run and the
+// test is recorded as teststatus.Errored rather than Failed, so a broken
+// environment is not mistaken for a failing assertion; setup should release
+// anything it acquired before returning an error.
+// Otherwise teardown runs once the test function finishes, however it
+// finishes: by returning, by stopping the test with FailNow or Fatalf, or by
+// panicking. A test that panics or is stopped is recorded as Failed, and a
+// panic is propagated once teardown has run.
+// The test is untagged: it is skipped, without running setup, if the runner
+// only includes tests with certain tags. The runner's BeforeEach hooks run
+// before setup, and its AfterEach hooks after teardown.
+// The runner's timeout, set with WithTimeout, applies to the test function
+// but not to setup: a test still running after it is recorded as Failed and
+// abandoned, and teardown runs at once, while the abandoned goroutine may
+// still be using the fixture.
+// The method returns the result of the test.
+//
+// It is a function rather than a method of TestRunner because Go methods
+// cannot take type parameters.
+//
+// Example:
+//
+//	testrunner.RunTestWithFixtures(tr, "TestSaveUser",
+//		func() (*sql.DB, func(), error) {
+//			db, err := sql.Open("sqlite", ":memory:")
+//			if err != nil {
+//				return nil, nil, err
+//			}
+//			return db, func() { db.Close() }, nil
+//		},
+//		func(assert *assertions.Assert, db *sql.DB) teststatus.TestStatus {
+//			assert.NoError(SaveUser(db, user))
+//			return teststatus.Passed
+//		})
+func RunTestWithFixtures[T any](tr *TestRunner, testName string, setup func() (T, func(), error), testFunc func(assert *assertions.Assert, fixture T) teststatus.TestStatus) teststatus.TestStatus {
+	var resultOutside teststatus.TestStatus
+
+	tr.t.Run(testName, func(t TestInterface) {
+		if reason, excluded := tr.filtered(nil); excluded {
+			resultOutside = teststatus.Skipped // Set first, as skipping may stop the subtest
+			tr.skipExcluded(t, testName, reason)
+			return
+		}
+		tr.start(testName)
+		startTime := time.Now()
+
+		if err := tr.beforeTest(testName); err != nil {
+			resultOutside = teststatus.Errored
+			endTime := time.Now()
+			tr.addResult(resultOutside, startTime, endTime)
+			tr.record(t, testName, resultOutside, endTime.Sub(startTime), "", nil, err)
+			return
+		}
+		defer tr.afterTest(t, testName) // After teardown, as defers run in reverse
+
+		fixture, teardown, err := setup()
+		if err != nil {
+			resultOutside = teststatus.Errored
+			endTime := time.Now()
+			tr.addResult(resultOutside, startTime, endTime)
+			tr.record(t, testName, resultOutside, endTime.Sub(startTime), "", nil, fmt.Errorf("fixture setup failed: %w", err))
+			return
+		}
+		if teardown != nil {
+			defer teardown()
+		}
+
+		// Until testFunc returns, the test counts as failed: if it panics or
+		// is stopped with FailNow, the deferred function below records it.
+		var assert *assertions.Assert
+		resultOutside = teststatus.Failed
+		returned := false
+		defer func() {
+			if returned {
+				return
+			}
+			endTime := time.Now()
+			tr.addResult(resultOutside, startTime, endTime)
+			if r := recover(); r != nil {
+				tr.logger.LogError(fmt.Errorf("test %s panicked: %v", testName, r))
+				tr.report(generateTestID(), testName, resultOutside.GetResult(), endTime.Sub(startTime))
+				panic(r)
+			}
+			tr.logger.LogError(fmt.Errorf("test %s failed", testName))
+			testID := generateTestID()
+			tr.report(testID, testName, resultOutside.GetResult(), endTime.Sub(startTime))
+			if failure := assert.Error(); failure != "" {
+				tr.reportFailure(testID, failure)
+			}
+			tr.reportStats(testID, assert)
+		}()
+
+		if tr.timeout > 0 {
+			// runTimed stops only the test's goroutine on FailNow, and
+			// propagates a panic to the deferred function above.
+			result, failure, timedAssert, err := tr.runTimed(t, func(assert *assertions.Assert) teststatus.TestStatus {
+				return testFunc(assert, fixture)
+			}, tr.timeout)
+			endTime := time.Now()
+			returned = true
+			resultOutside = result
+
+			tr.addResult(result, startTime, endTime)
+			tr.record(t, testName, result, endTime.Sub(startTime), failure, timedAssert, err)
+			return
+		}
+
+		assert = tr.newAssert(t)
+		result := testFunc(assert, fixture)
+		endTime := time.Now()
+		returned = true
+		resultOutside = result
+
+		tr.addResult(result, startTime, endTime)
+		tr.record(t, testName, result, endTime.Sub(startTime), assert.Error(), assert, nil)
+	})
+
+	return resultOutside
+}
@@ -0,0 +1,268 @@
+package testrunner
+
+import (
+	"errors"
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/logging"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// GoexitT is a TestInterface that, like *testing.T, runs each test in its own
+// goroutine and stops it with runtime.Goexit on Fatalf, FailNow or Skip.
+type GoexitT struct {
+	Errors  []string
+	Stopped bool
+	Skipped []string
+}
+
+// Errorf records the formatted error message.
+func (g *GoexitT) Errorf(format string, args ...interface{}) {
+	g.Errors = append(g.Errors, fmt.Sprintf(format, args...))
+}
+
+// Fatalf records the formatted error message and stops the test.
+func (g *GoexitT) Fatalf(format string, args ...interface{}) {
+	g.Errorf(format, args...)
+	g.FailNow()
+}
+
+// FailNow stops the test.
+func (g *GoexitT) FailNow() {
+	g.Stopped = true
+	runtime.Goexit()
+}
+
+// Skip records the reason and stops the test.
+func (g *GoexitT) Skip(args ...interface{}) {
+	g.Skipped = append(g.Skipped, fmt.Sprint(args...))
+	runtime.Goexit()
+}
+
+// Helper does nothing; it lets assertions report through GoexitT.
+func (g *GoexitT) Helper() {}
+
+// Run runs f in a new goroutine and waits for it to finish.
+func (g *GoexitT) Run(name string, f func(t TestInterface)) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(g)
+	}()
+	<-done
+	return !g.Stopped && len(g.Errors) == 0
+}
+
+// counterFixture returns a setup function whose fixture is a counter and
+// whose teardown increments *teardowns.
+func counterFixture(teardowns *int) func() (*int, func(), error) {
+	return func() (*int, func(), error) {
+		counter := new(int)
+		return counter, func() { *teardowns++ }, nil
+	}
+}
+
+// TestRunTestWithFixturesPasses checks that the fixture reaches the test
+// function and is torn down after a passing test.
+func TestRunTestWithFixturesPasses(t *testing.T) {
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, mockReporter)
+
+	teardowns := 0
+	result := RunTestWithFixtures(tr, "TestCounter", counterFixture(&teardowns), func(assert *assertions.Assert, counter *int) teststatus.TestStatus {
+		*counter++
+		assert.Equal(*counter, 1)
+		return teststatus.Passed
+	})
+
+	if result != teststatus.Passed {
+		t.Errorf("Expected result Passed, got %s", result.GetResult())
+	}
+	if teardowns != 1 {
+		t.Errorf("Expected teardown to run once, ran %d times", teardowns)
+	}
+	if len(mockReporter.ReportedOutput) != 1 || mockReporter.ReportedOutput[0].Status != "Passed" {
+		t.Errorf("Expected one Passed output to be reported, got %v", mockReporter.ReportedOutput)
+	}
+}
+
+// TestRunTestWithFixturesFails checks that teardown runs after a failing test.
+func TestRunTestWithFixturesFails(t *testing.T) {
+	mockT := &MockT{T: t}
+	tr := NewTestRunner(mockT, logging.NewMockLogger(), true, &MockReporter{})
+
+	teardowns := 0
+	result := RunTestWithFixtures(tr, "TestCounter", counterFixture(&teardowns), func(assert *assertions.Assert, counter *int) teststatus.TestStatus {
+		return teststatus.Failed
+	})
+
+	if result != teststatus.Failed {
+		t.Errorf("Expected result Failed, got %s", result.GetResult())
+	}
+	if teardowns != 1 {
+		t.Errorf("Expected teardown to run once, ran %d times", teardowns)
+	}
+	if !mockT.CalledErrorf {
+		t.Errorf("Expected Errorf to be called, but it was not")
+	}
+}
+
+// TestRunTestWithFixturesSetupError checks that a setup error skips the test
+// function and records the test as Errored rather than Failed.
+func TestRunTestWithFixturesSetupError(t *testing.T) {
+	mockT := &MockT{T: t}
+	mockLogger := logging.NewMockLogger()
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(mockT, mockLogger, true, mockReporter)
+
+	ran := false
+	result := RunTestWithFixtures(tr, "TestDatabase",
+		func() (string, func(), error) {
+			return "", nil, errors.New("connection refused")
+		},
+		func(assert *assertions.Assert, dsn string) teststatus.TestStatus {
+			ran = true
+			return teststatus.Passed
+		})
+
+	if result != teststatus.Errored {
+		t.Errorf("Expected result Errored, got %s", result.GetResult())
+	}
+	if ran {
+		t.Errorf("Expected the test function not to run after a setup error")
+	}
+
+	want := "Test TestDatabase errored: fixture setup failed: connection refused"
+	if len(mockT.Errors) != 1 || mockT.Errors[0] != want {
+		t.Errorf("Expected error %q, got %v", want, mockT.Errors)
+	}
+	if len(mockReporter.ReportedOutput) != 1 || mockReporter.ReportedOutput[0].Status != "Errored" {
+		t.Errorf("Expected one Errored output to be reported, got %v", mockReporter.ReportedOutput)
+	}
+	if report := tr.GenerateReport(); report.Total != 1 || report.Passed != 0 {
+		t.Errorf("Expected the report to count one test that did not pass, got %+v", report)
+	}
+}
+
+// TestRunTestWithFixturesFailNow checks that teardown runs when an assertion
+// stops the test with FailNow.
+func TestRunTestWithFixturesFailNow(t *testing.T) {
+	goexitT := &GoexitT{}
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(goexitT, logging.NewMockLogger(), true, mockReporter)
+
+	teardowns := 0
+	reachedEnd := false
+	result := RunTestWithFixtures(tr, "TestCounter", counterFixture(&teardowns), func(assert *assertions.Assert, counter *int) teststatus.TestStatus {
+		assert.With(assertions.UseFatal(true)).Equal(*counter, 1)
+		reachedEnd = true
+		return teststatus.Passed
+	})
+
+	if reachedEnd {
+		t.Errorf("Expected FailNow to stop the test function")
+	}
+	if teardowns != 1 {
+		t.Errorf("Expected teardown to run once, ran %d times", teardowns)
+	}
+	if result != teststatus.Failed {
+		t.Errorf("Expected result Failed, got %s", result.GetResult())
+	}
+	if len(mockReporter.ReportedOutput) != 1 || mockReporter.ReportedOutput[0].Status != "Failed" {
+		t.Errorf("Expected one Failed output to be reported, got %v", mockReporter.ReportedOutput)
+	}
+}
+
+// TestRunTestWithFixturesFatalf checks that teardown runs when the runner
+// stops a failing test with Fatalf because continueOnFail is false.
+func TestRunTestWithFixturesFatalf(t *testing.T) {
+	goexitT := &GoexitT{}
+	tr := NewTestRunner(goexitT, logging.NewMockLogger(), false, &MockReporter{})
+
+	teardowns := 0
+	RunTestWithFixtures(tr, "TestCounter", counterFixture(&teardowns), func(assert *assertions.Assert, counter *int) teststatus.TestStatus {
+		return teststatus.Failed
+	})
+
+	if !goexitT.Stopped {
+		t.Errorf("Expected Fatalf to stop the test")
+	}
+	if teardowns != 1 {
+		t.Errorf("Expected teardown to run once, ran %d times", teardowns)
+	}
+}
+
+// TestRunTestWithFixturesPanic checks that teardown runs when the test
+// function panics, and that the panic is propagated.
+func TestRunTestWithFixturesPanic(t *testing.T) {
+	mockLogger := logging.NewMockLogger()
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&MockT{T: t}, mockLogger, true, mockReporter)
+
+	teardowns := 0
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		RunTestWithFixtures(tr, "TestCounter", counterFixture(&teardowns), func(assert *assertions.Assert, counter *int) teststatus.TestStatus {
+			panic("boom")
+		})
+	}()
+
+	if recovered != "boom" {
+		t.Errorf("Expected the panic to be propagated, recovered %v", recovered)
+	}
+	if teardowns != 1 {
+		t.Errorf("Expected teardown to run once, ran %d times", teardowns)
+	}
+	if len(mockReporter.ReportedOutput) != 1 || mockReporter.ReportedOutput[0].Status != "Failed" {
+		t.Errorf("Expected one Failed output to be reported, got %v", mockReporter.ReportedOutput)
+	}
+	if len(mockLogger.ErrorMessages) != 1 || mockLogger.ErrorMessages[0] != "test TestCounter panicked: boom" {
+		t.Errorf("Expected the panic to be logged, got %v", mockLogger.ErrorMessages)
+	}
+}
+
+// TestRunTestWithFixturesTimeout checks that the runner's timeout aborts a
+// hung test function, and that teardown still runs.
+func TestRunTestWithFixturesTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	mockT := &MockT{T: t}
+	tr := NewTestRunner(mockT, logging.NewMockLogger(), true, &MockReporter{}, WithTimeout(20*time.Millisecond))
+
+	teardowns := 0
+	result := RunTestWithFixtures(tr, "TestCounter", counterFixture(&teardowns), func(assert *assertions.Assert, counter *int) teststatus.TestStatus {
+		<-release
+		return teststatus.Passed
+	})
+
+	if result != teststatus.Failed {
+		t.Errorf("Expected result Failed, got %s", result.GetResult())
+	}
+	if teardowns != 1 {
+		t.Errorf("Expected teardown to run once, ran %d times", teardowns)
+	}
+	if len(mockT.Errors) != 1 || mockT.Errors[0] != "Test TestCounter failed: timed out after 20ms" {
+		t.Errorf("Expected the runner to fail TestCounter with its timeout, got %v", mockT.Errors)
+	}
+}
+
+// ExampleRunTestWithFixtures shows a setup error recorded as Errored.
+func ExampleRunTestWithFixtures() {
+	tr := NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, &MockReporter{})
+
+	result := RunTestWithFixtures(tr, "TestDatabase",
+		func() (string, func(), error) {
+			return "", nil, errors.New("connection refused")
+		},
+		func(assert *assertions.Assert, dsn string) teststatus.TestStatus {
+			return teststatus.Passed
+		})
+
+	fmt.Println(result.GetResult())
+	// Output: Errored
+}
@@ -0,0 +1,212 @@
+package testrunner
+
+import (
+	"errors"
+	"fmt"
+	"gowise/pkg/interfaces/teststatus"
+	"slices"
+	"sync"
+)
+
+// hooks holds a runner's lifecycle hooks. It is shared with the runners of
+// tests scheduled with RunTestParallel, so that BeforeAll runs once for all
+// of them.
+type hooks struct {
+	mu         sync.Mutex
+	beforeAll  []func() error
+	afterAll   []func() error
+	beforeEach []func(testName string) error
+	afterEach  []func(testName string) error
+
+	beforeOnce sync.Once // Runs the BeforeAll hooks, which tests wait for
+	started    bool      // Whether BeforeAll has run
+	beforeErr  error     // The error of BeforeAll, given to every test
+	finished   bool      // Whether AfterAll has run
+	cleanupSet bool      // Whether Finish is registered with the testing context
+}
+
+// BeforeAll registers fn to run once, before the first test the runner
+// runs, to create resources shared by its tests, such as a database
+// container or a server. If fn fails, the failure is reported as a result
+// named "BeforeAll" with status teststatus.Errored, the remaining BeforeAll
+// hooks are not run, and every test is recorded as Errored without running.
+// Hooks run in the order they are registered, and may register other hooks,
+// such as the AfterAll that releases what they create. Tests scheduled with
+// RunTestParallel wait for them to finish.
+//
+// Example:
+//
+//	var db *sql.DB
+//	tr.BeforeAll(func() (err error) {
+//		db, err = startDatabase()
+//		return err
+//	})
+//	tr.AfterAll(func() error { return db.Close() })
+func (tr *TestRunner) BeforeAll(fn func() error) {
+	tr.hooks.mu.Lock()
+	defer tr.hooks.mu.Unlock()
+
+	tr.hooks.beforeAll = append(tr.hooks.beforeAll, fn)
+}
+
+// AfterAll registers fn to run once, after the runner's last test, if
+// BeforeAll hooks have run, even if they failed. It runs when Finish is
+// called, or, if the runner's testing context has a Cleanup method, as
+// *testing.T does, when the test that created the runner completes, so
+// that it runs even if a test panics. If fn fails, the failure is reported
+// as a result named "AfterAll" with status teststatus.Errored.
+// Hooks run in the reverse of the order they are registered, as deferred
+// calls do.
+func (tr *TestRunner) AfterAll(fn func() error) {
+	tr.hooks.mu.Lock()
+	defer tr.hooks.mu.Unlock()
+
+	tr.hooks.afterAll = append(tr.hooks.afterAll, fn)
+	if c, ok := tr.t.(interface{ Cleanup(func()) }); ok && !tr.hooks.cleanupSet {
+		tr.hooks.cleanupSet = true
+		c.Cleanup(tr.Finish)
+	}
+}
+
+// BeforeEach registers fn to run before each test, with the test's name.
+// If fn fails, the test is recorded as teststatus.Errored without running,
+// as when a fixture's setup fails.
+// Hooks run in the order they are registered.
+func (tr *TestRunner) BeforeEach(fn func(testName string) error) {
+	tr.hooks.mu.Lock()
+	defer tr.hooks.mu.Unlock()
+
+	tr.hooks.beforeEach = append(tr.hooks.beforeEach, fn)
+}
+
+// AfterEach registers fn to run after each test that BeforeEach hooks let
+// run, with the test's name, however the test finishes, including by
+// panicking. If fn fails, the failure is reported as a result named
+// "testName [AfterEach]" with status teststatus.Errored, so that it is not
+// mistaken for a failure of the test.
+// Hooks run in the reverse of the order they are registered.
+func (tr *TestRunner) AfterEach(fn func(testName string) error) {
+	tr.hooks.mu.Lock()
+	defer tr.hooks.mu.Unlock()
+
+	tr.hooks.afterEach = append(tr.hooks.afterEach, fn)
+}
+
+// Finish runs the AfterAll hooks, if they have not already run. Call it,
+// normally with defer, when the runner's testing context has no Cleanup
+// method; otherwise it is called for you.
+func (tr *TestRunner) Finish() {
+	tr.Wait()
+
+	h := tr.hooks
+	h.mu.Lock()
+	if h.finished || !h.started {
+		h.mu.Unlock()
+		return
+	}
+	h.finished = true
+	afterAll := slices.Clone(h.afterAll)
+	h.mu.Unlock()
+
+	var errs []error
+	for i := len(afterAll) - 1; i >= 0; i-- {
+		errs = append(errs, callHook(func() error { return afterAll[i]() }))
+	}
+	if err := errors.Join(errs...); err != nil {
+		tr.recordHook("AfterAll", err)
+	}
+}
+
+// beforeTest runs the BeforeAll hooks if they have not run, then the
+// BeforeEach hooks for testName. It returns the error that should stop the
+// test from running, if any.
+func (tr *TestRunner) beforeTest(testName string) error {
+	h := tr.hooks
+	h.beforeOnce.Do(tr.runBeforeAll)
+
+	h.mu.Lock()
+	beforeErr := h.beforeErr
+	beforeEach := slices.Clone(h.beforeEach)
+	h.mu.Unlock()
+
+	if beforeErr != nil {
+		return beforeErr
+	}
+	for _, fn := range beforeEach {
+		if err := callHook(func() error { return fn(testName) }); err != nil {
+			return fmt.Errorf("BeforeEach hook failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// runBeforeAll runs the BeforeAll hooks registered so far. The hooks' lock is
+// not held while they run, so a hook may register others, such as the
+// AfterAll that releases what it creates.
+func (tr *TestRunner) runBeforeAll() {
+	h := tr.hooks
+	h.mu.Lock()
+	h.started = true
+	beforeAll := slices.Clone(h.beforeAll)
+	h.mu.Unlock()
+
+	for _, fn := range beforeAll {
+		if err := callHook(fn); err != nil {
+			h.mu.Lock()
+			h.beforeErr = fmt.Errorf("BeforeAll hook failed: %w", err)
+			h.mu.Unlock()
+			tr.recordHook("BeforeAll", err)
+			return
+		}
+	}
+}
+
+// afterTest runs the AfterEach hooks for testName, reporting their failure
+// as a result of its own. Call it with defer, so that it runs however the
+// test finishes.
+func (tr *TestRunner) afterTest(t TestInterface, testName string) {
+	tr.hooks.mu.Lock()
+	afterEach := slices.Clone(tr.hooks.afterEach)
+	tr.hooks.mu.Unlock()
+
+	var errs []error
+	for i := len(afterEach) - 1; i >= 0; i-- {
+		errs = append(errs, callHook(func() error { return afterEach[i](testName) }))
+	}
+	if err := errors.Join(errs...); err != nil {
+		tr.recordHookOn(t, testName+" [AfterEach]", err)
+	}
+}
+
+// recordHook records the failure of the hook named name against the
+// runner's testing context.
+func (tr *TestRunner) recordHook(name string, err error) {
+	tr.recordHookOn(tr.t, name, err)
+}
+
+// recordHookOn records the failure of the hook named name as an Errored
+// result, reporting err as its failure message, and marks t as failed.
+// Hooks are not tests, so t is not stopped even if continueOnFail is false.
+func (tr *TestRunner) recordHookOn(t TestInterface, name string, err error) {
+	testID := generateTestID()
+	tr.report(testID, name, teststatus.Errored.GetResult(), 0)
+	tr.reportFailure(testID, err.Error())
+
+	tr.mu.Lock()
+	tr.results = append(tr.results, teststatus.Errored)
+	tr.durations = append(tr.durations, 0)
+	tr.mu.Unlock()
+
+	tr.logger.LogError(fmt.Errorf("hook %s failed: %v", name, err))
+	t.Errorf("Hook %s failed: %v", name, err)
+}
+
+// callHook calls fn, turning a panic into an error.
+func callHook(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,162 @@
+package testrunner
+
+import (
+	"errors"
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/logging"
+	"testing"
+	"time"
+)
+
+// passing is a test function that passes.
+func passing(*assertions.Assert) teststatus.TestStatus {
+	return teststatus.Passed
+}
+
+// TestHooksOrder checks that BeforeAll and AfterAll run once around all
+// tests, and BeforeEach and AfterEach around each, in registration order and
+// its reverse.
+func TestHooksOrder(t *testing.T) {
+	tr := NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, &MockReporter{})
+
+	var events []string
+	tr.BeforeAll(func() error { events = append(events, "before all 1"); return nil })
+	tr.BeforeAll(func() error { events = append(events, "before all 2"); return nil })
+	tr.AfterAll(func() error { events = append(events, "after all 1"); return nil })
+	tr.AfterAll(func() error { events = append(events, "after all 2"); return nil })
+	tr.BeforeEach(func(name string) error { events = append(events, "before "+name); return nil })
+	tr.AfterEach(func(name string) error { events = append(events, "after "+name); return nil })
+
+	tr.RunTest("TestA", func(*assertions.Assert) teststatus.TestStatus {
+		events = append(events, "run TestA")
+		return teststatus.Passed
+	})
+	RunTable(tr, "TestTable", []Case[int]{{Name: "one"}, {Name: "skipped", Skip: "not ready"}}, func(*assertions.Assert, Case[int]) teststatus.TestStatus {
+		return teststatus.Passed
+	})
+	RunTestWithFixtures(tr, "TestFixture", func() (int, func(), error) {
+		events = append(events, "setup")
+		return 0, func() { events = append(events, "teardown") }, nil
+	}, func(*assertions.Assert, int) teststatus.TestStatus { return teststatus.Passed })
+	tr.Finish()
+	tr.Finish()
+
+	want := "[before all 1 before all 2 before TestA run TestA after TestA before TestTable/one after TestTable/one " +
+		"before TestFixture setup teardown after TestFixture after all 2 after all 1]"
+	if fmt.Sprint(events) != want {
+		t.Errorf("Expected hooks to run as\n%s\ngot\n%v", want, events)
+	}
+}
+
+// TestBeforeAllRegistersHooks checks that a BeforeAll hook can register
+// other hooks, which then run, rather than deadlocking on registration.
+func TestBeforeAllRegistersHooks(t *testing.T) {
+	tr := NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, &MockReporter{})
+
+	var events []string
+	tr.BeforeAll(func() error {
+		events = append(events, "before all")
+		tr.AfterAll(func() error { events = append(events, "after all"); return nil })
+		tr.BeforeEach(func(name string) error { events = append(events, "before "+name); return nil })
+		return nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tr.RunTest("TestA", passing)
+		tr.Finish()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected registering hooks from BeforeAll not to deadlock")
+	}
+
+	want := "[before all before TestA after all]"
+	if fmt.Sprint(events) != want {
+		t.Errorf("Expected hooks to run as %s, got %v", want, events)
+	}
+}
+
+// TestHookFailures checks that hook failures are reported as Errored
+// results of their own, and that a failed BeforeAll stops every test from
+// running but still lets AfterAll run.
+func TestHookFailures(t *testing.T) {
+	goexitT := &GoexitT{}
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(goexitT, logging.NewMockLogger(), true, mockReporter)
+
+	afterAll := false
+	tr.BeforeAll(func() error { return errors.New("no docker") })
+	tr.AfterAll(func() error { afterAll = true; return nil })
+
+	ran := false
+	result := tr.RunTest("TestA", func(*assertions.Assert) teststatus.TestStatus { ran = true; return teststatus.Passed })
+	tr.Finish()
+
+	if ran || result != teststatus.Errored || !afterAll {
+		t.Errorf("Expected TestA to be Errored without running and AfterAll to run, got %v (ran %v, AfterAll %v)", result, ran, afterAll)
+	}
+	want := "[BeforeAll Errored TestA Errored]"
+	if got := reportedStatuses(mockReporter); got != want {
+		t.Errorf("Expected reported outputs %s, got %s", want, got)
+	}
+	if message := mockReporter.ReportedMessages[0]; message.Message != "no docker" || message.TestID != mockReporter.ReportedOutput[0].TestID {
+		t.Errorf("Expected the hook's error as its failure message, got %+v", message)
+	}
+	wantErrors := "[Hook BeforeAll failed: no docker Test TestA errored: BeforeAll hook failed: no docker]"
+	if fmt.Sprint(goexitT.Errors) != wantErrors {
+		t.Errorf("Expected errors %s, got %v", wantErrors, goexitT.Errors)
+	}
+
+	mockReporter = &MockReporter{}
+	tr = NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, mockReporter)
+	tr.BeforeEach(func(name string) error {
+		if name == "TestB" {
+			return errors.New("no fixture")
+		}
+		return nil
+	})
+	tr.AfterEach(func(name string) error { return fmt.Errorf("cleaning up after %s", name) })
+	tr.AfterAll(func() error { panic("cannot stop server") })
+	tr.RunTest("TestA", passing)
+	tr.RunTest("TestB", passing)
+	tr.Finish()
+
+	want = "[TestA Passed TestA [AfterEach] Errored TestB Errored AfterAll Errored]"
+	if got := reportedStatuses(mockReporter); got != want {
+		t.Errorf("Expected reported outputs %s, got %s", want, got)
+	}
+	if report := tr.GenerateReport(); report.Total != 4 || report.Passed != 1 {
+		t.Errorf("Expected hook failures counted as results, got %+v", report)
+	}
+	if last := mockReporter.ReportedMessages[len(mockReporter.ReportedMessages)-1]; last.Message != "panic: cannot stop server" {
+		t.Errorf("Expected a panicking hook to be reported as failing, got %+v", last)
+	}
+}
+
+// TestAfterHooksRunWhenTestsPanic checks that AfterEach runs when a test
+// panics, and that AfterAll runs when the runner's testing context
+// completes.
+func TestAfterHooksRunWhenTestsPanic(t *testing.T) {
+	var events []string
+	t.Run("suite", func(t *testing.T) {
+		tr := NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, &MockReporter{})
+		tr.BeforeAll(func() error { return nil })
+		tr.AfterEach(func(name string) error { events = append(events, "after "+name); return nil })
+		tr.AfterAll(func() error { events = append(events, "after all"); return nil })
+
+		func() {
+			defer func() { recover() }()
+			tr.RunTest("TestPanics", func(*assertions.Assert) teststatus.TestStatus { panic("boom") })
+		}()
+		events = append(events, "suite done")
+	})
+
+	if want := "[after TestPanics suite done after all]"; fmt.Sprint(events) != want {
+		t.Errorf("Expected %s, got %v", want, events)
+	}
+}
@@ -0,0 +1,181 @@
+package testrunner
+
+import (
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
+	"gowise/pkg/interfaces/testoutput"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/logging"
+	"gowise/pkg/reporter"
+)
+
+// MaxParallel sets the number of tests scheduled with RunTestParallel that
+// may run at once. The default is runtime.GOMAXPROCS(0); values below 1 are
+// treated as 1.
+func MaxParallel(n int) Option {
+	return func(tr *TestRunner) { tr.maxParallel = max(n, 1) }
+}
+
+// scheduledTest is a test scheduled with RunTestParallel. It runs on its own
+// runner, whose log messages and reporter output are buffered until every
+// test scheduled before it has been flushed.
+type scheduledTest struct {
+	runner *TestRunner
+	output *outputBuffer
+	done   bool
+}
+
+// RunTestParallel schedules a test to run on the runner's worker pool and
+// returns immediately; MaxParallel bounds how many scheduled tests run at
+// once. Log messages, reporter output and results are passed on in the
+// order tests were scheduled, whatever order they finish in, so the reporter
+// and logger need not be safe for concurrent use.
+// A failing test stops only itself, even if continueOnFail is false.
+// Call Wait, or GenerateReport, before the enclosing test returns.
+//
+// Example:
+//
+//	tr := testrunner.NewTestRunner(t, logger, true, rep, testrunner.MaxParallel(4))
+//	for _, endpoint := range endpoints {
+//		tr.RunTestParallel(endpoint.Name, func(assert *assertions.Assert) teststatus.TestStatus {
+//			return checkEndpoint(assert, endpoint)
+//		})
+//	}
+//	report := tr.GenerateReport()
+func (tr *TestRunner) RunTestParallel(testName string, testFunc func(assert *assertions.Assert) teststatus.TestStatus) {
+	output := &outputBuffer{}
+	st := &scheduledTest{runner: tr.scheduledRunner(output), output: output}
+
+	tr.mu.Lock()
+	if tr.workers == nil {
+		tr.workers = make(chan struct{}, tr.maxParallel)
+	}
+	tr.scheduled = append(tr.scheduled, st)
+	tr.mu.Unlock()
+
+	tr.running.Add(1)
+	go func() {
+		defer tr.running.Done()
+		tr.workers <- struct{}{}
+		defer func() { <-tr.workers }()
+		defer tr.flush(st)
+
+		st.runner.RunTest(testName, testFunc)
+	}()
+}
+
+// scheduledRunner returns the runner of a scheduled test: one with tr's
+// settings that logs and reports to output.
+func (tr *TestRunner) scheduledRunner(output *outputBuffer) *TestRunner {
+	runner := &TestRunner{settings: tr.settings}
+	runner.logger = output
+	runner.reporter = output
+	return runner
+}
+
+// Wait blocks until every test scheduled with RunTestParallel has finished
+// and its output has been passed on.
+func (tr *TestRunner) Wait() {
+	tr.running.Wait()
+}
+
+// flush marks st as done, then passes on the output and results of every
+// finished test not preceded by a running one.
+func (tr *TestRunner) flush(st *scheduledTest) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	st.done = true
+	for tr.flushed < len(tr.scheduled) && tr.scheduled[tr.flushed].done {
+		next := tr.scheduled[tr.flushed]
+		next.output.replay(tr.logger, tr.reporter)
+
+		tr.results = append(tr.results, next.runner.results...)
+		tr.durations = append(tr.durations, next.runner.durations...)
+		if len(next.runner.results) > 0 {
+			tr.observe(next.runner.firstStart, next.runner.lastEnd)
+		}
+
+		tr.scheduled[tr.flushed] = nil
+		tr.flushed++
+	}
+}
+
+// outputBuffer is the logger and reporter of a scheduled test. It records
+// each call so that it can be replayed, in order, to the real logger and
+// reporter.
+type outputBuffer struct {
+	calls []func(logging.LoggerInterface, reporter.ReporterInterface)
+}
+
+// replay makes the recorded calls on logger and rep, logging any reporter
+// errors.
+func (b *outputBuffer) replay(logger logging.LoggerInterface, rep reporter.ReporterInterface) {
+	for _, call := range b.calls {
+		call(logger, rep)
+	}
+}
+
+// LogInfo records an informational message.
+func (b *outputBuffer) LogInfo(message string) {
+	b.calls = append(b.calls, func(logger logging.LoggerInterface, _ reporter.ReporterInterface) {
+		logger.LogInfo(message)
+	})
+}
+
+// LogError records an error message.
+func (b *outputBuffer) LogError(err error) {
+	b.calls = append(b.calls, func(logger logging.LoggerInterface, _ reporter.ReporterInterface) {
+		logger.LogError(err)
+	})
+}
+
+// ReportTestStart records the start of a test, for reporters that stream
+// such events.
+func (b *outputBuffer) ReportTestStart(testName string) error {
+	b.calls = append(b.calls, func(logger logging.LoggerInterface, rep reporter.ReporterInterface) {
+		if starter, ok := rep.(reporter.StartReporter); ok {
+			if err := starter.ReportTestStart(testName); err != nil {
+				logger.LogError(fmt.Errorf("failed to report test start: %v", err))
+			}
+		}
+	})
+	return nil
+}
+
+// ReportTestOutput records test output.
+func (b *outputBuffer) ReportTestOutput(output testoutput.TestOutput) error {
+	b.calls = append(b.calls, func(logger logging.LoggerInterface, rep reporter.ReporterInterface) {
+		if err := rep.ReportTestOutput(output); err != nil {
+			logger.LogError(fmt.Errorf("failed to report test output: %v", err))
+		}
+	})
+	return nil
+}
+
+// ReportTestMessage records a test message.
+func (b *outputBuffer) ReportTestMessage(message testmessage.TestMessage) error {
+	b.calls = append(b.calls, func(logger logging.LoggerInterface, rep reporter.ReporterInterface) {
+		if err := rep.ReportTestMessage(message); err != nil {
+			logger.LogError(fmt.Errorf("failed to report test message: %v", err))
+		}
+	})
+	return nil
+}
+
+// ReportTestAttachment records a test attachment.
+func (b *outputBuffer) ReportTestAttachment(attachment testattachment.TestAttachment) error {
+	b.calls = append(b.calls, func(logger logging.LoggerInterface, rep reporter.ReporterInterface) {
+		if err := rep.ReportTestAttachment(attachment); err != nil {
+			logger.LogError(fmt.Errorf("failed to report test attachment: %v", err))
+		}
+	})
+	return nil
+}
+
+// Close does nothing; the real reporter is closed by its owner.
+func (b *outputBuffer) Close() error {
+	return nil
+}
@@ -0,0 +1,130 @@
+package testrunner
+
+import (
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/logging"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRunTestParallelKeepsOrder checks that output and results from parallel
+// tests are passed on in the order the tests were scheduled, although later
+// tests finish first.
+func TestRunTestParallelKeepsOrder(t *testing.T) {
+	mockLogger := logging.NewMockLogger()
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&TWrapper{t: t}, mockLogger, true, mockReporter, MaxParallel(5))
+
+	for i := 0; i < 5; i++ {
+		tr.RunTestParallel(fmt.Sprintf("Test%d", i), func(assert *assertions.Assert) teststatus.TestStatus {
+			time.Sleep(time.Duration(5-i) * 5 * time.Millisecond)
+			return teststatus.Passed
+		})
+	}
+	report := tr.GenerateReport()
+
+	var reported []string
+	for _, output := range mockReporter.ReportedOutput {
+		reported = append(reported, output.TestName)
+	}
+	if got := fmt.Sprint(reported); got != "[Test0 Test1 Test2 Test3 Test4]" {
+		t.Errorf("Expected outputs in scheduling order, got %s", got)
+	}
+	if got := fmt.Sprint(mockLogger.InfoMessages); got != "[Test Test0 passed Test Test1 passed Test Test2 passed Test Test3 passed Test Test4 passed]" {
+		t.Errorf("Expected log messages in scheduling order, got %s", got)
+	}
+	if report.Total != 5 || report.Passed != 5 || len(report.Durations) != 5 {
+		t.Errorf("Expected 5 passed results with durations, got %+v", report)
+	}
+	if report.Durations[0] < report.Durations[4] {
+		t.Errorf("Expected durations to stay aligned with results, got %v", report.Durations)
+	}
+}
+
+// TestRunTestParallelRunsConcurrently checks that two tests that each wait
+// for the other complete, and that the report's elapsed time is less than
+// the time spent in tests.
+func TestRunTestParallelRunsConcurrently(t *testing.T) {
+	tr := NewTestRunner(&TWrapper{t: t}, logging.NewMockLogger(), true, &MockReporter{}, MaxParallel(2))
+
+	arrived := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		tr.RunTestParallel(fmt.Sprintf("Test%d", i), func(assert *assertions.Assert) teststatus.TestStatus {
+			arrived <- struct{}{}
+			deadline := time.After(5 * time.Second)
+			for len(arrived) < 2 {
+				select {
+				case <-deadline:
+					return teststatus.Failed
+				case <-time.After(time.Millisecond):
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return teststatus.Passed
+		})
+	}
+	report := tr.GenerateReport()
+
+	if report.Passed != 2 {
+		t.Fatalf("Expected both tests to run at once and pass, got %+v", report)
+	}
+	if total := report.Durations[0] + report.Durations[1]; report.Elapsed >= total {
+		t.Errorf("Expected elapsed time %s to be less than the %s spent in tests", report.Elapsed, total)
+	}
+}
+
+// TestRunTestParallelMaxParallel checks that no more than MaxParallel tests
+// run at once.
+func TestRunTestParallelMaxParallel(t *testing.T) {
+	tr := NewTestRunner(&TWrapper{t: t}, logging.NewMockLogger(), true, &MockReporter{}, MaxParallel(2))
+
+	var running, peak atomic.Int32
+	for i := 0; i < 8; i++ {
+		tr.RunTestParallel(fmt.Sprintf("Test%d", i), func(assert *assertions.Assert) teststatus.TestStatus {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			running.Add(-1)
+			return teststatus.Passed
+		})
+	}
+	tr.Wait()
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("Expected at most 2 tests at once, saw %d", p)
+	}
+}
+
+// ExampleTestRunner_RunTestParallel shows that results are reported in the
+// order tests were scheduled.
+func ExampleTestRunner_RunTestParallel() {
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, mockReporter, MaxParallel(3))
+
+	for _, name := range []string{"TestSlow", "TestFast"} {
+		tr.RunTestParallel(name, func(assert *assertions.Assert) teststatus.TestStatus {
+			if name == "TestSlow" {
+				time.Sleep(10 * time.Millisecond)
+			}
+			return teststatus.Passed
+		})
+	}
+	report := tr.GenerateReport()
+
+	for _, output := range mockReporter.ReportedOutput {
+		fmt.Println(output.TestName, output.Status)
+	}
+	fmt.Println(report.Passed, "passed")
+	// Output:
+	// TestSlow Passed
+	// TestFast Passed
+	// 2 passed
+}
@@ -0,0 +1,190 @@
+package testrunner
+
+import (
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/reporter"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Case is a case of a table-driven test run by RunTable.
+type Case[T any] struct {
+	// Name names the case's subtest. Cases without a name are named by
+	// their index, as in "case 2".
+	Name string
+	// Input holds the data the case tests.
+	Input T
+	// Skip, if non-empty, skips the case and gives the reason.
+	Skip string
+	// Tags select the case with the runner's tag filters, as the tags of
+	// RunTestTagged do.
+	Tags []string
+	// ExpectFailure marks a case known to fail, such as the reproduction of
+	// an open bug. The case passes if it fails, and fails if it passes, so
+	// that a fix is noticed.
+	ExpectFailure bool
+}
+
+// RunTable runs a table-driven test named testName, running each case as a
+// subtest and reporting it individually through the reporter, under the name
+// testName/caseName.
+// testFunc is called with each case. A case fails if testFunc returns a
+// status other than teststatus.Passed.
+// Skipped cases, and cases the runner's tag filters exclude, are reported as
+// teststatus.Skipped without running, with the reason as a message to
+// reporter.SkipDestination, and the subtest is skipped if the testing
+// context has a Skip method.
+// For cases marked ExpectFailure, assertion failures are captured instead of
+// failing the test, and the case is reported as Passed if it fails as
+// expected.
+// The runner's BeforeEach and AfterEach hooks run around each case that is
+// not skipped.
+// The function returns the result of each case, in order.
+//
+// It is a function rather than a method of TestRunner because Go methods
+// cannot take type parameters.
+//
+// Example:
+//
+//	testrunner.RunTable(tr, "TestParsePort", []testrunner.Case[string]{
+//		{Name: "default", Input: "80"},
+//		{Name: "highest", Input: "65535"},
+//		{Name: "named service", Input: "http", Skip: "service lookup not implemented"},
+//		{Name: "overflow", Input: "65536", ExpectFailure: true},
+//	}, func(assert *assertions.Assert, c testrunner.Case[string]) teststatus.TestStatus {
+//		_, err := ParsePort(c.Input)
+//		assert.NoError(err)
+//		return teststatus.Passed
+//	})
+func RunTable[T any](tr *TestRunner, testName string, cases []Case[T], testFunc func(assert *assertions.Assert, c Case[T]) teststatus.TestStatus) []teststatus.TestStatus {
+	results := make([]teststatus.TestStatus, len(cases))
+
+	tr.t.Run(testName, func(t TestInterface) {
+		for i, c := range cases {
+			caseName := c.Name
+			if caseName == "" {
+				caseName = fmt.Sprintf("case %d", i)
+			}
+			fullName := testName + "/" + caseName
+
+			t.Run(caseName, func(t TestInterface) {
+				if reason, excluded := tr.filtered(c.Tags); excluded && c.Skip == "" {
+					results[i] = teststatus.Skipped
+					tr.skipExcluded(t, fullName, reason)
+					return
+				}
+				tr.start(fullName)
+				startTime := time.Now()
+				if c.Skip == "" {
+					if err := tr.beforeTest(fullName); err != nil {
+						results[i] = teststatus.Errored
+						endTime := time.Now()
+						tr.addResult(results[i], startTime, endTime)
+						tr.record(t, fullName, results[i], endTime.Sub(startTime), "", nil, err)
+						return
+					}
+					defer tr.afterTest(t, fullName)
+				}
+				switch {
+				case c.Skip != "":
+					results[i] = teststatus.Skipped
+					tr.addResult(results[i], startTime, startTime)
+					tr.skip(t, fullName, c.Skip)
+				case c.ExpectFailure:
+					results[i] = tr.runExpectingFailure(t, fullName, func(assert *assertions.Assert) teststatus.TestStatus {
+						return testFunc(assert, c)
+					})
+				default:
+					assert := tr.newAssert(t)
+					results[i] = testFunc(assert, c)
+					endTime := time.Now()
+					tr.addResult(results[i], startTime, endTime)
+					tr.record(t, fullName, results[i], endTime.Sub(startTime), assert.Error(), assert, nil)
+				}
+			})
+		}
+	})
+
+	return results
+}
+
+// skip reports a skipped test with its reason and skips t if it supports
+// skipping.
+func (tr *TestRunner) skip(t TestInterface, testName, reason string) {
+	testID := generateTestID()
+	tr.report(testID, testName, teststatus.Skipped.GetResult(), 0)
+	tr.reportMessage(testID, reporter.SkipDestination, reason)
+	tr.logger.LogInfo(fmt.Sprintf("Test %s skipped: %s", testName, reason))
+
+	if s, ok := t.(interface{ Skip(args ...interface{}) }); ok {
+		s.Skip(reason)
+	}
+}
+
+// runExpectingFailure runs a case marked ExpectFailure, capturing its
+// assertion failures, and reports it as Passed if it failed and Failed if it
+// passed.
+func (tr *TestRunner) runExpectingFailure(t TestInterface, testName string, testFunc func(assert *assertions.Assert) teststatus.TestStatus) teststatus.TestStatus {
+	startTime := time.Now()
+	capture := &failureCapture{}
+
+	// The case runs on its own goroutine, so that FailNow can stop it
+	// without stopping t.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				capture.Errorf("panic: %v", r)
+			}
+		}()
+		if testFunc(assertions.New(capture)) != teststatus.Passed {
+			capture.Errorf("test function returned a status other than Passed")
+		}
+	}()
+	<-done
+	endTime := time.Now()
+
+	if capture.failed() {
+		tr.addResult(teststatus.Passed, startTime, endTime)
+		tr.report(generateTestID(), testName, teststatus.Passed.GetResult(), endTime.Sub(startTime))
+		tr.logger.LogInfo(fmt.Sprintf("Test %s failed as expected: %s", testName, strings.Join(capture.messages, "; ")))
+		return teststatus.Passed
+	}
+
+	tr.addResult(teststatus.Failed, startTime, endTime)
+	tr.report(generateTestID(), testName, teststatus.Failed.GetResult(), endTime.Sub(startTime))
+	tr.logger.LogError(fmt.Errorf("test %s passed but was expected to fail", testName))
+	tr.fail(t, "Test %s passed but was expected to fail", testName)
+	return teststatus.Failed
+}
+
+// failureCapture is the testing context of a case expected to fail. It
+// records failures rather than reporting them, and stops the case's
+// goroutine on FailNow.
+type failureCapture struct {
+	messages []string
+	stopped  bool
+}
+
+// Errorf records a failure.
+func (c *failureCapture) Errorf(format string, args ...interface{}) {
+	c.messages = append(c.messages, fmt.Sprintf(format, args...))
+}
+
+// FailNow marks the case as failed and stops it.
+func (c *failureCapture) FailNow() {
+	c.stopped = true
+	runtime.Goexit()
+}
+
+// Helper does nothing; failures are not reported with source locations.
+func (c *failureCapture) Helper() {}
+
+// failed reports whether any failure was recorded.
+func (c *failureCapture) failed() bool {
+	return c.stopped || len(c.messages) > 0
+}
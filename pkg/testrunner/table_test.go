@@ -0,0 +1,164 @@
+package testrunner
+
+import (
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/logging"
+	"strconv"
+	"testing"
+)
+
+// parsePortCase checks that c.Input parses as a port number.
+func parsePortCase(assert *assertions.Assert, c Case[string]) teststatus.TestStatus {
+	port, err := strconv.Atoi(c.Input)
+	assert.NoError(err)
+	assert.True(port > 0 && port < 65536)
+	if assert.HasFailed() {
+		return teststatus.Failed
+	}
+	return teststatus.Passed
+}
+
+// TestRunTableReportsEachCase checks that every case is run and reported
+// under its own name, with unnamed cases named by index.
+func TestRunTableReportsEachCase(t *testing.T) {
+	mockT := &MockT{T: t}
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(mockT, logging.NewMockLogger(), true, mockReporter)
+
+	results := RunTable(tr, "TestParsePort", []Case[string]{
+		{Name: "default", Input: "80"},
+		{Input: "65535"},
+		{Name: "overflow", Input: "65536"},
+	}, parsePortCase)
+
+	want := []teststatus.TestStatus{teststatus.Passed, teststatus.Passed, teststatus.Failed}
+	if fmt.Sprint(results) != fmt.Sprint(want) {
+		t.Errorf("Expected results %v, got %v", want, results)
+	}
+
+	var reported []string
+	for _, output := range mockReporter.ReportedOutput {
+		reported = append(reported, output.TestName+" "+output.Status)
+	}
+	wantReported := "[TestParsePort/default Passed TestParsePort/case 1 Passed TestParsePort/overflow Failed]"
+	if fmt.Sprint(reported) != wantReported {
+		t.Errorf("Expected reported outputs %s, got %v", wantReported, reported)
+	}
+
+	// The overflow case's assertion fails, then the runner fails the case.
+	if len(mockT.Errors) != 2 || mockT.Errors[1] != "Test TestParsePort/overflow failed" {
+		t.Errorf("Expected the overflow case to fail, got %v", mockT.Errors)
+	}
+	if report := tr.GenerateReport(); report.Total != 3 || report.Failed != 1 {
+		t.Errorf("Expected the report to count 3 cases with 1 failure, got %+v", report)
+	}
+}
+
+// TestRunTableSkip checks that skipped cases are not run, are reported as
+// Skipped and skip their subtest.
+func TestRunTableSkip(t *testing.T) {
+	goexitT := &GoexitT{}
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(goexitT, logging.NewMockLogger(), true, mockReporter)
+
+	ran := 0
+	results := RunTable(tr, "TestParsePort", []Case[string]{
+		{Name: "named service", Input: "http", Skip: "service lookup not implemented"},
+		{Name: "default", Input: "80"},
+	}, func(assert *assertions.Assert, c Case[string]) teststatus.TestStatus {
+		ran++
+		return parsePortCase(assert, c)
+	})
+
+	if ran != 1 {
+		t.Errorf("Expected only the unskipped case to run, ran %d", ran)
+	}
+	if results[0] != teststatus.Skipped || results[1] != teststatus.Passed {
+		t.Errorf("Expected results [Skipped Passed], got %v", results)
+	}
+	if len(goexitT.Skipped) != 1 || goexitT.Skipped[0] != "service lookup not implemented" {
+		t.Errorf("Expected the subtest to be skipped with its reason, got %v", goexitT.Skipped)
+	}
+	if status := mockReporter.ReportedOutput[0].Status; status != "Skipped" {
+		t.Errorf("Expected the skipped case to be reported as Skipped, got %s", status)
+	}
+	if report := tr.GenerateReport(); report.Skipped != 1 || report.Failed != 0 {
+		t.Errorf("Expected the report to count 1 skipped case and no failures, got %+v", report)
+	}
+}
+
+// TestRunTableExpectFailure checks that a case expected to fail passes when
+// its assertions fail, including through FailNow, without failing the test.
+func TestRunTableExpectFailure(t *testing.T) {
+	mockT := &MockT{T: t}
+	mockLogger := logging.NewMockLogger()
+	tr := NewTestRunner(mockT, mockLogger, true, &MockReporter{})
+
+	results := RunTable(tr, "TestParsePort", []Case[string]{
+		{Name: "overflow", Input: "65536", ExpectFailure: true},
+		{Name: "fatal", Input: "x", ExpectFailure: true},
+		{Name: "panics", ExpectFailure: true},
+	}, func(assert *assertions.Assert, c Case[string]) teststatus.TestStatus {
+		switch c.Name {
+		case "fatal":
+			assert.With(assertions.UseFatal(true)).NoError(fmt.Errorf("bad port %q", c.Input))
+			t.Errorf("Expected FailNow to stop the case")
+		case "panics":
+			panic("boom")
+		}
+		return parsePortCase(assert, c)
+	})
+
+	for i, result := range results {
+		if result != teststatus.Passed {
+			t.Errorf("Expected case %d to pass by failing, got %s", i, result.GetResult())
+		}
+	}
+	if mockT.CalledErrorf {
+		t.Errorf("Expected expected failures not to fail the test, got %v", mockT.Errors)
+	}
+	if len(mockLogger.InfoMessages) != 3 {
+		t.Errorf("Expected each expected failure to be logged, got %v", mockLogger.InfoMessages)
+	}
+}
+
+// TestRunTableUnexpectedPass checks that a case expected to fail fails the
+// test when it passes.
+func TestRunTableUnexpectedPass(t *testing.T) {
+	mockT := &MockT{T: t}
+	tr := NewTestRunner(mockT, logging.NewMockLogger(), true, &MockReporter{})
+
+	results := RunTable(tr, "TestParsePort", []Case[string]{
+		{Name: "fixed", Input: "8080", ExpectFailure: true},
+	}, parsePortCase)
+
+	if results[0] != teststatus.Failed {
+		t.Errorf("Expected result Failed, got %s", results[0].GetResult())
+	}
+	want := "Test TestParsePort/fixed passed but was expected to fail"
+	if len(mockT.Errors) != 1 || mockT.Errors[0] != want {
+		t.Errorf("Expected error %q, got %v", want, mockT.Errors)
+	}
+}
+
+// ExampleRunTable shows the results of a table with a skipped case and an
+// expected failure.
+func ExampleRunTable() {
+	tr := NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, &MockReporter{})
+
+	results := RunTable(tr, "TestParsePort", []Case[string]{
+		{Name: "default", Input: "80"},
+		{Name: "named service", Input: "http", Skip: "service lookup not implemented"},
+		{Name: "overflow", Input: "65536", ExpectFailure: true},
+	}, parsePortCase)
+
+	for _, result := range results {
+		fmt.Println(result.GetResult())
+	}
+	// Output:
+	// Passed
+	// Skipped
+	// Passed
+}
@@ -0,0 +1,116 @@
+package testrunner
+
+import (
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Environment variables holding comma-separated tags to add to every
+// runner's filters, so that a CI job can select tests without changing
+// code.
+const (
+	IncludeTagsEnv = "GOWISE_INCLUDE_TAGS"
+	ExcludeTagsEnv = "GOWISE_EXCLUDE_TAGS"
+)
+
+// IncludeTags runs only tests with at least one of tags; other tests,
+// including untagged ones, are skipped. The tags are added to any in
+// IncludeTagsEnv.
+func IncludeTags(tags ...string) Option {
+	return func(tr *TestRunner) { tr.include = append(tr.include, tags...) }
+}
+
+// ExcludeTags skips tests with any of tags, even if they also have an
+// included tag. The tags are added to any in ExcludeTagsEnv.
+func ExcludeTags(tags ...string) Option {
+	return func(tr *TestRunner) { tr.exclude = append(tr.exclude, tags...) }
+}
+
+// RunTestTagged executes a test with the specified tags, such as
+// "integration" or "slow", as RunTest does, unless the runner's filters
+// exclude it. An excluded test is not run: it is reported as
+// teststatus.Skipped, with the reason as a message to
+// reporter.SkipDestination, and the subtest is skipped if the testing
+// context has a Skip method.
+// The method returns the result of the test.
+//
+// Example:
+//
+//	tr := testrunner.NewTestRunner(t, logger, true, rep, testrunner.ExcludeTags("slow"))
+//	tr.RunTestTagged("TestCheckoutFlow", []string{"integration", "slow"}, func(assert *assertions.Assert) teststatus.TestStatus {
+//		return checkCheckout(assert)
+//	})
+func (tr *TestRunner) RunTestTagged(testName string, tags []string, testFunc func(assert *assertions.Assert) teststatus.TestStatus) teststatus.TestStatus {
+	return tr.run(testName, tags, tr.timeout, testFunc)
+}
+
+// run runs a test with tags in a subtest, unless the runner's filters
+// exclude it, stopping waiting for it after timeout if timeout is positive.
+func (tr *TestRunner) run(testName string, tags []string, timeout time.Duration, testFunc func(assert *assertions.Assert) teststatus.TestStatus) teststatus.TestStatus {
+	var resultOutside teststatus.TestStatus
+
+	tr.t.Run(testName, func(t TestInterface) {
+		if reason, excluded := tr.filtered(tags); excluded {
+			resultOutside = teststatus.Skipped // Set first, as skipping may stop the subtest
+			tr.skipExcluded(t, testName, reason)
+			return
+		}
+		tr.runTest(t, testName, testFunc, timeout, &resultOutside)
+	})
+
+	return resultOutside
+}
+
+// filtered reports whether the runner's filters exclude a test with tags,
+// and why.
+func (tr *TestRunner) filtered(tags []string) (string, bool) {
+	for _, tag := range tags {
+		if slices.Contains(tr.exclude, tag) {
+			return fmt.Sprintf("tagged %q, which is excluded", tag), true
+		}
+	}
+	if len(tr.include) == 0 {
+		return "", false
+	}
+	for _, tag := range tags {
+		if slices.Contains(tr.include, tag) {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("not tagged %s", strings.Join(quoteAll(tr.include), " or ")), true
+}
+
+// skipExcluded records and skips a test the runner's filters exclude, for
+// the reason given by filtered.
+func (tr *TestRunner) skipExcluded(t TestInterface, testName, reason string) {
+	tr.start(testName)
+	now := time.Now()
+	tr.addResult(teststatus.Skipped, now, now)
+	tr.skip(t, testName, reason)
+}
+
+// tagsFromEnv returns the comma-separated tags in the environment variable
+// key.
+func tagsFromEnv(key string) []string {
+	var tags []string
+	for _, tag := range strings.Split(os.Getenv(key), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// quoteAll quotes each of s.
+func quoteAll(s []string) []string {
+	quoted := make([]string, len(s))
+	for i, v := range s {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return quoted
+}
@@ -0,0 +1,116 @@
+package testrunner
+
+import (
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/logging"
+	"gowise/pkg/reporter"
+	"testing"
+)
+
+// reportedStatuses returns the name and status of each output reported to
+// r, in order.
+func reportedStatuses(r *MockReporter) string {
+	var reported []string
+	for _, output := range r.ReportedOutput {
+		reported = append(reported, output.TestName+" "+output.Status)
+	}
+	return fmt.Sprint(reported)
+}
+
+// TestRunTestTaggedFilters checks that tests are selected by the runner's
+// include and exclude tags, with excluded tags taking precedence, and that
+// skipped tests are reported with their reason.
+func TestRunTestTaggedFilters(t *testing.T) {
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, mockReporter,
+		IncludeTags("smoke", "integration"), ExcludeTags("slow"))
+
+	var ran []string
+	test := func(name string) func(*assertions.Assert) teststatus.TestStatus {
+		return func(*assertions.Assert) teststatus.TestStatus {
+			ran = append(ran, name)
+			return teststatus.Passed
+		}
+	}
+	tr.RunTestTagged("TestLogin", []string{"smoke"}, test("TestLogin"))
+	tr.RunTestTagged("TestCheckout", []string{"integration", "slow"}, test("TestCheckout"))
+	tr.RunTest("TestParse", test("TestParse"))
+	result := tr.RunTestTagged("TestRender", []string{"unit"}, test("TestRender"))
+
+	if fmt.Sprint(ran) != "[TestLogin]" {
+		t.Errorf("Expected only TestLogin to run, got %v", ran)
+	}
+	if result != teststatus.Skipped {
+		t.Errorf("Expected a filtered test to return Skipped, got %v", result)
+	}
+	want := "[TestLogin Passed TestCheckout Skipped TestParse Skipped TestRender Skipped]"
+	if got := reportedStatuses(mockReporter); got != want {
+		t.Errorf("Expected reported outputs %s, got %s", want, got)
+	}
+
+	var reasons []string
+	for _, message := range mockReporter.ReportedMessages {
+		if message.Destination == reporter.SkipDestination {
+			reasons = append(reasons, message.Message)
+		}
+	}
+	wantReasons := `[tagged "slow", which is excluded not tagged "smoke" or "integration" not tagged "smoke" or "integration"]`
+	if fmt.Sprint(reasons) != wantReasons {
+		t.Errorf("Expected skip reasons %s, got %v", wantReasons, reasons)
+	}
+	if message := mockReporter.ReportedMessages[0]; message.TestID != mockReporter.ReportedOutput[1].TestID {
+		t.Errorf("Expected the reason to carry the skipped test's ID, got %+v", message)
+	}
+	if report := tr.GenerateReport(); report.Passed != 1 || report.Skipped != 3 {
+		t.Errorf("Expected the report to count 1 passed and 3 skipped tests, got %+v", report)
+	}
+}
+
+// TestTagFiltersFromEnvironment checks that the environment variables add
+// to the runner's filters, and that filters apply to table cases and
+// fixture tests.
+func TestTagFiltersFromEnvironment(t *testing.T) {
+	t.Setenv(ExcludeTagsEnv, " slow , flaky,")
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, mockReporter, ExcludeTags("network"))
+
+	RunTable(tr, "TestParsePort", []Case[string]{
+		{Name: "default", Input: "80"},
+		{Name: "flaky lookup", Input: "http", Tags: []string{"flaky"}},
+		{Name: "remote", Input: "8080", Tags: []string{"network"}},
+	}, parsePortCase)
+
+	setupRan := false
+	tr.RunTestTagged("TestUpload", []string{"slow"}, func(*assertions.Assert) teststatus.TestStatus {
+		t.Error("Expected a test tagged slow not to run")
+		return teststatus.Passed
+	})
+	RunTestWithFixtures(tr, "TestDatabase", func() (int, func(), error) {
+		setupRan = true
+		return 0, nil, nil
+	}, func(*assertions.Assert, int) teststatus.TestStatus {
+		return teststatus.Passed
+	})
+
+	want := "[TestParsePort/default Passed TestParsePort/flaky lookup Skipped TestParsePort/remote Skipped TestUpload Skipped TestDatabase Passed]"
+	if got := reportedStatuses(mockReporter); got != want {
+		t.Errorf("Expected reported outputs %s, got %s", want, got)
+	}
+	if !setupRan {
+		t.Error("Expected an untagged fixture test to run without include filters")
+	}
+
+	t.Setenv(IncludeTagsEnv, "smoke")
+	tr = NewTestRunner(&GoexitT{}, logging.NewMockLogger(), true, &MockReporter{})
+	setupRan = false
+	if result := RunTestWithFixtures(tr, "TestDatabase", func() (int, func(), error) {
+		setupRan = true
+		return 0, nil, nil
+	}, func(*assertions.Assert, int) teststatus.TestStatus {
+		return teststatus.Passed
+	}); result != teststatus.Skipped || setupRan {
+		t.Errorf("Expected an untagged fixture test to be skipped without setup, got %v (setup ran: %v)", result, setupRan)
+	}
+}
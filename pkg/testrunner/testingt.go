@@ -0,0 +1,40 @@
+package testrunner
+
+import "gowise/pkg/assertions"
+
+// AsTestingT adapts t to assertions.TestingT, for the package-level assertion
+// functions and helpers that need a context that reports failures itself.
+// Errorf reports to t, FailNow stops the test through t.Fatalf, and Helper
+// does nothing, as TestInterface has no helper marking. Name returns t's
+// name if t has one.
+//
+// Example:
+//
+//	tr.t.Run("checkout", func(t testrunner.TestInterface) {
+//		assertions.Equal(testrunner.AsTestingT(t), cart.Total(), 42)
+//	})
+func AsTestingT(t TestInterface) assertions.TestingT {
+	return testingT{t}
+}
+
+// testingT is the assertions.TestingT AsTestingT returns.
+type testingT struct {
+	TestInterface
+}
+
+// FailNow stops the test through Fatalf, with a note as TestInterface
+// cannot stop a test silently.
+func (t testingT) FailNow() {
+	t.Fatalf("%s", "test stopped after a failed assertion")
+}
+
+// Helper does nothing: TestInterface has no helper marking.
+func (t testingT) Helper() {}
+
+// Name returns the name of the adapted context, or "" if it has none.
+func (t testingT) Name() string {
+	if named, ok := t.TestInterface.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package testrunner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gowise/pkg/assertions"
+)
+
+// recordingT is a TestInterface with no methods beyond it, which records
+// what it is told.
+type recordingT struct {
+	errors, fatals []string
+}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...interface{}) {
+	r.fatals = append(r.fatals, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Run(name string, f func(t TestInterface)) bool {
+	f(r)
+	return len(r.errors) == 0
+}
+
+// TestAsTestingT tests that assertions report through the adapter.
+func TestAsTestingT(t *testing.T) {
+	rt := &recordingT{}
+	if assertions.Equal(AsTestingT(rt), 1, 2) {
+		t.Error("Expected Equal to fail")
+	}
+	if len(rt.errors) != 1 || !strings.Contains(rt.errors[0], "values differ") {
+		t.Errorf("Expected the failure reported through Errorf, got %v", rt.errors)
+	}
+
+	rt = &recordingT{}
+	assertions.New(AsTestingT(rt)).With(assertions.UseFatal(true)).True(false)
+	if len(rt.errors) != 1 || len(rt.fatals) != 1 {
+		t.Errorf("Expected a fatal failure to report and stop through Fatalf, got errors %v and fatals %v", rt.errors, rt.fatals)
+	}
+}
+
+// TestAsTestingTName tests that the adapter passes on the name of contexts
+// that have one.
+func TestAsTestingTName(t *testing.T) {
+	named := AsTestingT(&TWrapper{t: t}).(interface{ Name() string })
+	if got := named.Name(); got != "" {
+		t.Errorf("Expected no name from a context without one, got %q", got)
+	}
+	named = AsTestingT(&MockT{T: t}).(interface{ Name() string })
+	if got := named.Name(); got != t.Name() {
+		t.Errorf("Expected name %q, got %q", t.Name(), got)
+	}
+}
@@ -6,12 +6,16 @@ package testrunner
 
 import (
 	"fmt"
-	"gowise/pkg/assertions"            // Import assertions package
+	"gowise/pkg/assertions" // Import assertions package
+	"gowise/pkg/interfaces/testattachment"
+	"gowise/pkg/interfaces/testmessage"
 	"gowise/pkg/interfaces/testoutput" // Import test output package
 	"gowise/pkg/interfaces/teststatus" // Import test status package
 	"gowise/pkg/logging"               // Import logging package"
 	"gowise/pkg/reporter"              // Import reporter package
-	"time"                             // Import time package
+	"runtime"
+	"sync"
+	"time" // Import time package
 
 	"crypto/rand"
 	"encoding/hex"
@@ -28,29 +32,77 @@ type TestInterface interface {
 }
 
 // TestRunner represents a basic test runner component.
+// results stores the results of all executed tests, and durations the wall-clock time each took.
+type TestRunner struct {
+	settings
+	results   []teststatus.TestStatus
+	durations []time.Duration
+
+	mu                  sync.Mutex // Guards results, durations, the reporter and the schedule
+	firstStart, lastEnd time.Time  // Span of all executed tests, for the report's elapsed time
+	workers             chan struct{}
+	scheduled           []*scheduledTest
+	flushed             int
+	running             sync.WaitGroup
+}
+
+// settings holds the configuration of a TestRunner, which the runner of each
+// test scheduled with RunTestParallel copies, so that every Option applies
+// to parallel tests too.
 // t is the interface for running tests.
 // logger is used for logging test results.
 // continueOnFail determines whether the TestRunner should continue executing the remaining tests if a test fails.
-// results stores the results of all executed tests.
 // reporter is used for reporting test results.
-type TestRunner struct {
+// maxParallel bounds the number of tests scheduled with RunTestParallel that run at once.
+// include and exclude are the tags that select the tests to run.
+// timeout, if positive, bounds how long each test may run.
+// hooks holds the lifecycle hooks run around the tests.
+// assertionStats reports each test's assertion statistics to the reporter.
+type settings struct {
 	t              TestInterface
 	logger         logging.LoggerInterface
 	continueOnFail bool
-	results        []teststatus.TestStatus
 	reporter       reporter.ReporterInterface // Fix the undeclared name error by using the imported package
+	maxParallel    int
+	include        []string
+	exclude        []string
+	timeout        time.Duration
+	hooks          *hooks
+	assertionStats bool
 }
 
+// Option configures a TestRunner.
+type Option func(*TestRunner)
+
 // NewTestRunner creates a new TestRunner with the given TestInterface, LoggerInterface, a boolean indicating whether to continue on fail, and a ReporterInterface.
 // If continueOnFail is true, the TestRunner will continue executing the remaining tests even if a test fails.
 // If continueOnFail is false, the TestRunner will stop executing the remaining tests as soon as a test fails.
-func NewTestRunner(t TestInterface, logger logging.LoggerInterface, continueOnFail bool, reporter reporter.ReporterInterface) *TestRunner {
-	return &TestRunner{
+// opts configure the runner, for example with MaxParallel.
+// The runner's tag filters start with the tags in IncludeTagsEnv and ExcludeTagsEnv.
+func NewTestRunner(t TestInterface, logger logging.LoggerInterface, continueOnFail bool, reporter reporter.ReporterInterface, opts ...Option) *TestRunner {
+	tr := &TestRunner{settings: settings{
 		t:              t,
 		logger:         logger,
 		continueOnFail: continueOnFail,
 		reporter:       reporter,
+		maxParallel:    runtime.GOMAXPROCS(0),
+		include:        tagsFromEnv(IncludeTagsEnv),
+		exclude:        tagsFromEnv(ExcludeTagsEnv),
+		hooks:          &hooks{},
+	}}
+	for _, opt := range opts {
+		opt(tr)
 	}
+	return tr
+}
+
+// ReportAssertionStats makes the runner collect assertion statistics for each
+// test, with assertions.UseStats, and report their summary to the reporter
+// as a message to reporter.StatsDestination: the assertions evaluated,
+// failed and skipped, and the slowest waiting ones. A test that passes with
+// zero assertions is then easy to spot.
+func ReportAssertionStats() Option {
+	return func(tr *TestRunner) { tr.assertionStats = true }
 }
 
 // RunTest executes a test with the specified test name and test function.
@@ -59,59 +111,210 @@ func NewTestRunner(t TestInterface, logger logging.LoggerInterface, continueOnFa
 // The method logs a message indicating whether the test passed or failed.
 // If a test fails and continueOnFail is false, the method stops the test immediately.
 // If a test fails and continueOnFail is true, the method logs the failure and continues with the next test.
+// The test is untagged: it is skipped if the runner only includes tests with certain tags.
 // The method returns the result of the test.
 func (tr *TestRunner) RunTest(testName string, testFunc func(assert *assertions.Assert) teststatus.TestStatus) teststatus.TestStatus {
-	var resultOutside teststatus.TestStatus
-
-	tr.t.Run(testName, func(t TestInterface) {
-		startTime := time.Now() // Get the current time
-
-		assert := assertions.New(t)
-		resultInside := testFunc(assert) // Declare a new result variable here
-
-		endTime := time.Now()              // Get the current time
-		duration := endTime.Sub(startTime) // Calculate the duration of the test
-
-		resultString := resultInside.GetResult()
-		testID := generateTestID() // Generate a unique ID for the test
-
-		if resultInside != teststatus.Passed {
-			tr.logger.LogError(fmt.Errorf("test %s failed", testName))
-			var err error
-			if tr.continueOnFail {
-				t.Errorf("Test %s failed", testName)
-			} else {
-				t.Fatalf("Test %s failed", testName)
-			}
-			if err != nil {
-				tr.logger.LogError(fmt.Errorf("failed to log error: %v", err))
-			}
-		} else {
-			tr.logger.LogInfo(fmt.Sprintf("Test %s passed", testName))
-		}
+	return tr.RunTestTagged(testName, nil, testFunc)
+}
+
+// runTest runs testFunc in the subtest t and records its result, which it
+// stores in result before reporting it, as Fatalf may stop the subtest.
+// If timeout is positive, a test still running after timeout is abandoned
+// and recorded as Failed. The runner's BeforeEach and AfterEach hooks run
+// around the test.
+func (tr *TestRunner) runTest(t TestInterface, testName string, testFunc func(assert *assertions.Assert) teststatus.TestStatus, timeout time.Duration, result *teststatus.TestStatus) {
+	tr.start(testName)
+	startTime := time.Now() // Get the current time
+
+	if err := tr.beforeTest(testName); err != nil {
+		*result = teststatus.Errored
+		endTime := time.Now()
+		tr.addResult(teststatus.Errored, startTime, endTime)
+		tr.record(t, testName, teststatus.Errored, endTime.Sub(startTime), "", nil, err)
+		return
+	}
+	defer tr.afterTest(t, testName)
+
+	if timeout > 0 {
+		resultInside, failure, assert, err := tr.runTimed(t, testFunc, timeout)
+		endTime := time.Now()
+
+		*result = resultInside
+		tr.addResult(resultInside, startTime, endTime)
+		tr.record(t, testName, resultInside, endTime.Sub(startTime), failure, assert, err)
+		return
+	}
 
-		// Report the test output
-		output := testoutput.NewTestOutput(duration.String(), resultInside.GetResult(), testID, testName, resultString)
-		if err := tr.reporter.ReportTestOutput(output); err != nil {
-			tr.logger.LogError(fmt.Errorf("failed to report test output: %v", err))
+	assert := tr.newAssert(t)
+	resultInside := testFunc(assert) // Declare a new result variable here
+
+	endTime := time.Now()              // Get the current time
+	duration := endTime.Sub(startTime) // Calculate the duration of the test
+
+	*result = resultInside
+	tr.addResult(resultInside, startTime, endTime)
+
+	tr.record(t, testName, resultInside, duration, assert.Error(), assert, nil)
+}
+
+// record reports the output of a test, logs its result and marks t as failed
+// if the test did not pass. The output is reported first, as Fatalf may stop
+// the test.
+// failure is the message of the test's first failed assertion, if any; it is
+// reported as a message of the test if the test did not pass.
+// assert is the Assert the test ran with, or nil if it did not run.
+// cause, if non-nil, explains why the test could not run or was stopped.
+func (tr *TestRunner) record(t TestInterface, testName string, result teststatus.TestStatus, duration time.Duration, failure string, assert *assertions.Assert, cause error) {
+	testID := generateTestID() // Generate a unique ID for the test
+	tr.report(testID, testName, result.GetResult(), duration)
+	if failure != "" && result != teststatus.Passed {
+		tr.reportFailure(testID, failure)
+	}
+	tr.reportStats(testID, assert)
+
+	switch {
+	case cause != nil:
+		verb := "failed"
+		if result == teststatus.Errored {
+			verb = "errored"
 		}
+		tr.logger.LogError(fmt.Errorf("test %s %s: %v", testName, verb, cause))
+		tr.fail(t, "Test %s %s: %v", testName, verb, cause)
+	case result != teststatus.Passed:
+		tr.logger.LogError(fmt.Errorf("test %s failed", testName))
+		tr.fail(t, "Test %s failed", testName)
+	default:
+		tr.logger.LogInfo(fmt.Sprintf("Test %s passed", testName))
+	}
+}
 
-		resultOutside = resultInside // Assign the result from inside the goroutine to the outside variable
-	})
+// fail marks t as failed with Errorf, or stops it with Fatalf if
+// continueOnFail is false.
+func (tr *TestRunner) fail(t TestInterface, format string, args ...interface{}) {
+	if tr.continueOnFail {
+		t.Errorf(format, args...)
+	} else {
+		t.Fatalf(format, args...)
+	}
+}
 
-	tr.results = append(tr.results, resultOutside)
+// start tells the reporter that a test has started, if it streams such
+// events.
+func (tr *TestRunner) start(testName string) {
+	starter, ok := tr.reporter.(reporter.StartReporter)
+	if !ok {
+		return
+	}
 
-	return resultOutside
+	tr.mu.Lock()
+	err := starter.ReportTestStart(testName)
+	tr.mu.Unlock()
+
+	if err != nil {
+		tr.logger.LogError(fmt.Errorf("failed to report test start: %v", err))
+	}
+}
+
+// report passes the output of a test to the reporter.
+func (tr *TestRunner) report(testID, testName, result string, duration time.Duration) {
+	output := testoutput.NewTestOutput(duration.String(), result, testID, testName, result)
+
+	tr.mu.Lock()
+	err := tr.reporter.ReportTestOutput(output)
+	tr.mu.Unlock()
+
+	if err != nil {
+		tr.logger.LogError(fmt.Errorf("failed to report test output: %v", err))
+	}
+}
+
+// newAssert creates the Assert for a test reporting to t, which passes the
+// attachments of failed assertions to the reporter and, with
+// ReportAssertionStats, collects statistics.
+func (tr *TestRunner) newAssert(t interface{}) *assertions.Assert {
+	assert := assertions.New(t).With(assertions.UseAttachmentHandler(tr.reportAttachment))
+	if tr.assertionStats {
+		assert = assert.With(assertions.UseStats())
+	}
+	return assert
+}
+
+// reportAttachment passes an attachment to the reporter.
+func (tr *TestRunner) reportAttachment(attachment testattachment.TestAttachment) {
+	tr.mu.Lock()
+	err := tr.reporter.ReportTestAttachment(attachment)
+	tr.mu.Unlock()
+
+	if err != nil {
+		tr.logger.LogError(fmt.Errorf("failed to report test attachment: %v", err))
+	}
+}
+
+// reportFailure passes the message of a test's first failed assertion to
+// the reporter, as a message to reporter.FailureDestination.
+func (tr *TestRunner) reportFailure(testID, failure string) {
+	tr.reportMessage(testID, reporter.FailureDestination, failure)
+}
+
+// reportStats passes the summary of a test's assertion statistics to the
+// reporter, as a message to reporter.StatsDestination, if the runner was
+// created with ReportAssertionStats and the test ran.
+func (tr *TestRunner) reportStats(testID string, assert *assertions.Assert) {
+	if tr.assertionStats && assert != nil {
+		tr.reportMessage(testID, reporter.StatsDestination, assert.Stats().Summary())
+	}
+}
+
+// reportMessage passes a message about a test to the reporter.
+func (tr *TestRunner) reportMessage(testID, destination, text string) {
+	message := testmessage.NewTestMessage(destination, text, testID)
+
+	tr.mu.Lock()
+	err := tr.reporter.ReportTestMessage(message)
+	tr.mu.Unlock()
+
+	if err != nil {
+		tr.logger.LogError(fmt.Errorf("failed to report test message: %v", err))
+	}
+}
+
+// addResult records the result of a test that ran from start to end.
+func (tr *TestRunner) addResult(result teststatus.TestStatus, start, end time.Time) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.results = append(tr.results, result)
+	tr.durations = append(tr.durations, end.Sub(start))
+	tr.observe(start, end)
+}
+
+// observe widens the span of executed tests to include start and end.
+// The caller must hold tr.mu.
+func (tr *TestRunner) observe(start, end time.Time) {
+	if tr.firstStart.IsZero() || start.Before(tr.firstStart) {
+		tr.firstStart = start
+	}
+	if end.After(tr.lastEnd) {
+		tr.lastEnd = end
+	}
 }
 
 // GenerateReport generates a test report.
-// The report contains the results of all executed tests.
+// The report contains the results of all executed tests, each with its wall-clock duration, and the elapsed time from the first test starting to the last finishing.
+// The method waits for tests scheduled with RunTestParallel to finish first.
 func (tr *TestRunner) GenerateReport() *reporter.TestReport { // Fix the undeclared name error by using the imported package
+	tr.Wait()
+
 	report := reporter.NewTestReport() // Use the imported package to create a new TestReport
 
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
 	for _, result := range tr.results {
 		report.AddResult(result)
 	}
+	report.Durations = append(report.Durations, tr.durations...)
+	report.Elapsed = tr.lastEnd.Sub(tr.firstStart)
 
 	return report
 }
@@ -4,6 +4,7 @@
 package testrunner
 
 import (
+	"bytes"
 	"fmt"
 	"gowise/pkg/assertions"
 	"gowise/pkg/interfaces/testattachment"
@@ -11,7 +12,11 @@ import (
 	"gowise/pkg/interfaces/testoutput"
 	"gowise/pkg/interfaces/teststatus"
 	"gowise/pkg/logging"
+	"gowise/pkg/reporter"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TWrapper is a wrapper for *testing.T that implements the TestInterface.
@@ -74,17 +79,23 @@ func (m *MockT) Run(name string, f func(t TestInterface)) bool {
 type MockReporter struct {
 	CalledReportTestOutput bool
 	ReportedOutput         []testoutput.TestOutput
+	ReportedMessages       []testmessage.TestMessage
+	ReportedAttachments    []testattachment.TestAttachment
 	Error                  error
 }
 
 // ReportTestAttachment implements reporter.ReporterInterface.
-func (*MockReporter) ReportTestAttachment(ta testattachment.TestAttachment) error {
-	panic("unimplemented")
+// It records the attachment.
+func (m *MockReporter) ReportTestAttachment(ta testattachment.TestAttachment) error {
+	m.ReportedAttachments = append(m.ReportedAttachments, ta)
+	return nil
 }
 
 // ReportTestMessage implements reporter.ReporterInterface.
-func (*MockReporter) ReportTestMessage(tm testmessage.TestMessage) error {
-	panic("unimplemented")
+// It records the message.
+func (m *MockReporter) ReportTestMessage(tm testmessage.TestMessage) error {
+	m.ReportedMessages = append(m.ReportedMessages, tm)
+	return nil
 }
 
 // Errorf is a mock implementation of the Errorf method in the TestRunnerInterface.
@@ -316,3 +327,163 @@ func TestGenerateReportFailure(t *testing.T) {
 		}
 	}
 }
+
+// TestRunTestReportsStart checks that a reporter streaming start events is
+// told about each test before it runs.
+func TestRunTestReportsStart(t *testing.T) {
+	var buf bytes.Buffer
+	tr := NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, reporter.NewNDJSONReporter(&buf, "example.com/cart"))
+
+	tr.RunTest("TestAdd", func(assert *assertions.Assert) teststatus.TestStatus {
+		if !strings.Contains(buf.String(), `"Action":"run"`) {
+			t.Errorf("Expected a run event before the test function, got %s", buf.String())
+		}
+		return teststatus.Passed
+	})
+
+	if runs := strings.Count(buf.String(), `"Action":"run"`); runs != 1 {
+		t.Errorf("Expected exactly one run event, got %d in %s", runs, buf.String())
+	}
+	if !strings.Contains(buf.String(), `"Action":"pass"`) {
+		t.Errorf("Expected a pass event, got %s", buf.String())
+	}
+}
+
+// TestRunTestReportsFailureMessage checks that the message of a failing
+// test's first failed assertion reaches the reporter with the test's ID.
+func TestRunTestReportsFailureMessage(t *testing.T) {
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, mockReporter)
+
+	tr.RunTest("TestPasses", func(assert *assertions.Assert) teststatus.TestStatus {
+		return teststatus.Passed
+	})
+	tr.RunTest("TestFails", func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.Equal(2+2, 5)
+		return teststatus.Failed
+	})
+
+	if len(mockReporter.ReportedMessages) != 1 {
+		t.Fatalf("Expected one reported message, got %v", mockReporter.ReportedMessages)
+	}
+	message := mockReporter.ReportedMessages[0]
+	if message.Destination != reporter.FailureDestination || !strings.Contains(message.Message, "values differ") {
+		t.Errorf("Expected the assertion failure as a %q message, got %+v", reporter.FailureDestination, message)
+	}
+	if message.TestID != mockReporter.ReportedOutput[1].TestID {
+		t.Errorf("Expected the message to carry the failing test's ID %q, got %q", mockReporter.ReportedOutput[1].TestID, message.TestID)
+	}
+}
+
+// TestReportAssertionStats checks that each test's assertion statistics
+// reach the reporter with the test's ID, and only when enabled.
+func TestReportAssertionStats(t *testing.T) {
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, mockReporter, ReportAssertionStats())
+
+	tr.RunTest("TestEmpty", func(assert *assertions.Assert) teststatus.TestStatus {
+		return teststatus.Passed
+	})
+	tr.RunTestWithTimeout("TestChecks", time.Second, func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.Equal(1, 1).Equal(2, 3).True(true)
+		return teststatus.Failed
+	})
+
+	var stats []testmessage.TestMessage
+	for _, message := range mockReporter.ReportedMessages {
+		if message.Destination == reporter.StatsDestination {
+			stats = append(stats, message)
+		}
+	}
+	if len(stats) != 2 {
+		t.Fatalf("Expected a statistics message per test, got %v", mockReporter.ReportedMessages)
+	}
+	if stats[0].Message != "0 assertions: 0 passed, 0 failed, 0 skipped" || stats[0].TestID != mockReporter.ReportedOutput[0].TestID {
+		t.Errorf("Expected no assertions for TestEmpty, got %+v", stats[0])
+	}
+	if stats[1].Message != "2 assertions: 1 passed, 1 failed, 1 skipped" || stats[1].TestID != mockReporter.ReportedOutput[1].TestID {
+		t.Errorf("Expected the statistics of TestChecks, got %+v", stats[1])
+	}
+
+	mockReporter = &MockReporter{}
+	tr = NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, mockReporter)
+	tr.RunTest("TestPasses", func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.True(true)
+		return teststatus.Passed
+	})
+	if len(mockReporter.ReportedMessages) != 0 {
+		t.Errorf("Expected no statistics unless enabled, got %v", mockReporter.ReportedMessages)
+	}
+}
+
+// TestReportAssertionStatsParallel checks that tests scheduled with
+// RunTestParallel report their assertion statistics too.
+func TestReportAssertionStatsParallel(t *testing.T) {
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, mockReporter, ReportAssertionStats(), MaxParallel(2))
+
+	tr.RunTestParallel("TestFirst", func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.Equal(1, 1)
+		return teststatus.Passed
+	})
+	tr.RunTestParallel("TestSecond", func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.True(true).False(false)
+		return teststatus.Passed
+	})
+	tr.Wait()
+
+	var stats []string
+	for _, message := range mockReporter.ReportedMessages {
+		if message.Destination == reporter.StatsDestination {
+			stats = append(stats, message.Message)
+		}
+	}
+	want := []string{"1 assertions: 1 passed, 0 failed, 0 skipped", "2 assertions: 2 passed, 0 failed, 0 skipped"}
+	if !slices.Equal(stats, want) {
+		t.Errorf("Expected the statistics of each parallel test in order %q, got %q", want, stats)
+	}
+}
+
+// TestRunTestReportsAttachments checks that the attachments of a failed
+// assertion reach the reporter.
+func TestRunTestReportsAttachments(t *testing.T) {
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, mockReporter)
+
+	tr.RunTest("TestPasses", func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.WithAttachment("unused.txt", []byte("unused")).True(true)
+		return teststatus.Passed
+	})
+	tr.RunTest("TestFails", func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.WithAttachment("response.json", []byte(`{"status":"down"}`)).Equal("down", "ok")
+		return teststatus.Failed
+	})
+
+	if len(mockReporter.ReportedAttachments) != 1 {
+		t.Fatalf("Expected one reported attachment, got %v", mockReporter.ReportedAttachments)
+	}
+	if path := mockReporter.ReportedAttachments[0].FilePath; !strings.HasSuffix(path, "response.json") {
+		t.Errorf("Expected the response.json attachment, got %s", path)
+	}
+}
+
+// TestRunTestReportsAttachmentsWithoutTestingT checks that attachments reach
+// the reporter when the runner's context is a bare TestInterface, which
+// does not report assertion failures itself.
+func TestRunTestReportsAttachmentsWithoutTestingT(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(&recordingT{}, logging.NewMockLogger(), true, mockReporter)
+
+	tr.RunTest("TestFails", func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.WithAttachment("response.json", []byte(`{"status":"down"}`)).Equal("down", "ok")
+		return teststatus.Failed
+	})
+
+	if len(mockReporter.ReportedAttachments) != 1 {
+		t.Fatalf("Expected one reported attachment, got %v", mockReporter.ReportedAttachments)
+	}
+	if len(mockReporter.ReportedMessages) != 1 || !strings.Contains(mockReporter.ReportedMessages[0].Message, "attachment: ") {
+		t.Errorf("Expected the failure message to name the attachment, got %v", mockReporter.ReportedMessages)
+	}
+}
@@ -0,0 +1,146 @@
+package testrunner
+
+import (
+	"bytes"
+	"fmt"
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// WithTimeout aborts any test run by RunTest, RunTestTagged,
+// RunTestParallel or RunTestWithFixtures that is still running after d: the
+// test is recorded as Failed, with a message giving the timeout and the stack
+// of the test's goroutine, and the runner continues with the next test.
+// Values of 0 or less disable the timeout, which is the default.
+//
+// Go cannot stop a goroutine from outside, so the test function is run on a
+// goroutine of its own, which is abandoned when it times out. Its assertions
+// after that point are dropped.
+func WithTimeout(d time.Duration) Option {
+	return func(tr *TestRunner) { tr.timeout = d }
+}
+
+// RunTestWithTimeout executes a test as RunTest does, but with its own
+// timeout, d, in place of the runner's; see WithTimeout.
+// The method returns the result of the test.
+//
+// Example:
+//
+//	tr.RunTestWithTimeout("TestSync", 5*time.Second, func(assert *assertions.Assert) teststatus.TestStatus {
+//		assert.NoError(client.Sync())
+//		return teststatus.Passed
+//	})
+func (tr *TestRunner) RunTestWithTimeout(testName string, d time.Duration, testFunc func(assert *assertions.Assert) teststatus.TestStatus) teststatus.TestStatus {
+	return tr.run(testName, nil, d, testFunc)
+}
+
+// runTimed runs testFunc on its own goroutine, with an Assert reporting to
+// t, and waits for it for at most timeout. It returns the test's result, the
+// message of its first failed assertion and its Assert, or, if the test timed
+// out, Failed, a message with the test goroutine's stack, the Assert and the
+// error to report.
+// A panic in testFunc is propagated.
+func (tr *TestRunner) runTimed(t TestInterface, testFunc func(assert *assertions.Assert) teststatus.TestStatus, timeout time.Duration) (teststatus.TestStatus, string, *assertions.Assert, error) {
+	tt := &timedT{t: t}
+	assert := tr.newAssert(tt)
+
+	var result teststatus.TestStatus = teststatus.Failed // Unless testFunc returns, as FailNow stops it
+	var panicked interface{}
+	id := make(chan string, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() { panicked = recover() }()
+		id <- goroutineID()
+		result = testFunc(assert)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		if panicked != nil {
+			panic(panicked)
+		}
+		return result, assert.Error(), assert, nil
+	case <-timer.C:
+		tt.abandon()
+		err := fmt.Errorf("timed out after %s", timeout)
+		return teststatus.Failed, fmt.Sprintf("test %v\n\n%s", err, goroutineStack(<-id)), assert, err
+	}
+}
+
+// timedT is the testing context of a test run with a timeout. The test runs
+// on its own goroutine, so FailNow stops only that goroutine; once the test
+// is abandoned, failures are dropped, as t may have finished.
+type timedT struct {
+	mu        sync.Mutex
+	t         TestInterface
+	abandoned bool
+}
+
+// Errorf reports a failure to t unless the test was abandoned.
+func (tt *timedT) Errorf(format string, args ...interface{}) {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	if !tt.abandoned {
+		tt.t.Errorf(format, args...)
+	}
+}
+
+// FailNow stops the test's goroutine.
+func (tt *timedT) FailNow() {
+	runtime.Goexit()
+}
+
+// Helper marks the caller as a helper if t supports it.
+func (tt *timedT) Helper() {
+	if h, ok := tt.t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+}
+
+// abandon drops the test's later failures.
+func (tt *timedT) abandon() {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.abandoned = true
+}
+
+// goroutineID returns the ID of the calling goroutine, from the header of
+// its stack trace.
+func goroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	fields := bytes.Fields(buf)
+	if len(fields) < 2 {
+		return ""
+	}
+	return string(fields[1])
+}
+
+// goroutineStack returns the stack trace of the goroutine with ID id, or of
+// every goroutine if it is not found.
+func goroutineStack(id string) string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		if bytes.HasPrefix(stack, []byte("goroutine "+id+" ")) {
+			return string(bytes.TrimSpace(stack))
+		}
+	}
+	return string(bytes.TrimSpace(buf))
+}
@@ -0,0 +1,100 @@
+package testrunner
+
+import (
+	"gowise/pkg/assertions"
+	"gowise/pkg/interfaces/teststatus"
+	"gowise/pkg/logging"
+	"gowise/pkg/reporter"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWithTimeoutAbortsHungTest checks that a test still running after the
+// runner's timeout is recorded as Failed with the stack of its goroutine,
+// and that the runner moves on to the next test.
+func TestWithTimeoutAbortsHungTest(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	mockT := &MockT{T: t}
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(mockT, logging.NewMockLogger(), true, mockReporter, WithTimeout(20*time.Millisecond))
+
+	hung := tr.RunTest("TestHang", func(assert *assertions.Assert) teststatus.TestStatus {
+		<-release
+		assert.True(false) // Dropped: the test has been abandoned
+		return teststatus.Passed
+	})
+	next := tr.RunTest("TestNext", func(assert *assertions.Assert) teststatus.TestStatus {
+		return teststatus.Passed
+	})
+
+	if hung != teststatus.Failed || next != teststatus.Passed {
+		t.Errorf("Expected [Failed Passed], got [%v %v]", hung, next)
+	}
+	if len(mockT.Errors) != 1 || mockT.Errors[0] != "Test TestHang failed: timed out after 20ms" {
+		t.Errorf("Expected the runner to fail TestHang with its timeout, got %v", mockT.Errors)
+	}
+	if len(mockReporter.ReportedMessages) != 1 {
+		t.Fatalf("Expected one reported message, got %v", mockReporter.ReportedMessages)
+	}
+	message := mockReporter.ReportedMessages[0]
+	if message.Destination != reporter.FailureDestination ||
+		!strings.HasPrefix(message.Message, "test timed out after 20ms\n\ngoroutine ") ||
+		!strings.Contains(message.Message, "TestWithTimeoutAbortsHungTest.func") {
+		t.Errorf("Expected a failure message with the test goroutine's stack, got %+v", message)
+	}
+	if report := tr.GenerateReport(); report.Failed != 1 || report.Passed != 1 {
+		t.Errorf("Expected 1 failed and 1 passed test, got %+v", report)
+	}
+}
+
+// TestRunTestWithTimeout checks that a per-test timeout overrides the
+// runner's, and that a test finishing in time behaves as under RunTest.
+func TestRunTestWithTimeout(t *testing.T) {
+	mockT := &MockT{T: t}
+	mockReporter := &MockReporter{}
+	tr := NewTestRunner(mockT, logging.NewMockLogger(), true, mockReporter, WithTimeout(time.Millisecond))
+
+	slow := tr.RunTestWithTimeout("TestSlow", time.Minute, func(assert *assertions.Assert) teststatus.TestStatus {
+		time.Sleep(10 * time.Millisecond)
+		return teststatus.Passed
+	})
+	if slow != teststatus.Passed {
+		t.Errorf("Expected a test within its own timeout to pass, got %v", slow)
+	}
+
+	failing := tr.RunTestWithTimeout("TestFails", time.Minute, func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.Equal(2+2, 5)
+		return teststatus.Failed
+	})
+	if failing != teststatus.Failed || len(mockReporter.ReportedMessages) != 1 ||
+		!strings.Contains(mockReporter.ReportedMessages[0].Message, "values differ") {
+		t.Errorf("Expected the assertion failure to be reported, got %v and %v", failing, mockReporter.ReportedMessages)
+	}
+
+	stopped := tr.RunTestWithTimeout("TestStops", time.Minute, func(assert *assertions.Assert) teststatus.TestStatus {
+		assert.With(assertions.UseFatal(true)).True(false)
+		t.Error("Expected FailNow to stop the test function")
+		return teststatus.Passed
+	})
+	if stopped != teststatus.Failed {
+		t.Errorf("Expected a test stopped with FailNow to fail, got %v", stopped)
+	}
+}
+
+// TestRunTestWithTimeoutPanic checks that a panic in a timed test is
+// propagated.
+func TestRunTestWithTimeoutPanic(t *testing.T) {
+	tr := NewTestRunner(&MockT{T: t}, logging.NewMockLogger(), true, &MockReporter{})
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Expected the panic to propagate, got %v", r)
+		}
+	}()
+	tr.RunTestWithTimeout("TestPanics", time.Minute, func(assert *assertions.Assert) teststatus.TestStatus {
+		panic("boom")
+	})
+}
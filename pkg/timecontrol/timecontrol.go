@@ -0,0 +1,110 @@
+// Package timecontrol provides clocks for tests whose outcome depends on the
+// time: a Frozen clock that stands still until advanced, and Real, the wall
+// clock.
+//
+// Freeze stops the clock for one test. Assertions created for that test
+// with assertions.New read it: Eventually and Never wait on it, so with a
+// frozen clock they advance it by each polling interval rather than sleep,
+// and custom assertions read it through Assert.Now:
+//
+//	func TestSessionExpiry(t *testing.T) {
+//		clock := timecontrol.Freeze(t, time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC))
+//		session := auth.NewSession(clock.Now)
+//
+//		clock.Advance(31 * time.Minute)
+//		assertions.New(t).True(session.Expired())
+//	}
+//
+// A frozen clock only moves when told to, so code under test must read the
+// time from it, for instance through a func() time.Time, rather than from
+// time.Now.
+package timecontrol
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Clock is a source of the current time that can be waited on.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Sleep waits until d has passed on the clock.
+	Sleep(d time.Duration)
+}
+
+// Real is the wall clock: time.Now and time.Sleep.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+// Frozen is a clock that stands still until advanced. It is safe for
+// concurrent use.
+type Frozen struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFrozen returns a clock frozen at at, for code that takes a Clock
+// directly. Use Freeze to freeze the clock of a test's assertions.
+func NewFrozen(at time.Time) *Frozen {
+	return &Frozen{now: at}
+}
+
+// Now returns the time the clock stands at.
+func (f *Frozen) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Advance moves the clock forward by d; a negative d moves it back.
+func (f *Frozen) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Sleep advances the clock by d and returns at once, so code that waits on
+// the clock runs without waiting in real time.
+func (f *Frozen) Sleep(d time.Duration) {
+	if d > 0 {
+		f.Advance(d)
+	}
+}
+
+// frozen maps each test with a frozen clock to its *Frozen.
+var frozen sync.Map
+
+// Freeze freezes the clock of test t at at and returns it, to be advanced
+// with Advance. Until t finishes, For(t) returns the frozen clock, as do the
+// assertions of t. Freezing again replaces the clock. Subtests have clocks
+// of their own: freeze the clock in each subtest that needs it.
+func Freeze(t testing.TB, at time.Time) *Frozen {
+	t.Helper()
+	clock := NewFrozen(at)
+	frozen.Store(t, clock)
+	t.Cleanup(func() { frozen.CompareAndDelete(t, clock) })
+	return clock
+}
+
+// For returns the clock of test t: the one frozen by Freeze, or Real. t is
+// the value given to Freeze, usually a *testing.T.
+func For(t any) Clock {
+	if t != nil && reflect.TypeOf(t).Comparable() {
+		if clock, ok := frozen.Load(t); ok {
+			return clock.(*Frozen)
+		}
+	}
+	return Real
+}
+
+// Now returns the current time on the clock of test t.
+func Now(t any) time.Time {
+	return For(t).Now()
+}
@@ -0,0 +1,85 @@
+package timecontrol
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+var launch = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// TestFreeze tests that a frozen clock stands still until advanced and is
+// returned for its test only while the test runs.
+func TestFreeze(t *testing.T) {
+	var clock *Frozen
+	t.Run("frozen", func(t *testing.T) {
+		clock = Freeze(t, launch)
+		if got := Now(t); !got.Equal(launch) {
+			t.Fatalf("Now = %v, want %v", got, launch)
+		}
+
+		clock.Advance(90 * time.Second)
+		clock.Sleep(30 * time.Second)
+		clock.Sleep(-time.Hour) // Waiting for a past time returns at once
+		if got, want := Now(t), launch.Add(2*time.Minute); !got.Equal(want) {
+			t.Errorf("Now after advancing = %v, want %v", got, want)
+		}
+
+		t.Run("subtest", func(t *testing.T) {
+			if For(t) != Real {
+				t.Error("Expected a subtest to keep the real clock")
+			}
+		})
+	})
+
+	if For(t) != Real {
+		t.Error("Expected the parent test to keep the real clock")
+	}
+	if clock.Now().IsZero() {
+		t.Error("Expected the clock to remain usable after its test")
+	}
+}
+
+// TestForIgnoresUnknownValues tests For with values that cannot have frozen
+// clocks.
+func TestForIgnoresUnknownValues(t *testing.T) {
+	for _, v := range []any{nil, t, []int{1}, struct{ f func() }{}} {
+		if For(v) != Real {
+			t.Errorf("For(%T) should be the real clock", v)
+		}
+	}
+}
+
+// TestFrozenConcurrentUse tests that a frozen clock can be advanced from
+// several goroutines.
+func TestFrozenConcurrentUse(t *testing.T) {
+	clock := NewFrozen(launch)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clock.Advance(time.Second)
+			_ = clock.Now()
+		}()
+	}
+	wg.Wait()
+	if got, want := clock.Now(), launch.Add(10*time.Second); !got.Equal(want) {
+		t.Errorf("Now = %v, want %v", got, want)
+	}
+}
+
+// ExampleFrozen shows a frozen clock driving code that reads the time.
+func ExampleFrozen() {
+	clock := NewFrozen(launch)
+	expires := clock.Now().Add(30 * time.Minute)
+	expired := func() bool { return !clock.Now().Before(expires) }
+
+	fmt.Println(expired())
+	clock.Advance(30 * time.Minute)
+	fmt.Println(expired())
+	// Output:
+	// false
+	// true
+}